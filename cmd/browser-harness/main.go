@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/brentyates/squaregolf-connector/internal/app"
 	appcfg "github.com/brentyates/squaregolf-connector/internal/config"
 	"github.com/brentyates/squaregolf-connector/internal/core"
 	"github.com/brentyates/squaregolf-connector/internal/web"
@@ -22,9 +23,7 @@ func main() {
 
 	log.Printf("Browser harness starting with mock mode %q on port %d", *mockMode, *port)
 
-	stateManager := core.GetInstance()
 	settings := appcfg.GetInstance().GetSettings()
-	appcfg.GetInstance().ApplyToStateManager(stateManager)
 
 	var bleClient core.BluetoothClient
 	switch core.MockMode(*mockMode) {
@@ -40,22 +39,26 @@ func main() {
 		log.Fatalf("unsupported mock mode for browser harness: %q", *mockMode)
 	}
 
-	bluetoothManager := core.GetBluetoothInstance(stateManager)
-	bluetoothManager.SetClient(bleClient)
-	launchMonitor := core.GetLaunchMonitorInstance(stateManager, bluetoothManager)
-	launchMonitor.SetupNotifications(bluetoothManager)
+	application := app.New(app.Config{
+		Client:           bleClient,
+		GSProIP:          settings.GSProIP,
+		GSProPort:        settings.GSProPort,
+		InfiniteTeesIP:   settings.InfiniteTeesIP,
+		InfiniteTeesPort: settings.InfiniteTeesPort,
+		AwesomeGolfIP:    settings.AwesomeGolfIP,
+		AwesomeGolfPort:  settings.AwesomeGolfPort,
+		Features:         settings.Features,
+	})
+	appcfg.GetInstance().ApplyToStateManager(application.State)
+	if err := application.History.SetPath(appcfg.GetInstance().ShotHistoryPath()); err != nil {
+		log.Printf("History: failed to load shot history: %v", err)
+	}
+	if err := application.Games.SetDataDir(appcfg.GetInstance().DataDir()); err != nil {
+		log.Printf("Games: %v", err)
+	}
+	bluetoothManager := application.Bluetooth
 
-	server := web.NewServer(
-		stateManager,
-		bluetoothManager,
-		launchMonitor,
-		nil,
-		settings.GSProIP,
-		settings.GSProPort,
-		settings.InfiniteTeesIP,
-		settings.InfiniteTeesPort,
-		false,
-	)
+	server := web.NewServer(application)
 
 	bluetoothManager.StartBluetoothConnection(settings.DeviceName, "")
 
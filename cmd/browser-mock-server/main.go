@@ -104,12 +104,12 @@ func main() {
 	staticHandler := http.StripPrefix("/static/", http.FileServer(http.Dir(filepath.Join(a.webRoot, "static"))))
 	router.PathPrefix("/static/").Handler(staticHandler)
 	router.HandleFunc("/ws", a.handleWS)
-	router.HandleFunc("/api/features", a.handleFeatures).Methods("GET")
-	router.HandleFunc("/api/settings", a.handleSettings).Methods("GET", "POST")
-	router.HandleFunc("/api/device/connect", a.handleConnect).Methods("POST")
-	router.HandleFunc("/api/device/disconnect", a.handleDisconnect).Methods("POST")
-	router.HandleFunc("/api/device/practice", a.handlePractice).Methods("POST")
-	router.HandleFunc("/api/camera/config", a.handleCamera).Methods("GET", "POST")
+	router.HandleFunc("/api/v1/features", a.handleFeatures).Methods("GET")
+	router.HandleFunc("/api/v1/settings", a.handleSettings).Methods("GET", "POST")
+	router.HandleFunc("/api/v1/device/connect", a.handleConnect).Methods("POST")
+	router.HandleFunc("/api/v1/device/disconnect", a.handleDisconnect).Methods("POST")
+	router.HandleFunc("/api/v1/device/practice", a.handlePractice).Methods("POST")
+	router.HandleFunc("/api/v1/camera/config", a.handleCamera).Methods("GET", "POST")
 	router.HandleFunc("/", a.handleIndex)
 	router.PathPrefix("/").HandlerFunc(a.handleIndex)
 
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/awesomegolf"
+	"github.com/brentyates/squaregolf-connector/internal/core/camera"
+	"github.com/brentyates/squaregolf-connector/internal/core/chime"
+	"github.com/brentyates/squaregolf-connector/internal/core/export"
+	"github.com/brentyates/squaregolf-connector/internal/core/games"
+	"github.com/brentyates/squaregolf-connector/internal/core/gspro"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+	"github.com/brentyates/squaregolf-connector/internal/core/infinitetees"
+	"github.com/brentyates/squaregolf-connector/internal/core/placement"
+	"github.com/brentyates/squaregolf-connector/internal/core/voice"
+)
+
+// Config selects the Bluetooth client and simulator addresses an App is
+// built with
+type Config struct {
+	Client           core.BluetoothClient
+	GSProIP          string
+	GSProPort        int
+	InfiniteTeesIP   string
+	InfiniteTeesPort int
+	AwesomeGolfIP    string
+	AwesomeGolfPort  int
+	// Features turns optional modules on; missing ones use their default
+	Features      map[core.Feature]bool
+	Cameras       []camera.Endpoint
+	CameraEnabled bool
+	ClipDir       string // where RTSP cameras store clips
+	// ConnectServerPort is where shots from other launch monitors are
+	// accepted over the GSPro Connect API while the connectServer feature is
+	// on; GSPro's own port if zero
+	ConnectServerPort int
+	// ShotRouting picks between the device and connected launch monitors
+	ShotRouting core.ShotRouting
+	// BLEBackend is used if the Bluetooth client has to be created again
+	BLEBackend core.BLEBackend
+}
+
+// App owns the services for one launch monitor. Every service is constructed
+// here rather than looked up through a package singleton, so several Apps can
+// exist side by side.
+type App struct {
+	State         *core.StateManager
+	Bluetooth     *core.BluetoothManager
+	LaunchMonitor *core.LaunchMonitor
+	GSPro         *gspro.Integration
+	InfiniteTees  *infinitetees.Integration
+	AwesomeGolf   *awesomegolf.Integration
+	Camera        *camera.Manager // only records while the externalCamera feature is on
+	ConnectServer *gspro.ConnectServer
+	Supervisor    *core.Supervisor // restarts background tasks that panic
+	Battery       *core.BatteryTracker
+	BallHeatmap   *core.BallHeatmapRecorder
+	KnownDevices  *core.KnownDevices
+	Features      *core.FeatureFlags
+	History       *history.Store // only in memory until given a path
+	Games         *games.Manager // only in memory until given a data directory
+	Exporter      *export.Exporter
+	Voice         *voice.Announcer
+	Chime         *chime.Manager
+	Placement     *placement.Manager
+	shotArbiter   *core.ShotArbiter
+}
+
+// New builds an App and wires the launch monitor to the Bluetooth manager
+func New(cfg Config) *App {
+	state := core.NewStateManager()
+
+	bluetooth := core.NewBluetoothManager(state)
+	bluetooth.SetBackend(cfg.BLEBackend)
+	bluetooth.SetClient(cfg.Client)
+
+	supervisor := core.NewSupervisor(state)
+
+	launchMonitor := core.NewLaunchMonitor(state, bluetooth)
+	launchMonitor.SetSupervisor(supervisor)
+	launchMonitor.SetupNotifications(bluetooth)
+
+	a := &App{
+		State:         state,
+		Bluetooth:     bluetooth,
+		LaunchMonitor: launchMonitor,
+		GSPro:         gspro.New(state, launchMonitor, cfg.GSProIP, cfg.GSProPort),
+		InfiniteTees:  infinitetees.New(state, launchMonitor, cfg.InfiniteTeesIP, cfg.InfiniteTeesPort),
+		AwesomeGolf:   awesomegolf.New(state, launchMonitor, cfg.AwesomeGolfIP, cfg.AwesomeGolfPort),
+		Supervisor:    supervisor,
+		Battery:       core.NewBatteryTracker(state, core.RealClock()),
+		BallHeatmap:   core.NewBallHeatmapRecorder(state, core.RealClock()),
+		KnownDevices:  core.NewKnownDevices(core.RealClock()),
+		Features:      core.NewFeatureFlags(cfg.Features),
+		Voice:         voice.New(state),
+		Chime:         chime.New(state),
+		Placement:     placement.New(state),
+	}
+	a.History = history.New(state)
+	a.Games = games.New(a.History)
+	a.Exporter = export.New(a.History)
+	a.GSPro.Supervisor = supervisor
+	// Remember each device connected to so it can be nicknamed
+	state.RegisterConnectionStatusCallback(func(_, status core.ConnectionStatus) {
+		if status == core.ConnectionStatusConnected {
+			a.KnownDevices.Seen(bluetooth.ConnectedDevice())
+		}
+	})
+	a.InfiniteTees.Supervisor = supervisor
+	a.AwesomeGolf.Supervisor = supervisor
+
+	// The camera manager is always built so the feature can be turned on
+	// later; it does nothing until enabled
+	a.Camera = camera.New(state, cfg.Cameras, cfg.CameraEnabled && a.Features.Enabled(core.FeatureExternalCamera))
+	a.Camera.SetSupervisor(supervisor)
+	if cfg.ClipDir != "" {
+		a.Camera.SetClipDir(cfg.ClipDir)
+	}
+	// Link camera clips to the shots they recorded
+	a.Camera.OnRecording(func(recording camera.Recording) {
+		video := history.Video{Camera: recording.Camera, Filename: recording.Filename, Path: recording.Path}
+		if err := a.History.AttachVideo(recording.ShotTime, video); err != nil {
+			log.Printf("History: %v", err)
+		}
+	})
+
+	a.shotArbiter = core.NewShotArbiter(core.RealClock(), core.DefaultShotArbitrationWindow)
+	if cfg.ShotRouting != "" {
+		a.shotArbiter.SetRouting(cfg.ShotRouting)
+	}
+	port := cfg.ConnectServerPort
+	if port == 0 {
+		port = gspro.DefaultConnectServerPort
+	}
+	a.ConnectServer = gspro.NewConnectServer(launchMonitor, fmt.Sprintf(":%d", port))
+	a.Features.OnChange(func(feature core.Feature, enabled bool) {
+		if feature != core.FeatureConnectServer {
+			return
+		}
+		if enabled {
+			if err := a.StartConnectServer(); err != nil {
+				log.Printf("Failed to start GSPro Connect server: %v", err)
+			}
+		} else {
+			a.StopConnectServer()
+		}
+	})
+	return a
+}
+
+// StartConnectServer starts accepting shots from other launch monitors.
+// Their shots are arbitrated against the device's only while it runs.
+func (a *App) StartConnectServer() error {
+	a.LaunchMonitor.SetShotArbiter(a.shotArbiter)
+	if err := a.ConnectServer.Start(); err != nil {
+		a.LaunchMonitor.SetShotArbiter(nil)
+		return err
+	}
+	return nil
+}
+
+// StopConnectServer stops accepting shots from other launch monitors
+func (a *App) StopConnectServer() {
+	a.ConnectServer.Stop()
+	a.LaunchMonitor.SetShotArbiter(nil)
+}
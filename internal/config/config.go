@@ -2,37 +2,101 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"os"
 	"path/filepath"
 	"sync"
 
 	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/awesomegolf"
+	"github.com/brentyates/squaregolf-connector/internal/core/camera"
+	"github.com/brentyates/squaregolf-connector/internal/core/export"
+	"github.com/brentyates/squaregolf-connector/internal/core/gspro"
+	"github.com/brentyates/squaregolf-connector/internal/fileutil"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/brentyates/squaregolf-connector/internal/logging"
 )
 
 // Settings represents all persisted application settings
 type Settings struct {
-	DeviceName              string `json:"deviceName"`
-	SpinMode                string `json:"spinMode"`
-	OmniSpeedUnit           string `json:"omniSpeedUnit"`
-	OmniDistanceUnit        string `json:"omniDistanceUnit"`
-	OmniGreenSpeed          int    `json:"omniGreenSpeed"`
-	OmniCarryAdjustment     int    `json:"omniCarryAdjustment"`
-	GSProIP                 string `json:"gsproIP"`
-	GSProPort               int    `json:"gsproPort"`
-	GSProAutoConnect        bool   `json:"gsproAutoConnect"`
-	InfiniteTeesIP          string `json:"infiniteTeesIP"`
-	InfiniteTeesPort        int    `json:"infiniteTeesPort"`
-	InfiniteTeesAutoConnect bool   `json:"infiniteTeesAutoConnect"`
-	CameraURL               string `json:"cameraURL"`
-	CameraEnabled           bool   `json:"cameraEnabled"`
+	DeviceName              string                         `json:"deviceName"`
+	DeviceAddress           string                         `json:"deviceAddress"`
+	SpinMode                string                         `json:"spinMode"`
+	ClubSpinModes           core.ClubSpinModes             `json:"clubSpinModes"` // overrides SpinMode per club
+	OmniSpeedUnit           string                         `json:"omniSpeedUnit"`
+	OmniDistanceUnit        string                         `json:"omniDistanceUnit"`
+	OmniGreenSpeed          int                            `json:"omniGreenSpeed"`
+	OmniCarryAdjustment     int                            `json:"omniCarryAdjustment"`
+	GSProIP                 string                         `json:"gsproIP"`
+	GSProPort               int                            `json:"gsproPort"`
+	GSProAutoConnect        bool                           `json:"gsproAutoConnect"`
+	GSProStandbyIP          string                         `json:"gsproStandbyIP"` // Failover GSPro, empty when unset
+	GSProStandbyPort        int                            `json:"gsproStandbyPort"`
+	GSProTrafficLog         bool                           `json:"gsproTrafficLog"`
+	GSProPayload            gspro.PayloadIdentity          `json:"gsproPayload"`
+	GSProShotNumberPolicy   gspro.ShotNumberPolicy         `json:"gsproShotNumberPolicy"`
+	GSProRetryQueue         gspro.RetryQueueSettings       `json:"gsproRetryQueue"`
+	InfiniteTeesIP          string                         `json:"infiniteTeesIP"`
+	InfiniteTeesPort        int                            `json:"infiniteTeesPort"`
+	InfiniteTeesAutoConnect bool                           `json:"infiniteTeesAutoConnect"`
+	AwesomeGolfIP           string                         `json:"awesomeGolfIP"`
+	AwesomeGolfPort         int                            `json:"awesomeGolfPort"`
+	AwesomeGolfAutoConnect  bool                           `json:"awesomeGolfAutoConnect"`
+	CameraURL               string                         `json:"cameraURL"`
+	CameraEnabled           bool                           `json:"cameraEnabled"`
+	Cameras                 []camera.Endpoint              `json:"cameras,omitempty"` // Overrides CameraURL when set
+	BindAddress             string                         `json:"bindAddress"`
+	AllowedOrigins          []string                       `json:"allowedOrigins"`
+	AccessTokens            core.AccessTokens              `json:"accessTokens"` // none leaves the API open
+	VoiceEnabled            bool                           `json:"voiceEnabled"`
+	VoiceMetrics            []string                       `json:"voiceMetrics"`
+	ChimeEnabled            bool                           `json:"chimeEnabled"`
+	ChimeVolume             int                            `json:"chimeVolume"`
+	ChimeOutput             string                         `json:"chimeOutput"`
+	MisreadPrompt           bool                           `json:"misreadPrompt"`
+	ShotRawData             bool                           `json:"shotRawData"` // Keep raw device notifications with each shot
+	SpinEstimation          bool                           `json:"spinEstimation"`
+	SpinCurves              map[string]core.SpinCurve      `json:"spinCurves"`
+	MatCalibration          core.MatCalibration            `json:"matCalibration"`
+	AlignmentProfiles       core.AlignmentProfiles         `json:"alignmentProfiles"`
+	AlignmentSmoothing      core.AlignmentSmoothing        `json:"alignmentSmoothing"`
+	Features                map[core.Feature]bool          `json:"features,omitempty"` // optional modules turned on or off; missing ones use their default
+	PlacementZone           core.PlacementZone             `json:"placementZone"`
+	PositionBroadcastRate   int                            `json:"positionBroadcastRate"` // Hz, 0 for unlimited
+	PositionLogRate         int                            `json:"positionLogRate"`       // Hz, 0 for unlimited
+	Locale                  string                         `json:"locale"`
+	NumberFormat            i18n.NumberFormat              `json:"numberFormat"`
+	Environment             core.EnvironmentSettings       `json:"environment"`
+	Idle                    core.IdleSettings              `json:"idle"`
+	ShotCooldownMs          int                            `json:"shotCooldownMs"` // quiet period after a shot, 0 for none
+	SleepSchedule           core.SleepSchedule             `json:"sleepSchedule"`
+	Heartbeat               core.HeartbeatSettings         `json:"heartbeat"`
+	BondedDevice            core.BondedDevice              `json:"bondedDevice"`
+	DeviceType              core.DeviceType                `json:"deviceType"` // from the last advertisement seen
+	LogRotation             logging.Rotation               `json:"logRotation"`
+	ClubSpeedEstimation     bool                           `json:"clubSpeedEstimation"`
+	SmashFactors            map[string]float64             `json:"smashFactors"`
+	SpinConventions         map[string]core.SpinConvention `json:"spinConventions"`
+	Export                  export.Settings                `json:"export"`
+	Dashboard               core.DashboardSettings         `json:"dashboard"`
+}
+
+// CameraEndpoints returns the configured cameras, falling back to a single
+// front camera at CameraURL for settings saved before multi-camera support
+func (s Settings) CameraEndpoints() []camera.Endpoint {
+	if len(s.Cameras) > 0 {
+		return append([]camera.Endpoint(nil), s.Cameras...)
+	}
+	return []camera.Endpoint{{Name: camera.NameFront, URL: s.CameraURL}}
 }
 
 // Manager handles loading and saving configuration
 type Manager struct {
-	settings     Settings
-	configPath   string
-	mu           sync.RWMutex
-	saveCallback func() // Called when settings are saved
+	settings      Settings
+	configPath    string
+	mu            sync.RWMutex
+	saveCallbacks []func() // Called when settings are saved
 }
 
 var (
@@ -49,26 +113,12 @@ func GetInstance() *Manager {
 	return instance
 }
 
-// initialize sets up the config manager with default values
-func (m *Manager) initialize() {
-	// Get user's home directory
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		homeDir = "."
-	}
-
-	// Create config directory in user's home
-	configDir := filepath.Join(homeDir, ".squaregolf-connector")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		configDir = "."
-	}
-
-	m.configPath = filepath.Join(configDir, "config.json")
-
-	// Set default settings
-	m.settings = Settings{
+// defaultSettings returns the settings used before any are saved
+func defaultSettings() Settings {
+	return Settings{
 		DeviceName:              "",
 		SpinMode:                "advanced",
+		ClubSpinModes:           core.ClubSpinModes{},
 		OmniSpeedUnit:           "mps",
 		OmniDistanceUnit:        "meters",
 		OmniGreenSpeed:          10,
@@ -76,56 +126,202 @@ func (m *Manager) initialize() {
 		GSProIP:                 "127.0.0.1",
 		GSProPort:               921,
 		GSProAutoConnect:        false,
+		GSProStandbyIP:          "",
+		GSProStandbyPort:        921,
+		GSProTrafficLog:         false,
+		GSProPayload:            gspro.DefaultPayloadIdentity(),
+		GSProShotNumberPolicy:   gspro.ShotNumberKeep,
+		GSProRetryQueue:         gspro.DefaultRetryQueueSettings(),
 		InfiniteTeesIP:          "127.0.0.1",
 		InfiniteTeesPort:        999,
 		InfiniteTeesAutoConnect: false,
+		AwesomeGolfIP:           "127.0.0.1",
+		AwesomeGolfPort:         awesomegolf.DefaultPort,
+		AwesomeGolfAutoConnect:  false,
 		CameraURL:               "http://localhost:5000",
 		CameraEnabled:           false,
+		BindAddress:             "127.0.0.1",
+		AllowedOrigins:          []string{},
+		AccessTokens:            core.AccessTokens{},
+		VoiceEnabled:            false,
+		VoiceMetrics:            []string{"ballSpeed", "carry", "spin"},
+		ChimeEnabled:            false,
+		ChimeVolume:             80,
+		ChimeOutput:             "browser",
+		MisreadPrompt:           false,
+		ShotRawData:             false,
+		SpinEstimation:          false,
+		SpinCurves:              core.DefaultSpinCurves(),
+		PlacementZone:           core.DefaultPlacementZone(),
+		AlignmentSmoothing:      core.DefaultAlignmentSmoothing(),
+		PositionBroadcastRate:   core.DefaultPositionBroadcastRate,
+		PositionLogRate:         core.DefaultPositionLogRate,
+		Locale:                  i18n.DefaultLocale,
+		NumberFormat:            i18n.DefaultNumberFormat(),
+		Environment:             core.DefaultEnvironmentSettings(),
+		Idle:                    core.DefaultIdleSettings(),
+		SleepSchedule:           core.DefaultSleepSchedule(),
+		Heartbeat:               core.DefaultHeartbeatSettings(),
+		LogRotation:             logging.DefaultRotation(),
+		ClubSpeedEstimation:     true,
+		SmashFactors:            core.DefaultSmashFactors(),
+		SpinConventions:         core.DefaultSpinConventions(),
+		Export:                  export.DefaultSettings(),
+		Dashboard:               core.DefaultDashboardSettings(),
+	}
+}
+
+// initialize sets up the config manager with default values
+func (m *Manager) initialize() {
+	// Get user's home directory
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		homeDir = "."
+	}
+
+	// Create config directory in user's home. The config holds access
+	// tokens and export keys, so only the user may read it.
+	configDir := filepath.Join(homeDir, ".squaregolf-connector")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		configDir = "."
+	} else {
+		os.Chmod(configDir, 0700)
 	}
 
+	m.configPath = filepath.Join(configDir, "config.json")
+
+	m.settings = defaultSettings()
+
 	// Try to load existing settings
 	m.Load()
 }
 
-// Load reads settings from disk
+// Load reads settings from disk. If the config file is damaged the backup
+// kept by the last save is used, and settings that fail validation are
+// reset to their defaults so a bad value can't keep the connector from
+// starting.
 func (m *Manager) Load() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	data, err := os.ReadFile(m.configPath)
+	settings := m.settings
+	err := readSettings(m.configPath, &settings)
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("Warning: failed to read %s, trying the backup: %v", m.configPath, err)
+	}
 	if err != nil {
-		if os.IsNotExist(err) {
-			// File doesn't exist yet, use defaults
-			return nil
+		settings = m.settings
+		backupErr := readSettings(m.backupPath(), &settings)
+		if backupErr != nil {
+			if os.IsNotExist(err) && os.IsNotExist(backupErr) {
+				// Nothing saved yet, use defaults
+				return nil
+			}
+			return err
 		}
-		return err
+		log.Printf("Loaded settings from %s", m.backupPath())
 	}
 
-	return json.Unmarshal(data, &m.settings)
+	var invalid *ValidationError
+	if errors.As(settings.Validate(), &invalid) {
+		log.Printf("Warning: resetting invalid settings to defaults: %v", invalid)
+		resetInvalid(&settings, defaultSettings(), invalid)
+	}
+	m.settings = settings
+	return nil
+}
+
+func readSettings(path string, settings *Settings) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, settings)
 }
 
 // Save writes settings to disk
 func (m *Manager) Save() error {
-	m.mu.RLock()
-	data, err := json.MarshalIndent(m.settings, "", "  ")
-	m.mu.RUnlock()
-
+	m.mu.Lock()
+	err := m.write(m.settings)
+	m.mu.Unlock()
 	if err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(m.configPath, data, 0644); err != nil {
+	m.notifySaved()
+
+	return nil
+}
+
+// update applies change to a copy of the settings, then validates and saves
+// it. The settings are unchanged if the result is invalid or can't be
+// written.
+func (m *Manager) update(change func(*Settings)) error {
+	m.mu.Lock()
+	settings := m.settings
+	change(&settings)
+	if err := settings.Validate(); err != nil {
+		m.mu.Unlock()
 		return err
 	}
-
-	// Call save callback if set
-	if m.saveCallback != nil {
-		m.saveCallback()
+	if err := m.write(settings); err != nil {
+		m.mu.Unlock()
+		return err
 	}
+	m.settings = settings
+	m.mu.Unlock()
+
+	m.notifySaved()
 
 	return nil
 }
 
+// OnSave registers fn to be called after the settings are saved, however
+// they were changed
+func (m *Manager) OnSave(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saveCallbacks = append(m.saveCallbacks, fn)
+}
+
+func (m *Manager) notifySaved() {
+	m.mu.RLock()
+	callbacks := append([]func(){}, m.saveCallbacks...)
+	m.mu.RUnlock()
+	for _, fn := range callbacks {
+		fn()
+	}
+}
+
+// Update changes several settings at once, saving them only if all are
+// valid. A failed validation returns a *ValidationError.
+func (m *Manager) Update(change func(*Settings)) error {
+	return m.update(change)
+}
+
+// write saves settings to the config file. The caller holds mu.
+func (m *Manager) write(settings Settings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteAtomic(m.configPath, m.backupPath(), data, 0600)
+}
+
+func (m *Manager) backupPath() string {
+	return m.configPath + ".bak"
+}
+
+// DataDir returns the directory holding the config file and other app data
+func (m *Manager) DataDir() string {
+	return filepath.Dir(m.configPath)
+}
+
+// AlignmentFormatPath returns the path of the alignment calibration file
+func (m *Manager) AlignmentFormatPath() string {
+	return filepath.Join(m.DataDir(), "alignment_format.json")
+}
+
 // GetSettings returns a copy of the current settings
 func (m *Manager) GetSettings() Settings {
 	m.mu.RLock()
@@ -133,113 +329,362 @@ func (m *Manager) GetSettings() Settings {
 	return m.settings
 }
 
-// UpdateSettings updates the settings and saves to disk
+// UpdateSettings replaces the settings and saves them to disk
 func (m *Manager) UpdateSettings(settings Settings) error {
-	m.mu.Lock()
-	m.settings = settings
-	m.mu.Unlock()
-
-	return m.Save()
+	return m.update(func(s *Settings) {
+		*s = settings
+	})
 }
 
 // Update specific settings fields
 
 func (m *Manager) SetDeviceName(name string) error {
-	m.mu.Lock()
-	m.settings.DeviceName = name
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.DeviceName = name
+	})
+}
+
+func (m *Manager) SetDeviceAddress(address string) error {
+	return m.update(func(s *Settings) {
+		s.DeviceAddress = address
+	})
 }
 
 func (m *Manager) SetSpinMode(spinMode string) error {
-	m.mu.Lock()
-	m.settings.SpinMode = spinMode
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.SpinMode = spinMode
+	})
+}
+
+func (m *Manager) SetClubSpinModes(modes core.ClubSpinModes) error {
+	return m.update(func(s *Settings) {
+		s.ClubSpinModes = modes
+	})
 }
 
 func (m *Manager) SetOmniSpeedUnit(speedUnit string) error {
-	m.mu.Lock()
-	m.settings.OmniSpeedUnit = speedUnit
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.OmniSpeedUnit = speedUnit
+	})
 }
 
 func (m *Manager) SetOmniDistanceUnit(distanceUnit string) error {
-	m.mu.Lock()
-	m.settings.OmniDistanceUnit = distanceUnit
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.OmniDistanceUnit = distanceUnit
+	})
 }
 
 func (m *Manager) SetOmniGreenSpeed(greenSpeed int) error {
-	m.mu.Lock()
-	m.settings.OmniGreenSpeed = greenSpeed
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.OmniGreenSpeed = greenSpeed
+	})
 }
 
 func (m *Manager) SetOmniCarryAdjustment(adjustment int) error {
-	m.mu.Lock()
-	m.settings.OmniCarryAdjustment = adjustment
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.OmniCarryAdjustment = adjustment
+	})
 }
 
 func (m *Manager) SetGSProIP(ip string) error {
-	m.mu.Lock()
-	m.settings.GSProIP = ip
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.GSProIP = ip
+	})
 }
 
 func (m *Manager) SetGSProPort(port int) error {
-	m.mu.Lock()
-	m.settings.GSProPort = port
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.GSProPort = port
+	})
 }
 
 func (m *Manager) SetGSProAutoConnect(autoConnect bool) error {
-	m.mu.Lock()
-	m.settings.GSProAutoConnect = autoConnect
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.GSProAutoConnect = autoConnect
+	})
+}
+
+func (m *Manager) SetGSProStandbyIP(ip string) error {
+	return m.update(func(s *Settings) {
+		s.GSProStandbyIP = ip
+	})
+}
+
+func (m *Manager) SetGSProStandbyPort(port int) error {
+	return m.update(func(s *Settings) {
+		s.GSProStandbyPort = port
+	})
+}
+
+func (m *Manager) SetGSProTrafficLog(enabled bool) error {
+	return m.update(func(s *Settings) {
+		s.GSProTrafficLog = enabled
+	})
+}
+
+func (m *Manager) SetGSProPayload(identity gspro.PayloadIdentity) error {
+	return m.update(func(s *Settings) {
+		s.GSProPayload = identity
+	})
+}
+
+func (m *Manager) SetGSProShotNumberPolicy(policy gspro.ShotNumberPolicy) error {
+	return m.update(func(s *Settings) {
+		s.GSProShotNumberPolicy = policy
+	})
+}
+
+func (m *Manager) SetGSProRetryQueue(settings gspro.RetryQueueSettings) error {
+	return m.update(func(s *Settings) {
+		s.GSProRetryQueue = settings
+	})
+}
+
+// BatteryHistoryPath returns the path of the saved charge cycles and drain
+// rates
+func (m *Manager) BatteryHistoryPath() string {
+	return filepath.Join(m.DataDir(), "battery_history.json")
+}
+
+// ShotHistoryPath returns the path of the stored shots
+func (m *Manager) ShotHistoryPath() string {
+	return filepath.Join(m.DataDir(), "shots.jsonl")
+}
+
+// GSProShotNumberPath returns the path of the saved GSPro shot counter
+func (m *Manager) GSProShotNumberPath() string {
+	return filepath.Join(m.DataDir(), "gspro_shot_number.json")
+}
+
+// KnownDevicesPath returns the path of the devices seen by scans and
+// connections, with their nicknames
+func (m *Manager) KnownDevicesPath() string {
+	return filepath.Join(m.DataDir(), "known_devices.json")
 }
 
 func (m *Manager) SetInfiniteTeesIP(ip string) error {
-	m.mu.Lock()
-	m.settings.InfiniteTeesIP = ip
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.InfiniteTeesIP = ip
+	})
 }
 
 func (m *Manager) SetInfiniteTeesPort(port int) error {
-	m.mu.Lock()
-	m.settings.InfiniteTeesPort = port
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.InfiniteTeesPort = port
+	})
 }
 
 func (m *Manager) SetInfiniteTeesAutoConnect(autoConnect bool) error {
-	m.mu.Lock()
-	m.settings.InfiniteTeesAutoConnect = autoConnect
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.InfiniteTeesAutoConnect = autoConnect
+	})
 }
 
 func (m *Manager) SetCameraURL(url string) error {
-	m.mu.Lock()
-	m.settings.CameraURL = url
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.CameraURL = url
+		if len(s.Cameras) > 0 {
+			s.Cameras = append([]camera.Endpoint(nil), s.Cameras...)
+			s.Cameras[0].URL = url
+		}
+	})
+}
+
+// SetCameras stores the camera endpoints. CameraURL keeps the first camera's
+// URL for older versions.
+func (m *Manager) SetCameras(cameras []camera.Endpoint) error {
+	return m.update(func(s *Settings) {
+		s.Cameras = append([]camera.Endpoint(nil), cameras...)
+		if len(cameras) > 0 {
+			s.CameraURL = cameras[0].URL
+		}
+	})
 }
 
 func (m *Manager) SetCameraEnabled(enabled bool) error {
-	m.mu.Lock()
-	m.settings.CameraEnabled = enabled
-	m.mu.Unlock()
-	return m.Save()
+	return m.update(func(s *Settings) {
+		s.CameraEnabled = enabled
+	})
+}
+
+func (m *Manager) SetVoiceEnabled(enabled bool) error {
+	return m.update(func(s *Settings) {
+		s.VoiceEnabled = enabled
+	})
+}
+
+func (m *Manager) SetVoiceMetrics(metrics []string) error {
+	return m.update(func(s *Settings) {
+		s.VoiceMetrics = append([]string(nil), metrics...)
+	})
+}
+
+func (m *Manager) SetChimeEnabled(enabled bool) error {
+	return m.update(func(s *Settings) {
+		s.ChimeEnabled = enabled
+	})
+}
+
+func (m *Manager) SetChimeVolume(volume int) error {
+	return m.update(func(s *Settings) {
+		s.ChimeVolume = volume
+	})
+}
+
+func (m *Manager) SetChimeOutput(output string) error {
+	return m.update(func(s *Settings) {
+		s.ChimeOutput = output
+	})
+}
+
+func (m *Manager) SetMisreadPrompt(enabled bool) error {
+	return m.update(func(s *Settings) {
+		s.MisreadPrompt = enabled
+	})
+}
+
+func (m *Manager) SetShotRawData(enabled bool) error {
+	return m.update(func(s *Settings) {
+		s.ShotRawData = enabled
+	})
+}
+
+func (m *Manager) SetSpinEstimation(enabled bool) error {
+	return m.update(func(s *Settings) {
+		s.SpinEstimation = enabled
+	})
+}
+
+func (m *Manager) SetSpinCurves(curves map[string]core.SpinCurve) error {
+	return m.update(func(s *Settings) {
+		s.SpinCurves = curves
+	})
+}
+
+func (m *Manager) SetMatCalibration(calibration core.MatCalibration) error {
+	return m.update(func(s *Settings) {
+		s.MatCalibration = calibration
+	})
+}
+
+func (m *Manager) SetAlignmentProfiles(profiles core.AlignmentProfiles) error {
+	return m.update(func(s *Settings) {
+		s.AlignmentProfiles = profiles
+	})
+}
+
+// SetFeature saves whether an optional module is on
+func (m *Manager) SetFeature(feature core.Feature, enabled bool) error {
+	return m.update(func(s *Settings) {
+		features := make(map[core.Feature]bool, len(s.Features)+1)
+		for name, on := range s.Features {
+			features[name] = on
+		}
+		features[feature] = enabled
+		s.Features = features
+	})
+}
+
+func (m *Manager) SetPlacementZone(zone core.PlacementZone) error {
+	return m.update(func(s *Settings) {
+		s.PlacementZone = zone
+	})
+}
+
+func (m *Manager) SetPositionBroadcastRate(hz int) error {
+	return m.update(func(s *Settings) {
+		s.PositionBroadcastRate = hz
+	})
+}
+
+func (m *Manager) SetPositionLogRate(hz int) error {
+	return m.update(func(s *Settings) {
+		s.PositionLogRate = hz
+	})
+}
+
+func (m *Manager) SetLocale(locale string) error {
+	return m.update(func(s *Settings) {
+		s.Locale = locale
+	})
+}
+
+func (m *Manager) SetNumberFormat(format i18n.NumberFormat) error {
+	return m.update(func(s *Settings) {
+		s.NumberFormat = format
+	})
+}
+
+func (m *Manager) SetEnvironment(environment core.EnvironmentSettings) error {
+	return m.update(func(s *Settings) {
+		s.Environment = environment
+	})
+}
+
+func (m *Manager) SetAlignmentSmoothing(smoothing core.AlignmentSmoothing) error {
+	return m.update(func(s *Settings) {
+		s.AlignmentSmoothing = smoothing
+	})
+}
+
+func (m *Manager) SetIdle(idle core.IdleSettings) error {
+	return m.update(func(s *Settings) {
+		s.Idle = idle
+	})
+}
+
+func (m *Manager) SetShotCooldownMs(ms int) error {
+	return m.update(func(s *Settings) {
+		s.ShotCooldownMs = ms
+	})
+}
+
+func (m *Manager) SetSleepSchedule(schedule core.SleepSchedule) error {
+	return m.update(func(s *Settings) {
+		s.SleepSchedule = schedule
+	})
+}
+
+func (m *Manager) SetHeartbeat(heartbeat core.HeartbeatSettings) error {
+	return m.update(func(s *Settings) {
+		s.Heartbeat = heartbeat
+	})
+}
+
+func (m *Manager) SetBondedDevice(device core.BondedDevice) error {
+	return m.update(func(s *Settings) {
+		s.BondedDevice = device
+	})
+}
+
+func (m *Manager) SetDeviceType(deviceType core.DeviceType) error {
+	return m.update(func(s *Settings) {
+		s.DeviceType = deviceType
+	})
+}
+
+func (m *Manager) SetLogRotation(rotation logging.Rotation) error {
+	return m.update(func(s *Settings) {
+		s.LogRotation = rotation
+	})
+}
+
+func (m *Manager) SetClubSpeedEstimation(enabled bool) error {
+	return m.update(func(s *Settings) {
+		s.ClubSpeedEstimation = enabled
+	})
+}
+
+func (m *Manager) SetSmashFactors(smashFactors map[string]float64) error {
+	return m.update(func(s *Settings) {
+		s.SmashFactors = smashFactors
+	})
+}
+
+func (m *Manager) SetSpinConventions(conventions map[string]core.SpinConvention) error {
+	return m.update(func(s *Settings) {
+		s.SpinConventions = conventions
+	})
 }
 
 // ApplyToStateManager applies the configuration to the state manager
@@ -263,4 +708,6 @@ func (m *Manager) ApplyToStateManager(stateManager *core.StateManager) {
 	// Apply camera settings
 	stateManager.SetCameraURL(&m.settings.CameraURL)
 	stateManager.SetCameraEnabled(m.settings.CameraEnabled)
+
+	stateManager.SetMisreadPrompt(m.settings.MisreadPrompt)
 }
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brentyates/squaregolf-connector/internal/core/export"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	return &Manager{
+		configPath: filepath.Join(t.TempDir(), "config.json"),
+		settings:   defaultSettings(),
+	}
+}
+
+// writeSettings saves settings to path as a previous run would have
+func writeSettings(t *testing.T, path string, settings Settings) {
+	t.Helper()
+	data, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWrite_TightensTheModeOfAnExistingConfig(t *testing.T) {
+	m := newTestManager(t)
+	writeSettings(t, m.configPath, defaultSettings())
+	if err := os.Chmod(m.configPath, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.SetDeviceName("Bay 1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{m.configPath, m.backupPath()} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("%s permissions = %v, want 0600", filepath.Base(path), perm)
+		}
+	}
+}
+
+func TestUpdate_ExportSecretIsOnlyReadableByTheUser(t *testing.T) {
+	m := newTestManager(t)
+	err := m.Update(func(s *Settings) {
+		s.Export.Destination = export.DestinationS3
+		s.Export.SecretAccessKey = "secret"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(m.configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		t.Errorf("config holding the export secret has permissions %v, want none for other users", perm)
+	}
+}
+
+func TestLoad_NothingSavedUsesDefaults(t *testing.T) {
+	m := newTestManager(t)
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := m.GetSettings(); got.GSProPort != defaultSettings().GSProPort {
+		t.Errorf("gsproPort = %d, want the default", got.GSProPort)
+	}
+}
+
+func TestDefaultSettings_ListenOnLoopback(t *testing.T) {
+	settings := defaultSettings()
+	if settings.BindAddress != "127.0.0.1" || len(settings.AllowedOrigins) != 0 {
+		t.Errorf("bindAddress %q, allowedOrigins %v; want loopback only", settings.BindAddress, settings.AllowedOrigins)
+	}
+}
+
+func TestLoad_ReadsSavedSettings(t *testing.T) {
+	m := newTestManager(t)
+	if err := m.SetDeviceName("Bay 3"); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := &Manager{configPath: m.configPath, settings: defaultSettings()}
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := loaded.GetSettings().DeviceName; got != "Bay 3" {
+		t.Errorf("deviceName = %q, want Bay 3", got)
+	}
+}
+
+func TestLoad_CrashBetweenRenamesUsesTheBackup(t *testing.T) {
+	m := newTestManager(t)
+	saved := defaultSettings()
+	saved.DeviceName = "Bay 1"
+	// The old file was moved to the backup but the new one never replaced
+	// it, leaving only the backup and the temporary file
+	writeSettings(t, m.backupPath(), saved)
+	if err := os.WriteFile(m.configPath+".123.tmp", []byte(`{"deviceName": "half`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := m.GetSettings().DeviceName; got != "Bay 1" {
+		t.Errorf("deviceName = %q, want Bay 1 from the backup", got)
+	}
+}
+
+func TestLoad_CorruptFileFallsBackToTheBackup(t *testing.T) {
+	m := newTestManager(t)
+	saved := defaultSettings()
+	saved.DeviceName = "Bay 1"
+	writeSettings(t, m.backupPath(), saved)
+	if err := os.WriteFile(m.configPath, []byte(`{"deviceName": "Bay 2",`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := m.GetSettings().DeviceName; got != "Bay 1" {
+		t.Errorf("deviceName = %q, want Bay 1 from the backup", got)
+	}
+
+	// The next save replaces the corrupt file
+	if err := m.SetDeviceName("Bay 4"); err != nil {
+		t.Fatal(err)
+	}
+	var written Settings
+	if err := json.Unmarshal([]byte(readFile(t, m.configPath)), &written); err != nil || written.DeviceName != "Bay 4" {
+		t.Errorf("saved file = %+v, %v; want deviceName Bay 4", written.DeviceName, err)
+	}
+}
+
+func TestLoad_CorruptFileWithoutBackupKeepsDefaults(t *testing.T) {
+	m := newTestManager(t)
+	if err := os.WriteFile(m.configPath, []byte(`not json`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Load(); err == nil {
+		t.Fatal("Expected an error with no backup to fall back to")
+	}
+	if got := m.GetSettings(); got.DeviceName != "" || got.GSProPort != defaultSettings().GSProPort {
+		t.Errorf("settings = %+v, want the defaults kept", got)
+	}
+}
+
+func TestLoad_ResetsInvalidSettings(t *testing.T) {
+	m := newTestManager(t)
+	saved := defaultSettings()
+	saved.DeviceName = "Bay 1"
+	saved.GSProPort = 70000
+	writeSettings(t, m.configPath, saved)
+
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got := m.GetSettings()
+	if got.GSProPort != defaultSettings().GSProPort || got.DeviceName != "Bay 1" {
+		t.Errorf("gsproPort %d, deviceName %q; want the default port and the name kept", got.GSProPort, got.DeviceName)
+	}
+}
+
+func TestUpdate_InvalidSettingsAreNotSaved(t *testing.T) {
+	m := newTestManager(t)
+	if err := m.SetDeviceName("Bay 1"); err != nil {
+		t.Fatal(err)
+	}
+	before := readFile(t, m.configPath)
+
+	if err := m.SetGSProPort(0); err == nil {
+		t.Fatal("Expected a validation error")
+	}
+	if got := m.GetSettings().GSProPort; got != defaultSettings().GSProPort {
+		t.Errorf("gsproPort = %d, want it unchanged", got)
+	}
+	if after := readFile(t, m.configPath); after != before {
+		t.Error("Expected the file to be unchanged")
+	}
+}
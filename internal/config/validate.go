@@ -0,0 +1,262 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/chime"
+	"github.com/brentyates/squaregolf-connector/internal/core/voice"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// Validation messages, translated by the web server
+const (
+	msgInvalidValue   = "is not a supported value"
+	msgOutOfRange     = "is out of range"
+	msgInvalidHost    = "must be an IP address or host name"
+	msgInvalidIP      = "must be an IP address"
+	msgInvalidPort    = "must be a port from 1 to 65535"
+	msgInvalidURL     = "must be an http, https or rtsp URL"
+	msgInvalidOrigin  = "must be an origin such as http://192.168.1.20:8080, or *"
+	msgInvalidAddress = "must be a MAC address or device UUID"
+	msgSharedAddress  = "must be off while Awesome Golf uses GSPro's address"
+)
+
+// FieldError is a setting that failed validation, named by its JSON key
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every setting that failed validation
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		parts[i] = field.Field + " " + field.Message
+	}
+	return "invalid settings: " + strings.Join(parts, "; ")
+}
+
+// fieldNames returns the top-level settings named by the errors, without
+// any index into a list
+func (e *ValidationError) fieldNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, field := range e.Fields {
+		name, _, _ := strings.Cut(field.Field, "[")
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) check(field string, ok bool, message string) {
+	if !ok {
+		v.fields = append(v.fields, FieldError{Field: field, Message: message})
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	sort.SliceStable(v.fields, func(i, j int) bool { return v.fields[i].Field < v.fields[j].Field })
+	return &ValidationError{Fields: v.fields}
+}
+
+// Validate checks the settings that would otherwise only fail once used,
+// such as an address a simulator can't be reached at. It returns a
+// *ValidationError listing every invalid field.
+func (s Settings) Validate() error {
+	v := &validator{}
+
+	v.check("deviceAddress", core.ValidDeviceAddress(s.DeviceAddress), msgInvalidAddress)
+	v.check("spinMode", s.SpinMode == "standard" || s.SpinMode == "advanced", msgInvalidValue)
+	v.check("clubSpinModes", s.ClubSpinModes.Valid(), msgInvalidValue)
+	v.check("omniSpeedUnit", s.OmniSpeedUnit == "mps" || s.OmniSpeedUnit == "mph", msgInvalidValue)
+	v.check("omniDistanceUnit", s.OmniDistanceUnit == "meters" || s.OmniDistanceUnit == "mixed" || s.OmniDistanceUnit == "yards", msgInvalidValue)
+	v.check("omniGreenSpeed", s.OmniGreenSpeed >= 8 && s.OmniGreenSpeed <= 13, msgOutOfRange)
+	v.check("omniCarryAdjustment", s.OmniCarryAdjustment >= -99 && s.OmniCarryAdjustment <= 99, msgOutOfRange)
+
+	v.check("gsproIP", validHost(s.GSProIP), msgInvalidHost)
+	v.check("gsproPort", validPort(s.GSProPort), msgInvalidPort)
+	v.check("gsproStandbyIP", s.GSProStandbyIP == "" || validHost(s.GSProStandbyIP), msgInvalidHost)
+	// The standby port is kept while no standby is set, so 0 is allowed then
+	v.check("gsproStandbyPort", validPort(s.GSProStandbyPort) || (s.GSProStandbyIP == "" && s.GSProStandbyPort == 0), msgInvalidPort)
+	v.check("gsproPayload", s.GSProPayload.Valid(), msgInvalidValue)
+	v.check("gsproShotNumberPolicy", s.GSProShotNumberPolicy.Valid(), msgInvalidValue)
+	v.check("gsproRetryQueue", s.GSProRetryQueue.Valid(), msgOutOfRange)
+	v.check("infiniteTeesIP", validHost(s.InfiniteTeesIP), msgInvalidHost)
+	v.check("infiniteTeesPort", validPort(s.InfiniteTeesPort), msgInvalidPort)
+	v.check("awesomeGolfIP", validHost(s.AwesomeGolfIP), msgInvalidHost)
+	v.check("awesomeGolfPort", validPort(s.AwesomeGolfPort), msgInvalidPort)
+	// Both speak Open Connect on the same default port, so with both
+	// connecting on startup one would take the other's shots
+	v.check("awesomeGolfAutoConnect", !s.AwesomeGolfAutoConnect || !s.GSProAutoConnect ||
+		!sameEndpoint(s.AwesomeGolfIP, s.AwesomeGolfPort, s.GSProIP, s.GSProPort), msgSharedAddress)
+
+	v.check("cameraURL", s.CameraURL == "" || validCameraURL(s.CameraURL), msgInvalidURL)
+	for i, endpoint := range s.Cameras {
+		v.check(fmt.Sprintf("cameras[%d].url", i), validCameraURL(endpoint.URL), msgInvalidURL)
+	}
+
+	bindAddress := strings.TrimSpace(s.BindAddress)
+	v.check("bindAddress", bindAddress == "" || net.ParseIP(bindAddress) != nil, msgInvalidIP)
+	for i, origin := range s.AllowedOrigins {
+		v.check(fmt.Sprintf("allowedOrigins[%d]", i), validOrigin(origin), msgInvalidOrigin)
+	}
+	v.check("accessTokens", s.AccessTokens.Valid(), msgInvalidValue)
+
+	for _, metric := range s.VoiceMetrics {
+		if !voice.IsValidMetric(metric) {
+			v.check("voiceMetrics", false, msgInvalidValue)
+			break
+		}
+	}
+	v.check("chimeVolume", s.ChimeVolume >= 0 && s.ChimeVolume <= 100, msgOutOfRange)
+	v.check("chimeOutput", chime.IsValidOutput(s.ChimeOutput), msgInvalidValue)
+
+	for _, curve := range s.SpinCurves {
+		if !curve.Valid() {
+			v.check("spinCurves", false, msgInvalidValue)
+			break
+		}
+	}
+	v.check("placementZone", s.PlacementZone.Valid(), msgInvalidValue)
+	v.check("alignmentSmoothing", s.AlignmentSmoothing.Valid(), msgInvalidValue)
+	v.check("positionBroadcastRate", s.PositionBroadcastRate >= 0, msgOutOfRange)
+	v.check("positionLogRate", s.PositionLogRate >= 0, msgOutOfRange)
+	v.check("locale", i18n.IsSupported(s.Locale), msgInvalidValue)
+	v.check("numberFormat", s.NumberFormat.Valid(), msgInvalidValue)
+	v.check("environment", s.Environment.Valid(), msgInvalidValue)
+	v.check("idle", s.Idle.Valid(), msgInvalidValue)
+	v.check("shotCooldownMs", core.ValidShotCooldown(s.ShotCooldownMs), msgOutOfRange)
+	v.check("sleepSchedule", s.SleepSchedule.Valid(), msgInvalidValue)
+	v.check("heartbeat", s.Heartbeat.Valid(), msgInvalidValue)
+	v.check("logRotation", s.LogRotation.Valid(), msgInvalidValue)
+	v.check("export", s.Export.Valid(), msgInvalidValue)
+	v.check("dashboard", s.Dashboard.Valid(), msgInvalidValue)
+
+	for _, smash := range s.SmashFactors {
+		if !core.ValidSmashFactor(smash) {
+			v.check("smashFactors", false, msgOutOfRange)
+			break
+		}
+	}
+	for simulator := range s.SpinConventions {
+		if !core.ValidSpinConventionSimulator(simulator) {
+			v.check("spinConventions", false, msgInvalidValue)
+			break
+		}
+	}
+
+	return v.err()
+}
+
+// validHost reports whether host is an IP address or a DNS name. A name
+// made only of digits and dots is a mistyped IP address, not a host.
+func validHost(host string) bool {
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	if net.ParseIP(host) != nil {
+		return true
+	}
+	numeric := true
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+	}
+	return !numeric
+}
+
+// sameEndpoint reports whether two simulator addresses reach the same
+// server, taking every loopback address as this computer
+func sameEndpoint(hostA string, portA int, hostB string, portB int) bool {
+	if portA != portB {
+		return false
+	}
+	return strings.EqualFold(hostA, hostB) || (isLoopbackHost(hostA) && isLoopbackHost(hostB))
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func validPort(port int) bool {
+	return port >= 1 && port <= 65535
+}
+
+func validCameraURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Hostname() == "" {
+		return false
+	}
+	switch u.Scheme {
+	case "http", "https", "rtsp", "rtsps":
+		return true
+	}
+	return false
+}
+
+// validOrigin reports whether origin is "*" or a browser origin, which has
+// a scheme and host but no path
+func validOrigin(origin string) bool {
+	origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" || u.Path != "" || u.RawQuery != "" {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// resetInvalid replaces the settings named by err with their defaults
+func resetInvalid(settings *Settings, defaults Settings, err *ValidationError) {
+	names := err.fieldNames()
+	reset := make(map[string]bool, len(names))
+	for _, name := range names {
+		reset[name] = true
+	}
+
+	target := reflect.ValueOf(settings).Elem()
+	source := reflect.ValueOf(defaults)
+	t := target.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if reset[name] {
+			target.Field(i).Set(source.Field(i))
+		}
+	}
+}
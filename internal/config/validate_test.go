@@ -0,0 +1,235 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/camera"
+	"github.com/brentyates/squaregolf-connector/internal/core/export"
+)
+
+// fieldsOf returns the fields named by a validation error
+func fieldsOf(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("error = %v, want a *ValidationError", err)
+	}
+	var fields []string
+	for _, field := range invalid.Fields {
+		fields = append(fields, field.Field)
+	}
+	return fields
+}
+
+func TestValidate_DefaultsAreValid(t *testing.T) {
+	if err := defaultSettings().Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+}
+
+func TestValidate_Rules(t *testing.T) {
+	tests := []struct {
+		field  string
+		change func(*Settings)
+	}{
+		{"deviceAddress", func(s *Settings) { s.DeviceAddress = "not-an-address" }},
+		{"spinMode", func(s *Settings) { s.SpinMode = "auto" }},
+		{"clubSpinModes", func(s *Settings) { s.ClubSpinModes = core.ClubSpinModes{"Driver": "fast"} }},
+		{"omniSpeedUnit", func(s *Settings) { s.OmniSpeedUnit = "kph" }},
+		{"omniDistanceUnit", func(s *Settings) { s.OmniDistanceUnit = "feet" }},
+		{"omniGreenSpeed", func(s *Settings) { s.OmniGreenSpeed = 14 }},
+		{"omniCarryAdjustment", func(s *Settings) { s.OmniCarryAdjustment = -100 }},
+		{"gsproIP", func(s *Settings) { s.GSProIP = "192.168.1" }},
+		{"gsproPort", func(s *Settings) { s.GSProPort = 0 }},
+		{"gsproStandbyIP", func(s *Settings) { s.GSProStandbyIP = "bad host" }},
+		{"gsproStandbyPort", func(s *Settings) { s.GSProStandbyIP, s.GSProStandbyPort = "192.168.1.30", 0 }},
+		{"gsproPayload", func(s *Settings) { s.GSProPayload.Units = "Feet" }},
+		{"gsproShotNumberPolicy", func(s *Settings) { s.GSProShotNumberPolicy = "never" }},
+		{"gsproRetryQueue", func(s *Settings) { s.GSProRetryQueue.Depth = -1 }},
+		{"infiniteTeesIP", func(s *Settings) { s.InfiniteTeesIP = "" }},
+		{"infiniteTeesPort", func(s *Settings) { s.InfiniteTeesPort = 65536 }},
+		{"awesomeGolfIP", func(s *Settings) { s.AwesomeGolfIP = "-golf.local" }},
+		{"awesomeGolfPort", func(s *Settings) { s.AwesomeGolfPort = -1 }},
+		{"cameraURL", func(s *Settings) { s.CameraURL = "ftp://camera.local/stream" }},
+		{"cameras[1].url", func(s *Settings) {
+			s.Cameras = []camera.Endpoint{{Name: "front", URL: "rtsp://10.0.0.5/live"}, {Name: "side", URL: "side"}}
+		}},
+		{"bindAddress", func(s *Settings) { s.BindAddress = "localhost" }},
+		{"allowedOrigins[0]", func(s *Settings) { s.AllowedOrigins = []string{"http://bay.local/app"} }},
+		{"accessTokens", func(s *Settings) {
+			s.AccessTokens = core.AccessTokens{{Name: "bay", Token: "short", Scope: core.AccessViewer}}
+		}},
+		{"voiceMetrics", func(s *Settings) { s.VoiceMetrics = []string{"carry", "smash"} }},
+		{"chimeVolume", func(s *Settings) { s.ChimeVolume = 101 }},
+		{"chimeOutput", func(s *Settings) { s.ChimeOutput = "speaker" }},
+		{"spinCurves", func(s *Settings) { s.SpinCurves = map[string]core.SpinCurve{"Driver": {}} }},
+		{"placementZone", func(s *Settings) { s.PlacementZone = core.PlacementZone{MinX: 10, MaxX: 10, MinY: -10, MaxY: 10} }},
+		{"alignmentSmoothing", func(s *Settings) { s.AlignmentSmoothing.Readings = 0 }},
+		{"positionBroadcastRate", func(s *Settings) { s.PositionBroadcastRate = -1 }},
+		{"positionLogRate", func(s *Settings) { s.PositionLogRate = -1 }},
+		{"locale", func(s *Settings) { s.Locale = "xx" }},
+		{"numberFormat", func(s *Settings) { s.NumberFormat.DecimalSeparator = ";" }},
+		{"environment", func(s *Settings) { s.Environment.Mode = "guess" }},
+		{"idle", func(s *Settings) { s.Idle.Minutes = 0 }},
+		{"shotCooldownMs", func(s *Settings) { s.ShotCooldownMs = -1 }},
+		{"sleepSchedule", func(s *Settings) { s.SleepSchedule.Wake = s.SleepSchedule.Sleep }},
+		{"heartbeat", func(s *Settings) { s.Heartbeat.DeviceTimeoutSeconds = s.Heartbeat.IntervalSeconds }},
+		{"logRotation", func(s *Settings) { s.LogRotation.MaxSizeMB = 0 }},
+		{"export", func(s *Settings) { s.Export = export.Settings{Enabled: true, Destination: export.DestinationS3} }},
+		{"dashboard", func(s *Settings) { s.Dashboard.PollSeconds = 0 }},
+		{"smashFactors", func(s *Settings) { s.SmashFactors = map[string]float64{"Driver": 3} }},
+		{"spinConventions", func(s *Settings) { s.SpinConventions = map[string]core.SpinConvention{"trackman": {}} }},
+	}
+
+	for _, tt := range tests {
+		settings := defaultSettings()
+		tt.change(&settings)
+		fields := fieldsOf(t, settings.Validate())
+		if len(fields) != 1 || fields[0] != tt.field {
+			t.Errorf("%s: invalid fields = %v, want only %s", tt.field, fields, tt.field)
+		}
+	}
+}
+
+func TestValidate_ListsEveryInvalidFieldInOrder(t *testing.T) {
+	settings := defaultSettings()
+	settings.SpinMode = "auto"
+	settings.ChimeVolume = -1
+	settings.GSProPort = 0
+
+	err := settings.Validate()
+	fields := fieldsOf(t, err)
+	if want := []string{"chimeVolume", "gsproPort", "spinMode"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+	if want := "invalid settings: chimeVolume is out of range; gsproPort must be a port from 1 to 65535; spinMode is not a supported value"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestValidHost(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1":                        true,
+		"::1":                              true,
+		"gspro-pc":                         true,
+		"gspro.bay.local":                  true,
+		"GSPRO.local":                      true,
+		"":                                 false,
+		"192.168.1":                        false, // a mistyped IP address
+		"192.168.1.300":                    false,
+		"-gspro.local":                     false,
+		"gspro-.local":                     false,
+		"gspro..local":                     false,
+		"gspro_pc":                         false,
+		"gspro pc":                         false,
+		"http://gspro-pc":                  false,
+		"gspro-pc:921":                     false,
+		"a." + strings.Repeat("a", 250):    false,
+		strings.Repeat("a", 64) + ".local": false,
+	}
+	for host, want := range tests {
+		if got := validHost(host); got != want {
+			t.Errorf("validHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestValidOrigin(t *testing.T) {
+	tests := map[string]bool{
+		"*":                        true,
+		"http://192.168.1.20:8080": true,
+		"https://bay.example.com":  true,
+		"http://bay.local/":        true, // a trailing slash is trimmed
+		" https://bay.local ":      true,
+		"":                         false,
+		"bay.local":                false,
+		"ftp://bay.local":          false,
+		"http://bay.local/app":     false,
+		"http://bay.local?debug=1": false,
+		"http://":                  false,
+	}
+	for origin, want := range tests {
+		if got := validOrigin(origin); got != want {
+			t.Errorf("validOrigin(%q) = %v, want %v", origin, got, want)
+		}
+	}
+}
+
+func TestResetInvalid(t *testing.T) {
+	defaults := defaultSettings()
+	settings := defaults
+	settings.DeviceName = "Bay 2"
+	settings.GSProIP = "192.168.1.50"
+	settings.GSProPort = 0
+	settings.ChimeVolume = 500
+	settings.AllowedOrigins = []string{"http://bay.local", "not an origin"}
+
+	var invalid *ValidationError
+	if !errors.As(settings.Validate(), &invalid) {
+		t.Fatal("Expected a *ValidationError")
+	}
+	resetInvalid(&settings, defaults, invalid)
+
+	if settings.GSProPort != defaults.GSProPort || settings.ChimeVolume != defaults.ChimeVolume {
+		t.Errorf("gsproPort/chimeVolume = %d/%d, want the defaults %d/%d",
+			settings.GSProPort, settings.ChimeVolume, defaults.GSProPort, defaults.ChimeVolume)
+	}
+	// A bad entry resets the whole list it is in
+	if !reflect.DeepEqual(settings.AllowedOrigins, defaults.AllowedOrigins) {
+		t.Errorf("allowedOrigins = %v, want the default %v", settings.AllowedOrigins, defaults.AllowedOrigins)
+	}
+	if settings.DeviceName != "Bay 2" || settings.GSProIP != "192.168.1.50" {
+		t.Errorf("valid settings changed: deviceName %q, gsproIP %q", settings.DeviceName, settings.GSProIP)
+	}
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Validate() after reset = %v", err)
+	}
+}
+
+func TestValidate_AwesomeGolfSharingGSProsAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*Settings)
+		invalid bool
+	}{
+		{"defaults", func(s *Settings) {}, false},
+		{"only Awesome Golf connects on startup", func(s *Settings) {
+			s.AwesomeGolfAutoConnect = true
+			s.GSProAutoConnect = false
+		}, false},
+		{"both connect to the same address", func(s *Settings) {
+			s.AwesomeGolfAutoConnect, s.GSProAutoConnect = true, true
+		}, true},
+		{"loopback under different names", func(s *Settings) {
+			s.AwesomeGolfAutoConnect, s.GSProAutoConnect = true, true
+			s.AwesomeGolfIP, s.GSProIP = "localhost", "127.0.0.1"
+		}, true},
+		{"different ports", func(s *Settings) {
+			s.AwesomeGolfAutoConnect, s.GSProAutoConnect = true, true
+			s.AwesomeGolfPort = 922
+		}, false},
+		{"different computers", func(s *Settings) {
+			s.AwesomeGolfAutoConnect, s.GSProAutoConnect = true, true
+			s.AwesomeGolfIP = "192.168.1.20"
+		}, false},
+	}
+
+	for _, tt := range tests {
+		settings := defaultSettings()
+		tt.change(&settings)
+		fields := fieldsOf(t, settings.Validate())
+		if tt.invalid && (len(fields) != 1 || fields[0] != "awesomeGolfAutoConnect") {
+			t.Errorf("%s: invalid fields = %v, want awesomeGolfAutoConnect", tt.name, fields)
+		}
+		if !tt.invalid && len(fields) != 0 {
+			t.Errorf("%s: invalid fields = %v, want none", tt.name, fields)
+		}
+	}
+}
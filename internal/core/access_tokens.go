@@ -0,0 +1,103 @@
+package core
+
+import (
+	"crypto/subtle"
+	"strings"
+)
+
+// AccessScope is what a web API token may do
+type AccessScope string
+
+const (
+	// AccessViewer may read status, shots and the overlay, for display
+	// tablets and second screens
+	AccessViewer AccessScope = "viewer"
+	// AccessOperator may also connect and disconnect devices, align and
+	// change settings
+	AccessOperator AccessScope = "operator"
+)
+
+// MinAccessTokenLength keeps tokens too long to guess
+const MinAccessTokenLength = 16
+
+// RedactedAccessToken stands in for a saved token. Saving it keeps the token
+// saved under the same name.
+const RedactedAccessToken = "********"
+
+// Allows reports whether the scope covers required
+func (s AccessScope) Allows(required AccessScope) bool {
+	return s == AccessOperator || s == required
+}
+
+// AccessToken lets another machine use the web API. Name says whose it is,
+// e.g. "Lobby tablet".
+type AccessToken struct {
+	Name  string      `json:"name"`
+	Token string      `json:"token"`
+	Scope AccessScope `json:"scope"`
+}
+
+// AccessTokens are the web API tokens. With none, the API is open to anyone
+// who can reach it, as before tokens were added.
+type AccessTokens []AccessToken
+
+// Valid reports whether every token is named, long enough, unique and has a
+// known scope
+func (t AccessTokens) Valid() bool {
+	names := make(map[string]bool, len(t))
+	tokens := make(map[string]bool, len(t))
+	for _, token := range t {
+		name := strings.TrimSpace(token.Name)
+		if name == "" || names[name] || len(token.Token) < MinAccessTokenLength || tokens[token.Token] {
+			return false
+		}
+		if token.Scope != AccessViewer && token.Scope != AccessOperator {
+			return false
+		}
+		names[name] = true
+		tokens[token.Token] = true
+	}
+	return true
+}
+
+// Scope returns the scope of token, and false if it isn't one of them. Every
+// token is compared so the time taken doesn't give away a near match.
+func (t AccessTokens) Scope(token string) (AccessScope, bool) {
+	var scope AccessScope
+	found := false
+	for _, saved := range t {
+		if subtle.ConstantTimeCompare([]byte(saved.Token), []byte(token)) == 1 {
+			scope, found = saved.Scope, true
+		}
+	}
+	return scope, found
+}
+
+// Redacted returns the tokens without their secrets, for showing in the UI
+func (t AccessTokens) Redacted() AccessTokens {
+	redacted := make(AccessTokens, len(t))
+	for i, token := range t {
+		token.Token = RedactedAccessToken
+		redacted[i] = token
+	}
+	return redacted
+}
+
+// Merge returns updated with each redacted token replaced by the saved token
+// of the same name. A redacted token without a saved one is left redacted,
+// which is too short to be valid.
+func (t AccessTokens) Merge(updated AccessTokens) AccessTokens {
+	merged := make(AccessTokens, len(updated))
+	for i, token := range updated {
+		if token.Token == RedactedAccessToken {
+			for _, saved := range t {
+				if saved.Name == token.Name {
+					token.Token = saved.Token
+					break
+				}
+			}
+		}
+		merged[i] = token
+	}
+	return merged
+}
@@ -0,0 +1,86 @@
+package core
+
+import "testing"
+
+func TestAccessTokensValid(t *testing.T) {
+	tablet := AccessToken{Name: "Lobby tablet", Token: "0123456789abcdef", Scope: AccessViewer}
+	desk := AccessToken{Name: "Front desk", Token: "fedcba9876543210", Scope: AccessOperator}
+
+	tests := []struct {
+		name   string
+		tokens AccessTokens
+		want   bool
+	}{
+		{"none", AccessTokens{}, true},
+		{"viewer and operator", AccessTokens{tablet, desk}, true},
+		{"no name", AccessTokens{{Name: " ", Token: tablet.Token, Scope: AccessViewer}}, false},
+		{"too short", AccessTokens{{Name: "Short", Token: "abc", Scope: AccessViewer}}, false},
+		{"unknown scope", AccessTokens{{Name: "Admin", Token: tablet.Token, Scope: "admin"}}, false},
+		{"duplicate name", AccessTokens{tablet, {Name: tablet.Name, Token: desk.Token, Scope: AccessOperator}}, false},
+		{"duplicate token", AccessTokens{tablet, {Name: "Other", Token: tablet.Token, Scope: AccessOperator}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.tokens.Valid(); got != tt.want {
+			t.Errorf("%s: Valid() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAccessTokensScope(t *testing.T) {
+	tokens := AccessTokens{
+		{Name: "Lobby tablet", Token: "0123456789abcdef", Scope: AccessViewer},
+		{Name: "Front desk", Token: "fedcba9876543210", Scope: AccessOperator},
+	}
+
+	if scope, ok := tokens.Scope("0123456789abcdef"); !ok || scope != AccessViewer {
+		t.Errorf("Scope(viewer token) = %q, %v", scope, ok)
+	}
+	if scope, ok := tokens.Scope("fedcba9876543210"); !ok || scope != AccessOperator {
+		t.Errorf("Scope(operator token) = %q, %v", scope, ok)
+	}
+	for _, token := range []string{"", "0123456789abcde", RedactedAccessToken} {
+		if _, ok := tokens.Scope(token); ok {
+			t.Errorf("Scope(%q) should not match a token", token)
+		}
+	}
+
+	if !AccessOperator.Allows(AccessViewer) || !AccessOperator.Allows(AccessOperator) {
+		t.Error("an operator token should allow everything")
+	}
+	if !AccessViewer.Allows(AccessViewer) || AccessViewer.Allows(AccessOperator) {
+		t.Error("a viewer token should only allow viewing")
+	}
+}
+
+func TestAccessTokensRedactedAndMerge(t *testing.T) {
+	saved := AccessTokens{
+		{Name: "Lobby tablet", Token: "0123456789abcdef", Scope: AccessViewer},
+		{Name: "Front desk", Token: "fedcba9876543210", Scope: AccessOperator},
+	}
+
+	redacted := saved.Redacted()
+	for _, token := range redacted {
+		if token.Token != RedactedAccessToken {
+			t.Errorf("%s: token %q was not redacted", token.Name, token.Token)
+		}
+	}
+	if saved[0].Token != "0123456789abcdef" {
+		t.Error("Redacted() should not change the saved tokens")
+	}
+
+	updated := AccessTokens{
+		{Name: "Lobby tablet", Token: RedactedAccessToken, Scope: AccessOperator},
+		{Name: "Bar screen", Token: "abcdefabcdef0123", Scope: AccessViewer},
+		{Name: "New", Token: RedactedAccessToken, Scope: AccessViewer},
+	}
+	merged := saved.Merge(updated)
+	if merged[0].Token != "0123456789abcdef" || merged[0].Scope != AccessOperator {
+		t.Errorf("a redacted token should keep the saved one and take the new scope, got %+v", merged[0])
+	}
+	if merged[1].Token != "abcdefabcdef0123" {
+		t.Errorf("a new token should be kept, got %+v", merged[1])
+	}
+	if merged.Valid() {
+		t.Error("a redacted token without a saved one should leave the tokens invalid")
+	}
+}
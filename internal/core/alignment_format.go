@@ -0,0 +1,291 @@
+package core
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+)
+
+// maxAlignmentFitErrorDeg is the largest RMS error accepted for a fitted format
+const maxAlignmentFitErrorDeg = 1.0
+
+// AlignmentFormat describes where the aim angle lives in an 11 04 alignment
+// notification and how to scale it to degrees
+type AlignmentFormat struct {
+	Offset     int     `json:"offset"`
+	BigEndian  bool    `json:"bigEndian"`
+	Unsigned   bool    `json:"unsigned"`
+	Scale      float64 `json:"scale"`
+	ZeroOffset float64 `json:"zeroOffset"`
+}
+
+// DefaultAlignmentFormat returns the built-in format: a signed little-endian
+// int16 at bytes 5-6 in hundredths of a degree
+func DefaultAlignmentFormat() AlignmentFormat {
+	return AlignmentFormat{Offset: 5, Scale: 100}
+}
+
+// LoadAlignmentFormat reads a calibration file written by SaveAlignmentFormat.
+// A missing file gives the default format.
+func LoadAlignmentFormat(path string) (AlignmentFormat, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultAlignmentFormat(), nil
+		}
+		return DefaultAlignmentFormat(), err
+	}
+
+	var format AlignmentFormat
+	if err := json.Unmarshal(data, &format); err != nil {
+		return DefaultAlignmentFormat(), fmt.Errorf("invalid alignment calibration file: %w", err)
+	}
+	if format.Offset < 2 || format.Scale == 0 {
+		return DefaultAlignmentFormat(), errors.New("invalid alignment calibration file: missing offset or scale")
+	}
+	return format, nil
+}
+
+// SaveAlignmentFormat writes a format to a calibration file
+func SaveAlignmentFormat(path string, format AlignmentFormat) error {
+	data, err := json.MarshalIndent(format, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// rawValue decodes the 16-bit field at the format's offset
+func (f AlignmentFormat) rawValue(bytesList []string) (float64, error) {
+	if f.Offset < 0 || len(bytesList) < f.Offset+2 {
+		return 0, fmt.Errorf("insufficient data for parsing alignment data (need at least %d bytes, got %d)", f.Offset+2, len(bytesList))
+	}
+
+	fieldBytes, err := hex.DecodeString(bytesList[f.Offset] + bytesList[f.Offset+1])
+	if err != nil || len(fieldBytes) != 2 {
+		return 0, fmt.Errorf("invalid alignment bytes: %v", err)
+	}
+
+	var value uint16
+	if f.BigEndian {
+		value = binary.BigEndian.Uint16(fieldBytes)
+	} else {
+		value = binary.LittleEndian.Uint16(fieldBytes)
+	}
+	if f.Unsigned {
+		return float64(value), nil
+	}
+	return float64(int16(value)), nil
+}
+
+// Decode returns the aim angle in degrees
+func (f AlignmentFormat) Decode(bytesList []string) (float64, error) {
+	raw, err := f.rawValue(bytesList)
+	if err != nil {
+		return 0, err
+	}
+	return raw/f.Scale + f.ZeroOffset, nil
+}
+
+// AlignmentCaptureSample holds the notifications recorded while the device
+// was held at a known angle
+type AlignmentCaptureSample struct {
+	Angle   float64    `json:"angle"`
+	Packets [][]string `json:"packets"`
+}
+
+// FitAlignmentFormat tries every 16-bit field layout in the captured packets
+// and returns the one whose linear fit best matches the prompted angles,
+// along with its RMS error in degrees.
+func FitAlignmentFormat(samples []AlignmentCaptureSample) (AlignmentFormat, float64, error) {
+	minLen := math.MaxInt
+	angles := make(map[float64]bool)
+	for _, sample := range samples {
+		if len(sample.Packets) > 0 {
+			angles[sample.Angle] = true
+		}
+		for _, packet := range sample.Packets {
+			minLen = min(minLen, len(packet))
+		}
+	}
+	if len(angles) < 2 {
+		return AlignmentFormat{}, 0, errors.New("need packets captured at two or more angles")
+	}
+
+	best := AlignmentFormat{}
+	bestErr := math.Inf(1)
+
+	// Skip the 11 04 header
+	for offset := 2; offset+2 <= minLen; offset++ {
+		for _, bigEndian := range []bool{false, true} {
+			for _, unsigned := range []bool{false, true} {
+				candidate := AlignmentFormat{Offset: offset, BigEndian: bigEndian, Unsigned: unsigned}
+				format, rms, ok := fitAlignmentCandidate(candidate, samples)
+				if ok && rms < bestErr-1e-9 {
+					best, bestErr = format, rms
+				}
+			}
+		}
+	}
+
+	if math.IsInf(bestErr, 1) {
+		return AlignmentFormat{}, 0, errors.New("no field in the captured packets tracks the angle")
+	}
+	if bestErr > maxAlignmentFitErrorDeg {
+		return best, bestErr, fmt.Errorf("best fit error %.2f degrees is too large", bestErr)
+	}
+	return best, bestErr, nil
+}
+
+// fitAlignmentCandidate performs a least squares fit of angle = a*raw + b for
+// one field layout
+func fitAlignmentCandidate(format AlignmentFormat, samples []AlignmentCaptureSample) (AlignmentFormat, float64, bool) {
+	var xs, ys []float64
+	for _, sample := range samples {
+		for _, packet := range sample.Packets {
+			raw, err := format.rawValue(packet)
+			if err != nil {
+				return format, 0, false
+			}
+			xs = append(xs, raw)
+			ys = append(ys, sample.Angle)
+		}
+	}
+
+	n := float64(len(xs))
+	var sumX, sumY, sumXX, sumXY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXX += xs[i] * xs[i]
+		sumXY += xs[i] * ys[i]
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return format, 0, false
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	if slope == 0 {
+		return format, 0, false
+	}
+	intercept := (sumY - slope*sumX) / n
+
+	var sumSq float64
+	for i := range xs {
+		residual := slope*xs[i] + intercept - ys[i]
+		sumSq += residual * residual
+	}
+
+	format.Scale = 1 / slope
+	format.ZeroOffset = intercept
+	return format, math.Sqrt(sumSq / n), true
+}
+
+// AlignmentCapture walks the user through a list of prompted angles and
+// records the alignment notifications received at each one
+type AlignmentCapture struct {
+	mu      sync.Mutex
+	samples []AlignmentCaptureSample
+	current int
+}
+
+// AlignmentCaptureStatus reports the progress of a capture
+type AlignmentCaptureStatus struct {
+	Active      bool     `json:"active"`
+	PromptAngle *float64 `json:"promptAngle"`
+	Step        int      `json:"step"`
+	Steps       int      `json:"steps"`
+	Packets     int      `json:"packets"`
+}
+
+// DefaultAlignmentCaptureAngles are the angles prompted when none are given
+var DefaultAlignmentCaptureAngles = []float64{-10, -5, 0, 5, 10}
+
+// NewAlignmentCapture creates a capture prompting for each angle in turn
+func NewAlignmentCapture(angles []float64) *AlignmentCapture {
+	samples := make([]AlignmentCaptureSample, len(angles))
+	for i, angle := range angles {
+		samples[i].Angle = angle
+	}
+	return &AlignmentCapture{samples: samples}
+}
+
+// Record stores a notification for the current prompt
+func (c *AlignmentCapture) Record(bytesList []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current >= len(c.samples) {
+		return
+	}
+	packet := append([]string(nil), bytesList...)
+	c.samples[c.current].Packets = append(c.samples[c.current].Packets, packet)
+}
+
+// Next advances to the next prompted angle. It returns false when the
+// capture has no more prompts.
+func (c *AlignmentCapture) Next() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current < len(c.samples) {
+		c.current++
+	}
+	return c.current < len(c.samples)
+}
+
+// Samples returns a copy of the recorded samples
+func (c *AlignmentCapture) Samples() []AlignmentCaptureSample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	samples := make([]AlignmentCaptureSample, len(c.samples))
+	copy(samples, c.samples)
+	return samples
+}
+
+// Status returns the capture progress
+func (c *AlignmentCapture) Status() AlignmentCaptureStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := AlignmentCaptureStatus{Active: true, Step: c.current + 1, Steps: len(c.samples)}
+	if c.current < len(c.samples) {
+		angle := c.samples[c.current].Angle
+		status.PromptAngle = &angle
+		status.Packets = len(c.samples[c.current].Packets)
+	}
+	return status
+}
+
+// StartAlignmentCapture begins recording alignment notifications for the
+// given prompted angles, replacing any capture in progress
+func (lm *LaunchMonitor) StartAlignmentCapture(angles []float64) *AlignmentCapture {
+	if len(angles) == 0 {
+		angles = DefaultAlignmentCaptureAngles
+	}
+	capture := NewAlignmentCapture(angles)
+
+	lm.alignmentCaptureMu.Lock()
+	lm.alignmentCapture = capture
+	lm.alignmentCaptureMu.Unlock()
+	return capture
+}
+
+// AlignmentCapture returns the capture in progress, or nil
+func (lm *LaunchMonitor) AlignmentCapture() *AlignmentCapture {
+	lm.alignmentCaptureMu.Lock()
+	defer lm.alignmentCaptureMu.Unlock()
+	return lm.alignmentCapture
+}
+
+// StopAlignmentCapture ends the capture in progress and returns it
+func (lm *LaunchMonitor) StopAlignmentCapture() *AlignmentCapture {
+	lm.alignmentCaptureMu.Lock()
+	defer lm.alignmentCaptureMu.Unlock()
+	capture := lm.alignmentCapture
+	lm.alignmentCapture = nil
+	return capture
+}
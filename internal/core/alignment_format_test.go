@@ -0,0 +1,120 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// alignmentPacket builds an 11 04 notification with a big-endian angle in
+// tenths of a degree at bytes 7-8 and a changing sequence byte
+func alignmentPacket(seq int, angle float64) []string {
+	raw := uint16(int16(math.Round(angle * 10)))
+	return []string{"11", "04", fmt.Sprintf("%02x", seq%256), "01", "00", "00", "00",
+		fmt.Sprintf("%02x", raw>>8), fmt.Sprintf("%02x", raw&0xff), "00"}
+}
+
+func TestParseAlignmentData_DefaultFormat(t *testing.T) {
+	data, err := ParseAlignmentData([]string{"11", "04", "00", "01", "00", "f4", "01"}, DefaultAlignmentFormat())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data.AimAngle != 5 || data.IsAligned {
+		t.Errorf("Expected 5 degrees and not aligned, got %v aligned=%v", data.AimAngle, data.IsAligned)
+	}
+}
+
+func TestFitAlignmentFormat(t *testing.T) {
+	var samples []AlignmentCaptureSample
+	seq := 0
+	for _, angle := range []float64{-10, -5, 0, 5, 10} {
+		sample := AlignmentCaptureSample{Angle: angle}
+		for i := 0; i < 3; i++ {
+			sample.Packets = append(sample.Packets, alignmentPacket(seq, angle))
+			seq++
+		}
+		samples = append(samples, sample)
+	}
+
+	format, rms, err := FitAlignmentFormat(samples)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if format.Offset != 7 || !format.BigEndian || format.Unsigned {
+		t.Errorf("Expected signed big-endian field at offset 7, got %+v", format)
+	}
+	if math.Abs(format.Scale-10) > 1e-6 || math.Abs(format.ZeroOffset) > 1e-6 || rms > 1e-6 {
+		t.Errorf("Expected scale 10 with no offset, got %+v (rms %v)", format, rms)
+	}
+
+	angle, err := format.Decode(alignmentPacket(0, -7.5))
+	if err != nil || math.Abs(angle+7.5) > 1e-6 {
+		t.Errorf("Expected -7.5 degrees, got %v (%v)", angle, err)
+	}
+}
+
+func TestFitAlignmentFormat_NeedsTwoAngles(t *testing.T) {
+	samples := []AlignmentCaptureSample{{Angle: 0, Packets: [][]string{alignmentPacket(0, 0)}}}
+	if _, _, err := FitAlignmentFormat(samples); err == nil {
+		t.Error("Expected error with a single angle")
+	}
+}
+
+func TestAlignmentFormatSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alignment_format.json")
+	loaded, err := LoadAlignmentFormat(path)
+	if err != nil || loaded != DefaultAlignmentFormat() {
+		t.Fatalf("Expected missing file to give the default, got %+v (%v)", loaded, err)
+	}
+
+	format := AlignmentFormat{Offset: 7, BigEndian: true, Scale: 10}
+	if err := SaveAlignmentFormat(path, format); err != nil {
+		t.Fatalf("Unexpected error saving: %v", err)
+	}
+	loaded, err = LoadAlignmentFormat(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading: %v", err)
+	}
+	if loaded != format {
+		t.Errorf("Expected loaded format %+v, got %+v", format, loaded)
+	}
+}
+
+func TestAlignmentNotificationUsesTheStateFormat(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+	if sm.GetAlignmentFormat() != DefaultAlignmentFormat() {
+		t.Errorf("Expected the default format, got %+v", sm.GetAlignmentFormat())
+	}
+
+	sm.SetAlignmentFormat(AlignmentFormat{Offset: 7, BigEndian: true, Scale: 10})
+	lm.HandleAlignmentNotification(alignmentPacket(0, 3))
+	if angle := sm.GetAlignmentAngle(); angle != 3 {
+		t.Errorf("Expected the angle read with the set format, got %v", angle)
+	}
+}
+
+func TestAlignmentCaptureRecordsNotifications(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	capture := lm.StartAlignmentCapture([]float64{-5, 5})
+	lm.HandleAlignmentNotification(alignmentPacket(0, -5))
+	if !capture.Next() {
+		t.Fatal("Expected a second prompt")
+	}
+	lm.HandleAlignmentNotification(alignmentPacket(1, 5))
+	lm.HandleAlignmentNotification(alignmentPacket(2, 5))
+	if capture.Next() {
+		t.Error("Expected no more prompts")
+	}
+
+	if lm.StopAlignmentCapture() != capture || lm.AlignmentCapture() != nil {
+		t.Error("Expected capture to be stopped")
+	}
+
+	samples := capture.Samples()
+	if len(samples[0].Packets) != 1 || len(samples[1].Packets) != 2 {
+		t.Errorf("Expected 1 and 2 packets, got %d and %d", len(samples[0].Packets), len(samples[1].Packets))
+	}
+}
@@ -0,0 +1,82 @@
+package core
+
+import "log"
+
+// AlignmentProfiles holds the aim saved with the OK button for each
+// handedness, so a group of left and right handed players doesn't have to
+// re-align each time the player changes. A nil angle hasn't been saved.
+type AlignmentProfiles struct {
+	Right *float64 `json:"right,omitempty"`
+	Left  *float64 `json:"left,omitempty"`
+}
+
+// Angle returns the angle saved for a handedness
+func (p AlignmentProfiles) Angle(handedness HandednessType) (float64, bool) {
+	angle := p.Right
+	if handedness == LeftHanded {
+		angle = p.Left
+	}
+	if angle == nil {
+		return 0, false
+	}
+	return *angle, true
+}
+
+// With returns the profiles with the angle for a handedness replaced
+func (p AlignmentProfiles) With(handedness HandednessType, angle float64) AlignmentProfiles {
+	if handedness == LeftHanded {
+		p.Left = &angle
+	} else {
+		p.Right = &angle
+	}
+	return p
+}
+
+// SetAlignmentProfiles sets the saved aim for each handedness
+func (lm *LaunchMonitor) SetAlignmentProfiles(profiles AlignmentProfiles) {
+	lm.alignmentProfilesMu.Lock()
+	defer lm.alignmentProfilesMu.Unlock()
+	lm.alignmentProfiles = profiles
+}
+
+// GetAlignmentProfiles returns the saved aim for each handedness
+func (lm *LaunchMonitor) GetAlignmentProfiles() AlignmentProfiles {
+	lm.alignmentProfilesMu.Lock()
+	defer lm.alignmentProfilesMu.Unlock()
+	return lm.alignmentProfiles
+}
+
+// saveAlignmentProfile records the aim just confirmed for the current
+// handedness
+func (lm *LaunchMonitor) saveAlignmentProfile(angle float64) {
+	handedness := RightHanded
+	if h := lm.stateManager.GetHandedness(); h != nil {
+		handedness = *h
+	}
+	lm.alignmentProfilesMu.Lock()
+	defer lm.alignmentProfilesMu.Unlock()
+	lm.alignmentProfiles = lm.alignmentProfiles.With(handedness, angle)
+}
+
+// applyAlignmentProfile confirms the aim saved for a handedness again when
+// the player changes, so the device uses it without re-aligning. Nothing is
+// sent while the player is aligning or when no aim was saved.
+func (lm *LaunchMonitor) applyAlignmentProfile(handedness HandednessType) {
+	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
+		return
+	}
+	if lm.stateManager.GetIsAligning() {
+		return
+	}
+	angle, ok := lm.GetAlignmentProfiles().Angle(handedness)
+	if !ok {
+		return
+	}
+
+	command := StopAlignmentCommand(lm.getNextSequence(), angle)
+	if err := lm.SendCommand(command); err != nil {
+		log.Printf("LaunchMonitor: Failed to apply saved alignment: %v", err)
+		return
+	}
+	log.Printf("LaunchMonitor: Applied saved alignment of %.2f°", angle)
+}
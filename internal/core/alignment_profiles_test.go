@@ -0,0 +1,71 @@
+package core
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestAlignmentProfiles_AngleAndWith(t *testing.T) {
+	var profiles AlignmentProfiles
+	if _, ok := profiles.Angle(RightHanded); ok {
+		t.Error("Angle(RightHanded) ok = true before any aim was saved")
+	}
+
+	profiles = profiles.With(LeftHanded, -2.5)
+	if angle, ok := profiles.Angle(LeftHanded); !ok || angle != -2.5 {
+		t.Errorf("Angle(LeftHanded) = %v, %v, want -2.5", angle, ok)
+	}
+	if _, ok := profiles.Angle(RightHanded); ok {
+		t.Error("saving the left handed aim also saved a right handed one")
+	}
+}
+
+func TestAlignmentProfiles_AppliedWhenHandednessSwitches(t *testing.T) {
+	sm, lm, mockClient, btManager := newTestLaunchMonitor(t)
+	lm.SetupNotifications(btManager)
+	mockClient.connected = true
+
+	right, left := RightHanded, LeftHanded
+	sm.SetHandedness(&right)
+	sm.Flush()
+
+	// OK saves the current aim for the current handedness
+	sm.SetIsAligning(true)
+	sm.SetAlignmentAngle(1.5)
+	if err := lm.StopAlignment(); err != nil {
+		t.Fatalf("StopAlignment() error = %v", err)
+	}
+	if angle, ok := lm.GetAlignmentProfiles().Angle(RightHanded); !ok || angle != 1.5 {
+		t.Fatalf("right handed aim = %v, %v, want 1.5", angle, ok)
+	}
+
+	// No left handed aim is saved yet, so switching sends nothing
+	mockClient.ClearWriteHistory()
+	sm.SetHandedness(&left)
+	sm.Flush()
+	if writes := mockClient.GetWriteHistory(); len(writes) != 0 {
+		t.Fatalf("switching to an unsaved handedness wrote %d commands", len(writes))
+	}
+
+	lm.SetAlignmentProfiles(lm.GetAlignmentProfiles().With(LeftHanded, -3))
+	sm.SetHandedness(&right)
+	sm.Flush()
+	writes := mockClient.GetWriteHistory()
+	if len(writes) != 1 {
+		t.Fatalf("switching handedness wrote %d commands, want 1", len(writes))
+	}
+	// 1185, sequence, confirm=1, 150 (1.5°) as little-endian int32
+	if got := strings.ToLower(hex.EncodeToString(writes[0].Data)); !strings.HasPrefix(got, "1185") || !strings.HasSuffix(got, "0196000000") {
+		t.Errorf("command = %s, want a confirmed alignment at 1.5°", got)
+	}
+
+	// Switching while the player is aligning leaves the aim alone
+	mockClient.ClearWriteHistory()
+	sm.SetIsAligning(true)
+	sm.SetHandedness(&left)
+	sm.Flush()
+	if writes := mockClient.GetWriteHistory(); len(writes) != 0 {
+		t.Errorf("switching while aligning wrote %d commands", len(writes))
+	}
+}
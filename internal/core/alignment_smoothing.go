@@ -0,0 +1,90 @@
+package core
+
+// Limits for alignment smoothing
+const (
+	minAlignmentReadings   = 1
+	maxAlignmentReadings   = 20
+	maxAlignmentUpdateRate = 30 // Hz
+)
+
+// AlignmentSmoothing steadies the aim angle shown while aligning. Raw 11 04
+// readings jitter by a few tenths of a degree, which makes the needle jump
+// around, so the angle is averaged over the last few readings and passed on
+// at most UpdateRate times a second.
+type AlignmentSmoothing struct {
+	Readings   int `json:"readings"`   // readings averaged, 1 for none
+	UpdateRate int `json:"updateRate"` // Hz, 0 for every reading
+}
+
+// DefaultAlignmentSmoothing averages 5 readings and updates 10 times a second
+func DefaultAlignmentSmoothing() AlignmentSmoothing {
+	return AlignmentSmoothing{Readings: 5, UpdateRate: 10}
+}
+
+// Valid reports whether both values are in range
+func (s AlignmentSmoothing) Valid() bool {
+	return s.Readings >= minAlignmentReadings && s.Readings <= maxAlignmentReadings &&
+		s.UpdateRate >= 0 && s.UpdateRate <= maxAlignmentUpdateRate
+}
+
+// SetAlignmentSmoothing sets how the aim angle is smoothed. Readings already
+// averaged are kept, up to the new count.
+func (lm *LaunchMonitor) SetAlignmentSmoothing(settings AlignmentSmoothing) {
+	lm.alignmentMu.Lock()
+	defer lm.alignmentMu.Unlock()
+	lm.alignmentSmoothing = settings
+	if extra := len(lm.alignmentReadings) - settings.Readings; extra > 0 {
+		lm.alignmentReadings = lm.alignmentReadings[extra:]
+	}
+	lm.alignmentThrottle = nil
+}
+
+// smoothAlignmentAngle adds a reading to the average and schedules the
+// averaged angle for the state manager
+func (lm *LaunchMonitor) smoothAlignmentAngle(angle float64) {
+	lm.alignmentMu.Lock()
+	readings := lm.alignmentSmoothing.Readings
+	if readings < minAlignmentReadings {
+		readings = minAlignmentReadings
+	}
+	lm.alignmentReadings = append(lm.alignmentReadings, angle)
+	if extra := len(lm.alignmentReadings) - readings; extra > 0 {
+		lm.alignmentReadings = lm.alignmentReadings[extra:]
+	}
+	sum := 0.0
+	for _, reading := range lm.alignmentReadings {
+		sum += reading
+	}
+	lm.alignmentAngle = sum / float64(len(lm.alignmentReadings))
+	lm.alignmentPending = true
+
+	if lm.alignmentThrottle == nil {
+		lm.alignmentThrottle = NewThrottle(lm.clock, RateInterval(lm.alignmentSmoothing.UpdateRate), lm.publishAlignmentAngle)
+	}
+	throttle := lm.alignmentThrottle
+	lm.alignmentMu.Unlock()
+
+	throttle.Trigger()
+}
+
+// publishAlignmentAngle passes the averaged angle to the state manager if it
+// hasn't been already
+func (lm *LaunchMonitor) publishAlignmentAngle() {
+	lm.alignmentMu.Lock()
+	defer lm.alignmentMu.Unlock()
+	if !lm.alignmentPending {
+		return
+	}
+	lm.alignmentPending = false
+	lm.stateManager.SetAlignmentAngle(lm.alignmentAngle)
+}
+
+// resetAlignmentSmoothing forgets the readings so far, so a new alignment
+// doesn't start from the last one's average. An angle still waiting for the
+// update rate is dropped.
+func (lm *LaunchMonitor) resetAlignmentSmoothing() {
+	lm.alignmentMu.Lock()
+	defer lm.alignmentMu.Unlock()
+	lm.alignmentReadings = nil
+	lm.alignmentPending = false
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// defaultAlignmentPacket builds an 11 04 notification in the default format:
+// a little-endian angle in hundredths of a degree at bytes 5-6
+func defaultAlignmentPacket(angle float64) []string {
+	raw := uint16(int16(math.Round(angle * 100)))
+	return []string{"11", "04", "00", "01", "00", fmt.Sprintf("%02x", raw&0xff), fmt.Sprintf("%02x", raw>>8)}
+}
+
+func TestAlignmentSmoothing_AveragesAndLimitsUpdateRate(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	clock := NewFakeClock(time.Unix(0, 0))
+	lm.SetClock(clock)
+	lm.SetAlignmentSmoothing(AlignmentSmoothing{Readings: 3, UpdateRate: 10})
+
+	lm.HandleAlignmentNotification(defaultAlignmentPacket(1))
+	if angle := sm.GetAlignmentAngle(); angle != 1 {
+		t.Fatalf("Expected the first reading to be shown straight away, got %v", angle)
+	}
+
+	lm.HandleAlignmentNotification(defaultAlignmentPacket(2))
+	lm.HandleAlignmentNotification(defaultAlignmentPacket(3))
+	if angle := sm.GetAlignmentAngle(); angle != 1 {
+		t.Fatalf("Expected readings within the update interval to wait, got %v", angle)
+	}
+
+	clock.BlockUntil(1)
+	clock.Advance(100 * time.Millisecond)
+	deadline := time.Now().Add(time.Second)
+	for sm.GetAlignmentAngle() == 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if angle := sm.GetAlignmentAngle(); math.Abs(angle-2) > 1e-9 {
+		t.Errorf("Expected the average of 1, 2 and 3, got %v", angle)
+	}
+
+	// Only the last 3 readings are averaged, and OK saves one still waiting
+	// for the update rate
+	lm.HandleAlignmentNotification(defaultAlignmentPacket(7))
+	sm.SetIsAligning(true)
+	if err := lm.StopAlignment(); err != nil {
+		t.Fatalf("StopAlignment() error = %v", err)
+	}
+	if angle, ok := lm.GetAlignmentProfiles().Angle(RightHanded); !ok || math.Abs(angle-4) > 1e-9 {
+		t.Errorf("Expected the saved aim to average 2, 3 and 7, got %v, %v", angle, ok)
+	}
+	if angle := sm.GetAlignmentAngle(); angle != 0 {
+		t.Errorf("Expected the angle to be cleared after alignment, got %v", angle)
+	}
+}
+
+func TestAlignmentSmoothing_Valid(t *testing.T) {
+	tests := []struct {
+		settings AlignmentSmoothing
+		want     bool
+	}{
+		{DefaultAlignmentSmoothing(), true},
+		{AlignmentSmoothing{Readings: 1, UpdateRate: 0}, true},
+		{AlignmentSmoothing{Readings: 0, UpdateRate: 10}, false},
+		{AlignmentSmoothing{Readings: 21, UpdateRate: 10}, false},
+		{AlignmentSmoothing{Readings: 5, UpdateRate: -1}, false},
+		{AlignmentSmoothing{Readings: 5, UpdateRate: 31}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.settings.Valid(); got != tt.want {
+			t.Errorf("%+v.Valid() = %v, want %v", tt.settings, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+// ellipseScale95 scales standard deviations to a 95% confidence ellipse
+// (square root of the chi-squared value for two degrees of freedom).
+const ellipseScale95 = 2.4477
+
+// Ellipse describes a dispersion ellipse centered on the mean landing point.
+// Axes are in yards and Rotation is in degrees from the offline axis.
+type Ellipse struct {
+	CenterOffline float64 `json:"centerOffline"`
+	CenterCarry   float64 `json:"centerCarry"`
+	SemiMajor     float64 `json:"semiMajor"`
+	SemiMinor     float64 `json:"semiMinor"`
+	Rotation      float64 `json:"rotation"`
+}
+
+// ClubDispersion summarizes landing spread for one club
+type ClubDispersion struct {
+	Club          string  `json:"club"`
+	Shots         int     `json:"shots"`
+	AvgCarry      float64 `json:"avgCarry"`
+	AvgOffline    float64 `json:"avgOffline"`
+	CarryStdDev   float64 `json:"carryStdDev"`
+	OfflineStdDev float64 `json:"offlineStdDev"`
+	Ellipse       Ellipse `json:"ellipse"`
+}
+
+// ClubGap is one club's average carry and the distance to the next shorter club
+type ClubGap struct {
+	Club      string   `json:"club"`
+	Shots     int      `json:"shots"`
+	AvgCarry  float64  `json:"avgCarry"`
+	GapToNext *float64 `json:"gapToNext"`
+}
+
+// ClubConsistency scores how repeatable a club is, from 0 to 100
+type ClubConsistency struct {
+	Club             string  `json:"club"`
+	Shots            int     `json:"shots"`
+	BallSpeedStdDev  float64 `json:"ballSpeedStdDev"`
+	LaunchStdDev     float64 `json:"launchStdDev"`
+	SpinStdDev       float64 `json:"spinStdDev"`
+	CarryStdDev      float64 `json:"carryStdDev"`
+	ConsistencyScore float64 `json:"consistencyScore"`
+}
+
+// groupByClub returns full-swing shots grouped by club, in first-seen order
+func groupByClub(shots []history.Shot) ([]string, map[string][]history.Shot) {
+	var order []string
+	groups := make(map[string][]history.Shot)
+	for _, shot := range shots {
+		if shot.Ball.ShotType == core.ShotTypePutt || shot.Club == core.ClubPutter.Name() || shot.CarryYards <= 0 {
+			continue
+		}
+		if _, exists := groups[shot.Club]; !exists {
+			order = append(order, shot.Club)
+		}
+		groups[shot.Club] = append(groups[shot.Club], shot)
+	}
+	return order, groups
+}
+
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(values)-1))
+}
+
+// Dispersion computes landing dispersion per club
+func Dispersion(shots []history.Shot) []ClubDispersion {
+	order, groups := groupByClub(shots)
+	results := make([]ClubDispersion, 0, len(order))
+
+	for _, club := range order {
+		group := groups[club]
+		carries := make([]float64, len(group))
+		offlines := make([]float64, len(group))
+		for i, shot := range group {
+			carries[i] = shot.CarryYards
+			offlines[i] = shot.OfflineYards
+		}
+
+		avgCarry, carryStd := meanStd(carries)
+		avgOffline, offlineStd := meanStd(offlines)
+
+		results = append(results, ClubDispersion{
+			Club:          club,
+			Shots:         len(group),
+			AvgCarry:      avgCarry,
+			AvgOffline:    avgOffline,
+			CarryStdDev:   carryStd,
+			OfflineStdDev: offlineStd,
+			Ellipse:       confidenceEllipse(offlines, carries, avgOffline, avgCarry),
+		})
+	}
+
+	return results
+}
+
+// confidenceEllipse derives the 95% ellipse from the covariance matrix of the
+// landing points.
+func confidenceEllipse(xs, ys []float64, meanX, meanY float64) Ellipse {
+	ellipse := Ellipse{CenterOffline: meanX, CenterCarry: meanY}
+	n := len(xs)
+	if n < 2 {
+		return ellipse
+	}
+
+	var varX, varY, cov float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		dy := ys[i] - meanY
+		varX += dx * dx
+		varY += dy * dy
+		cov += dx * dy
+	}
+	varX /= float64(n - 1)
+	varY /= float64(n - 1)
+	cov /= float64(n - 1)
+
+	// Eigenvalues of the 2x2 covariance matrix
+	trace := varX + varY
+	diff := math.Sqrt(math.Max(0, (varX-varY)*(varX-varY)/4+cov*cov))
+	major := trace/2 + diff
+	minor := math.Max(0, trace/2-diff)
+
+	ellipse.SemiMajor = ellipseScale95 * math.Sqrt(major)
+	ellipse.SemiMinor = ellipseScale95 * math.Sqrt(minor)
+	ellipse.Rotation = 0.5 * math.Atan2(2*cov, varX-varY) * 180 / math.Pi
+	return ellipse
+}
+
+// Gapping returns average carry per club, longest first, with the gap to the
+// next club in the bag.
+func Gapping(shots []history.Shot) []ClubGap {
+	order, groups := groupByClub(shots)
+	gaps := make([]ClubGap, 0, len(order))
+
+	for _, club := range order {
+		group := groups[club]
+		carries := make([]float64, len(group))
+		for i, shot := range group {
+			carries[i] = shot.CarryYards
+		}
+		avgCarry, _ := meanStd(carries)
+		gaps = append(gaps, ClubGap{Club: club, Shots: len(group), AvgCarry: avgCarry})
+	}
+
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i].AvgCarry > gaps[j].AvgCarry })
+	for i := 0; i < len(gaps)-1; i++ {
+		gap := gaps[i].AvgCarry - gaps[i+1].AvgCarry
+		gaps[i].GapToNext = &gap
+	}
+
+	return gaps
+}
+
+// Consistency scores each club by how much its carry and landing spread vary
+// relative to its distance.
+func Consistency(shots []history.Shot) []ClubConsistency {
+	order, groups := groupByClub(shots)
+	results := make([]ClubConsistency, 0, len(order))
+
+	for _, club := range order {
+		group := groups[club]
+		speeds := make([]float64, len(group))
+		launches := make([]float64, len(group))
+		spins := make([]float64, len(group))
+		carries := make([]float64, len(group))
+		offlines := make([]float64, len(group))
+		for i, shot := range group {
+			speeds[i] = shot.Ball.BallSpeedMPS * 2.23694
+			launches[i] = shot.Ball.VerticalAngle
+			spins[i] = float64(shot.Ball.TotalspinRPM)
+			carries[i] = shot.CarryYards
+			offlines[i] = shot.OfflineYards
+		}
+
+		_, speedStd := meanStd(speeds)
+		_, launchStd := meanStd(launches)
+		_, spinStd := meanStd(spins)
+		avgCarry, carryStd := meanStd(carries)
+		_, offlineStd := meanStd(offlines)
+
+		score := 0.0
+		if avgCarry > 0 && len(group) > 1 {
+			spread := math.Hypot(carryStd, offlineStd) / avgCarry
+			score = math.Max(0, 100*(1-spread*5))
+		}
+
+		results = append(results, ClubConsistency{
+			Club:             club,
+			Shots:            len(group),
+			BallSpeedStdDev:  speedStd,
+			LaunchStdDev:     launchStd,
+			SpinStdDev:       spinStd,
+			CarryStdDev:      carryStd,
+			ConsistencyScore: math.Round(score*10) / 10,
+		})
+	}
+
+	return results
+}
+
+// FilterByClub returns shots hit with the named club; an empty name keeps all shots
+func FilterByClub(shots []history.Shot, club string) []history.Shot {
+	if club == "" {
+		return shots
+	}
+	filtered := make([]history.Shot, 0, len(shots))
+	for _, shot := range shots {
+		if shot.Club == club {
+			filtered = append(filtered, shot)
+		}
+	}
+	return filtered
+}
+
+// FilterByPlayer returns shots hit by the named GSPro player; an empty name
+// keeps all shots
+func FilterByPlayer(shots []history.Shot, player string) []history.Shot {
+	if player == "" {
+		return shots
+	}
+	filtered := make([]history.Shot, 0, len(shots))
+	for _, shot := range shots {
+		if shot.Player == player {
+			filtered = append(filtered, shot)
+		}
+	}
+	return filtered
+}
+
+// SessionNone selects the shots hit outside any session
+const SessionNone = "none"
+
+// FilterBySession returns shots hit during the kind of session, such as
+// warmup, or outside one for SessionNone; an empty kind keeps all shots
+func FilterBySession(shots []history.Shot, session string) []history.Shot {
+	if session == "" {
+		return shots
+	}
+	if session == SessionNone {
+		session = ""
+	}
+	filtered := make([]history.Shot, 0, len(shots))
+	for _, shot := range shots {
+		if shot.Session == session {
+			filtered = append(filtered, shot)
+		}
+	}
+	return filtered
+}
+
+// FilterByTime returns shots taken from from up to but not including to; a
+// zero time leaves that end of the range open
+func FilterByTime(shots []history.Shot, from, to time.Time) []history.Shot {
+	if from.IsZero() && to.IsZero() {
+		return shots
+	}
+	filtered := make([]history.Shot, 0, len(shots))
+	for _, shot := range shots {
+		if !from.IsZero() && shot.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !shot.Timestamp.Before(to) {
+			continue
+		}
+		filtered = append(filtered, shot)
+	}
+	return filtered
+}
+
+// After returns shots with an ID greater than id, so a client can fetch only
+// what it has not seen
+func After(shots []history.Shot, id int) []history.Shot {
+	for i, shot := range shots {
+		if shot.ID > id {
+			return shots[i:]
+		}
+	}
+	return shots[len(shots):]
+}
+
+// LastN returns the most recent n shots; n <= 0 keeps all shots
+func LastN(shots []history.Shot, n int) []history.Shot {
+	return Page(shots, 0, n)
+}
+
+// Page returns up to n shots after skipping the offset most recent ones, so
+// offset 0 is the latest page; n <= 0 keeps every shot before the offset
+func Page(shots []history.Shot, offset, n int) []history.Shot {
+	if offset >= len(shots) {
+		return shots[:0]
+	}
+	end := len(shots) - offset
+	if n <= 0 || n >= end {
+		return shots[:end]
+	}
+	return shots[end-n : end]
+}
@@ -0,0 +1,149 @@
+package awesomegolf
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/openconnect"
+	"github.com/brentyates/squaregolf-connector/internal/core/simulator"
+)
+
+// DefaultPort is the Open Connect port Awesome Golf listens on unless its
+// connection settings say otherwise. It is GSPro's port too, so the two
+// can't both connect to the same computer on startup.
+const DefaultPort = 921
+
+// Integration sends shots to Awesome Golf over its Open Connect interface
+type Integration struct {
+	*simulator.Base
+	stateManager  *core.StateManager
+	launchMonitor *core.LaunchMonitor
+	shotNumber    int
+	conventionMu  sync.RWMutex
+	convention    core.SpinConvention
+}
+
+func New(stateManager *core.StateManager, launchMonitor *core.LaunchMonitor, host string, port int) *Integration {
+	ag := &Integration{
+		stateManager:  stateManager,
+		launchMonitor: launchMonitor,
+		convention:    core.DefaultSpinConventions()[core.SimulatorAwesomeGolf],
+	}
+	ag.Base = simulator.NewBase(ag, host, port)
+	ag.registerStateListeners()
+	return ag
+}
+
+// SetSpinConvention sets how spin axis, sidespin and horizontal launch angle
+// signs are mapped before shots are sent
+func (ag *Integration) SetSpinConvention(convention core.SpinConvention) {
+	ag.conventionMu.Lock()
+	defer ag.conventionMu.Unlock()
+	ag.convention = convention
+}
+
+func (ag *Integration) spinConvention() core.SpinConvention {
+	ag.conventionMu.RLock()
+	defer ag.conventionMu.RUnlock()
+	return ag.convention
+}
+
+func (ag *Integration) Name() string {
+	return "Awesome Golf"
+}
+
+func (ag *Integration) DefaultPort() int {
+	return DefaultPort
+}
+
+func (ag *Integration) GetStateManager() *core.StateManager {
+	return ag.stateManager
+}
+
+func (ag *Integration) GetLaunchMonitor() *core.LaunchMonitor {
+	return ag.launchMonitor
+}
+
+func (ag *Integration) SetStatus(status simulator.ConnectionStatus) {
+	switch status {
+	case simulator.StatusDisconnected:
+		ag.stateManager.SetAwesomeGolfStatus(core.AwesomeGolfStatusDisconnected)
+	case simulator.StatusConnecting:
+		ag.stateManager.SetAwesomeGolfStatus(core.AwesomeGolfStatusConnecting)
+	case simulator.StatusConnected:
+		ag.stateManager.SetAwesomeGolfStatus(core.AwesomeGolfStatusConnected)
+	case simulator.StatusError:
+		ag.stateManager.SetAwesomeGolfStatus(core.AwesomeGolfStatusError)
+	}
+}
+
+func (ag *Integration) SetError(err error) {
+	ag.stateManager.SetAwesomeGolfError(err)
+}
+
+func (ag *Integration) OnConnected() {
+	log.Printf("[%s] Connected - activating ball detection immediately", ag.Name())
+	if err := ag.launchMonitor.ActivateBallDetection(); err != nil {
+		log.Printf("[%s] Failed to activate ball detection: %v", ag.Name(), err)
+	}
+}
+
+func (ag *Integration) OnDisconnected() {
+}
+
+// ProcessMessage handles a response from Awesome Golf. Responses are told
+// apart by their code rather than their text, which differs between
+// Open Connect hosts.
+func (ag *Integration) ProcessMessage(rawMessage string) {
+	var response Response
+	if err := json.Unmarshal([]byte(rawMessage), &response); err != nil {
+		log.Printf("[%s] Invalid JSON: %v", ag.Name(), err)
+		return
+	}
+
+	switch {
+	case response.Code == CodePlayerInfo && response.Player != nil:
+		openconnect.ApplyPlayer(ag.stateManager, ag.Name(), *response.Player)
+		ag.activateBallDetection()
+	case response.Code == CodeShotReceived:
+		log.Printf("[%s] Shot data confirmed by server", ag.Name())
+	case response.Code >= 500:
+		log.Printf("[%s] Server reported an error: %d %s", ag.Name(), response.Code, response.Message)
+	default:
+		log.Printf("[%s] Unknown message: %s", ag.Name(), rawMessage)
+	}
+}
+
+func (ag *Integration) activateBallDetection() {
+	if err := ag.launchMonitor.ActivateBallDetection(); err != nil {
+		log.Printf("[%s] Failed to activate ball detection: %v", ag.Name(), err)
+	}
+}
+
+func (ag *Integration) connected() bool {
+	return ag.Base.Connected && ag.Base.Socket != nil
+}
+
+// nextShot numbers a new shot. Its club data is sent under the same number.
+func (ag *Integration) nextShot() {
+	ag.shotNumber++
+}
+
+func (ag *Integration) newShotData() openconnect.ShotData {
+	return openconnect.ShotData{
+		DeviceID:   "CustomLaunchMonitor",
+		Units:      "Yards",
+		APIversion: "1",
+		ShotNumber: ag.shotNumber,
+	}
+}
+
+func (ag *Integration) sendData(shotData openconnect.ShotData) error {
+	jsonData, err := json.Marshal(shotData)
+	if err != nil {
+		return err
+	}
+	return ag.Base.SendMessage(jsonData)
+}
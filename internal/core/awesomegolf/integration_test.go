@@ -0,0 +1,180 @@
+package awesomegolf
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/openconnect"
+)
+
+const testTimeout = 5 * time.Second
+
+// fakeHost stands in for Awesome Golf, collecting the shot data it is sent
+type fakeHost struct {
+	conn     net.Conn
+	messages chan openconnect.ShotData
+}
+
+func (h *fakeHost) reply(t *testing.T, response Response) {
+	t.Helper()
+	data, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.conn.Write(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// next returns the next message matching match, skipping the rest
+func (h *fakeHost) next(t *testing.T, what string, match func(openconnect.ShotData) bool) openconnect.ShotData {
+	t.Helper()
+	timeout := time.After(testTimeout)
+	for {
+		select {
+		case msg := <-h.messages:
+			if match(msg) {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(testTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newTestIntegration connects a simulated device through an Integration to
+// a fake Awesome Golf
+func newTestIntegration(t *testing.T) (*Integration, *core.SimulatorBluetoothClient, *fakeHost) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	state := core.NewStateManager()
+	bluetooth := core.NewBluetoothManager(state)
+	sim := core.NewSimulatorBluetoothClient(core.SimulatorConfig{})
+	sim.SetManual(true)
+	bluetooth.SetClient(sim)
+	launchMonitor := core.NewLaunchMonitor(state, bluetooth)
+	launchMonitor.SetupNotifications(bluetooth)
+
+	bluetooth.StartBluetoothConnection("SquareGolf(****)", "")
+	waitFor(t, "the device to connect", func() bool {
+		return state.GetConnectionStatus() == core.ConnectionStatusConnected
+	})
+
+	addr := listener.Addr().(*net.TCPAddr)
+	ag := New(state, launchMonitor, "127.0.0.1", addr.Port)
+	ag.Start()
+	t.Cleanup(func() {
+		ag.Shutdown()
+		bluetooth.DisconnectBluetooth()
+	})
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	host := &fakeHost{conn: conn, messages: make(chan openconnect.ShotData, 32)}
+	go func() {
+		decoder := json.NewDecoder(conn)
+		for {
+			var msg openconnect.ShotData
+			if err := decoder.Decode(&msg); err != nil {
+				return
+			}
+			host.messages <- msg
+		}
+	}()
+
+	waitFor(t, "Awesome Golf to connect", func() bool {
+		return state.GetAwesomeGolfStatus() == core.AwesomeGolfStatusConnected
+	})
+	return ag, sim, host
+}
+
+func TestHandshake_ActivatesDetectionAndSelectsPlayersClub(t *testing.T) {
+	ag, sim, host := newTestIntegration(t)
+
+	waitFor(t, "ball detection to be activated", func() bool {
+		return sim.ControlStatus().DeviceState == core.DeviceStateBallDetection
+	})
+
+	host.reply(t, Response{Code: CodePlayerInfo, Message: "Player Information", Player: &openconnect.Player{Club: "I7", Handed: "LH"}})
+
+	waitFor(t, "the club to change", func() bool {
+		club := ag.stateManager.GetClub()
+		return club != nil && *club == core.ClubIron7
+	})
+	waitFor(t, "left-handed to be selected", func() bool {
+		handedness := ag.stateManager.GetHandedness()
+		return handedness != nil && *handedness == core.LeftHanded
+	})
+	if name := ag.stateManager.GetClubName(); name == nil || *name != "7I" {
+		t.Errorf("club name = %v, want 7I", name)
+	}
+}
+
+func TestHandshake_ShotIsSentAndAcknowledged(t *testing.T) {
+	_, sim, host := newTestIntegration(t)
+
+	if err := sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+	ready := host.next(t, "the readiness update", func(msg openconnect.ShotData) bool {
+		return msg.ShotDataOptions.LaunchMonitorIsReady
+	})
+	if ready.ShotDataOptions.ContainsBallData || ready.ShotDataOptions.ContainsClubData || ready.DeviceID != "CustomLaunchMonitor" {
+		t.Errorf("readiness update = %+v, want no shot data", ready)
+	}
+
+	shot := &core.SimulatedShot{
+		BallSpeedMPS:  60,
+		VerticalAngle: 14.5,
+		TotalspinRPM:  3000,
+		SpinAxis:      5,
+		BackspinRPM:   2989,
+		SidespinRPM:   261,
+		Club:          &core.SimulatedClub{PathAngle: 3.5, FaceAngle: -1.5, AttackAngle: -4, DynamicLoftAngle: 18},
+	}
+	if err := sim.InjectShot(shot); err != nil {
+		t.Fatalf("InjectShot() error = %v", err)
+	}
+
+	ball := host.next(t, "the ball data", func(msg openconnect.ShotData) bool { return msg.ShotDataOptions.ContainsBallData })
+	if ball.ShotNumber != 1 || ball.ClubData != nil {
+		t.Errorf("ball message = %+v, want shot 1 without club data", ball)
+	}
+	if math.Abs(ball.BallData.Speed-60*2.23694) > 0.05 || ball.BallData.SpinAxis != -5 || ball.BallData.SideSpin != -261 {
+		t.Errorf("BallData = %+v", ball.BallData)
+	}
+	host.reply(t, Response{Code: CodeShotReceived, Message: "Shot received successfully"})
+
+	club := host.next(t, "the club data", func(msg openconnect.ShotData) bool { return msg.ShotDataOptions.ContainsClubData })
+	if club.ShotNumber != 1 || club.BallData != nil {
+		t.Errorf("club message = %+v, want shot 1 without ball data", club)
+	}
+	if club.ClubData.Path != 3.5 || club.ClubData.FaceToTarget != -1.5 || club.ClubData.AngleOfAttack != -4 {
+		t.Errorf("ClubData = %+v", club.ClubData)
+	}
+}
@@ -0,0 +1,50 @@
+package awesomegolf
+
+import (
+	"log"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/openconnect"
+)
+
+func (ag *Integration) registerStateListeners() {
+	ag.stateManager.RegisterBallReadyCallback(ag.onBallReadyChanged)
+	ag.stateManager.RegisterLastBallMetricsCallback(ag.onLastBallMetricsChanged)
+	ag.stateManager.RegisterLastClubMetricsCallback(ag.onLastClubMetricsChanged)
+}
+
+func (ag *Integration) onBallReadyChanged(oldValue, newValue bool) {
+	if oldValue == newValue || !ag.connected() {
+		return
+	}
+
+	if err := ag.sendData(openconnect.ReadyMessage(ag.newShotData(), newValue)); err != nil {
+		log.Printf("[%s] Error sending ready status: %v", ag.Name(), err)
+	}
+}
+
+func (ag *Integration) onLastBallMetricsChanged(oldValue, newValue *core.BallMetrics) {
+	if oldValue == newValue || newValue == nil || !ag.connected() {
+		return
+	}
+
+	ag.nextShot()
+	shotData := openconnect.BallMessage(ag.newShotData(), *newValue, ag.spinConvention())
+	if err := ag.sendData(shotData); err != nil {
+		log.Printf("[%s] Error sending shot data: %v", ag.Name(), err)
+		return
+	}
+	ag.launchMonitor.Latency().MarkSent(ag.Name())
+}
+
+// onLastClubMetricsChanged sends the club data for the last shot under the
+// same shot number. Cleared club data is sent as zeros.
+func (ag *Integration) onLastClubMetricsChanged(oldValue, newValue *core.ClubMetrics) {
+	if oldValue == newValue || !ag.connected() {
+		return
+	}
+
+	if err := ag.sendData(openconnect.ClubMessage(ag.newShotData(), newValue)); err != nil {
+		log.Printf("[%s] Error sending club data: %v", ag.Name(), err)
+	}
+}
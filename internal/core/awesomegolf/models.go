@@ -0,0 +1,20 @@
+package awesomegolf
+
+import "github.com/brentyates/squaregolf-connector/internal/core/openconnect"
+
+// Awesome Golf takes shots as Open Connect ShotData messages and replies
+// with a Response.
+
+// Response is a message from Awesome Golf. Code 200 confirms a shot, 201
+// carries the player's club and handedness, and 5xx reports an error.
+type Response struct {
+	Code    int                 `json:"Code"`
+	Message string              `json:"Message"`
+	Player  *openconnect.Player `json:"Player,omitempty"`
+}
+
+// Response codes
+const (
+	CodeShotReceived = 200
+	CodePlayerInfo   = 201
+)
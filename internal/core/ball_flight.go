@@ -0,0 +1,89 @@
+package core
+
+import "math"
+
+// Ball flight constants for a regulation golf ball in standard air.
+const (
+	ballMassKg       = 0.04593
+	ballRadiusM      = 0.021335
+	airDensityKgM3   = 1.194
+	gravityMPS2      = 9.81
+	flightTimeStepS  = 0.005
+	maxFlightTimeS   = 15.0
+	metersToYards    = 1.09361
+	ballDragBase     = 0.26
+	ballDragSpinRate = 0.2
+	curvePerSpinAxis = 0.35
+)
+
+// EstimateCarryYards returns an approximate carry distance for a shot using a
+// simple drag and lift model. It is intended for feedback such as voice
+// announcements, not as a replacement for a simulator's ball flight.
+func EstimateCarryYards(ballMetrics *BallMetrics) float64 {
+	return estimateCarryYardsInAir(ballMetrics, airDensityKgM3)
+}
+
+// estimateCarryYardsInAir runs the flight model in air of the given density
+func estimateCarryYardsInAir(ballMetrics *BallMetrics, airDensity float64) float64 {
+	if ballMetrics == nil || ballMetrics.BallSpeedMPS <= 0 {
+		return 0
+	}
+
+	backspin := float64(ballMetrics.BackspinRPM)
+	if backspin == 0 && ballMetrics.TotalspinRPM != 0 {
+		backspin = float64(ballMetrics.TotalspinRPM) * math.Cos(ballMetrics.SpinAxis*math.Pi/180)
+	}
+	omega := math.Abs(backspin) * 2 * math.Pi / 60
+
+	launch := ballMetrics.VerticalAngle * math.Pi / 180
+	vx := ballMetrics.BallSpeedMPS * math.Cos(launch)
+	vy := ballMetrics.BallSpeedMPS * math.Sin(launch)
+	x, y := 0.0, 0.0
+
+	area := math.Pi * ballRadiusM * ballRadiusM
+	k := 0.5 * airDensity * area / ballMassKg
+
+	for t := 0.0; t < maxFlightTimeS; t += flightTimeStepS {
+		v := math.Hypot(vx, vy)
+		if v == 0 {
+			break
+		}
+
+		spinFactor := ballRadiusM * omega / v
+		cd := ballDragBase + ballDragSpinRate*spinFactor*spinFactor
+		cl := math.Min(0.35, 0.54*math.Pow(spinFactor, 0.4))
+
+		// Drag opposes velocity; lift is perpendicular to it
+		ax := -k * v * (cd*vx + cl*vy)
+		ay := k*v*(cl*vx-cd*vy) - gravityMPS2
+
+		vx += ax * flightTimeStepS
+		vy += ay * flightTimeStepS
+		nextX := x + vx*flightTimeStepS
+		nextY := y + vy*flightTimeStepS
+
+		if nextY < 0 && vy < 0 {
+			// Interpolate the landing point between the last two steps
+			fraction := y / (y - nextY)
+			x += (nextX - x) * fraction
+			break
+		}
+		x, y = nextX, nextY
+	}
+
+	return x * metersToYards
+}
+
+// EstimateOfflineYards returns an approximate lateral landing position for a
+// shot, positive to the right. It combines the start direction with a curve
+// term driven by spin axis.
+func EstimateOfflineYards(ballMetrics *BallMetrics) float64 {
+	carry := EstimateCarryYards(ballMetrics)
+	if carry == 0 {
+		return 0
+	}
+
+	start := carry * math.Sin(ballMetrics.HorizontalAngle*math.Pi/180)
+	curve := carry * curvePerSpinAxis * math.Sin(ballMetrics.SpinAxis*math.Pi/180)
+	return start + curve
+}
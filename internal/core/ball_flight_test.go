@@ -0,0 +1,40 @@
+package core
+
+import "testing"
+
+func TestEstimateCarryYards(t *testing.T) {
+	tests := []struct {
+		name     string
+		speedMPH float64
+		launch   float64
+		backspin int16
+		min      float64
+		max      float64
+	}{
+		{"Driver", 167, 10.9, 2686, 255, 295},
+		{"FiveIron", 132, 12.1, 5361, 185, 225},
+		{"PitchingWedge", 102, 24.2, 9304, 120, 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carry := EstimateCarryYards(&BallMetrics{
+				BallSpeedMPS:  tt.speedMPH / 2.23694,
+				VerticalAngle: tt.launch,
+				BackspinRPM:   tt.backspin,
+			})
+			if carry < tt.min || carry > tt.max {
+				t.Errorf("EstimateCarryYards() = %.1f, want between %.0f and %.0f", carry, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestEstimateCarryYardsNoSpeed(t *testing.T) {
+	if carry := EstimateCarryYards(nil); carry != 0 {
+		t.Errorf("EstimateCarryYards(nil) = %.1f, want 0", carry)
+	}
+	if carry := EstimateCarryYards(&BallMetrics{}); carry != 0 {
+		t.Errorf("EstimateCarryYards(zero speed) = %.1f, want 0", carry)
+	}
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Heatmap cell sizes, in mat coordinate units
+const (
+	DefaultHeatmapCellSize = 100
+	MinHeatmapCellSize     = 10
+	MaxHeatmapCellSize     = 1000
+)
+
+// Bounds on the heatmap: the oldest samples are dropped past the limit, and
+// cells are widened so a stray reading can't make the grid huge
+const (
+	maxHeatmapSamples = 10000
+	maxHeatmapCells   = 64
+)
+
+// BallHeatmap is a grid of how often the ball sat in each cell while ready
+// to hit. Rows run from the far end of the mat (+Y, towards the target) to
+// the near end, and columns from left to right when facing the target.
+type BallHeatmap struct {
+	SessionStartedAt time.Time     `json:"sessionStartedAt"`
+	Samples          int           `json:"samples"`
+	CellSize         int32         `json:"cellSize"`
+	MinX             int32         `json:"minX"` // left edge of the first column
+	MaxY             int32         `json:"maxY"` // far edge of the first row
+	Columns          int           `json:"columns"`
+	Rows             int           `json:"rows"`
+	Counts           [][]int       `json:"counts"` // [row][column]
+	MaxCount         int           `json:"maxCount"`
+	MeanX            *float64      `json:"meanX"`   // nil without samples
+	MeanY            *float64      `json:"meanY"`   // nil without samples
+	SpreadX          *float64      `json:"spreadX"` // standard deviation, nil without samples
+	SpreadY          *float64      `json:"spreadY"` // standard deviation, nil without samples
+	InZone           int           `json:"inZone"`  // samples inside the placement zone
+	Zone             PlacementZone `json:"zone"`
+}
+
+// BallHeatmapRecorder collects the ball positions reported while the ball is
+// ready, so an inconsistent setup that leads to misreads shows up as a spread
+// across the mat. The session runs from startup until Reset.
+type BallHeatmapRecorder struct {
+	clock     Clock
+	mu        sync.Mutex
+	samples   []BallPosition
+	startedAt time.Time
+}
+
+// NewBallHeatmapRecorder creates a recorder that samples the ball position in sm
+func NewBallHeatmapRecorder(sm *StateManager, clock Clock) *BallHeatmapRecorder {
+	h := &BallHeatmapRecorder{clock: clock, startedAt: clock.Now()}
+	sm.RegisterBallPositionCallback(func(_, position *BallPosition) {
+		// Ready is set before the position from the same notification
+		if position != nil && sm.GetBallReady() {
+			h.Add(*position)
+		}
+	})
+	return h
+}
+
+// Add records one ball position
+func (h *BallHeatmapRecorder) Add(position BallPosition) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.samples = append(h.samples, position)
+	if len(h.samples) > maxHeatmapSamples {
+		h.samples = h.samples[len(h.samples)-maxHeatmapSamples:]
+	}
+}
+
+// Reset clears the samples and starts a new session
+func (h *BallHeatmapRecorder) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.samples = nil
+	h.startedAt = h.clock.Now()
+}
+
+// Heatmap bins the session's samples into square cells of cellSize. The grid
+// covers the placement zone and every sample.
+func (h *BallHeatmapRecorder) Heatmap(cellSize int32, zone PlacementZone) BallHeatmap {
+	h.mu.Lock()
+	samples := append([]BallPosition{}, h.samples...)
+	startedAt := h.startedAt
+	h.mu.Unlock()
+
+	minX, maxX, minY, maxY := zone.MinX, zone.MaxX, zone.MinY, zone.MaxY
+	for _, p := range samples {
+		minX, maxX = min(minX, p.X), max(maxX, p.X)
+		minY, maxY = min(minY, p.Y), max(maxY, p.Y)
+	}
+	span := max(int64(maxX)-int64(minX), int64(maxY)-int64(minY))
+	if limit := int64(cellSize) * maxHeatmapCells; span >= limit {
+		cellSize = int32(span/maxHeatmapCells + 1)
+	}
+
+	// Snap the edges to whole cells so grids from the same cell size line up
+	left := floorTo(minX, cellSize)
+	top := floorTo(maxY, cellSize) + cellSize
+	columns := int((int64(maxX)-int64(left))/int64(cellSize)) + 1
+	rows := int((int64(top)-int64(minY)-1)/int64(cellSize)) + 1
+
+	heatmap := BallHeatmap{
+		SessionStartedAt: startedAt,
+		Samples:          len(samples),
+		CellSize:         cellSize,
+		MinX:             left,
+		MaxY:             top,
+		Columns:          columns,
+		Rows:             rows,
+		Counts:           make([][]int, rows),
+		Zone:             zone,
+	}
+	for row := range heatmap.Counts {
+		heatmap.Counts[row] = make([]int, columns)
+	}
+
+	var sumX, sumY float64
+	for _, p := range samples {
+		column := int((int64(p.X) - int64(left)) / int64(cellSize))
+		row := int((int64(top) - int64(p.Y) - 1) / int64(cellSize))
+		heatmap.Counts[row][column]++
+		heatmap.MaxCount = max(heatmap.MaxCount, heatmap.Counts[row][column])
+		sumX += float64(p.X)
+		sumY += float64(p.Y)
+		if p.X >= zone.MinX && p.X <= zone.MaxX && p.Y >= zone.MinY && p.Y <= zone.MaxY {
+			heatmap.InZone++
+		}
+	}
+	if len(samples) == 0 {
+		return heatmap
+	}
+
+	n := float64(len(samples))
+	meanX, meanY := sumX/n, sumY/n
+	var varX, varY float64
+	for _, p := range samples {
+		varX += (float64(p.X) - meanX) * (float64(p.X) - meanX)
+		varY += (float64(p.Y) - meanY) * (float64(p.Y) - meanY)
+	}
+	spreadX, spreadY := math.Sqrt(varX/n), math.Sqrt(varY/n)
+	heatmap.MeanX, heatmap.MeanY = &meanX, &meanY
+	heatmap.SpreadX, heatmap.SpreadY = &spreadX, &spreadY
+	return heatmap
+}
+
+// floorTo rounds value down to a multiple of step
+func floorTo(value, step int32) int32 {
+	return int32(math.Floor(float64(value)/float64(step))) * step
+}
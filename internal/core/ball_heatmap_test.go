@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBallHeatmapRecorder_SamplesWhileReady(t *testing.T) {
+	sm := NewStateManager()
+	clock := NewFakeClock(time.Unix(0, 0))
+	recorder := NewBallHeatmapRecorder(sm, clock)
+
+	// Positions before the ball is ready are not where it was addressed
+	sm.SetBallPosition(&BallPosition{X: 900, Y: 900})
+	sm.Flush()
+	sm.SetBallReady(true)
+	sm.SetBallPosition(&BallPosition{X: 50, Y: 50})
+	sm.Flush()
+	sm.SetBallPosition(&BallPosition{X: 60, Y: 40})
+	sm.Flush()
+
+	zone := PlacementZone{MinX: -100, MaxX: 100, MinY: -100, MaxY: 100}
+	heatmap := recorder.Heatmap(100, zone)
+	if heatmap.Samples != 2 || heatmap.InZone != 2 {
+		t.Fatalf("Expected 2 samples in the zone, got %+v", heatmap)
+	}
+	if heatmap.MinX != -100 || heatmap.MaxY != 200 || heatmap.Columns != 3 || heatmap.Rows != 3 {
+		t.Fatalf("Expected a 3x3 grid from (-100, 200), got %+v", heatmap)
+	}
+	// Both samples fall in the cell from (0, 0) to (100, 100)
+	if heatmap.Counts[1][1] != 2 || heatmap.MaxCount != 2 {
+		t.Errorf("Expected both samples in the middle cell, got %v", heatmap.Counts)
+	}
+	if heatmap.MeanX == nil || *heatmap.MeanX != 55 || *heatmap.SpreadY != 5 {
+		t.Errorf("Expected a mean X of 55 and a Y spread of 5, got %v, %v", heatmap.MeanX, heatmap.SpreadY)
+	}
+
+	clock.Advance(time.Minute)
+	recorder.Reset()
+	heatmap = recorder.Heatmap(100, zone)
+	if heatmap.Samples != 0 || heatmap.MeanX != nil || !heatmap.SessionStartedAt.Equal(clock.Now()) {
+		t.Errorf("Expected an empty new session, got %+v", heatmap)
+	}
+}
+
+func TestBallHeatmapRecorder_WidensCellsForStrayReadings(t *testing.T) {
+	recorder := NewBallHeatmapRecorder(NewStateManager(), NewFakeClock(time.Unix(0, 0)))
+	recorder.Add(BallPosition{X: 0, Y: 0})
+	recorder.Add(BallPosition{X: 100000, Y: -100000})
+
+	heatmap := recorder.Heatmap(10, DefaultPlacementZone())
+	if heatmap.Columns > maxHeatmapCells+2 || heatmap.Rows > maxHeatmapCells+2 {
+		t.Errorf("Expected the grid to stay bounded, got %dx%d cells of %d", heatmap.Columns, heatmap.Rows, heatmap.CellSize)
+	}
+	total := 0
+	for _, row := range heatmap.Counts {
+		for _, count := range row {
+			total += count
+		}
+	}
+	if total != 2 {
+		t.Errorf("Expected both samples binned, got %d", total)
+	}
+}
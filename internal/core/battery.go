@@ -0,0 +1,307 @@
+package core
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/fileutil"
+)
+
+// A session's drain rate is only trusted once the level has fallen this far
+// over this long; the device reports whole percentages
+const (
+	minSessionDrain    = 2
+	minSessionDuration = 10 * time.Minute
+)
+
+// Bounds on the saved battery history
+const (
+	maxChargeCycles = 50
+	maxDrainRates   = 10
+)
+
+// Charging statuses reported by the device that mean it is plugged in.
+// 0 is not charging and 1 discharging.
+const (
+	batteryCharging  = 2
+	batteryFull      = 3
+	batteryACPowered = 5
+)
+
+// BatteryEstimate is how fast the battery is draining this session and how
+// long it has left
+type BatteryEstimate struct {
+	PluggedIn        bool       `json:"pluggedIn"`
+	SessionStartedAt *time.Time `json:"sessionStartedAt,omitempty"` // since the device was connected or unplugged
+	SessionDrained   int        `json:"sessionDrained"`             // percentage points used this session
+	DrainPerHour     *float64   `json:"drainPerHour"`               // percent per hour, nil until known
+	Calibrated       bool       `json:"calibrated"`                 // the rate comes from earlier sessions
+	MinutesRemaining *int       `json:"minutesRemaining"`           // nil while plugged in or unknown
+}
+
+// ChargeCycle is one period on the charger
+type ChargeCycle struct {
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"` // nil while charging
+	FromLevel int        `json:"fromLevel"`
+	ToLevel   int        `json:"toLevel"`
+}
+
+// BatteryHistory is the estimate with the charge cycles and the drain rates
+// of recent sessions, newest last
+type BatteryHistory struct {
+	Estimate   BatteryEstimate `json:"estimate"`
+	Cycles     []ChargeCycle   `json:"cycles"`
+	DrainRates []float64       `json:"drainRates"`
+}
+
+// savedBattery is the part of the history kept across restarts
+type savedBattery struct {
+	Cycles     []ChargeCycle `json:"cycles"`
+	DrainRates []float64     `json:"drainRates"`
+}
+
+// BatteryTracker follows the battery level through each session on battery
+// to estimate the time remaining. Until a session has drained enough to
+// measure, the average rate of recent sessions is used, so the estimate is
+// calibrated to the user's device.
+type BatteryTracker struct {
+	clock Clock
+	mu    sync.Mutex
+	path  string
+
+	level     *int
+	pluggedIn bool
+
+	sessionStart      time.Time
+	sessionStartLevel int
+
+	cycles     []ChargeCycle
+	drainRates []float64
+	listeners  []func()
+}
+
+// NewBatteryTracker creates a tracker that follows the battery state in sm
+func NewBatteryTracker(sm *StateManager, clock Clock) *BatteryTracker {
+	t := &BatteryTracker{clock: clock}
+	sm.RegisterBatteryLevelCallback(func(_, level *int) {
+		t.setLevel(level)
+		t.notify()
+	})
+	sm.RegisterBatteryChargingCallback(func(_, status *int) {
+		t.setCharging(status)
+		t.notify()
+	})
+	return t
+}
+
+// SetPath loads the saved battery history from path and saves it there
+func (t *BatteryTracker) SetPath(path string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.path = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var saved savedBattery
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	t.cycles = saved.Cycles
+	t.drainRates = saved.DrainRates
+	return nil
+}
+
+// OnChange registers fn to be called after each battery update is taken in.
+// State subscribers run concurrently, so this is how to report the estimate
+// that follows an update.
+func (t *BatteryTracker) OnChange(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.listeners = append(t.listeners, fn)
+}
+
+func (t *BatteryTracker) notify() {
+	t.mu.Lock()
+	listeners := append([]func(){}, t.listeners...)
+	t.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// Estimate returns the drain rate and time remaining
+func (t *BatteryTracker) Estimate() BatteryEstimate {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.estimateLocked()
+}
+
+// History returns the estimate with the charge cycle and drain rate history
+func (t *BatteryTracker) History() BatteryHistory {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return BatteryHistory{
+		Estimate:   t.estimateLocked(),
+		Cycles:     append([]ChargeCycle{}, t.cycles...),
+		DrainRates: append([]float64{}, t.drainRates...),
+	}
+}
+
+func (t *BatteryTracker) estimateLocked() BatteryEstimate {
+	estimate := BatteryEstimate{PluggedIn: t.pluggedIn}
+	if t.level == nil || t.pluggedIn {
+		return estimate
+	}
+	if !t.sessionStart.IsZero() {
+		start := t.sessionStart
+		estimate.SessionStartedAt = &start
+		estimate.SessionDrained = t.sessionStartLevel - *t.level
+	}
+
+	rate, ok := t.sessionRateLocked()
+	if !ok {
+		rate, ok = t.averageRateLocked()
+		estimate.Calibrated = ok
+	}
+	if !ok {
+		return estimate
+	}
+	estimate.DrainPerHour = &rate
+	minutes := int(math.Round(float64(*t.level) / rate * 60))
+	estimate.MinutesRemaining = &minutes
+	return estimate
+}
+
+// sessionRateLocked is this session's drain in percent per hour, once it
+// has drained enough to measure
+func (t *BatteryTracker) sessionRateLocked() (float64, bool) {
+	if t.sessionStart.IsZero() || t.level == nil {
+		return 0, false
+	}
+	drained := t.sessionStartLevel - *t.level
+	elapsed := t.clock.Since(t.sessionStart)
+	if drained < minSessionDrain || elapsed < minSessionDuration {
+		return 0, false
+	}
+	return float64(drained) / elapsed.Hours(), true
+}
+
+func (t *BatteryTracker) averageRateLocked() (float64, bool) {
+	if len(t.drainRates) == 0 {
+		return 0, false
+	}
+	sum := 0.0
+	for _, rate := range t.drainRates {
+		sum += rate
+	}
+	return sum / float64(len(t.drainRates)), true
+}
+
+func (t *BatteryTracker) setLevel(level *int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if level == nil {
+		// Disconnected: the session ends, and a charge in progress is
+		// finished at the last level seen
+		t.endSessionLocked()
+		if t.pluggedIn {
+			t.endCycleLocked()
+		}
+		t.level = nil
+		t.pluggedIn = false
+		t.saveLocked()
+		return
+	}
+
+	value := *level
+	t.level = &value
+	if !t.pluggedIn && (t.sessionStart.IsZero() || value > t.sessionStartLevel) {
+		// A rise while on battery means the reading settled; start over
+		t.sessionStart = t.clock.Now()
+		t.sessionStartLevel = value
+	}
+	if t.pluggedIn && len(t.cycles) > 0 && t.cycles[len(t.cycles)-1].EndedAt == nil {
+		t.cycles[len(t.cycles)-1].ToLevel = value
+	}
+}
+
+func (t *BatteryTracker) setCharging(status *int) {
+	pluggedIn := status != nil && (*status == batteryCharging || *status == batteryFull || *status == batteryACPowered)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if pluggedIn == t.pluggedIn {
+		return
+	}
+	t.pluggedIn = pluggedIn
+	if pluggedIn {
+		t.endSessionLocked()
+		level := 0
+		if t.level != nil {
+			level = *t.level
+		}
+		t.cycles = append(t.cycles, ChargeCycle{StartedAt: t.clock.Now(), FromLevel: level, ToLevel: level})
+		if len(t.cycles) > maxChargeCycles {
+			t.cycles = t.cycles[len(t.cycles)-maxChargeCycles:]
+		}
+	} else {
+		t.endCycleLocked()
+		if t.level != nil {
+			t.sessionStart = t.clock.Now()
+			t.sessionStartLevel = *t.level
+		}
+	}
+	t.saveLocked()
+}
+
+// endSessionLocked keeps the session's drain rate for calibration if it ran
+// long enough to measure
+func (t *BatteryTracker) endSessionLocked() {
+	if rate, ok := t.sessionRateLocked(); ok {
+		t.drainRates = append(t.drainRates, rate)
+		if len(t.drainRates) > maxDrainRates {
+			t.drainRates = t.drainRates[len(t.drainRates)-maxDrainRates:]
+		}
+	}
+	t.sessionStart = time.Time{}
+}
+
+func (t *BatteryTracker) endCycleLocked() {
+	if len(t.cycles) == 0 || t.cycles[len(t.cycles)-1].EndedAt != nil {
+		return
+	}
+	now := t.clock.Now()
+	cycle := &t.cycles[len(t.cycles)-1]
+	cycle.EndedAt = &now
+	if t.level != nil {
+		cycle.ToLevel = *t.level
+	}
+}
+
+func (t *BatteryTracker) saveLocked() {
+	if t.path == "" {
+		return
+	}
+	data, err := json.Marshal(savedBattery{Cycles: t.cycles, DrainRates: t.drainRates})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		log.Printf("Failed to save battery history: %v", err)
+		return
+	}
+	if err := fileutil.WriteAtomic(t.path, "", data, 0644); err != nil {
+		log.Printf("Failed to save battery history: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBatteryTracker_EstimatesFromSessionDrain(t *testing.T) {
+	sm := NewStateManager()
+	clock := NewFakeClock(time.Unix(0, 0))
+	tracker := NewBatteryTracker(sm, clock)
+	level := func(value int) {
+		sm.SetBatteryLevel(&value)
+		sm.Flush()
+	}
+	charging := func(value int) {
+		sm.SetBatteryCharging(&value)
+		sm.Flush()
+	}
+
+	level(80)
+	if estimate := tracker.Estimate(); estimate.MinutesRemaining != nil {
+		t.Fatalf("Expected no estimate before any drain, got %d minutes", *estimate.MinutesRemaining)
+	}
+
+	// 10% over an hour leaves 7 hours at 70%
+	clock.Advance(time.Hour)
+	level(70)
+	estimate := tracker.Estimate()
+	if estimate.MinutesRemaining == nil || *estimate.MinutesRemaining != 420 || estimate.Calibrated {
+		t.Fatalf("Expected 420 minutes from this session, got %+v", estimate)
+	}
+	if estimate.SessionDrained != 10 {
+		t.Errorf("Expected 10 points drained, got %d", estimate.SessionDrained)
+	}
+
+	// Plugging in keeps the session's rate and starts a charge cycle
+	charging(2)
+	if estimate := tracker.Estimate(); !estimate.PluggedIn || estimate.MinutesRemaining != nil {
+		t.Errorf("Expected no time remaining while plugged in, got %+v", estimate)
+	}
+	clock.Advance(30 * time.Minute)
+	level(100)
+	charging(1)
+
+	history := tracker.History()
+	if len(history.Cycles) != 1 || history.Cycles[0].FromLevel != 70 || history.Cycles[0].ToLevel != 100 || history.Cycles[0].EndedAt == nil {
+		t.Fatalf("Expected one finished charge from 70 to 100, got %+v", history.Cycles)
+	}
+	if len(history.DrainRates) != 1 || history.DrainRates[0] != 10 {
+		t.Fatalf("Expected the session's 10%% per hour to be kept, got %v", history.DrainRates)
+	}
+
+	// The new session has no drain yet, so the earlier rate is used
+	estimate = tracker.Estimate()
+	if estimate.MinutesRemaining == nil || *estimate.MinutesRemaining != 600 || !estimate.Calibrated {
+		t.Errorf("Expected 600 minutes from earlier sessions, got %+v", estimate)
+	}
+}
+
+func TestBatteryTracker_SavesHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "battery_history.json")
+	sm := NewStateManager()
+	clock := NewFakeClock(time.Unix(0, 0))
+	tracker := NewBatteryTracker(sm, clock)
+	if err := tracker.SetPath(path); err != nil {
+		t.Fatalf("SetPath() error = %v", err)
+	}
+
+	level, status := 50, 2
+	sm.SetBatteryLevel(&level)
+	sm.SetBatteryCharging(&status)
+	sm.SetBatteryLevel(nil)
+	sm.Flush()
+
+	restored := NewBatteryTracker(NewStateManager(), clock)
+	if err := restored.SetPath(path); err != nil {
+		t.Fatalf("SetPath() error = %v", err)
+	}
+	if cycles := restored.History().Cycles; len(cycles) != 1 || cycles[0].FromLevel != 50 || cycles[0].EndedAt == nil {
+		t.Errorf("Expected the charge ended by the disconnect to be restored, got %+v", cycles)
+	}
+}
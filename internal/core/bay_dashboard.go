@@ -0,0 +1,360 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dashboard polling bounds
+const (
+	DefaultDashboardPollSeconds = 5
+	MinDashboardPollSeconds     = 1
+	MaxDashboardPollSeconds     = 300
+	// MaxDashboardBays bounds the other connectors a dashboard polls
+	MaxDashboardBays = 32
+
+	// DefaultDashboardName is what this connector is called on its own
+	// dashboard until it is named
+	DefaultDashboardName = "This bay"
+
+	dashboardRequestTimeout = 3 * time.Second
+)
+
+// DashboardBay is another connector shown on the dashboard. URL is the
+// address of its web server, e.g. http://192.168.1.23:8080; it has to be
+// started with a bind address other ones can reach. Token is one of its
+// access tokens, if it has any; a viewer token is enough.
+type DashboardBay struct {
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+	Token string `json:"token,omitempty"`
+}
+
+// DashboardSettings configures the multi-bay dashboard
+type DashboardSettings struct {
+	Name        string         `json:"name"` // of this connector's bay
+	Bays        []DashboardBay `json:"bays"`
+	PollSeconds int            `json:"pollSeconds"`
+}
+
+// DefaultDashboardSettings returns a dashboard showing only this bay
+func DefaultDashboardSettings() DashboardSettings {
+	return DashboardSettings{Bays: []DashboardBay{}, PollSeconds: DefaultDashboardPollSeconds}
+}
+
+// Valid reports whether every bay has an http or https URL and the poll
+// interval is in range
+func (s DashboardSettings) Valid() bool {
+	if s.PollSeconds < MinDashboardPollSeconds || s.PollSeconds > MaxDashboardPollSeconds || len(s.Bays) > MaxDashboardBays {
+		return false
+	}
+	for _, bay := range s.Bays {
+		u, err := url.Parse(bay.URL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return false
+		}
+	}
+	return true
+}
+
+// BayDevice is the launch monitor in a bay
+type BayDevice struct {
+	ConnectionStatus string     `json:"connectionStatus"`
+	DeviceName       *string    `json:"deviceName"`
+	DeviceType       DeviceType `json:"deviceType"`
+	BatteryLevel     *int       `json:"batteryLevel"`
+	MinutesRemaining *int       `json:"minutesRemaining"` // nil while plugged in or unknown
+	Idle             bool       `json:"idle"`
+	Standby          bool       `json:"standby"`
+	Paused           bool       `json:"paused"`
+	LastError        string     `json:"lastError"`
+}
+
+// BayGSPro is a bay's GSPro connection
+type BayGSPro struct {
+	ConnectionStatus string `json:"connectionStatus"`
+	Player           string `json:"player,omitempty"`
+	LastError        string `json:"lastError"`
+}
+
+// BayShot is the last shot hit in a bay
+type BayShot struct {
+	ID           int       `json:"id"`
+	Timestamp    time.Time `json:"timestamp"`
+	Club         string    `json:"club"`
+	Player       string    `json:"player,omitempty"`
+	BallSpeedMPS float64   `json:"ballSpeedMps"`
+	CarryYards   float64   `json:"carryYards"`
+	OfflineYards float64   `json:"offlineYards"`
+}
+
+// BayStatus is one bay on the dashboard. Device, GSPro and LastShot are from
+// the last successful poll.
+type BayStatus struct {
+	Name      string    `json:"name"`
+	URL       string    `json:"url,omitempty"` // empty for this connector
+	Local     bool      `json:"local"`
+	Reachable bool      `json:"reachable"`
+	Error     string    `json:"error,omitempty"`
+	Device    BayDevice `json:"device"`
+	GSPro     BayGSPro  `json:"gspro"`
+	LastShot  *BayShot  `json:"lastShot"`
+	CheckedAt time.Time `json:"checkedAt"` // zero until first polled
+}
+
+// remoteDeviceStatus and remoteShot are the parts of another connector's
+// API responses the dashboard uses
+type remoteDeviceStatus struct {
+	BayDevice
+	Battery struct {
+		MinutesRemaining *int `json:"minutesRemaining"`
+	} `json:"battery"`
+}
+
+type remoteShot struct {
+	BayShot
+	Ball struct {
+		BallSpeedMPS float64 `json:"speed"`
+	} `json:"ball"`
+}
+
+// BayDashboard polls other connectors' APIs so a facility operator can see
+// every bay from one connector. This connector's own bay comes first, from
+// the local source rather than over HTTP.
+type BayDashboard struct {
+	clock Clock
+	http  *http.Client
+	local func() BayStatus
+	wake  chan struct{}
+
+	mu        sync.Mutex
+	settings  DashboardSettings
+	enabled   bool
+	remote    []BayStatus
+	lastLocal BayStatus // as of the last poll
+	listeners []func([]BayStatus)
+}
+
+// NewBayDashboard creates a dashboard that reports this bay with local
+func NewBayDashboard(clock Clock, local func() BayStatus) *BayDashboard {
+	return &BayDashboard{
+		clock:    clock,
+		http:     &http.Client{Timeout: dashboardRequestTimeout},
+		local:    local,
+		wake:     make(chan struct{}, 1),
+		settings: DefaultDashboardSettings(),
+	}
+}
+
+// Configure sets the bays to poll. Bays whose URL is unchanged keep their
+// last status until the next poll.
+func (d *BayDashboard) Configure(settings DashboardSettings) {
+	d.mu.Lock()
+	previous := make(map[string]BayStatus, len(d.remote))
+	for _, bay := range d.remote {
+		previous[bay.URL] = bay
+	}
+	d.settings = settings
+	d.remote = make([]BayStatus, len(settings.Bays))
+	for i, bay := range settings.Bays {
+		status, ok := previous[bay.URL]
+		if !ok {
+			status = BayStatus{URL: bay.URL}
+		}
+		status.Name = bayName(bay)
+		d.remote[i] = status
+	}
+	d.mu.Unlock()
+	d.signal()
+}
+
+// SetEnabled starts or stops polling
+func (d *BayDashboard) SetEnabled(enabled bool) {
+	d.mu.Lock()
+	d.enabled = enabled
+	d.mu.Unlock()
+	d.signal()
+}
+
+// Bays returns this bay followed by the others in the order configured
+func (d *BayDashboard) Bays() []BayStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.baysLocked()
+}
+
+func (d *BayDashboard) baysLocked() []BayStatus {
+	local := d.local()
+	local.Local = true
+	local.Reachable = true
+	local.Name = d.settings.Name
+	if local.Name == "" {
+		local.Name = DefaultDashboardName
+	}
+	local.CheckedAt = d.clock.Now()
+	return append([]BayStatus{local}, d.remote...)
+}
+
+// OnChange registers a listener called with every bay when a poll finds a
+// bay's status changed
+func (d *BayDashboard) OnChange(listener func([]BayStatus)) {
+	d.mu.Lock()
+	d.listeners = append(d.listeners, listener)
+	d.mu.Unlock()
+}
+
+// Run polls the bays while the dashboard is enabled. It never returns.
+func (d *BayDashboard) Run() {
+	for {
+		d.mu.Lock()
+		ready := d.enabled && len(d.settings.Bays) > 0
+		interval := time.Duration(d.settings.PollSeconds) * time.Second
+		d.mu.Unlock()
+
+		if !ready {
+			<-d.wake
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), interval+dashboardRequestTimeout)
+		d.Refresh(ctx)
+		cancel()
+		select {
+		case <-d.wake:
+		case <-d.clock.After(interval):
+		}
+	}
+}
+
+// Refresh polls every bay at once and tells the listeners if any changed,
+// this one included
+func (d *BayDashboard) Refresh(ctx context.Context) {
+	d.mu.Lock()
+	bays := append([]DashboardBay(nil), d.settings.Bays...)
+	d.mu.Unlock()
+
+	results := make([]BayStatus, len(bays))
+	var wg sync.WaitGroup
+	for i, bay := range bays {
+		wg.Add(1)
+		go func(i int, bay DashboardBay) {
+			defer wg.Done()
+			results[i] = d.poll(ctx, bay)
+		}(i, bay)
+	}
+	wg.Wait()
+
+	d.mu.Lock()
+	changed := false
+	for i, result := range results {
+		// The bays may have been reconfigured during the poll
+		if i >= len(d.remote) || d.remote[i].URL != result.URL {
+			continue
+		}
+		if !result.Reachable {
+			// Keep showing what was last seen alongside the error
+			result.Device, result.GSPro, result.LastShot = d.remote[i].Device, d.remote[i].GSPro, d.remote[i].LastShot
+		}
+		if !sameBayStatus(d.remote[i], result) {
+			changed = true
+		}
+		d.remote[i] = result
+	}
+	all := d.baysLocked()
+	if !sameBayStatus(d.lastLocal, all[0]) {
+		changed = true
+	}
+	d.lastLocal = all[0]
+	var listeners []func([]BayStatus)
+	if changed {
+		listeners = append(listeners, d.listeners...)
+	}
+	d.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(all)
+	}
+}
+
+// poll fetches one bay's device and GSPro status and its last shot
+func (d *BayDashboard) poll(ctx context.Context, bay DashboardBay) BayStatus {
+	status := BayStatus{Name: bayName(bay), URL: bay.URL, CheckedAt: d.clock.Now()}
+	base := strings.TrimRight(bay.URL, "/") + "/api/v1"
+
+	var device remoteDeviceStatus
+	var gspro BayGSPro
+	var shots []remoteShot
+	for _, request := range []struct {
+		path string
+		into interface{}
+	}{
+		{"/device/status", &device},
+		{"/gspro/status", &gspro},
+		{"/shots?limit=1", &shots},
+	} {
+		if err := d.getJSON(ctx, base+request.path, bay.Token, request.into); err != nil {
+			status.Error = err.Error()
+			return status
+		}
+	}
+
+	status.Reachable = true
+	status.Device = device.BayDevice
+	status.Device.MinutesRemaining = device.Battery.MinutesRemaining
+	status.GSPro = gspro
+	if len(shots) > 0 {
+		shot := shots[len(shots)-1]
+		shot.BayShot.BallSpeedMPS = shot.Ball.BallSpeedMPS
+		status.LastShot = &shot.BayShot
+	}
+	return status
+}
+
+func (d *BayDashboard) getJSON(ctx context.Context, url, token string, into interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
+
+func (d *BayDashboard) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// bayName is the bay's name, or its host if it wasn't named
+func bayName(bay DashboardBay) string {
+	if name := strings.TrimSpace(bay.Name); name != "" {
+		return name
+	}
+	if u, err := url.Parse(bay.URL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return bay.URL
+}
+
+// sameBayStatus compares two statuses, ignoring when they were checked
+func sameBayStatus(a, b BayStatus) bool {
+	a.CheckedAt, b.CheckedAt = time.Time{}, time.Time{}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDashboardSettings_Valid(t *testing.T) {
+	settings := DefaultDashboardSettings()
+	if !settings.Valid() {
+		t.Fatal("Expected the default settings to be valid")
+	}
+	settings.Bays = []DashboardBay{{Name: "Bay 2", URL: "192.168.1.12:8080"}}
+	if settings.Valid() {
+		t.Error("Expected a URL without a scheme to be rejected")
+	}
+	settings.Bays[0].URL = "http://192.168.1.12:8080"
+	settings.PollSeconds = 0
+	if settings.Valid() {
+		t.Error("Expected a zero poll interval to be rejected")
+	}
+}
+
+func TestBayDashboard_PollsOtherConnectors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/device/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"connectionStatus":"connected","batteryLevel":80,"battery":{"minutesRemaining":240},"idle":true}`))
+	})
+	mux.HandleFunc("/api/v1/gspro/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"connectionStatus":"connected","player":"Sam"}`))
+	})
+	mux.HandleFunc("/api/v1/shots", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "1" {
+			t.Errorf("Expected only the last shot to be asked for, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[{"id":7,"club":"Driver","ball":{"speed":70.5},"carryYards":250}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	clock := NewFakeClock(time.Unix(0, 0))
+	dashboard := NewBayDashboard(clock, func() BayStatus {
+		return BayStatus{Device: BayDevice{ConnectionStatus: "disconnected"}}
+	})
+	dashboard.Configure(DashboardSettings{
+		Name:        "Bay 1",
+		Bays:        []DashboardBay{{URL: server.URL}, {Name: "Bay 3", URL: "http://127.0.0.1:1"}},
+		PollSeconds: DefaultDashboardPollSeconds,
+	})
+	var notified [][]BayStatus
+	dashboard.OnChange(func(bays []BayStatus) {
+		notified = append(notified, bays)
+	})
+
+	dashboard.Refresh(context.Background())
+	bays := dashboard.Bays()
+	if len(bays) != 3 || !bays[0].Local || bays[0].Name != "Bay 1" {
+		t.Fatalf("Bays() = %+v, want this bay first", bays)
+	}
+	bay2 := bays[1]
+	if !bay2.Reachable || bay2.Name != server.Listener.Addr().String() {
+		t.Fatalf("Bay 2 = %+v, want it reachable and named after its host", bay2)
+	}
+	if *bay2.Device.BatteryLevel != 80 || *bay2.Device.MinutesRemaining != 240 || !bay2.Device.Idle || bay2.GSPro.Player != "Sam" {
+		t.Errorf("Bay 2 status = %+v", bay2)
+	}
+	if bay2.LastShot == nil || bay2.LastShot.ID != 7 || bay2.LastShot.BallSpeedMPS != 70.5 || bay2.LastShot.CarryYards != 250 {
+		t.Errorf("Bay 2 last shot = %+v, want shot 7", bay2.LastShot)
+	}
+	if bays[2].Reachable || bays[2].Error == "" || bays[2].Name != "Bay 3" {
+		t.Errorf("Bay 3 = %+v, want it unreachable with an error", bays[2])
+	}
+
+	// Nothing changed, so the listeners aren't told again
+	clock.Advance(time.Minute)
+	dashboard.Refresh(context.Background())
+	if len(notified) != 1 {
+		t.Errorf("Expected one change notification, got %d", len(notified))
+	}
+}
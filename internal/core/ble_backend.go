@@ -0,0 +1,60 @@
+package core
+
+import "fmt"
+
+// BLEBackend selects the library used to talk to real Bluetooth hardware
+type BLEBackend string
+
+const (
+	// BLEBackendTinyGo uses tinygo-org/bluetooth on every platform
+	BLEBackendTinyGo BLEBackend = "tinygo"
+	// BLEBackendBlueZ talks to BlueZ directly over D-Bus. Linux only; it
+	// supports turning notifications off and copes with adapters where the
+	// tinygo backend has trouble connecting.
+	BLEBackendBlueZ BLEBackend = "bluez"
+)
+
+// ParseBLEBackend validates a backend name. An empty name is BLEBackendTinyGo.
+func ParseBLEBackend(name string) (BLEBackend, error) {
+	switch backend := BLEBackend(name); backend {
+	case "":
+		return BLEBackendTinyGo, nil
+	case BLEBackendTinyGo, BLEBackendBlueZ:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("unknown BLE backend %q", name)
+	}
+}
+
+// NewBluetoothClient creates a client for real hardware using backend
+func NewBluetoothClient(backend BLEBackend) (BluetoothClient, error) {
+	// Return a nil interface rather than a nil client on failure
+	switch backend {
+	case BLEBackendBlueZ:
+		client, err := NewBlueZBluetoothClient()
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case BLEBackendTinyGo, "":
+		client, err := NewTinyGoBluetoothClient()
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unknown BLE backend %q", backend)
+	}
+}
+
+// phaseReporter is implemented by clients that report scanning and connecting
+// while Connect runs
+type phaseReporter interface {
+	SetPhaseChangeCallback(callback func(ConnectionPhase))
+}
+
+// connectionLossReporter is implemented by clients that notice the link
+// dropping without Disconnect being called
+type connectionLossReporter interface {
+	SetConnectionLostCallback(callback func())
+}
@@ -0,0 +1,36 @@
+package core
+
+import "testing"
+
+func TestParseBLEBackend(t *testing.T) {
+	if backend, err := ParseBLEBackend(""); err != nil || backend != BLEBackendTinyGo {
+		t.Errorf("ParseBLEBackend(\"\") = %q, %v, want tinygo", backend, err)
+	}
+	if backend, err := ParseBLEBackend("bluez"); err != nil || backend != BLEBackendBlueZ {
+		t.Errorf("ParseBLEBackend(\"bluez\") = %q, %v, want bluez", backend, err)
+	}
+	if _, err := ParseBLEBackend("bleak"); err == nil {
+		t.Error("Expected an unknown backend to be rejected")
+	}
+}
+
+func TestNewBluetoothClient_UnknownBackend(t *testing.T) {
+	client, err := NewBluetoothClient("bleak")
+	if err == nil || client != nil {
+		t.Errorf("NewBluetoothClient() = %v, %v, want an error and no client", client, err)
+	}
+}
+
+func TestSetClient_RegistersConnectionLossCallback(t *testing.T) {
+	sm := NewStateManager()
+	bm := NewBluetoothManager(sm)
+	sim := NewSimulatorBluetoothClient(SimulatorConfig{})
+
+	bm.SetClient(sim)
+	sim.lock.RLock()
+	registered := sim.onConnectionLost != nil
+	sim.lock.RUnlock()
+	if !registered {
+		t.Error("Expected the manager to watch the simulator for dropped connections")
+	}
+}
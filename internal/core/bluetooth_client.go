@@ -3,6 +3,7 @@ package core
 import (
 	"fmt"
 	"log"
+	"sync"
 )
 
 // BluetoothClient interface defines the methods required for BLE communication
@@ -29,6 +30,7 @@ type WriteHistory struct {
 
 // MockBluetoothClient implements BluetoothClient interface for testing
 type MockBluetoothClient struct {
+	mu             sync.Mutex // guards connected and the writes, which come from the command queue
 	connected      bool
 	writeCalled    bool
 	readCalled     bool
@@ -36,11 +38,14 @@ type MockBluetoothClient struct {
 	lastWriteUUID  string
 	lastReadUUID   string
 	readReturnData []byte
+	readByUUID     map[string][]byte // Per-characteristic read data
 	readError      error
 	writeError     error
-	writeCount     int            // Track number of writes
-	writeHistory   []WriteHistory // Track history of all writes
-	deviceName     string         // Store the connected device name
+	failWrites     int               // Number of upcoming writes to fail
+	writeCount     int               // Track number of writes
+	writeHistory   []WriteHistory    // Track history of all writes
+	deviceName     string            // Store the connected device name
+	writeNotify    chan WriteHistory // Receives each write, see NotifyWrites
 }
 
 // NewMockBluetoothClient creates a new mock Bluetooth client
@@ -54,6 +59,8 @@ func NewMockBluetoothClient() *MockBluetoothClient {
 
 // Connect simulates connecting to a device
 func (m *MockBluetoothClient) Connect(deviceName, deviceAddress string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.connected = true
 	m.deviceName = deviceName
 	return nil
@@ -61,12 +68,16 @@ func (m *MockBluetoothClient) Connect(deviceName, deviceAddress string) error {
 
 // Disconnect simulates disconnecting from a device
 func (m *MockBluetoothClient) Disconnect() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.connected = false
 	return nil
 }
 
 // WriteCharacteristic simulates writing to a characteristic
 func (m *MockBluetoothClient) WriteCharacteristic(uuid string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if !m.connected {
 		return fmt.Errorf("not connected")
 	}
@@ -76,12 +87,20 @@ func (m *MockBluetoothClient) WriteCharacteristic(uuid string, data []byte) erro
 	m.writeCount++
 
 	// Add to write history
-	m.writeHistory = append(m.writeHistory, WriteHistory{
-		UUID: uuid,
-		Data: data,
-	})
+	write := WriteHistory{UUID: uuid, Data: data}
+	m.writeHistory = append(m.writeHistory, write)
+	if m.writeNotify != nil {
+		select {
+		case m.writeNotify <- write:
+		default:
+		}
+	}
 
 	log.Printf("Mock write to %s: %x", uuid, data)
+	if m.failWrites > 0 {
+		m.failWrites--
+		return fmt.Errorf("mock write failure")
+	}
 	return m.writeError
 }
 
@@ -92,6 +111,9 @@ func (m *MockBluetoothClient) ReadCharacteristic(uuid string) ([]byte, error) {
 	}
 	m.readCalled = true
 	m.lastReadUUID = uuid
+	if data, ok := m.readByUUID[uuid]; ok {
+		return data, m.readError
+	}
 	if m.readReturnData != nil {
 		return m.readReturnData, m.readError
 	}
@@ -121,16 +143,30 @@ func (m *MockBluetoothClient) StopNotifications(uuid string) error {
 
 // IsConnected returns the connection status
 func (m *MockBluetoothClient) IsConnected() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.connected
 }
 
-// GetWriteHistory returns the complete history of write operations
+// GetWriteHistory returns a copy of the history of write operations
 func (m *MockBluetoothClient) GetWriteHistory() []WriteHistory {
-	return m.writeHistory
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]WriteHistory(nil), m.writeHistory...)
+}
+
+// NotifyWrites returns a channel that receives the writes made from now on
+func (m *MockBluetoothClient) NotifyWrites() <-chan WriteHistory {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.writeNotify = make(chan WriteHistory, 64)
+	return m.writeNotify
 }
 
 // ClearWriteHistory clears the write history
 func (m *MockBluetoothClient) ClearWriteHistory() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.writeHistory = make([]WriteHistory, 0)
 }
 
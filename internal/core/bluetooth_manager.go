@@ -6,28 +6,17 @@ import (
 	"fmt"
 	"log"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 )
 
-var (
-	bluetoothInstance *BluetoothManager
-	bluetoothOnce     sync.Once
-)
-
-// GetBluetoothInstance returns the singleton instance of BluetoothManager
-func GetBluetoothInstance(stateManager *StateManager) *BluetoothManager {
-	bluetoothOnce.Do(func() {
-		bluetoothInstance = &BluetoothManager{
-			stateManager: stateManager,
-		}
-	})
-	return bluetoothInstance
-}
-
-// NewBluetoothManager is deprecated, use GetBluetoothInstance instead
+// NewBluetoothManager creates a BluetoothManager reporting to stateManager
 func NewBluetoothManager(stateManager *StateManager) *BluetoothManager {
-	return GetBluetoothInstance(stateManager)
+	return &BluetoothManager{
+		stateManager: stateManager,
+		clock:        RealClock(),
+	}
 }
 
 // BluetoothManager is responsible for handling Bluetooth connection logic
@@ -41,6 +30,28 @@ type BluetoothManager struct {
 	connectMutex        sync.Mutex
 	connecting          bool
 	preDisconnectHook   func() // Hook to run before disconnecting
+	lastDeviceName      string
+	lastDeviceAddress   string
+	clock               Clock
+	backend             BLEBackend
+	bonded              BondedDevice
+	deviceTypeHint      DeviceType
+	onDeviceIdentified  func(DeviceType)
+}
+
+// reconnectDelay is how long to wait after an unexpected drop before
+// reconnecting to the same device
+const reconnectDelay = 2 * time.Second
+
+// SetClock replaces the clock used to schedule reconnects
+func (bm *BluetoothManager) SetClock(clock Clock) {
+	bm.clock = clock
+}
+
+// SetBackend selects the backend used if the Bluetooth client has to be
+// created again
+func (bm *BluetoothManager) SetBackend(backend BLEBackend) {
+	bm.backend = backend
 }
 
 // GetClient returns the current Bluetooth client
@@ -56,8 +67,8 @@ func (bm *BluetoothManager) SetClient(client BluetoothClient) {
 
 // setupPhaseCallback sets up the phase change callback on the Bluetooth client
 func (bm *BluetoothManager) setupPhaseCallback() {
-	if tinyGoClient, ok := bm.bluetoothClient.(*TinyGoBluetoothClient); ok {
-		tinyGoClient.SetPhaseChangeCallback(func(phase ConnectionPhase) {
+	if reporter, ok := bm.bluetoothClient.(phaseReporter); ok {
+		reporter.SetPhaseChangeCallback(func(phase ConnectionPhase) {
 			switch phase {
 			case PhaseScanning:
 				bm.stateManager.SetConnectionStatus(ConnectionStatusScanning)
@@ -66,6 +77,34 @@ func (bm *BluetoothManager) setupPhaseCallback() {
 			}
 		})
 	}
+	if reporter, ok := bm.bluetoothClient.(connectionLossReporter); ok {
+		reporter.SetConnectionLostCallback(bm.handleConnectionLost)
+	}
+}
+
+// handleConnectionLost marks the device disconnected after an unexpected drop
+// and reconnects to it. Notifications and device state are restored by the
+// normal connection path.
+func (bm *BluetoothManager) handleConnectionLost() {
+	log.Println("BluetoothManager: Connection lost, reconnecting")
+	bm.stateManager.SetConnectionStatus(ConnectionStatusDisconnected)
+
+	// Cancelling or disconnecting during the delay stops the reconnect
+	bm.connectionMutex.Lock()
+	deviceName, deviceAddress := bm.lastDeviceName, bm.lastDeviceAddress
+	ctx, cancel := context.WithCancel(context.Background())
+	bm.currentCtx = ctx
+	bm.currentCancelFunc = cancel
+	bm.connectionMutex.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-bm.clock.After(reconnectDelay):
+		}
+		bm.StartBluetoothConnection(deviceName, deviceAddress)
+	}()
 }
 
 // StartBluetoothConnection starts the Bluetooth connection in a background goroutine
@@ -74,6 +113,12 @@ func (bm *BluetoothManager) StartBluetoothConnection(deviceName, deviceAddress s
 	defer bm.connectionMutex.Unlock()
 
 	log.Printf("BluetoothManager: Starting connection to device: %s", deviceName)
+	if deviceAddress == "" {
+		// A paired device is found by address without waiting for its name
+		deviceAddress = bm.bondedAddressFor(deviceName)
+	}
+	bm.lastDeviceName = deviceName
+	bm.lastDeviceAddress = deviceAddress
 
 	// Cancel any existing connection attempt
 	if bm.currentCancelFunc != nil {
@@ -88,8 +133,7 @@ func (bm *BluetoothManager) StartBluetoothConnection(deviceName, deviceAddress s
 		// Add a small delay before reinitialization to ensure resources are fully released
 		time.Sleep(500 * time.Millisecond)
 
-		// We'll create a real TinyGo client since that's what was used in the logs
-		bleClient, err := NewTinyGoBluetoothClient()
+		bleClient, err := NewBluetoothClient(bm.backend)
 		if err != nil {
 			log.Printf("BluetoothManager: Failed to initialize Bluetooth client: %v", err)
 			bm.stateManager.SetLastError(fmt.Errorf("Failed to initialize Bluetooth: %v", err))
@@ -163,13 +207,7 @@ func (bm *BluetoothManager) CancelBluetoothConnection() {
 // DisconnectBluetooth disconnects from the current device
 func (bm *BluetoothManager) DisconnectBluetooth() {
 	// First, cancel any in-progress connection attempt
-	bm.connectionMutex.Lock()
-	if bm.currentCancelFunc != nil {
-		log.Println("BluetoothManager: Cancelling in-progress connection attempt")
-		bm.currentCancelFunc()
-		bm.currentCancelFunc = nil
-	}
-	bm.connectionMutex.Unlock()
+	bm.cancelConnectionAttempt()
 
 	go func() {
 		defer func() {
@@ -185,18 +223,42 @@ func (bm *BluetoothManager) DisconnectBluetooth() {
 			bm.preDisconnectHook()
 		}
 
-		err := bm.disconnectDevice()
-		if err != nil {
+		if err := bm.disconnectAndReset(); err != nil {
 			log.Printf("Error disconnecting bluetooth: %v", err)
 		}
-
-		// Reset states
-		bm.stateManager.SetConnectionStatus(ConnectionStatusDisconnected)
-		bm.stateManager.SetBatteryLevel(nil)
-		bm.stateManager.SetDeviceDisplayName(nil)
 	}()
 }
 
+// Shutdown cancels any connection attempt and disconnects from the device,
+// returning once the disconnect has finished. The pre-disconnect hook is not
+// run; the launch monitor is expected to have been shut down first.
+func (bm *BluetoothManager) Shutdown() error {
+	bm.cancelConnectionAttempt()
+	return bm.disconnectAndReset()
+}
+
+// cancelConnectionAttempt cancels an in-progress connection or reconnect
+func (bm *BluetoothManager) cancelConnectionAttempt() {
+	bm.connectionMutex.Lock()
+	defer bm.connectionMutex.Unlock()
+	if bm.currentCancelFunc != nil {
+		log.Println("BluetoothManager: Cancelling in-progress connection attempt")
+		bm.currentCancelFunc()
+		bm.currentCancelFunc = nil
+	}
+}
+
+// disconnectAndReset disconnects from the device and clears the device state
+func (bm *BluetoothManager) disconnectAndReset() error {
+	err := bm.disconnectDevice()
+
+	// Reset states
+	bm.stateManager.SetConnectionStatus(ConnectionStatusDisconnected)
+	bm.stateManager.SetBatteryLevel(nil)
+	bm.stateManager.SetDeviceDisplayName(nil)
+	return err
+}
+
 // SetNotificationHandler sets the handler for BLE notifications
 func (bm *BluetoothManager) SetNotificationHandler(handler func(uuid string, data []byte)) {
 	bm.notificationHandler = handler
@@ -343,15 +405,19 @@ func (bm *BluetoothManager) connectDevice(ctx context.Context, deviceName, devic
 		return fmt.Errorf("failed to connect to device: %w", err)
 	}
 
-	// Get the actual connected device name from the client
+	// Get the actual connected device name from the client. It isn't known
+	// when the client connected by address without scanning.
 	connectedDeviceName := bm.bluetoothClient.GetConnectedDeviceName()
+	if connectedDeviceName == "" {
+		connectedDeviceName = deviceName
+	}
 	log.Printf("BluetoothManager: Successfully connected to device: %s", connectedDeviceName)
 
 	// Update state with device name
 	bm.stateManager.SetDeviceDisplayName(&connectedDeviceName)
 
 	mfgData := bm.bluetoothClient.GetConnectedDeviceManufacturerData()
-	deviceType := DetectDeviceType(mfgData)
+	deviceType := bm.detectDeviceType(mfgData)
 	bm.stateManager.SetDeviceType(deviceType)
 	log.Printf("BluetoothManager: Detected device type: %s", deviceType)
 
@@ -380,6 +446,12 @@ func (bm *BluetoothManager) connectDevice(ctx context.Context, deviceName, devic
 		log.Printf("BluetoothManager: Firmware version: %s", firmwareVersion)
 	}
 
+	// Fill in any versions the firmware version read missed from the standard
+	// Device Information Service
+	if _, err := bm.ReadDeviceInformation(); err != nil {
+		log.Printf("BluetoothManager: Could not read device information: %v", err)
+	}
+
 	// Enable notifications
 	err = bm.EnableNotifications()
 	if err != nil {
@@ -393,6 +465,55 @@ func (bm *BluetoothManager) connectDevice(ctx context.Context, deviceName, devic
 	return nil
 }
 
+// DeviceInformation holds the strings published by the GATT Device
+// Information Service. Characteristics the device does not expose are empty.
+type DeviceInformation struct {
+	ModelNumber      string
+	SerialNumber     string
+	FirmwareRevision string
+	HardwareRevision string
+	SoftwareRevision string
+}
+
+// ReadDeviceInformation reads the Device Information Service and fills any
+// version fields in the state manager that are still empty: firmware from the
+// firmware revision, launcher from the software revision and MMI from the
+// hardware revision.
+func (bm *BluetoothManager) ReadDeviceInformation() (DeviceInformation, error) {
+	if bm.bluetoothClient == nil || !bm.bluetoothClient.IsConnected() {
+		return DeviceInformation{}, fmt.Errorf("not connected to device")
+	}
+
+	readString := func(uuid string) string {
+		data, err := bm.bluetoothClient.ReadCharacteristic(uuid)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimRight(string(data), "\x00 ")
+	}
+
+	info := DeviceInformation{
+		ModelNumber:      readString(DISModelNumberCharUUID),
+		SerialNumber:     readString(DISSerialNumberCharUUID),
+		FirmwareRevision: readString(DISFirmwareRevisionCharUUID),
+		HardwareRevision: readString(DISHardwareRevisionCharUUID),
+		SoftwareRevision: readString(DISSoftwareRevisionCharUUID),
+	}
+	log.Printf("BluetoothManager: Device information - Model: %q, Serial: %q, Firmware: %q, Hardware: %q, Software: %q",
+		info.ModelNumber, info.SerialNumber, info.FirmwareRevision, info.HardwareRevision, info.SoftwareRevision)
+
+	fillIfEmpty := func(current *string, value string, set func(*string)) {
+		if value != "" && (current == nil || *current == "") {
+			set(&value)
+		}
+	}
+	fillIfEmpty(bm.stateManager.GetFirmwareVersion(), info.FirmwareRevision, bm.stateManager.SetFirmwareVersion)
+	fillIfEmpty(bm.stateManager.GetLauncherVersion(), info.SoftwareRevision, bm.stateManager.SetLauncherVersion)
+	fillIfEmpty(bm.stateManager.GetMMIVersion(), info.HardwareRevision, bm.stateManager.SetMMIVersion)
+
+	return info, nil
+}
+
 // disconnectDevice disconnects from the BLE device
 func (bm *BluetoothManager) disconnectDevice() error {
 	if bm.bluetoothClient == nil {
@@ -0,0 +1,806 @@
+//go:build linux
+
+package core
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// BlueZ D-Bus names
+const (
+	bluezService               = "org.bluez"
+	bluezAdapterInterface      = "org.bluez.Adapter1"
+	bluezDeviceInterface       = "org.bluez.Device1"
+	bluezCharInterface         = "org.bluez.GattCharacteristic1"
+	dbusPropertiesInterface    = "org.freedesktop.DBus.Properties"
+	dbusObjectManagerInterface = "org.freedesktop.DBus.ObjectManager"
+)
+
+const (
+	// bluezFindTimeout is how long Connect scans for a device it hasn't seen
+	bluezFindTimeout = 10 * time.Second
+	// bluezConnectTimeout bounds the connection and service discovery
+	bluezConnectTimeout = 30 * time.Second
+	// bluezPairTimeout bounds pairing, which may wait for the device to
+	// accept
+	bluezPairTimeout = 30 * time.Second
+)
+
+// bluezObjects is the result of ObjectManager.GetManagedObjects
+type bluezObjects map[dbus.ObjectPath]map[string]map[string]dbus.Variant
+
+// bluezDevice is a device seen by the adapter
+type bluezDevice struct {
+	path             dbus.ObjectPath
+	name             string
+	address          string
+	manufacturerData map[uint16][]byte
+}
+
+// update applies Device1 properties, which arrive in full when the device
+// is first seen and one at a time as they change
+func (d *bluezDevice) update(props map[string]dbus.Variant) {
+	if name, ok := props["Name"].Value().(string); ok {
+		d.name = name
+	}
+	if address, ok := props["Address"].Value().(string); ok {
+		d.address = address
+	}
+	if data, ok := props["ManufacturerData"].Value().(map[uint16]dbus.Variant); ok {
+		d.manufacturerData = make(map[uint16][]byte, len(data))
+		for company, value := range data {
+			if bytes, ok := value.Value().([]byte); ok {
+				d.manufacturerData[company] = bytes
+			}
+		}
+	}
+}
+
+// manufacturerDataHex returns the manufacturer data as hex, using the lowest
+// company ID if there are several
+func (d *bluezDevice) manufacturerDataHex() string {
+	if len(d.manufacturerData) == 0 {
+		return ""
+	}
+	companies := make([]uint16, 0, len(d.manufacturerData))
+	for company := range d.manufacturerData {
+		companies = append(companies, company)
+	}
+	sort.Slice(companies, func(i, j int) bool { return companies[i] < companies[j] })
+	return hex.EncodeToString(d.manufacturerData[companies[0]])
+}
+
+// matches reports whether the device is the one Connect was asked for. With
+// neither a name nor an address, any SquareGolf device matches.
+func (d *bluezDevice) matches(targetName, targetAddress string) bool {
+	return (targetName != "" && d.name == targetName) ||
+		(targetAddress != "" && strings.EqualFold(d.address, targetAddress)) ||
+		(targetName == "" && targetAddress == "" && strings.HasPrefix(d.name, BluetoothDevicePrefix))
+}
+
+// BlueZBluetoothClient implements BluetoothClient by talking to BlueZ over
+// D-Bus
+type BlueZBluetoothClient struct {
+	conn    *dbus.Conn
+	adapter dbus.ObjectPath
+	signals chan *dbus.Signal
+
+	// connectMutex lets one Connect run at a time without holding mutex
+	// while it waits for the device
+	connectMutex sync.Mutex
+
+	mutex                sync.Mutex
+	device               *bluezDevice
+	connected            bool
+	characteristics      map[string]dbus.ObjectPath
+	notificationHandlers map[string]func([]byte)
+	onPhaseChange        func(ConnectionPhase)
+	onConnectionLost     func()
+	// connectedPath is the connected device's path, so signals for other
+	// devices are dropped without taking mutex
+	connectedPath atomic.Value
+
+	scanMutex   sync.Mutex
+	scanning    bool
+	scanPrefix  string
+	scanResults map[dbus.ObjectPath]*bluezDevice // every device seen, matching the prefix or not
+}
+
+// NewBlueZBluetoothClient connects to BlueZ on the system bus and uses the
+// first Bluetooth adapter
+func NewBlueZBluetoothClient() (*BlueZBluetoothClient, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the system bus: %w", err)
+	}
+
+	c := &BlueZBluetoothClient{
+		conn:                 conn,
+		signals:              make(chan *dbus.Signal, 64),
+		characteristics:      make(map[string]dbus.ObjectPath),
+		notificationHandlers: make(map[string]func([]byte)),
+		scanResults:          make(map[dbus.ObjectPath]*bluezDevice),
+	}
+	c.connectedPath.Store(dbus.ObjectPath(""))
+
+	c.adapter, err = c.findAdapter()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	log.Printf("BlueZ: Using adapter %s", c.adapter)
+
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchInterface(dbusObjectManagerInterface),
+	); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to watch for devices: %w", err)
+	}
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchInterface(dbusPropertiesInterface),
+		dbus.WithMatchMember("PropertiesChanged"),
+		dbus.WithMatchPathNamespace(c.adapter),
+	); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to watch for device changes: %w", err)
+	}
+	conn.Signal(c.signals)
+	go c.watchSignals()
+
+	return c, nil
+}
+
+// findAdapter returns the first adapter, powering it on if needed
+func (c *BlueZBluetoothClient) findAdapter() (dbus.ObjectPath, error) {
+	objects, err := c.managedObjects()
+	if err != nil {
+		return "", err
+	}
+
+	var adapters []dbus.ObjectPath
+	for path, interfaces := range objects {
+		if _, ok := interfaces[bluezAdapterInterface]; ok {
+			adapters = append(adapters, path)
+		}
+	}
+	if len(adapters) == 0 {
+		return "", errors.New("no Bluetooth adapter found")
+	}
+	sort.Slice(adapters, func(i, j int) bool { return adapters[i] < adapters[j] })
+	adapter := adapters[0]
+
+	if powered, _ := objects[adapter][bluezAdapterInterface]["Powered"].Value().(bool); !powered {
+		log.Printf("BlueZ: Powering on adapter %s", adapter)
+		if err := c.conn.Object(bluezService, adapter).SetProperty(bluezAdapterInterface+".Powered", dbus.MakeVariant(true)); err != nil {
+			return "", fmt.Errorf("failed to power on Bluetooth adapter: %w", err)
+		}
+	}
+	return adapter, nil
+}
+
+func (c *BlueZBluetoothClient) managedObjects() (bluezObjects, error) {
+	var objects bluezObjects
+	err := c.conn.Object(bluezService, "/").Call(dbusObjectManagerInterface+".GetManagedObjects", 0).Store(&objects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BlueZ objects: %w", err)
+	}
+	return objects, nil
+}
+
+// knownDevices returns the devices BlueZ already knows about on our adapter
+func (c *BlueZBluetoothClient) knownDevices() ([]*bluezDevice, error) {
+	objects, err := c.managedObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []*bluezDevice
+	for path, interfaces := range objects {
+		props, ok := interfaces[bluezDeviceInterface]
+		if !ok {
+			continue
+		}
+		if adapter, _ := props["Adapter"].Value().(dbus.ObjectPath); adapter != c.adapter {
+			continue
+		}
+		device := &bluezDevice{path: path}
+		device.update(props)
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// watchSignals routes BlueZ signals until the bus connection closes
+func (c *BlueZBluetoothClient) watchSignals() {
+	for signal := range c.signals {
+		switch signal.Name {
+		case dbusObjectManagerInterface + ".InterfacesAdded":
+			if len(signal.Body) < 2 {
+				continue
+			}
+			path, _ := signal.Body[0].(dbus.ObjectPath)
+			interfaces, _ := signal.Body[1].(map[string]map[string]dbus.Variant)
+			if props, ok := interfaces[bluezDeviceInterface]; ok {
+				c.recordScanResult(path, props)
+			}
+		case dbusObjectManagerInterface + ".InterfacesRemoved":
+			// Removing a paired device drops its connection
+			if len(signal.Body) > 0 {
+				path, _ := signal.Body[0].(dbus.ObjectPath)
+				c.handleConnectionLost(path)
+			}
+		case dbusPropertiesInterface + ".PropertiesChanged":
+			if len(signal.Body) < 2 {
+				continue
+			}
+			iface, _ := signal.Body[0].(string)
+			changed, _ := signal.Body[1].(map[string]dbus.Variant)
+			switch iface {
+			case bluezDeviceInterface:
+				c.recordScanResult(signal.Path, changed)
+				if connected, ok := changed["Connected"].Value().(bool); ok && !connected {
+					c.handleConnectionLost(signal.Path)
+				}
+			case bluezCharInterface:
+				if value, ok := changed["Value"].Value().([]byte); ok {
+					c.handleNotification(signal.Path, value)
+				}
+			}
+		}
+	}
+}
+
+// recordScanResult adds or updates a device while a scan is running
+func (c *BlueZBluetoothClient) recordScanResult(path dbus.ObjectPath, props map[string]dbus.Variant) {
+	if !strings.HasPrefix(string(path), string(c.adapter)+"/") {
+		return
+	}
+
+	c.scanMutex.Lock()
+	defer c.scanMutex.Unlock()
+	if !c.scanning {
+		return
+	}
+
+	device, ok := c.scanResults[path]
+	if !ok {
+		device = &bluezDevice{path: path}
+		c.scanResults[path] = device
+	}
+	matched := c.matchesScanPrefix(device)
+	device.update(props)
+	if !matched && c.matchesScanPrefix(device) {
+		log.Printf("Scan found matching device: %s [%s]", device.name, device.address)
+	}
+}
+
+// matchesScanPrefix reports whether a named device matches the scan prefix
+func (c *BlueZBluetoothClient) matchesScanPrefix(device *bluezDevice) bool {
+	return device.name != "" && strings.HasPrefix(device.name, c.scanPrefix)
+}
+
+// handleNotification passes a characteristic value change to its handler
+func (c *BlueZBluetoothClient) handleNotification(path dbus.ObjectPath, value []byte) {
+	c.mutex.Lock()
+	var handler func([]byte)
+	for uuid, charPath := range c.characteristics {
+		if charPath == path {
+			handler = c.notificationHandlers[uuid]
+			break
+		}
+	}
+	c.mutex.Unlock()
+
+	if handler != nil {
+		dataCopy := make([]byte, len(value))
+		copy(dataCopy, value)
+		handler(dataCopy)
+	}
+}
+
+// SetPhaseChangeCallback sets a callback to be notified of connection phase changes
+func (c *BlueZBluetoothClient) SetPhaseChangeCallback(callback func(ConnectionPhase)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.onPhaseChange = callback
+}
+
+// notifyPhaseChange notifies the callback of a phase change if set
+func (c *BlueZBluetoothClient) notifyPhaseChange(phase ConnectionPhase) {
+	c.mutex.Lock()
+	callback := c.onPhaseChange
+	c.mutex.Unlock()
+
+	if callback != nil {
+		callback(phase)
+	}
+}
+
+// SetConnectionLostCallback sets a callback to be notified when the link to the
+// device drops without Disconnect being called
+func (c *BlueZBluetoothClient) SetConnectionLostCallback(callback func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.onConnectionLost = callback
+}
+
+// handleConnectionLost clears the connection state after an unexpected drop
+func (c *BlueZBluetoothClient) handleConnectionLost(path dbus.ObjectPath) {
+	if c.connectedPath.Load() != path {
+		return
+	}
+
+	c.mutex.Lock()
+	if !c.connected || c.device == nil || c.device.path != path {
+		c.mutex.Unlock()
+		return
+	}
+
+	log.Printf("Connection to device %s lost", c.device.address)
+	c.connected = false
+	c.device = nil
+	c.connectedPath.Store(dbus.ObjectPath(""))
+	c.characteristics = make(map[string]dbus.ObjectPath)
+	c.notificationHandlers = make(map[string]func([]byte))
+	callback := c.onConnectionLost
+	c.mutex.Unlock()
+
+	if callback != nil {
+		callback()
+	}
+}
+
+// StartScan starts scanning for BLE devices in the background
+func (c *BlueZBluetoothClient) StartScan(prefix string) error {
+	c.scanMutex.Lock()
+	defer c.scanMutex.Unlock()
+
+	if c.scanning {
+		log.Println("Scan already in progress")
+		return nil
+	}
+
+	log.Printf("Starting Bluetooth scan for devices with prefix: %s", prefix)
+	c.scanResults = make(map[dbus.ObjectPath]*bluezDevice)
+	c.scanPrefix = prefix
+	if err := c.startDiscovery(); err != nil {
+		return err
+	}
+	c.scanning = true
+
+	// BlueZ only announces devices it hasn't seen before, so include the
+	// ones it already knows about
+	devices, err := c.knownDevices()
+	if err != nil {
+		log.Printf("Error listing known devices: %v", err)
+	}
+	for _, device := range devices {
+		c.scanResults[device.path] = device
+	}
+	return nil
+}
+
+// startDiscovery starts LE discovery on the adapter
+func (c *BlueZBluetoothClient) startDiscovery() error {
+	adapter := c.conn.Object(bluezService, c.adapter)
+	filter := map[string]dbus.Variant{"Transport": dbus.MakeVariant("le")}
+	if err := adapter.Call(bluezAdapterInterface+".SetDiscoveryFilter", 0, filter).Err; err != nil {
+		log.Printf("BlueZ: Failed to set discovery filter: %v", err)
+	}
+	if err := adapter.Call(bluezAdapterInterface+".StartDiscovery", 0).Err; err != nil {
+		return fmt.Errorf("failed to start scan: %w", err)
+	}
+	return nil
+}
+
+// stopDiscovery stops discovery on the adapter. BlueZ reports an error if
+// discovery already stopped, which is ignored.
+func (c *BlueZBluetoothClient) stopDiscovery() {
+	c.conn.Object(bluezService, c.adapter).Call(bluezAdapterInterface+".StopDiscovery", 0)
+}
+
+// StopScan stops an ongoing BLE scan
+func (c *BlueZBluetoothClient) StopScan() error {
+	c.scanMutex.Lock()
+	defer c.scanMutex.Unlock()
+
+	if c.scanning {
+		log.Println("Stopping scan...")
+		c.stopDiscovery()
+		c.scanning = false
+	}
+	return nil
+}
+
+// GetConnectedDeviceName returns the name of the currently connected device
+func (c *BlueZBluetoothClient) GetConnectedDeviceName() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.device == nil {
+		return ""
+	}
+	return c.device.name
+}
+
+// findDevice looks for the target in the scan results and devices BlueZ
+// already knows about
+func (c *BlueZBluetoothClient) findDevice(targetName, targetAddress string) *bluezDevice {
+	c.scanMutex.Lock()
+	for _, device := range c.scanResults {
+		if c.matchesScanPrefix(device) && device.matches(targetName, targetAddress) {
+			c.scanMutex.Unlock()
+			return device
+		}
+	}
+	c.scanMutex.Unlock()
+
+	devices, err := c.knownDevices()
+	if err != nil {
+		log.Printf("Error listing known devices: %v", err)
+		return nil
+	}
+	for _, device := range devices {
+		if device.matches(targetName, targetAddress) {
+			return device
+		}
+	}
+	return nil
+}
+
+// Connect connects to the BLE device. mutex is only held to check and record
+// the connection, so signals and scan results keep flowing while it waits.
+func (c *BlueZBluetoothClient) Connect(targetName, targetAddress string) error {
+	c.connectMutex.Lock()
+	defer c.connectMutex.Unlock()
+
+	log.Println("Starting Bluetooth connection process...")
+
+	if c.IsConnected() {
+		log.Println("Already connected to device, skipping connection")
+		return nil
+	}
+
+	device := c.findDevice(targetName, targetAddress)
+	if device == nil {
+		log.Printf("Target device not in scan results, starting new scan for '%s' or '%s'...", targetName, targetAddress)
+		c.notifyPhaseChange(PhaseScanning)
+
+		if err := c.startDiscovery(); err != nil {
+			return err
+		}
+		log.Printf("Waiting for device to be found (timeout: %v)...", bluezFindTimeout)
+		deadline := time.Now().Add(bluezFindTimeout)
+		for device == nil && time.Now().Before(deadline) {
+			time.Sleep(250 * time.Millisecond)
+			device = c.findDevice(targetName, targetAddress)
+		}
+		if device == nil {
+			c.stopDiscovery()
+			log.Println("Device not found after timeout")
+			return errors.New("device not found")
+		}
+		log.Printf("Found target device: %s [%s]", device.name, device.address)
+	}
+
+	// Discovery slows the connection down on most adapters
+	c.scanMutex.Lock()
+	c.stopDiscovery()
+	c.scanning = false
+	c.scanMutex.Unlock()
+
+	c.notifyPhaseChange(PhaseConnecting)
+
+	log.Printf("Connecting to device %s [%s]...", device.name, device.address)
+	ctx, cancel := context.WithTimeout(context.Background(), bluezConnectTimeout)
+	defer cancel()
+	deviceObject := c.conn.Object(bluezService, device.path)
+	if err := deviceObject.CallWithContext(ctx, bluezDeviceInterface+".Connect", 0).Err; err != nil {
+		log.Printf("Error connecting to device: %v", err)
+		return fmt.Errorf("failed to connect to device: %w", err)
+	}
+	log.Println("Successfully connected to device")
+
+	log.Println("Discovering services...")
+	if err := c.waitForServices(ctx, deviceObject); err != nil {
+		deviceObject.Call(bluezDeviceInterface+".Disconnect", 0)
+		log.Printf("Error discovering services: %v", err)
+		return fmt.Errorf("failed to discover services: %w", err)
+	}
+
+	characteristics, err := c.discoverCharacteristics(device.path)
+	if err != nil {
+		deviceObject.Call(bluezDeviceInterface+".Disconnect", 0)
+		return err
+	}
+	log.Printf("Found %d characteristics", len(characteristics))
+
+	// The scan keeps updating its copy of the device
+	connected := *device
+	c.mutex.Lock()
+	c.device = &connected
+	c.characteristics = characteristics
+	c.connected = true
+	c.connectedPath.Store(device.path)
+	c.mutex.Unlock()
+	log.Println("Connection process completed successfully")
+	return nil
+}
+
+// waitForServices waits for BlueZ to finish resolving the device's services
+func (c *BlueZBluetoothClient) waitForServices(ctx context.Context, deviceObject dbus.BusObject) error {
+	for {
+		resolved, err := deviceObject.GetProperty(bluezDeviceInterface + ".ServicesResolved")
+		if err != nil {
+			return err
+		}
+		if done, _ := resolved.Value().(bool); done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.New("timed out waiting for services")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// discoverCharacteristics maps characteristic UUIDs to their object paths
+func (c *BlueZBluetoothClient) discoverCharacteristics(devicePath dbus.ObjectPath) (map[string]dbus.ObjectPath, error) {
+	objects, err := c.managedObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	characteristics := make(map[string]dbus.ObjectPath)
+	for path, interfaces := range objects {
+		props, ok := interfaces[bluezCharInterface]
+		if !ok || !strings.HasPrefix(string(path), string(devicePath)+"/") {
+			continue
+		}
+		if uuid, ok := props["UUID"].Value().(string); ok {
+			characteristics[strings.ToLower(uuid)] = path
+			log.Printf("Characteristic %s", uuid)
+		}
+	}
+	return characteristics, nil
+}
+
+// Disconnect disconnects from the BLE device, waiting for a Connect in
+// progress so the device it connects to isn't left connected
+func (c *BlueZBluetoothClient) Disconnect() error {
+	c.connectMutex.Lock()
+	defer c.connectMutex.Unlock()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.connected || c.device == nil {
+		return nil
+	}
+
+	for uuid := range c.notificationHandlers {
+		c.stopNotificationsLocked(uuid)
+	}
+
+	c.scanMutex.Lock()
+	if c.scanning {
+		log.Println("Stopping scan during disconnect...")
+		c.stopDiscovery()
+		c.scanning = false
+	}
+	c.scanMutex.Unlock()
+
+	// Clear the state first so the Connected change isn't taken for a drop
+	path := c.device.path
+	c.connected = false
+	c.device = nil
+	c.connectedPath.Store(dbus.ObjectPath(""))
+	c.characteristics = make(map[string]dbus.ObjectPath)
+	c.notificationHandlers = make(map[string]func([]byte))
+
+	if err := c.conn.Object(bluezService, path).Call(bluezDeviceInterface+".Disconnect", 0).Err; err != nil {
+		return fmt.Errorf("failed to disconnect: %w", err)
+	}
+	return nil
+}
+
+// characteristic returns the object for a characteristic on the connected
+// device
+func (c *BlueZBluetoothClient) characteristic(uuid string) (dbus.BusObject, error) {
+	if !c.connected || c.device == nil {
+		return nil, errors.New("not connected")
+	}
+	path, ok := c.characteristics[uuid]
+	if !ok {
+		return nil, fmt.Errorf("characteristic not found: %s", uuid)
+	}
+	return c.conn.Object(bluezService, path), nil
+}
+
+// WriteCharacteristic writes data to a characteristic
+func (c *BlueZBluetoothClient) WriteCharacteristic(uuid string, data []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	char, err := c.characteristic(uuid)
+	if err != nil {
+		return err
+	}
+
+	options := map[string]dbus.Variant{"type": dbus.MakeVariant("command")}
+	if err := char.Call(bluezCharInterface+".WriteValue", 0, data, options).Err; err != nil {
+		return fmt.Errorf("failed to write to characteristic: %w", err)
+	}
+	return nil
+}
+
+// ReadCharacteristic reads data from a characteristic
+func (c *BlueZBluetoothClient) ReadCharacteristic(uuid string) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	char, err := c.characteristic(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	if err := char.Call(bluezCharInterface+".ReadValue", 0, map[string]dbus.Variant{}).Store(&value); err != nil {
+		return nil, fmt.Errorf("failed to read characteristic: %w", err)
+	}
+	return value, nil
+}
+
+// StartNotifications starts notifications for a characteristic
+func (c *BlueZBluetoothClient) StartNotifications(uuid string, handler func([]byte)) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	char, err := c.characteristic(uuid)
+	if err != nil {
+		return err
+	}
+
+	c.notificationHandlers[uuid] = handler
+	if err := char.Call(bluezCharInterface+".StartNotify", 0).Err; err != nil {
+		delete(c.notificationHandlers, uuid)
+		return fmt.Errorf("failed to enable notifications: %w", err)
+	}
+	return nil
+}
+
+// StopNotifications stops notifications for a characteristic
+func (c *BlueZBluetoothClient) StopNotifications(uuid string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stopNotificationsLocked(uuid)
+}
+
+func (c *BlueZBluetoothClient) stopNotificationsLocked(uuid string) error {
+	char, err := c.characteristic(uuid)
+	if err != nil {
+		return err
+	}
+
+	delete(c.notificationHandlers, uuid)
+	if err := char.Call(bluezCharInterface+".StopNotify", 0).Err; err != nil {
+		return fmt.Errorf("failed to disable notifications: %w", err)
+	}
+	log.Printf("Stopped notifications for %s", uuid)
+	return nil
+}
+
+// Pair pairs with the connected device and marks it trusted so BlueZ lets
+// it reconnect without asking again
+func (c *BlueZBluetoothClient) Pair() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.connected || c.device == nil {
+		return errors.New("not connected")
+	}
+	device := c.conn.Object(bluezService, c.device.path)
+
+	paired, err := device.GetProperty(bluezDeviceInterface + ".Paired")
+	if done, _ := paired.Value().(bool); err != nil || !done {
+		log.Printf("BlueZ: Pairing with %s...", c.device.address)
+		ctx, cancel := context.WithTimeout(context.Background(), bluezPairTimeout)
+		defer cancel()
+		if err := device.CallWithContext(ctx, bluezDeviceInterface+".Pair", 0).Err; err != nil {
+			return err
+		}
+	}
+
+	if err := device.SetProperty(bluezDeviceInterface+".Trusted", dbus.MakeVariant(true)); err != nil {
+		return fmt.Errorf("failed to trust device: %w", err)
+	}
+	return nil
+}
+
+// Unpair removes the pairing with the device at address. BlueZ forgets the
+// device entirely, disconnecting it if connected.
+func (c *BlueZBluetoothClient) Unpair(address string) error {
+	devices, err := c.knownDevices()
+	if err != nil {
+		return err
+	}
+	for _, device := range devices {
+		if !strings.EqualFold(device.address, address) {
+			continue
+		}
+		return c.conn.Object(bluezService, c.adapter).Call(bluezAdapterInterface+".RemoveDevice", 0, device.path).Err
+	}
+	return nil
+}
+
+// GetConnectedDeviceAddress returns the address of the connected device
+func (c *BlueZBluetoothClient) GetConnectedDeviceAddress() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.device == nil {
+		return ""
+	}
+	return c.device.address
+}
+
+// IsConnected returns the connection status
+func (c *BlueZBluetoothClient) IsConnected() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.connected
+}
+
+// GetScanResultDevices returns the name and address of each device found by
+// the scan
+func (c *BlueZBluetoothClient) GetScanResultDevices() []DiscoveredDevice {
+	c.scanMutex.Lock()
+	defer c.scanMutex.Unlock()
+
+	devices := make([]DiscoveredDevice, 0, len(c.scanResults))
+	for _, device := range c.scanResults {
+		if !c.matchesScanPrefix(device) {
+			continue
+		}
+		discovered := DiscoveredDevice{Name: device.name, Address: device.address}
+		if data := device.manufacturerDataHex(); data != "" {
+			discovered.Type = DetectDeviceType(data)
+		}
+		devices = append(devices, discovered)
+	}
+	return devices
+}
+
+// GetDiscoveredDevices returns the list of discovered devices
+func (c *BlueZBluetoothClient) GetDiscoveredDevices() []string {
+	c.scanMutex.Lock()
+	defer c.scanMutex.Unlock()
+
+	devices := make([]string, 0, len(c.scanResults))
+	for _, device := range c.scanResults {
+		if c.matchesScanPrefix(device) {
+			devices = append(devices, device.name)
+		}
+	}
+	return devices
+}
+
+// GetConnectedDeviceManufacturerData returns the connected device's
+// manufacturer data as hex
+func (c *BlueZBluetoothClient) GetConnectedDeviceManufacturerData() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.device == nil {
+		return ""
+	}
+	return c.device.manufacturerDataHex()
+}
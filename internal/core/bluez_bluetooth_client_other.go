@@ -0,0 +1,15 @@
+//go:build !linux
+
+package core
+
+import "errors"
+
+// BlueZBluetoothClient is only available on Linux
+type BlueZBluetoothClient struct {
+	BluetoothClient
+}
+
+// NewBlueZBluetoothClient reports that BlueZ isn't available on this platform
+func NewBlueZBluetoothClient() (*BlueZBluetoothClient, error) {
+	return nil, errors.New("the BlueZ Bluetooth backend is only available on Linux")
+}
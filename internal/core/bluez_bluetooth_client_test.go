@@ -0,0 +1,42 @@
+//go:build linux
+
+package core
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestBluezDeviceUpdate(t *testing.T) {
+	device := &bluezDevice{path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF"}
+	device.update(map[string]dbus.Variant{
+		"Address": dbus.MakeVariant("AA:BB:CC:DD:EE:FF"),
+		"RSSI":    dbus.MakeVariant(int16(-60)),
+	})
+	device.update(map[string]dbus.Variant{
+		"Name":             dbus.MakeVariant("SquareGolf(1234)"),
+		"ManufacturerData": dbus.MakeVariant(map[uint16]dbus.Variant{0x0059: dbus.MakeVariant([]byte{0x01, 0x02})}),
+	})
+
+	if device.name != "SquareGolf(1234)" || device.address != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("device = %q [%q], want both properties kept", device.name, device.address)
+	}
+	if data := device.manufacturerData[0x0059]; len(data) != 2 || data[1] != 0x02 {
+		t.Errorf("manufacturer data = %v", device.manufacturerData)
+	}
+}
+
+func TestBluezDeviceMatches(t *testing.T) {
+	device := &bluezDevice{name: BluetoothDevicePrefix + "(1234)", address: "AA:BB:CC:DD:EE:FF"}
+
+	if !device.matches("", "aa:bb:cc:dd:ee:ff") {
+		t.Error("Expected addresses to match regardless of case")
+	}
+	if !device.matches("", "") {
+		t.Error("Expected any SquareGolf device to match without a target")
+	}
+	if device.matches("Other", "") {
+		t.Error("Expected a different name not to match")
+	}
+}
@@ -1,59 +1,81 @@
 package camera
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/brentyates/squaregolf-connector/internal/core"
 )
 
-var (
-	cameraInstance *Manager
-	cameraOnce     sync.Once
-)
+// statusTimeout bounds how long Statuses waits for a camera to answer
+const statusTimeout = 3 * time.Second
 
-// Manager handles communication with the swing camera via HTTP REST API
+// Manager triggers every configured swing camera around each shot. Commands
+// go to all cameras in parallel, so a slow camera does not delay the others.
 type Manager struct {
-	stateManager        *core.StateManager
-	baseURL             string
-	enabled             bool
-	httpClient          *http.Client
-	pendingFilename     string              // Stores filename from shot-detected to update with club metrics later
-	pendingClubMetrics  *core.ClubMetrics   // Buffers club metrics that arrive before shot-detected response
-	mu                  sync.Mutex
+	stateManager *core.StateManager
+	enabled      bool
+	httpClient   *http.Client
+	clipDir      string
+	cameras      []*cameraSlot
+	listeners    []func(Recording)
+	supervisor   *core.Supervisor
+	mu           sync.Mutex
 }
 
-// GetInstance returns the singleton instance of CameraManager
-func GetInstance(stateManager *core.StateManager, baseURL string, enabled bool) *Manager {
-	cameraOnce.Do(func() {
-		if baseURL == "" {
-			baseURL = "http://localhost:5000"
-		}
+// cameraSlot is one camera and the recording it is working on
+type cameraSlot struct {
+	provider           CameraProvider
+	endpoint           *Endpoint         // nil for providers added with SetProviders
+	pendingFilename    string            // Filename from shot-detected, updated with club metrics later
+	pendingClubMetrics *core.ClubMetrics // Club metrics that arrived before the shot-detected response
+	lastAction         string
+	lastError          string
+	lastActionAt       time.Time
+}
 
-		cameraInstance = &Manager{
-			stateManager: stateManager,
-			baseURL:      baseURL,
-			enabled:      enabled,
-			httpClient: &http.Client{
-				Timeout: 10 * time.Second,
-			},
-		}
+// Status is one camera's configuration, recent activity and, when it could
+// be reached, its recording state
+type Status struct {
+	Name         string        `json:"name"`
+	URL          string        `json:"url,omitempty"`
+	Reachable    bool          `json:"reachable"`
+	Camera       *CameraStatus `json:"camera,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	LastAction   string        `json:"lastAction,omitempty"`
+	LastActionAt *time.Time    `json:"lastActionAt,omitempty"`
+	LastError    string        `json:"lastError,omitempty"`
+	LastFilename string        `json:"lastFilename,omitempty"`
+}
 
-		// Register state listeners if enabled
-		if enabled {
-			cameraInstance.registerStateListeners()
-			log.Printf("Camera integration initialized with URL: %s", baseURL)
-		} else {
-			log.Println("Camera integration initialized but disabled")
-		}
-	})
-	return cameraInstance
+// New creates a camera manager for the given cameras. With no cameras, a
+// single front camera at DefaultURL is used.
+func New(stateManager *core.StateManager, cameras []Endpoint, enabled bool) *Manager {
+	m := &Manager{
+		stateManager: stateManager,
+		enabled:      enabled,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+	m.SetCameras(cameras)
+
+	// Listeners check the enabled flag, so they are registered once
+	m.registerStateListeners()
+	if enabled {
+		log.Printf("Camera integration initialized with %d camera(s)", len(m.cameras))
+	} else {
+		log.Println("Camera integration initialized but disabled")
+	}
+	return m
 }
 
 // IsEnabled returns whether the camera integration is enabled
@@ -78,231 +100,340 @@ func (m *Manager) SetEnabled(enabled bool) {
 	m.stateManager.SetCameraEnabled(enabled)
 
 	if enabled {
-		// Register state listeners when enabling
-		m.registerStateListeners()
 		log.Println("Camera integration enabled")
 	} else {
 		log.Println("Camera integration disabled")
 	}
 }
 
-// SetBaseURL updates the camera base URL
-func (m *Manager) SetBaseURL(baseURL string) {
+// SetSupervisor recovers panics in camera calls made from state listeners
+func (m *Manager) SetSupervisor(supervisor *core.Supervisor) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.supervisor = supervisor
+}
 
-	if baseURL == "" {
-		baseURL = "http://localhost:5000"
-	}
-
-	m.baseURL = baseURL
-	m.stateManager.SetCameraURL(&baseURL)
-	log.Printf("Camera base URL updated to: %s", baseURL)
+// guard runs call in its own goroutine, recovering a panic
+func (m *Manager) guard(name string, call func()) {
+	m.mu.Lock()
+	supervisor := m.supervisor
+	m.mu.Unlock()
+	go supervisor.Guard(name, call)
 }
 
-// GetBaseURL returns the current camera base URL
-func (m *Manager) GetBaseURL() string {
+// SetClipDir sets where RTSP cameras store their clips and rebuilds the
+// cameras so it takes effect
+func (m *Manager) SetClipDir(dir string) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-	return m.baseURL
+	m.clipDir = dir
+	m.mu.Unlock()
+	m.SetCameras(m.Cameras())
 }
 
-// Arm sends the arm command to the camera (fire and forget)
-func (m *Manager) Arm() error {
+// SetCameras replaces the cameras with ones built from the given endpoints.
+// The first camera's URL is mirrored to the state manager.
+func (m *Manager) SetCameras(endpoints []Endpoint) {
+	endpoints = NormalizeEndpoints(endpoints)
+	if len(endpoints) == 0 {
+		endpoints = []Endpoint{{Name: NameFront, URL: DefaultURL, Type: TypeSwingCam}}
+	}
+
 	m.mu.Lock()
-	baseURL := m.baseURL
-	enabled := m.enabled
-	// Clear any pending state from previous shot
-	m.pendingFilename = ""
-	m.pendingClubMetrics = nil
+	clipDir := m.clipDir
 	m.mu.Unlock()
 
-	if !enabled {
-		log.Println("Camera integration disabled, skipping arm command")
-		return nil // Silent failure as requested
+	cameras := make([]*cameraSlot, len(endpoints))
+	for i := range endpoints {
+		endpoint := endpoints[i]
+		cameras[i] = &cameraSlot{provider: m.newProvider(endpoint, clipDir), endpoint: &endpoint}
 	}
+	m.replaceCameras(cameras)
 
-	url := fmt.Sprintf("%s/api/lm/arm", baseURL)
-	resp, err := m.httpClient.Post(url, "application/json", nil)
-	if err != nil {
-		log.Printf("Failed to arm camera: %v", err)
-		return nil // Silent failure
+	m.stateManager.SetCameraURL(&endpoints[0].URL)
+	log.Printf("Camera endpoints updated: %v", endpoints)
+}
+
+// newProvider builds the provider for an endpoint's type
+func (m *Manager) newProvider(endpoint Endpoint, clipDir string) CameraProvider {
+	if endpoint.Type == TypeRTSP {
+		if clipDir == "" {
+			clipDir = filepath.Join(os.TempDir(), "squaregolf-clips")
+		}
+		return NewRTSPRecorder(RTSPConfig{
+			Name:     endpoint.Name,
+			URL:      endpoint.URL,
+			ClipDir:  clipDir,
+			PreRoll:  time.Duration(endpoint.PreRollSeconds * float64(time.Second)),
+			PostRoll: time.Duration(endpoint.PostRollSeconds * float64(time.Second)),
+		})
 	}
-	defer resp.Body.Close()
+	return NewSwingCam(endpoint.Name, endpoint.URL, m.httpClient)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		log.Printf("Camera arm request failed: %d - %s", resp.StatusCode, string(body))
-		return nil // Silent failure
+// SetProviders replaces the cameras with custom providers. Any pending
+// recordings are dropped.
+func (m *Manager) SetProviders(providers ...CameraProvider) {
+	cameras := make([]*cameraSlot, len(providers))
+	for i, provider := range providers {
+		cameras[i] = &cameraSlot{provider: provider}
 	}
+	m.replaceCameras(cameras)
+}
+
+// replaceCameras swaps in new cameras and closes the old ones that hold
+// resources, such as an RTSP buffer
+func (m *Manager) replaceCameras(cameras []*cameraSlot) {
+	m.mu.Lock()
+	old := m.cameras
+	m.cameras = cameras
+	m.mu.Unlock()
 
-	log.Println("Camera arm command sent successfully")
-	return nil
+	closeCameras(old)
 }
 
-// ShotDetected sends the shot-detected command to the camera with ball metrics only (fire and forget)
-// Club metrics are sent separately via UpdateMetadata() when they arrive
-func (m *Manager) ShotDetected(ballMetrics *core.BallMetrics) error {
+// Close stops every camera that holds resources
+func (m *Manager) Close() {
 	m.mu.Lock()
-	baseURL := m.baseURL
-	enabled := m.enabled
+	old := m.cameras
+	m.cameras = nil
 	m.mu.Unlock()
 
-	if !enabled {
-		log.Println("Camera integration disabled, skipping shot-detected command")
-		return nil // Silent failure
+	closeCameras(old)
+}
+
+func closeCameras(cameras []*cameraSlot) {
+	for _, camera := range cameras {
+		if closer, ok := camera.provider.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Printf("Failed to close camera %s: %v", camera.provider.Name(), err)
+			}
+		}
 	}
+}
 
-	// Convert ball metrics to SwingCam format (flat structure)
-	ballData := convertBallMetrics(ballMetrics)
+// Cameras returns the endpoints of the configured cameras, excluding custom
+// providers
+func (m *Manager) Cameras() []Endpoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	// Marshal ball data directly (no wrapper object)
-	payloadBytes, err := json.Marshal(ballData)
-	if err != nil {
-		log.Printf("Failed to marshal ball data for shot-detected: %v", err)
-		return nil // Silent failure
+	endpoints := make([]Endpoint, 0, len(m.cameras))
+	for _, camera := range m.cameras {
+		if camera.endpoint != nil {
+			endpoints = append(endpoints, *camera.endpoint)
+		}
 	}
+	return endpoints
+}
 
-	url := fmt.Sprintf("%s/api/lm/shot-detected", baseURL)
-	resp, err := m.httpClient.Post(url, "application/json", bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		log.Printf("Failed to send shot-detected to camera: %v", err)
-		return nil // Silent failure
+// SetBaseURL updates the first camera's URL and keeps the others
+func (m *Manager) SetBaseURL(baseURL string) {
+	endpoints := m.Cameras()
+	if len(endpoints) == 0 {
+		endpoints = []Endpoint{{Name: NameFront}}
 	}
-	defer resp.Body.Close()
+	endpoints[0].URL = baseURL
+	endpoints[0].Type = "" // infer from the new URL
+	m.SetCameras(endpoints)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		log.Printf("Camera shot-detected request failed: %d - %s", resp.StatusCode, string(body))
-		return nil // Silent failure
+// GetBaseURL returns the first camera's URL
+func (m *Manager) GetBaseURL() string {
+	endpoints := m.Cameras()
+	if len(endpoints) == 0 {
+		return DefaultURL
 	}
+	return endpoints[0].URL
+}
 
-	// Parse response to get filename for potential club metrics update later
-	var shotResponse ShotResponse
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("Failed to read shot-detected response: %v", err)
-	} else if err := json.Unmarshal(body, &shotResponse); err != nil {
-		log.Printf("Failed to parse shot-detected response: %v", err)
-	} else if shotResponse.Filename != "" {
-		// Store filename and check for buffered club metrics
-		m.mu.Lock()
-		m.pendingFilename = shotResponse.Filename
-		bufferedClubMetrics := m.pendingClubMetrics
-		m.pendingClubMetrics = nil // Clear buffer after retrieving
-		m.mu.Unlock()
-
-		log.Printf("Camera shot-detected successful, filename: %s", shotResponse.Filename)
+// OnRecording registers a listener called when a camera saves a clip
+func (m *Manager) OnRecording(listener func(Recording)) {
+	m.mu.Lock()
+	m.listeners = append(m.listeners, listener)
+	m.mu.Unlock()
+}
 
-		// If club metrics arrived before the filename (race condition), send them now
-		if bufferedClubMetrics != nil {
-			log.Printf("Applying buffered club metrics to %s", shotResponse.Filename)
-			go m.UpdateMetadata(shotResponse.Filename, bufferedClubMetrics)
-		}
-	}
+func (m *Manager) notifyRecording(recording Recording) {
+	m.mu.Lock()
+	listeners := make([]func(Recording), len(m.listeners))
+	copy(listeners, m.listeners)
+	m.mu.Unlock()
 
-	// Log success with metrics info
-	if ballData != nil {
-		log.Printf("Camera shot-detected sent successfully with ball metrics (ball speed: %.1f mph)", ballData.BallSpeed)
-	} else {
-		log.Println("Camera shot-detected sent successfully (no ball metrics)")
+	for _, listener := range listeners {
+		listener(recording)
 	}
-	return nil
 }
 
-// Cancel sends the cancel command to the camera (fire and forget)
-func (m *Manager) Cancel() error {
+// snapshot returns the cameras if the integration is enabled
+func (m *Manager) snapshot() ([]*cameraSlot, bool) {
 	m.mu.Lock()
-	baseURL := m.baseURL
-	enabled := m.enabled
-	m.mu.Unlock()
+	defer m.mu.Unlock()
+	return append([]*cameraSlot(nil), m.cameras...), m.enabled
+}
 
+// each runs fn on every camera in parallel and waits for all of them. Failures
+// are logged and recorded on the camera's status.
+func (m *Manager) each(action string, fn func(ctx context.Context, camera *cameraSlot) error) error {
+	cameras, enabled := m.snapshot()
 	if !enabled {
-		log.Println("Camera integration disabled, skipping cancel command")
-		return nil // Silent failure
-	}
-
-	url := fmt.Sprintf("%s/api/lm/cancel", baseURL)
-	resp, err := m.httpClient.Post(url, "application/json", nil)
-	if err != nil {
-		log.Printf("Failed to cancel camera: %v", err)
-		return nil // Silent failure
+		log.Printf("Camera integration disabled, skipping %s command", action)
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		log.Printf("Camera cancel request failed: %d - %s", resp.StatusCode, string(body))
-		return nil // Silent failure
+	errs := make([]error, len(cameras))
+	var wg sync.WaitGroup
+	for i, camera := range cameras {
+		wg.Add(1)
+		go func(i int, camera *cameraSlot) {
+			defer wg.Done()
+			err := fn(context.Background(), camera)
+
+			m.mu.Lock()
+			camera.lastAction = action
+			camera.lastActionAt = time.Now()
+			camera.lastError = ""
+			if err != nil {
+				camera.lastError = err.Error()
+			}
+			m.mu.Unlock()
+
+			name := camera.provider.Name()
+			if err != nil {
+				log.Printf("Camera %s %s failed: %v", name, action, err)
+				errs[i] = fmt.Errorf("camera %s: %w", name, err)
+				return
+			}
+			log.Printf("Camera %s %s command sent successfully", name, action)
+		}(i, camera)
 	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
 
-	log.Println("Camera cancel command sent successfully")
-	return nil
+// Arm tells every camera to start recording
+func (m *Manager) Arm() error {
+	return m.each("arm", func(ctx context.Context, camera *cameraSlot) error {
+		// Clear any pending state from the previous shot
+		m.mu.Lock()
+		camera.pendingFilename = ""
+		camera.pendingClubMetrics = nil
+		m.mu.Unlock()
+		return camera.provider.Arm(ctx)
+	})
 }
 
-// UpdateMetadata sends club metrics to update the metadata of a recorded video (fire and forget)
-// Sends club data directly (flat structure) via PATCH /api/recordings/{filename}/metadata
-func (m *Manager) UpdateMetadata(filename string, clubMetrics *core.ClubMetrics) error {
-	m.mu.Lock()
-	baseURL := m.baseURL
-	enabled := m.enabled
-	m.mu.Unlock()
+// ShotDetected tells every camera to save its recording with the ball metrics.
+// Club metrics are sent separately via ClubMetricsReceived when they arrive.
+func (m *Manager) ShotDetected(ballMetrics *core.BallMetrics) error {
+	shotTime := time.Now()
+	return m.each("shot-detected", func(ctx context.Context, camera *cameraSlot) error {
+		filename, err := camera.provider.ShotDetected(ctx, ballMetrics)
+		if err != nil || filename == "" {
+			return err
+		}
 
-	if !enabled {
-		log.Println("Camera integration disabled, skipping metadata update")
-		return nil // Silent failure
-	}
+		recording := Recording{Camera: camera.provider.Name(), Filename: filename, ShotTime: shotTime}
+		if clipStore, ok := camera.provider.(ClipStore); ok {
+			recording.Path = clipStore.ClipPath(filename)
+		}
+		m.notifyRecording(recording)
 
-	if filename == "" {
-		log.Println("No filename available for metadata update")
+		// Store filename and check for buffered club metrics
+		m.mu.Lock()
+		camera.pendingFilename = filename
+		bufferedClubMetrics := camera.pendingClubMetrics
+		camera.pendingClubMetrics = nil
+		m.mu.Unlock()
+
+		log.Printf("Camera %s saved recording %s", camera.provider.Name(), filename)
+
+		// Club metrics arrived before the filename, send them now
+		if bufferedClubMetrics != nil {
+			log.Printf("Applying buffered club metrics to %s", filename)
+			return camera.provider.UpdateMetadata(ctx, filename, m.clubData(bufferedClubMetrics))
+		}
 		return nil
-	}
+	})
+}
+
+// Cancel tells every camera to discard its armed recording
+func (m *Manager) Cancel() error {
+	return m.each("cancel", func(ctx context.Context, camera *cameraSlot) error {
+		return camera.provider.Cancel(ctx)
+	})
+}
 
+// ClubMetricsReceived adds club metrics to each camera's latest recording,
+// or holds them until the camera reports the recording's filename
+func (m *Manager) ClubMetricsReceived(clubMetrics *core.ClubMetrics) error {
 	if clubMetrics == nil {
-		log.Println("No club metrics available for metadata update")
 		return nil
 	}
+	return m.each("metadata update", func(ctx context.Context, camera *cameraSlot) error {
+		m.mu.Lock()
+		filename := camera.pendingFilename
+		if filename == "" {
+			camera.pendingClubMetrics = clubMetrics
+		}
+		m.mu.Unlock()
 
-	// Convert club metrics to SwingCam format (flat structure)
-	clubData := convertClubMetrics(clubMetrics)
-	if clubData == nil {
-		log.Println("Failed to convert club metrics")
-		return nil
-	}
+		if filename == "" {
+			log.Printf("Camera %s: club metrics received before filename, buffering", camera.provider.Name())
+			return nil
+		}
+		return camera.provider.UpdateMetadata(ctx, filename, m.clubData(clubMetrics))
+	})
+}
 
-	// Add club name from state manager (set by GSPro)
+// clubData converts club metrics to SwingCam format with the club name from
+// the state manager (set by GSPro)
+func (m *Manager) clubData(clubMetrics *core.ClubMetrics) *ClubData {
+	clubData := convertClubMetrics(clubMetrics)
 	if clubName := m.stateManager.GetClubName(); clubName != nil {
 		clubData.ClubType = *clubName
 	}
+	return clubData
+}
 
-	// Marshal club data directly (no wrapper object)
-	payloadBytes, err := json.Marshal(clubData)
-	if err != nil {
-		log.Printf("Failed to marshal club data for metadata update: %v", err)
-		return nil // Silent failure
-	}
-
-	url := fmt.Sprintf("%s/api/recordings/%s/metadata", baseURL, filename)
-	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		log.Printf("Failed to create metadata update request: %v", err)
-		return nil // Silent failure
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := m.httpClient.Do(req)
-	if err != nil {
-		log.Printf("Failed to send metadata update to camera: %v", err)
-		return nil // Silent failure
+// Statuses queries every camera in parallel and reports its status
+func (m *Manager) Statuses(ctx context.Context) []Status {
+	m.mu.Lock()
+	cameras := append([]*cameraSlot(nil), m.cameras...)
+	statuses := make([]Status, len(cameras))
+	for i, camera := range cameras {
+		statuses[i] = Status{
+			Name:         camera.provider.Name(),
+			LastAction:   camera.lastAction,
+			LastError:    camera.lastError,
+			LastFilename: camera.pendingFilename,
+		}
+		if !camera.lastActionAt.IsZero() {
+			lastActionAt := camera.lastActionAt
+			statuses[i].LastActionAt = &lastActionAt
+		}
+		if camera.endpoint != nil {
+			statuses[i].URL = camera.endpoint.URL
+		}
 	}
-	defer resp.Body.Close()
+	m.mu.Unlock()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		log.Printf("Camera metadata update request failed: %d - %s", resp.StatusCode, string(body))
-		return nil // Silent failure
+	ctx, cancel := context.WithTimeout(ctx, statusTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i, camera := range cameras {
+		wg.Add(1)
+		go func(status *Status, provider CameraProvider) {
+			defer wg.Done()
+			cameraStatus, err := provider.Status(ctx)
+			if err != nil {
+				status.Error = err.Error()
+				return
+			}
+			status.Reachable = true
+			status.Camera = &cameraStatus
+		}(&statuses[i], camera.provider)
 	}
-
-	log.Printf("Camera metadata updated successfully for %s with club data", filename)
-	return nil
+	wg.Wait()
+	return statuses
 }
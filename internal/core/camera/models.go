@@ -32,6 +32,7 @@ type BallData struct {
 	MaxHeight       float64 `json:"maxHeight,omitempty"`       // Apex height (yards or meters)
 	LandingAngle    float64 `json:"landingAngle,omitempty"`    // Descent angle at landing in degrees
 	HangTime        float64 `json:"hangTime,omitempty"`        // Time in air in seconds
+	ShotID          int64   `json:"shotId,omitempty"`          // Connector shot ID, shared with the shot's club data
 }
 
 // ClubData represents club metrics sent to SwingCam (flat structure, camelCase)
@@ -46,6 +47,7 @@ type ClubData struct {
 	SmashFactor  float64 `json:"smashFactor,omitempty"`  // Smash factor (ball speed / club speed)
 	LowPoint     float64 `json:"lowPoint,omitempty"`     // Low point position (inches before/after ball)
 	ClubType     string  `json:"clubType,omitempty"`     // Club name (e.g., "Driver", "7-iron")
+	ShotID       int64   `json:"shotId,omitempty"`       // Connector shot ID, shared with the shot's ball data
 }
 
 // ShotResponse represents the response from POST /api/lm/shot-detected
@@ -75,6 +77,7 @@ func convertBallMetrics(metrics *core.BallMetrics) *BallData {
 		SpinAxis:        metrics.SpinAxis,
 		BackSpin:        int(metrics.BackspinRPM),
 		SideSpin:        int(metrics.SidespinRPM),
+		ShotID:          metrics.ShotID,
 		// Note: CarryDistance, TotalDistance, MaxHeight, LandingAngle, HangTime not available from SquareGolf
 	}
 }
@@ -89,6 +92,8 @@ func convertClubMetrics(metrics *core.ClubMetrics) *ClubData {
 		FaceAngle:   metrics.FaceAngle,
 		AttackAngle: metrics.AttackAngle,
 		DynamicLoft: metrics.DynamicLoftAngle,
-		// Note: ClubSpeed, FaceToPath, SmashFactor, LowPoint, ClubType not available from SquareGolf
+		FaceToPath:  metrics.FaceToPath,
+		ShotID:      metrics.ShotID,
+		// Note: ClubSpeed, SmashFactor, LowPoint, ClubType not available from SquareGolf
 	}
 }
@@ -0,0 +1,99 @@
+package camera
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// DefaultURL is the address of a SwingCam running on this machine
+const DefaultURL = "http://localhost:5000"
+
+// Camera types
+const (
+	TypeSwingCam = "swingcam" // SwingCam-style HTTP API
+	TypeRTSP     = "rtsp"     // RTSP stream recorded locally
+)
+
+// Common camera names for a two-camera setup
+const (
+	NameFront = "front"
+	NameDTL   = "dtl" // down the line
+)
+
+// CameraProvider is a swing camera the manager triggers around each shot.
+// Implementations report failures as errors; the manager decides whether
+// they are fatal.
+type CameraProvider interface {
+	// Name identifies the camera, e.g. "front" or "dtl"
+	Name() string
+	// Arm starts recording when the ball is ready
+	Arm(ctx context.Context) error
+	// ShotDetected saves the recording and returns its filename, or an
+	// empty string if the camera did not report one
+	ShotDetected(ctx context.Context, ballMetrics *core.BallMetrics) (string, error)
+	// Cancel discards an armed recording
+	Cancel(ctx context.Context) error
+	// UpdateMetadata attaches club data to a saved recording
+	UpdateMetadata(ctx context.Context, filename string, clubData *ClubData) error
+	// Status queries the camera's current state
+	Status(ctx context.Context) (CameraStatus, error)
+}
+
+// ClipStore is implemented by providers that save clips on this machine
+type ClipStore interface {
+	// ClipPath returns the local path of a saved clip
+	ClipPath(filename string) string
+}
+
+// Recording is a clip a camera saved for a shot
+type Recording struct {
+	Camera   string
+	Filename string
+	Path     string // local file, only for providers that are a ClipStore
+	ShotTime time.Time
+}
+
+// Endpoint configures one camera. Pre and post roll only apply to RTSP
+// cameras; SwingCam cameras choose their own.
+type Endpoint struct {
+	Name            string  `json:"name"`
+	URL             string  `json:"url"`
+	Type            string  `json:"type,omitempty"` // inferred from the URL when empty
+	PreRollSeconds  float64 `json:"preRollSeconds,omitempty"`
+	PostRollSeconds float64 `json:"postRollSeconds,omitempty"`
+}
+
+// NormalizeEndpoints fills in default names and URLs and drops duplicate
+// names, keeping the first
+func NormalizeEndpoints(endpoints []Endpoint) []Endpoint {
+	normalized := make([]Endpoint, 0, len(endpoints))
+	seen := make(map[string]bool)
+	for i, endpoint := range endpoints {
+		endpoint.Name = strings.TrimSpace(endpoint.Name)
+		endpoint.URL = strings.TrimRight(strings.TrimSpace(endpoint.URL), "/")
+		if endpoint.Name == "" {
+			endpoint.Name = NameFront
+			if i > 0 {
+				endpoint.Name = NameDTL
+			}
+		}
+		if endpoint.URL == "" {
+			endpoint.URL = DefaultURL
+		}
+		if endpoint.Type == "" {
+			endpoint.Type = TypeSwingCam
+			if strings.HasPrefix(endpoint.URL, "rtsp://") || strings.HasPrefix(endpoint.URL, "rtsps://") {
+				endpoint.Type = TypeRTSP
+			}
+		}
+		if seen[endpoint.Name] {
+			continue
+		}
+		seen[endpoint.Name] = true
+		normalized = append(normalized, endpoint)
+	}
+	return normalized
+}
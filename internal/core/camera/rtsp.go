@@ -0,0 +1,442 @@
+package camera
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// Default clip timing around the shot
+const (
+	DefaultPreRoll  = 3 * time.Second
+	DefaultPostRoll = 2 * time.Second
+)
+
+// segmentDuration is the length of each buffered segment. Clips are cut on
+// segment boundaries, so pre and post roll are rounded up to it.
+const segmentDuration = time.Second
+
+// recorderRestartDelay is how long to wait before restarting ffmpeg after the
+// stream drops
+const recorderRestartDelay = 5 * time.Second
+
+// bufferPruneInterval is how often segments too old for a clip are removed
+const bufferPruneInterval = 5 * time.Second
+
+// Buffered segments are numbered in recording order, carrying on across
+// ffmpeg restarts
+const (
+	segmentPrefix = "segment"
+	segmentExt    = ".ts"
+)
+
+// ErrRecorderNotRunning is returned when a shot arrives before the buffer has
+// started
+var ErrRecorderNotRunning = errors.New("RTSP recorder is not running")
+
+// RTSPConfig configures an RTSP recorder
+type RTSPConfig struct {
+	Name       string
+	URL        string        // rtsp:// stream, e.g. from an ONVIF camera
+	ClipDir    string        // where finished clips and their metadata are stored
+	PreRoll    time.Duration // video kept before the shot
+	PostRoll   time.Duration // video recorded after the shot
+	FFmpegPath string        // defaults to ffmpeg on the PATH
+}
+
+// ClipMetadata is stored next to each clip as <clip>.json
+type ClipMetadata struct {
+	Camera     string    `json:"camera"`
+	RecordedAt time.Time `json:"recordedAt"`
+	Ball       *BallData `json:"ball,omitempty"`
+	Club       *ClubData `json:"club,omitempty"`
+}
+
+// RTSPRecorder is a camera provider for IP cameras without a SwingCam API.
+// ffmpeg keeps a rolling buffer of short segments from the stream, and each
+// shot is saved as a clip cut from the segments around it.
+type RTSPRecorder struct {
+	config    RTSPConfig
+	bufferDir string
+	command   func(ctx context.Context, name string, args ...string) *exec.Cmd
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	done      chan struct{}
+	running   bool
+	lastErr   error
+	startedAt time.Time
+}
+
+// NewRTSPRecorder creates a recorder. Buffering starts on the first Arm.
+func NewRTSPRecorder(config RTSPConfig) *RTSPRecorder {
+	if config.PreRoll <= 0 {
+		config.PreRoll = DefaultPreRoll
+	}
+	if config.PostRoll <= 0 {
+		config.PostRoll = DefaultPostRoll
+	}
+	if config.FFmpegPath == "" {
+		config.FFmpegPath = "ffmpeg"
+	}
+	return &RTSPRecorder{
+		config:    config,
+		bufferDir: filepath.Join(config.ClipDir, ".buffer-"+config.Name),
+		command:   exec.CommandContext,
+	}
+}
+
+// Name returns the camera's name
+func (r *RTSPRecorder) Name() string {
+	return r.config.Name
+}
+
+// StreamURL returns the RTSP stream address
+func (r *RTSPRecorder) StreamURL() string {
+	return r.config.URL
+}
+
+// Arm makes sure the rolling buffer is running. The buffer records
+// continuously, so there is nothing else to arm.
+func (r *RTSPRecorder) Arm(ctx context.Context) error {
+	return r.start()
+}
+
+// Cancel does nothing; unused buffer segments are overwritten
+func (r *RTSPRecorder) Cancel(ctx context.Context) error {
+	return nil
+}
+
+// ShotDetected waits for the post roll, saves the clip and its metadata and
+// returns the clip's filename
+func (r *RTSPRecorder) ShotDetected(ctx context.Context, ballMetrics *core.BallMetrics) (string, error) {
+	shotTime := time.Now()
+
+	r.mu.Lock()
+	running := r.running
+	r.mu.Unlock()
+	if !running {
+		return "", ErrRecorderNotRunning
+	}
+
+	select {
+	case <-time.After(r.config.PostRoll):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	segments, err := r.segmentsSince(shotTime.Add(-r.config.PreRoll))
+	if err != nil {
+		return "", err
+	}
+	if len(segments) == 0 {
+		return "", fmt.Errorf("no buffered video around the shot")
+	}
+
+	filename := fmt.Sprintf("%s-%s.mp4", r.config.Name, shotTime.Format("20060102-150405.000"))
+	if err := r.concat(ctx, segments, filepath.Join(r.config.ClipDir, filename)); err != nil {
+		return "", err
+	}
+
+	metadata := ClipMetadata{Camera: r.config.Name, RecordedAt: shotTime, Ball: convertBallMetrics(ballMetrics)}
+	if err := r.writeMetadata(filename, metadata); err != nil {
+		return "", err
+	}
+
+	log.Printf("RTSP camera %s saved clip %s", r.config.Name, filepath.Join(r.config.ClipDir, filename))
+	return filename, nil
+}
+
+// UpdateMetadata adds club data to a saved clip's metadata
+func (r *RTSPRecorder) UpdateMetadata(ctx context.Context, filename string, clubData *ClubData) error {
+	path := r.metadataPath(filename)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read clip metadata: %w", err)
+	}
+
+	var metadata ClipMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return fmt.Errorf("failed to parse clip metadata: %w", err)
+	}
+	metadata.Club = clubData
+	return r.writeMetadata(filename, metadata)
+}
+
+// Status reports whether the buffer is running
+func (r *RTSPRecorder) Status(ctx context.Context) (CameraStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		if r.lastErr != nil {
+			return CameraStatus{State: "idle"}, r.lastErr
+		}
+		return CameraStatus{State: "idle"}, nil
+	}
+	status := CameraStatus{
+		State:             "buffering",
+		RecordingDuration: time.Since(r.startedAt).Seconds(),
+	}
+	if r.lastErr != nil {
+		return status, r.lastErr
+	}
+	return status, nil
+}
+
+// Close stops the buffer and removes its segments
+func (r *RTSPRecorder) Close() error {
+	r.mu.Lock()
+	cancel := r.cancel
+	done := r.done
+	r.cancel = nil
+	r.running = false
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	return os.RemoveAll(r.bufferDir)
+}
+
+// start launches the ffmpeg buffer loop if it is not running
+func (r *RTSPRecorder) start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return r.lastErr
+	}
+
+	if _, err := exec.LookPath(r.config.FFmpegPath); err != nil {
+		r.lastErr = fmt.Errorf("ffmpeg not found: %w", err)
+		return r.lastErr
+	}
+	if err := os.MkdirAll(r.bufferDir, 0755); err != nil {
+		r.lastErr = fmt.Errorf("failed to create buffer directory: %w", err)
+		return r.lastErr
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	r.running = true
+	r.startedAt = time.Now()
+	r.lastErr = nil
+	go r.bufferLoop(ctx, r.done)
+	return nil
+}
+
+// bufferLoop runs ffmpeg until stopped, restarting it when the stream drops
+func (r *RTSPRecorder) bufferLoop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	pruned := make(chan struct{})
+	go r.pruneLoop(ctx, pruned)
+	defer func() { <-pruned }()
+
+	for {
+		// Number on from the last segment so the sequence stays in
+		// recording order after a restart
+		next := 0
+		if segments, err := r.bufferedSegments(); err == nil && len(segments) > 0 {
+			next = segments[len(segments)-1].sequence + 1
+		}
+
+		log.Printf("RTSP camera %s: buffering %s", r.config.Name, r.config.URL)
+		cmd := r.command(ctx, r.config.FFmpegPath,
+			"-hide_banner", "-loglevel", "error",
+			"-rtsp_transport", "tcp",
+			"-i", r.config.URL,
+			"-map", "0", "-c", "copy",
+			"-f", "segment",
+			"-segment_time", fmt.Sprintf("%g", segmentDuration.Seconds()),
+			"-segment_start_number", strconv.Itoa(next),
+			"-segment_format", "mpegts",
+			"-reset_timestamps", "1",
+			filepath.Join(r.bufferDir, segmentPrefix+"%09d"+segmentExt),
+		)
+		output, err := cmd.CombinedOutput()
+		if ctx.Err() != nil {
+			return
+		}
+
+		err = fmt.Errorf("ffmpeg exited: %v: %s", err, strings.TrimSpace(string(output)))
+		log.Printf("RTSP camera %s: %v, restarting in %s", r.config.Name, err, recorderRestartDelay)
+		r.mu.Lock()
+		r.lastErr = err
+		r.mu.Unlock()
+
+		select {
+		case <-time.After(recorderRestartDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// keptSegments is how many segments the buffer holds: enough for a clip
+// twice over
+func (r *RTSPRecorder) keptSegments() int {
+	keep := int((r.config.PreRoll+r.config.PostRoll)/segmentDuration) * 2
+	if keep < 10 {
+		keep = 10
+	}
+	return keep
+}
+
+// pruneLoop removes segments too old for a clip until stopped
+func (r *RTSPRecorder) pruneLoop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(bufferPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.pruneSegments()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// pruneSegments removes all but the newest segments
+func (r *RTSPRecorder) pruneSegments() {
+	segments, err := r.bufferedSegments()
+	if err != nil {
+		return
+	}
+	for len(segments) > r.keptSegments() {
+		os.Remove(segments[0].path)
+		segments = segments[1:]
+	}
+}
+
+// bufferedSegment is a segment file and when ffmpeg finished writing it
+type bufferedSegment struct {
+	path     string
+	sequence int
+	end      time.Time
+}
+
+// bufferedSegments returns the segments in the buffer in recording order
+func (r *RTSPRecorder) bufferedSegments() ([]bufferedSegment, error) {
+	entries, err := os.ReadDir(r.bufferDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read buffer: %w", err)
+	}
+
+	var segments []bufferedSegment
+	for _, entry := range entries {
+		number, ok := strings.CutPrefix(entry.Name(), segmentPrefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		number, ok = strings.CutSuffix(number, segmentExt)
+		sequence, err := strconv.Atoi(number)
+		if !ok || err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		segments = append(segments, bufferedSegment{
+			path:     filepath.Join(r.bufferDir, entry.Name()),
+			sequence: sequence,
+			end:      info.ModTime(),
+		})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].sequence < segments[j].sequence })
+	return segments, nil
+}
+
+// segmentsSince returns the buffered segments from the one recording at
+// since onwards, oldest first. A segment starts when the one before it in
+// the sequence was finished, so the sequence gives the order; modification
+// times only say when each segment ended.
+func (r *RTSPRecorder) segmentsSince(since time.Time) ([]string, error) {
+	segments, err := r.bufferedSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	first := len(segments)
+	for i := len(segments) - 1; i >= 0; i-- {
+		first = i
+		start := segments[i].end.Add(-segmentDuration)
+		if i > 0 {
+			start = segments[i-1].end
+		}
+		if !start.After(since) {
+			break
+		}
+	}
+
+	paths := make([]string, 0, len(segments)-first)
+	for _, segment := range segments[first:] {
+		paths = append(paths, segment.path)
+	}
+	return paths, nil
+}
+
+// concat joins segments into one clip without re-encoding
+func (r *RTSPRecorder) concat(ctx context.Context, segments []string, output string) error {
+	listFile, err := os.CreateTemp(r.bufferDir, "concat-*.txt")
+	if err != nil {
+		return fmt.Errorf("failed to create segment list: %w", err)
+	}
+	defer os.Remove(listFile.Name())
+
+	for _, segment := range segments {
+		fmt.Fprintf(listFile, "file '%s'\n", strings.ReplaceAll(segment, "'", `'\''`))
+	}
+	if err := listFile.Close(); err != nil {
+		return fmt.Errorf("failed to write segment list: %w", err)
+	}
+
+	cmd := r.command(ctx, r.config.FFmpegPath,
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-f", "concat", "-safe", "0",
+		"-i", listFile.Name(),
+		"-c", "copy", "-movflags", "+faststart",
+		output,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to save clip: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ClipPath returns the local path of a saved clip
+func (r *RTSPRecorder) ClipPath(filename string) string {
+	return filepath.Join(r.config.ClipDir, filename)
+}
+
+func (r *RTSPRecorder) metadataPath(filename string) string {
+	return filepath.Join(r.config.ClipDir, strings.TrimSuffix(filename, filepath.Ext(filename))+".json")
+}
+
+func (r *RTSPRecorder) writeMetadata(filename string, metadata ClipMetadata) error {
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal clip metadata: %w", err)
+	}
+	if err := os.WriteFile(r.metadataPath(filename), data, 0644); err != nil {
+		return fmt.Errorf("failed to write clip metadata: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package camera
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeFFmpegEnv makes the test binary act as ffmpeg, see TestFakeFFmpeg
+const fakeFFmpegEnv = "RTSP_TEST_FAKE_FFMPEG"
+
+// TestFakeFFmpeg is not a test. Run with fakeFFmpegEnv set it stands in for
+// ffmpeg: buffering waits to be stopped, and a concat writes the list of
+// segments it was given to the output file in place of the clip.
+func TestFakeFFmpeg(t *testing.T) {
+	if os.Getenv(fakeFFmpegEnv) == "" {
+		return
+	}
+	args := os.Args
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+
+	concat := false
+	list := ""
+	for i, arg := range args {
+		if arg == "concat" {
+			concat = true
+		}
+		if arg == "-i" && i+1 < len(args) {
+			list = args[i+1]
+		}
+	}
+	if !concat {
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+
+	data, err := os.ReadFile(list)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(args[len(args)-1], data, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// newTestRecorder returns a recorder that runs the fake ffmpeg
+func newTestRecorder(t *testing.T, config RTSPConfig) *RTSPRecorder {
+	t.Helper()
+	config.Name = "bay"
+	config.URL = "rtsp://camera/stream"
+	config.ClipDir = t.TempDir()
+	config.FFmpegPath = os.Args[0]
+
+	r := NewRTSPRecorder(config)
+	r.command = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, name, append([]string{"-test.run=^TestFakeFFmpeg$", "--"}, args...)...)
+		cmd.Env = append(os.Environ(), fakeFFmpegEnv+"=1")
+		return cmd
+	}
+	if err := os.MkdirAll(r.bufferDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+// writeSegment buffers a segment numbered sequence that ended at end. It
+// may be called from another goroutine, so it doesn't stop the test.
+func writeSegment(t *testing.T, r *RTSPRecorder, sequence int, end time.Time) {
+	t.Helper()
+	path := filepath.Join(r.bufferDir, fmt.Sprintf("%s%09d%s", segmentPrefix, sequence, segmentExt))
+	if err := os.WriteFile(path, []byte("video"), 0644); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := os.Chtimes(path, end, end); err != nil {
+		t.Error(err)
+	}
+}
+
+func segmentNames(paths []string) []string {
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), segmentPrefix), segmentExt)
+	}
+	return names
+}
+
+func TestRTSPRecorder_SegmentsSinceFollowsTheSequence(t *testing.T) {
+	r := newTestRecorder(t, RTSPConfig{})
+	base := time.Now().Truncate(time.Second)
+
+	writeSegment(t, r, 7, base.Add(-4*time.Second))
+	writeSegment(t, r, 8, base.Add(-2*time.Second))
+	// Filesystems with coarse timestamps give segments the same time, and
+	// a late close can make a segment look older than the one before it
+	writeSegment(t, r, 9, base)
+	writeSegment(t, r, 10, base)
+	writeSegment(t, r, 11, base.Add(-time.Second))
+	os.WriteFile(filepath.Join(r.bufferDir, "concat-1.txt"), nil, 0644)
+
+	segments, err := r.segmentsSince(base.Add(-3 * time.Second))
+	if err != nil {
+		t.Fatalf("segmentsSince() error = %v", err)
+	}
+	// Segment 8 ran from 4s to 2s before, so it holds the start of the clip
+	got := strings.Join(segmentNames(segments), ",")
+	if want := "000000008,000000009,000000010,000000011"; got != want {
+		t.Errorf("segments = %s, want %s", got, want)
+	}
+}
+
+func TestRTSPRecorder_ClipCoversPreAndPostRoll(t *testing.T) {
+	r := newTestRecorder(t, RTSPConfig{PreRoll: 3 * time.Second, PostRoll: 300 * time.Millisecond})
+	if err := r.Arm(context.Background()); err != nil {
+		t.Fatalf("Arm() error = %v", err)
+	}
+
+	now := time.Now()
+	writeSegment(t, r, 1, now.Add(-5*time.Second)) // before the pre roll
+	writeSegment(t, r, 2, now.Add(-3500*time.Millisecond))
+	writeSegment(t, r, 3, now.Add(-2*time.Second))
+	writeSegment(t, r, 4, now.Add(-500*time.Millisecond))
+
+	// The segment finished during the post roll belongs to the clip
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		writeSegment(t, r, 5, time.Now())
+	}()
+
+	filename, err := r.ShotDetected(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ShotDetected() error = %v", err)
+	}
+	clip, err := os.ReadFile(r.ClipPath(filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var segments []string
+	for _, line := range strings.Split(strings.TrimSpace(string(clip)), "\n") {
+		segments = append(segments, strings.Trim(strings.TrimPrefix(line, "file "), "'"))
+	}
+	if got, want := strings.Join(segmentNames(segments), ","), "000000003,000000004,000000005"; got != want {
+		t.Errorf("clip segments = %s, want %s", got, want)
+	}
+	if _, err := os.Stat(r.metadataPath(filename)); err != nil {
+		t.Errorf("Expected the clip metadata saved, got %v", err)
+	}
+}
+
+func TestRTSPRecorder_PruneKeepsTheNewestSegments(t *testing.T) {
+	r := newTestRecorder(t, RTSPConfig{})
+	now := time.Now()
+	keep := r.keptSegments()
+	for i := 0; i < keep+5; i++ {
+		writeSegment(t, r, i, now.Add(time.Duration(i)*time.Second))
+	}
+
+	r.pruneSegments()
+
+	segments, err := r.bufferedSegments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != keep || segments[0].sequence != 5 {
+		t.Errorf("kept %d segments from %d, want %d from 5", len(segments), segments[0].sequence, keep)
+	}
+}
@@ -0,0 +1,119 @@
+package camera
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// SwingCam talks to a SwingCam-style camera over its HTTP REST API
+type SwingCam struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewSwingCam creates a provider for the camera at baseURL
+func NewSwingCam(name, baseURL string, httpClient *http.Client) *SwingCam {
+	return &SwingCam{name: name, baseURL: baseURL, httpClient: httpClient}
+}
+
+// Name returns the camera's name
+func (c *SwingCam) Name() string {
+	return c.name
+}
+
+// BaseURL returns the camera's address
+func (c *SwingCam) BaseURL() string {
+	return c.baseURL
+}
+
+// Arm sends POST /api/lm/arm
+func (c *SwingCam) Arm(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodPost, "/api/lm/arm", nil)
+	return err
+}
+
+// ShotDetected sends the ball metrics to POST /api/lm/shot-detected. Club
+// metrics follow separately via UpdateMetadata.
+func (c *SwingCam) ShotDetected(ctx context.Context, ballMetrics *core.BallMetrics) (string, error) {
+	payload, err := json.Marshal(convertBallMetrics(ballMetrics))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal ball data: %w", err)
+	}
+
+	body, err := c.do(ctx, http.MethodPost, "/api/lm/shot-detected", payload)
+	if err != nil {
+		return "", err
+	}
+
+	var shotResponse ShotResponse
+	if err := json.Unmarshal(body, &shotResponse); err != nil {
+		return "", fmt.Errorf("failed to parse shot-detected response: %w", err)
+	}
+	return shotResponse.Filename, nil
+}
+
+// Cancel sends POST /api/lm/cancel
+func (c *SwingCam) Cancel(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodPost, "/api/lm/cancel", nil)
+	return err
+}
+
+// UpdateMetadata sends the club data to PATCH /api/recordings/{filename}/metadata
+func (c *SwingCam) UpdateMetadata(ctx context.Context, filename string, clubData *ClubData) error {
+	payload, err := json.Marshal(clubData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal club data: %w", err)
+	}
+	_, err = c.do(ctx, http.MethodPatch, "/api/recordings/"+url.PathEscape(filename)+"/metadata", payload)
+	return err
+}
+
+// Status sends GET /api/lm/status
+func (c *SwingCam) Status(ctx context.Context) (CameraStatus, error) {
+	var status CameraStatus
+	body, err := c.do(ctx, http.MethodGet, "/api/lm/status", nil)
+	if err != nil {
+		return status, err
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return status, fmt.Errorf("failed to parse status response: %w", err)
+	}
+	return status, nil
+}
+
+// do sends a request and returns the response body, treating any status
+// other than 200 as an error
+func (c *SwingCam) do(ctx context.Context, method, path string, payload []byte) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s failed: %d - %s", method, path, resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
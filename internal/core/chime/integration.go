@@ -0,0 +1,129 @@
+package chime
+
+import (
+	"log"
+	"sync"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// Output selects where the ready chime is played
+const (
+	OutputBrowser = "browser"
+	OutputLocal   = "local"
+	OutputBoth    = "both"
+)
+
+// Manager plays a chime when the ball becomes ready
+type Manager struct {
+	stateManager *core.StateManager
+	player       Player
+	enabled      bool
+	volume       int
+	output       string
+	listeners    []*chimeListener
+	mu           sync.Mutex
+}
+
+// chimeListener wraps a listener so it can be found again to remove it
+type chimeListener struct {
+	notify func(volume int)
+}
+
+// New creates a chime manager for the ball readiness on stateManager
+func New(stateManager *core.StateManager) *Manager {
+	m := &Manager{
+		stateManager: stateManager,
+		player:       NewSystemPlayer(),
+		volume:       80,
+		output:       OutputBrowser,
+	}
+	m.registerStateListeners()
+	return m
+}
+
+// IsValidOutput reports whether output is a supported chime output
+func IsValidOutput(output string) bool {
+	return output == OutputBrowser || output == OutputLocal || output == OutputBoth
+}
+
+// SetEnabled enables or disables the chime
+func (m *Manager) SetEnabled(enabled bool) {
+	m.mu.Lock()
+	m.enabled = enabled
+	m.mu.Unlock()
+}
+
+// SetVolume sets the chime volume from 0 to 100
+func (m *Manager) SetVolume(volume int) {
+	if volume < 0 {
+		volume = 0
+	} else if volume > 100 {
+		volume = 100
+	}
+
+	m.mu.Lock()
+	m.volume = volume
+	m.mu.Unlock()
+}
+
+// SetOutput sets where the chime is played
+func (m *Manager) SetOutput(output string) {
+	if !IsValidOutput(output) {
+		return
+	}
+
+	m.mu.Lock()
+	m.output = output
+	m.mu.Unlock()
+}
+
+// OnChime registers a listener notified when the browser should play the
+// chime. The returned func removes it.
+func (m *Manager) OnChime(listener func(volume int)) func() {
+	l := &chimeListener{notify: listener}
+	m.mu.Lock()
+	m.listeners = append(m.listeners, l)
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i, registered := range m.listeners {
+			if registered == l {
+				m.listeners = append(m.listeners[:i:i], m.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Play plays the chime on the configured outputs
+func (m *Manager) Play() {
+	m.mu.Lock()
+	enabled := m.enabled
+	volume := m.volume
+	output := m.output
+	player := m.player
+	listeners := make([]*chimeListener, len(m.listeners))
+	copy(listeners, m.listeners)
+	m.mu.Unlock()
+
+	if !enabled || volume == 0 {
+		return
+	}
+
+	if output == OutputLocal || output == OutputBoth {
+		go func() {
+			if err := player.Play(volume); err != nil {
+				log.Printf("Chime: %v", err)
+			}
+		}()
+	}
+
+	if output == OutputBrowser || output == OutputBoth {
+		for _, listener := range listeners {
+			listener.notify(volume)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package chime
+
+// registerStateListeners registers callbacks for state changes
+func (m *Manager) registerStateListeners() {
+	m.stateManager.RegisterBallReadyCallback(func(oldValue, newValue bool) {
+		if newValue && !oldValue {
+			m.Play()
+		}
+	})
+}
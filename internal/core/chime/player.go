@@ -0,0 +1,45 @@
+package chime
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+// Player plays the ready chime on the local machine
+type Player interface {
+	Play(volume int) error
+}
+
+// systemPlayer uses the platform's command line audio tools
+type systemPlayer struct{}
+
+// NewSystemPlayer returns a Player using afplay on macOS, the system sounds
+// API on Windows and paplay elsewhere.
+func NewSystemPlayer() Player {
+	return systemPlayer{}
+}
+
+func (systemPlayer) Play(volume int) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("afplay", "-v", strconv.FormatFloat(float64(volume)/100, 'f', 2, 64), "/System/Library/Sounds/Glass.aiff")
+	case "windows":
+		// SystemSounds follow the Windows system volume
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", "[System.Media.SystemSounds]::Asterisk.Play(); Start-Sleep -Milliseconds 500")
+	default:
+		path, err := exec.LookPath("paplay")
+		if err != nil {
+			return fmt.Errorf("no audio player found (install pulseaudio-utils)")
+		}
+		cmd = exec.Command(path, "--volume="+strconv.Itoa(volume*65536/100), "/usr/share/sounds/freedesktop/stereo/complete.oga")
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to play chime: %w", err)
+	}
+	return nil
+}
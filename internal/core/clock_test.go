@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock_After(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ch := clock.After(2 * time.Second)
+
+	clock.Advance(time.Second)
+	select {
+	case <-ch:
+		t.Fatal("Expected timer not to fire before its deadline")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case fired := <-ch:
+		if !fired.Equal(time.Unix(2, 0)) {
+			t.Errorf("Expected timer to fire at 2s, got %v", fired)
+		}
+	default:
+		t.Fatal("Expected timer to fire at its deadline")
+	}
+
+	if got := clock.Since(time.Unix(0, 0)); got != 2*time.Second {
+		t.Errorf("Expected 2s elapsed, got %v", got)
+	}
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ticker := clock.NewTicker(time.Second)
+
+	// A ticker that falls behind delivers one tick
+	clock.Advance(3 * time.Second)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Fatal("Expected a single tick after falling behind")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-ticker.C():
+	default:
+		t.Fatal("Expected a tick after the next interval")
+	}
+
+	ticker.Stop()
+	clock.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("Expected no tick after Stop")
+	default:
+	}
+}
+
+func TestFakeClock_Sleep(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Minute)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	<-done
+}
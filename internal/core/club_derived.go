@@ -0,0 +1,103 @@
+package core
+
+import "math"
+
+// Face weights in the D-plane model of start direction: the ball starts
+// this share of the way from the path to where the face points. Irons and
+// wedges sit near the low end, woods near the high end, so the lie estimate
+// is given as the range between them.
+const (
+	minFaceWeight = 0.70
+	maxFaceWeight = 0.85
+)
+
+const (
+	// minLieEstimateLoft is the dynamic loft below which a tilted face barely
+	// turns the start direction, too little to read the lie from
+	minLieEstimateLoft = 15.0
+	// maxLieEstimate drops estimates further from the address lie than any
+	// real swing, which come from misreads rather than the lie
+	maxLieEstimate = 10.0
+)
+
+// LieRange is an estimated dynamic lie, in degrees from the club's lie at
+// address: positive with the toe up, negative with the toe down
+type LieRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Mid returns the middle of the range
+func (r LieRange) Mid() float64 {
+	return (r.Min + r.Max) / 2
+}
+
+// DeriveClubMetrics fills in the values coaches work from that the device
+// doesn't report: face to path, and with ball metrics from the same shot an
+// estimated dynamic lie, the smash factor, spin loft and launch efficiency.
+func DeriveClubMetrics(club *ClubMetrics, ball *BallMetrics, handedness HandednessType) {
+	if club == nil {
+		return
+	}
+	if ball != nil && (!club.SameShot(ball) || !ball.IsBallSpeedValid || ball.ShotType == ShotTypePutt) {
+		ball = nil
+	}
+	club.deriveFaceToPath()
+	club.deriveDynamicLie(ball, handedness)
+	club.deriveEfficiency(ball)
+}
+
+func (club *ClubMetrics) deriveFaceToPath() {
+	club.IsFaceToPathValid = club.IsFaceAngleValid && club.IsPathAngleValid
+	club.FaceToPath = 0
+	if club.IsFaceToPathValid {
+		club.FaceToPath = roundTenth(club.FaceAngle - club.PathAngle)
+	}
+}
+
+// deriveDynamicLie reads the lie from the start direction. A lofted face
+// tilted toe up points left of where it is aimed for a right handed player,
+// and right for a left handed one, so whatever part of the start direction
+// the face angle and path don't explain is put down to the lie.
+func (club *ClubMetrics) deriveDynamicLie(ball *BallMetrics, handedness HandednessType) {
+	club.DynamicLie = nil
+	if ball == nil || !club.IsFaceToPathValid || !club.IsDynamicLoftValid || club.DynamicLoftAngle < minLieEstimateLoft {
+		return
+	}
+
+	toeUpTurn := -1.0 // toe up points the face left
+	if handedness == LeftHanded {
+		toeUpTurn = 1.0
+	}
+	lie := func(faceWeight float64) (float64, bool) {
+		pointing := (ball.HorizontalAngle - (1-faceWeight)*club.PathAngle) / faceWeight
+		turn := (pointing - club.FaceAngle) * toeUpTurn
+		sinLie := math.Tan(turn*math.Pi/180) / math.Tan(club.DynamicLoftAngle*math.Pi/180)
+		if math.Abs(sinLie) > math.Sin(maxLieEstimate*math.Pi/180) {
+			return 0, false
+		}
+		return math.Asin(sinLie) * 180 / math.Pi, true
+	}
+	low, ok := lie(minFaceWeight)
+	if !ok {
+		return
+	}
+	high, ok := lie(maxFaceWeight)
+	if !ok {
+		return
+	}
+	club.DynamicLie = &LieRange{Min: roundTenth(math.Min(low, high)), Max: roundTenth(math.Max(low, high))}
+}
+
+// deriveClubMetrics fills in the derived club values for the player up now
+func (lm *LaunchMonitor) deriveClubMetrics(club *ClubMetrics, ball *BallMetrics) {
+	handedness := RightHanded
+	if h := lm.stateManager.GetHandedness(); h != nil {
+		handedness = *h
+	}
+	DeriveClubMetrics(club, ball, handedness)
+}
+
+func roundTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,65 @@
+package core
+
+import "testing"
+
+func TestDeriveClubMetrics_FaceToPath(t *testing.T) {
+	club := &ClubMetrics{PathAngle: 3.2, FaceAngle: 1.1, IsPathAngleValid: true, IsFaceAngleValid: true}
+	DeriveClubMetrics(club, nil, RightHanded)
+	if !club.IsFaceToPathValid || club.FaceToPath != -2.1 {
+		t.Errorf("Expected face to path -2.1, got %v (valid %v)", club.FaceToPath, club.IsFaceToPathValid)
+	}
+	if club.DynamicLie != nil {
+		t.Errorf("Expected no lie estimate without ball metrics, got %+v", club.DynamicLie)
+	}
+
+	club.IsPathAngleValid = false
+	DeriveClubMetrics(club, nil, RightHanded)
+	if club.IsFaceToPathValid || club.FaceToPath != 0 {
+		t.Errorf("Expected no face to path without a valid path, got %v (valid %v)", club.FaceToPath, club.IsFaceToPathValid)
+	}
+}
+
+func TestDeriveClubMetrics_DynamicLie(t *testing.T) {
+	// A square face and path with the ball starting left: the toe was up for
+	// a right handed player and down for a left handed one
+	newShot := func(loft, startDirection float64) (*ClubMetrics, *BallMetrics) {
+		club := &ClubMetrics{
+			DynamicLoftAngle: loft, IsDynamicLoftValid: true,
+			IsPathAngleValid: true, IsFaceAngleValid: true,
+			ShotID: 7,
+		}
+		ball := &BallMetrics{BallSpeedMPS: 50, IsBallSpeedValid: true, HorizontalAngle: startDirection, ShotType: ShotTypeFull, ShotID: 7}
+		return club, ball
+	}
+
+	club, ball := newShot(30, -1)
+	DeriveClubMetrics(club, ball, RightHanded)
+	if club.DynamicLie == nil || club.DynamicLie.Min != 2.0 || club.DynamicLie.Max != 2.5 {
+		t.Fatalf("Expected toe up 2.0-2.5 degrees, got %+v", club.DynamicLie)
+	}
+
+	club, ball = newShot(30, -1)
+	DeriveClubMetrics(club, ball, LeftHanded)
+	if club.DynamicLie == nil || club.DynamicLie.Min != -2.5 || club.DynamicLie.Max != -2.0 {
+		t.Fatalf("Expected toe down 2.0-2.5 degrees for a left handed player, got %+v", club.DynamicLie)
+	}
+
+	tests := []struct {
+		name   string
+		change func(club *ClubMetrics, ball *BallMetrics)
+	}{
+		{"low loft", func(club *ClubMetrics, ball *BallMetrics) { club.DynamicLoftAngle = 10 }},
+		{"beyond any real lie", func(club *ClubMetrics, ball *BallMetrics) { ball.HorizontalAngle = -8 }},
+		{"another shot", func(club *ClubMetrics, ball *BallMetrics) { ball.ShotID = 8 }},
+		{"putt", func(club *ClubMetrics, ball *BallMetrics) { ball.ShotType = ShotTypePutt }},
+		{"invalid loft", func(club *ClubMetrics, ball *BallMetrics) { club.IsDynamicLoftValid = false }},
+	}
+	for _, tt := range tests {
+		club, ball := newShot(30, -1)
+		tt.change(club, ball)
+		DeriveClubMetrics(club, ball, RightHanded)
+		if club.DynamicLie != nil {
+			t.Errorf("%s: expected no lie estimate, got %+v", tt.name, club.DynamicLie)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"log"
+
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// Bounds for user-tuned smash factors
+const (
+	minSmashFactor = 1.0
+	maxSmashFactor = 1.6
+
+	// defaultSmashFactor is used for clubs without a configured smash factor
+	defaultSmashFactor = 1.35
+)
+
+// DefaultSmashFactors returns typical smash factors keyed by club name
+func DefaultSmashFactors() map[string]float64 {
+	return map[string]float64{
+		ClubDriver.Name():        1.48,
+		ClubWood3.Name():         1.46,
+		ClubWood5.Name():         1.45,
+		ClubWood7.Name():         1.43,
+		ClubIron4.Name():         1.40,
+		ClubIron5.Name():         1.38,
+		ClubIron6.Name():         1.36,
+		ClubIron7.Name():         1.33,
+		ClubIron8.Name():         1.31,
+		ClubIron9.Name():         1.28,
+		ClubPitchingWedge.Name(): 1.24,
+		ClubApproachWedge.Name(): 1.20,
+		ClubSandWedge.Name():     1.15,
+	}
+}
+
+// ValidSmashFactor reports whether a smash factor is in the usable range
+func ValidSmashFactor(smash float64) bool {
+	return smash >= minSmashFactor && smash <= maxSmashFactor
+}
+
+// EstimateClubSpeed derives club head speed in m/s from ball speed and the
+// club's smash factor, falling back to the typical smash factor for clubs
+// not in smashFactors. It returns false when no estimate can be made.
+func EstimateClubSpeed(club *ClubType, ballMetrics *BallMetrics, smashFactors map[string]float64) (float64, bool) {
+	if ballMetrics == nil || !ballMetrics.IsBallSpeedValid || ballMetrics.BallSpeedMPS <= 0 || ballMetrics.ShotType == ShotTypePutt {
+		return 0, false
+	}
+
+	smash := defaultSmashFactor
+	if club != nil {
+		if configured, ok := smashFactors[club.Name()]; ok && ValidSmashFactor(configured) {
+			smash = configured
+		} else if typical, ok := DefaultSmashFactors()[club.Name()]; ok {
+			smash = typical
+		}
+	}
+	return ballMetrics.BallSpeedMPS / smash, true
+}
+
+// SetSmashFactors replaces the per-club smash factors used to estimate club
+// speed. Clubs that are missing or out of range use the default for the club.
+func (lm *LaunchMonitor) SetSmashFactors(smashFactors map[string]float64) {
+	copied := make(map[string]float64, len(smashFactors))
+	for club, smash := range smashFactors {
+		copied[club] = smash
+	}
+
+	lm.clubSpeedMu.Lock()
+	defer lm.clubSpeedMu.Unlock()
+	lm.smashFactors = copied
+}
+
+// SetClubSpeedEstimationEnabled sets whether club speed is estimated for
+// shots the device reports without it
+func (lm *LaunchMonitor) SetClubSpeedEstimationEnabled(enabled bool) {
+	lm.clubSpeedMu.Lock()
+	defer lm.clubSpeedMu.Unlock()
+	lm.clubSpeedEstimation = enabled
+}
+
+// applyClubSpeedEstimation fills in club speed from the shot's ball speed
+// when the device did not measure it
+func (lm *LaunchMonitor) applyClubSpeedEstimation(clubMetrics *ClubMetrics, ballMetrics *BallMetrics) {
+	if clubMetrics == nil || (clubMetrics.IsClubSpeedValid && clubMetrics.ClubSpeed > 0) {
+		return
+	}
+
+	lm.clubSpeedMu.RLock()
+	enabled := lm.clubSpeedEstimation
+	smashFactors := lm.smashFactors
+	lm.clubSpeedMu.RUnlock()
+
+	if !enabled {
+		return
+	}
+
+	speed, ok := EstimateClubSpeed(lm.stateManager.GetClub(), ballMetrics, smashFactors)
+	if !ok {
+		return
+	}
+	clubMetrics.ClubSpeed = speed
+	clubMetrics.IsClubSpeedValid = true
+	clubMetrics.ClubSpeedEstimated = true
+	log.Printf("LaunchMonitor: Estimated %s club speed", i18n.Speed(speed, 1))
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+var homeClubData = []byte{
+	0x11, 0x07, 0x0d,
+	0x32, 0x00, // Path 0.5
+	0x14, 0x00, // Face 0.2
+	0x0A, 0x00, // Attack 0.1
+	0x28, 0x00, // DynLoft 0.4
+}
+
+func TestEstimateClubSpeed(t *testing.T) {
+	ball := &BallMetrics{BallSpeedMPS: 66.6, IsBallSpeedValid: true}
+	driver, iron7, unknown := ClubDriver, ClubIron7, ClubType{RegularCode: "ffff"}
+
+	tests := []struct {
+		name   string
+		club   *ClubType
+		smash  map[string]float64
+		want   float64
+		wantOK bool
+	}{
+		{"configured", &driver, map[string]float64{"Driver": 1.5}, 66.6 / 1.5, true},
+		{"typical when not configured", &iron7, map[string]float64{"Driver": 1.5}, 66.6 / 1.33, true},
+		{"typical when out of range", &driver, map[string]float64{"Driver": 3}, 66.6 / 1.48, true},
+		{"unknown club", &unknown, nil, 66.6 / defaultSmashFactor, true},
+		{"no club", nil, nil, 66.6 / defaultSmashFactor, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := EstimateClubSpeed(tt.club, ball, tt.smash)
+		if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: EstimateClubSpeed() = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if _, ok := EstimateClubSpeed(&driver, &BallMetrics{BallSpeedMPS: 60}, nil); ok {
+		t.Error("expected no estimate without a valid ball speed")
+	}
+	if _, ok := EstimateClubSpeed(&driver, &BallMetrics{BallSpeedMPS: 5, IsBallSpeedValid: true, ShotType: ShotTypePutt}, nil); ok {
+		t.Error("expected no estimate for a putt")
+	}
+}
+
+func TestClubSpeedEstimation_HomeDevice(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+	sm.SetDeviceType(DeviceTypeHome)
+	driver := ClubDriver
+	sm.SetClub(&driver)
+	sm.SetLastBallMetrics(&BallMetrics{BallSpeedMPS: 74, IsBallSpeedValid: true})
+
+	// Disabled by default
+	lm.NotificationHandler("", homeClubData)
+	if metrics := sm.GetLastClubMetrics(); metrics == nil || metrics.ClubSpeed != 0 || metrics.ClubSpeedEstimated {
+		t.Fatalf("Expected no club speed while estimation is disabled, got %+v", metrics)
+	}
+
+	lm.SetClubSpeedEstimationEnabled(true)
+	lm.SetSmashFactors(map[string]float64{"Driver": 1.48})
+	sm.SetLastClubMetrics(nil)
+	lm.NotificationHandler("", homeClubData)
+
+	metrics := sm.GetLastClubMetrics()
+	if metrics == nil || !metrics.ClubSpeedEstimated || !metrics.IsClubSpeedValid {
+		t.Fatalf("Expected an estimated club speed, got %+v", metrics)
+	}
+	if math.Abs(metrics.ClubSpeed-50) > 1e-9 {
+		t.Errorf("ClubSpeed = %v, want 50 m/s", metrics.ClubSpeed)
+	}
+}
+
+func TestClubSpeedEstimation_KeepsMeasuredSpeed(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+	lm.SetClubSpeedEstimationEnabled(true)
+	sm.SetLastBallMetrics(&BallMetrics{BallSpeedMPS: 74, IsBallSpeedValid: true})
+
+	measured := &ClubMetrics{ClubSpeed: 48, IsClubSpeedValid: true}
+	lm.publishClubMetrics(measured)
+
+	if metrics := sm.GetLastClubMetrics(); metrics.ClubSpeed != 48 || metrics.ClubSpeedEstimated {
+		t.Errorf("Expected the measured club speed to be kept, got %+v", metrics)
+	}
+}
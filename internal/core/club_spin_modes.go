@@ -0,0 +1,84 @@
+package core
+
+import "log"
+
+// ClubSpinModes overrides the spin mode setting for some clubs, keyed by
+// club name, e.g. standard for the putter and wedges and advanced for the
+// driver. The values are "standard" or "advanced".
+type ClubSpinModes map[string]string
+
+// Valid reports whether every club is known and every mode is standard or
+// advanced
+func (m ClubSpinModes) Valid() bool {
+	for club, mode := range m {
+		if _, ok := ClubByName(club); !ok {
+			return false
+		}
+		if mode != "standard" && mode != "advanced" {
+			return false
+		}
+	}
+	return true
+}
+
+// SetClubSpinModes replaces the per-club spin modes. If ball detection is
+// armed with a different mode than the current club now uses, it is re-armed.
+func (lm *LaunchMonitor) SetClubSpinModes(modes ClubSpinModes) {
+	copied := make(ClubSpinModes, len(modes))
+	for name, mode := range modes {
+		// Keyed by the name the device reports so lookups match
+		if club, ok := ClubByName(name); ok {
+			copied[club.Name()] = mode
+		}
+	}
+
+	lm.spinMu.Lock()
+	lm.clubSpinModes = copied
+	lm.spinMu.Unlock()
+
+	lm.reapplySpinMode()
+}
+
+// spinModeFor returns the club's spin mode override, or the spin mode
+// setting if it has none
+func (lm *LaunchMonitor) spinModeFor(club *ClubType) SpinMode {
+	if club != nil {
+		lm.spinMu.RLock()
+		mode, ok := lm.clubSpinModes[club.Name()]
+		lm.spinMu.RUnlock()
+		if ok {
+			if mode == "standard" {
+				return Standard
+			}
+			return Advanced
+		}
+	}
+	if spinMode := lm.stateManager.GetSpinMode(); spinMode != nil {
+		return *spinMode
+	}
+	return Advanced
+}
+
+// currentSpinMode is the spin mode for the selected club
+func (lm *LaunchMonitor) currentSpinMode() SpinMode {
+	return lm.spinModeFor(lm.stateManager.GetClub())
+}
+
+// reapplySpinMode re-arms ball detection when the selected club needs a
+// different spin mode than detection was armed with, so a club change from
+// GSPro takes effect without waiting for the next ready message
+func (lm *LaunchMonitor) reapplySpinMode() {
+	lm.detectStateMu.Lock()
+	active := lm.detectModeActive
+	armed := lm.armedSpinMode
+	lm.detectStateMu.Unlock()
+
+	spinMode := lm.currentSpinMode()
+	if !active || spinMode == armed {
+		return
+	}
+	log.Printf("LaunchMonitor: Re-arming ball detection for spin mode %d", spinMode)
+	if err := lm.ActivateBallDetection(); err != nil {
+		log.Printf("LaunchMonitor: Failed to re-arm ball detection: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import "testing"
+
+func TestClubSpinModes_Valid(t *testing.T) {
+	if !(ClubSpinModes{"putter": "standard", "Driver": "advanced"}).Valid() {
+		t.Error("Expected known clubs and modes to be valid")
+	}
+	if (ClubSpinModes{"Hybrid": "standard"}).Valid() {
+		t.Error("Expected an unknown club to be rejected")
+	}
+	if (ClubSpinModes{"Putter": "fast"}).Valid() {
+		t.Error("Expected an unknown spin mode to be rejected")
+	}
+}
+
+func TestClubSpinModes_ClubChangeRearmsDetection(t *testing.T) {
+	sm, lm, mockClient, btManager := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	lm.SetupNotifications(btManager)
+	lm.SetClubSpinModes(ClubSpinModes{"putter": "standard", "sand wedge": "standard"})
+
+	driver := ClubDriver
+	sm.SetClub(&driver)
+	sm.Flush()
+	if err := lm.ActivateBallDetection(); err != nil {
+		t.Fatalf("ActivateBallDetection() error = %v", err)
+	}
+
+	// The spin mode is the low nibble of the detect command's fifth byte
+	detectSpinMode := func() (int, bool) {
+		writes := mockClient.GetWriteHistory()
+		mockClient.ClearWriteHistory()
+		if len(writes) != 2 || writes[1].Data[1] != 0x81 {
+			return 0, false
+		}
+		return int(writes[1].Data[4] & 0x0f), true
+	}
+	if mode, ok := detectSpinMode(); !ok || mode != int(Advanced) {
+		t.Fatalf("Expected the driver to be armed in advanced mode, got %d", mode)
+	}
+
+	putter := ClubPutter
+	sm.SetClub(&putter)
+	sm.Flush()
+	if mode, ok := detectSpinMode(); !ok || mode != int(Standard) {
+		t.Fatalf("Expected the putter to re-arm detection in standard mode, got %d (ok=%v)", mode, ok)
+	}
+
+	// Same spin mode, so detection is left alone
+	sandWedge := ClubSandWedge
+	sm.SetClub(&sandWedge)
+	sm.Flush()
+	if writes := mockClient.GetWriteHistory(); len(writes) != 0 {
+		t.Fatalf("Expected no writes for a club with the same spin mode, got %d", len(writes))
+	}
+
+	// Clearing the overrides falls back to the spin mode setting
+	lm.SetClubSpinModes(nil)
+	if mode, ok := detectSpinMode(); !ok || mode != int(Advanced) {
+		t.Fatalf("Expected clearing the overrides to re-arm in advanced mode, got %d (ok=%v)", mode, ok)
+	}
+}
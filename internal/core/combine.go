@@ -0,0 +1,275 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// CombineStatus is where a combine is in its run
+type CombineStatus string
+
+const (
+	CombineRunning  CombineStatus = "running"
+	CombinePaused   CombineStatus = "paused"
+	CombineFinished CombineStatus = "finished"
+)
+
+// CombineRounds is how many times the combine works through its targets
+const CombineRounds = 3
+
+// Combine errors
+var (
+	ErrCombineNotRunning = errors.New("combine is not running")
+	ErrCombineNotPaused  = errors.New("combine is not paused")
+)
+
+// CombineTargets returns the carry distances each round works through,
+// shortest first
+func CombineTargets() []float64 {
+	targets := make([]float64, 0, 13)
+	for yards := 60.0; yards <= 180; yards += 10 {
+		targets = append(targets, yards)
+	}
+	return targets
+}
+
+// CombineBand scores a shot that finishes within MaxMissPercent of the
+// target distance
+type CombineBand struct {
+	Name           string  `json:"name"`
+	MaxMissPercent float64 `json:"maxMissPercent"`
+	Points         int     `json:"points"`
+}
+
+// CombineBands are the scoring bands from best to worst. A shot outside
+// the last band scores nothing. Measuring the miss against the distance
+// asks the same accuracy of a wedge and a long iron.
+var CombineBands = []CombineBand{
+	{Name: "Pin High", MaxMissPercent: 3, Points: 100},
+	{Name: "Excellent", MaxMissPercent: 6, Points: 85},
+	{Name: "Good", MaxMissPercent: 10, Points: 70},
+	{Name: "Fair", MaxMissPercent: 15, Points: 50},
+	{Name: "Poor", MaxMissPercent: 20, Points: 30},
+	{Name: "Miss", MaxMissPercent: 30, Points: 10},
+}
+
+// CombineShot is a scored combine shot
+type CombineShot struct {
+	Number       int       `json:"number"`
+	TargetYards  float64   `json:"targetYards"`
+	CarryYards   float64   `json:"carryYards"`
+	OfflineYards float64   `json:"offlineYards"`
+	MissYards    float64   `json:"missYards"`
+	Band         string    `json:"band,omitempty"` // empty outside every band
+	Points       int       `json:"points"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// CombineState is a combine's progress. TargetYards is 0 once it is
+// finished.
+type CombineState struct {
+	Profile     string        `json:"profile"`
+	Status      CombineStatus `json:"status"`
+	StartedAt   time.Time     `json:"startedAt"`
+	FinishedAt  *time.Time    `json:"finishedAt,omitempty"`
+	TargetYards float64       `json:"targetYards"`
+	Round       int           `json:"round"` // 1 based
+	Shots       []CombineShot `json:"shots"`
+	Total       int           `json:"total"` // shots in the combine
+	Score       float64       `json:"score"` // average points so far
+}
+
+// Combine runs a skills assessment: every target in CombineTargets, in
+// order, for CombineRounds rounds. Shots are ignored while it is paused. It
+// is not safe for concurrent use.
+type Combine struct {
+	state   CombineState
+	targets []float64
+}
+
+// NewCombine starts a combine
+func NewCombine(profile string) *Combine {
+	profile = strings.TrimSpace(profile)
+	if profile == "" {
+		profile = DefaultGameProfile
+	}
+	c := &Combine{targets: CombineTargets()}
+	c.state = CombineState{
+		Profile:   profile,
+		Status:    CombineRunning,
+		StartedAt: time.Now(),
+		Shots:     []CombineShot{},
+		Total:     len(c.targets) * CombineRounds,
+	}
+	c.next()
+	return c
+}
+
+// State returns a copy of the combine's progress
+func (c *Combine) State() CombineState {
+	state := c.state
+	state.Shots = append([]CombineShot(nil), c.state.Shots...)
+	if c.state.FinishedAt != nil {
+		finished := *c.state.FinishedAt
+		state.FinishedAt = &finished
+	}
+	return state
+}
+
+// Pause stops scoring shots until Resume
+func (c *Combine) Pause() error {
+	if c.state.Status != CombineRunning {
+		return ErrCombineNotRunning
+	}
+	c.state.Status = CombinePaused
+	return nil
+}
+
+// Resume scores shots again after Pause
+func (c *Combine) Resume() error {
+	if c.state.Status != CombinePaused {
+		return ErrCombineNotPaused
+	}
+	c.state.Status = CombineRunning
+	return nil
+}
+
+// Score scores a shot against the current target and moves on to the next.
+// Offline is positive to the right.
+func (c *Combine) Score(carryYards, offlineYards float64, at time.Time) (CombineShot, error) {
+	if c.state.Status != CombineRunning {
+		return CombineShot{}, ErrCombineNotRunning
+	}
+
+	target := c.state.TargetYards
+	miss := math.Hypot(carryYards-target, offlineYards)
+	shot := CombineShot{
+		Number:       len(c.state.Shots) + 1,
+		TargetYards:  target,
+		CarryYards:   roundYards(carryYards),
+		OfflineYards: roundYards(offlineYards),
+		MissYards:    roundYards(miss),
+		Timestamp:    at,
+	}
+	if band, ok := combineBand(target, miss); ok {
+		shot.Band = band.Name
+		shot.Points = band.Points
+	}
+	c.state.Shots = append(c.state.Shots, shot)
+	c.state.Score = combineScore(c.state.Shots)
+
+	if len(c.state.Shots) >= c.state.Total {
+		c.state.Status = CombineFinished
+		c.state.TargetYards = 0
+		finished := at
+		c.state.FinishedAt = &finished
+	} else {
+		c.next()
+	}
+	return shot, nil
+}
+
+func (c *Combine) next() {
+	shot := len(c.state.Shots)
+	c.state.TargetYards = c.targets[shot%len(c.targets)]
+	c.state.Round = shot/len(c.targets) + 1
+}
+
+// combineBand returns the best band a miss falls within
+func combineBand(targetYards, missYards float64) (CombineBand, bool) {
+	percent := missYards / targetYards * 100
+	for _, band := range CombineBands {
+		if percent <= band.MaxMissPercent {
+			return band, true
+		}
+	}
+	return CombineBand{}, false
+}
+
+func combineScore(shots []CombineShot) float64 {
+	if len(shots) == 0 {
+		return 0
+	}
+	points := 0
+	for _, shot := range shots {
+		points += shot.Points
+	}
+	return math.Round(float64(points)/float64(len(shots))*10) / 10
+}
+
+// CombineTargetReport summarizes the shots at one target
+type CombineTargetReport struct {
+	TargetYards      float64 `json:"targetYards"`
+	Shots            int     `json:"shots"`
+	Score            float64 `json:"score"` // average points
+	AverageMissYards float64 `json:"averageMissYards"`
+}
+
+// CombineReport is a combine's score, overall and by target, with how many
+// shots fell in each band
+type CombineReport struct {
+	Profile    string                `json:"profile"`
+	Status     CombineStatus         `json:"status"`
+	StartedAt  time.Time             `json:"startedAt"`
+	FinishedAt *time.Time            `json:"finishedAt,omitempty"`
+	Score      float64               `json:"score"`
+	Shots      int                   `json:"shots"`
+	Targets    []CombineTargetReport `json:"targets"` // shortest first
+	Bands      map[string]int        `json:"bands"`   // shots by band name
+	Best       *CombineTargetReport  `json:"best,omitempty"`
+	Worst      *CombineTargetReport  `json:"worst,omitempty"`
+}
+
+// Report summarizes the combine's shots. It can be taken at any point, but
+// is only final once the combine has finished.
+func (s CombineState) Report() CombineReport {
+	report := CombineReport{
+		Profile:    s.Profile,
+		Status:     s.Status,
+		StartedAt:  s.StartedAt,
+		FinishedAt: s.FinishedAt,
+		Score:      s.Score,
+		Shots:      len(s.Shots),
+		Targets:    []CombineTargetReport{},
+		Bands:      make(map[string]int),
+	}
+
+	for _, target := range CombineTargets() {
+		var shots []CombineShot
+		miss := 0.0
+		for _, shot := range s.Shots {
+			if shot.TargetYards == target {
+				shots = append(shots, shot)
+				miss += shot.MissYards
+			}
+		}
+		if len(shots) == 0 {
+			continue
+		}
+		report.Targets = append(report.Targets, CombineTargetReport{
+			TargetYards:      target,
+			Shots:            len(shots),
+			Score:            combineScore(shots),
+			AverageMissYards: roundYards(miss / float64(len(shots))),
+		})
+	}
+	for _, shot := range s.Shots {
+		if shot.Band != "" {
+			report.Bands[shot.Band]++
+		}
+	}
+
+	for _, target := range report.Targets {
+		if report.Best == nil || target.Score > report.Best.Score {
+			best := target
+			report.Best = &best
+		}
+		if report.Worst == nil || target.Score < report.Worst.Score {
+			worst := target
+			report.Worst = &worst
+		}
+	}
+	return report
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewCombine(t *testing.T) {
+	state := NewCombine(" ").State()
+	targets := CombineTargets()
+	if state.Profile != DefaultGameProfile || state.Status != CombineRunning {
+		t.Errorf("State() = %+v, want a running combine for the default profile", state)
+	}
+	if state.Total != len(targets)*CombineRounds || state.TargetYards != targets[0] || state.Round != 1 {
+		t.Errorf("State() = %+v, want %d shots starting at %v yards", state, len(targets)*CombineRounds, targets[0])
+	}
+}
+
+func TestCombineBand(t *testing.T) {
+	tests := []struct {
+		target, miss float64
+		band         string
+		points       int
+	}{
+		{100, 3, "Pin High", 100},
+		{100, 3.1, "Excellent", 85},
+		{60, 5, "Good", 70}, // 8.3%
+		{180, 5, "Pin High", 100},
+		{100, 30, "Miss", 10},
+		{100, 31, "", 0},
+	}
+	for _, tt := range tests {
+		band, ok := combineBand(tt.target, tt.miss)
+		if band.Name != tt.band || band.Points != tt.points || ok != (tt.band != "") {
+			t.Errorf("combineBand(%v, %v) = %+v, %v, want %s", tt.target, tt.miss, band, ok, tt.band)
+		}
+	}
+}
+
+func TestCombine_PauseResume(t *testing.T) {
+	c := NewCombine("Sam")
+	if err := c.Resume(); !errors.Is(err, ErrCombineNotPaused) {
+		t.Errorf("Resume() while running error = %v, want ErrCombineNotPaused", err)
+	}
+	if err := c.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if _, err := c.Score(60, 0, time.Now()); !errors.Is(err, ErrCombineNotRunning) {
+		t.Errorf("Score() while paused error = %v, want ErrCombineNotRunning", err)
+	}
+	if err := c.Pause(); !errors.Is(err, ErrCombineNotRunning) {
+		t.Errorf("Pause() while paused error = %v, want ErrCombineNotRunning", err)
+	}
+	if err := c.Resume(); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if state := c.State(); state.Status != CombineRunning || len(state.Shots) != 0 {
+		t.Errorf("State() = %+v, want running with no shots", state)
+	}
+}
+
+func TestCombine_Run(t *testing.T) {
+	c := NewCombine("Sam")
+	targets := CombineTargets()
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	// Perfect shots for the first round, then 10% short
+	for i := 0; i < len(targets)*CombineRounds; i++ {
+		state := c.State()
+		if want := targets[i%len(targets)]; state.TargetYards != want || state.Round != i/len(targets)+1 {
+			t.Fatalf("shot %d target = %v round %d, want %v round %d", i+1, state.TargetYards, state.Round, want, i/len(targets)+1)
+		}
+		carry := state.TargetYards
+		if i >= len(targets) {
+			carry *= 0.9
+		}
+		if _, err := c.Score(carry, 0, at); err != nil {
+			t.Fatalf("Score() error = %v", err)
+		}
+	}
+
+	state := c.State()
+	if state.Status != CombineFinished || state.TargetYards != 0 || state.FinishedAt == nil || !state.FinishedAt.Equal(at) {
+		t.Errorf("State() = %+v, want finished", state)
+	}
+	if _, err := c.Score(100, 0, at); !errors.Is(err, ErrCombineNotRunning) {
+		t.Errorf("Score() after the last shot error = %v, want ErrCombineNotRunning", err)
+	}
+	if err := c.Pause(); !errors.Is(err, ErrCombineNotRunning) {
+		t.Errorf("Pause() after the last shot error = %v, want ErrCombineNotRunning", err)
+	}
+
+	// One round of 100 and two of 70
+	if state.Score != 80 {
+		t.Errorf("Score = %v, want 80", state.Score)
+	}
+	report := state.Report()
+	if report.Shots != len(targets)*CombineRounds || len(report.Targets) != len(targets) {
+		t.Errorf("Report() = %+v, want every target", report)
+	}
+	if report.Bands["Pin High"] != len(targets) || report.Bands["Good"] != 2*len(targets) {
+		t.Errorf("Bands = %v, want %d Pin High and %d Good", report.Bands, len(targets), 2*len(targets))
+	}
+	if got := report.Targets[0]; got.TargetYards != 60 || got.Shots != CombineRounds || got.Score != 80 || got.AverageMissYards != 4 {
+		t.Errorf("Targets[0] = %+v, want 60 yards, 3 shots, 80 points, 4 yards", got)
+	}
+}
+
+func TestCombineReport_BestAndWorst(t *testing.T) {
+	c := NewCombine("Sam")
+	c.Score(60, 0, time.Now())  // 100
+	c.Score(50, 20, time.Now()) // 70 yards, 22 yards away: 0
+	c.Score(80, 4, time.Now())  // 5% away: 85
+
+	report := c.State().Report()
+	if report.Status != CombineRunning || report.FinishedAt != nil {
+		t.Errorf("Report() = %+v, want an unfinished report", report)
+	}
+	if report.Best == nil || report.Best.TargetYards != 60 || report.Worst == nil || report.Worst.TargetYards != 70 {
+		t.Errorf("Best = %+v, Worst = %+v, want 60 and 70 yards", report.Best, report.Worst)
+	}
+	if report.Score != 61.7 {
+		t.Errorf("Score = %v, want 61.7", report.Score)
+	}
+}
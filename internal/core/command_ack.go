@@ -0,0 +1,205 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// commandAckTimeout is how long a tracked command waits for its response
+const commandAckTimeout = 3 * time.Second
+
+// maxCommandResults bounds the results kept for lookup by sequence number
+const maxCommandResults = 64
+
+// ErrCommandNotAcknowledged is returned when the device does not respond to a
+// command in time
+var ErrCommandNotAcknowledged = errors.New("command not acknowledged by device")
+
+// AckMatcher reports whether a notification is the device's response to a
+// command
+type AckMatcher func(bytesList []string) bool
+
+// CommandResult is the outcome of one command
+type CommandResult struct {
+	Command  string
+	Sequence int
+	Written  bool // the BLE write succeeded
+	Acked    bool // the device responded
+	Err      error
+}
+
+// pendingAck is a written command waiting for its response
+type pendingAck struct {
+	sequence int
+	match    AckMatcher
+	done     chan struct{}
+}
+
+// ackTracker matches notifications to commands waiting for a response. The
+// device's responses do not echo the sequence number, so a response
+// acknowledges the oldest pending command it matches.
+type ackTracker struct {
+	mu      sync.Mutex
+	pending []*pendingAck
+	results map[int]CommandResult
+	order   []int
+}
+
+func (t *ackTracker) expect(sequence int, match AckMatcher) *pendingAck {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p := &pendingAck{sequence: sequence, match: match, done: make(chan struct{})}
+	t.pending = append(t.pending, p)
+	return p
+}
+
+func (t *ackTracker) cancel(target *pendingAck) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, p := range t.pending {
+		if p == target {
+			t.pending = append(t.pending[:i], t.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// observe resolves the oldest pending command the notification matches
+func (t *ackTracker) observe(bytesList []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, p := range t.pending {
+		if p.match(bytesList) {
+			t.pending = append(t.pending[:i], t.pending[i+1:]...)
+			close(p.done)
+			return
+		}
+	}
+}
+
+func (t *ackTracker) record(result CommandResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.results == nil {
+		t.results = make(map[int]CommandResult)
+	}
+	if _, exists := t.results[result.Sequence]; !exists {
+		t.order = append(t.order, result.Sequence)
+	}
+	t.results[result.Sequence] = result
+	for len(t.order) > maxCommandResults {
+		delete(t.results, t.order[0])
+		t.order = t.order[1:]
+	}
+}
+
+func (t *ackTracker) result(sequence int) (CommandResult, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result, ok := t.results[sequence]
+	return result, ok
+}
+
+// commandSequence reads the sequence byte from a command (11 <id> <seq> ...)
+func commandSequence(commandHex string) int {
+	if len(commandHex) < 6 {
+		return -1
+	}
+	sequence, err := strconv.ParseUint(commandHex[4:6], 16, 8)
+	if err != nil {
+		return -1
+	}
+	return int(sequence)
+}
+
+// notificationAck matches a notification by its two-byte type, for commands
+// answered by a dedicated response such as the OS version (11 10)
+func notificationAck(first, second string) AckMatcher {
+	return func(bytesList []string) bool {
+		return len(bytesList) >= 2 && bytesList[0] == first && bytesList[1] == second
+	}
+}
+
+// detectAck matches a status notification (11 03) reporting detect or ready
+// mode, the device's response to an activate detect command
+func (lm *LaunchMonitor) detectAck() AckMatcher {
+	statusIndex := 2
+	if lm.stateManager.GetDeviceType() == DeviceTypeOmni {
+		statusIndex = 3
+	}
+	return func(bytesList []string) bool {
+		if len(bytesList) <= statusIndex || bytesList[0] != "11" || bytesList[1] != "03" {
+			return false
+		}
+		return bytesList[statusIndex] == "03" || bytesList[statusIndex] == "04"
+	}
+}
+
+// SendCommandWithAck sends a command and waits up to timeout for the device
+// response matched by ack. The result is also kept for CommandResult.
+func (lm *LaunchMonitor) SendCommandWithAck(commandHex string, ack AckMatcher, timeout time.Duration) CommandResult {
+	result := CommandResult{Command: commandHex, Sequence: commandSequence(commandHex)}
+	pending := lm.acks.expect(result.Sequence, ack)
+
+	if err := lm.SendCommand(commandHex); err != nil {
+		lm.acks.cancel(pending)
+		result.Err = err
+		lm.acks.record(result)
+		return result
+	}
+	result.Written = true
+
+	select {
+	case <-pending.done:
+		result.Acked = true
+	case <-lm.clock.After(timeout):
+		lm.acks.cancel(pending)
+		result.Err = fmt.Errorf("%w: %s", ErrCommandNotAcknowledged, commandHex)
+	}
+	lm.acks.record(result)
+	return result
+}
+
+// sendTrackedCommand writes a command and waits for its response in the
+// background, so callers keep their existing latency. A missing response is
+// logged and recorded.
+func (lm *LaunchMonitor) sendTrackedCommand(commandHex string, ack AckMatcher) error {
+	if ack == nil {
+		return lm.SendCommand(commandHex)
+	}
+
+	result := CommandResult{Command: commandHex, Sequence: commandSequence(commandHex)}
+	pending := lm.acks.expect(result.Sequence, ack)
+
+	if err := lm.SendCommand(commandHex); err != nil {
+		lm.acks.cancel(pending)
+		result.Err = err
+		lm.acks.record(result)
+		return err
+	}
+	result.Written = true
+	lm.acks.record(result)
+
+	go func() {
+		select {
+		case <-pending.done:
+			result.Acked = true
+		case <-lm.clock.After(commandAckTimeout):
+			lm.acks.cancel(pending)
+			result.Err = fmt.Errorf("%w: %s", ErrCommandNotAcknowledged, commandHex)
+			log.Printf("LaunchMonitor: Device did not acknowledge command %s", commandHex)
+		}
+		lm.acks.record(result)
+	}()
+	return nil
+}
+
+// CommandResult returns the outcome of the command sent with sequence. A
+// tracked command reports Written until its response arrives or times out.
+func (lm *LaunchMonitor) CommandResult(sequence int) (CommandResult, bool) {
+	return lm.acks.result(sequence)
+}
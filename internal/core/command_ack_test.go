@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSendCommandWithAck_ResolvedByResponse(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	go func() {
+		deadline := time.Now().Add(time.Second)
+		for len(mockClient.GetWriteHistory()) == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		lm.NotificationHandler("", []byte{0x11, 0x10, 0x01, 0x09})
+	}()
+
+	result := lm.SendCommandWithAck(GetOSVersionCommand(0x2a), notificationAck("11", "10"), time.Second)
+	if result.Err != nil || !result.Written || !result.Acked {
+		t.Fatalf("Expected an acknowledged command, got %+v", result)
+	}
+	if result.Sequence != 0x2a {
+		t.Errorf("Expected sequence 0x2a, got %#x", result.Sequence)
+	}
+
+	recorded, ok := lm.CommandResult(0x2a)
+	if !ok || !recorded.Acked {
+		t.Errorf("Expected the result to be recorded by sequence, got %+v", recorded)
+	}
+}
+
+func TestSendCommandWithAck_TimesOutWithoutResponse(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	result := lm.SendCommandWithAck(GetChargeCommand(3), notificationAck("11", "06"), 20*time.Millisecond)
+	if !result.Written {
+		t.Error("Expected the write to succeed")
+	}
+	if result.Acked || !errors.Is(result.Err, ErrCommandNotAcknowledged) {
+		t.Errorf("Expected ErrCommandNotAcknowledged, got %+v", result)
+	}
+}
+
+func TestDetectAck_MatchesDetectStatus(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+
+	ack := lm.detectAck()
+	if !ack([]string{"11", "03", "03"}) {
+		t.Error("Expected a detect status to acknowledge the detect command")
+	}
+	if ack([]string{"11", "03", "01"}) {
+		t.Error("Expected an idle status not to acknowledge the detect command")
+	}
+
+	sm.SetDeviceType(DeviceTypeOmni)
+	omniAck := lm.detectAck()
+	if !omniAck([]string{"11", "03", "00", "04"}) {
+		t.Error("Expected an Omni ready status to acknowledge the detect command")
+	}
+}
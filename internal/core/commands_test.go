@@ -279,3 +279,23 @@ func TestClubTypeValues(t *testing.T) {
 		t.Errorf("ClubSandWedge has incorrect codes: %v", ClubSandWedge)
 	}
 }
+
+func TestClubTypeName(t *testing.T) {
+	tests := []struct {
+		club     ClubType
+		expected string
+	}{
+		{ClubDriver, "Driver"},
+		{ClubIron7, "7 Iron"},
+		{ClubSandWedge, "Sand Wedge"},
+		{ClubType{RegularCode: "ffff"}, "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if name := tt.club.Name(); name != tt.expected {
+				t.Errorf("ClubType{%s}.Name() = %s, want %s", tt.club.RegularCode, name, tt.expected)
+			}
+		})
+	}
+}
@@ -33,6 +33,16 @@ const (
 	InfiniteTeesStatusError        InfiniteTeesConnectionStatus = "error"
 )
 
+// AwesomeGolfConnectionStatus represents the current state of the Awesome Golf connection
+type AwesomeGolfConnectionStatus string
+
+const (
+	AwesomeGolfStatusDisconnected AwesomeGolfConnectionStatus = "disconnected"
+	AwesomeGolfStatusConnecting   AwesomeGolfConnectionStatus = "connecting"
+	AwesomeGolfStatusConnected    AwesomeGolfConnectionStatus = "connected"
+	AwesomeGolfStatusError        AwesomeGolfConnectionStatus = "error"
+)
+
 // MockMode represents the type of mock implementation to use
 type MockMode string
 
@@ -119,6 +129,44 @@ var (
 	ClubAlignmentStick = ClubType{RegularCode: "0008", SwingStickCode: "0008"}
 )
 
+// Name returns a display name for the club, or "Unknown" for unrecognized codes
+func (c ClubType) Name() string {
+	switch c.RegularCode {
+	case ClubPutter.RegularCode:
+		return "Putter"
+	case ClubDriver.RegularCode:
+		return "Driver"
+	case ClubWood3.RegularCode:
+		return "3 Wood"
+	case ClubWood5.RegularCode:
+		return "5 Wood"
+	case ClubWood7.RegularCode:
+		return "7 Wood"
+	case ClubIron4.RegularCode:
+		return "4 Iron"
+	case ClubIron5.RegularCode:
+		return "5 Iron"
+	case ClubIron6.RegularCode:
+		return "6 Iron"
+	case ClubIron7.RegularCode:
+		return "7 Iron"
+	case ClubIron8.RegularCode:
+		return "8 Iron"
+	case ClubIron9.RegularCode:
+		return "9 Iron"
+	case ClubPitchingWedge.RegularCode:
+		return "Pitching Wedge"
+	case ClubApproachWedge.RegularCode:
+		return "Approach Wedge"
+	case ClubSandWedge.RegularCode:
+		return "Sand Wedge"
+	case ClubAlignmentStick.RegularCode:
+		return "Alignment Stick"
+	default:
+		return "Unknown"
+	}
+}
+
 // ShotType represents the type of shot
 type ShotType string
 
@@ -167,6 +215,15 @@ const (
 	SerialNumberCharUUID    = "86602001-6b7e-439a-bdd1-489a3213e9bb"
 )
 
+// Standard GATT Device Information Service characteristic UUIDs
+const (
+	DISModelNumberCharUUID      = "00002a24-0000-1000-8000-00805f9b34fb"
+	DISSerialNumberCharUUID     = "00002a25-0000-1000-8000-00805f9b34fb"
+	DISFirmwareRevisionCharUUID = "00002a26-0000-1000-8000-00805f9b34fb"
+	DISHardwareRevisionCharUUID = "00002a27-0000-1000-8000-00805f9b34fb"
+	DISSoftwareRevisionCharUUID = "00002a28-0000-1000-8000-00805f9b34fb"
+)
+
 const (
 	// AppName is the consistent name used for directories and files
 	AppName = "SquareGolf Connector"
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"log"
+	"time"
+)
+
+// MaxShotCooldownMs bounds the quiet period after a shot
+const MaxShotCooldownMs = 10000
+
+// ValidShotCooldown reports whether a cooldown in milliseconds is within
+// range. 0 turns the cooldown off.
+func ValidShotCooldown(ms int) bool {
+	return ms >= 0 && ms <= MaxShotCooldownMs
+}
+
+// SetShotCooldown sets the quiet period after each device shot. During it the
+// ball is not reported ready and ball metrics are dropped, so a waggle or the
+// next ball being placed can't be sent to the simulator as another shot. 0
+// turns it off and ends any cooldown in progress.
+func (lm *LaunchMonitor) SetShotCooldown(period time.Duration) {
+	lm.cooldownMu.Lock()
+	lm.shotCooldown = period
+	ending := period == 0 && lm.clock.Now().Before(lm.cooldownUntil)
+	if ending {
+		lm.cooldownUntil = time.Time{}
+		lm.cooldownGen++
+	}
+	lm.cooldownMu.Unlock()
+
+	if ending {
+		lm.stateManager.SetShotCooldown(false)
+	}
+}
+
+// InShotCooldown reports whether the quiet period after a shot is running
+func (lm *LaunchMonitor) InShotCooldown() bool {
+	lm.cooldownMu.Lock()
+	defer lm.cooldownMu.Unlock()
+	return lm.clock.Now().Before(lm.cooldownUntil)
+}
+
+// startShotCooldown begins the quiet period after a shot, replacing any
+// cooldown still running
+func (lm *LaunchMonitor) startShotCooldown() {
+	lm.cooldownMu.Lock()
+	period := lm.shotCooldown
+	if period <= 0 {
+		lm.cooldownMu.Unlock()
+		return
+	}
+	lm.cooldownUntil = lm.clock.Now().Add(period)
+	lm.cooldownGen++
+	gen := lm.cooldownGen
+	lm.cooldownMu.Unlock()
+
+	lm.stateManager.SetShotCooldown(true)
+	lm.afterFunc(period, func() {
+		lm.cooldownMu.Lock()
+		current := gen == lm.cooldownGen
+		lm.cooldownMu.Unlock()
+		if current {
+			lm.stateManager.SetShotCooldown(false)
+		}
+	})
+}
+
+// dropInCooldown reports whether a notification should be ignored because
+// the cooldown after the last shot is running, logging what was dropped
+func (lm *LaunchMonitor) dropInCooldown(what string) bool {
+	if !lm.InShotCooldown() {
+		return false
+	}
+	log.Printf("LaunchMonitor: Ignored %s during the post-shot cooldown", what)
+	return true
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShotCooldown_IgnoresReadyAndShots(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	clock := NewFakeClock(time.Unix(0, 0))
+	lm.SetClock(clock)
+	lm.SetShotCooldown(2 * time.Second)
+
+	shot := func(speed byte) []byte {
+		return []byte{
+			0x11, 0x02, 0x37,
+			speed, 0x00,
+			0x00, 0x00,
+			0x00, 0x00,
+			0x00, 0x00,
+			0x00, 0x00,
+			0x00, 0x00,
+			0x00, 0x00,
+		}
+	}
+	ready := make([]byte, 17)
+	ready[0], ready[1], ready[3], ready[4] = 0x11, 0x01, 0x01, 0x01
+
+	lm.NotificationHandler("", shot(0xC8))
+	if !lm.InShotCooldown() || !sm.GetShotCooldown() {
+		t.Fatal("Expected a shot to start the cooldown")
+	}
+
+	lm.NotificationHandler("", ready)
+	if sm.GetBallReady() || sm.GetBallDetected() {
+		t.Error("Expected ball ready to be ignored during the cooldown")
+	}
+	lm.NotificationHandler("", shot(0xD0))
+	if metrics := sm.GetLastBallMetrics(); metrics == nil || metrics.BallSpeedMPS != 2 {
+		t.Errorf("Expected a shot during the cooldown to be dropped, got %+v", metrics)
+	}
+
+	clock.Advance(2 * time.Second)
+	deadline := time.Now().Add(time.Second)
+	for sm.GetShotCooldown() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if lm.InShotCooldown() || sm.GetShotCooldown() {
+		t.Fatal("Expected the cooldown to end")
+	}
+
+	lm.NotificationHandler("", ready)
+	if !sm.GetBallReady() {
+		t.Error("Expected ball ready once the cooldown ended")
+	}
+	lm.NotificationHandler("", shot(0xD0))
+	if metrics := sm.GetLastBallMetrics(); metrics == nil || metrics.BallSpeedMPS != 2.08 {
+		t.Errorf("Expected a shot after the cooldown to be published, got %+v", metrics)
+	}
+}
+
+func TestShotCooldown_OffByDefault(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	lm.NotificationHandler("", noSpinShot)
+	if lm.InShotCooldown() || sm.GetShotCooldown() {
+		t.Error("Expected no cooldown unless one is set")
+	}
+}
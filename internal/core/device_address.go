@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// deviceScanDuration is how long Scan listens for devices
+const deviceScanDuration = 5 * time.Second
+
+// ErrScanWhileConnected is returned when a scan is requested while a device
+// is connected or being connected to
+var ErrScanWhileConnected = errors.New("disconnect before scanning")
+
+// DiscoveredDevice is a launch monitor found by a scan
+type DiscoveredDevice struct {
+	Name    string     `json:"name"`
+	Address string     `json:"address"`
+	Type    DeviceType `json:"type,omitempty"` // empty if the device didn't advertise it
+}
+
+// scanResultLister is implemented by clients that report the address of each
+// device found by a scan
+type scanResultLister interface {
+	GetScanResultDevices() []DiscoveredDevice
+}
+
+var (
+	macAddressPattern = regexp.MustCompile(`^[0-9A-Fa-f]{2}(:[0-9A-Fa-f]{2}){5}$`)
+	// macOS identifies peripherals by a UUID instead of a MAC address
+	uuidAddressPattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}(-[0-9A-Fa-f]{4}){3}-[0-9A-Fa-f]{12}$`)
+)
+
+// ValidDeviceAddress reports whether address is a MAC address or a macOS
+// peripheral UUID. An empty address is valid and means scan by name.
+func ValidDeviceAddress(address string) bool {
+	return address == "" || macAddressPattern.MatchString(address) || uuidAddressPattern.MatchString(address)
+}
+
+// SetDeviceTypeHint sets the device type to assume when the client connects
+// by address without seeing the device's advertisement
+func (bm *BluetoothManager) SetDeviceTypeHint(deviceType DeviceType) {
+	bm.connectionMutex.Lock()
+	defer bm.connectionMutex.Unlock()
+	bm.deviceTypeHint = deviceType
+}
+
+// SetDeviceIdentifiedCallback sets a callback run when a connection learns
+// the device type from its advertisement, so it can be saved for later
+// connections by address
+func (bm *BluetoothManager) SetDeviceIdentifiedCallback(callback func(DeviceType)) {
+	bm.connectionMutex.Lock()
+	defer bm.connectionMutex.Unlock()
+	bm.onDeviceIdentified = callback
+}
+
+// detectDeviceType works out the device type after connecting. Without
+// manufacturer data the hint from an earlier connection is used.
+func (bm *BluetoothManager) detectDeviceType(mfgData string) DeviceType {
+	bm.connectionMutex.Lock()
+	hint := bm.deviceTypeHint
+	callback := bm.onDeviceIdentified
+	if mfgData != "" {
+		bm.deviceTypeHint = DetectDeviceType(mfgData)
+	}
+	bm.connectionMutex.Unlock()
+
+	if mfgData == "" {
+		if hint != "" {
+			log.Printf("BluetoothManager: No advertisement seen, assuming device type %s", hint)
+			return hint
+		}
+		return DetectDeviceType(mfgData)
+	}
+
+	deviceType := DetectDeviceType(mfgData)
+	if callback != nil && deviceType != hint {
+		callback(deviceType)
+	}
+	return deviceType
+}
+
+// Scan listens for launch monitors for a few seconds and returns the ones
+// found, sorted by name
+func (bm *BluetoothManager) Scan(ctx context.Context) ([]DiscoveredDevice, error) {
+	switch bm.stateManager.GetConnectionStatus() {
+	case ConnectionStatusConnected, ConnectionStatusConnecting, ConnectionStatusScanning:
+		return nil, ErrScanWhileConnected
+	}
+	client := bm.bluetoothClient
+	if client == nil {
+		return nil, errors.New("Failed to initialize Bluetooth")
+	}
+
+	if err := client.StartScan(BluetoothDevicePrefix); err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+	case <-bm.clock.After(deviceScanDuration):
+	}
+	client.StopScan()
+
+	var devices []DiscoveredDevice
+	if lister, ok := client.(scanResultLister); ok {
+		devices = lister.GetScanResultDevices()
+	} else {
+		for _, name := range client.GetDiscoveredDevices() {
+			devices = append(devices, DiscoveredDevice{Name: name})
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		if devices[i].Name != devices[j].Name {
+			return devices[i].Name < devices[j].Name
+		}
+		return devices[i].Address < devices[j].Address
+	})
+	return devices, nil
+}
+
+// connectedAddressReporter is implemented by clients that report the address
+// of the connected device
+type connectedAddressReporter interface {
+	GetConnectedDeviceAddress() string
+}
+
+// ConnectedDevice returns the name, address and type of the connected
+// device. The name is empty if it was connected to by address and the address
+// is empty if it was found by name, unless the client reports them.
+func (bm *BluetoothManager) ConnectedDevice() DiscoveredDevice {
+	bm.connectionMutex.Lock()
+	device := DiscoveredDevice{Name: bm.lastDeviceName, Address: bm.lastDeviceAddress}
+	client := bm.bluetoothClient
+	bm.connectionMutex.Unlock()
+
+	if deviceType := bm.stateManager.GetDeviceType(); deviceType != DeviceTypeUnknown {
+		device.Type = deviceType
+	}
+	if client == nil || !client.IsConnected() {
+		return device
+	}
+	if name := client.GetConnectedDeviceName(); name != "" {
+		device.Name = name
+	}
+	if reporter, ok := client.(connectedAddressReporter); ok {
+		if connected := reporter.GetConnectedDeviceAddress(); connected != "" {
+			device.Address = connected
+		}
+	}
+	return device
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidDeviceAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{"", true},
+		{"5A:5A:5A:00:00:01", true},
+		{"5a:5a:5a:00:00:01", true},
+		{"1D2B3C4E-0000-4A5B-8C6D-0123456789AB", true},
+		{"5A:5A:5A:00:00", false},
+		{"5A-5A-5A-00-00-01", false},
+		{"SquareGolf(1234)", false},
+	}
+	for _, tt := range tests {
+		if got := ValidDeviceAddress(tt.address); got != tt.want {
+			t.Errorf("ValidDeviceAddress(%q) = %v, want %v", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestScan_ReturnsAddresses(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	bm := NewBluetoothManager(NewStateManager())
+	bm.SetClock(clock)
+	bm.SetClient(NewSimulatorBluetoothClient(SimulatorConfig{SimulateOmni: true}))
+
+	done := make(chan []DiscoveredDevice)
+	go func() {
+		devices, err := bm.Scan(context.Background())
+		if err != nil {
+			t.Errorf("Scan() error = %v", err)
+		}
+		done <- devices
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(deviceScanDuration)
+
+	devices := <-done
+	want := DiscoveredDevice{Name: "SquareGolf(****)", Address: SimulatedDeviceAddress, Type: DeviceTypeOmni}
+	if len(devices) != 1 || devices[0] != want {
+		t.Errorf("Scan() = %+v, want [%+v]", devices, want)
+	}
+}
+
+func TestScan_RefusedWhileConnected(t *testing.T) {
+	state := NewStateManager()
+	bm := NewBluetoothManager(state)
+	bm.SetClient(NewSimulatorBluetoothClient(SimulatorConfig{}))
+	state.SetConnectionStatus(ConnectionStatusConnected)
+
+	if _, err := bm.Scan(context.Background()); !errors.Is(err, ErrScanWhileConnected) {
+		t.Errorf("Scan() error = %v, want ErrScanWhileConnected", err)
+	}
+}
+
+func TestDetectDeviceType_UsesHintWithoutAdvertisement(t *testing.T) {
+	bm := NewBluetoothManager(NewStateManager())
+	var identified []DeviceType
+	bm.SetDeviceIdentifiedCallback(func(deviceType DeviceType) {
+		identified = append(identified, deviceType)
+	})
+
+	if got := bm.detectDeviceType(""); got != DeviceTypeHome {
+		t.Errorf("detectDeviceType() without a hint = %s, want home", got)
+	}
+
+	if got := bm.detectDeviceType(OmniManufacturerDataHex); got != DeviceTypeOmni {
+		t.Errorf("detectDeviceType(omni) = %s, want omni", got)
+	}
+	if got := bm.detectDeviceType(""); got != DeviceTypeOmni {
+		t.Errorf("detectDeviceType() after an Omni = %s, want omni", got)
+	}
+	if got := bm.detectDeviceType(OmniManufacturerDataHex); got != DeviceTypeOmni {
+		t.Errorf("detectDeviceType(omni) = %s, want omni", got)
+	}
+
+	if len(identified) != 1 || identified[0] != DeviceTypeOmni {
+		t.Errorf("identified = %v, want a single omni", identified)
+	}
+}
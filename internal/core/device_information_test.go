@@ -0,0 +1,44 @@
+package core
+
+import "testing"
+
+func TestReadDeviceInformation(t *testing.T) {
+	sm, _, mockClient, btManager := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	mockClient.readByUUID = map[string][]byte{
+		DISModelNumberCharUUID:      []byte("Omni\x00"),
+		DISSerialNumberCharUUID:     []byte("SN12345"),
+		DISFirmwareRevisionCharUUID: []byte("2.1.0 "),
+		DISHardwareRevisionCharUUID: []byte("HW3"),
+		DISSoftwareRevisionCharUUID: []byte("1.4.2"),
+	}
+
+	existing := "9.9.9"
+	sm.SetLauncherVersion(&existing)
+
+	info, err := btManager.ReadDeviceInformation()
+	if err != nil {
+		t.Fatalf("ReadDeviceInformation failed: %v", err)
+	}
+	if info.ModelNumber != "Omni" || info.SerialNumber != "SN12345" || info.FirmwareRevision != "2.1.0" {
+		t.Errorf("Unexpected device information: %+v", info)
+	}
+
+	if v := sm.GetFirmwareVersion(); v == nil || *v != "2.1.0" {
+		t.Errorf("Expected firmware version 2.1.0, got %v", v)
+	}
+	if v := sm.GetMMIVersion(); v == nil || *v != "HW3" {
+		t.Errorf("Expected MMI version HW3, got %v", v)
+	}
+	if v := sm.GetLauncherVersion(); v == nil || *v != "9.9.9" {
+		t.Errorf("Expected existing launcher version to be kept, got %v", v)
+	}
+}
+
+func TestReadDeviceInformation_NotConnected(t *testing.T) {
+	_, _, _, btManager := newTestLaunchMonitor(t)
+
+	if _, err := btManager.ReadDeviceInformation(); err == nil {
+		t.Error("Expected error when not connected")
+	}
+}
@@ -0,0 +1,127 @@
+package core
+
+import "fmt"
+
+// DeviceSettings are the device configuration values the connector can read
+// back and write. Altitude and temperature compensation have no known command
+// in the device protocol, so those adjustments are left to the simulator.
+type DeviceSettings struct {
+	SpinMode        string   `json:"spinMode"`
+	Handedness      string   `json:"handedness"`
+	SpeedUnit       string   `json:"speedUnit"`
+	DistanceUnit    string   `json:"distanceUnit"`
+	GreenSpeed      int      `json:"greenSpeed"`
+	CarryAdjustment int      `json:"carryAdjustment"`
+	Supported       []string `json:"supported"`
+}
+
+// Validate checks that every setting holds a value the device accepts
+func (s DeviceSettings) Validate() error {
+	if s.SpinMode != "standard" && s.SpinMode != "advanced" {
+		return fmt.Errorf("invalid spinMode: %q", s.SpinMode)
+	}
+	if s.Handedness != "right" && s.Handedness != "left" {
+		return fmt.Errorf("invalid handedness: %q", s.Handedness)
+	}
+	if s.SpeedUnit != "mps" && s.SpeedUnit != "mph" {
+		return fmt.Errorf("invalid speedUnit: %q", s.SpeedUnit)
+	}
+	if s.DistanceUnit != "meters" && s.DistanceUnit != "mixed" && s.DistanceUnit != "yards" {
+		return fmt.Errorf("invalid distanceUnit: %q", s.DistanceUnit)
+	}
+	if s.GreenSpeed < 8 || s.GreenSpeed > 13 {
+		return fmt.Errorf("invalid greenSpeed: %d", s.GreenSpeed)
+	}
+	if s.CarryAdjustment < -99 || s.CarryAdjustment > 99 {
+		return fmt.Errorf("invalid carryAdjustment: %d", s.CarryAdjustment)
+	}
+	return nil
+}
+
+// supportedDeviceSettings lists the settings the device type accepts.
+// Units, green speed and carry adjustment are Omni-only commands.
+func supportedDeviceSettings(deviceType DeviceType) []string {
+	supported := []string{"spinMode", "handedness"}
+	if deviceType == DeviceTypeOmni {
+		supported = append(supported, "speedUnit", "distanceUnit", "greenSpeed", "carryAdjustment")
+	}
+	return supported
+}
+
+// GetDeviceSettings reads the current device settings
+func (lm *LaunchMonitor) GetDeviceSettings() DeviceSettings {
+	settings := DeviceSettings{
+		SpinMode:        "advanced",
+		Handedness:      "right",
+		SpeedUnit:       "mps",
+		DistanceUnit:    "meters",
+		GreenSpeed:      lm.omniGreenSpeedFromState() + 8,
+		CarryAdjustment: lm.omniCarryAdjustmentFromState(),
+		Supported:       supportedDeviceSettings(lm.stateManager.GetDeviceType()),
+	}
+
+	if spinMode := lm.stateManager.GetSpinMode(); spinMode != nil && *spinMode == Standard {
+		settings.SpinMode = "standard"
+	}
+	if handedness := lm.stateManager.GetHandedness(); handedness != nil && *handedness == LeftHanded {
+		settings.Handedness = "left"
+	}
+	if speedUnit := lm.stateManager.GetOmniSpeedUnit(); speedUnit != nil {
+		settings.SpeedUnit = *speedUnit
+	}
+	if distanceUnit := lm.stateManager.GetOmniDistanceUnit(); distanceUnit != nil {
+		settings.DistanceUnit = *distanceUnit
+	}
+
+	return settings
+}
+
+// UpdateDeviceSettings reads the current settings, applies modify and writes
+// back any values that changed. Nothing is written if modify fails or the
+// result is invalid.
+// Changes reach the device through the state callbacks registered in
+// SetupNotifications.
+func (lm *LaunchMonitor) UpdateDeviceSettings(modify func(*DeviceSettings) error) (DeviceSettings, error) {
+	lm.deviceSettingsMu.Lock()
+	defer lm.deviceSettingsMu.Unlock()
+
+	current := lm.GetDeviceSettings()
+	updated := current
+	if err := modify(&updated); err != nil {
+		return current, err
+	}
+	updated.Supported = current.Supported
+
+	if err := updated.Validate(); err != nil {
+		return current, err
+	}
+
+	if updated.SpinMode != current.SpinMode {
+		spinMode := Advanced
+		if updated.SpinMode == "standard" {
+			spinMode = Standard
+		}
+		lm.stateManager.SetSpinMode(&spinMode)
+	}
+	if updated.Handedness != current.Handedness {
+		handedness := RightHanded
+		if updated.Handedness == "left" {
+			handedness = LeftHanded
+		}
+		lm.stateManager.SetHandedness(&handedness)
+	}
+	if updated.SpeedUnit != current.SpeedUnit {
+		lm.stateManager.SetOmniSpeedUnit(&updated.SpeedUnit)
+	}
+	if updated.DistanceUnit != current.DistanceUnit {
+		lm.stateManager.SetOmniDistanceUnit(&updated.DistanceUnit)
+	}
+	if updated.GreenSpeed != current.GreenSpeed {
+		lm.stateManager.SetOmniGreenSpeed(&updated.GreenSpeed)
+	}
+	if updated.CarryAdjustment != current.CarryAdjustment {
+		lm.stateManager.SetOmniCarryAdjustment(&updated.CarryAdjustment)
+	}
+
+	return lm.GetDeviceSettings(), nil
+}
@@ -0,0 +1,60 @@
+package core
+
+import "testing"
+
+func TestGetDeviceSettings_Defaults(t *testing.T) {
+	_, lm, _, _ := newTestLaunchMonitor(t)
+
+	settings := lm.GetDeviceSettings()
+	if settings.SpinMode != "advanced" || settings.Handedness != "right" || settings.SpeedUnit != "mps" ||
+		settings.DistanceUnit != "meters" || settings.GreenSpeed != 10 || settings.CarryAdjustment != 0 {
+		t.Errorf("Unexpected default settings: %+v", settings)
+	}
+	if len(settings.Supported) != 2 {
+		t.Errorf("Expected only spin mode and handedness on a Home device, got %v", settings.Supported)
+	}
+}
+
+func TestUpdateDeviceSettings(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	sm.SetDeviceType(DeviceTypeOmni)
+
+	settings, err := lm.UpdateDeviceSettings(func(s *DeviceSettings) error {
+		s.SpinMode = "standard"
+		s.Handedness = "left"
+		s.GreenSpeed = 12
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.SpinMode != "standard" || settings.Handedness != "left" || settings.GreenSpeed != 12 {
+		t.Errorf("Expected updated settings, got %+v", settings)
+	}
+	if spinMode := sm.GetSpinMode(); spinMode == nil || *spinMode != Standard {
+		t.Error("Expected spin mode to be written to state")
+	}
+	if greenSpeed := sm.GetOmniGreenSpeed(); greenSpeed == nil || *greenSpeed != 12 {
+		t.Error("Expected green speed to be written to state")
+	}
+	if len(settings.Supported) != 6 {
+		t.Errorf("Expected all settings supported on Omni, got %v", settings.Supported)
+	}
+}
+
+func TestUpdateDeviceSettings_InvalidLeavesStateUnchanged(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+
+	_, err := lm.UpdateDeviceSettings(func(s *DeviceSettings) error {
+		s.Handedness = "left"
+		s.GreenSpeed = 20
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if sm.GetHandedness() != nil || sm.GetOmniGreenSpeed() != nil {
+		t.Error("Expected no settings to be written")
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package core
+
+import "golang.org/x/sys/unix"
+
+// freeDiskSpace returns the bytes available to this user on the disk holding
+// path
+func freeDiskSpace(path string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,17 @@
+package core
+
+import "golang.org/x/sys/windows"
+
+// freeDiskSpace returns the bytes available to this user on the disk holding
+// path
+func freeDiskSpace(path string) (uint64, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(name, &free, nil, nil); err != nil {
+		return 0, err
+	}
+	return free, nil
+}
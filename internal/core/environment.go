@@ -0,0 +1,154 @@
+package core
+
+import (
+	"log"
+	"math"
+
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// EnvironmentMode controls what the environmental adjustment does to a shot
+type EnvironmentMode string
+
+const (
+	// EnvironmentOff leaves shots as measured
+	EnvironmentOff EnvironmentMode = "off"
+	// EnvironmentAnnotate keeps the measured values and records the carry
+	// the shot would have in the entered conditions
+	EnvironmentAnnotate EnvironmentMode = "annotate"
+	// EnvironmentScale scales ball speed so the simulator's standard air
+	// gives the carry the shot would have in the entered conditions
+	EnvironmentScale EnvironmentMode = "scale"
+)
+
+// Limits for user-entered conditions
+const (
+	minAltitudeMeters = -500
+	maxAltitudeMeters = 5000
+	minTemperatureC   = -30
+	maxTemperatureC   = 50
+)
+
+const (
+	seaLevelPressurePa  = 101325
+	dryAirGasConstant   = 287.05
+	celsiusToKelvin     = 273.15
+	minBallSpeedScale   = 0.5
+	maxBallSpeedScale   = 1.5
+	ballSpeedScaleSteps = 40
+)
+
+// EnvironmentSettings holds the user-entered playing conditions
+type EnvironmentSettings struct {
+	Mode           EnvironmentMode `json:"mode"`
+	AltitudeMeters float64         `json:"altitudeMeters"`
+	TemperatureC   float64         `json:"temperatureC"`
+}
+
+// DefaultEnvironmentSettings returns the adjustment switched off, with sea
+// level and mild conditions ready to edit
+func DefaultEnvironmentSettings() EnvironmentSettings {
+	return EnvironmentSettings{Mode: EnvironmentOff, AltitudeMeters: 0, TemperatureC: 20}
+}
+
+// Valid reports whether the mode is known and the conditions are plausible
+func (s EnvironmentSettings) Valid() bool {
+	switch s.Mode {
+	case EnvironmentOff, EnvironmentAnnotate, EnvironmentScale:
+	default:
+		return false
+	}
+	return s.AltitudeMeters >= minAltitudeMeters && s.AltitudeMeters <= maxAltitudeMeters &&
+		s.TemperatureC >= minTemperatureC && s.TemperatureC <= maxTemperatureC
+}
+
+// EnvironmentAdjustment records how a shot was adjusted for the entered
+// conditions
+type EnvironmentAdjustment struct {
+	Mode               EnvironmentMode `json:"mode"`
+	AirDensity         float64         `json:"airDensity"`
+	StandardCarryYards float64         `json:"standardCarryYards"`
+	CarryYards         float64         `json:"carryYards"`
+	MeasuredSpeedMPS   float64         `json:"measuredSpeed,omitempty"` // set when ball speed was scaled
+}
+
+// AirDensity returns dry air density in kg/m³ at an altitude and temperature,
+// using the standard atmosphere for pressure
+func AirDensity(altitudeMeters, temperatureC float64) float64 {
+	pressure := seaLevelPressurePa * math.Pow(1-2.25577e-5*altitudeMeters, 5.25588)
+	return pressure / (dryAirGasConstant * (temperatureC + celsiusToKelvin))
+}
+
+// AdjustForEnvironment applies settings to a full shot with a valid ball
+// speed. It returns false when the shot was left unchanged.
+func AdjustForEnvironment(ballMetrics *BallMetrics, settings EnvironmentSettings) bool {
+	if ballMetrics == nil || settings.Mode == EnvironmentOff || !settings.Valid() {
+		return false
+	}
+	if ballMetrics.ShotType == ShotTypePutt || !ballMetrics.IsBallSpeedValid || ballMetrics.BallSpeedMPS <= 0 {
+		return false
+	}
+
+	density := AirDensity(settings.AltitudeMeters, settings.TemperatureC)
+	adjustment := &EnvironmentAdjustment{
+		Mode:               settings.Mode,
+		AirDensity:         density,
+		StandardCarryYards: EstimateCarryYards(ballMetrics),
+		CarryYards:         estimateCarryYardsInAir(ballMetrics, density),
+	}
+
+	if settings.Mode == EnvironmentScale {
+		adjustment.MeasuredSpeedMPS = ballMetrics.BallSpeedMPS
+		ballMetrics.BallSpeedMPS *= ballSpeedScaleForCarry(ballMetrics, adjustment.CarryYards)
+	}
+	ballMetrics.Environment = adjustment
+	return true
+}
+
+// ballSpeedScaleForCarry finds the ball speed factor that gives the target
+// carry in standard air. Carry rises with ball speed, so a bisection
+// converges.
+func ballSpeedScaleForCarry(ballMetrics *BallMetrics, targetYards float64) float64 {
+	scaled := *ballMetrics
+	low, high := minBallSpeedScale, maxBallSpeedScale
+	for i := 0; i < ballSpeedScaleSteps; i++ {
+		mid := (low + high) / 2
+		scaled.BallSpeedMPS = ballMetrics.BallSpeedMPS * mid
+		if EstimateCarryYards(&scaled) < targetYards {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// ShotCarryYards returns a shot's carry, adjusted for the entered conditions
+// when the shot was adjusted
+func ShotCarryYards(ballMetrics *BallMetrics) float64 {
+	if ballMetrics != nil && ballMetrics.Environment != nil {
+		return ballMetrics.Environment.CarryYards
+	}
+	return EstimateCarryYards(ballMetrics)
+}
+
+// SetEnvironment sets the conditions shots are adjusted for
+func (lm *LaunchMonitor) SetEnvironment(settings EnvironmentSettings) {
+	lm.environmentMu.Lock()
+	defer lm.environmentMu.Unlock()
+	lm.environment = settings
+}
+
+// applyEnvironment adjusts a new shot for the configured conditions
+func (lm *LaunchMonitor) applyEnvironment(ballMetrics *BallMetrics) {
+	lm.environmentMu.RLock()
+	settings := lm.environment
+	lm.environmentMu.RUnlock()
+
+	if AdjustForEnvironment(ballMetrics, settings) {
+		adjustment := ballMetrics.Environment
+		log.Printf("LaunchMonitor: Adjusted shot for %.0fm and %.0f°C (air density %.3f), carry %s -> %s",
+			settings.AltitudeMeters, settings.TemperatureC, adjustment.AirDensity,
+			i18n.Distance(adjustment.StandardCarryYards, 1), i18n.Distance(adjustment.CarryYards, 1))
+	}
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func environmentTestShot() *BallMetrics {
+	return &BallMetrics{
+		BallSpeedMPS:     67,
+		VerticalAngle:    12,
+		TotalspinRPM:     2800,
+		BackspinRPM:      2800,
+		IsBallSpeedValid: true,
+		IsTotalSpinValid: true,
+		ShotType:         ShotTypeFull,
+	}
+}
+
+func TestAirDensity(t *testing.T) {
+	seaLevel := AirDensity(0, 15)
+	if math.Abs(seaLevel-1.225) > 0.002 {
+		t.Errorf("AirDensity(0, 15) = %.4f, want about 1.225", seaLevel)
+	}
+	if denver := AirDensity(1609, 15); denver >= seaLevel*0.9 {
+		t.Errorf("AirDensity(1609, 15) = %.4f, want well below sea level %.4f", denver, seaLevel)
+	}
+	if hot := AirDensity(0, 35); hot >= seaLevel {
+		t.Errorf("AirDensity(0, 35) = %.4f, want below %.4f", hot, seaLevel)
+	}
+}
+
+func TestAdjustForEnvironment_Annotate(t *testing.T) {
+	shot := environmentTestShot()
+	settings := EnvironmentSettings{Mode: EnvironmentAnnotate, AltitudeMeters: 1609, TemperatureC: 25}
+
+	if !AdjustForEnvironment(shot, settings) {
+		t.Fatal("AdjustForEnvironment() = false, want the shot annotated")
+	}
+	if shot.BallSpeedMPS != 67 {
+		t.Errorf("BallSpeedMPS = %v, annotate mode must not change measured values", shot.BallSpeedMPS)
+	}
+	adjustment := shot.Environment
+	if adjustment.CarryYards <= adjustment.StandardCarryYards {
+		t.Errorf("carry at altitude %.1f should exceed standard carry %.1f", adjustment.CarryYards, adjustment.StandardCarryYards)
+	}
+	if ShotCarryYards(shot) != adjustment.CarryYards {
+		t.Errorf("ShotCarryYards() = %.1f, want the adjusted %.1f", ShotCarryYards(shot), adjustment.CarryYards)
+	}
+}
+
+func TestAdjustForEnvironment_ScaleMatchesAdjustedCarry(t *testing.T) {
+	shot := environmentTestShot()
+	settings := EnvironmentSettings{Mode: EnvironmentScale, AltitudeMeters: 1609, TemperatureC: 25}
+
+	if !AdjustForEnvironment(shot, settings) {
+		t.Fatal("AdjustForEnvironment() = false, want the shot scaled")
+	}
+	if shot.Environment.MeasuredSpeedMPS != 67 || shot.BallSpeedMPS <= 67 {
+		t.Errorf("speed %v (measured %v), want a higher speed than the measured 67", shot.BallSpeedMPS, shot.Environment.MeasuredSpeedMPS)
+	}
+	if carry := EstimateCarryYards(shot); math.Abs(carry-shot.Environment.CarryYards) > 0.5 {
+		t.Errorf("standard-air carry of the scaled shot = %.1f, want %.1f", carry, shot.Environment.CarryYards)
+	}
+}
+
+func TestAdjustForEnvironment_LeavesShotsAlone(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings EnvironmentSettings
+		shot     func() *BallMetrics
+	}{
+		{"off", DefaultEnvironmentSettings(), environmentTestShot},
+		{"zero settings", EnvironmentSettings{}, environmentTestShot},
+		{"out of range", EnvironmentSettings{Mode: EnvironmentScale, AltitudeMeters: 9000}, environmentTestShot},
+		{"putt", EnvironmentSettings{Mode: EnvironmentScale, AltitudeMeters: 1609}, func() *BallMetrics {
+			shot := environmentTestShot()
+			shot.ShotType = ShotTypePutt
+			return shot
+		}},
+		{"invalid speed", EnvironmentSettings{Mode: EnvironmentScale, AltitudeMeters: 1609}, func() *BallMetrics {
+			shot := environmentTestShot()
+			shot.IsBallSpeedValid = false
+			return shot
+		}},
+	}
+
+	for _, tt := range tests {
+		shot := tt.shot()
+		if AdjustForEnvironment(shot, tt.settings) || shot.Environment != nil || shot.BallSpeedMPS != 67 {
+			t.Errorf("%s: shot was adjusted: %+v", tt.name, shot)
+		}
+	}
+}
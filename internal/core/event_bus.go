@@ -0,0 +1,261 @@
+package core
+
+import (
+	"log"
+	"runtime/debug"
+	"sync"
+)
+
+// defaultEventWorkers is how many subscribers of one event run at once
+const defaultEventWorkers = 4
+
+// maxQueuedEvents is how many events a topic holds for slow subscribers
+// before the oldest are dropped
+const maxQueuedEvents = 256
+
+// Topic identifies a stream of events carrying values of type T
+type Topic[T any] struct {
+	name       string
+	queue      string
+	latestOnly bool
+}
+
+// NewTopic creates a topic with its own queue. Topics with the same name
+// share subscribers.
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name, queue: name}
+}
+
+// NewLatestTopic creates a topic for a high-rate stream, such as the ball
+// position, where only the latest value matters. An event still waiting to
+// be delivered is replaced by the next one.
+func NewLatestTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name, queue: name, latestOnly: true}
+}
+
+// InQueue returns the topic delivered through the named queue, for topics
+// whose subscribers rely on seeing their events in the order published
+func (t Topic[T]) InQueue(queue string) Topic[T] {
+	t.queue = queue
+	return t
+}
+
+// Name returns the topic name
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// Subscription is the handle returned by Subscribe
+type Subscription struct {
+	bus     *EventBus
+	id      uint64
+	topic   string
+	handler func(any)
+	active  bool
+}
+
+// Unsubscribe stops delivery to the subscriber. An event already being
+// delivered may still reach it. Calling Unsubscribe more than once is safe.
+func (s *Subscription) Unsubscribe() {
+	if s == nil || s.bus == nil {
+		return
+	}
+	s.bus.unsubscribe(s)
+}
+
+// EventBus delivers published events to subscribers off the publisher's
+// goroutine. Each topic has its own bounded queue unless it shares one with
+// InQueue, so a slow subscriber only holds up its own queue. A queue's events
+// are delivered in publish order:
+// the subscribers of one event run concurrently on a bounded pool and all of
+// them finish before the next event is delivered. A panicking subscriber is
+// logged and does not affect the publisher or other subscribers.
+type EventBus struct {
+	mu          sync.Mutex
+	idle        *sync.Cond
+	nextID      uint64
+	subscribers map[string][]*Subscription
+	queues      map[string]*topicQueue
+	dispatching int // queues being dispatched
+	workers     int
+}
+
+// topicQueue holds the events of one or more topics waiting for delivery
+type topicQueue struct {
+	name        string
+	events      []queuedEvent
+	dispatching bool
+	dropped     int
+}
+
+// queuedEvent holds the subscribers at publish time, so a subscriber added
+// later does not see events published before it subscribed
+type queuedEvent struct {
+	topic       string
+	event       any
+	subscribers []*Subscription
+}
+
+// NewEventBus creates an event bus running at most workers subscribers of a
+// queue at once. A non-positive value uses the default.
+func NewEventBus(workers int) *EventBus {
+	if workers <= 0 {
+		workers = defaultEventWorkers
+	}
+	b := &EventBus{
+		subscribers: make(map[string][]*Subscription),
+		queues:      make(map[string]*topicQueue),
+		workers:     workers,
+	}
+	b.idle = sync.NewCond(&b.mu)
+	return b
+}
+
+// Subscribe registers handler for events published on topic
+func Subscribe[T any](bus *EventBus, topic Topic[T], handler func(T)) *Subscription {
+	return bus.subscribe(topic.name, func(event any) {
+		handler(event.(T))
+	})
+}
+
+// Publish queues event for delivery to the topic's subscribers and returns
+// without waiting for them
+func Publish[T any](bus *EventBus, topic Topic[T], event T) {
+	bus.publish(topic.name, topic.queue, topic.latestOnly, event)
+}
+
+func (b *EventBus) subscribe(topic string, handler func(any)) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	sub := &Subscription{bus: b, id: b.nextID, topic: topic, handler: handler, active: true}
+	b.subscribers[topic] = append(b.subscribers[topic], sub)
+	return sub
+}
+
+func (b *EventBus) unsubscribe(target *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	target.active = false
+	subs := b.subscribers[target.topic]
+	for i, sub := range subs {
+		if sub == target {
+			// Copy so queued events keep the slice they captured
+			b.subscribers[target.topic] = append(append([]*Subscription(nil), subs[:i]...), subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// SubscriberCount returns how many subscribers a topic has
+func (b *EventBus) SubscriberCount(topic string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers[topic])
+}
+
+func (b *EventBus) publish(topic, queue string, latestOnly bool, event any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs := b.subscribers[topic]
+	if len(subs) == 0 {
+		return
+	}
+
+	q, ok := b.queues[queue]
+	if !ok {
+		q = &topicQueue{name: queue}
+		b.queues[queue] = q
+	}
+
+	queued := queuedEvent{topic: topic, event: event, subscribers: subs}
+	last := len(q.events) - 1
+	switch {
+	case latestOnly && last >= 0 && q.events[last].topic == topic:
+		q.events[last] = queued
+	case len(q.events) >= maxQueuedEvents:
+		if q.dropped == 0 {
+			log.Printf("EventBus: Subscribers to %s are falling behind, dropping the oldest events", queue)
+		}
+		q.dropped++
+		q.events[0] = queuedEvent{}
+		q.events = append(q.events[1:], queued)
+	default:
+		q.events = append(q.events, queued)
+	}
+
+	if !q.dispatching {
+		q.dispatching = true
+		b.dispatching++
+		go b.dispatch(q)
+	}
+}
+
+// dispatch delivers a queue's events until it is empty
+func (b *EventBus) dispatch(q *topicQueue) {
+	sem := make(chan struct{}, b.workers)
+
+	for {
+		b.mu.Lock()
+		if len(q.events) == 0 {
+			if q.dropped > 0 {
+				log.Printf("EventBus: Dropped %d events for slow subscribers to %s", q.dropped, q.name)
+				q.dropped = 0
+			}
+			q.dispatching = false
+			b.dispatching--
+			if b.dispatching == 0 {
+				b.idle.Broadcast()
+			}
+			b.mu.Unlock()
+			return
+		}
+		next := q.events[0]
+		q.events[0] = queuedEvent{}
+		q.events = q.events[1:]
+		b.mu.Unlock()
+
+		var wg sync.WaitGroup
+		for _, sub := range next.subscribers {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(sub *Subscription) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				b.deliver(sub, next)
+			}(sub)
+		}
+		wg.Wait()
+	}
+}
+
+func (b *EventBus) deliver(sub *Subscription, next queuedEvent) {
+	b.mu.Lock()
+	active := sub.active
+	b.mu.Unlock()
+	if !active {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("EventBus: Subscriber %d to %s panicked: %v\nStack trace:\n%s", sub.id, next.topic, r, debug.Stack())
+		}
+	}()
+	sub.handler(next.event)
+}
+
+// Flush waits until every event published so far has been delivered. It must
+// not be called from a subscriber.
+func (b *EventBus) Flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.dispatching > 0 {
+		b.idle.Wait()
+	}
+}
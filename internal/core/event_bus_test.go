@@ -0,0 +1,300 @@
+package core
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBus_Unsubscribe(t *testing.T) {
+	bus := NewEventBus(0)
+	topic := NewTopic[int]("test")
+
+	var mu sync.Mutex
+	var received []int
+	sub := Subscribe(bus, topic, func(v int) {
+		mu.Lock()
+		received = append(received, v)
+		mu.Unlock()
+	})
+
+	Publish(bus, topic, 1)
+	bus.Flush()
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	Publish(bus, topic, 2)
+	bus.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || received[0] != 1 {
+		t.Errorf("Expected only the event before Unsubscribe, got %v", received)
+	}
+	if count := bus.SubscriberCount("test"); count != 0 {
+		t.Errorf("Expected no subscribers after Unsubscribe, got %d", count)
+	}
+}
+
+func TestEventBus_PanickingSubscriberIsIsolated(t *testing.T) {
+	bus := NewEventBus(0)
+	topic := NewTopic[string]("test")
+
+	Subscribe(bus, topic, func(string) {
+		panic("subscriber failure")
+	})
+	var got string
+	Subscribe(bus, topic, func(v string) {
+		got = v
+	})
+
+	Publish(bus, topic, "shot")
+	bus.Flush()
+
+	if got != "shot" {
+		t.Errorf("Expected the second subscriber to receive the event, got %q", got)
+	}
+}
+
+func TestEventBus_DeliversEachTopicInPublishOrder(t *testing.T) {
+	bus := NewEventBus(0)
+	first := NewTopic[int]("first")
+	second := NewTopic[int]("second")
+
+	var mu sync.Mutex
+	order := map[string][]int{}
+	record := func(topic string) func(int) {
+		return func(v int) {
+			mu.Lock()
+			order[topic] = append(order[topic], v)
+			mu.Unlock()
+		}
+	}
+	Subscribe(bus, first, record("first"))
+	Subscribe(bus, second, record("second"))
+
+	for i := 0; i < 50; i++ {
+		if i%2 == 0 {
+			Publish(bus, first, i)
+		} else {
+			Publish(bus, second, i)
+		}
+	}
+	bus.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for topic, start := range map[string]int{"first": 0, "second": 1} {
+		if len(order[topic]) != 25 {
+			t.Fatalf("Expected 25 %s events, got %d", topic, len(order[topic]))
+		}
+		for i, v := range order[topic] {
+			if want := start + 2*i; v != want {
+				t.Fatalf("Expected %s event %d at position %d, got %d", topic, want, i, v)
+			}
+		}
+	}
+}
+
+func TestEventBus_TopicsSharingAQueueKeepTheirOrder(t *testing.T) {
+	bus := NewEventBus(0)
+	level := NewTopic[int]("level").InQueue("battery")
+	charging := NewTopic[int]("charging").InQueue("battery")
+
+	var mu sync.Mutex
+	var order []int
+	record := func(v int) {
+		mu.Lock()
+		order = append(order, v)
+		mu.Unlock()
+	}
+	Subscribe(bus, level, record)
+	Subscribe(bus, charging, record)
+
+	for i := 0; i < 50; i++ {
+		if i%2 == 0 {
+			Publish(bus, level, i)
+		} else {
+			Publish(bus, charging, i)
+		}
+	}
+	bus.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 50 {
+		t.Fatalf("Expected 50 events, got %d", len(order))
+	}
+	for i, v := range order {
+		if v != i {
+			t.Fatalf("Expected event %d at position %d, got %d", i, i, v)
+		}
+	}
+}
+
+func TestEventBus_BlockedSubscriberDoesNotDelayOtherTopics(t *testing.T) {
+	bus := NewEventBus(1)
+	slow := NewTopic[int]("slow")
+	fast := NewTopic[int]("fast")
+
+	release := make(chan struct{})
+	blocked := make(chan struct{})
+	Subscribe(bus, slow, func(int) {
+		close(blocked)
+		<-release
+	})
+	received := make(chan int, 10)
+	Subscribe(bus, fast, func(v int) {
+		received <- v
+	})
+
+	Publish(bus, slow, 1)
+	<-blocked
+	for i := 0; i < 10; i++ {
+		Publish(bus, fast, i)
+	}
+
+	for i := 0; i < 10; i++ {
+		select {
+		case v := <-received:
+			if v != i {
+				t.Fatalf("Expected event %d, got %d", i, v)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Event %d was held up by the blocked subscriber", i)
+		}
+	}
+	close(release)
+	bus.Flush()
+}
+
+func TestEventBus_LatestTopicKeepsOnlyTheNewestPendingEvent(t *testing.T) {
+	bus := NewEventBus(0)
+	topic := NewLatestTopic[int]("position")
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var mu sync.Mutex
+	var received []int
+	Subscribe(bus, topic, func(v int) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		if v == 0 {
+			<-release
+		}
+		mu.Lock()
+		received = append(received, v)
+		mu.Unlock()
+	})
+
+	Publish(bus, topic, 0)
+	<-started
+	for i := 1; i <= 100; i++ {
+		Publish(bus, topic, i)
+	}
+	close(release)
+	bus.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 || received[0] != 0 || received[1] != 100 {
+		t.Errorf("Expected the event being delivered and the latest one, got %v", received)
+	}
+}
+
+func TestEventBus_QueueIsBounded(t *testing.T) {
+	bus := NewEventBus(0)
+	topic := NewTopic[int]("test")
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var mu sync.Mutex
+	var received []int
+	Subscribe(bus, topic, func(v int) {
+		if v == 0 {
+			started <- struct{}{}
+			<-release
+		}
+		mu.Lock()
+		received = append(received, v)
+		mu.Unlock()
+	})
+
+	Publish(bus, topic, 0)
+	<-started
+	total := maxQueuedEvents + 50
+	for i := 1; i <= total; i++ {
+		Publish(bus, topic, i)
+	}
+	close(release)
+	bus.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != maxQueuedEvents+1 {
+		t.Fatalf("Expected %d events, got %d", maxQueuedEvents+1, len(received))
+	}
+	if first, last := received[1], received[len(received)-1]; first != total-maxQueuedEvents+1 || last != total {
+		t.Errorf("Expected the newest events %d to %d kept, got %d to %d", total-maxQueuedEvents+1, total, first, last)
+	}
+}
+
+func TestEventBus_ReentrantPublishDoesNotDeadlock(t *testing.T) {
+	bus := NewEventBus(1)
+	topic := NewTopic[int]("test")
+
+	var mu sync.Mutex
+	total := 0
+	Subscribe(bus, topic, func(v int) {
+		mu.Lock()
+		total += v
+		mu.Unlock()
+		if v < 3 {
+			Publish(bus, topic, v+1)
+		}
+	})
+
+	Publish(bus, topic, 1)
+	bus.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if total != 6 {
+		t.Errorf("Expected events 1, 2 and 3 to be delivered, got total %d", total)
+	}
+}
+
+func TestStateManager_UnregisterCallback(t *testing.T) {
+	sm := NewStateManager()
+
+	calls := 0
+	sub := sm.RegisterBallDetectedCallback(func(oldValue, newValue bool) {
+		calls++
+	})
+
+	sm.SetBallDetected(true)
+	sm.Flush()
+	sm.UnregisterCallback(sub)
+	sm.SetBallDetected(false)
+	sm.Flush()
+
+	if calls != 1 {
+		t.Errorf("Expected 1 callback before unsubscribing, got %d", calls)
+	}
+}
+
+func TestStateManager_SubscriberMissesEarlierChanges(t *testing.T) {
+	sm := NewStateManager()
+	sm.SetDeviceType(DeviceTypeOmni)
+
+	called := false
+	sm.RegisterDeviceTypeCallback(func(oldValue, newValue DeviceType) {
+		called = true
+	})
+	sm.Flush()
+
+	if called {
+		t.Error("Expected a change published before registering not to reach the callback")
+	}
+}
@@ -0,0 +1,325 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+const (
+	// maxPending bounds the shots waiting to be sent; the oldest are dropped
+	// first when a destination is unreachable for a long time
+	maxPending = 5000
+	// maxBatch is the most rows sent to a sheet at once
+	maxBatch = 100
+
+	initialRetryDelay = 5 * time.Second
+	maxRetryDelay     = 5 * time.Minute
+	requestTimeout    = time.Minute
+)
+
+// Status describes the export for the web UI
+type Status struct {
+	Enabled      bool        `json:"enabled"`
+	Destination  Destination `json:"destination"`
+	Pending      int         `json:"pending"`
+	Exported     int         `json:"exported"`
+	Dropped      int         `json:"dropped"`
+	LastExportAt *time.Time  `json:"lastExportAt,omitempty"`
+	LastError    string      `json:"lastError,omitempty"`
+	LastErrorAt  *time.Time  `json:"lastErrorAt,omitempty"`
+	NextRetryAt  *time.Time  `json:"nextRetryAt,omitempty"`
+
+	// Err is the last error, for translating LastError
+	Err error `json:"-"`
+}
+
+// Exporter sends each stored shot to the configured destination in the
+// background, so a slow or unreachable destination never holds up a shot.
+// Shots that fail to send are kept and retried with a growing delay.
+type Exporter struct {
+	store *history.Store
+	http  *http.Client
+	clock core.Clock
+	wake  chan struct{}
+
+	mu         sync.Mutex
+	settings   Settings
+	generation int // bumped when the settings change
+	pending    []history.Shot
+	retryDelay time.Duration
+	nextRetry  time.Time
+	status     Status
+	listeners  []func(Status)
+
+	// Only used by the worker
+	sheets           *sheetsClient
+	s3               *s3Client
+	clientGeneration int
+}
+
+// New creates an exporter for the shots stored in store. It is off until
+// configured, and sends nothing until Run is started.
+func New(store *history.Store) *Exporter {
+	e := &Exporter{
+		store:            store,
+		http:             &http.Client{Timeout: requestTimeout},
+		clock:            core.RealClock(),
+		wake:             make(chan struct{}, 1),
+		settings:         DefaultSettings(),
+		clientGeneration: -1,
+	}
+	e.registerShotListener(store)
+	return e
+}
+
+// SetClock replaces the clock retries are timed by. Set it before Run starts.
+func (e *Exporter) SetClock(clock core.Clock) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.clock = clock
+}
+
+// Configure applies new settings. Turning the export off discards the shots
+// waiting to be sent.
+func (e *Exporter) Configure(settings Settings) {
+	e.mu.Lock()
+	e.settings = settings
+	e.generation++
+	if !settings.Enabled {
+		e.pending = nil
+	}
+	e.retryDelay = 0
+	e.nextRetry = time.Time{}
+	e.status.LastError = ""
+	e.status.LastErrorAt = nil
+	e.status.Err = nil
+	notify := e.notifyLocked()
+	e.mu.Unlock()
+
+	notify()
+	e.signal()
+}
+
+// Status returns the export's progress
+func (e *Exporter) Status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.statusLocked()
+}
+
+func (e *Exporter) statusLocked() Status {
+	status := e.status
+	status.Enabled = e.settings.Enabled
+	status.Destination = e.settings.Destination
+	status.Pending = len(e.pending)
+	if !e.nextRetry.IsZero() {
+		next := e.nextRetry
+		status.NextRetryAt = &next
+	}
+	return status
+}
+
+// RetryNow sends the waiting shots without waiting out the retry delay
+func (e *Exporter) RetryNow() {
+	e.mu.Lock()
+	e.nextRetry = time.Time{}
+	e.mu.Unlock()
+	e.signal()
+}
+
+// OnChange registers a listener called when the export's status changes
+func (e *Exporter) OnChange(listener func(Status)) {
+	e.mu.Lock()
+	e.listeners = append(e.listeners, listener)
+	e.mu.Unlock()
+}
+
+// notifyLocked returns a function that passes the current status to the
+// listeners. The caller holds mu and calls it after unlocking.
+func (e *Exporter) notifyLocked() func() {
+	status := e.statusLocked()
+	listeners := make([]func(Status), len(e.listeners))
+	copy(listeners, e.listeners)
+	return func() {
+		for _, listener := range listeners {
+			listener(status)
+		}
+	}
+}
+
+// enqueue queues a stored shot for sending
+func (e *Exporter) enqueue(shot history.Shot) {
+	e.mu.Lock()
+	if !e.settings.Enabled {
+		e.mu.Unlock()
+		return
+	}
+	e.pending = append(e.pending, shot)
+	if dropped := len(e.pending) - maxPending; dropped > 0 {
+		e.pending = append([]history.Shot(nil), e.pending[dropped:]...)
+		e.status.Dropped += dropped
+	}
+	notify := e.notifyLocked()
+	e.mu.Unlock()
+
+	notify()
+	e.signal()
+}
+
+func (e *Exporter) signal() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends queued shots until the app exits. It is meant to run in its own
+// goroutine.
+func (e *Exporter) Run() {
+	for {
+		e.mu.Lock()
+		ready := e.settings.Enabled && len(e.pending) > 0
+		wait := e.nextRetry.Sub(e.clock.Now())
+		clock := e.clock
+		e.mu.Unlock()
+
+		switch {
+		case !ready:
+			<-e.wake
+		case wait > 0:
+			select {
+			case <-e.wake:
+			case <-clock.After(wait):
+			}
+		default:
+			e.exportPending()
+		}
+	}
+}
+
+// exportPending sends the oldest waiting shots, keeping them for a retry if
+// they can't be sent
+func (e *Exporter) exportPending() {
+	e.mu.Lock()
+	settings := e.settings
+	generation := e.generation
+	batch := e.pending
+	if len(batch) > maxBatch {
+		batch = batch[:maxBatch]
+	}
+	batch = append([]history.Shot(nil), batch...)
+	e.mu.Unlock()
+
+	err := e.send(settings, generation, batch)
+
+	e.mu.Lock()
+	now := e.clock.Now()
+	if err != nil {
+		if e.retryDelay == 0 {
+			e.retryDelay = initialRetryDelay
+		} else if e.retryDelay *= 2; e.retryDelay > maxRetryDelay {
+			e.retryDelay = maxRetryDelay
+		}
+		e.nextRetry = now.Add(e.retryDelay)
+		e.status.LastError = err.Error()
+		e.status.LastErrorAt = &now
+		e.status.Err = err
+		log.Printf("Export: %v; retrying in %v", err, e.retryDelay)
+	} else {
+		e.removePendingLocked(batch)
+		e.retryDelay = 0
+		e.nextRetry = time.Time{}
+		e.status.Exported += len(batch)
+		e.status.LastExportAt = &now
+		e.status.LastError = ""
+		e.status.LastErrorAt = nil
+		e.status.Err = nil
+	}
+	notify := e.notifyLocked()
+	e.mu.Unlock()
+
+	notify()
+}
+
+// removePendingLocked drops sent shots from the queue. The caller holds mu.
+func (e *Exporter) removePendingLocked(sent []history.Shot) {
+	ids := make(map[int]bool, len(sent))
+	for _, shot := range sent {
+		ids[shot.ID] = true
+	}
+	kept := e.pending[:0]
+	for _, shot := range e.pending {
+		if !ids[shot.ID] {
+			kept = append(kept, shot)
+		}
+	}
+	e.pending = kept
+}
+
+// send exports a batch. A sheet gets a row per shot; S3 gets the whole
+// session's CSV again for each session with a new shot.
+func (e *Exporter) send(settings Settings, generation int, batch []history.Shot) error {
+	if err := e.connect(settings, generation); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	switch settings.Destination {
+	case DestinationSheets:
+		rows := make([][]string, len(batch))
+		for i, shot := range batch {
+			rows[i] = row(shot)
+		}
+		return e.sheets.append(ctx, rows)
+	case DestinationS3:
+		shots := e.store.Shots()
+		uploaded := make(map[string]bool)
+		for _, shot := range batch {
+			session := sessionOf(shots, shot)
+			key := e.s3.key(session[0].Timestamp)
+			if uploaded[key] {
+				continue
+			}
+			data, err := sessionCSV(session)
+			if err != nil {
+				return err
+			}
+			if err := e.s3.put(ctx, key, data, "text/csv"); err != nil {
+				return err
+			}
+			uploaded[key] = true
+		}
+		return nil
+	}
+	return errors.New("unknown export destination")
+}
+
+// connect creates the destination's client when the settings have changed
+// since it was last made
+func (e *Exporter) connect(settings Settings, generation int) error {
+	if e.clientGeneration == generation {
+		return nil
+	}
+	e.sheets, e.s3 = nil, nil
+
+	var err error
+	switch settings.Destination {
+	case DestinationSheets:
+		e.sheets, err = newSheetsClient(settings, e.http, e.clock)
+	case DestinationS3:
+		e.s3, err = newS3Client(settings, e.http, e.clock)
+	}
+	if err != nil {
+		return err
+	}
+	e.clientGeneration = generation
+	return nil
+}
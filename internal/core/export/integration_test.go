@@ -0,0 +1,145 @@
+package export
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+// s3Server is an S3-compatible store that fails the first failures uploads
+type s3Server struct {
+	*httptest.Server
+	mu       sync.Mutex
+	failures int
+	uploads  []string
+}
+
+func newS3Server(t *testing.T, failures int) *s3Server {
+	s := &s3Server{failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if r.Method != http.MethodPut || r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if s.failures > 0 {
+			s.failures--
+			http.Error(w, "SlowDown", http.StatusServiceUnavailable)
+			return
+		}
+		s.uploads = append(s.uploads, r.URL.Path)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *s3Server) uploaded() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uploads)
+}
+
+func newTestExporter(t *testing.T, server *s3Server, clock core.Clock) (*Exporter, *history.Store) {
+	t.Helper()
+	store := history.New(core.NewStateManager())
+	exporter := New(store)
+	exporter.SetClock(clock)
+	exporter.Configure(Settings{
+		Enabled:         true,
+		Destination:     DestinationS3,
+		Bucket:          "shots",
+		Region:          "us-east-1",
+		Endpoint:        server.URL,
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+	})
+	return exporter, store
+}
+
+func waitForStatus(t *testing.T, exporter *Exporter, what string, cond func(Status) bool) Status {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		status := exporter.Status()
+		if cond(status) {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s, status %+v", what, status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestExporter_RetriesWithBackoffUntilSent(t *testing.T) {
+	server := newS3Server(t, 2)
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	clock := core.NewFakeClock(start)
+	exporter, store := newTestExporter(t, server, clock)
+	go exporter.Run()
+
+	if _, err := store.Add(history.Shot{Timestamp: start, Club: "Driver"}); err != nil {
+		t.Fatal(err)
+	}
+
+	status := waitForStatus(t, exporter, "the first failure", func(s Status) bool { return s.NextRetryAt != nil })
+	if !status.NextRetryAt.Equal(start.Add(initialRetryDelay)) || status.LastError == "" || status.Pending != 1 {
+		t.Errorf("after the first failure: %+v, want a retry in %v", status, initialRetryDelay)
+	}
+
+	// Nothing is sent before the retry is due
+	clock.BlockUntil(1)
+	clock.Advance(initialRetryDelay - time.Second)
+	time.Sleep(20 * time.Millisecond)
+	if server.uploaded() != 0 {
+		t.Fatal("Expected no upload before the retry was due")
+	}
+
+	clock.Advance(time.Second)
+	status = waitForStatus(t, exporter, "the second failure", func(s Status) bool {
+		return s.NextRetryAt != nil && s.NextRetryAt.After(start.Add(initialRetryDelay))
+	})
+	if want := start.Add(3 * initialRetryDelay); !status.NextRetryAt.Equal(want) {
+		t.Errorf("second retry at %v, want the delay doubled to %v", status.NextRetryAt, want)
+	}
+
+	clock.BlockUntil(1)
+	clock.Advance(2 * initialRetryDelay)
+	status = waitForStatus(t, exporter, "the shot to be sent", func(s Status) bool { return s.Exported == 1 })
+	if status.Pending != 0 || status.NextRetryAt != nil || status.LastError != "" {
+		t.Errorf("after sending: %+v, want nothing pending and the error cleared", status)
+	}
+	if server.uploaded() != 1 {
+		t.Errorf("uploads = %d, want 1", server.uploaded())
+	}
+}
+
+func TestExporter_BackoffIsCapped(t *testing.T) {
+	server := newS3Server(t, 100)
+	clock := core.NewFakeClock(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	exporter, store := newTestExporter(t, server, clock)
+	if _, err := store.Add(history.Shot{Club: "7 Iron"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second,
+		80 * time.Second, 160 * time.Second, maxRetryDelay, maxRetryDelay}
+	for i, delay := range want {
+		exporter.exportPending()
+		if exporter.retryDelay != delay {
+			t.Errorf("attempt %d: retry delay = %v, want %v", i+1, exporter.retryDelay, delay)
+		}
+	}
+
+	// New settings start the backoff again
+	exporter.Configure(exporter.settings)
+	if status := exporter.Status(); status.NextRetryAt != nil || exporter.retryDelay != 0 {
+		t.Errorf("after reconfiguring: retry at %v, delay %v; want none", status.NextRetryAt, exporter.retryDelay)
+	}
+}
@@ -0,0 +1,9 @@
+package export
+
+import "github.com/brentyates/squaregolf-connector/internal/core/history"
+
+// registerShotListener queues each shot once it is stored, which is when its
+// club data and estimated carry are known
+func (e *Exporter) registerShotListener(store *history.Store) {
+	store.OnShot(e.enqueue)
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+// sessionGap is the pause between shots that starts a new session
+const sessionGap = 30 * time.Minute
+
+const mpsToMPH = 2.23694
+
+// columns heads each exported row
+var columns = []string{
+	"Time", "Club", "Ball Speed (mph)", "Launch Angle", "Launch Direction", "Total Spin (rpm)",
+	"Back Spin (rpm)", "Side Spin (rpm)", "Spin Axis", "Carry (yd)", "Offline (yd)",
+	"Club Speed (mph)", "Smash Factor", "Club Path", "Face Angle", "Attack Angle",
+}
+
+// row formats a shot for a spreadsheet. Club values the device didn't
+// measure are left empty.
+func row(shot history.Shot) []string {
+	ball := shot.Ball
+	values := []string{
+		shot.Timestamp.Local().Format("2006-01-02 15:04:05"),
+		shot.Club,
+		format(ball.BallSpeedMPS * mpsToMPH),
+		format(ball.VerticalAngle),
+		format(ball.HorizontalAngle),
+		strconv.Itoa(int(ball.TotalspinRPM)),
+		strconv.Itoa(int(ball.BackspinRPM)),
+		strconv.Itoa(int(ball.SidespinRPM)),
+		format(ball.SpinAxis),
+		format(shot.CarryYards),
+		format(shot.OfflineYards),
+		"", "", "", "", "",
+	}
+	if club := shot.ClubMetrics; club != nil {
+		if club.IsClubSpeedValid {
+			values[11] = format(club.ClubSpeed * mpsToMPH)
+		}
+		if club.IsSmashFactorValid {
+			values[12] = strconv.FormatFloat(club.SmashFactor, 'f', 2, 64)
+		}
+		if club.IsPathAngleValid {
+			values[13] = format(club.PathAngle)
+		}
+		if club.IsFaceAngleValid {
+			values[14] = format(club.FaceAngle)
+		}
+		if club.IsAttackAngleValid {
+			values[15] = format(club.AttackAngle)
+		}
+	}
+	return values
+}
+
+func format(value float64) string {
+	return strconv.FormatFloat(value, 'f', 1, 64)
+}
+
+// sessionOf returns the shots in the same session as shot: the run of shots
+// around it with no pause longer than sessionGap. shots are oldest first.
+func sessionOf(shots []history.Shot, shot history.Shot) []history.Shot {
+	index := -1
+	for i := range shots {
+		if shots[i].ID == shot.ID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return []history.Shot{shot}
+	}
+
+	start, end := index, index+1
+	for start > 0 && shots[start].Timestamp.Sub(shots[start-1].Timestamp) <= sessionGap {
+		start--
+	}
+	for end < len(shots) && shots[end].Timestamp.Sub(shots[end-1].Timestamp) <= sessionGap {
+		end++
+	}
+	return shots[start:end]
+}
+
+// sessionCSV formats a session's shots with a header row
+func sessionCSV(shots []history.Shot) ([]byte, error) {
+	var buf bytes.Buffer
+	out := csv.NewWriter(&buf)
+	out.Write(columns)
+	for _, shot := range shots {
+		out.Write(row(shot))
+	}
+	out.Flush()
+	return buf.Bytes(), out.Error()
+}
@@ -0,0 +1,200 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// s3Client uploads objects to an S3 bucket, signing each request with AWS
+// Signature Version 4
+type s3Client struct {
+	http      *http.Client
+	bucket    string
+	region    string
+	endpoint  *url.URL // nil for AWS itself
+	prefix    string
+	accessKey string
+	secretKey string
+	clock     core.Clock
+}
+
+func newS3Client(settings Settings, httpClient *http.Client, clock core.Clock) (*s3Client, error) {
+	client := &s3Client{
+		http:      httpClient,
+		clock:     clock,
+		bucket:    settings.Bucket,
+		region:    settings.Region,
+		prefix:    strings.Trim(settings.Prefix, "/"),
+		accessKey: settings.AccessKeyID,
+		secretKey: settings.SecretAccessKey,
+	}
+	if settings.Endpoint != "" {
+		endpoint, err := url.Parse(strings.TrimRight(settings.Endpoint, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+		}
+		client.endpoint = endpoint
+	}
+	return client, nil
+}
+
+// key names a session's CSV by when the session started
+func (c *s3Client) key(session time.Time) string {
+	name := "session-" + session.Local().Format("2006-01-02-150405") + ".csv"
+	if c.prefix == "" {
+		return name
+	}
+	return c.prefix + "/" + name
+}
+
+// objectURL addresses an object. AWS buckets are addressed by host; an
+// S3-compatible endpoint is addressed by path, which they all support.
+func (c *s3Client) objectURL(key string) *url.URL {
+	if c.endpoint == nil {
+		return &url.URL{
+			Scheme:  "https",
+			Host:    c.bucket + ".s3." + c.region + ".amazonaws.com",
+			Path:    "/" + key,
+			RawPath: "/" + awsEscape(key),
+		}
+	}
+	u := *c.endpoint
+	u.Path += "/" + c.bucket + "/" + key
+	u.RawPath = c.endpoint.EscapedPath() + "/" + awsEscape(c.bucket) + "/" + awsEscape(key)
+	return &u
+}
+
+// put uploads body, replacing any object already at key
+func (c *s3Client) put(ctx context.Context, key string, body []byte, contentType string) error {
+	target := c.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	c.sign(req, body, c.clock.Now().UTC())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("S3 upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("S3 upload failed: %w", responseError(resp))
+	}
+	return nil
+}
+
+// sign adds the Signature Version 4 headers to req. The host, content hash
+// and date headers are signed.
+func (c *s3Client) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	scope := now.Format("20060102") + "/" + c.region + "/s3/aws4_request"
+	toSign := stringToSign(amzDate, scope, canonicalRequest(req, payloadHash, amzDate))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, signedHeaders, c.signature(now, toSign)))
+}
+
+// signedHeaders are the headers sign covers, in the order they are signed
+const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+
+// canonicalRequest is the request in the form Signature Version 4 hashes
+func canonicalRequest(req *http.Request, payloadHash, amzDate string) string {
+	return strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+}
+
+// canonicalQuery sorts the query by name then value, with every name given
+// a value, even if empty
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var params []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+func stringToSign(amzDate, scope, canonicalRequest string) string {
+	return strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+}
+
+// signature signs with a key derived from the secret for the day and region
+func (c *s3Client) signature(now time.Time, stringToSign string) string {
+	key := hmacSHA256([]byte("AWS4"+c.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// awsEscape percent-encodes a path the way AWS signs it: everything but
+// unreserved characters and slashes
+func awsEscape(s string) string {
+	return uriEncode(s, false)
+}
+
+// uriEncode percent-encodes everything but unreserved characters, and
+// slashes unless encodeSlash is set, as query names and values need
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~', ch == '/' && !encodeSlash:
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Destination is where shots are exported
+type Destination string
+
+const (
+	// DestinationSheets appends a row for each shot to a Google Sheet
+	DestinationSheets Destination = "sheets"
+	// DestinationS3 uploads a CSV for each session to an S3 bucket or an
+	// S3-compatible store
+	DestinationS3 Destination = "s3"
+)
+
+// DefaultSheetName is the tab rows are appended to
+const DefaultSheetName = "Shots"
+
+// Settings configures the shot export. Credentials are supplied by the
+// user: a Google service account key file, or an S3 access key.
+type Settings struct {
+	Enabled     bool        `json:"enabled"`
+	Destination Destination `json:"destination"`
+
+	// Google Sheets. The sheet must be shared with the service account.
+	SpreadsheetID   string `json:"spreadsheetId"`
+	SheetName       string `json:"sheetName"`
+	CredentialsFile string `json:"credentialsFile"` // service account key (JSON)
+
+	// S3. Endpoint is only set for S3-compatible stores.
+	Bucket          string `json:"bucket"`
+	Region          string `json:"region"`
+	Endpoint        string `json:"endpoint"`
+	Prefix          string `json:"prefix"`
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"` // saved in the config, which only the user can read
+}
+
+// DefaultSettings returns the export turned off
+func DefaultSettings() Settings {
+	return Settings{Destination: DestinationSheets, SheetName: DefaultSheetName}
+}
+
+// Valid reports whether the destination is known and, when the export is
+// on, has what it needs to send shots
+func (s Settings) Valid() bool {
+	switch s.Destination {
+	case DestinationSheets:
+		return !s.Enabled || (strings.TrimSpace(s.SpreadsheetID) != "" && strings.TrimSpace(s.CredentialsFile) != "")
+	case DestinationS3:
+		if s.Endpoint != "" {
+			u, err := url.Parse(s.Endpoint)
+			if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+				return false
+			}
+		}
+		return !s.Enabled || (s.Bucket != "" && s.Region != "" && s.AccessKeyID != "" && s.SecretAccessKey != "")
+	}
+	return false
+}
+
+// Redacted returns the settings without the S3 secret, for showing in the
+// web UI
+func (s Settings) Redacted() Settings {
+	if s.SecretAccessKey != "" {
+		s.SecretAccessKey = RedactedSecret
+	}
+	return s
+}
+
+// RedactedSecret stands in for a saved secret. Saving it keeps the secret
+// unchanged.
+const RedactedSecret = "********"
@@ -0,0 +1,248 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+const (
+	sheetsScope   = "https://www.googleapis.com/auth/spreadsheets"
+	sheetsBaseURL = "https://sheets.googleapis.com/v4/spreadsheets/"
+	// tokenRefreshMargin renews an access token this long before it expires
+	tokenRefreshMargin = time.Minute
+)
+
+// serviceAccount is the part of a Google service account key file used to
+// sign in
+type serviceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// sheetsClient appends rows to a Google Sheet, signing in as a service
+// account with a signed JWT, which needs no library beyond the standard one
+type sheetsClient struct {
+	http          *http.Client
+	clock         core.Clock
+	account       serviceAccount
+	key           *rsa.PrivateKey
+	spreadsheetID string
+	sheetName     string
+	baseURL       string
+
+	token         string
+	tokenExpires  time.Time
+	headerChecked bool
+}
+
+func newSheetsClient(settings Settings, httpClient *http.Client, clock core.Clock) (*sheetsClient, error) {
+	data, err := os.ReadFile(settings.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the credentials file: %w", err)
+	}
+	var account serviceAccount
+	if err := json.Unmarshal(data, &account); err != nil || account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, errors.New("the credentials file is not a service account key")
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+	key, err := parsePrivateKey(account.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sheetName := strings.TrimSpace(settings.SheetName)
+	if sheetName == "" {
+		sheetName = DefaultSheetName
+	}
+	return &sheetsClient{
+		http:          httpClient,
+		clock:         clock,
+		account:       account,
+		key:           key,
+		spreadsheetID: strings.TrimSpace(settings.SpreadsheetID),
+		sheetName:     sheetName,
+		baseURL:       sheetsBaseURL,
+	}, nil
+}
+
+func parsePrivateKey(encoded string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil {
+		return nil, errors.New("the service account private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the service account private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the service account private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// accessToken returns a token for the Sheets API, signing in again when the
+// last one is about to expire
+func (c *sheetsClient) accessToken(ctx context.Context) (string, error) {
+	now := c.clock.Now()
+	if c.token != "" && now.Add(tokenRefreshMargin).Before(c.tokenExpires) {
+		return c.token, nil
+	}
+
+	assertion, err := c.signJWT(now)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := c.do(req, &token); err != nil {
+		return "", fmt.Errorf("Google sign-in failed: %w", err)
+	}
+	c.token = token.AccessToken
+	c.tokenExpires = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return c.token, nil
+}
+
+// signJWT signs the service account's request for a Sheets token
+func (c *sheetsClient) signJWT(now time.Time) (string, error) {
+	encode := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	unsigned := encode(map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + encode(map[string]interface{}{
+		"iss":   c.account.ClientEmail,
+		"scope": sheetsScope,
+		"aud":   c.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign in to Google: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// append adds rows to the end of the sheet, heading an empty sheet with
+// the column names first
+func (c *sheetsClient) append(ctx context.Context, rows [][]string) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !c.headerChecked {
+		empty, err := c.isEmpty(ctx, token)
+		if err != nil {
+			return err
+		}
+		if empty {
+			rows = append([][]string{columns}, rows...)
+		}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"values": rows})
+	if err != nil {
+		return err
+	}
+	endpoint := c.valuesURL("A1") + ":append?valueInputOption=USER_ENTERED&insertDataOption=INSERT_ROWS"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	if err := c.do(req, nil); err != nil {
+		return fmt.Errorf("Google Sheets append failed: %w", err)
+	}
+	c.headerChecked = true
+	return nil
+}
+
+// isEmpty reports whether the sheet has nothing in its first cell
+func (c *sheetsClient) isEmpty(ctx context.Context, token string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.valuesURL("A1"), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	var values struct {
+		Values [][]string `json:"values"`
+	}
+	if err := c.do(req, &values); err != nil {
+		return false, fmt.Errorf("Google Sheets read failed: %w", err)
+	}
+	return len(values.Values) == 0, nil
+}
+
+// valuesURL addresses a range of the export's tab
+func (c *sheetsClient) valuesURL(cells string) string {
+	a1 := "'" + strings.ReplaceAll(c.sheetName, "'", "''") + "'!" + cells
+	return c.baseURL + url.PathEscape(c.spreadsheetID) + "/values/" + url.PathEscape(a1)
+}
+
+// do sends req and decodes a JSON response into out, if given. An error
+// response is returned with the start of its body, which Google fills with
+// the reason.
+func (c *sheetsClient) do(req *http.Request, out interface{}) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return responseError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// responseError describes a failed response by its status and the start of
+// its body
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	message := strings.Join(strings.Fields(string(body)), " ")
+	if message == "" {
+		return errors.New(resp.Status)
+	}
+	return fmt.Errorf("%s (%s)", resp.Status, message)
+}
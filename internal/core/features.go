@@ -0,0 +1,119 @@
+package core
+
+import (
+	"errors"
+	"log"
+	"sync"
+)
+
+// Feature names an optional module that can be turned on and off while the
+// app runs
+type Feature string
+
+const (
+	// FeatureExternalCamera triggers swing cameras around each shot
+	FeatureExternalCamera Feature = "externalCamera"
+	// FeatureConnectServer accepts shots from other launch monitors over the
+	// GSPro Connect API
+	FeatureConnectServer Feature = "connectServer"
+	// FeatureGames runs target games, wedge practice and the combine
+	FeatureGames Feature = "games"
+	// FeatureRawCommands allows raw commands to be written to the device from
+	// the API, for working out undocumented parts of the protocol
+	FeatureRawCommands Feature = "rawCommands"
+	// FeatureDashboard polls other connectors to show every bay in a
+	// facility on one dashboard
+	FeatureDashboard Feature = "dashboard"
+)
+
+// ErrUnknownFeature is returned when a feature that doesn't exist is set
+var ErrUnknownFeature = errors.New("unknown feature")
+
+// DefaultFeatures returns every feature with the state it has when nothing
+// was saved
+func DefaultFeatures() map[Feature]bool {
+	return map[Feature]bool{
+		FeatureExternalCamera: false,
+		FeatureConnectServer:  false,
+		FeatureGames:          true,
+		FeatureRawCommands:    false,
+		FeatureDashboard:      false,
+	}
+}
+
+// FeatureFlags holds which optional modules are on. Modules that need to
+// start or stop when their flag changes register with OnChange.
+type FeatureFlags struct {
+	mu        sync.Mutex
+	flags     map[Feature]bool
+	listeners []func(feature Feature, enabled bool)
+}
+
+// NewFeatureFlags creates flags from the defaults with the given ones
+// applied. Unknown features are ignored, so a flag saved by a newer version
+// doesn't stop an older one starting.
+func NewFeatureFlags(flags map[Feature]bool) *FeatureFlags {
+	f := &FeatureFlags{flags: DefaultFeatures()}
+	for feature, enabled := range flags {
+		if _, ok := f.flags[feature]; ok {
+			f.flags[feature] = enabled
+		}
+	}
+	return f
+}
+
+// Enabled reports whether a feature is on
+func (f *FeatureFlags) Enabled(feature Feature) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.flags[feature]
+}
+
+// All returns the state of every feature
+func (f *FeatureFlags) All() map[Feature]bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	flags := make(map[Feature]bool, len(f.flags))
+	for feature, enabled := range f.flags {
+		flags[feature] = enabled
+	}
+	return flags
+}
+
+// Set turns a feature on or off and tells the listeners if it changed
+func (f *FeatureFlags) Set(feature Feature, enabled bool) error {
+	f.mu.Lock()
+	current, ok := f.flags[feature]
+	if !ok {
+		f.mu.Unlock()
+		return ErrUnknownFeature
+	}
+	if current == enabled {
+		f.mu.Unlock()
+		return nil
+	}
+	f.flags[feature] = enabled
+	listeners := make([]func(Feature, bool), len(f.listeners))
+	copy(listeners, f.listeners)
+	f.mu.Unlock()
+
+	log.Printf("Feature %s turned %s", feature, onOff(enabled))
+	for _, listener := range listeners {
+		listener(feature, enabled)
+	}
+	return nil
+}
+
+// OnChange registers a listener called after a feature is turned on or off
+func (f *FeatureFlags) OnChange(listener func(feature Feature, enabled bool)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listeners = append(f.listeners, listener)
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewFeatureFlagsAppliesSavedFlags(t *testing.T) {
+	flags := NewFeatureFlags(map[Feature]bool{
+		FeatureExternalCamera: true,
+		FeatureGames:          false,
+		"mqtt":                true,
+	})
+
+	if !flags.Enabled(FeatureExternalCamera) {
+		t.Error("external camera should be on when saved on")
+	}
+	if flags.Enabled(FeatureGames) {
+		t.Error("games should be off when saved off")
+	}
+	if flags.Enabled(FeatureConnectServer) {
+		t.Error("connect server should keep its default of off")
+	}
+	if _, ok := flags.All()["mqtt"]; ok {
+		t.Error("an unknown saved feature should be ignored")
+	}
+}
+
+func TestFeatureFlagsSetNotifiesOnChange(t *testing.T) {
+	flags := NewFeatureFlags(nil)
+	var changes []Feature
+	flags.OnChange(func(feature Feature, enabled bool) {
+		if enabled != flags.Enabled(feature) {
+			t.Errorf("listener for %s called with %v before the flag changed", feature, enabled)
+		}
+		changes = append(changes, feature)
+	})
+
+	if err := flags.Set(FeatureConnectServer, true); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := flags.Set(FeatureConnectServer, true); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if len(changes) != 1 || changes[0] != FeatureConnectServer {
+		t.Errorf("changes = %v, want a single connectServer change", changes)
+	}
+
+	if err := flags.Set("mqtt", true); !errors.Is(err, ErrUnknownFeature) {
+		t.Errorf("Set of an unknown feature = %v, want ErrUnknownFeature", err)
+	}
+	if len(changes) != 1 {
+		t.Errorf("an unknown feature notified listeners: %v", changes)
+	}
+}
@@ -0,0 +1,175 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+// maxCombineReports bounds the saved combine reports; the oldest are dropped
+// first
+const maxCombineReports = 200
+
+func (m *Manager) loadCombines() error {
+	data, err := os.ReadFile(m.combinePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &m.combineReports)
+}
+
+// saveCombinesLocked writes the combine reports. The caller holds mu.
+func (m *Manager) saveCombinesLocked() error {
+	if m.combinePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(m.combineReports, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.combinePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to save combine reports: %w", err)
+	}
+	return nil
+}
+
+// StartCombine begins a combine, abandoning anything in progress
+func (m *Manager) StartCombine(profile string) core.CombineState {
+	combine := core.NewCombine(profile)
+
+	m.mu.Lock()
+	notifyStopped := m.stopAllLocked()
+	m.combine = combine
+	state := combine.State()
+	m.mu.Unlock()
+
+	log.Printf("Games: started a combine for %s", state.Profile)
+	notifyStopped()
+	m.notifyCombine(&state)
+	return state
+}
+
+// PauseCombine stops scoring shots until ResumeCombine
+func (m *Manager) PauseCombine() (core.CombineState, error) {
+	return m.changeCombine((*core.Combine).Pause)
+}
+
+// ResumeCombine scores shots again after PauseCombine
+func (m *Manager) ResumeCombine() (core.CombineState, error) {
+	return m.changeCombine((*core.Combine).Resume)
+}
+
+func (m *Manager) changeCombine(change func(*core.Combine) error) (core.CombineState, error) {
+	m.mu.Lock()
+	if m.combine == nil {
+		m.mu.Unlock()
+		return core.CombineState{}, core.ErrCombineNotRunning
+	}
+	if err := change(m.combine); err != nil {
+		m.mu.Unlock()
+		return core.CombineState{}, err
+	}
+	state := m.combine.State()
+	m.mu.Unlock()
+
+	m.notifyCombine(&state)
+	return state, nil
+}
+
+// StopCombine abandons the combine in progress without saving its report
+func (m *Manager) StopCombine() {
+	m.mu.Lock()
+	stopped := m.combine != nil
+	m.combine = nil
+	m.mu.Unlock()
+
+	if stopped {
+		m.notifyCombine(nil)
+	}
+}
+
+// CombineState returns the current or last finished combine, or nil if there
+// is none
+func (m *Manager) CombineState() *core.CombineState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.combine == nil {
+		return nil
+	}
+	state := m.combine.State()
+	return &state
+}
+
+// CombineReports returns the saved reports, newest first. A profile limits
+// them to that player, matched without regard to case.
+func (m *Manager) CombineReports(profile string) []core.CombineReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	reports := make([]core.CombineReport, 0, len(m.combineReports))
+	for i := len(m.combineReports) - 1; i >= 0; i-- {
+		report := m.combineReports[i]
+		if profile == "" || strings.EqualFold(report.Profile, profile) {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
+
+// OnCombineChange registers a listener called with the combine's state after
+// it starts, pauses, resumes, scores a shot or stops. A stopped combine is
+// reported as nil.
+func (m *Manager) OnCombineChange(listener func(*core.CombineState)) {
+	m.mu.Lock()
+	m.combineListeners = append(m.combineListeners, listener)
+	m.mu.Unlock()
+}
+
+// scoreCombine scores a shot against the combine in progress, saving its
+// report when it finishes
+func (m *Manager) scoreCombine(shot history.Shot) {
+	m.mu.Lock()
+	if m.combine == nil {
+		m.mu.Unlock()
+		return
+	}
+	scored, err := m.combine.Score(shot.CarryYards, shot.OfflineYards, shot.Timestamp)
+	if err != nil {
+		// The combine is paused or already finished
+		m.mu.Unlock()
+		return
+	}
+	state := m.combine.State()
+	m.addTargetShotLocked(core.PracticeCombine, state.Profile, state.StartedAt, shot.Club, scored.TargetYards, shot)
+	if state.Status == core.CombineFinished {
+		m.combineReports = append(m.combineReports, state.Report())
+		if len(m.combineReports) > maxCombineReports {
+			m.combineReports = append([]core.CombineReport(nil), m.combineReports[len(m.combineReports)-maxCombineReports:]...)
+		}
+		if err := m.saveCombinesLocked(); err != nil {
+			log.Printf("Games: %v", err)
+		}
+		log.Printf("Games: %s finished a combine with a score of %.1f", state.Profile, state.Score)
+	}
+	m.mu.Unlock()
+
+	m.notifyCombine(&state)
+}
+
+func (m *Manager) notifyCombine(state *core.CombineState) {
+	m.mu.Lock()
+	listeners := make([]func(*core.CombineState), len(m.combineListeners))
+	copy(listeners, m.combineListeners)
+	m.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(state)
+	}
+}
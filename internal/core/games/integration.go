@@ -0,0 +1,244 @@
+package games
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+// maxResults bounds the saved results; the oldest are dropped first
+const maxResults = 1000
+
+// Manager runs one target game, wedge practice, combine or warmup at a time,
+// scoring each stored shot. It keeps finished games for the leaderboards,
+// wedge shots for each profile's matrix, combine reports, warmup summaries,
+// and every shot hit at a target for strokes gained.
+type Manager struct {
+	store     *history.Store
+	path      string
+	game      *core.Game
+	results   []core.GameResult
+	listeners []func(*core.GameState)
+
+	wedgePath      string
+	wedge          *core.WedgePractice
+	wedgeAttempts  map[string][]core.WedgeAttempt // by profile
+	wedgeListeners []func(*core.WedgePracticeState)
+
+	targetShotsPath string
+	targetShots     []core.TargetShot
+
+	combinePath      string
+	combine          *core.Combine
+	combineReports   []core.CombineReport
+	combineListeners []func(*core.CombineState)
+
+	warmupPath      string
+	warmup          *core.Warmup
+	warmups         []core.WarmupSummary
+	warmupListeners []func(*core.WarmupState)
+
+	mu sync.Mutex
+}
+
+// New creates a game manager scoring the shots stored in store. Results are
+// only kept in memory until SetDataDir gives it a directory.
+func New(store *history.Store) *Manager {
+	m := &Manager{
+		store:         store,
+		wedgeAttempts: make(map[string][]core.WedgeAttempt),
+	}
+	m.registerShotListener(store)
+	return m
+}
+
+// SetDataDir loads the results, wedge matrix, combine reports, warmups and
+// target shots saved in dataDir, and saves new ones there. Every file is
+// loaded even if an earlier one fails.
+func (m *Manager) SetDataDir(dataDir string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.path = filepath.Join(dataDir, "games.json")
+	m.wedgePath = filepath.Join(dataDir, "wedge_matrix.json")
+	m.combinePath = filepath.Join(dataDir, "combine.json")
+	m.warmupPath = filepath.Join(dataDir, "warmup.json")
+	m.targetShotsPath = filepath.Join(dataDir, "target_shots.jsonl")
+
+	var errs []error
+	if err := m.load(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to load results: %w", err))
+	}
+	if err := m.loadWedges(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to load wedge matrix: %w", err))
+	}
+	if err := m.loadCombines(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to load combine reports: %w", err))
+	}
+	if err := m.loadWarmups(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to load warmups: %w", err))
+	}
+	if err := m.loadTargetShots(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to load target shots: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) load() error {
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &m.results)
+}
+
+// saveLocked writes the results. The caller holds mu.
+func (m *Manager) saveLocked() error {
+	if m.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(m.results, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save game results: %w", err)
+	}
+	return nil
+}
+
+// Start begins a game, abandoning anything in progress
+func (m *Manager) Start(config core.GameConfig) (core.GameState, error) {
+	game, err := core.NewGame(config, nil)
+	if err != nil {
+		return core.GameState{}, err
+	}
+
+	m.mu.Lock()
+	notifyStopped := m.stopAllLocked()
+	m.game = game
+	state := game.State()
+	m.mu.Unlock()
+
+	log.Printf("Games: started %s for %s", state.Config.Mode, state.Config.Profile)
+	notifyStopped()
+	m.notify(&state)
+	return state, nil
+}
+
+// stopAllLocked abandons the game, wedge practice, combine and warmup, so
+// only one scores each shot. It returns a function that tells their listeners, to be
+// called once mu is released. The caller holds mu.
+func (m *Manager) stopAllLocked() func() {
+	stoppedGame := m.game != nil
+	stoppedWedge := m.wedge != nil
+	stoppedCombine := m.combine != nil
+	stoppedWarmup := m.stopWarmupLocked()
+	m.game = nil
+	m.wedge = nil
+	m.combine = nil
+
+	return func() {
+		if stoppedGame {
+			m.notify(nil)
+		}
+		if stoppedWedge {
+			m.notifyWedge(nil)
+		}
+		if stoppedCombine {
+			m.notifyCombine(nil)
+		}
+		if stoppedWarmup {
+			m.notifyWarmup(nil)
+		}
+	}
+}
+
+// Stop abandons the game in progress without recording it
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	stopped := m.game != nil
+	m.game = nil
+	m.mu.Unlock()
+
+	if stopped {
+		m.notify(nil)
+	}
+}
+
+// State returns the current or last finished game, or nil if there is none
+func (m *Manager) State() *core.GameState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.game == nil {
+		return nil
+	}
+	state := m.game.State()
+	return &state
+}
+
+// Leaderboard ranks each profile's best result in mode
+func (m *Manager) Leaderboard(mode core.GameMode) []core.GameResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return core.GameLeaderboard(m.results, mode)
+}
+
+// OnChange registers a listener called with the game's state after it
+// starts, scores a shot or stops. A stopped game is reported as nil.
+func (m *Manager) OnChange(listener func(*core.GameState)) {
+	m.mu.Lock()
+	m.listeners = append(m.listeners, listener)
+	m.mu.Unlock()
+}
+
+// score scores a shot against the game in progress, recording the game
+// when it finishes
+func (m *Manager) score(shot history.Shot) {
+	m.mu.Lock()
+	if m.game == nil {
+		m.mu.Unlock()
+		return
+	}
+	scored, err := m.game.Score(shot.CarryYards, shot.OfflineYards)
+	if err != nil {
+		// The game already finished
+		m.mu.Unlock()
+		return
+	}
+	state := m.game.State()
+	m.addTargetShotLocked(string(state.Config.Mode), state.Config.Profile, state.StartedAt, shot.Club, scored.Target.DistanceYards, shot)
+	if state.Finished {
+		m.results = append(m.results, state.Result())
+		if len(m.results) > maxResults {
+			m.results = append([]core.GameResult(nil), m.results[len(m.results)-maxResults:]...)
+		}
+		if err := m.saveLocked(); err != nil {
+			log.Printf("Games: %v", err)
+		}
+		log.Printf("Games: %s finished %s with a score of %d", state.Config.Profile, state.Config.Mode, state.Score)
+	}
+	m.mu.Unlock()
+
+	m.notify(&state)
+}
+
+func (m *Manager) notify(state *core.GameState) {
+	m.mu.Lock()
+	listeners := make([]func(*core.GameState), len(m.listeners))
+	copy(listeners, m.listeners)
+	m.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(state)
+	}
+}
@@ -0,0 +1,14 @@
+package games
+
+import "github.com/brentyates/squaregolf-connector/internal/core/history"
+
+// registerShotListener scores each shot once it is stored, which is when its
+// estimated carry and offline are known
+func (m *Manager) registerShotListener(store *history.Store) {
+	store.OnShot(func(shot history.Shot) {
+		m.score(shot)
+		m.recordWedge(shot)
+		m.scoreCombine(shot)
+		m.recordWarmup(shot)
+	})
+}
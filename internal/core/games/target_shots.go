@@ -0,0 +1,94 @@
+package games
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+// maxTargetShots bounds the target shots kept in memory; the file keeps them
+// all
+const maxTargetShots = 10000
+
+func (m *Manager) loadTargetShots() error {
+	file, err := os.Open(m.targetShotsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var shot core.TargetShot
+		if err := json.Unmarshal(scanner.Bytes(), &shot); err != nil {
+			continue
+		}
+		m.targetShots = append(m.targetShots, shot)
+	}
+	m.trimTargetShotsLocked()
+	return scanner.Err()
+}
+
+func (m *Manager) trimTargetShotsLocked() {
+	if len(m.targetShots) > maxTargetShots {
+		m.targetShots = append([]core.TargetShot(nil), m.targetShots[len(m.targetShots)-maxTargetShots:]...)
+	}
+}
+
+// addTargetShotLocked records a shot hit at a target and appends it to the
+// file. The caller holds mu.
+func (m *Manager) addTargetShotLocked(practice, profile string, session time.Time, club string, targetYards float64, shot history.Shot) {
+	target := core.TargetShot{
+		Practice:     practice,
+		Profile:      profile,
+		Session:      session,
+		Club:         club,
+		TargetYards:  targetYards,
+		CarryYards:   shot.CarryYards,
+		OfflineYards: shot.OfflineYards,
+		Timestamp:    shot.Timestamp,
+	}
+	m.targetShots = append(m.targetShots, target)
+	m.trimTargetShotsLocked()
+	if err := m.appendTargetShotLocked(target); err != nil {
+		log.Printf("Games: %v", err)
+	}
+}
+
+func (m *Manager) appendTargetShotLocked(shot core.TargetShot) error {
+	if m.targetShotsPath == "" {
+		return nil
+	}
+	data, err := json.Marshal(shot)
+	if err != nil {
+		return fmt.Errorf("failed to encode target shot: %w", err)
+	}
+
+	file, err := os.OpenFile(m.targetShotsPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open target shots: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write target shots: %w", err)
+	}
+	return nil
+}
+
+// TargetShots returns a copy of the shots hit at a target in any practice,
+// oldest first
+func (m *Manager) TargetShots() []core.TargetShot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]core.TargetShot(nil), m.targetShots...)
+}
@@ -0,0 +1,175 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+// maxWarmups bounds the saved warmup summaries; the oldest are dropped first
+const maxWarmups = 200
+
+func (m *Manager) loadWarmups() error {
+	data, err := os.ReadFile(m.warmupPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &m.warmups)
+}
+
+// saveWarmupsLocked writes the warmup summaries. The caller holds mu.
+func (m *Manager) saveWarmupsLocked() error {
+	if m.warmupPath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(m.warmups, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.warmupPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to save warmups: %w", err)
+	}
+	return nil
+}
+
+// StartWarmup begins a warmup, abandoning anything in progress. Shots are
+// tagged as warmup shots in the history until it finishes or is stopped.
+func (m *Manager) StartWarmup(config core.WarmupConfig) (core.WarmupState, error) {
+	warmup, err := core.NewWarmup(config)
+	if err != nil {
+		return core.WarmupState{}, err
+	}
+
+	m.mu.Lock()
+	notifyStopped := m.stopAllLocked()
+	m.warmup = warmup
+	state := warmup.State()
+	m.mu.Unlock()
+	m.setSession(core.SessionWarmup)
+
+	log.Printf("Games: started a warmup for %s", state.Config.Profile)
+	notifyStopped()
+	m.notifyWarmup(&state)
+	return state, nil
+}
+
+// StopWarmup ends the warmup in progress, keeping a summary of the shots
+// already hit
+func (m *Manager) StopWarmup() {
+	m.mu.Lock()
+	stopped := m.stopWarmupLocked()
+	m.mu.Unlock()
+
+	if stopped {
+		m.notifyWarmup(nil)
+	}
+}
+
+// stopWarmupLocked drops the warmup, saving its summary if it was stopped
+// early with shots hit. It reports whether there was a warmup. The caller
+// holds mu.
+func (m *Manager) stopWarmupLocked() bool {
+	if m.warmup == nil {
+		return false
+	}
+	state := m.warmup.State()
+	m.warmup = nil
+	if !state.Finished {
+		m.setSession("")
+		if len(state.Shots) > 0 {
+			m.addWarmupLocked(state.Summary())
+		}
+	}
+	return true
+}
+
+// WarmupState returns the current or last finished warmup, or nil if there
+// is none
+func (m *Manager) WarmupState() *core.WarmupState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.warmup == nil {
+		return nil
+	}
+	state := m.warmup.State()
+	return &state
+}
+
+// Warmups returns the saved warmup summaries, newest first
+func (m *Manager) Warmups() []core.WarmupSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	warmups := make([]core.WarmupSummary, len(m.warmups))
+	for i, warmup := range m.warmups {
+		warmups[len(m.warmups)-1-i] = warmup
+	}
+	return warmups
+}
+
+// OnWarmupChange registers a listener called with the warmup's state after
+// it starts, records a shot or stops. A stopped warmup is reported as nil.
+func (m *Manager) OnWarmupChange(listener func(*core.WarmupState)) {
+	m.mu.Lock()
+	m.warmupListeners = append(m.warmupListeners, listener)
+	m.mu.Unlock()
+}
+
+// recordWarmup records a shot against the warmup in progress, saving its
+// summary once the last club has been hit
+func (m *Manager) recordWarmup(shot history.Shot) {
+	m.mu.Lock()
+	if m.warmup == nil {
+		m.mu.Unlock()
+		return
+	}
+	if _, err := m.warmup.Record(shot.ID, shot.Ball.BallSpeedMPS, shot.CarryYards, shot.OfflineYards, shot.Timestamp); err != nil {
+		// The warmup already finished
+		m.mu.Unlock()
+		return
+	}
+	state := m.warmup.State()
+	if state.Finished {
+		m.setSession("")
+		m.addWarmupLocked(state.Summary())
+		log.Printf("Games: %s finished a warmup", state.Config.Profile)
+	}
+	m.mu.Unlock()
+
+	m.notifyWarmup(&state)
+}
+
+// addWarmupLocked saves a warmup summary. The caller holds mu.
+func (m *Manager) addWarmupLocked(summary core.WarmupSummary) {
+	m.warmups = append(m.warmups, summary)
+	if len(m.warmups) > maxWarmups {
+		m.warmups = append([]core.WarmupSummary(nil), m.warmups[len(m.warmups)-maxWarmups:]...)
+	}
+	if err := m.saveWarmupsLocked(); err != nil {
+		log.Printf("Games: %v", err)
+	}
+}
+
+// setSession tags the shots that follow in the history
+func (m *Manager) setSession(session string) {
+	if m.store != nil {
+		m.store.SetSession(session)
+	}
+}
+
+func (m *Manager) notifyWarmup(state *core.WarmupState) {
+	m.mu.Lock()
+	listeners := make([]func(*core.WarmupState), len(m.warmupListeners))
+	copy(listeners, m.warmupListeners)
+	m.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(state)
+	}
+}
@@ -0,0 +1,172 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+)
+
+// maxWedgeAttempts bounds the shots kept for each profile's matrix; the
+// oldest are dropped first so the matrix follows the player's current game
+const maxWedgeAttempts = 500
+
+func (m *Manager) loadWedges() error {
+	data, err := os.ReadFile(m.wedgePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &m.wedgeAttempts)
+}
+
+// saveWedgesLocked writes every profile's wedge shots. The caller holds mu.
+func (m *Manager) saveWedgesLocked() error {
+	if m.wedgePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(m.wedgeAttempts, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.wedgePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to save wedge matrix: %w", err)
+	}
+	return nil
+}
+
+// profileKeyLocked returns the saved profile matching profile without regard
+// to case, or profile itself if none does. The caller holds mu.
+func (m *Manager) profileKeyLocked(profile string) string {
+	for key := range m.wedgeAttempts {
+		if strings.EqualFold(key, profile) {
+			return key
+		}
+	}
+	return profile
+}
+
+// StartWedges begins a wedge practice, abandoning anything in progress
+func (m *Manager) StartWedges(config core.WedgePracticeConfig) (core.WedgePracticeState, error) {
+	practice, err := core.NewWedgePractice(config)
+	if err != nil {
+		return core.WedgePracticeState{}, err
+	}
+
+	m.mu.Lock()
+	notifyStopped := m.stopAllLocked()
+	m.wedge = practice
+	state := practice.State()
+	m.mu.Unlock()
+
+	log.Printf("Games: started wedge practice for %s", state.Config.Profile)
+	notifyStopped()
+	m.notifyWedge(&state)
+	return state, nil
+}
+
+// StopWedges ends the wedge practice in progress. Shots already hit stay in
+// the matrix.
+func (m *Manager) StopWedges() {
+	m.mu.Lock()
+	stopped := m.wedge != nil
+	m.wedge = nil
+	m.mu.Unlock()
+
+	if stopped {
+		m.notifyWedge(nil)
+	}
+}
+
+// WedgeState returns the current or last finished wedge practice, or nil if
+// there is none
+func (m *Manager) WedgeState() *core.WedgePracticeState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.wedge == nil {
+		return nil
+	}
+	state := m.wedge.State()
+	return &state
+}
+
+// WedgeMatrix summarizes a profile's wedge shots
+func (m *Manager) WedgeMatrix(profile string) core.WedgeMatrix {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := m.profileKeyLocked(profile)
+	return core.BuildWedgeMatrix(key, m.wedgeAttempts[key])
+}
+
+// WedgeProfiles lists the profiles with wedge shots
+func (m *Manager) WedgeProfiles() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	profiles := make([]string, 0, len(m.wedgeAttempts))
+	for profile := range m.wedgeAttempts {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+// OnWedgeChange registers a listener called with the wedge practice's state
+// after it starts, records a shot or stops. A stopped practice is reported
+// as nil.
+func (m *Manager) OnWedgeChange(listener func(*core.WedgePracticeState)) {
+	m.mu.Lock()
+	m.wedgeListeners = append(m.wedgeListeners, listener)
+	m.mu.Unlock()
+}
+
+// recordWedge records a shot against the wedge practice in progress and
+// saves it to the profile's matrix
+func (m *Manager) recordWedge(shot history.Shot) {
+	m.mu.Lock()
+	if m.wedge == nil {
+		m.mu.Unlock()
+		return
+	}
+	attempt, err := m.wedge.Record(shot.CarryYards, shot.OfflineYards, shot.Timestamp)
+	if err != nil {
+		// The practice already finished
+		m.mu.Unlock()
+		return
+	}
+	state := m.wedge.State()
+	key := m.profileKeyLocked(state.Config.Profile)
+	attempts := append(m.wedgeAttempts[key], attempt)
+	if len(attempts) > maxWedgeAttempts {
+		attempts = append([]core.WedgeAttempt(nil), attempts[len(attempts)-maxWedgeAttempts:]...)
+	}
+	m.wedgeAttempts[key] = attempts
+	// The prompted wedge, since players rarely change the device's club
+	m.addTargetShotLocked(core.PracticeWedges, state.Config.Profile, state.StartedAt, attempt.Club, attempt.TargetYards, shot)
+	if err := m.saveWedgesLocked(); err != nil {
+		log.Printf("Games: %v", err)
+	}
+	if state.Finished {
+		log.Printf("Games: %s finished wedge practice", state.Config.Profile)
+	}
+	m.mu.Unlock()
+
+	m.notifyWedge(&state)
+}
+
+func (m *Manager) notifyWedge(state *core.WedgePracticeState) {
+	m.mu.Lock()
+	listeners := make([]func(*core.WedgePracticeState), len(m.wedgeListeners))
+	copy(listeners, m.wedgeListeners)
+	m.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(state)
+	}
+}
@@ -0,0 +1,200 @@
+package gspro
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// MaxAuditedShots is how many recent shots the send audit keeps
+const MaxAuditedShots = 500
+
+// Kinds of message sent to GSPro for a shot
+const (
+	AuditKindBall   = "ball"
+	AuditKindClub   = "club"
+	AuditKindResend = "resend"
+	AuditKindReplay = "replay" // queued while GSPro was reconnecting
+)
+
+// AuditAttempt is one message sent to GSPro for a shot and GSPro's
+// acknowledgement of it
+type AuditAttempt struct {
+	Kind       string          `json:"kind"`
+	ShotNumber int             `json:"shotNumber"`
+	SentAt     time.Time       `json:"sentAt"`
+	Payload    json.RawMessage `json:"payload"` // exactly as sent
+	Error      string          `json:"error,omitempty"`
+	Response   json.RawMessage `json:"response,omitempty"`
+	AckedAt    *time.Time      `json:"ackedAt,omitempty"`
+}
+
+// ShotAudit is what the connector sent to GSPro for a shot. A shot that
+// wasn't sent, or is still queued, has no shot number and a reason in
+// Skipped. Retries counts the ball data sent after the first, including
+// resends.
+type ShotAudit struct {
+	ShotNumber   int            `json:"shotNumber"`
+	ShotID       int64          `json:"shotId,omitempty"`
+	Time         time.Time      `json:"time"`
+	Sent         bool           `json:"sent"`
+	Acknowledged bool           `json:"acknowledged"`
+	Retries      int            `json:"retries"`
+	Skipped      string         `json:"skipped,omitempty"`
+	Attempts     []AuditAttempt `json:"attempts"`
+}
+
+type auditedShot struct {
+	ball       *core.BallMetrics
+	shotNumber int
+	time       time.Time
+	skipped    string
+	attempts   []*AuditAttempt
+}
+
+// sendAudit records every shot and the messages sent to GSPro for it, so a
+// missing shot can be traced to what was actually transmitted
+type sendAudit struct {
+	mu    sync.Mutex
+	shots []*auditedShot
+	// pending are the sent messages waiting for GSPro's acknowledgement, in
+	// the order sent. GSPro acknowledges each shot message in turn, so
+	// messages that aren't audited are held here too to keep them in step.
+	pending []*AuditAttempt
+}
+
+// shotLocked returns the audit of the shot with ball metrics ball, adding it
+// if it's new
+func (a *sendAudit) shotLocked(ball *core.BallMetrics, shotNumber int) *auditedShot {
+	for i := len(a.shots) - 1; i >= 0; i-- {
+		if a.shots[i].ball == ball {
+			return a.shots[i]
+		}
+	}
+	shot := &auditedShot{ball: ball, shotNumber: shotNumber, time: time.Now()}
+	a.shots = append(a.shots, shot)
+	if len(a.shots) > MaxAuditedShots {
+		a.shots = a.shots[len(a.shots)-MaxAuditedShots:]
+	}
+	return shot
+}
+
+// skip records a shot that wasn't sent and why
+func (a *sendAudit) skip(ball *core.BallMetrics, shotNumber int, reason string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	shot := a.shotLocked(ball, shotNumber)
+	if len(shot.attempts) == 0 {
+		shot.skipped = reason
+	}
+}
+
+// sending records a message about to be sent. Messages for no shot, with a
+// nil ball, are only tracked until acknowledged.
+func (a *sendAudit) sending(ball *core.BallMetrics, kind string, shotNumber int, payload []byte, acked bool) *AuditAttempt {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	attempt := &AuditAttempt{
+		Kind:       kind,
+		ShotNumber: shotNumber,
+		SentAt:     time.Now(),
+		Payload:    json.RawMessage(payload),
+	}
+	if ball != nil {
+		shot := a.shotLocked(ball, shotNumber)
+		shot.skipped = ""
+		if shot.shotNumber == 0 {
+			shot.shotNumber = shotNumber
+		}
+		shot.attempts = append(shot.attempts, attempt)
+	}
+	if acked {
+		a.pending = append(a.pending, attempt)
+	}
+	return attempt
+}
+
+// failed records that a message couldn't be sent
+func (a *sendAudit) failed(attempt *AuditAttempt, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	attempt.Error = err.Error()
+	for i, pending := range a.pending {
+		if pending == attempt {
+			a.pending = append(a.pending[:i], a.pending[i+1:]...)
+			break
+		}
+	}
+}
+
+// acknowledged records GSPro's acknowledgement of the oldest message
+// waiting for one
+func (a *sendAudit) acknowledged(response string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.pending) == 0 {
+		return
+	}
+	attempt := a.pending[0]
+	a.pending = a.pending[1:]
+	now := time.Now()
+	attempt.Response = json.RawMessage(response)
+	attempt.AckedAt = &now
+}
+
+// disconnected forgets the messages waiting for an acknowledgement, which
+// won't come on a new connection
+func (a *sendAudit) disconnected() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending = nil
+}
+
+// Last returns up to n of the most recent shots, oldest first
+func (a *sendAudit) Last(n int) []ShotAudit {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if n <= 0 || n > len(a.shots) {
+		n = len(a.shots)
+	}
+	audits := make([]ShotAudit, 0, n)
+	for _, shot := range a.shots[len(a.shots)-n:] {
+		audit := ShotAudit{
+			ShotNumber: shot.shotNumber,
+			ShotID:     shot.ball.ShotID,
+			Time:       shot.time,
+			Skipped:    shot.skipped,
+			Attempts:   make([]AuditAttempt, 0, len(shot.attempts)),
+		}
+		balls := 0
+		for _, attempt := range shot.attempts {
+			if attempt.Error == "" {
+				audit.Sent = true
+			}
+			if attempt.AckedAt != nil {
+				audit.Acknowledged = true
+			}
+			if attempt.Kind != AuditKindClub {
+				balls++
+			}
+			audit.Attempts = append(audit.Attempts, *attempt)
+		}
+		if balls > 1 {
+			audit.Retries = balls - 1
+		}
+		audits = append(audits, audit)
+	}
+	return audits
+}
+
+// Audit returns what was sent to GSPro for up to n of the most recent
+// shots, oldest first
+func (g *Integration) Audit(n int) []ShotAudit {
+	return g.audit.Last(n)
+}
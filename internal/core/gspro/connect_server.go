@@ -0,0 +1,231 @@
+package gspro
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// DefaultConnectServerPort is the port GSPro listens on for launch monitors
+const DefaultConnectServerPort = 921
+
+// Response codes sent back to connected launch monitors, as GSPro does
+const (
+	responseShotReceived = 200
+	responseShotRejected = 501
+)
+
+// mphToMPS converts GSPro's mph speeds back to m/s
+const mphToMPS = 1 / 2.23694
+
+// ConnectResponse is the reply to each message from a launch monitor
+type ConnectResponse struct {
+	Code    int    `json:"Code"`
+	Message string `json:"Message"`
+}
+
+// ConnectServer accepts connections from other launch monitor bridges using
+// the GSPro Connect API and feeds their shots into this connector's pipeline,
+// so they reach every simulator alongside the Square's own shots
+type ConnectServer struct {
+	launchMonitor *core.LaunchMonitor
+	address       string
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+// NewConnectServer creates a server listening on address, e.g. ":921"
+func NewConnectServer(launchMonitor *core.LaunchMonitor, address string) *ConnectServer {
+	return &ConnectServer{
+		launchMonitor: launchMonitor,
+		address:       address,
+		conns:         make(map[net.Conn]struct{}),
+	}
+}
+
+// Start begins accepting connections
+func (s *ConnectServer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener != nil {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
+	}
+	s.listener = listener
+	log.Printf("GSPro Connect server listening on %s", listener.Addr())
+
+	s.wg.Add(1)
+	go s.acceptLoop(listener)
+	return nil
+}
+
+// Addr returns the address the server is listening on, or nil if stopped
+func (s *ConnectServer) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Stop closes the listener and every connection and waits for their
+// goroutines to finish
+func (s *ConnectServer) Stop() {
+	s.mu.Lock()
+	if s.listener == nil {
+		s.mu.Unlock()
+		return
+	}
+	s.listener.Close()
+	s.listener = nil
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	log.Println("GSPro Connect server stopped")
+}
+
+func (s *ConnectServer) acceptLoop(listener net.Listener) {
+	defer s.wg.Done()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("GSPro Connect server: accept failed: %v", err)
+			}
+			return
+		}
+
+		s.mu.Lock()
+		if s.listener == nil {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.handleConn(conn)
+	}
+}
+
+func (s *ConnectServer) handleConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	remote := conn.RemoteAddr().String()
+	log.Printf("GSPro Connect server: launch monitor connected from %s", remote)
+
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+	for {
+		var shot ShotData
+		if err := decoder.Decode(&shot); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				log.Printf("GSPro Connect server: closing %s: %v", remote, err)
+			}
+			log.Printf("GSPro Connect server: launch monitor %s disconnected", remote)
+			return
+		}
+
+		response := s.handleShot(shot, remote)
+		if err := encoder.Encode(response); err != nil {
+			log.Printf("GSPro Connect server: failed to reply to %s: %v", remote, err)
+			return
+		}
+	}
+}
+
+// handleShot submits a shot message and builds the reply. Messages without
+// ball data are heartbeats and readiness updates, which are acknowledged and
+// otherwise ignored.
+func (s *ConnectServer) handleShot(shot ShotData, remote string) ConnectResponse {
+	if !shot.ShotDataOptions.ContainsBallData || shot.BallData == nil {
+		return ConnectResponse{Code: responseShotReceived, Message: "Message received"}
+	}
+
+	ballMetrics, clubMetrics := convertFromGSProShotFormat(shot)
+	if err := s.launchMonitor.SubmitExternalShot(connectShotSource(shot, remote), ballMetrics, clubMetrics); err != nil {
+		return ConnectResponse{Code: responseShotRejected, Message: err.Error()}
+	}
+	return ConnectResponse{Code: responseShotReceived, Message: "Shot received successfully"}
+}
+
+// connectShotSource names a connected launch monitor by its DeviceID, falling
+// back to its address
+func connectShotSource(shot ShotData, remote string) core.ShotSource {
+	name := shot.DeviceID
+	if name == "" {
+		name = remote
+	}
+	return core.ShotSource("gspro-connect:" + name)
+}
+
+// convertFromGSProShotFormat reverses openconnect.NewBallData and
+// openconnect.NewClubData. Club metrics are nil unless the shot contains them.
+func convertFromGSProShotFormat(shot ShotData) (*core.BallMetrics, *core.ClubMetrics) {
+	ball := shot.BallData
+	ballMetrics := &core.BallMetrics{
+		BallSpeedMPS:     ball.Speed * mphToMPS,
+		VerticalAngle:    ball.VLA,
+		HorizontalAngle:  ball.HLA,
+		TotalspinRPM:     ball.TotalSpin,
+		SpinAxis:         ball.SpinAxis * -1,
+		BackspinRPM:      ball.BackSpin,
+		SidespinRPM:      ball.SideSpin * -1,
+		IsBallSpeedValid: ball.Speed > 0,
+		IsTotalSpinValid: true,
+		IsSpinAxisValid:  true,
+		IsBackspinValid:  true,
+		IsSidespinValid:  true,
+	}
+
+	if !shot.ShotDataOptions.ContainsClubData || shot.ClubData == nil {
+		return ballMetrics, nil
+	}
+
+	club := shot.ClubData
+	clubMetrics := &core.ClubMetrics{
+		PathAngle:               club.Path,
+		FaceAngle:               club.FaceToTarget,
+		AttackAngle:             club.AngleOfAttack,
+		DynamicLoftAngle:        club.Loft,
+		ImpactHorizontal:        club.HorizontalFaceImpact,
+		ImpactVertical:          club.VerticalFaceImpact,
+		ClubSpeed:               club.Speed * mphToMPS,
+		IsPathAngleValid:        true,
+		IsFaceAngleValid:        true,
+		IsAttackAngleValid:      true,
+		IsDynamicLoftValid:      true,
+		IsImpactHorizontalValid: true,
+		IsImpactVerticalValid:   true,
+		IsClubSpeedValid:        club.Speed > 0,
+	}
+	if clubMetrics.ClubSpeed > 0 {
+		clubMetrics.SmashFactor = ballMetrics.BallSpeedMPS / clubMetrics.ClubSpeed
+		clubMetrics.IsSmashFactorValid = true
+	}
+	return ballMetrics, clubMetrics
+}
@@ -0,0 +1,123 @@
+package gspro
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// GSPro Connect doesn't announce itself, so discovery probes for its port
+const (
+	discoveryDialTimeout = 300 * time.Millisecond
+	discoveryConcurrency = 64
+)
+
+// DiscoveryCandidate is a host with an open GSPro Connect port
+type DiscoveryCandidate struct {
+	IP    string `json:"ip"`
+	Port  int    `json:"port"`
+	Local bool   `json:"local"` // this machine
+}
+
+// Discover probes this machine and every host on its local IPv4 subnets for
+// an open GSPro Connect port. Subnets larger than a /24 are limited to the /24
+// around this machine's address. Candidates are sorted by address with this
+// machine first; when ctx ends early, the hosts found so far are returned.
+func Discover(ctx context.Context, port int) ([]DiscoveryCandidate, error) {
+	if port == 0 {
+		port = DefaultConnectServerPort
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	hosts := discoveryHosts(addrs)
+
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		candidates = make([]DiscoveryCandidate, 0)
+		slots      = make(chan struct{}, discoveryConcurrency)
+	)
+	dialer := net.Dialer{Timeout: discoveryDialTimeout}
+	for _, host := range hosts {
+		select {
+		case <-ctx.Done():
+		case slots <- struct{}{}:
+			wg.Add(1)
+			go func(host net.IP) {
+				defer wg.Done()
+				defer func() { <-slots }()
+
+				conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host.String(), strconv.Itoa(port)))
+				if err != nil {
+					return
+				}
+				conn.Close()
+
+				mu.Lock()
+				candidates = append(candidates, DiscoveryCandidate{IP: host.String(), Port: port, Local: host.IsLoopback()})
+				mu.Unlock()
+			}(host)
+		}
+	}
+	wg.Wait()
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Local != candidates[j].Local {
+			return candidates[i].Local
+		}
+		return bytes.Compare(net.ParseIP(candidates[i].IP).To4(), net.ParseIP(candidates[j].IP).To4()) < 0
+	})
+	return candidates, nil
+}
+
+// discoveryHosts lists loopback and the other hosts on each IPv4 subnet in
+// addrs, skipping this machine's own addresses
+func discoveryHosts(addrs []net.Addr) []net.IP {
+	own := make(map[string]bool)
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			own[ipNet.IP.String()] = true
+		}
+	}
+
+	hosts := []net.IP{net.IPv4(127, 0, 0, 1).To4()}
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP.To4()
+		if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+
+		mask := ipNet.Mask
+		if ones, bits := mask.Size(); bits != 32 || ones < 24 {
+			mask = net.CIDRMask(24, 32)
+		}
+		network := ip.Mask(mask)
+		ones, _ := mask.Size()
+		size := 1 << (32 - ones)
+
+		// Skip the network and broadcast addresses
+		for i := 1; i < size-1; i++ {
+			host := make(net.IP, 4)
+			copy(host, network)
+			host[3] += byte(i)
+			if own[host.String()] || seen[host.String()] {
+				continue
+			}
+			seen[host.String()] = true
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
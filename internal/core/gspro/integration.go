@@ -4,37 +4,75 @@ import (
 	"encoding/json"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/openconnect"
 	"github.com/brentyates/squaregolf-connector/internal/core/simulator"
 )
 
-var (
-	gsproInstance *Integration
-	gsproOnce     sync.Once
-)
+// StallTimeout is how long GSPro may leave a shot unacknowledged before the
+// connection is treated as stale and cycled. GSPro acknowledges every shot
+// straight away, so a long silence means it has stopped reading.
+const StallTimeout = 30 * time.Second
 
 type Integration struct {
 	*simulator.Base
-	stateManager   *core.StateManager
-	launchMonitor  *core.LaunchMonitor
-	shotNumber     int
-	lastShotNumber int
-	shotListeners  []func(ShotData)
-	lastPlayerInfo *PlayerInfo
-}
-
-func GetInstance(stateManager *core.StateManager, launchMonitor *core.LaunchMonitor, host string, port int) *Integration {
-	gsproOnce.Do(func() {
-		gsproInstance = &Integration{
-			stateManager:  stateManager,
-			launchMonitor: launchMonitor,
-			shotListeners: make([]func(ShotData), 0),
-		}
-		gsproInstance.Base = simulator.NewBase(gsproInstance, host, port)
-		gsproInstance.registerStateListeners()
-	})
-	return gsproInstance
+	stateManager     *core.StateManager
+	launchMonitor    *core.LaunchMonitor
+	shotMu           sync.Mutex
+	shotNumber       int
+	lastShotNumber   int
+	lastBall         *core.BallMetrics // ball metrics the current shot number was counted for
+	shotNumberPath   string
+	shotNumberPolicy ShotNumberPolicy
+	lastSent         *sentShot // last shot sent, for resending
+	audit            sendAudit
+	queueMu          sync.Mutex
+	queueSettings    RetryQueueSettings
+	queue            []queuedShot // shots hit while GSPro was reconnecting
+	queueApproved    bool         // whether the queue may be replayed
+	outage           bool         // whether the connection dropped and is being re-established
+	queueListeners   []func()
+	shotListeners    []func(ShotData)
+	lastPlayerInfo   *PlayerInfo
+	playersMu        sync.Mutex
+	players          map[string]PlayerState // players seen since GSPro connected, by name
+	currentPlayer    string
+	playerUp         bool // whether currentPlayer was set by a player message
+	settingsMu       sync.RWMutex
+	convention       core.SpinConvention
+	identity         PayloadIdentity
+}
+
+func New(stateManager *core.StateManager, launchMonitor *core.LaunchMonitor, host string, port int) *Integration {
+	g := &Integration{
+		stateManager:     stateManager,
+		launchMonitor:    launchMonitor,
+		shotListeners:    make([]func(ShotData), 0),
+		convention:       core.DefaultSpinConventions()[core.SimulatorGSPro],
+		identity:         DefaultPayloadIdentity(),
+		shotNumberPolicy: ShotNumberKeep,
+		queueSettings:    DefaultRetryQueueSettings(),
+	}
+	g.Base = simulator.NewBase(g, host, port)
+	g.Base.SetStallTimeout(StallTimeout)
+	g.registerStateListeners()
+	return g
+}
+
+// SetSpinConvention sets how spin axis, sidespin and horizontal launch angle
+// signs are mapped before shots are sent
+func (g *Integration) SetSpinConvention(convention core.SpinConvention) {
+	g.settingsMu.Lock()
+	defer g.settingsMu.Unlock()
+	g.convention = convention
+}
+
+func (g *Integration) spinConvention() core.SpinConvention {
+	g.settingsMu.RLock()
+	defer g.settingsMu.RUnlock()
+	return g.convention
 }
 
 func (g *Integration) Name() string {
@@ -71,11 +109,16 @@ func (g *Integration) SetError(err error) {
 }
 
 func (g *Integration) OnConnected() {
-	g.shotNumber = 0
-	g.lastShotNumber = 0
+	g.onConnectedShotNumber()
+	if !g.droppedConnection() || !g.resumePlayer() {
+		g.resetPlayers()
+	}
+	g.queueConnected()
 }
 
 func (g *Integration) OnDisconnected() {
+	g.audit.disconnected()
+	g.queueDisconnected()
 }
 
 func (g *Integration) ProcessMessage(rawMessage string) {
@@ -98,14 +141,19 @@ func (g *Integration) ProcessMessage(rawMessage string) {
 		g.handleGSProReadyMessage()
 	case "Ball Data received":
 		log.Printf("Received ball data confirmation from GSPro")
+		g.audit.acknowledged(rawMessage)
 	case "Club & Ball Data received":
 		log.Printf("Received club and ball data confirmation from GSPro")
+		g.audit.acknowledged(rawMessage)
 	default:
 		log.Printf("Unknown GSPro message type: %s", baseMsg.Message)
 	}
 }
 
 func (g *Integration) handleGSProReadyMessage() {
+	// GSPro asking for a shot takes the next one queued while it was away
+	g.replayNext()
+
 	err := g.launchMonitor.ActivateBallDetection()
 	if err != nil {
 		log.Printf("Failed to activate ball detection: %v", err)
@@ -114,39 +162,36 @@ func (g *Integration) handleGSProReadyMessage() {
 
 func (g *Integration) handlePlayerMessage(playerInfo *PlayerInfo) {
 	g.lastPlayerInfo = playerInfo
+	g.applyPlayer(g.trackPlayer(playerInfo.Player))
+}
 
-	if clubName := playerInfo.Player.Club; clubName != "" {
-		clubType := g.mapGSProClubToInternal(clubName)
-		if clubType != nil {
-			log.Printf("GSPro selected club: %s (mapped to %v)", clubName, clubType)
-			g.stateManager.SetClub(clubType)
-		} else {
-			log.Printf("Unmapped GSPro club: %s", clubName)
-		}
-
-		friendlyName := mapGSProClubToFriendlyName(clubName)
-		g.stateManager.SetClubName(&friendlyName)
-	}
-
-	if handed := playerInfo.Player.Handed; handed != "" {
-		var handednessType core.HandednessType
-		if handed == "LH" {
-			handednessType = core.LeftHanded
-			log.Printf("GSPro selected handedness: Left-handed")
-		} else {
-			handednessType = core.RightHanded
-			log.Printf("GSPro selected handedness: Right-handed")
-		}
-		g.stateManager.SetHandedness(&handednessType)
-	}
+// applyPlayer selects the club and handedness of the player who is up
+func (g *Integration) applyPlayer(player PlayerState) {
+	openconnect.ApplyPlayer(g.stateManager, g.Name(), Player{Name: player.Name, Club: player.Club, Handed: player.Handed})
 }
 
 func (g *Integration) sendData(shotData ShotData) error {
+	return g.sendAudited(nil, "", shotData)
+}
+
+// sendAudited sends a message for the shot with ball metrics ball and
+// records it in the send audit
+func (g *Integration) sendAudited(ball *core.BallMetrics, kind string, shotData ShotData) error {
 	jsonData, err := json.Marshal(shotData)
 	if err != nil {
 		return err
 	}
-	return g.Base.SendMessage(jsonData)
+	// Shots are acknowledged; readiness updates are not
+	acked := shotData.ShotDataOptions.ContainsBallData || shotData.ShotDataOptions.ContainsClubData
+	attempt := g.audit.sending(ball, kind, shotData.ShotNumber, jsonData, acked)
+	if err := g.Base.SendMessage(jsonData); err != nil {
+		g.audit.failed(attempt, err)
+		return err
+	}
+	if acked {
+		g.Base.ExpectReply()
+	}
+	return nil
 }
 
 func (g *Integration) AddShotListener(listener func(ShotData)) {
@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/openconnect"
 )
 
 func (g *Integration) registerStateListeners() {
@@ -21,20 +22,7 @@ func (g *Integration) onBallReadyChanged(oldValue, newValue bool) {
 		return
 	}
 
-	emptyShotData := ShotData{
-		DeviceID:   "CustomLaunchMonitor",
-		Units:      "Yards",
-		APIversion: "1",
-		ShotNumber: g.lastShotNumber,
-		ShotDataOptions: ShotOptions{
-			ContainsBallData:          false,
-			ContainsClubData:          false,
-			LaunchMonitorIsReady:      newValue,
-			LaunchMonitorBallDetected: newValue,
-		},
-	}
-
-	if err := g.sendData(emptyShotData); err != nil {
+	if err := g.sendData(openconnect.ReadyMessage(g.newShotData(), newValue)); err != nil {
 		log.Printf("Error sending empty shot data to GSPro: %v", err)
 	}
 }
@@ -44,18 +32,27 @@ func (g *Integration) onLastBallMetricsChanged(oldValue, newValue *core.BallMetr
 		return
 	}
 
-	if !g.Base.Connected || g.Base.Socket == nil {
+	if newValue == nil {
 		return
 	}
 
-	if newValue == nil {
+	if !g.Base.Connected || g.Base.Socket == nil {
+		if g.queueShot(newValue) {
+			g.audit.skip(newValue, 0, "queued until GSPro reconnects")
+			return
+		}
+		g.audit.skip(newValue, 0, "GSPro not connected")
 		return
 	}
 
-	gsproShotData := g.convertToGSProShotFormat(*newValue, true)
-	if err := g.sendData(gsproShotData); err != nil {
+	// A shot published again keeps its number
+	shotNumber := g.shotNumberFor(newValue)
+	if err := g.sendAudited(newValue, AuditKindBall, g.ballShotData(*newValue)); err != nil {
 		log.Printf("Error sending shot data to GSPro: %v", err)
+		return
 	}
+	g.recordSentShot(newValue, shotNumber)
+	g.launchMonitor.Latency().MarkSent(g.Name())
 }
 
 func (g *Integration) onLastClubMetricsChanged(oldValue, newValue *core.ClubMetrics) {
@@ -64,38 +61,21 @@ func (g *Integration) onLastClubMetricsChanged(oldValue, newValue *core.ClubMetr
 	}
 
 	if !g.Base.Connected || g.Base.Socket == nil {
+		if newValue != nil {
+			g.queueClub(newValue)
+		}
 		return
 	}
 
 	if newValue == nil {
-		zeroedClubData := &ClubData{
-			Speed:                0,
-			AngleOfAttack:        0,
-			FaceToTarget:         0,
-			Lie:                  0,
-			Loft:                 0,
-			Path:                 0,
-			SpeedAtImpact:        0,
-			VerticalFaceImpact:   0,
-			HorizontalFaceImpact: 0,
-			ClosureRate:          0,
-		}
-
-		gsproShotData := g.convertToGSProShotFormat(core.BallMetrics{}, false)
-		gsproShotData.ShotDataOptions.ContainsBallData = false
-		gsproShotData.ShotDataOptions.ContainsClubData = true
-		gsproShotData.ClubData = zeroedClubData
-		if err := g.sendData(gsproShotData); err != nil {
+		if err := g.sendData(g.clubShotData(nil)); err != nil {
 			log.Printf("Error sending zeroed club data to GSPro: %v", err)
 		}
 		return
 	}
 
-	gsproShotData := g.convertToGSProShotFormat(core.BallMetrics{}, false)
-	gsproShotData.ShotDataOptions.ContainsBallData = false
-	gsproShotData.ShotDataOptions.ContainsClubData = true
-	gsproShotData.ClubData = g.convertClubDataToGSPro(*newValue)
-	if err := g.sendData(gsproShotData); err != nil {
+	if err := g.sendAudited(g.lastSentBall(), AuditKindClub, g.clubShotData(newValue)); err != nil {
 		log.Printf("Error sending club data to GSPro: %v", err)
 	}
+	g.recordSentClub(newValue)
 }
@@ -0,0 +1,63 @@
+package gspro
+
+import "fmt"
+
+// Units GSPro accepts in shot data
+const (
+	UnitsYards  = "Yards"
+	UnitsMeters = "Meters"
+)
+
+// maxDeviceIDLength keeps device IDs readable in GSPro's logs
+const maxDeviceIDLength = 64
+
+// PayloadIdentity sets the fields that identify the connector in every
+// message sent to GSPro
+type PayloadIdentity struct {
+	DeviceID        string `json:"deviceID"`
+	Units           string `json:"units"`
+	APIVersion      string `json:"apiVersion"`
+	IncludeFirmware bool   `json:"includeFirmware"` // append the device firmware version to DeviceID
+}
+
+// DefaultPayloadIdentity returns the values the connector has always sent
+func DefaultPayloadIdentity() PayloadIdentity {
+	return PayloadIdentity{DeviceID: "CustomLaunchMonitor", Units: UnitsYards, APIVersion: "1"}
+}
+
+// Valid reports whether the identity can be sent to GSPro
+func (p PayloadIdentity) Valid() bool {
+	if p.DeviceID == "" || len(p.DeviceID) > maxDeviceIDLength || p.APIVersion == "" {
+		return false
+	}
+	return p.Units == UnitsYards || p.Units == UnitsMeters
+}
+
+// SetPayloadIdentity sets the device ID, units and API version sent to GSPro
+func (g *Integration) SetPayloadIdentity(identity PayloadIdentity) {
+	g.settingsMu.Lock()
+	defer g.settingsMu.Unlock()
+	g.identity = identity
+}
+
+// newShotData returns a message carrying the connector's identity and the
+// current shot number
+func (g *Integration) newShotData() ShotData {
+	g.settingsMu.RLock()
+	identity := g.identity
+	g.settingsMu.RUnlock()
+
+	deviceID := identity.DeviceID
+	if identity.IncludeFirmware {
+		if firmware := g.stateManager.GetFirmwareVersion(); firmware != nil && *firmware != "" {
+			deviceID = fmt.Sprintf("%s (firmware %s)", deviceID, *firmware)
+		}
+	}
+
+	return ShotData{
+		DeviceID:   deviceID,
+		Units:      identity.Units,
+		APIversion: identity.APIVersion,
+		ShotNumber: g.ShotNumber(),
+	}
+}
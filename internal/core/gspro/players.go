@@ -0,0 +1,106 @@
+package gspro
+
+import (
+	"log"
+	"sort"
+)
+
+// PlayerState is what GSPro last said about a player in the round. GSPro
+// doesn't repeat a player's handedness or club in every message, so the
+// last ones seen are kept for when the player is up again.
+type PlayerState struct {
+	Name   string `json:"name"`
+	Handed string `json:"handed,omitempty"`
+	Club   string `json:"club,omitempty"`
+}
+
+// Players returns the players seen since GSPro connected, by name, and the
+// name of the player who is up. Rounds where GSPro doesn't name its players
+// have a single unnamed player.
+func (g *Integration) Players() ([]PlayerState, string) {
+	g.playersMu.Lock()
+	defer g.playersMu.Unlock()
+
+	players := make([]PlayerState, 0, len(g.players))
+	for _, player := range g.players {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].Name < players[j].Name })
+	return players, g.currentPlayer
+}
+
+// trackPlayer records a player message and returns the player who is up,
+// with the handedness and club remembered for them filled in
+func (g *Integration) trackPlayer(player Player) PlayerState {
+	g.playersMu.Lock()
+	if g.players == nil {
+		g.players = make(map[string]PlayerState)
+	}
+	state, known := g.players[player.Name]
+	state.Name = player.Name
+	if player.Handed != "" {
+		state.Handed = player.Handed
+	}
+	if player.Club != "" {
+		state.Club = player.Club
+	}
+	g.players[player.Name] = state
+	changed := !g.playerUp || player.Name != g.currentPlayer
+	g.currentPlayer = player.Name
+	g.playerUp = true
+	g.playersMu.Unlock()
+
+	if changed {
+		if state.Name != "" {
+			log.Printf("GSPro player up: %s (seen before: %t)", state.Name, known)
+			name := state.Name
+			g.stateManager.SetPlayerName(&name)
+		} else {
+			g.stateManager.SetPlayerName(nil)
+		}
+	}
+	return state
+}
+
+// resetPlayers forgets the players when GSPro connects, as a new round may
+// have started. A connection that dropped and reconnected by itself keeps
+// them, see resumePlayer.
+func (g *Integration) resetPlayers() {
+	g.playersMu.Lock()
+	g.players = nil
+	g.currentPlayer = ""
+	hadPlayer := g.playerUp
+	g.playerUp = false
+	g.playersMu.Unlock()
+
+	if hadPlayer {
+		g.stateManager.SetPlayerName(nil)
+	}
+}
+
+// resumePlayer picks the round up again when GSPro reconnects after the
+// connection dropped. GSPro doesn't repeat the player or ask for a shot
+// straight away after a reconnect, and sometimes not at all, so the player
+// who was up keeps their club and handedness and ball detection is
+// re-activated without waiting for it. Returns false if no player was up.
+func (g *Integration) resumePlayer() bool {
+	g.playersMu.Lock()
+	state, known := g.players[g.currentPlayer]
+	resumed := g.playerUp && known
+	g.playersMu.Unlock()
+
+	if !resumed {
+		return false
+	}
+	log.Printf("GSPro reconnected mid-round, resuming with player %q", state.Name)
+	g.applyPlayer(state)
+
+	// OnConnected runs with the connection lock held; activating waits on
+	// the device
+	go func() {
+		if err := g.launchMonitor.ActivateBallDetection(); err != nil {
+			log.Printf("Failed to activate ball detection: %v", err)
+		}
+	}()
+	return true
+}
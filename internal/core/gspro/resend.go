@@ -0,0 +1,183 @@
+package gspro
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/openconnect"
+)
+
+var (
+	// ErrNoShotToResend is returned when no shot has been sent since the app
+	// started
+	ErrNoShotToResend = errors.New("no shot to resend")
+	// ErrResendTokenMismatch is returned when the confirmed shot is no longer
+	// the last one sent
+	ErrResendTokenMismatch = errors.New("the last shot has changed, confirm the resend again")
+	// ErrShotAlreadyResent is returned when the last shot was already resent
+	ErrShotAlreadyResent = errors.New("the last shot was already resent")
+	// ErrNotConnected is returned when GSPro isn't connected
+	ErrNotConnected = errors.New("GSPro is not connected")
+)
+
+// LastShot is the last shot sent to GSPro. Resending it takes its token, so
+// a resend is only made for the shot the player confirmed, and only once.
+type LastShot struct {
+	Token      string    `json:"token"`
+	ShotNumber int       `json:"shotNumber"`
+	ShotID     int64     `json:"shotId,omitempty"`
+	SentAt     time.Time `json:"sentAt"`
+	Resent     bool      `json:"resent"`
+	BallData   BallData  `json:"ballData"`
+	ClubData   *ClubData `json:"clubData,omitempty"`
+}
+
+// sentShot is the last shot sent to GSPro with the club metrics that
+// followed it
+type sentShot struct {
+	ball       *core.BallMetrics
+	club       *core.ClubMetrics
+	shotNumber int
+	sentAt     time.Time
+	token      string
+	resent     bool
+}
+
+// recordSentShot remembers a shot sent to GSPro so it can be resent. The
+// same shot published again keeps its token.
+func (g *Integration) recordSentShot(ball *core.BallMetrics, shotNumber int) {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+
+	if g.lastSent != nil && g.lastSent.ball == ball {
+		return
+	}
+	g.lastSent = &sentShot{
+		ball:       ball,
+		shotNumber: shotNumber,
+		sentAt:     time.Now(),
+		token:      newResendToken(),
+	}
+}
+
+// recordSentClub adds club metrics to the last shot sent, if they are from
+// the same shot
+func (g *Integration) recordSentClub(club *core.ClubMetrics) {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+	if g.lastSent != nil && club.SameShot(g.lastSent.ball) {
+		g.lastSent.club = club
+	}
+}
+
+// lastSentBall returns the ball metrics of the last shot sent, or nil
+func (g *Integration) lastSentBall() *core.BallMetrics {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+	if g.lastSent == nil {
+		return nil
+	}
+	return g.lastSent.ball
+}
+
+// LastShot returns the last shot sent to GSPro
+func (g *Integration) LastShot() (LastShot, error) {
+	g.shotMu.Lock()
+	sent := g.lastSent
+	var shot sentShot
+	if sent != nil {
+		shot = *sent
+	}
+	g.shotMu.Unlock()
+
+	if sent == nil {
+		return LastShot{}, ErrNoShotToResend
+	}
+	data := g.combinedShotData(shot)
+	return LastShot{
+		Token:      shot.token,
+		ShotNumber: shot.shotNumber,
+		ShotID:     shot.ball.ShotID,
+		SentAt:     shot.sentAt,
+		Resent:     shot.resent,
+		BallData:   *data.BallData,
+		ClubData:   shotClubData(data),
+	}, nil
+}
+
+// ResendLastShot sends the last shot to GSPro again, with its club data and
+// a new shot number, for when GSPro missed it during a reconnect. token must
+// be the one returned by LastShot; each shot can only be resent once. It
+// returns the new shot number.
+func (g *Integration) ResendLastShot(token string) (int, error) {
+	if !g.IsConnected() {
+		return 0, ErrNotConnected
+	}
+
+	g.shotMu.Lock()
+	sent := g.lastSent
+	switch {
+	case sent == nil:
+		g.shotMu.Unlock()
+		return 0, ErrNoShotToResend
+	case token != sent.token:
+		g.shotMu.Unlock()
+		return 0, ErrResendTokenMismatch
+	case sent.resent:
+		g.shotMu.Unlock()
+		return 0, ErrShotAlreadyResent
+	}
+	// Claimed before sending so a second request can't send it too
+	sent.resent = true
+	g.shotNumber++
+	g.lastShotNumber = g.shotNumber
+	g.saveShotNumberLocked()
+	shot := *sent
+	shot.shotNumber = g.lastShotNumber
+	g.shotMu.Unlock()
+
+	data := g.combinedShotData(shot)
+	if err := g.sendAudited(shot.ball, AuditKindResend, data); err != nil {
+		g.shotMu.Lock()
+		if g.lastSent == sent {
+			sent.resent = false
+		}
+		g.shotMu.Unlock()
+		log.Printf("Error resending shot data to GSPro: %v", err)
+		return 0, err
+	}
+	log.Printf("Resent the last shot to GSPro as shot %d", shot.shotNumber)
+	return shot.shotNumber, nil
+}
+
+// combinedShotData returns a sent shot as one message with its ball and club
+// data
+func (g *Integration) combinedShotData(shot sentShot) ShotData {
+	data := g.ballShotData(*shot.ball)
+	data.ShotNumber = shot.shotNumber
+	if shot.club != nil {
+		data.ShotDataOptions.ContainsClubData = true
+		data.ClubData = openconnect.NewClubData(*shot.club)
+	}
+	return data
+}
+
+// shotClubData returns a message's club data, or nil when it has none
+func shotClubData(data ShotData) *ClubData {
+	if !data.ShotDataOptions.ContainsClubData {
+		return nil
+	}
+	return data.ClubData
+}
+
+func newResendToken() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return time.Now().Format(time.RFC3339Nano)
+	}
+	return hex.EncodeToString(buf)
+}
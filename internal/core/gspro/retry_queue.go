@@ -0,0 +1,234 @@
+package gspro
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// Retry queue depth bounds
+const (
+	DefaultRetryQueueDepth = 10
+	MaxRetryQueueDepth     = 100
+)
+
+// ErrQueueEmpty is returned when there are no queued shots to replay
+var ErrQueueEmpty = errors.New("no shots are queued")
+
+// RetryQueueSettings sets how many shots hit while GSPro is reconnecting are
+// kept to send once it's back, and whether they wait for the player to
+// replay them. A depth of 0 drops them.
+type RetryQueueSettings struct {
+	Depth   int  `json:"depth"`
+	Confirm bool `json:"confirm"`
+}
+
+// DefaultRetryQueueSettings returns a queue that replays shots by itself
+func DefaultRetryQueueSettings() RetryQueueSettings {
+	return RetryQueueSettings{Depth: DefaultRetryQueueDepth}
+}
+
+// Valid reports whether the depth is in range
+func (s RetryQueueSettings) Valid() bool {
+	return s.Depth >= 0 && s.Depth <= MaxRetryQueueDepth
+}
+
+// QueuedShot is a shot waiting for GSPro to reconnect
+type QueuedShot struct {
+	ShotID   int64     `json:"shotId,omitempty"`
+	QueuedAt time.Time `json:"queuedAt"`
+	BallData BallData  `json:"ballData"`
+	ClubData *ClubData `json:"clubData,omitempty"`
+}
+
+// RetryQueue is the shots waiting to be sent to GSPro, oldest first.
+// AwaitingReplay is set while they wait for the player to replay them.
+type RetryQueue struct {
+	Shots          []QueuedShot `json:"shots"`
+	AwaitingReplay bool         `json:"awaitingReplay"`
+}
+
+type queuedShot struct {
+	ball     *core.BallMetrics
+	club     *core.ClubMetrics
+	queuedAt time.Time
+}
+
+// SetRetryQueue sets the retry queue depth and whether queued shots wait to
+// be replayed. Shots beyond a smaller depth are dropped, newest first.
+func (g *Integration) SetRetryQueue(settings RetryQueueSettings) {
+	g.queueMu.Lock()
+	g.queueSettings = settings
+	if len(g.queue) > settings.Depth {
+		g.queue = g.queue[:settings.Depth]
+	}
+	g.queueMu.Unlock()
+	g.notifyQueueChanged()
+}
+
+// OnQueueChanged registers a listener called when shots are queued, replayed
+// or discarded
+func (g *Integration) OnQueueChanged(listener func()) {
+	g.queueMu.Lock()
+	defer g.queueMu.Unlock()
+	g.queueListeners = append(g.queueListeners, listener)
+}
+
+// Queue returns the shots waiting for GSPro
+func (g *Integration) Queue() RetryQueue {
+	g.queueMu.Lock()
+	defer g.queueMu.Unlock()
+
+	queue := RetryQueue{
+		Shots:          make([]QueuedShot, 0, len(g.queue)),
+		AwaitingReplay: len(g.queue) > 0 && !g.queueApproved,
+	}
+	for _, shot := range g.queue {
+		data := g.combinedShotData(sentShot{ball: shot.ball, club: shot.club})
+		queue.Shots = append(queue.Shots, QueuedShot{
+			ShotID:   shot.ball.ShotID,
+			QueuedAt: shot.queuedAt,
+			BallData: *data.BallData,
+			ClubData: shotClubData(data),
+		})
+	}
+	return queue
+}
+
+// ReplayQueue sends the queued shots to GSPro when they are waiting for the
+// player. The first is sent straight away and the rest one at a time as
+// GSPro asks for the next shot.
+func (g *Integration) ReplayQueue() error {
+	if !g.IsConnected() {
+		return ErrNotConnected
+	}
+	g.queueMu.Lock()
+	if len(g.queue) == 0 {
+		g.queueMu.Unlock()
+		return ErrQueueEmpty
+	}
+	g.queueApproved = true
+	g.queueMu.Unlock()
+
+	g.replayNext()
+	return nil
+}
+
+// DiscardQueue drops the queued shots and returns how many there were
+func (g *Integration) DiscardQueue() int {
+	g.queueMu.Lock()
+	discarded := len(g.queue)
+	g.queue = nil
+	g.queueMu.Unlock()
+
+	if discarded > 0 {
+		log.Printf("Discarded %d shots queued for GSPro", discarded)
+		g.notifyQueueChanged()
+	}
+	return discarded
+}
+
+// queueShot keeps a shot to send once GSPro reconnects. Shots are only
+// queued while the connection is being re-established after dropping, not
+// while the player has disconnected GSPro, and only up to the queue depth.
+func (g *Integration) queueShot(ball *core.BallMetrics) bool {
+	g.queueMu.Lock()
+	if !g.outage || len(g.queue) >= g.queueSettings.Depth {
+		g.queueMu.Unlock()
+		return false
+	}
+	g.queue = append(g.queue, queuedShot{ball: ball, queuedAt: time.Now()})
+	count := len(g.queue)
+	g.queueMu.Unlock()
+
+	log.Printf("GSPro not connected, queued the shot to send when it reconnects (%d queued)", count)
+	g.notifyQueueChanged()
+	return true
+}
+
+// queueClub adds club metrics to the last shot queued, if they are from the
+// same shot
+func (g *Integration) queueClub(club *core.ClubMetrics) {
+	g.queueMu.Lock()
+	defer g.queueMu.Unlock()
+	if len(g.queue) == 0 {
+		return
+	}
+	if last := &g.queue[len(g.queue)-1]; last.club == nil && club.SameShot(last.ball) {
+		last.club = club
+	}
+}
+
+// queueConnected is called once GSPro is connected again. Queued shots are
+// sent as GSPro asks for a shot, unless they wait for the player.
+func (g *Integration) queueConnected() {
+	g.queueMu.Lock()
+	g.outage = false
+	g.queueApproved = !g.queueSettings.Confirm
+	queued := len(g.queue)
+	g.queueMu.Unlock()
+
+	if queued > 0 {
+		g.notifyQueueChanged()
+	}
+}
+
+// queueDisconnected is called with the connection lock held, so it reads
+// the connection state directly. A connection that is still running and
+// reconnecting by itself dropped rather than being closed by the player.
+func (g *Integration) queueDisconnected() {
+	g.queueMu.Lock()
+	defer g.queueMu.Unlock()
+	g.outage = g.Base.Running && g.Base.AutoReconnect
+	g.queueApproved = false
+}
+
+// droppedConnection reports whether GSPro is connecting again after the
+// connection dropped, rather than being connected by the player
+func (g *Integration) droppedConnection() bool {
+	g.queueMu.Lock()
+	defer g.queueMu.Unlock()
+	return g.outage
+}
+
+// replayNext sends the oldest queued shot with its club data and a new shot
+// number, if the queue may be replayed
+func (g *Integration) replayNext() {
+	g.queueMu.Lock()
+	if !g.queueApproved || len(g.queue) == 0 {
+		g.queueMu.Unlock()
+		return
+	}
+	shot := g.queue[0]
+	g.queue = g.queue[1:]
+	g.queueMu.Unlock()
+
+	shotNumber := g.shotNumberFor(shot.ball)
+	data := g.combinedShotData(sentShot{ball: shot.ball, club: shot.club, shotNumber: shotNumber})
+	if err := g.sendAudited(shot.ball, AuditKindReplay, data); err != nil {
+		log.Printf("Error replaying a queued shot to GSPro: %v", err)
+		// Sent again after the next reconnect
+		g.queueMu.Lock()
+		g.queue = append([]queuedShot{shot}, g.queue...)
+		g.queueMu.Unlock()
+		return
+	}
+	g.recordSentShot(shot.ball, shotNumber)
+	if shot.club != nil {
+		g.recordSentClub(shot.club)
+	}
+	log.Printf("Replayed a shot queued while GSPro was disconnected as shot %d", shotNumber)
+	g.notifyQueueChanged()
+}
+
+func (g *Integration) notifyQueueChanged() {
+	g.queueMu.Lock()
+	listeners := append([]func(){}, g.queueListeners...)
+	g.queueMu.Unlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
@@ -0,0 +1,128 @@
+package gspro
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/fileutil"
+)
+
+// ShotNumberPolicy decides when the GSPro shot counter starts over
+type ShotNumberPolicy string
+
+const (
+	// ShotNumberKeep keeps counting across reconnects and restarts until the
+	// counter is reset
+	ShotNumberKeep ShotNumberPolicy = "keep"
+	// ShotNumberResetOnConnect starts over each time GSPro connects
+	ShotNumberResetOnConnect ShotNumberPolicy = "connect"
+)
+
+// Valid reports whether the policy is known
+func (p ShotNumberPolicy) Valid() bool {
+	return p == ShotNumberKeep || p == ShotNumberResetOnConnect
+}
+
+type savedShotNumber struct {
+	ShotNumber int `json:"shotNumber"`
+}
+
+// SetShotNumberPolicy sets when the shot counter starts over
+func (g *Integration) SetShotNumberPolicy(policy ShotNumberPolicy) {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+	g.shotNumberPolicy = policy
+}
+
+// SetShotNumberPath sets the file the shot counter is saved to and restores
+// the saved counter
+func (g *Integration) SetShotNumberPath(path string) error {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+
+	g.shotNumberPath = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var saved savedShotNumber
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	if saved.ShotNumber > 0 {
+		g.shotNumber = saved.ShotNumber
+		g.lastShotNumber = saved.ShotNumber
+	}
+	return nil
+}
+
+// ShotNumber returns the number of the last shot sent to GSPro
+func (g *Integration) ShotNumber() int {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+	return g.lastShotNumber
+}
+
+// ResetShotNumber starts the shot counter over so the next shot is number 1
+func (g *Integration) ResetShotNumber() {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+	g.resetShotNumberLocked()
+}
+
+// shotNumberFor returns the number to send with ball data, counting a new
+// shot only for ball metrics that haven't been sent before
+func (g *Integration) shotNumberFor(ballMetrics *core.BallMetrics) int {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+
+	if ballMetrics != nil && ballMetrics == g.lastBall {
+		return g.lastShotNumber
+	}
+	g.lastBall = ballMetrics
+	g.shotNumber++
+	g.lastShotNumber = g.shotNumber
+	g.saveShotNumberLocked()
+	return g.lastShotNumber
+}
+
+// onConnectedShotNumber applies the reset policy when GSPro connects
+func (g *Integration) onConnectedShotNumber() {
+	g.shotMu.Lock()
+	defer g.shotMu.Unlock()
+	if g.shotNumberPolicy == ShotNumberResetOnConnect {
+		g.resetShotNumberLocked()
+	}
+}
+
+func (g *Integration) resetShotNumberLocked() {
+	g.shotNumber = 0
+	g.lastShotNumber = 0
+	g.lastBall = nil
+	g.saveShotNumberLocked()
+}
+
+// saveShotNumberLocked writes the counter atomically so a crash can't leave
+// it truncated
+func (g *Integration) saveShotNumberLocked() {
+	if g.shotNumberPath == "" {
+		return
+	}
+	data, err := json.Marshal(savedShotNumber{ShotNumber: g.lastShotNumber})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(g.shotNumberPath), 0755); err != nil {
+		log.Printf("Failed to save GSPro shot number: %v", err)
+		return
+	}
+	if err := fileutil.WriteAtomic(g.shotNumberPath, "", data, 0644); err != nil {
+		log.Printf("Failed to save GSPro shot number: %v", err)
+	}
+}
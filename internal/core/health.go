@@ -0,0 +1,57 @@
+package core
+
+import "fmt"
+
+// HealthStatus rates one dependency, or the connector as a whole
+type HealthStatus string
+
+const (
+	HealthOK   HealthStatus = "ok"
+	HealthWarn HealthStatus = "warn" // working, but worth a look
+	HealthFail HealthStatus = "fail"
+	HealthOff  HealthStatus = "off" // not in use; ignored in the overall status
+)
+
+// Free disk space below these is a warning or a failure. Logs and shot
+// history stop being written when the disk fills up.
+const (
+	DiskSpaceWarnBytes = 1 << 30
+	DiskSpaceFailBytes = 100 << 20
+)
+
+// HealthCheck is the result of checking one dependency
+type HealthCheck struct {
+	Status HealthStatus `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+}
+
+// OverallHealth returns the worst status among the checks in use
+func OverallHealth(checks map[string]HealthCheck) HealthStatus {
+	overall := HealthOK
+	for _, check := range checks {
+		switch check.Status {
+		case HealthFail:
+			return HealthFail
+		case HealthWarn:
+			overall = HealthWarn
+		}
+	}
+	return overall
+}
+
+// CheckDiskSpace checks the free space on the disk holding path
+func CheckDiskSpace(path string) HealthCheck {
+	free, err := freeDiskSpace(path)
+	if err != nil {
+		return HealthCheck{Status: HealthFail, Detail: fmt.Sprintf("can't read free space for %s: %v", path, err)}
+	}
+
+	detail := fmt.Sprintf("%.1f GB free for %s", float64(free)/(1<<30), path)
+	switch {
+	case free < DiskSpaceFailBytes:
+		return HealthCheck{Status: HealthFail, Detail: detail}
+	case free < DiskSpaceWarnBytes:
+		return HealthCheck{Status: HealthWarn, Detail: detail}
+	}
+	return HealthCheck{Status: HealthOK, Detail: detail}
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOverallHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks map[string]HealthCheck
+		want   HealthStatus
+	}{
+		{"No checks", nil, HealthOK},
+		{"All ok", map[string]HealthCheck{"a": {Status: HealthOK}, "b": {Status: HealthOK}}, HealthOK},
+		{"Off is ignored", map[string]HealthCheck{"a": {Status: HealthOK}, "b": {Status: HealthOff}}, HealthOK},
+		{"Warning", map[string]HealthCheck{"a": {Status: HealthWarn}, "b": {Status: HealthOK}}, HealthWarn},
+		{"Failure wins", map[string]HealthCheck{"a": {Status: HealthWarn}, "b": {Status: HealthFail}}, HealthFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OverallHealth(tt.checks); got != tt.want {
+				t.Errorf("OverallHealth() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+	check := CheckDiskSpace(dir)
+	if check.Status == HealthOff || !strings.Contains(check.Detail, "GB free") {
+		t.Errorf("CheckDiskSpace(%q) = %+v, want the free space", dir, check)
+	}
+
+	missing := filepath.Join(dir, "missing")
+	if check := CheckDiskSpace(missing); check.Status != HealthFail {
+		t.Errorf("CheckDiskSpace(%q) = %+v, want a failure", missing, check)
+	}
+}
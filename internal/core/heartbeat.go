@@ -0,0 +1,78 @@
+package core
+
+import "time"
+
+const (
+	// DefaultHeartbeatInterval is how often a heartbeat is sent to the device
+	DefaultHeartbeatInterval = 5 * time.Second
+	// DefaultDeviceTimeout is how long the simulated device waits without
+	// hearing from the connector before it disconnects
+	DefaultDeviceTimeout = 10 * time.Second
+)
+
+// Limits for user-configured heartbeat settings
+const (
+	minHeartbeatSeconds     = 1
+	maxHeartbeatSeconds     = 30
+	minDeviceTimeoutSeconds = 2
+	maxDeviceTimeoutSeconds = 120
+)
+
+// HeartbeatSettings controls how often the device is sent a heartbeat and how
+// long the simulated device tolerates silence
+type HeartbeatSettings struct {
+	IntervalSeconds      int `json:"intervalSeconds"`
+	DeviceTimeoutSeconds int `json:"deviceTimeoutSeconds"`
+}
+
+// DefaultHeartbeatSettings returns the intervals the connector has always used
+func DefaultHeartbeatSettings() HeartbeatSettings {
+	return HeartbeatSettings{
+		IntervalSeconds:      int(DefaultHeartbeatInterval / time.Second),
+		DeviceTimeoutSeconds: int(DefaultDeviceTimeout / time.Second),
+	}
+}
+
+// Valid reports whether both values are in range and a heartbeat arrives
+// before the device times out
+func (s HeartbeatSettings) Valid() bool {
+	return s.IntervalSeconds >= minHeartbeatSeconds && s.IntervalSeconds <= maxHeartbeatSeconds &&
+		s.DeviceTimeoutSeconds >= minDeviceTimeoutSeconds && s.DeviceTimeoutSeconds <= maxDeviceTimeoutSeconds &&
+		s.DeviceTimeoutSeconds > s.IntervalSeconds
+}
+
+// Interval returns the heartbeat interval
+func (s HeartbeatSettings) Interval() time.Duration {
+	return time.Duration(s.IntervalSeconds) * time.Second
+}
+
+// DeviceTimeout returns the simulated device's inactivity timeout
+func (s HeartbeatSettings) DeviceTimeout() time.Duration {
+	return time.Duration(s.DeviceTimeoutSeconds) * time.Second
+}
+
+// SetHeartbeatInterval sets how often a heartbeat is sent, restarting a
+// running heartbeat task so the change applies right away
+func (lm *LaunchMonitor) SetHeartbeatInterval(interval time.Duration) {
+	lm.heartbeatCancelMu.Lock()
+	defer lm.heartbeatCancelMu.Unlock()
+
+	lm.heartbeatInterval = interval
+	if lm.heartbeatCancel != nil {
+		lm.startHeartbeatTaskLocked()
+	}
+}
+
+// HeartbeatInterval returns how often a heartbeat is sent while the device
+// is active
+func (lm *LaunchMonitor) HeartbeatInterval() time.Duration {
+	lm.heartbeatCancelMu.Lock()
+	defer lm.heartbeatCancelMu.Unlock()
+	return lm.heartbeatInterval
+}
+
+// IdleHeartbeatInterval returns how often a heartbeat is sent while the
+// device is idle
+func (lm *LaunchMonitor) IdleHeartbeatInterval() time.Duration {
+	return lm.HeartbeatInterval() * idleHeartbeatEvery
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeartbeatSettings_Valid(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings HeartbeatSettings
+		want     bool
+	}{
+		{"defaults", DefaultHeartbeatSettings(), true},
+		{"slowest", HeartbeatSettings{IntervalSeconds: 30, DeviceTimeoutSeconds: 120}, true},
+		{"zero interval", HeartbeatSettings{IntervalSeconds: 0, DeviceTimeoutSeconds: 10}, false},
+		{"interval too long", HeartbeatSettings{IntervalSeconds: 31, DeviceTimeoutSeconds: 60}, false},
+		{"timeout too long", HeartbeatSettings{IntervalSeconds: 5, DeviceTimeoutSeconds: 121}, false},
+		{"timeout not after interval", HeartbeatSettings{IntervalSeconds: 10, DeviceTimeoutSeconds: 10}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.settings.Valid(); got != tt.want {
+			t.Errorf("%s: Valid() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetHeartbeatInterval_UsesNewInterval(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	clock := NewFakeClock(time.Unix(0, 0))
+	lm.SetClock(clock)
+	writes := mockClient.NotifyWrites()
+
+	lm.SetHeartbeatInterval(2 * time.Second)
+	if lm.HeartbeatInterval() != 2*time.Second || lm.IdleHeartbeatInterval() != 12*time.Second {
+		t.Fatalf("intervals = %v/%v, want 2s/12s", lm.HeartbeatInterval(), lm.IdleHeartbeatInterval())
+	}
+
+	lm.startHeartbeatTask()
+	defer lm.stopHeartbeatTask()
+	clock.BlockUntil(1)
+
+	clock.Advance(2 * time.Second)
+	waitForWrite(t, writes, isHeartbeat)
+}
+
+func TestSetHeartbeatInterval_KeepsRunningTask(t *testing.T) {
+	_, lm, _, _ := newTestLaunchMonitor(t)
+
+	lm.startHeartbeatTask()
+	defer lm.stopHeartbeatTask()
+
+	lm.SetHeartbeatInterval(time.Second)
+	if lm.heartbeatCancel == nil {
+		t.Error("Expected the heartbeat to be restarted")
+	}
+}
+
+func TestSetHeartbeatInterval_DoesNotStartStoppedTask(t *testing.T) {
+	_, lm, _, _ := newTestLaunchMonitor(t)
+
+	lm.SetHeartbeatInterval(time.Second)
+	if lm.heartbeatCancel != nil {
+		t.Error("Expected the heartbeat to stay stopped")
+	}
+}
+
+func TestReconnect_SendsImmediateHeartbeat(t *testing.T) {
+	sm, lm, mockClient, btManager := newTestLaunchMonitor(t)
+	lm.SetupNotifications(btManager)
+	defer lm.Shutdown()
+
+	writes := mockClient.NotifyWrites()
+	sm.SetConnectionStatus(ConnectionStatusDisconnected)
+	mockClient.connected = true
+	sm.SetConnectionStatus(ConnectionStatusConnected)
+
+	// The heartbeat comes as soon as the device reconnects
+	waitForWrite(t, writes, isHeartbeat)
+}
+
+func TestSimulatorInactivityTimeout(t *testing.T) {
+	sim := NewSimulatorBluetoothClient(SimulatorConfig{})
+	if sim.InactivityTimeout() != DefaultDeviceTimeout {
+		t.Fatalf("InactivityTimeout() = %v, want %v", sim.InactivityTimeout(), DefaultDeviceTimeout)
+	}
+
+	sim.SetInactivityTimeout(30 * time.Second)
+	if sim.InactivityTimeout() != 30*time.Second {
+		t.Errorf("InactivityTimeout() = %v, want 30s", sim.InactivityTimeout())
+	}
+	sim.SetInactivityTimeout(0)
+	if sim.InactivityTimeout() != 30*time.Second {
+		t.Error("Expected a zero timeout to be ignored")
+	}
+}
@@ -0,0 +1,26 @@
+package history
+
+import (
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// registerStateListeners registers callbacks for state changes
+func (s *Store) registerStateListeners() {
+	s.stateManager.RegisterLastBallMetricsCallback(func(oldValue, newValue *core.BallMetrics) {
+		if newValue == nil {
+			return
+		}
+		gen := s.beginShot(newValue, s.stateManager.GetClub(), s.stateManager.GetPlayerName())
+		time.AfterFunc(clubDataWait, func() {
+			s.completeShot(gen, nil)
+		})
+	})
+
+	s.stateManager.RegisterLastClubMetricsCallback(func(oldValue, newValue *core.ClubMetrics) {
+		if newValue != nil {
+			s.completeShot(0, newValue)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package history
+
+import (
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// Shot is a completed shot with merged ball and club data
+type Shot struct {
+	ID           int               `json:"id"`
+	Timestamp    time.Time         `json:"timestamp"`
+	Club         string            `json:"club"`
+	ClubCode     string            `json:"clubCode,omitempty"`
+	Player       string            `json:"player,omitempty"`
+	Session      string            `json:"session,omitempty"` // such as warmup; empty for regular play
+	Ball         core.BallMetrics  `json:"ball"`
+	ClubMetrics  *core.ClubMetrics `json:"clubMetrics,omitempty"`
+	CarryYards   float64           `json:"carryYards"`
+	OfflineYards float64           `json:"offlineYards"`
+	Videos       []Video           `json:"videos,omitempty"`
+}
+
+// Video is a camera recording of a shot. Path is set when the clip is stored
+// on this machine rather than on the camera.
+type Video struct {
+	Camera   string `json:"camera"`
+	Filename string `json:"filename"`
+	Path     string `json:"path,omitempty"`
+}
+
+// RawFrames are the device notifications a shot was parsed from, as hex
+// bytes. They are only kept while raw data retention is on.
+type RawFrames struct {
+	ShotID int      `json:"shotId"`
+	Ball   []string `json:"ball"`
+	Club   []string `json:"club,omitempty"`
+}
+
+// RawFrames returns the notifications the shot was parsed from, if they were
+// kept
+func (s Shot) RawFrames() (RawFrames, bool) {
+	frames := RawFrames{ShotID: s.ID, Ball: s.Ball.RawData}
+	if s.ClubMetrics != nil {
+		frames.Club = s.ClubMetrics.RawData
+	}
+	return frames, len(frames.Ball) > 0 || len(frames.Club) > 0
+}
+
+// WithoutRawData returns a copy of the shot with its raw notifications dropped
+func (s Shot) WithoutRawData() Shot {
+	s.Ball.RawData = nil
+	if s.ClubMetrics != nil {
+		club := *s.ClubMetrics
+		club.RawData = nil
+		s.ClubMetrics = &club
+	}
+	return s
+}
@@ -0,0 +1,326 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// clubDataWait is how long a shot waits for club data before it is stored
+// with ball data only.
+const clubDataWait = 2 * time.Second
+
+// videoMatchSlack is how far a recording's shot time may be from a shot's
+// for the two to be linked
+const videoMatchSlack = 5 * time.Second
+
+// maxShotsInMemory bounds the in-memory history; the file keeps everything.
+const maxShotsInMemory = 5000
+
+// Store keeps the history of completed shots and appends them to a JSON
+// lines file so they survive restarts.
+type Store struct {
+	stateManager   *core.StateManager
+	path           string
+	shots          []Shot
+	nextID         int
+	listeners      []func(Shot)
+	videoListeners []func(Shot)
+
+	pendingBall    *core.BallMetrics
+	pendingClub    *core.ClubType
+	pendingPlayer  *string
+	pendingSession string
+	pendingGen     int
+	pendingStart   time.Time
+	pendingVideos  []Video
+
+	// keepRawData keeps the device notifications each shot was parsed from
+	keepRawData bool
+
+	// session tags shots hit while a session such as a warmup runs
+	session string
+
+	mu sync.Mutex
+}
+
+// New creates a store for the shots published on stateManager. Shots are
+// only kept in memory until SetPath gives it a file.
+func New(stateManager *core.StateManager) *Store {
+	s := &Store{
+		stateManager: stateManager,
+		nextID:       1,
+	}
+	s.registerStateListeners()
+	return s
+}
+
+// SetPath loads the shots saved in the file at path, and appends new shots
+// to it
+func (s *Store) SetPath(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+	return s.load()
+}
+
+func (s *Store) load() error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	// A shot that gained a video is appended again; the later line wins
+	index := make(map[int]int)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var shot Shot
+		if err := json.Unmarshal(scanner.Bytes(), &shot); err != nil {
+			continue
+		}
+		if i, ok := index[shot.ID]; ok {
+			s.shots[i] = shot
+			continue
+		}
+		index[shot.ID] = len(s.shots)
+		s.shots = append(s.shots, shot)
+		if shot.ID >= s.nextID {
+			s.nextID = shot.ID + 1
+		}
+	}
+	s.trimLocked()
+	return scanner.Err()
+}
+
+func (s *Store) trimLocked() {
+	if len(s.shots) > maxShotsInMemory {
+		s.shots = append([]Shot(nil), s.shots[len(s.shots)-maxShotsInMemory:]...)
+	}
+}
+
+// Shots returns a copy of the stored shots, oldest first
+func (s *Store) Shots() []Shot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Shot(nil), s.shots...)
+}
+
+// Last returns the most recent shot, if any
+func (s *Store) Last() (Shot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.shots) == 0 {
+		return Shot{}, false
+	}
+	return s.shots[len(s.shots)-1], true
+}
+
+// Shot returns the stored shot with the given ID
+func (s *Store) Shot(id int) (Shot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.shots) - 1; i >= 0; i-- {
+		if s.shots[i].ID == id {
+			return s.shots[i], true
+		}
+	}
+	return Shot{}, false
+}
+
+// OnShot registers a listener called after each shot is stored
+func (s *Store) OnShot(listener func(Shot)) {
+	s.mu.Lock()
+	s.listeners = append(s.listeners, listener)
+	s.mu.Unlock()
+}
+
+// OnVideo registers a listener called when a stored shot gains a video
+func (s *Store) OnVideo(listener func(Shot)) {
+	s.mu.Lock()
+	s.videoListeners = append(s.videoListeners, listener)
+	s.mu.Unlock()
+}
+
+// AttachVideo links a camera recording to the shot taken at shotTime. A shot
+// still waiting for club data stores the video with it; a stored shot is
+// updated in place.
+func (s *Store) AttachVideo(shotTime time.Time, video Video) error {
+	s.mu.Lock()
+	if s.pendingBall != nil && withinSlack(s.pendingStart, shotTime) {
+		s.pendingVideos = append(s.pendingVideos, video)
+		s.mu.Unlock()
+		return nil
+	}
+
+	// The shot for this recording is the first one stored after it was taken
+	index := -1
+	for i := len(s.shots) - 1; i >= 0; i-- {
+		if s.shots[i].Timestamp.Before(shotTime.Add(-videoMatchSlack)) {
+			break
+		}
+		index = i
+	}
+	if index < 0 || s.shots[index].Timestamp.Sub(shotTime) > clubDataWait+videoMatchSlack {
+		s.mu.Unlock()
+		return fmt.Errorf("no shot found for video %s", video.Filename)
+	}
+
+	shot := s.shots[index]
+	shot.Videos = append(append([]Video(nil), shot.Videos...), video)
+	s.shots[index] = shot
+	listeners := make([]func(Shot), len(s.videoListeners))
+	copy(listeners, s.videoListeners)
+	err := s.appendLocked(shot)
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(shot)
+	}
+	return err
+}
+
+func withinSlack(a, b time.Time) bool {
+	d := a.Sub(b)
+	return d > -videoMatchSlack && d < videoMatchSlack
+}
+
+// Add stores a completed shot and returns it with its ID assigned
+func (s *Store) Add(shot Shot) (Shot, error) {
+	s.mu.Lock()
+	shot.ID = s.nextID
+	s.nextID++
+	if shot.Timestamp.IsZero() {
+		shot.Timestamp = time.Now()
+	}
+	s.shots = append(s.shots, shot)
+	s.trimLocked()
+	listeners := make([]func(Shot), len(s.listeners))
+	copy(listeners, s.listeners)
+	err := s.appendLocked(shot)
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(shot)
+	}
+	return shot, err
+}
+
+func (s *Store) appendLocked(shot Shot) error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(shot)
+	if err != nil {
+		return fmt.Errorf("failed to encode shot: %w", err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open shot history: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write shot history: %w", err)
+	}
+	return nil
+}
+
+// SetKeepRawData sets whether new shots keep the raw device notifications
+// they were parsed from, for debugging suspect readings. Shots stored while
+// it is off have them dropped.
+func (s *Store) SetKeepRawData(enabled bool) {
+	s.mu.Lock()
+	s.keepRawData = enabled
+	s.mu.Unlock()
+}
+
+// SetSession tags the shots hit from now on with a session type such as
+// warmup; empty ends the session. A shot takes the session it was hit in.
+func (s *Store) SetSession(session string) {
+	s.mu.Lock()
+	s.session = session
+	s.mu.Unlock()
+}
+
+// Flush stores any shot still waiting for club data
+func (s *Store) Flush() {
+	s.completeShot(0, nil)
+}
+
+// beginShot holds ball metrics until club metrics arrive. club and player
+// are the ones up when the ball was hit.
+func (s *Store) beginShot(ball *core.BallMetrics, club *core.ClubType, player *string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pendingBall = ball
+	s.pendingClub = club
+	s.pendingPlayer = player
+	s.pendingSession = s.session
+	s.pendingStart = time.Now()
+	s.pendingVideos = nil
+	s.pendingGen++
+	return s.pendingGen
+}
+
+// completeShot stores the pending shot. gen of zero completes whatever shot is pending.
+func (s *Store) completeShot(gen int, clubMetrics *core.ClubMetrics) {
+	s.mu.Lock()
+	if s.pendingBall == nil || (gen != 0 && gen != s.pendingGen) ||
+		(clubMetrics != nil && !clubMetrics.SameShot(s.pendingBall)) {
+		s.mu.Unlock()
+		return
+	}
+	ball := *s.pendingBall
+	club := s.pendingClub
+	player := s.pendingPlayer
+	session := s.pendingSession
+	videos := s.pendingVideos
+	keepRawData := s.keepRawData
+	s.pendingBall = nil
+	s.pendingClub = nil
+	s.pendingPlayer = nil
+	s.pendingVideos = nil
+	s.mu.Unlock()
+
+	shot := Shot{
+		Timestamp:    time.Now(),
+		Club:         "Unknown",
+		Ball:         ball,
+		ClubMetrics:  clubMetrics,
+		CarryYards:   core.ShotCarryYards(&ball),
+		OfflineYards: core.EstimateOfflineYards(&ball),
+		Session:      session,
+		Videos:       videos,
+	}
+	if keepRawData {
+		if shot.ClubMetrics != nil {
+			clubCopy := *shot.ClubMetrics
+			shot.ClubMetrics = &clubCopy
+		}
+	} else {
+		shot = shot.WithoutRawData()
+	}
+	if club != nil {
+		shot.Club = club.Name()
+		shot.ClubCode = club.RegularCode
+	}
+	if player != nil {
+		shot.Player = *player
+	}
+
+	if _, err := s.Add(shot); err != nil {
+		log.Printf("History: %v", err)
+	}
+}
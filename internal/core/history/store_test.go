@@ -0,0 +1,118 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+func TestStore_MergesBallAndClubMetrics(t *testing.T) {
+	sm := core.NewStateManager()
+	store := New(sm)
+	var stored []Shot
+	store.OnShot(func(shot Shot) { stored = append(stored, shot) })
+
+	club := core.ClubIron7
+	player := "Sam"
+	sm.SetClub(&club)
+	sm.SetPlayerName(&player)
+	sm.SetLastBallMetrics(&core.BallMetrics{ShotID: 3, BallSpeedMPS: 60, VerticalAngle: 14})
+	sm.Flush()
+	if shots := store.Shots(); len(shots) != 0 {
+		t.Fatalf("Expected the shot to wait for club data, got %d shots", len(shots))
+	}
+
+	// Club data from another shot is not merged
+	sm.SetLastClubMetrics(&core.ClubMetrics{ShotID: 2, ClubSpeed: 30})
+	sm.SetLastClubMetrics(&core.ClubMetrics{ShotID: 3, ClubSpeed: 40})
+	sm.Flush()
+
+	shots := store.Shots()
+	if len(shots) != 1 {
+		t.Fatalf("Expected 1 shot, got %d", len(shots))
+	}
+	shot := shots[0]
+	if shot.ID != 1 || shot.Ball.ShotID != 3 || shot.ClubMetrics == nil || shot.ClubMetrics.ClubSpeed != 40 {
+		t.Errorf("shot = %+v, want shot 3 with its club data", shot)
+	}
+	if shot.Club != club.Name() || shot.ClubCode != club.RegularCode || shot.Player != "Sam" {
+		t.Errorf("club %q (%q), player %q; want the club and player up when the ball was hit", shot.Club, shot.ClubCode, shot.Player)
+	}
+	if len(stored) != 1 || stored[0].ID != 1 {
+		t.Errorf("Expected the listener to get the stored shot once, got %+v", stored)
+	}
+}
+
+func TestStore_FlushStoresAShotWaitingForClubData(t *testing.T) {
+	sm := core.NewStateManager()
+	store := New(sm)
+
+	store.Flush()
+	if shots := store.Shots(); len(shots) != 0 {
+		t.Fatalf("Expected nothing stored without a pending shot, got %d", len(shots))
+	}
+
+	sm.SetLastBallMetrics(&core.BallMetrics{ShotID: 1, BallSpeedMPS: 50})
+	sm.Flush()
+	store.Flush()
+
+	shots := store.Shots()
+	if len(shots) != 1 || shots[0].ClubMetrics != nil || shots[0].Club != "Unknown" {
+		t.Fatalf("Expected one ball-only shot, got %+v", shots)
+	}
+
+	// Late club data doesn't store the shot again
+	sm.SetLastClubMetrics(&core.ClubMetrics{ShotID: 1})
+	sm.Flush()
+	store.Flush()
+	if shots := store.Shots(); len(shots) != 1 {
+		t.Errorf("Expected 1 shot after late club data, got %d", len(shots))
+	}
+}
+
+func TestStore_PersistsShotsAndVideos(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shot_history.jsonl")
+	store := New(core.NewStateManager())
+	if err := store.SetPath(path); err != nil {
+		t.Fatalf("SetPath() error = %v", err)
+	}
+
+	now := time.Now()
+	if _, err := store.Add(Shot{Timestamp: now.Add(-time.Minute), Club: "Driver", Ball: core.BallMetrics{BallSpeedMPS: 70}}); err != nil {
+		t.Fatal(err)
+	}
+	second, err := store.Add(Shot{Timestamp: now, Club: "7 Iron", Ball: core.BallMetrics{BallSpeedMPS: 50}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	video := Video{Camera: "bay", Filename: "bay-1.mp4"}
+	if err := store.AttachVideo(now, video); err != nil {
+		t.Fatalf("AttachVideo() error = %v", err)
+	}
+
+	loaded := New(core.NewStateManager())
+	if err := loaded.SetPath(path); err != nil {
+		t.Fatalf("SetPath() error = %v", err)
+	}
+	shots := loaded.Shots()
+	if len(shots) != 2 {
+		t.Fatalf("Expected 2 shots loaded, got %d", len(shots))
+	}
+	if shots[0].Club != "Driver" || len(shots[0].Videos) != 0 {
+		t.Errorf("first shot = %+v", shots[0])
+	}
+	// The shot was appended again with its video; the later line wins
+	if shots[1].ID != second.ID || len(shots[1].Videos) != 1 || shots[1].Videos[0] != video {
+		t.Errorf("second shot = %+v, want it with its video", shots[1])
+	}
+
+	next, err := loaded.Add(Shot{Club: "Putter"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.ID != 3 {
+		t.Errorf("next ID = %d, want 3 after the loaded shots", next.ID)
+	}
+}
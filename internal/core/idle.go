@@ -0,0 +1,123 @@
+package core
+
+import (
+	"log"
+	"time"
+)
+
+// Limits for the idle timeout
+const (
+	minIdleMinutes = 1
+	maxIdleMinutes = 240
+)
+
+// idleHeartbeatEvery sends one heartbeat per this many ticks while the device
+// is idle, stretching the default 5 second interval to 30 seconds
+const idleHeartbeatEvery = 6
+
+// IdleSettings controls when ball detection is switched off to save the
+// device's battery during a break
+type IdleSettings struct {
+	Enabled bool `json:"enabled"`
+	Minutes int  `json:"minutes"` // time without a shot before going idle
+}
+
+// DefaultIdleSettings returns the idle policy switched off, with a 15 minute
+// timeout ready to enable
+func DefaultIdleSettings() IdleSettings {
+	return IdleSettings{Enabled: false, Minutes: 15}
+}
+
+// Valid reports whether the timeout is within range
+func (s IdleSettings) Valid() bool {
+	return s.Minutes >= minIdleMinutes && s.Minutes <= maxIdleMinutes
+}
+
+func (s IdleSettings) timeout() time.Duration {
+	return time.Duration(s.Minutes) * time.Minute
+}
+
+// SetIdleSettings sets the idle policy. The idle timer restarts from now.
+func (lm *LaunchMonitor) SetIdleSettings(settings IdleSettings) {
+	lm.idleMu.Lock()
+	defer lm.idleMu.Unlock()
+	lm.idleSettings = settings
+	lm.lastActivity = lm.clock.Now()
+}
+
+// IsIdle reports whether ball detection was switched off by the idle policy
+func (lm *LaunchMonitor) IsIdle() bool {
+	lm.idleMu.Lock()
+	defer lm.idleMu.Unlock()
+	return lm.idle
+}
+
+// Wake restarts the idle timer and brings the device out of standby or idle,
+// re-arming ball detection. While the session is paused only standby ends;
+// detection is re-armed by Resume.
+func (lm *LaunchMonitor) Wake() error {
+	lm.recordActivity()
+	if lm.IsPaused() {
+		lm.clearStandby()
+		return nil
+	}
+	if lm.InStandby() {
+		return lm.leaveStandby()
+	}
+	if !lm.IsIdle() {
+		return nil
+	}
+	log.Println("LaunchMonitor: Waking from idle, re-arming ball detection")
+	return lm.ActivateBallDetection()
+}
+
+// recordActivity restarts the idle timer
+func (lm *LaunchMonitor) recordActivity() {
+	lm.idleMu.Lock()
+	defer lm.idleMu.Unlock()
+	lm.lastActivity = lm.clock.Now()
+}
+
+// setIdle records whether the device is idle and publishes changes
+func (lm *LaunchMonitor) setIdle(idle bool) {
+	lm.idleMu.Lock()
+	changed := lm.idle != idle
+	lm.idle = idle
+	lm.idleMu.Unlock()
+
+	if changed {
+		lm.stateManager.SetDeviceIdle(idle)
+	}
+}
+
+// checkIdle switches ball detection off once the idle timeout has passed
+// without a shot. It is called on every heartbeat tick.
+func (lm *LaunchMonitor) checkIdle() {
+	lm.idleMu.Lock()
+	settings := lm.idleSettings
+	expired := settings.Enabled && !lm.idle && lm.clock.Since(lm.lastActivity) >= settings.timeout()
+	lm.idleMu.Unlock()
+	if !expired {
+		return
+	}
+
+	lm.detectStateMu.Lock()
+	active := lm.detectModeActive
+	lm.detectStateMu.Unlock()
+	if !active {
+		return
+	}
+
+	log.Printf("LaunchMonitor: No shots for %d minutes, deactivating ball detection", settings.Minutes)
+	if err := lm.DeactivateBallDetection(); err != nil {
+		log.Printf("LaunchMonitor: Failed to deactivate ball detection for idle: %v", err)
+		return
+	}
+	lm.setIdle(true)
+}
+
+// heartbeatDue reports whether a heartbeat should be sent on this tick,
+// sending fewer while the device is idle
+func (lm *LaunchMonitor) heartbeatDue(tick int) bool {
+	return !lm.IsIdle() || tick%idleHeartbeatEvery == 0
+}
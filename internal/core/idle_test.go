@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckIdle_DeactivatesAndWakeRearms(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	lm.SetIdleSettings(IdleSettings{Enabled: true, Minutes: 15})
+
+	if err := lm.ActivateBallDetection(); err != nil {
+		t.Fatalf("ActivateBallDetection() error = %v", err)
+	}
+
+	lm.checkIdle()
+	if lm.IsIdle() {
+		t.Fatal("Expected the device to stay active before the timeout")
+	}
+
+	lm.idleMu.Lock()
+	lm.lastActivity = lm.clock.Now().Add(-16 * time.Minute)
+	lm.idleMu.Unlock()
+	writes := len(mockClient.GetWriteHistory())
+
+	lm.checkIdle()
+	if !lm.IsIdle() || !sm.GetDeviceIdle() {
+		t.Fatal("Expected the device to go idle after the timeout")
+	}
+	history := mockClient.GetWriteHistory()
+	if len(history) != writes+1 || history[len(history)-1].Data[1] != 0x81 || history[len(history)-1].Data[3] != 0x00 {
+		t.Fatalf("Expected a deactivate detect command, got %d new writes", len(history)-writes)
+	}
+	if lm.heartbeatDue(1) || !lm.heartbeatDue(idleHeartbeatEvery) {
+		t.Error("Expected heartbeats to slow down while idle")
+	}
+
+	if err := lm.Wake(); err != nil {
+		t.Fatalf("Wake() error = %v", err)
+	}
+	if lm.IsIdle() || sm.GetDeviceIdle() {
+		t.Error("Expected waking to clear the idle state")
+	}
+	if !lm.detectModeActive {
+		t.Error("Expected waking to re-arm ball detection")
+	}
+}
+
+func TestCheckIdle_DisabledDoesNothing(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	if err := lm.ActivateBallDetection(); err != nil {
+		t.Fatalf("ActivateBallDetection() error = %v", err)
+	}
+	lm.idleMu.Lock()
+	lm.lastActivity = lm.clock.Now().Add(-time.Hour)
+	lm.idleMu.Unlock()
+	writes := len(mockClient.GetWriteHistory())
+
+	lm.checkIdle()
+	if lm.IsIdle() || len(mockClient.GetWriteHistory()) != writes {
+		t.Error("Expected no idle handling while the policy is off")
+	}
+	if err := lm.Wake(); err != nil || len(mockClient.GetWriteHistory()) != writes {
+		t.Error("Expected waking an active device to send nothing")
+	}
+}
@@ -10,6 +10,9 @@ func (it *Integration) convertToShotFormat(ballMetrics core.BallMetrics, increme
 		it.lastShotNumber = it.shotNumber
 	}
 
+	spinAxis, sideSpin, horizontalAngle := it.spinConvention().Apply(
+		ballMetrics.SpinAxis, ballMetrics.SidespinRPM, ballMetrics.HorizontalAngle)
+
 	return ShotData{
 		DeviceID:   "CustomLaunchMonitor",
 		Units:      "Yards",
@@ -21,11 +24,11 @@ func (it *Integration) convertToShotFormat(ballMetrics core.BallMetrics, increme
 		},
 		BallData: &BallData{
 			Speed:     ballMetrics.BallSpeedMPS * 2.23694,
-			SpinAxis:  ballMetrics.SpinAxis * -1,
+			SpinAxis:  spinAxis,
 			TotalSpin: ballMetrics.TotalspinRPM,
 			BackSpin:  ballMetrics.BackspinRPM,
-			SideSpin:  ballMetrics.SidespinRPM * -1,
-			HLA:       ballMetrics.HorizontalAngle,
+			SideSpin:  sideSpin,
+			HLA:       horizontalAngle,
 			VLA:       ballMetrics.VerticalAngle,
 		},
 		ClubData: &ClubData{},
@@ -9,11 +9,6 @@ import (
 	"github.com/brentyates/squaregolf-connector/internal/core/simulator"
 )
 
-var (
-	itInstance *Integration
-	itOnce     sync.Once
-)
-
 type Integration struct {
 	*simulator.Base
 	stateManager   *core.StateManager
@@ -22,19 +17,34 @@ type Integration struct {
 	lastShotNumber int
 	shotListeners  []func(ShotData)
 	lastPlayerInfo *PlayerInfo
+	conventionMu   sync.RWMutex
+	convention     core.SpinConvention
 }
 
-func GetInstance(stateManager *core.StateManager, launchMonitor *core.LaunchMonitor, host string, port int) *Integration {
-	itOnce.Do(func() {
-		itInstance = &Integration{
-			stateManager:  stateManager,
-			launchMonitor: launchMonitor,
-			shotListeners: make([]func(ShotData), 0),
-		}
-		itInstance.Base = simulator.NewBase(itInstance, host, port)
-		itInstance.registerStateListeners()
-	})
-	return itInstance
+func New(stateManager *core.StateManager, launchMonitor *core.LaunchMonitor, host string, port int) *Integration {
+	it := &Integration{
+		stateManager:  stateManager,
+		launchMonitor: launchMonitor,
+		shotListeners: make([]func(ShotData), 0),
+		convention:    core.DefaultSpinConventions()[core.SimulatorInfiniteTees],
+	}
+	it.Base = simulator.NewBase(it, host, port)
+	it.registerStateListeners()
+	return it
+}
+
+// SetSpinConvention sets how spin axis, sidespin and horizontal launch angle
+// signs are mapped before shots are sent
+func (it *Integration) SetSpinConvention(convention core.SpinConvention) {
+	it.conventionMu.Lock()
+	defer it.conventionMu.Unlock()
+	it.convention = convention
+}
+
+func (it *Integration) spinConvention() core.SpinConvention {
+	it.conventionMu.RLock()
+	defer it.conventionMu.RUnlock()
+	return it.convention
 }
 
 func (it *Integration) Name() string {
@@ -55,7 +55,9 @@ func (it *Integration) onLastBallMetricsChanged(oldValue, newValue *core.BallMet
 	shotData := it.convertToShotFormat(*newValue, true)
 	if err := it.sendData(shotData); err != nil {
 		log.Printf("[%s] Error sending shot data: %v", it.Name(), err)
+		return
 	}
+	it.launchMonitor.Latency().MarkSent(it.Name())
 }
 
 func (it *Integration) onLastClubMetricsChanged(oldValue, newValue *core.ClubMetrics) {
@@ -0,0 +1,213 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/fileutil"
+)
+
+// maxNicknameLength bounds a device nickname
+const maxNicknameLength = 40
+
+// Errors returned by KnownDevices
+var (
+	ErrUnknownDevice = errors.New("unknown device")
+	ErrNicknameInUse = errors.New("nickname already in use")
+)
+
+// KnownDevice is a launch monitor seen by a scan or connected to before
+type KnownDevice struct {
+	Name     string     `json:"name"`
+	Address  string     `json:"address"`
+	Type     DeviceType `json:"type,omitempty"`
+	Nickname string     `json:"nickname,omitempty"` // set by the user, e.g. "Bay 3 unit"
+	LastSeen time.Time  `json:"lastSeen"`
+}
+
+// KnownDevices remembers every device with an address that has been seen, so
+// a room with several launch monitors can tell them apart by nickname.
+// Devices are keyed by address; ones seen only by name aren't kept.
+type KnownDevices struct {
+	clock   Clock
+	mu      sync.Mutex
+	path    string
+	devices []KnownDevice
+}
+
+// NewKnownDevices creates an empty store
+func NewKnownDevices(clock Clock) *KnownDevices {
+	return &KnownDevices{clock: clock}
+}
+
+// SetPath loads the saved devices from path and saves them there
+func (k *KnownDevices) SetPath(path string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.path = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var devices []KnownDevice
+	if err := json.Unmarshal(data, &devices); err != nil {
+		return err
+	}
+	k.devices = devices
+	return nil
+}
+
+// List returns the known devices, most recently seen first
+func (k *KnownDevices) List() []KnownDevice {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	devices := append([]KnownDevice{}, k.devices...)
+	sort.SliceStable(devices, func(i, j int) bool {
+		return devices[i].LastSeen.After(devices[j].LastSeen)
+	})
+	return devices
+}
+
+// Seen records that a device was found or connected to. A blank name or type
+// keeps the one already known.
+func (k *KnownDevices) Seen(device DiscoveredDevice) {
+	if device.Address == "" {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.seenLocked(device)
+	k.saveLocked()
+}
+
+// Merge records the devices found by a scan and returns them with what is
+// known about each, in the scan's order. Devices without an address are
+// returned as found.
+func (k *KnownDevices) Merge(found []DiscoveredDevice) []KnownDevice {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	merged := make([]KnownDevice, 0, len(found))
+	for _, device := range found {
+		if device.Address == "" {
+			merged = append(merged, KnownDevice{Name: device.Name, Type: device.Type, LastSeen: k.clock.Now()})
+			continue
+		}
+		merged = append(merged, *k.seenLocked(device))
+	}
+	k.saveLocked()
+	return merged
+}
+
+func (k *KnownDevices) seenLocked(device DiscoveredDevice) *KnownDevice {
+	known := k.findLocked(device.Address)
+	if known == nil {
+		k.devices = append(k.devices, KnownDevice{Address: device.Address})
+		known = &k.devices[len(k.devices)-1]
+	}
+	if device.Name != "" {
+		known.Name = device.Name
+	}
+	if device.Type != "" {
+		known.Type = device.Type
+	}
+	known.LastSeen = k.clock.Now()
+	return known
+}
+
+// SetNickname names the device at address. An empty nickname clears it.
+func (k *KnownDevices) SetNickname(address, nickname string) (KnownDevice, error) {
+	nickname = strings.TrimSpace(nickname)
+	if len(nickname) > maxNicknameLength {
+		return KnownDevice{}, errors.New("nickname too long")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	known := k.findLocked(address)
+	if known == nil {
+		return KnownDevice{}, ErrUnknownDevice
+	}
+	if nickname != "" {
+		if other, ok := k.byNicknameLocked(nickname); ok && !strings.EqualFold(other.Address, address) {
+			return KnownDevice{}, ErrNicknameInUse
+		}
+	}
+	known.Nickname = nickname
+	device := *known
+	k.saveLocked()
+	return device, nil
+}
+
+// ByNickname returns the device with the nickname, ignoring case
+func (k *KnownDevices) ByNickname(nickname string) (KnownDevice, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.byNicknameLocked(strings.TrimSpace(nickname))
+}
+
+// Forget removes the device at address
+func (k *KnownDevices) Forget(address string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for i := range k.devices {
+		if strings.EqualFold(k.devices[i].Address, address) {
+			k.devices = append(k.devices[:i], k.devices[i+1:]...)
+			k.saveLocked()
+			return nil
+		}
+	}
+	return ErrUnknownDevice
+}
+
+func (k *KnownDevices) byNicknameLocked(nickname string) (KnownDevice, bool) {
+	if nickname == "" {
+		return KnownDevice{}, false
+	}
+	for _, device := range k.devices {
+		if strings.EqualFold(device.Nickname, nickname) {
+			return device, true
+		}
+	}
+	return KnownDevice{}, false
+}
+
+// findLocked returns the device at address. MAC addresses are compared
+// ignoring case since platforms differ in how they print them.
+func (k *KnownDevices) findLocked(address string) *KnownDevice {
+	for i := range k.devices {
+		if strings.EqualFold(k.devices[i].Address, address) {
+			return &k.devices[i]
+		}
+	}
+	return nil
+}
+
+func (k *KnownDevices) saveLocked() {
+	if k.path == "" {
+		return
+	}
+	data, err := json.Marshal(k.devices)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(k.path), 0755); err != nil {
+		log.Printf("Failed to save known devices: %v", err)
+		return
+	}
+	if err := fileutil.WriteAtomic(k.path, "", data, 0644); err != nil {
+		log.Printf("Failed to save known devices: %v", err)
+	}
+}
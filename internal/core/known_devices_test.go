@@ -0,0 +1,71 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestKnownDevices_MergeKeepsNicknames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "known_devices.json")
+	clock := NewFakeClock(time.Unix(0, 0))
+	known := NewKnownDevices(clock)
+	if err := known.SetPath(path); err != nil {
+		t.Fatalf("SetPath() error = %v", err)
+	}
+
+	bay3 := DiscoveredDevice{Name: "SquareGolf(1234)", Address: "AA:BB:CC:DD:EE:03", Type: DeviceTypeHome}
+	known.Merge([]DiscoveredDevice{bay3, {Name: "SquareGolf(5678)"}})
+	if _, err := known.SetNickname("aa:bb:cc:dd:ee:03", "Bay 3 unit"); err != nil {
+		t.Fatalf("SetNickname() error = %v", err)
+	}
+
+	clock.Advance(time.Hour)
+	merged := known.Merge([]DiscoveredDevice{{Name: "SquareGolf(1234)", Address: bay3.Address}})
+	if len(merged) != 1 || merged[0].Nickname != "Bay 3 unit" || merged[0].Type != DeviceTypeHome || !merged[0].LastSeen.Equal(clock.Now()) {
+		t.Fatalf("Merge() = %+v, want the nicknamed device seen now", merged)
+	}
+
+	restored := NewKnownDevices(clock)
+	if err := restored.SetPath(path); err != nil {
+		t.Fatalf("SetPath() error = %v", err)
+	}
+	if devices := restored.List(); len(devices) != 1 {
+		t.Fatalf("Expected only the device with an address to be kept, got %+v", devices)
+	}
+	device, ok := restored.ByNickname("bay 3 UNIT")
+	if !ok || device.Address != bay3.Address {
+		t.Errorf("ByNickname() = %+v, %v, want the bay 3 device", device, ok)
+	}
+}
+
+func TestKnownDevices_SetNickname(t *testing.T) {
+	known := NewKnownDevices(NewFakeClock(time.Unix(0, 0)))
+	known.Seen(DiscoveredDevice{Name: "SquareGolf(1)", Address: "AA:BB:CC:DD:EE:01"})
+	known.Seen(DiscoveredDevice{Name: "SquareGolf(2)", Address: "AA:BB:CC:DD:EE:02"})
+
+	if _, err := known.SetNickname("AA:BB:CC:DD:EE:01", "Bay 1"); err != nil {
+		t.Fatalf("SetNickname() error = %v", err)
+	}
+	if _, err := known.SetNickname("AA:BB:CC:DD:EE:02", "BAY 1"); !errors.Is(err, ErrNicknameInUse) {
+		t.Errorf("SetNickname() with a taken nickname error = %v, want ErrNicknameInUse", err)
+	}
+	if _, err := known.SetNickname("AA:BB:CC:DD:EE:09", "Bay 9"); !errors.Is(err, ErrUnknownDevice) {
+		t.Errorf("SetNickname() for an unseen device error = %v, want ErrUnknownDevice", err)
+	}
+
+	if _, err := known.SetNickname("AA:BB:CC:DD:EE:01", ""); err != nil {
+		t.Fatalf("SetNickname() clearing error = %v", err)
+	}
+	if _, ok := known.ByNickname("Bay 1"); ok {
+		t.Error("Expected the cleared nickname not to match")
+	}
+
+	if err := known.Forget("AA:BB:CC:DD:EE:02"); err != nil {
+		t.Fatalf("Forget() error = %v", err)
+	}
+	if devices := known.List(); len(devices) != 1 {
+		t.Errorf("Expected one device after forgetting, got %+v", devices)
+	}
+}
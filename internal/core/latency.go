@@ -0,0 +1,213 @@
+package core
+
+import (
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxLatencyShots bounds how many shots' timings are kept
+const maxLatencyShots = 100
+
+// ShotLatency breaks down how long one shot took to pass through the
+// pipeline. Every duration is in milliseconds from the BLE notification
+// arriving.
+type ShotLatency struct {
+	ShotID     int                `json:"shotId"`
+	ReceivedAt time.Time          `json:"receivedAt"`
+	ParsedMs   float64            `json:"parsedMs"`
+	StateMs    *float64           `json:"stateMs,omitempty"` // nil while a misread is held
+	SentMs     map[string]float64 `json:"sentMs,omitempty"`  // keyed by simulator
+	TotalMs    *float64           `json:"totalMs,omitempty"` // to the last simulator write
+}
+
+// LatencySummary aggregates the recent shots' total latency
+type LatencySummary struct {
+	Shots  int     `json:"shots"`
+	MeanMs float64 `json:"meanMs"`
+	P50Ms  float64 `json:"p50Ms"`
+	P95Ms  float64 `json:"p95Ms"`
+	MaxMs  float64 `json:"maxMs"`
+}
+
+// shotTiming holds the raw timestamps for one shot
+type shotTiming struct {
+	id         int
+	receivedAt time.Time
+	parsedAt   time.Time
+	stateAt    time.Time
+	sentAt     map[string]time.Time
+}
+
+// LatencyTracker timestamps each device shot as it moves from BLE
+// notification to parse, state update and the writes to each simulator.
+// Shots are handled one at a time, so stages are attributed to the most
+// recent shot.
+type LatencyTracker struct {
+	clock          Clock
+	mu             sync.Mutex
+	lastReceivedAt time.Time
+	shots          []*shotTiming
+	nextID         int
+}
+
+// NewLatencyTracker creates a tracker that reads time from clock
+func NewLatencyTracker(clock Clock) *LatencyTracker {
+	return &LatencyTracker{clock: clock, nextID: 1}
+}
+
+// NotificationReceived records when the latest BLE notification arrived
+func (t *LatencyTracker) NotificationReceived() {
+	now := t.clock.Now()
+	t.mu.Lock()
+	t.lastReceivedAt = now
+	t.mu.Unlock()
+}
+
+// BeginShot starts timing a new shot from the latest notification, which was
+// parsed at parsedAt
+func (t *LatencyTracker) BeginShot(parsedAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	receivedAt := t.lastReceivedAt
+	if receivedAt.IsZero() || receivedAt.After(parsedAt) {
+		receivedAt = parsedAt
+	}
+	t.shots = append(t.shots, &shotTiming{
+		id:         t.nextID,
+		receivedAt: receivedAt,
+		parsedAt:   parsedAt,
+		sentAt:     make(map[string]time.Time),
+	})
+	t.nextID++
+	if len(t.shots) > maxLatencyShots {
+		t.shots = t.shots[len(t.shots)-maxLatencyShots:]
+	}
+}
+
+// MarkStateUpdated records when the current shot reached the state manager
+func (t *LatencyTracker) MarkStateUpdated() {
+	now := t.clock.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if shot := t.currentLocked(); shot != nil && shot.stateAt.IsZero() {
+		shot.stateAt = now
+	}
+}
+
+// MarkSent records when the current shot was written to a simulator and logs
+// its breakdown so far. Later writes for the same shot, such as club data,
+// are ignored.
+func (t *LatencyTracker) MarkSent(simulator string) {
+	now := t.clock.Now()
+	t.mu.Lock()
+	shot := t.currentLocked()
+	if shot == nil {
+		t.mu.Unlock()
+		return
+	}
+	if _, sent := shot.sentAt[simulator]; sent {
+		t.mu.Unlock()
+		return
+	}
+	shot.sentAt[simulator] = now
+	latency := shot.breakdown()
+	t.mu.Unlock()
+
+	stateMs := -1.0
+	if latency.StateMs != nil {
+		stateMs = *latency.StateMs
+	}
+	log.Printf("Latency: shot %d parsed %.1fms, state %.1fms, %s %.1fms after notification",
+		latency.ShotID, latency.ParsedMs, stateMs, simulator, latency.SentMs[simulator])
+}
+
+// Recent returns the breakdown of the recent shots, newest first
+func (t *LatencyTracker) Recent() []ShotLatency {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	latencies := make([]ShotLatency, 0, len(t.shots))
+	for i := len(t.shots) - 1; i >= 0; i-- {
+		latencies = append(latencies, t.shots[i].breakdown())
+	}
+	return latencies
+}
+
+// Summary aggregates the total latency of recent shots that reached a
+// simulator
+func (t *LatencyTracker) Summary() LatencySummary {
+	var totals []float64
+	for _, latency := range t.Recent() {
+		if latency.TotalMs != nil {
+			totals = append(totals, *latency.TotalMs)
+		}
+	}
+
+	summary := LatencySummary{Shots: len(totals)}
+	if len(totals) == 0 {
+		return summary
+	}
+	sort.Float64s(totals)
+
+	var sum float64
+	for _, total := range totals {
+		sum += total
+	}
+	summary.MeanMs = sum / float64(len(totals))
+	summary.P50Ms = percentile(totals, 0.50)
+	summary.P95Ms = percentile(totals, 0.95)
+	summary.MaxMs = totals[len(totals)-1]
+	return summary
+}
+
+func (t *LatencyTracker) currentLocked() *shotTiming {
+	if len(t.shots) == 0 {
+		return nil
+	}
+	return t.shots[len(t.shots)-1]
+}
+
+// breakdown converts the timestamps to durations from the notification
+func (s *shotTiming) breakdown() ShotLatency {
+	since := func(at time.Time) float64 {
+		return float64(at.Sub(s.receivedAt).Microseconds()) / 1000
+	}
+
+	latency := ShotLatency{
+		ShotID:     s.id,
+		ReceivedAt: s.receivedAt,
+		ParsedMs:   since(s.parsedAt),
+	}
+	if !s.stateAt.IsZero() {
+		stateMs := since(s.stateAt)
+		latency.StateMs = &stateMs
+	}
+	if len(s.sentAt) > 0 {
+		latency.SentMs = make(map[string]float64, len(s.sentAt))
+		var totalMs float64
+		for simulator, at := range s.sentAt {
+			sentMs := since(at)
+			latency.SentMs[simulator] = sentMs
+			if sentMs > totalMs {
+				totalMs = sentMs
+			}
+		}
+		latency.TotalMs = &totalMs
+	}
+	return latency
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
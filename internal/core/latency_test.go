@@ -0,0 +1,105 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyTracker_Breakdown(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	tracker := NewLatencyTracker(clock)
+
+	tracker.NotificationReceived()
+	clock.Advance(2 * time.Millisecond)
+	tracker.BeginShot(clock.Now())
+	clock.Advance(3 * time.Millisecond)
+	tracker.MarkStateUpdated()
+	clock.Advance(10 * time.Millisecond)
+	tracker.MarkSent("GSPro")
+	clock.Advance(20 * time.Millisecond)
+	tracker.MarkSent("Infinite Tees")
+
+	// Club data for the same shot is written later and must not count
+	clock.Advance(500 * time.Millisecond)
+	tracker.MarkSent("GSPro")
+
+	shots := tracker.Recent()
+	if len(shots) != 1 {
+		t.Fatalf("Recent() returned %d shots, want 1", len(shots))
+	}
+	shot := shots[0]
+	if shot.ShotID != 1 {
+		t.Errorf("ShotID = %d, want 1", shot.ShotID)
+	}
+	if shot.ParsedMs != 2 {
+		t.Errorf("ParsedMs = %v, want 2", shot.ParsedMs)
+	}
+	if shot.StateMs == nil || *shot.StateMs != 5 {
+		t.Errorf("StateMs = %v, want 5", shot.StateMs)
+	}
+	if shot.SentMs["GSPro"] != 15 || shot.SentMs["Infinite Tees"] != 35 {
+		t.Errorf("SentMs = %v, want GSPro 15 and Infinite Tees 35", shot.SentMs)
+	}
+	if shot.TotalMs == nil || *shot.TotalMs != 35 {
+		t.Errorf("TotalMs = %v, want 35", shot.TotalMs)
+	}
+}
+
+func TestLatencyTracker_HeldShotHasNoStateTime(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	tracker := NewLatencyTracker(clock)
+
+	tracker.NotificationReceived()
+	tracker.BeginShot(clock.Now())
+
+	shot := tracker.Recent()[0]
+	if shot.StateMs != nil || shot.TotalMs != nil {
+		t.Errorf("unsent shot should have no state or total time, got %+v", shot)
+	}
+	if summary := tracker.Summary(); summary.Shots != 0 {
+		t.Errorf("Summary().Shots = %d, want 0 for a shot that was never sent", summary.Shots)
+	}
+}
+
+func TestLatencyTracker_Summary(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	tracker := NewLatencyTracker(clock)
+
+	for i := 1; i <= 20; i++ {
+		tracker.NotificationReceived()
+		tracker.BeginShot(clock.Now())
+		clock.Advance(time.Duration(i) * time.Millisecond)
+		tracker.MarkSent("GSPro")
+		clock.Advance(time.Second)
+	}
+
+	summary := tracker.Summary()
+	if summary.Shots != 20 {
+		t.Errorf("Shots = %d, want 20", summary.Shots)
+	}
+	if summary.MeanMs != 10.5 {
+		t.Errorf("MeanMs = %v, want 10.5", summary.MeanMs)
+	}
+	if summary.P50Ms != 10 || summary.P95Ms != 19 || summary.MaxMs != 20 {
+		t.Errorf("P50/P95/Max = %v/%v/%v, want 10/19/20", summary.P50Ms, summary.P95Ms, summary.MaxMs)
+	}
+
+	if recent := tracker.Recent(); recent[0].ShotID != 20 {
+		t.Errorf("Recent()[0].ShotID = %d, want the newest shot first", recent[0].ShotID)
+	}
+}
+
+func TestLatencyTracker_KeepsRecentShots(t *testing.T) {
+	tracker := NewLatencyTracker(NewFakeClock(time.Unix(1700000000, 0)))
+	for i := 0; i < maxLatencyShots+5; i++ {
+		tracker.BeginShot(time.Unix(1700000000, 0))
+	}
+
+	shots := tracker.Recent()
+	if len(shots) != maxLatencyShots {
+		t.Fatalf("Recent() returned %d shots, want %d", len(shots), maxLatencyShots)
+	}
+	if shots[len(shots)-1].ShotID != 6 {
+		t.Errorf("oldest kept shot = %d, want 6", shots[len(shots)-1].ShotID)
+	}
+}
@@ -3,63 +3,194 @@ package core
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
-)
 
-var (
-	launchMonitorInstance *LaunchMonitor
-	launchMonitorOnce     sync.Once
+	"github.com/brentyates/squaregolf-connector/internal/core/protocol"
 )
 
-// GetLaunchMonitorInstance returns the singleton instance of LaunchMonitor
-func GetLaunchMonitorInstance(sm *StateManager, btManager *BluetoothManager) *LaunchMonitor {
-	launchMonitorOnce.Do(func() {
-		launchMonitorInstance = &LaunchMonitor{
-			stateManager:    sm,
-			sequence:        0,
-			bluetoothClient: btManager.GetClient(),
-		}
-	})
-	return launchMonitorInstance
-}
-
-// NewLaunchMonitor is deprecated, use GetLaunchMonitorInstance instead
+// NewLaunchMonitor creates a LaunchMonitor using the Bluetooth manager's client
 func NewLaunchMonitor(sm *StateManager, btManager *BluetoothManager) *LaunchMonitor {
-	return GetLaunchMonitorInstance(sm, btManager)
+	return &LaunchMonitor{
+		stateManager:       sm,
+		sequence:           0,
+		bluetoothClient:    btManager.GetClient(),
+		clock:              RealClock(),
+		latency:            NewLatencyTracker(RealClock()),
+		notifications:      NewNotificationCounter(RealClock()),
+		environment:        DefaultEnvironmentSettings(),
+		idleSettings:       DefaultIdleSettings(),
+		alignmentSmoothing: DefaultAlignmentSmoothing(),
+		heartbeatInterval:  DefaultHeartbeatInterval,
+		sleepSchedule:      DefaultSleepSchedule(),
+	}
 }
 
+// Command queue pacing and retry
+const (
+	commandGap        = 150 * time.Millisecond // minimum time between writes
+	commandRetries    = 2                      // extra attempts after a failed write
+	commandRetryDelay = 100 * time.Millisecond
+
+	alignmentClubSettle = 1 * time.Second
+)
+
 type cmdEntry struct {
 	hexCmd string
+	settle time.Duration // time the device needs after this command, if longer than commandGap
 	errCh  chan error
 }
 
+// commandQueue is a running command queue. Stopping it cancels ctx, and done
+// is closed once its drain goroutine has exited.
+type commandQueue struct {
+	entries chan cmdEntry
+	ctx     context.Context
+	stop    context.CancelFunc
+	done    chan struct{}
+}
+
 // LaunchMonitor encapsulates the launch monitor functionality
 type LaunchMonitor struct {
 	stateManager      *StateManager
+	clock             Clock
 	sequence          int
 	sequenceMutex     sync.Mutex
 	heartbeatCancel   context.CancelFunc
 	heartbeatCancelMu sync.Mutex
+	heartbeatInterval time.Duration
 	bluetoothClient   BluetoothClient
 	omniClubRetryMu   sync.Mutex
 	omniClubRetryGen  int
 	omniClubRetried   bool
 	detectStateMu     sync.Mutex
 	detectModeActive  bool
+	armedSpinMode     SpinMode
+	resumeDetection   bool
 	omniIdleCount     int
-	cmdQueue          chan cmdEntry
-	cmdQueueCtx       context.Context
-	cmdQueueStop      context.CancelFunc
-	cmdQueueOnce      sync.Once
+	cmdQueueMu        sync.Mutex
+	cmdQueue          *commandQueue
+	cmdQueueDone      chan struct{} // closed when the last stopped queue has drained
+	acks              ackTracker
+	shotDedup         shotDeduplicator
 	chargeCancel      context.CancelFunc
 	chargeCancelMu    sync.Mutex
 	capacitorReady    bool
 	capacitorReadyMu  sync.Mutex
+
+	misreadMu              sync.Mutex
+	nextMisreadID          int
+	lastMisreadRaw         string
+	awaitingClubMisreadID  int
+	lastDiscardedMisreadID int
+
+	spinMu         sync.RWMutex
+	spinEstimator  SpinEstimator
+	spinEstimation bool
+	clubSpinModes  ClubSpinModes
+
+	environmentMu sync.RWMutex
+	environment   EnvironmentSettings
+
+	clubSpeedMu         sync.RWMutex
+	smashFactors        map[string]float64
+	clubSpeedEstimation bool
+
+	positionMu      sync.Mutex
+	matCalibration  MatCalibration
+	rawBallPosition *BallPosition
+
+	alignmentCaptureMu sync.Mutex
+	alignmentCapture   *AlignmentCapture
+
+	alignmentProfilesMu sync.Mutex
+	alignmentProfiles   AlignmentProfiles
+
+	alignmentMu        sync.Mutex
+	alignmentSmoothing AlignmentSmoothing
+	alignmentReadings  []float64
+	alignmentAngle     float64
+	alignmentPending   bool
+	alignmentThrottle  *Throttle
+
+	deviceSettingsMu sync.Mutex
+
+	idleMu       sync.Mutex
+	idleSettings IdleSettings
+	idle         bool
+	lastActivity time.Time
+
+	standbyMu       sync.Mutex
+	standby         bool
+	sleepSchedule   SleepSchedule
+	scheduledAsleep bool
+	scheduleCancel  context.CancelFunc
+
+	pauseMu          sync.Mutex
+	paused           bool
+	resumeAfterPause bool
+
+	swingStickMu sync.Mutex
+	swingStick   bool
+	lastSwingRaw string
+
+	cooldownMu    sync.Mutex
+	shotCooldown  time.Duration
+	cooldownUntil time.Time
+	cooldownGen   int
+
+	notificationWatchers notificationWatchers
+
+	arbiterMu          sync.Mutex
+	shotArbiter        *ShotArbiter
+	deviceShotRejected bool
+	rejectedShotRaw    string
+
+	shotIDMu sync.Mutex
+	shotID   int64
+
+	latency       *LatencyTracker
+	notifications *NotificationCounter
+	supervisor    *Supervisor
+}
+
+// SetClock replaces the clock used for heartbeats, polling and command
+// timeouts. It must be called before the launch monitor starts any timers.
+func (lm *LaunchMonitor) SetClock(clock Clock) {
+	lm.clock = clock
+	lm.latency = NewLatencyTracker(clock)
+	lm.notifications = NewNotificationCounter(clock)
+}
+
+// SetSupervisor restarts the heartbeat task if it panics. It must be called
+// before the launch monitor starts any timers.
+func (lm *LaunchMonitor) SetSupervisor(supervisor *Supervisor) {
+	lm.supervisor = supervisor
+}
+
+// Latency returns the tracker timing each shot through the pipeline
+func (lm *LaunchMonitor) Latency() *LatencyTracker {
+	return lm.latency
+}
+
+// Notifications returns the counter of notification frames by header
+func (lm *LaunchMonitor) Notifications() *NotificationCounter {
+	return lm.notifications
+}
+
+// afterFunc calls f in its own goroutine once d has elapsed on the clock.
+// The timer starts before afterFunc returns.
+func (lm *LaunchMonitor) afterFunc(d time.Duration, f func()) {
+	timer := lm.clock.After(d)
+	go func() {
+		<-timer
+		f()
+	}()
 }
 
 // UpdateBluetoothClient updates the bluetooth client reference
@@ -73,6 +204,12 @@ func (lm *LaunchMonitor) NotificationHandler(uuid string, data []byte) {
 		log.Println("Received empty notification data")
 		return
 	}
+	if len(data) > protocol.MaxMessageLength {
+		log.Printf("LaunchMonitor: Ignoring oversized notification (%d bytes, at most %d)", len(data), protocol.MaxMessageLength)
+		return
+	}
+	lm.latency.NotificationReceived()
+	lm.notificationWatchers.notify(uuid, data)
 
 	hexData := hex.EncodeToString(data)
 
@@ -91,53 +228,44 @@ func (lm *LaunchMonitor) NotificationHandler(uuid string, data []byte) {
 		}
 	}
 
+	lm.acks.observe(bytesList)
+
 	// Battery message on main characteristic (type 0x91 = 145)
-	if len(bytesList) >= 2 && bytesList[0] == "91" {
+	if len(data) >= 2 && data[0] == protocol.BatteryMessageType {
+		lm.notifications.record(batteryHeader, "battery", frameParsed, data)
 		lm.HandleBatteryMessage(bytesList)
 		return
 	}
 
-	// Process by byte patterns
-	if len(bytesList) >= 2 {
-		// Handle alignment notifications (format 11 04)
-		if bytesList[0] == "11" && bytesList[1] == "04" {
-			lm.HandleAlignmentNotification(bytesList)
-			return
-		}
-
-		// Sensor notifications (format 11 01)
-		if bytesList[0] == "11" && bytesList[1] == "01" {
-			lm.HandleSensorNotification(bytesList)
-			return
-		} else if len(bytesList) >= 3 {
-			// Shot Ball Metrics (format 11 02)
-			if bytesList[0] == "11" && bytesList[1] == "02" {
-				lm.HandleShotBallMetrics(bytesList)
-				return
-			}
-			if bytesList[0] == "11" && bytesList[1] == "03" {
-				lm.HandleStatusNotification(bytesList)
-				return
-			}
-			// Capacitor charge status (format 11 06)
-			if bytesList[0] == "11" && bytesList[1] == "06" {
-				lm.HandleChargeNotification(bytesList)
-				return
-			}
-			// OS Version response (format 11 10)
-			if bytesList[0] == "11" && bytesList[1] == "10" {
-				lm.HandleOSVersionNotification(bytesList)
-				return
-			}
-			// Shot Club Metrics (format 11 07)
-			if bytesList[0] == "11" && bytesList[1] == "07" && len(bytesList) >= 11 {
-				lm.HandleShotClubMetrics(bytesList)
-				return
-			}
-		}
+	messageType, ok := protocol.Default().Lookup(data)
+	if !ok {
+		lm.notifications.record(frameHeader(data), "", frameUnknown, data)
+		return
+	}
+	if len(data) < messageType.MinLength {
+		lm.notifications.record(messageType.Header.String(), messageType.Name, frameShort, data)
+		log.Printf("LaunchMonitor: Ignoring short %s notification (%d bytes, need %d)", messageType.Name, len(data), messageType.MinLength)
+		return
+	}
+	lm.notifications.record(messageType.Header.String(), messageType.Name, frameParsed, data)
+	if handler, ok := notificationHandlers[messageType.Header]; ok {
+		handler(lm, bytesList)
 	}
 }
 
+// notificationHandlers routes each message type in the protocol registry to
+// its handler. Adding a message type means registering it in the protocol
+// package and adding its handler here.
+var notificationHandlers = map[protocol.Header]func(lm *LaunchMonitor, bytesList []string){
+	protocol.HeaderSensor:    (*LaunchMonitor).HandleSensorNotification,
+	protocol.HeaderShotBall:  (*LaunchMonitor).HandleShotBallMetrics,
+	protocol.HeaderStatus:    (*LaunchMonitor).HandleStatusNotification,
+	protocol.HeaderAlignment: (*LaunchMonitor).HandleAlignmentNotification,
+	protocol.HeaderCharge:    (*LaunchMonitor).HandleChargeNotification,
+	protocol.HeaderShotClub:  (*LaunchMonitor).HandleShotClubMetrics,
+	protocol.HeaderOSVersion: (*LaunchMonitor).HandleOSVersionNotification,
+}
+
 // HandleSensorNotification handles sensor notifications (format 11 01)
 func (lm *LaunchMonitor) HandleSensorNotification(bytesList []string) {
 	sensorData, err := ParseSensorData(bytesList)
@@ -146,27 +274,33 @@ func (lm *LaunchMonitor) HandleSensorNotification(bytesList []string) {
 		return
 	}
 
-	lm.stateManager.SetBallDetected(sensorData.BallDetected)
-	lm.stateManager.SetBallReady(sensorData.BallReady)
+	// A ball placed during the cooldown after a shot is reported once it ends
+	cooldown := lm.InShotCooldown()
+	lm.stateManager.SetBallDetected(sensorData.BallDetected && !cooldown)
+	lm.stateManager.SetBallReady(sensorData.BallReady && !cooldown)
 
-	ballPosition := &BallPosition{
+	ballPosition := lm.calibratePosition(BallPosition{
 		X: sensorData.PositionX,
 		Y: sensorData.PositionY,
 		Z: sensorData.PositionZ,
-	}
+	})
 	lm.stateManager.SetBallPosition(ballPosition)
 }
 
 // HandleAlignmentNotification handles alignment/aim notifications (format 11 04)
 func (lm *LaunchMonitor) HandleAlignmentNotification(bytesList []string) {
-	alignmentData, err := ParseAlignmentData(bytesList)
+	if capture := lm.AlignmentCapture(); capture != nil {
+		capture.Record(bytesList)
+	}
+
+	alignmentData, err := ParseAlignmentData(bytesList, lm.stateManager.GetAlignmentFormat())
 	if err != nil {
 		log.Printf("Error parsing alignment data: %v", err)
 		return
 	}
 
 	// Update alignment state - IsAligning is controlled by the UI
-	lm.stateManager.SetAlignmentAngle(alignmentData.AimAngle)
+	lm.smoothAlignmentAngle(alignmentData.AimAngle)
 	lm.stateManager.SetIsAligned(alignmentData.IsAligned)
 }
 
@@ -177,32 +311,55 @@ func (lm *LaunchMonitor) HandleShotBallMetrics(bytesList []string) {
 		log.Printf("Failed to parse shot metrics data: %v", err)
 		return
 	}
+	parsedAt := lm.clock.Now()
+
+	if lm.IsPaused() {
+		log.Printf("LaunchMonitor: Dropped device shot, session is paused")
+		return
+	}
+	if lm.dropInCooldown("ball metrics") {
+		return
+	}
 
 	if lm.stateManager.GetDeviceType() == DeviceTypeOmni {
 		ApplyOmniBallValidityBitmask(shotMetrics)
 		lm.applyOmniPutterBallValidityFilter(shotMetrics)
 	}
 
-	// Update state manager with ball metrics
-	lastBallMetrics := lm.stateManager.GetLastBallMetrics()
-
-	// Convert RawData to string for comparison and storage
-	rawDataStr := ""
-	for i, b := range shotMetrics.RawData {
-		if i > 0 {
-			rawDataStr += " "
-		}
-		rawDataStr += b
-	}
+	rawDataStr := strings.Join(shotMetrics.RawData, " ")
 
-	// Check if this is a new shot by comparing raw data
-	var lastRawData string
-	if lastBallMetrics != nil {
-		lastRawData = strings.Join(lastBallMetrics.RawData, " ")
+	if lm.IsSwingStick() {
+		lm.handleSwingStickSwing(rawDataStr)
+		return
 	}
 
-	if lastBallMetrics == nil || lastRawData != rawDataStr {
-		lm.stateManager.SetLastBallMetrics(shotMetrics)
+	// The device resends a shot's metrics; only a new shot is published.
+	// With no last shot, as after it is cleared, there is nothing to repeat.
+	repeat := lm.shotDedup.repeat(rawDataStr, parsedAt)
+	if !repeat || lm.stateManager.GetLastBallMetrics() == nil {
+		shotMetrics.ShotType = ShotTypeFull
+		if club := lm.stateManager.GetClub(); club != nil && *club == ClubPutter {
+			shotMetrics.ShotType = ShotTypePutt
+		}
+		if !lm.acceptDeviceShot(rawDataStr) {
+			log.Printf("LaunchMonitor: Dropped device shot, routed to another source")
+			return
+		}
+		lm.latency.BeginShot(parsedAt)
+		lm.stampBallMetrics(shotMetrics, parsedAt)
+		lm.recordActivity()
+		lm.startShotCooldown()
+		lm.applyAutomaticSpinEstimation(shotMetrics)
+		lm.applyEnvironment(shotMetrics)
+
+		held, duplicate := lm.holdIfMisread(shotMetrics, rawDataStr)
+		if duplicate {
+			return
+		}
+		if !held {
+			lm.stateManager.SetLastBallMetrics(shotMetrics)
+			lm.latency.MarkStateUpdated()
+		}
 
 		// Automatically request club metrics after receiving shot metrics
 		if lm.bluetoothClient != nil && lm.bluetoothClient.IsConnected() {
@@ -225,6 +382,7 @@ func (lm *LaunchMonitor) HandleShotBallMetrics(bytesList []string) {
 func (lm *LaunchMonitor) HandleShotClubMetrics(bytesList []string) {
 	var clubMetrics *ClubMetrics
 	var err error
+	parsedAt := lm.clock.Now()
 
 	if lm.stateManager.GetDeviceType() == DeviceTypeOmni {
 		if len(bytesList) < 19 {
@@ -244,7 +402,12 @@ func (lm *LaunchMonitor) HandleShotClubMetrics(bytesList []string) {
 		return
 	}
 
-	lm.stateManager.SetLastClubMetrics(clubMetrics)
+	if lm.IsSwingStick() {
+		lm.publishSwing(clubMetrics)
+		return
+	}
+	lm.stampClubMetrics(clubMetrics, parsedAt)
+	lm.publishClubMetrics(clubMetrics)
 }
 
 // HandleStatusNotification handles device status notifications (format 11 03 {status}).
@@ -346,18 +509,15 @@ func (lm *LaunchMonitor) handleOmniStatusRecovery(status LaunchMonitorStatus) {
 		return
 	}
 
-	spinMode := lm.stateManager.GetSpinMode()
-	if spinMode == nil {
-		defaultSpinMode := Advanced
-		spinMode = &defaultSpinMode
-	}
+	spinMode := lm.currentSpinMode()
 
 	seq := lm.getNextSequence()
-	detectCommand := DetectBallCommand(seq, Activate, *spinMode)
+	detectCommand := DetectBallCommand(seq, Activate, spinMode)
 	if err := lm.SendCommand(detectCommand); err != nil {
 		log.Printf("LaunchMonitor: Failed to re-arm Omni detect mode after idle status: %v", err)
 		return
 	}
+	lm.armedSpinMode = spinMode
 
 	log.Println("LaunchMonitor: Re-armed Omni detect mode after repeated idle status")
 }
@@ -378,7 +538,7 @@ func (lm *LaunchMonitor) startOmniClubMetricsRequest() {
 	lm.omniClubRetried = false
 	lm.omniClubRetryMu.Unlock()
 
-	time.AfterFunc(1*time.Second, func() {
+	lm.afterFunc(1*time.Second, func() {
 		lm.handleOmniClubMetricsTimeout(gen)
 	})
 }
@@ -409,7 +569,7 @@ func (lm *LaunchMonitor) handleOmniClubMetricsTimeout(gen int) {
 			}
 		}
 
-		time.AfterFunc(1*time.Second, func() {
+		lm.afterFunc(1*time.Second, func() {
 			lm.handleOmniClubMetricsTimeout(gen)
 		})
 		return
@@ -420,7 +580,7 @@ func (lm *LaunchMonitor) handleOmniClubMetricsTimeout(gen int) {
 	lm.omniClubRetryMu.Unlock()
 
 	log.Printf("Omni club metrics request timed out twice, applying invalid fallback")
-	lm.stateManager.SetLastClubMetrics(&ClubMetrics{})
+	lm.publishClubMetrics(&ClubMetrics{})
 }
 
 func (lm *LaunchMonitor) applyPutterClubFilter(clubMetrics *ClubMetrics) {
@@ -576,69 +736,138 @@ func (lm *LaunchMonitor) syncOmniCarryAdjustment() {
 	}
 }
 
-func (lm *LaunchMonitor) ensureCommandQueue() {
-	lm.cmdQueueOnce.Do(func() {
-		lm.cmdQueue = make(chan cmdEntry, 32)
-		lm.cmdQueueCtx, lm.cmdQueueStop = context.WithCancel(context.Background())
-		go lm.drainCommandQueue()
-	})
+// ensureCommandQueue returns the running command queue, starting one once
+// the previous queue's drain goroutine has exited
+func (lm *LaunchMonitor) ensureCommandQueue() *commandQueue {
+	lm.cmdQueueMu.Lock()
+	defer lm.cmdQueueMu.Unlock()
+	if lm.cmdQueue != nil {
+		return lm.cmdQueue
+	}
+	if lm.cmdQueueDone != nil {
+		<-lm.cmdQueueDone
+		lm.cmdQueueDone = nil
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	q := &commandQueue{
+		entries: make(chan cmdEntry, 32),
+		ctx:     ctx,
+		stop:    stop,
+		done:    make(chan struct{}),
+	}
+	lm.cmdQueue = q
+	go lm.drainCommandQueue(q)
+	return q
 }
 
-func (lm *LaunchMonitor) drainCommandQueue() {
+func (lm *LaunchMonitor) drainCommandQueue(q *commandQueue) {
+	defer close(q.done)
 	for {
 		select {
-		case <-lm.cmdQueueCtx.Done():
+		case <-q.ctx.Done():
 			return
-		case entry := <-lm.cmdQueue:
-			err := lm.writeCommand(entry.hexCmd)
+		case entry := <-q.entries:
+			err := lm.writeCommandWithRetry(q.ctx, entry.hexCmd)
 			entry.errCh <- err
+
+			gap := commandGap
+			if entry.settle > gap {
+				gap = entry.settle
+			}
 			select {
-			case <-lm.cmdQueueCtx.Done():
+			case <-q.ctx.Done():
 				return
-			case <-time.After(150 * time.Millisecond):
+			case <-lm.clock.After(gap):
 			}
 		}
 	}
 }
 
+// writeCommandWithRetry retries writes that fail while the device is still
+// connected. A dropped connection or a malformed command fails at once.
+func (lm *LaunchMonitor) writeCommandWithRetry(ctx context.Context, commandHex string) error {
+	for attempt := 0; ; attempt++ {
+		err := lm.writeCommand(commandHex)
+
+		var permanent *permanentCommandError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if err == nil || attempt == commandRetries {
+			return err
+		}
+
+		log.Printf("LaunchMonitor: Write of %s failed (%v), retrying (%d/%d)", commandHex, err, attempt+1, commandRetries)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-lm.clock.After(commandRetryDelay):
+		}
+	}
+}
+
+// permanentCommandError marks a write failure that retrying cannot fix
+type permanentCommandError struct {
+	err error
+}
+
+func (e *permanentCommandError) Error() string { return e.err.Error() }
+func (e *permanentCommandError) Unwrap() error { return e.err }
+
 func (lm *LaunchMonitor) writeCommand(commandHex string) error {
 	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
-		return fmt.Errorf("not connected to device")
+		return &permanentCommandError{err: fmt.Errorf("not connected to device")}
 	}
 
 	commandBytes, err := hex.DecodeString(commandHex)
 	if err != nil {
-		return fmt.Errorf("invalid hex command: %w", err)
+		return &permanentCommandError{err: fmt.Errorf("invalid hex command: %w", err)}
 	}
 
 	return lm.bluetoothClient.WriteCharacteristic(CommandCharUUID, commandBytes)
 }
 
+// stopCommandQueue stops the running queue. The next command starts a new
+// one.
 func (lm *LaunchMonitor) stopCommandQueue() {
-	if lm.cmdQueueStop != nil {
-		lm.cmdQueueStop()
+	lm.cmdQueueMu.Lock()
+	defer lm.cmdQueueMu.Unlock()
+	if lm.cmdQueue == nil {
+		return
 	}
+	lm.cmdQueue.stop()
+	lm.cmdQueueDone = lm.cmdQueue.done
+	lm.cmdQueue = nil
 }
 
-// SendCommand sends a command to the BLE device via the rate-limited queue
+// SendCommand sends a command to the BLE device via the rate-limited queue.
+// Commands are written in the order they are sent, at least commandGap apart,
+// and failed writes are retried while the device stays connected.
 func (lm *LaunchMonitor) SendCommand(commandHex string) error {
-	lm.ensureCommandQueue()
+	return lm.sendCommand(commandHex, 0)
+}
+
+// sendCommand queues a command and holds back the next one for settle
+func (lm *LaunchMonitor) sendCommand(commandHex string, settle time.Duration) error {
+	q := lm.ensureCommandQueue()
 
 	entry := cmdEntry{
 		hexCmd: commandHex,
+		settle: settle,
 		errCh:  make(chan error, 1),
 	}
 
 	select {
-	case lm.cmdQueue <- entry:
-	case <-time.After(5 * time.Second):
+	case q.entries <- entry:
+	case <-lm.clock.After(5 * time.Second):
 		return fmt.Errorf("command queue full")
 	}
 
 	select {
 	case err := <-entry.errCh:
 		return err
-	case <-time.After(5 * time.Second):
+	case <-lm.clock.After(5 * time.Second):
 		return fmt.Errorf("command execution timed out")
 	}
 }
@@ -668,14 +897,16 @@ func (lm *LaunchMonitor) ReadBatteryLevel() (int, error) {
 
 // ActivateBallDetection activates ball detection mode
 func (lm *LaunchMonitor) ActivateBallDetection() error {
+	if lm.IsPaused() {
+		return ErrSessionPaused
+	}
 	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
 		return fmt.Errorf("not connected to device")
 	}
 
-	// Get current club, handedness, and spin mode from state
+	// Get current club and handedness from state
 	club := lm.stateManager.GetClub()
 	handedness := lm.stateManager.GetHandedness()
-	spinMode := lm.stateManager.GetSpinMode()
 
 	// Default to right-handed driver if not set
 	if club == nil {
@@ -686,19 +917,12 @@ func (lm *LaunchMonitor) ActivateBallDetection() error {
 		defaultHandedness := RightHanded
 		handedness = &defaultHandedness
 	}
-	if spinMode == nil {
-		defaultSpinMode := Advanced
-		spinMode = &defaultSpinMode
-	}
+	// The club may have its own spin mode
+	spinMode := lm.spinModeFor(club)
 
-	// Send club command (Omni uses adjusted clubSel encoding)
+	// Send club command
 	seq := lm.getNextSequence()
-	var clubCommand string
-	if lm.stateManager.GetDeviceType() == DeviceTypeOmni {
-		clubCommand = OmniClubCommand(seq, *club, *handedness)
-	} else {
-		clubCommand = ClubCommand(seq, *club, *handedness)
-	}
+	clubCommand := lm.clubCommand(seq, *club, *handedness)
 
 	err := lm.SendCommand(clubCommand)
 	if err != nil {
@@ -707,17 +931,25 @@ func (lm *LaunchMonitor) ActivateBallDetection() error {
 
 	// Send detect ball command
 	seq = lm.getNextSequence()
-	detectCommand := DetectBallCommand(seq, Activate, *spinMode)
+	detectCommand := DetectBallCommand(seq, Activate, spinMode)
 
-	err = lm.SendCommand(detectCommand)
+	// The club command has no known response; the detect command is answered
+	// by a status notification
+	err = lm.sendTrackedCommand(detectCommand, lm.detectAck())
 	if err != nil {
 		return fmt.Errorf("failed to send detect ball command: %w", err)
 	}
 
 	lm.detectStateMu.Lock()
 	lm.detectModeActive = true
+	lm.armedSpinMode = spinMode
 	lm.omniIdleCount = 0
 	lm.detectStateMu.Unlock()
+	lm.shotDedup.armed()
+
+	lm.recordActivity()
+	lm.setIdle(false)
+	lm.clearStandby()
 
 	return nil
 }
@@ -728,15 +960,10 @@ func (lm *LaunchMonitor) DeactivateBallDetection() error {
 		return fmt.Errorf("not connected to device")
 	}
 
-	// Get current spin mode from state
-	spinMode := lm.stateManager.GetSpinMode()
-	if spinMode == nil {
-		defaultSpinMode := Advanced
-		spinMode = &defaultSpinMode
-	}
+	spinMode := lm.currentSpinMode()
 
 	seq := lm.getNextSequence()
-	detectCommand := DetectBallCommand(seq, Deactivate, *spinMode)
+	detectCommand := DetectBallCommand(seq, Deactivate, spinMode)
 
 	err := lm.SendCommand(detectCommand)
 	if err != nil {
@@ -745,9 +972,12 @@ func (lm *LaunchMonitor) DeactivateBallDetection() error {
 
 	lm.detectStateMu.Lock()
 	lm.detectModeActive = false
+	lm.resumeDetection = false
 	lm.omniIdleCount = 0
 	lm.detectStateMu.Unlock()
 
+	lm.setIdle(false)
+
 	return nil
 }
 
@@ -770,7 +1000,10 @@ func (lm *LaunchMonitor) getNextSequence() int {
 func (lm *LaunchMonitor) startHeartbeatTask() {
 	lm.heartbeatCancelMu.Lock()
 	defer lm.heartbeatCancelMu.Unlock()
+	lm.startHeartbeatTaskLocked()
+}
 
+func (lm *LaunchMonitor) startHeartbeatTaskLocked() {
 	lm.stopHeartbeatTaskLocked()
 
 	// Create a new context with cancellation
@@ -778,19 +1011,25 @@ func (lm *LaunchMonitor) startHeartbeatTask() {
 	lm.heartbeatCancel = cancel
 
 	// Start the heartbeat task in a goroutine
-	go func() {
-		ticker := time.NewTicker(5 * time.Second)
+	interval := lm.heartbeatInterval
+	lm.supervisor.Go("heartbeat", func() {
+		ticker := lm.clock.NewTicker(interval)
 		defer ticker.Stop()
 
+		tick := 0
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-ticker.C:
-				lm.sendHeartbeatTick()
+			case <-ticker.C():
+				tick++
+				lm.checkIdle()
+				if lm.heartbeatDue(tick) {
+					lm.sendHeartbeatTick()
+				}
 			}
 		}
-	}()
+	})
 }
 
 func (lm *LaunchMonitor) sendHeartbeatTick() {
@@ -798,14 +1037,20 @@ func (lm *LaunchMonitor) sendHeartbeatTick() {
 		return
 	}
 
-	seq := lm.getNextSequence()
-	command := HeartbeatCommand(seq)
-	err := lm.SendCommand(command)
-	if err != nil {
+	if err := lm.SendHeartbeat(); err != nil {
 		log.Printf("Error sending heartbeat: %v", err)
 	}
 }
 
+// SendHeartbeat sends a single heartbeat to the device outside the regular
+// interval
+func (lm *LaunchMonitor) SendHeartbeat() error {
+	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
+		return fmt.Errorf("not connected to device")
+	}
+	return lm.SendCommand(HeartbeatCommand(lm.getNextSequence()))
+}
+
 func (lm *LaunchMonitor) stopHeartbeatTaskLocked() {
 	if lm.heartbeatCancel != nil {
 		lm.heartbeatCancel()
@@ -868,8 +1113,20 @@ func (lm *LaunchMonitor) SetupNotifications(btManager *BluetoothManager) {
 		if newValue == ConnectionStatusConnected && oldValue != ConnectionStatusConnected {
 			log.Println("LaunchMonitor: Device connected")
 			lm.setCapacitorReady(false)
-			lm.startChargePolling()
-			go lm.sendOmniInitSequence()
+			if !lm.InStandby() {
+				// The heartbeat stops on disconnect
+				lm.startHeartbeatTask()
+				lm.startChargePolling()
+			}
+			go func() {
+				// Don't leave the device waiting a full interval while it is
+				// set up again
+				if !lm.InStandby() {
+					lm.sendHeartbeatTick()
+				}
+				lm.sendOmniInitSequence()
+				lm.restoreDeviceState()
+			}()
 		} else if newValue == ConnectionStatusDisconnected {
 			// When Bluetooth disconnects, reset ball detection state
 			lm.HandleBluetoothDisconnect()
@@ -884,6 +1141,19 @@ func (lm *LaunchMonitor) SetupNotifications(btManager *BluetoothManager) {
 			return
 		}
 		lm.syncOmniHandedness(newValue)
+		// Only a switch between players re-applies a saved aim
+		if oldValue != nil {
+			lm.applyAlignmentProfile(*newValue)
+		}
+	})
+
+	// GSPro or the user picking a club with its own spin mode re-arms
+	// detection with it
+	lm.stateManager.RegisterClubCallback(func(oldValue, newValue *ClubType) {
+		if newValue == nil {
+			return
+		}
+		lm.reapplySpinMode()
 	})
 
 	lm.stateManager.RegisterOmniSpeedUnitCallback(func(oldValue, newValue *string) {
@@ -930,6 +1200,52 @@ func (lm *LaunchMonitor) SetupNotifications(btManager *BluetoothManager) {
 	lm.startHeartbeatTask()
 }
 
+// restoreDeviceState re-sends the club and handedness after a connection.
+// If ball detection was active when the previous connection dropped it is
+// re-armed, which also sends the spin mode; otherwise only the club
+// selection is sent, and the spin mode goes with the next arm.
+func (lm *LaunchMonitor) restoreDeviceState() {
+	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
+		return
+	}
+	// Detection stays off until the device is woken or the session resumed
+	if lm.InStandby() || lm.IsPaused() {
+		return
+	}
+
+	lm.detectStateMu.Lock()
+	resume := lm.resumeDetection
+	lm.resumeDetection = false
+	lm.detectStateMu.Unlock()
+
+	if resume {
+		log.Println("LaunchMonitor: Re-arming ball detection after reconnect")
+		if err := lm.ActivateBallDetection(); err != nil {
+			log.Printf("LaunchMonitor: Failed to re-arm ball detection: %v", err)
+		}
+		return
+	}
+
+	if err := lm.sendClubSelection(); err != nil {
+		log.Printf("LaunchMonitor: Failed to restore club selection: %v", err)
+	}
+}
+
+// sendClubSelection sends the selected club and handedness without arming
+// ball detection. Nothing is sent before a club is selected.
+func (lm *LaunchMonitor) sendClubSelection() error {
+	club := lm.stateManager.GetClub()
+	if club == nil {
+		return nil
+	}
+	handedness := RightHanded
+	if h := lm.stateManager.GetHandedness(); h != nil {
+		handedness = *h
+	}
+
+	return lm.SendCommand(lm.clubCommand(lm.getNextSequence(), *club, handedness))
+}
+
 // sendOmniInitSequence sends the Omni-specific configuration commands after connection.
 // The Omni requires SetUnits, SetCarryDistanceAdjustment, SetGreenSpeed, and SetHanded
 // to be sent after connection with delays between each command.
@@ -1033,7 +1349,7 @@ func (lm *LaunchMonitor) startChargePolling() {
 	lm.chargeCancel = cancel
 
 	go func() {
-		ticker := time.NewTicker(3 * time.Second)
+		ticker := lm.clock.NewTicker(3 * time.Second)
 		defer ticker.Stop()
 
 		lm.sendChargeCommand()
@@ -1042,7 +1358,7 @@ func (lm *LaunchMonitor) startChargePolling() {
 			select {
 			case <-ctx.Done():
 				return
-			case <-ticker.C:
+			case <-ticker.C():
 				if lm.GetCapacitorReady() {
 					return
 				}
@@ -1057,7 +1373,7 @@ func (lm *LaunchMonitor) sendChargeCommand() {
 		return
 	}
 	seq := lm.getNextSequence()
-	if err := lm.SendCommand(GetChargeCommand(seq)); err != nil {
+	if err := lm.sendTrackedCommand(GetChargeCommand(seq), notificationAck("11", "06")); err != nil {
 		log.Printf("LaunchMonitor: Failed to send GetCharge: %v", err)
 	}
 }
@@ -1075,6 +1391,20 @@ func (lm *LaunchMonitor) stopChargePollingLocked() {
 	}
 }
 
+// Shutdown stops the heartbeat and charge polling and deactivates ball
+// detection so the device is left idle before it is disconnected
+func (lm *LaunchMonitor) Shutdown() {
+	lm.stopSleepSchedule()
+	lm.stopHeartbeatTask()
+	lm.stopChargePolling()
+
+	if lm.bluetoothClient != nil && lm.bluetoothClient.IsConnected() {
+		if err := lm.DeactivateBallDetection(); err != nil {
+			log.Printf("LaunchMonitor: Failed to deactivate ball detection: %v", err)
+		}
+	}
+}
+
 // HandleBluetoothDisconnect handles cleanup when Bluetooth disconnects
 func (lm *LaunchMonitor) HandleBluetoothDisconnect() {
 	log.Println("LaunchMonitor: Bluetooth disconnected - resetting ball detection state")
@@ -1083,6 +1413,9 @@ func (lm *LaunchMonitor) HandleBluetoothDisconnect() {
 	lm.stateManager.SetBallDetected(false)
 	lm.stateManager.SetBallReady(false)
 	lm.stateManager.SetBallPosition(nil)
+	lm.positionMu.Lock()
+	lm.rawBallPosition = nil
+	lm.positionMu.Unlock()
 	lm.stateManager.SetLaunchMonitorStatus(LaunchMonitorStatusNone)
 	lm.stateManager.SetDeviceType(DeviceTypeUnknown)
 	lm.stateManager.SetOmniHomeGolfStatus(nil)
@@ -1090,18 +1423,23 @@ func (lm *LaunchMonitor) HandleBluetoothDisconnect() {
 	lm.stateManager.SetOmniClubSelection(nil)
 	lm.stateManager.SetOmniSensorStatus(nil)
 	lm.detectStateMu.Lock()
+	// Remember active detection so it can be re-armed on reconnect, including
+	// detection the idle policy switched off
+	if lm.detectModeActive || lm.IsIdle() {
+		lm.resumeDetection = true
+	}
 	lm.detectModeActive = false
 	lm.omniIdleCount = 0
 	lm.detectStateMu.Unlock()
 
 	lm.setCapacitorReady(false)
+	lm.setIdle(false)
 	lm.stopChargePolling()
 
 	// Stop any heartbeat task
 	lm.stopHeartbeatTask()
 
 	lm.stopCommandQueue()
-	lm.cmdQueueOnce = sync.Once{}
 }
 
 // StartAlignment starts alignment mode
@@ -1131,14 +1469,13 @@ func (lm *LaunchMonitor) StartAlignment() error {
 	// This puts the device in alignment mode (Windows app Awake method)
 	seq := lm.getNextSequence()
 
+	// The device needs a second in alignment mode before the detect command
 	command := ClubCommand(seq, ClubAlignmentStick, handedness)
-	err := lm.SendCommand(command)
+	err := lm.sendCommand(command, alignmentClubSettle)
 	if err != nil {
 		return fmt.Errorf("failed to start alignment: %w", err)
 	}
 
-	time.Sleep(1 * time.Second)
-
 	// Activate ball detection mode 2 to turn on the red LED
 	detectSeq := lm.getNextSequence()
 	detectCmd := DetectBallCommand(detectSeq, ActivateAlignmentMode, Advanced)
@@ -1147,8 +1484,7 @@ func (lm *LaunchMonitor) StartAlignment() error {
 		return fmt.Errorf("failed to activate ball detection: %w", err)
 	}
 
-	time.Sleep(100 * time.Millisecond)
-
+	lm.resetAlignmentSmoothing()
 	lm.stateManager.SetIsAligning(true)
 	return nil
 }
@@ -1159,7 +1495,9 @@ func (lm *LaunchMonitor) StopAlignment() error {
 		return fmt.Errorf("not connected to device")
 	}
 
-	// Get current alignment angle to send as target
+	// Get current alignment angle to send as target, including a reading
+	// still waiting for the update rate
+	lm.publishAlignmentAngle()
 	currentAngle := lm.stateManager.GetAlignmentAngle()
 
 	// Send stop alignment command (confirm=1, current angle)
@@ -1169,8 +1507,10 @@ func (lm *LaunchMonitor) StopAlignment() error {
 	if err != nil {
 		return fmt.Errorf("failed to stop alignment: %w", err)
 	}
+	lm.saveAlignmentProfile(currentAngle)
 
 	// Update state
+	lm.resetAlignmentSmoothing()
 	lm.stateManager.SetIsAligning(false)
 	lm.stateManager.SetAlignmentAngle(0)
 	lm.stateManager.SetIsAligned(false)
@@ -1184,6 +1524,7 @@ func (lm *LaunchMonitor) CancelAlignment() error {
 	}
 
 	// Get current alignment angle to send with cancel
+	lm.publishAlignmentAngle()
 	currentAngle := lm.stateManager.GetAlignmentAngle()
 
 	// Send cancel alignment command (confirm=0, current angle)
@@ -1195,6 +1536,7 @@ func (lm *LaunchMonitor) CancelAlignment() error {
 	}
 
 	// Update state
+	lm.resetAlignmentSmoothing()
 	lm.stateManager.SetIsAligning(false)
 	lm.stateManager.SetAlignmentAngle(0)
 	lm.stateManager.SetIsAligned(false)
@@ -1219,7 +1561,7 @@ func (lm *LaunchMonitor) RequestFirmwareVersion() error {
 	command := GetOSVersionCommand(seq)
 	log.Printf("LaunchMonitor: Sending firmware version request command: %v", command)
 
-	err := lm.SendCommand(command)
+	err := lm.sendTrackedCommand(command, notificationAck("11", "10"))
 	if err != nil {
 		log.Printf("LaunchMonitor: Failed to send firmware version command: %v", err)
 		return fmt.Errorf("failed to request firmware version: %w", err)
@@ -2,22 +2,16 @@ package core
 
 import (
 	"bytes"
-	"sync"
+	"encoding/hex"
+	"reflect"
 	"testing"
-)
+	"time"
 
-func resetSingletonsForTest(t *testing.T) {
-	launchMonitorOnce = sync.Once{}
-	launchMonitorInstance = nil
-	bluetoothOnce = sync.Once{}
-	bluetoothInstance = nil
-	once = sync.Once{}
-	instance = nil
-}
+	"github.com/brentyates/squaregolf-connector/internal/core/protocol"
+)
 
 func newTestLaunchMonitor(t *testing.T) (*StateManager, *LaunchMonitor, *MockBluetoothClient, *BluetoothManager) {
-	resetSingletonsForTest(t)
-	sm := GetInstance()
+	sm := NewStateManager()
 	btManager := NewBluetoothManager(sm)
 	mockClient := NewMockBluetoothClient()
 	btManager.SetClient(mockClient)
@@ -26,6 +20,28 @@ func newTestLaunchMonitor(t *testing.T) (*StateManager, *LaunchMonitor, *MockBlu
 	return sm, lm, mockClient, btManager
 }
 
+// waitForWrite waits for a write to the device that matches
+func waitForWrite(t *testing.T, writes <-chan WriteHistory, matches func(WriteHistory) bool) WriteHistory {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case write := <-writes:
+			if matches(write) {
+				return write
+			}
+		case <-deadline:
+			t.Fatal("Expected a write to the device")
+			return WriteHistory{}
+		}
+	}
+}
+
+// isHeartbeat reports whether a write is a heartbeat command
+func isHeartbeat(write WriteHistory) bool {
+	return write.UUID == CommandCharUUID && len(write.Data) > 1 && write.Data[1] == 0x83
+}
+
 func TestNewLaunchMonitor(t *testing.T) {
 	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
 
@@ -633,6 +649,20 @@ func TestNotificationHandler_InvalidSensorData(t *testing.T) {
 	}
 }
 
+func TestNotificationHandler_OversizedNotification(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+
+	// A valid sensor update padded past the longest BLE attribute value
+	data := []byte{0x11, 0x01, 0x00, 0x01, 0x01, 0x0a, 0, 0, 0, 0x14, 0, 0, 0, 0x1e, 0, 0, 0}
+	data = append(data, make([]byte, protocol.MaxMessageLength)...)
+
+	lm.NotificationHandler("", data)
+
+	if sm.GetBallDetected() || sm.GetBallPosition() != nil {
+		t.Error("Expected an oversized notification to be ignored")
+	}
+}
+
 func TestNotificationHandler_InvalidBallMetrics(t *testing.T) {
 	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
 	mockClient.connected = true
@@ -1115,6 +1145,7 @@ func TestSetupNotifications_OmniHandednessChangeSendsCommand(t *testing.T) {
 
 	handedness := LeftHanded
 	sm.SetHandedness(&handedness)
+	sm.Flush()
 
 	writeHistory := mockClient.GetWriteHistory()
 	if len(writeHistory) != 1 {
@@ -1226,6 +1257,7 @@ func TestSetupNotifications_OmniSettingChangesSendCommands(t *testing.T) {
 
 	speedUnit := "mph"
 	sm.SetOmniSpeedUnit(&speedUnit)
+	sm.Flush()
 	writeHistory := mockClient.GetWriteHistory()
 	if len(writeHistory) != 1 {
 		t.Fatalf("Expected 1 Omni units write after speed unit change, got %d", len(writeHistory))
@@ -1237,6 +1269,7 @@ func TestSetupNotifications_OmniSettingChangesSendCommands(t *testing.T) {
 	mockClient.ClearWriteHistory()
 	distanceUnit := "yards"
 	sm.SetOmniDistanceUnit(&distanceUnit)
+	sm.Flush()
 	writeHistory = mockClient.GetWriteHistory()
 	if len(writeHistory) != 1 {
 		t.Fatalf("Expected 1 Omni units write after distance unit change, got %d", len(writeHistory))
@@ -1248,6 +1281,7 @@ func TestSetupNotifications_OmniSettingChangesSendCommands(t *testing.T) {
 	mockClient.ClearWriteHistory()
 	greenSpeed := 12
 	sm.SetOmniGreenSpeed(&greenSpeed)
+	sm.Flush()
 	writeHistory = mockClient.GetWriteHistory()
 	if len(writeHistory) != 1 {
 		t.Fatalf("Expected 1 Omni green speed write, got %d", len(writeHistory))
@@ -1259,6 +1293,7 @@ func TestSetupNotifications_OmniSettingChangesSendCommands(t *testing.T) {
 	mockClient.ClearWriteHistory()
 	carryAdjustment := -5
 	sm.SetOmniCarryAdjustment(&carryAdjustment)
+	sm.Flush()
 	writeHistory = mockClient.GetWriteHistory()
 	if len(writeHistory) != 1 {
 		t.Fatalf("Expected 1 Omni carry adjustment write, got %d", len(writeHistory))
@@ -1352,3 +1387,177 @@ func TestHeartbeatCommand_ExactBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreDeviceState_RearmsDetectionAfterDrop(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	club := ClubIron7
+	sm.SetClub(&club)
+	if err := lm.ActivateBallDetection(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Connection drops and comes back
+	lm.HandleBluetoothDisconnect()
+	lm.HandleBluetoothDisconnect()
+	mockClient.ClearWriteHistory()
+	lm.restoreDeviceState()
+
+	writeHistory := mockClient.GetWriteHistory()
+	if len(writeHistory) != 2 {
+		t.Fatalf("Expected club and detect commands, got %d writes", len(writeHistory))
+	}
+	if !lm.detectModeActive {
+		t.Error("Expected ball detection to be active after restore")
+	}
+
+	// A second reconnect without an active session only sends the club
+	lm.DeactivateBallDetection()
+	lm.HandleBluetoothDisconnect()
+	mockClient.ClearWriteHistory()
+	lm.restoreDeviceState()
+
+	if writeHistory := mockClient.GetWriteHistory(); len(writeHistory) != 1 {
+		t.Errorf("Expected only the club command, got %d writes", len(writeHistory))
+	}
+}
+
+func TestRestoreDeviceState_ResendsTheSelection(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	club := ClubIron7
+	handedness := LeftHanded
+	spinMode := Standard
+	sm.SetClub(&club)
+	sm.SetHandedness(&handedness)
+	sm.SetSpinMode(&spinMode)
+
+	written := func() []string {
+		var commands []string
+		for _, write := range mockClient.GetWriteHistory() {
+			commands = append(commands, hex.EncodeToString(write.Data))
+		}
+		return commands
+	}
+	restore := func(sequence int) []string {
+		mockClient.ClearWriteHistory()
+		lm.sequenceMutex.Lock()
+		lm.sequence = sequence
+		lm.sequenceMutex.Unlock()
+		lm.restoreDeviceState()
+		return written()
+	}
+
+	// Armed when the connection dropped: the club, then detection with the
+	// spin mode
+	if err := lm.ActivateBallDetection(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	lm.HandleBluetoothDisconnect()
+	want := []string{ClubCommand(0x20, club, handedness), DetectBallCommand(0x21, Activate, spinMode)}
+	if got := restore(0x20); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v re-sent after a drop while armed, got %v", want, got)
+	}
+
+	// Not armed: only the club and handedness
+	lm.DeactivateBallDetection()
+	lm.HandleBluetoothDisconnect()
+	want = []string{ClubCommand(0x30, club, handedness)}
+	if got := restore(0x30); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v re-sent after a drop while idle, got %v", want, got)
+	}
+
+	// Nothing selected: nothing to restore
+	sm.SetClub(nil)
+	if got := restore(0x40); len(got) != 0 {
+		t.Errorf("Expected nothing re-sent without a club, got %v", got)
+	}
+}
+
+func TestHeartbeatTask_UsesClock(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	clock := NewFakeClock(time.Unix(0, 0))
+	lm.SetClock(clock)
+	writes := mockClient.NotifyWrites()
+
+	lm.startHeartbeatTask()
+	defer lm.stopHeartbeatTask()
+	clock.BlockUntil(1)
+
+	// The task is still waiting on the clock, so nothing was written
+	clock.Advance(4 * time.Second)
+	clock.BlockUntil(1)
+	select {
+	case write := <-writes:
+		t.Fatalf("Expected no heartbeat before the interval, got %x", write.Data)
+	default:
+	}
+
+	clock.Advance(time.Second)
+	write := waitForWrite(t, writes, func(WriteHistory) bool { return true })
+	if !isHeartbeat(write) {
+		t.Errorf("Expected heartbeat command, got %x", write.Data)
+	}
+}
+
+func TestLaunchMonitorInstancesAreIndependent(t *testing.T) {
+	smA, lmA, clientA, _ := newTestLaunchMonitor(t)
+	smB, _, clientB, _ := newTestLaunchMonitor(t)
+	clientA.connected = true
+	clientB.connected = true
+
+	if smA == smB {
+		t.Fatal("Expected separate state managers")
+	}
+
+	lmA.NotificationHandler(BatteryLevelCharUUID, []byte{42})
+	if level := smA.GetBatteryLevel(); level == nil || *level != 42 {
+		t.Errorf("Expected battery level 42 on the first instance, got %v", level)
+	}
+	if level := smB.GetBatteryLevel(); level != nil {
+		t.Errorf("Expected the second instance to be unaffected, got %v", *level)
+	}
+	if len(clientB.GetWriteHistory()) != 0 {
+		t.Error("Expected no writes on the second client")
+	}
+}
+
+func TestSendCommand_RetriesTransientWriteFailure(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	mockClient.failWrites = 1
+
+	if err := lm.SendCommand("1183000000000000"); err != nil {
+		t.Fatalf("Expected the retried write to succeed, got %v", err)
+	}
+	if writes := len(mockClient.GetWriteHistory()); writes != 2 {
+		t.Errorf("Expected 2 write attempts, got %d", writes)
+	}
+}
+
+func TestSendCommand_GivesUpAfterRetries(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	mockClient.failWrites = commandRetries + 1
+
+	if err := lm.SendCommand("1183000000000000"); err == nil {
+		t.Fatal("Expected an error once retries are exhausted")
+	}
+	if writes := len(mockClient.GetWriteHistory()); writes != commandRetries+1 {
+		t.Errorf("Expected %d write attempts, got %d", commandRetries+1, writes)
+	}
+}
+
+func TestSendCommand_DoesNotRetryWhenDisconnected(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+
+	if err := lm.SendCommand("1183000000000000"); err == nil {
+		t.Fatal("Expected an error while disconnected")
+	}
+	if writes := len(mockClient.GetWriteHistory()); writes != 0 {
+		t.Errorf("Expected no write attempts while disconnected, got %d", writes)
+	}
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"sync"
+)
+
+// Calibration points sampled by the wizard
+const (
+	CalibrationPointOrigin = "origin"
+	CalibrationPointTarget = "target"
+)
+
+// minCalibrationDistance is the smallest origin-to-target distance, in device
+// units, that gives a usable rotation
+const minCalibrationDistance = 10
+
+// MatCalibration maps raw device coordinates onto the user's hitting area.
+// The offset is the raw position of the mat origin and the rotation turns the
+// target line onto the +Y axis.
+type MatCalibration struct {
+	OffsetX     float64 `json:"offsetX"`
+	OffsetY     float64 `json:"offsetY"`
+	OffsetZ     float64 `json:"offsetZ"`
+	RotationDeg float64 `json:"rotationDeg"`
+}
+
+// Apply converts a raw device position into mat coordinates
+func (c MatCalibration) Apply(raw BallPosition) BallPosition {
+	dx := float64(raw.X) - c.OffsetX
+	dy := float64(raw.Y) - c.OffsetY
+	theta := c.RotationDeg * math.Pi / 180
+
+	return BallPosition{
+		X: int32(math.Round(dx*math.Cos(theta) - dy*math.Sin(theta))),
+		Y: int32(math.Round(dx*math.Sin(theta) + dy*math.Cos(theta))),
+		Z: int32(math.Round(float64(raw.Z) - c.OffsetZ)),
+	}
+}
+
+// MatCalibrationWizard collects raw ball positions at the mat origin and at a
+// point further along the target line, then derives a MatCalibration.
+type MatCalibrationWizard struct {
+	mu      sync.Mutex
+	samples map[string][]BallPosition
+}
+
+// NewMatCalibrationWizard creates an empty calibration wizard
+func NewMatCalibrationWizard() *MatCalibrationWizard {
+	return &MatCalibrationWizard{samples: make(map[string][]BallPosition)}
+}
+
+// AddSample records a raw position for a calibration point
+func (w *MatCalibrationWizard) AddSample(point string, raw BallPosition) error {
+	if point != CalibrationPointOrigin && point != CalibrationPointTarget {
+		return errors.New("unknown calibration point")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.samples[point] = append(w.samples[point], raw)
+	return nil
+}
+
+// SampleCounts returns how many samples were taken for each point
+func (w *MatCalibrationWizard) SampleCounts() map[string]int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return map[string]int{
+		CalibrationPointOrigin: len(w.samples[CalibrationPointOrigin]),
+		CalibrationPointTarget: len(w.samples[CalibrationPointTarget]),
+	}
+}
+
+// Reset discards all samples
+func (w *MatCalibrationWizard) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.samples = make(map[string][]BallPosition)
+}
+
+// Compute averages the samples for each point and returns the calibration
+func (w *MatCalibrationWizard) Compute() (MatCalibration, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	origins := w.samples[CalibrationPointOrigin]
+	targets := w.samples[CalibrationPointTarget]
+	if len(origins) == 0 || len(targets) == 0 {
+		return MatCalibration{}, errors.New("calibration needs origin and target samples")
+	}
+
+	ox, oy, oz := averagePosition(origins)
+	tx, ty, _ := averagePosition(targets)
+	dx, dy := tx-ox, ty-oy
+	if math.Hypot(dx, dy) < minCalibrationDistance {
+		return MatCalibration{}, errors.New("target samples are too close to the origin")
+	}
+
+	return MatCalibration{
+		OffsetX:     ox,
+		OffsetY:     oy,
+		OffsetZ:     oz,
+		RotationDeg: math.Atan2(dx, dy) * 180 / math.Pi,
+	}, nil
+}
+
+func averagePosition(positions []BallPosition) (x, y, z float64) {
+	for _, p := range positions {
+		x += float64(p.X)
+		y += float64(p.Y)
+		z += float64(p.Z)
+	}
+	n := float64(len(positions))
+	return x / n, y / n, z / n
+}
+
+// SetMatCalibration sets the calibration applied to reported ball positions
+func (lm *LaunchMonitor) SetMatCalibration(calibration MatCalibration) {
+	lm.positionMu.Lock()
+	defer lm.positionMu.Unlock()
+	lm.matCalibration = calibration
+}
+
+// GetMatCalibration returns the calibration applied to reported ball positions
+func (lm *LaunchMonitor) GetMatCalibration() MatCalibration {
+	lm.positionMu.Lock()
+	defer lm.positionMu.Unlock()
+	return lm.matCalibration
+}
+
+// RawBallPosition returns the last uncalibrated position from the device
+func (lm *LaunchMonitor) RawBallPosition() *BallPosition {
+	lm.positionMu.Lock()
+	defer lm.positionMu.Unlock()
+	if lm.rawBallPosition == nil {
+		return nil
+	}
+	position := *lm.rawBallPosition
+	return &position
+}
+
+// calibratePosition records a raw position and returns it in mat coordinates
+func (lm *LaunchMonitor) calibratePosition(raw BallPosition) *BallPosition {
+	lm.positionMu.Lock()
+	defer lm.positionMu.Unlock()
+	lm.rawBallPosition = &raw
+	position := lm.matCalibration.Apply(raw)
+	return &position
+}
@@ -0,0 +1,86 @@
+package core
+
+import "testing"
+
+func TestMatCalibration_Apply(t *testing.T) {
+	identity := MatCalibration{}
+	if got := identity.Apply(BallPosition{X: 10, Y: 20, Z: 30}); got != (BallPosition{X: 10, Y: 20, Z: 30}) {
+		t.Errorf("Expected zero calibration to leave position unchanged, got %+v", got)
+	}
+
+	// Target line runs along +X in device coordinates
+	calibration := MatCalibration{OffsetX: 100, OffsetY: 50, OffsetZ: 5, RotationDeg: 90}
+	got := calibration.Apply(BallPosition{X: 140, Y: 50, Z: 5})
+	if got != (BallPosition{X: 0, Y: 40, Z: 0}) {
+		t.Errorf("Expected (0,40,0), got %+v", got)
+	}
+
+	got = calibration.Apply(BallPosition{X: 100, Y: 30, Z: 5})
+	if got != (BallPosition{X: 20, Y: 0, Z: 0}) {
+		t.Errorf("Expected (20,0,0), got %+v", got)
+	}
+}
+
+func TestMatCalibrationWizard(t *testing.T) {
+	wizard := NewMatCalibrationWizard()
+
+	if _, err := wizard.Compute(); err == nil {
+		t.Error("Expected error without samples")
+	}
+	if err := wizard.AddSample("middle", BallPosition{}); err == nil {
+		t.Error("Expected error for unknown calibration point")
+	}
+
+	wizard.AddSample(CalibrationPointOrigin, BallPosition{X: 98, Y: 50})
+	wizard.AddSample(CalibrationPointOrigin, BallPosition{X: 102, Y: 50})
+	wizard.AddSample(CalibrationPointTarget, BallPosition{X: 100, Y: 52})
+	if _, err := wizard.Compute(); err == nil {
+		t.Error("Expected error when target is too close to origin")
+	}
+
+	wizard.AddSample(CalibrationPointTarget, BallPosition{X: 200, Y: 48})
+	counts := wizard.SampleCounts()
+	if counts[CalibrationPointOrigin] != 2 || counts[CalibrationPointTarget] != 2 {
+		t.Errorf("Unexpected sample counts: %v", counts)
+	}
+
+	calibration, err := wizard.Compute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calibration.OffsetX != 100 || calibration.OffsetY != 50 || calibration.RotationDeg != 90 {
+		t.Errorf("Expected offset (100,50) rotated 90 degrees, got %+v", calibration)
+	}
+
+	wizard.Reset()
+	if wizard.SampleCounts()[CalibrationPointOrigin] != 0 {
+		t.Error("Expected reset to clear samples")
+	}
+}
+
+func TestNotificationHandler_SensorDataCalibrated(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	lm.SetMatCalibration(MatCalibration{OffsetX: 10, OffsetY: 10})
+
+	sensorData := []byte{
+		0x11, 0x01,
+		0x00,
+		0x01,
+		0x01,
+		0x0A, 0x00, 0x00, 0x00, // Position X (10)
+		0x14, 0x00, 0x00, 0x00, // Position Y (20)
+		0x1E, 0x00, 0x00, 0x00, // Position Z (30)
+		0x00, 0x00, 0x00, 0x00,
+	}
+	lm.NotificationHandler(NotificationCharUUID, sensorData)
+
+	pos := sm.GetBallPosition()
+	if pos == nil || pos.X != 0 || pos.Y != 10 || pos.Z != 30 {
+		t.Errorf("Expected calibrated position (0,10,30), got %+v", pos)
+	}
+	raw := lm.RawBallPosition()
+	if raw == nil || raw.X != 10 || raw.Y != 20 {
+		t.Errorf("Expected raw position (10,20), got %+v", raw)
+	}
+}
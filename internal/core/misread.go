@@ -0,0 +1,194 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// MisreadAction is how the user resolves a misread shot
+type MisreadAction string
+
+const (
+	MisreadActionDiscard       MisreadAction = "discard"
+	MisreadActionSendAsIs      MisreadAction = "send"
+	MisreadActionSendEstimated MisreadAction = "estimate"
+)
+
+// maxMisreadShots bounds the shots held at once. Once full, the oldest is
+// dropped to make room.
+const maxMisreadShots = 20
+
+// ErrMisreadNotFound is returned when resolving a shot that is no longer held
+var ErrMisreadNotFound = errors.New("misread shot not found")
+
+// MisreadShot is a shot held back from simulators until the user decides what to do with it
+type MisreadShot struct {
+	ID          int          `json:"id"`
+	Reason      string       `json:"reason"`
+	Timestamp   time.Time    `json:"timestamp"`
+	BallMetrics *BallMetrics `json:"ballMetrics"`
+	ClubMetrics *ClubMetrics `json:"clubMetrics"`
+}
+
+// DetectMisread returns why a parsed shot looks like a misread, or an empty
+// string if the shot is usable.
+func DetectMisread(ballMetrics *BallMetrics) string {
+	if ballMetrics == nil {
+		return ""
+	}
+	if !ballMetrics.IsBallSpeedValid || ballMetrics.BallSpeedMPS <= 0 {
+		return "invalid ball speed"
+	}
+	if ballMetrics.ShotType == ShotTypePutt {
+		return ""
+	}
+	if !ballMetrics.IsTotalSpinValid || ballMetrics.TotalspinRPM == 0 {
+		return "missing spin"
+	}
+	return ""
+}
+
+// holdIfMisread queues a shot that fails validation when misread prompts are
+// enabled. duplicate is true when the same packet was already held.
+func (lm *LaunchMonitor) holdIfMisread(ballMetrics *BallMetrics, rawData string) (held bool, duplicate bool) {
+	reason := ""
+	if lm.stateManager.GetMisreadPrompt() {
+		reason = DetectMisread(ballMetrics)
+	}
+	if reason == "" {
+		// Club metrics that follow now belong to this shot
+		lm.misreadMu.Lock()
+		lm.awaitingClubMisreadID = 0
+		lm.misreadMu.Unlock()
+		return false, false
+	}
+
+	lm.misreadMu.Lock()
+	if rawData != "" && rawData == lm.lastMisreadRaw {
+		lm.misreadMu.Unlock()
+		return true, true
+	}
+	lm.lastMisreadRaw = rawData
+	lm.nextMisreadID++
+	shot := MisreadShot{
+		ID:          lm.nextMisreadID,
+		Reason:      reason,
+		Timestamp:   lm.clock.Now(),
+		BallMetrics: ballMetrics,
+	}
+	lm.awaitingClubMisreadID = shot.ID
+	lm.misreadMu.Unlock()
+
+	log.Printf("LaunchMonitor: Holding misread shot %d (%s)", shot.ID, reason)
+	lm.stateManager.UpdateMisreadShots(func(shots []MisreadShot) []MisreadShot {
+		shots = append(shots, shot)
+		if extra := len(shots) - maxMisreadShots; extra > 0 {
+			log.Printf("LaunchMonitor: Too many misread shots held, dropping %d", shots[0].ID)
+			shots = shots[extra:]
+		}
+		return shots
+	})
+	return true, false
+}
+
+// attachMisreadClubMetrics routes club metrics that belong to a held shot.
+// It returns true when the metrics were consumed and must not be published.
+func (lm *LaunchMonitor) attachMisreadClubMetrics(clubMetrics *ClubMetrics) bool {
+	lm.misreadMu.Lock()
+	id := lm.awaitingClubMisreadID
+	lm.awaitingClubMisreadID = 0
+	discarded := id != 0 && id == lm.lastDiscardedMisreadID
+	lm.misreadMu.Unlock()
+
+	if id == 0 {
+		return false
+	}
+	if discarded {
+		return true
+	}
+
+	attached := false
+	lm.stateManager.UpdateMisreadShots(func(shots []MisreadShot) []MisreadShot {
+		for i := range shots {
+			if shots[i].ID == id {
+				shots[i].ClubMetrics = clubMetrics
+				attached = true
+			}
+		}
+		return shots
+	})
+
+	// If the shot was already sent, its club data follows normally
+	return attached
+}
+
+// publishClubMetrics publishes club metrics unless they belong to a held shot
+// or to a shot that lost arbitration
+func (lm *LaunchMonitor) publishClubMetrics(clubMetrics *ClubMetrics) {
+	if lm.deviceShotDropped() {
+		return
+	}
+	if lm.attachMisreadClubMetrics(clubMetrics) {
+		return
+	}
+	ballMetrics := lm.stateManager.GetLastBallMetrics()
+	lm.applyClubSpeedEstimation(clubMetrics, ballMetrics)
+	lm.deriveClubMetrics(clubMetrics, ballMetrics)
+	lm.stateManager.SetLastClubMetrics(clubMetrics)
+}
+
+// ResolveMisread discards or releases a held shot
+func (lm *LaunchMonitor) ResolveMisread(id int, action MisreadAction) error {
+	switch action {
+	case MisreadActionDiscard, MisreadActionSendAsIs, MisreadActionSendEstimated:
+	default:
+		return fmt.Errorf("unknown misread action: %s", action)
+	}
+
+	// Taking the shot off the queue and removing it are one step, so a shot
+	// resolved twice at once is only released once
+	var shot *MisreadShot
+	lm.stateManager.UpdateMisreadShots(func(shots []MisreadShot) []MisreadShot {
+		for i := range shots {
+			if shots[i].ID != id {
+				continue
+			}
+			found := shots[i]
+			shot = &found
+			if action == MisreadActionDiscard {
+				// Before it leaves the queue, so late club metrics are dropped
+				lm.misreadMu.Lock()
+				lm.lastDiscardedMisreadID = id
+				lm.misreadMu.Unlock()
+			}
+			return append(shots[:i], shots[i+1:]...)
+		}
+		return shots
+	})
+	if shot == nil {
+		return fmt.Errorf("%w: %d", ErrMisreadNotFound, id)
+	}
+
+	if action == MisreadActionDiscard {
+		log.Printf("LaunchMonitor: Discarded misread shot %d", id)
+		return nil
+	}
+
+	ballMetrics := *shot.BallMetrics
+	if action == MisreadActionSendEstimated {
+		lm.estimateSpin(&ballMetrics)
+	}
+
+	log.Printf("LaunchMonitor: Releasing misread shot %d (%s)", id, action)
+	lm.stateManager.SetLastBallMetrics(&ballMetrics)
+	lm.latency.MarkStateUpdated()
+	if shot.ClubMetrics != nil {
+		clubMetrics := *shot.ClubMetrics
+		lm.applyClubSpeedEstimation(&clubMetrics, &ballMetrics)
+		lm.deriveClubMetrics(&clubMetrics, &ballMetrics)
+		lm.stateManager.SetLastClubMetrics(&clubMetrics)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// noSpinShot is a shot packet with a 60 m/s ball speed and no spin
+var noSpinShot = []byte{
+	0x11, 0x02, 0x37,
+	0x70, 0x17, // Ball speed (6000 = 60 m/s)
+	0xE8, 0x03, // Vertical angle (1000 = 10 degrees)
+	0x00, 0x00,
+	0x00, 0x00, // Total spin (0 rpm)
+	0x00, 0x00,
+	0x00, 0x00,
+	0x00, 0x00,
+}
+
+func TestDetectMisread(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics *BallMetrics
+		want    string
+	}{
+		{"nil", nil, ""},
+		{"valid", &BallMetrics{BallSpeedMPS: 60, IsBallSpeedValid: true, TotalspinRPM: 3000, IsTotalSpinValid: true}, ""},
+		{"invalid speed", &BallMetrics{BallSpeedMPS: 60, TotalspinRPM: 3000, IsTotalSpinValid: true}, "invalid ball speed"},
+		{"missing spin", &BallMetrics{BallSpeedMPS: 60, IsBallSpeedValid: true}, "missing spin"},
+		{"putt without spin", &BallMetrics{BallSpeedMPS: 3, IsBallSpeedValid: true, ShotType: ShotTypePutt}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := DetectMisread(tt.metrics); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestMisreadShotIsHeldWhenPromptEnabled(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	sm.SetMisreadPrompt(true)
+
+	lm.NotificationHandler("", noSpinShot)
+
+	if sm.GetLastBallMetrics() != nil {
+		t.Error("Expected misread shot not to be published")
+	}
+	shots := sm.GetMisreadShots()
+	if len(shots) != 1 || shots[0].Reason != "missing spin" {
+		t.Fatalf("Expected one held shot with missing spin, got %+v", shots)
+	}
+
+	// A repeated notification for the same shot must not queue it twice
+	lm.NotificationHandler("", noSpinShot)
+	if len(sm.GetMisreadShots()) != 1 {
+		t.Errorf("Expected duplicate packet to be ignored, got %d shots", len(sm.GetMisreadShots()))
+	}
+
+	// Club metrics for the held shot are attached rather than published
+	lm.publishClubMetrics(&ClubMetrics{PathAngle: 1.5})
+	if sm.GetLastClubMetrics() != nil {
+		t.Error("Expected club metrics for a held shot not to be published")
+	}
+	if sm.GetMisreadShots()[0].ClubMetrics == nil {
+		t.Error("Expected club metrics to be attached to the held shot")
+	}
+}
+
+func TestMisreadShotIsPublishedWhenPromptDisabled(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	lm.NotificationHandler("", noSpinShot)
+
+	if sm.GetLastBallMetrics() == nil {
+		t.Error("Expected shot to be published when misread prompts are disabled")
+	}
+	if len(sm.GetMisreadShots()) != 0 {
+		t.Error("Expected no held shots")
+	}
+}
+
+func TestResolveMisread(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	sm.SetMisreadPrompt(true)
+
+	lm.NotificationHandler("", noSpinShot)
+	id := sm.GetMisreadShots()[0].ID
+
+	if err := lm.ResolveMisread(id, "bogus"); err == nil {
+		t.Error("Expected error for unknown action")
+	}
+	if err := lm.ResolveMisread(id+1, MisreadActionDiscard); !errors.Is(err, ErrMisreadNotFound) {
+		t.Errorf("Expected ErrMisreadNotFound, got %v", err)
+	}
+
+	if err := lm.ResolveMisread(id, MisreadActionSendEstimated); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sm.GetMisreadShots()) != 0 {
+		t.Error("Expected held shot to be removed")
+	}
+	metrics := sm.GetLastBallMetrics()
+	if metrics == nil || !metrics.SpinEstimated || !metrics.IsTotalSpinValid || metrics.TotalspinRPM == 0 {
+		t.Errorf("Expected shot with estimated spin, got %+v", metrics)
+	}
+}
+
+func TestResolveMisreadDiscardDropsLateClubMetrics(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	sm.SetMisreadPrompt(true)
+
+	lm.NotificationHandler("", noSpinShot)
+	id := sm.GetMisreadShots()[0].ID
+
+	if err := lm.ResolveMisread(id, MisreadActionDiscard); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lm.publishClubMetrics(&ClubMetrics{PathAngle: 1.5})
+
+	if sm.GetLastBallMetrics() != nil || sm.GetLastClubMetrics() != nil {
+		t.Error("Expected discarded shot and its club metrics not to be published")
+	}
+}
+
+func TestResolveMisreadConcurrentlyReleasesOnce(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	sm.SetMisreadPrompt(true)
+
+	lm.NotificationHandler("", noSpinShot)
+	id := sm.GetMisreadShots()[0].ID
+
+	released := 0
+	var mu sync.Mutex
+	sm.RegisterLastBallMetricsCallback(func(_, _ *BallMetrics) {
+		mu.Lock()
+		released++
+		mu.Unlock()
+	})
+
+	const resolvers = 8
+	errs := make(chan error, resolvers)
+	var wg sync.WaitGroup
+	for i := 0; i < resolvers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- lm.ResolveMisread(id, MisreadActionSendAsIs)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, ErrMisreadNotFound):
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("Expected the shot to be resolved once, got %d", succeeded)
+	}
+	sm.bus.Flush()
+	mu.Lock()
+	defer mu.Unlock()
+	if released != 1 {
+		t.Errorf("Expected the shot to be released once, got %d", released)
+	}
+}
+
+func TestMisreadQueueIsBounded(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	sm.SetMisreadPrompt(true)
+
+	for i := 0; i < maxMisreadShots+3; i++ {
+		packet := append([]byte(nil), noSpinShot...)
+		packet[3] = byte(i) // a different packet each time, not a repeat
+		lm.NotificationHandler("", packet)
+	}
+
+	shots := sm.GetMisreadShots()
+	if len(shots) != maxMisreadShots {
+		t.Fatalf("Expected %d held shots, got %d", maxMisreadShots, len(shots))
+	}
+	if shots[0].ID != 4 {
+		t.Errorf("Expected the oldest shots to be dropped, first held is %d", shots[0].ID)
+	}
+}
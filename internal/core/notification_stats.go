@@ -0,0 +1,130 @@
+package core
+
+import (
+	"encoding/hex"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core/protocol"
+)
+
+// unknownHeaderLogInterval is how often a sample of each unknown header is
+// logged, so a new firmware message is noticed without flooding the log
+const unknownHeaderLogInterval = time.Minute
+
+// batteryHeader labels battery messages on the notification characteristic,
+// whose second byte is not part of the type
+const batteryHeader = "91"
+
+// Outcomes of a notification frame
+const (
+	frameParsed  = "parsed"
+	frameShort   = "short"
+	frameUnknown = "unknown"
+)
+
+// HeaderCount counts the notification frames received with one header
+type HeaderCount struct {
+	Header     string    `json:"header"`         // "11 02"
+	Name       string    `json:"name,omitempty"` // empty for unknown headers
+	Known      bool      `json:"known"`
+	Parsed     int64     `json:"parsed"`
+	Unparsed   int64     `json:"unparsed"`
+	LastSample string    `json:"lastSample,omitempty"` // hex of the last unparsed frame
+	LastSeen   time.Time `json:"lastSeen"`
+}
+
+// NotificationStats summarises the frames received on the notification
+// characteristic since the connector started
+type NotificationStats struct {
+	Total    int64         `json:"total"`
+	Parsed   int64         `json:"parsed"`
+	Unparsed int64         `json:"unparsed"`
+	Short    int64         `json:"short"`   // known header, too few bytes
+	Unknown  int64         `json:"unknown"` // header not in the protocol registry
+	Headers  []HeaderCount `json:"headers"`
+}
+
+// NotificationCounter counts notification frames per header, split into
+// those handed to a handler and those dropped, and logs a rate-limited
+// sample of each header the protocol registry doesn't know
+type NotificationCounter struct {
+	clock   Clock
+	mu      sync.Mutex
+	stats   NotificationStats
+	headers map[string]*HeaderCount
+	logs    map[string]*RateLimiter
+}
+
+// NewNotificationCounter creates a counter that reads time from clock
+func NewNotificationCounter(clock Clock) *NotificationCounter {
+	return &NotificationCounter{
+		clock:   clock,
+		headers: make(map[string]*HeaderCount),
+		logs:    make(map[string]*RateLimiter),
+	}
+}
+
+// record counts one frame. name is empty for an unknown header.
+func (c *NotificationCounter) record(header, name, outcome string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count, ok := c.headers[header]
+	if !ok {
+		count = &HeaderCount{Header: header, Name: name, Known: outcome != frameUnknown}
+		c.headers[header] = count
+	}
+	count.LastSeen = c.clock.Now()
+	c.stats.Total++
+
+	if outcome == frameParsed {
+		count.Parsed++
+		c.stats.Parsed++
+		return
+	}
+	count.Unparsed++
+	count.LastSample = hex.EncodeToString(data)
+	c.stats.Unparsed++
+	if outcome == frameShort {
+		c.stats.Short++
+		return
+	}
+
+	c.stats.Unknown++
+	limiter, ok := c.logs[header]
+	if !ok {
+		limiter = NewRateLimiter(c.clock, unknownHeaderLogInterval)
+		c.logs[header] = limiter
+	}
+	if limiter.Allow() {
+		log.Printf("LaunchMonitor: Unknown notification header %s (%d seen): %s", header, count.Unparsed, count.LastSample)
+	}
+}
+
+// Stats returns the counts so far, with headers in order
+func (c *NotificationCounter) Stats() NotificationStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Headers = make([]HeaderCount, 0, len(c.headers))
+	for _, count := range c.headers {
+		stats.Headers = append(stats.Headers, *count)
+	}
+	sort.Slice(stats.Headers, func(i, j int) bool {
+		return stats.Headers[i].Header < stats.Headers[j].Header
+	})
+	return stats
+}
+
+// frameHeader labels a frame by its header, or by its bytes when it is too
+// short to have one
+func frameHeader(data []byte) string {
+	if header, ok := protocol.HeaderOf(data); ok {
+		return header.String()
+	}
+	return hex.EncodeToString(data)
+}
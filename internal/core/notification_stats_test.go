@@ -0,0 +1,56 @@
+package core
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotificationCounter_CountsByHeader(t *testing.T) {
+	_, lm, _, _ := newTestLaunchMonitor(t)
+	clock := NewFakeClock(time.Unix(0, 0))
+	lm.SetClock(clock)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	sensor := make([]byte, 17)
+	sensor[0], sensor[1] = 0x11, 0x01
+	lm.NotificationHandler("", sensor)
+	lm.NotificationHandler("", sensor[:5])
+	lm.NotificationHandler("", []byte{0x91, 0x00, 0x50})
+	unknown := []byte{0x11, 0x20, 0x01, 0x02}
+	lm.NotificationHandler("", unknown)
+	lm.NotificationHandler("", unknown)
+
+	stats := lm.Notifications().Stats()
+	if stats.Total != 5 || stats.Parsed != 2 || stats.Unparsed != 3 || stats.Short != 1 || stats.Unknown != 2 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	counts := make(map[string]HeaderCount)
+	for _, count := range stats.Headers {
+		counts[count.Header] = count
+	}
+	if c := counts["11 01"]; c.Name != "sensor" || !c.Known || c.Parsed != 1 || c.Unparsed != 1 || c.LastSample != "1101000000" {
+		t.Errorf("Unexpected sensor count: %+v", c)
+	}
+	if c := counts[batteryHeader]; c.Parsed != 1 {
+		t.Errorf("Expected the battery message to be counted as parsed, got %+v", c)
+	}
+	if c := counts["11 20"]; c.Known || c.Unparsed != 2 || c.LastSample != "11200102" {
+		t.Errorf("Unexpected unknown header count: %+v", c)
+	}
+
+	if n := strings.Count(logs.String(), "Unknown notification header 11 20"); n != 1 {
+		t.Errorf("Expected one sample of the unknown header to be logged, got %d", n)
+	}
+	clock.Advance(unknownHeaderLogInterval)
+	lm.NotificationHandler("", unknown)
+	if n := strings.Count(logs.String(), "Unknown notification header 11 20"); n != 2 {
+		t.Errorf("Expected another sample once the interval passed, got %d", n)
+	}
+}
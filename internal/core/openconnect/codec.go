@@ -0,0 +1,169 @@
+package openconnect
+
+import (
+	"log"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+const mpsToMPH = 2.23694
+
+// ReadyMessage reports whether the launch monitor is ready, with no shot
+// data. header carries the sender's identity and shot number.
+func ReadyMessage(header ShotData, ready bool) ShotData {
+	header.ShotDataOptions = ShotOptions{
+		LaunchMonitorIsReady:      ready,
+		LaunchMonitorBallDetected: ready,
+	}
+	return header
+}
+
+// BallMessage carries a shot's ball data, with its signs mapped onto the
+// host's spin convention
+func BallMessage(header ShotData, ball core.BallMetrics, convention core.SpinConvention) ShotData {
+	header.ShotDataOptions = ShotOptions{ContainsBallData: true}
+	header.BallData = NewBallData(ball, convention)
+	return header
+}
+
+// ClubMessage carries a shot's club data. A nil club is sent as zeros, to
+// clear the club data of the last shot.
+func ClubMessage(header ShotData, club *core.ClubMetrics) ShotData {
+	header.ShotDataOptions = ShotOptions{ContainsClubData: true}
+	header.ClubData = &ClubData{}
+	if club != nil {
+		header.ClubData = NewClubData(*club)
+	}
+	return header
+}
+
+// NewBallData converts the device's ball metrics to Open Connect ball data
+func NewBallData(ball core.BallMetrics, convention core.SpinConvention) *BallData {
+	spinAxis, sideSpin, horizontalAngle := convention.Apply(ball.SpinAxis, ball.SidespinRPM, ball.HorizontalAngle)
+	return &BallData{
+		Speed:     ball.BallSpeedMPS * mpsToMPH,
+		SpinAxis:  spinAxis,
+		TotalSpin: ball.TotalspinRPM,
+		BackSpin:  ball.BackspinRPM,
+		SideSpin:  sideSpin,
+		HLA:       horizontalAngle,
+		VLA:       ball.VerticalAngle,
+	}
+}
+
+// NewClubData converts the device's club metrics to Open Connect club data
+func NewClubData(club core.ClubMetrics) *ClubData {
+	// Lie is degrees toe up from the club's lie at address; send the middle
+	// of the estimate when there is one
+	lie := 0.0
+	if club.DynamicLie != nil {
+		lie = club.DynamicLie.Mid()
+	}
+	return &ClubData{
+		Speed:                club.ClubSpeed * mpsToMPH,
+		AngleOfAttack:        club.AttackAngle,
+		FaceToTarget:         club.FaceAngle,
+		Lie:                  lie,
+		Loft:                 club.DynamicLoftAngle,
+		Path:                 club.PathAngle,
+		VerticalFaceImpact:   club.ImpactVertical,
+		HorizontalFaceImpact: club.ImpactHorizontal,
+	}
+}
+
+// clubTypes maps Open Connect club codes to the nearest club the device
+// knows
+var clubTypes = map[string]core.ClubType{
+	// Drivers and woods
+	"DR": core.ClubDriver,
+	"W2": core.ClubWood3,
+	"W3": core.ClubWood3,
+	"W4": core.ClubWood5,
+	"W5": core.ClubWood5,
+	"W6": core.ClubWood7,
+	"W7": core.ClubWood7,
+
+	// Hybrids
+	"H2": core.ClubWood3,
+	"H3": core.ClubWood3,
+	"H4": core.ClubWood3,
+	"H5": core.ClubWood3,
+	"H6": core.ClubWood5,
+	"H7": core.ClubIron4,
+
+	// Irons
+	"I1": core.ClubWood3,
+	"I2": core.ClubWood3,
+	"I3": core.ClubWood5,
+	"I4": core.ClubIron4,
+	"I5": core.ClubIron5,
+	"I6": core.ClubIron6,
+	"I7": core.ClubIron7,
+	"I8": core.ClubIron8,
+	"I9": core.ClubIron9,
+
+	// Wedges
+	"PW": core.ClubPitchingWedge,
+	"AW": core.ClubApproachWedge,
+	"GW": core.ClubApproachWedge,
+	"SW": core.ClubSandWedge,
+	"LW": core.ClubSandWedge,
+
+	// Putter
+	"PT": core.ClubPutter,
+}
+
+// ClubType returns the device's club for an Open Connect club code, or nil
+// if there is none
+func ClubType(code string) *core.ClubType {
+	if club, ok := clubTypes[code]; ok {
+		return &club
+	}
+	return nil
+}
+
+// ClubName turns an Open Connect club code such as "I7" into the short name
+// shown in the UI, such as "7I". Unknown codes are returned unchanged.
+func ClubName(code string) string {
+	switch code {
+	case "PT":
+		return "PUTT"
+	case "DR", "PW", "AW", "GW", "SW", "LW":
+		return code
+	}
+	if _, ok := clubTypes[code]; ok {
+		return code[1:] + code[:1]
+	}
+	return code
+}
+
+// Handedness returns the handedness for an Open Connect "Handed" value.
+// Anything but "LH" is right-handed.
+func Handedness(handed string) core.HandednessType {
+	if handed == "LH" {
+		return core.LeftHanded
+	}
+	return core.RightHanded
+}
+
+// ApplyPlayer selects the club and handedness of the player who is up.
+// host names the simulator in the log.
+func ApplyPlayer(stateManager *core.StateManager, host string, player Player) {
+	if code := player.Club; code != "" {
+		if club := ClubType(code); club != nil {
+			log.Printf("[%s] Selected club: %s (mapped to %v)", host, code, club)
+			stateManager.SetClub(club)
+		} else {
+			log.Printf("[%s] Unmapped club: %s", host, code)
+		}
+
+		name := ClubName(code)
+		stateManager.SetClubName(&name)
+	}
+
+	if player.Handed != "" {
+		handedness := Handedness(player.Handed)
+		log.Printf("[%s] Selected handedness: %s", host, player.Handed)
+		stateManager.SetHandedness(&handedness)
+	}
+}
@@ -0,0 +1,155 @@
+package openconnect
+
+import (
+	"math"
+	"testing"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+func TestBallMessage(t *testing.T) {
+	header := ShotData{DeviceID: "CustomLaunchMonitor", Units: "Yards", APIversion: "1", ShotNumber: 4}
+	ball := core.BallMetrics{
+		BallSpeedMPS:    60,
+		VerticalAngle:   14.5,
+		HorizontalAngle: -2.25,
+		TotalspinRPM:    3000,
+		SpinAxis:        5,
+		BackspinRPM:     2989,
+		SidespinRPM:     261,
+	}
+
+	tests := []struct {
+		name       string
+		convention core.SpinConvention
+		spinAxis   float64
+		sideSpin   int16
+		hla        float64
+	}{
+		{"standard", core.SpinConventionPresets()[core.SpinPresetStandard], -5, -261, -2.25},
+		{"device", core.SpinConventionPresets()[core.SpinPresetDevice], 5, 261, -2.25},
+		{"mirrored", core.SpinConventionPresets()[core.SpinPresetMirrored], -5, -261, 2.25},
+	}
+	for _, tt := range tests {
+		msg := BallMessage(header, ball, tt.convention)
+		if msg.DeviceID != header.DeviceID || msg.ShotNumber != 4 {
+			t.Errorf("%s: header = %+v", tt.name, msg)
+		}
+		if !msg.ShotDataOptions.ContainsBallData || msg.ShotDataOptions.ContainsClubData || msg.ClubData != nil {
+			t.Errorf("%s: options = %+v, club = %+v; want ball data only", tt.name, msg.ShotDataOptions, msg.ClubData)
+		}
+		data := msg.BallData
+		if math.Abs(data.Speed-134.22) > 0.01 {
+			t.Errorf("%s: Speed = %.2f mph, want 134.22", tt.name, data.Speed)
+		}
+		if data.VLA != 14.5 || data.TotalSpin != 3000 || data.BackSpin != 2989 {
+			t.Errorf("%s: BallData = %+v", tt.name, data)
+		}
+		if data.SpinAxis != tt.spinAxis || data.SideSpin != tt.sideSpin || data.HLA != tt.hla {
+			t.Errorf("%s: SpinAxis/SideSpin/HLA = %v/%d/%v, want %v/%d/%v", tt.name,
+				data.SpinAxis, data.SideSpin, data.HLA, tt.spinAxis, tt.sideSpin, tt.hla)
+		}
+	}
+}
+
+func TestClubMessage(t *testing.T) {
+	club := &core.ClubMetrics{
+		ClubSpeed:        40,
+		PathAngle:        3.5,
+		FaceAngle:        -1.5,
+		AttackAngle:      -4,
+		DynamicLoftAngle: 18,
+		ImpactHorizontal: 0.25,
+		ImpactVertical:   -0.5,
+		DynamicLie:       &core.LieRange{Min: 1, Max: 3},
+	}
+
+	msg := ClubMessage(ShotData{ShotNumber: 2}, club)
+	if !msg.ShotDataOptions.ContainsClubData || msg.ShotDataOptions.ContainsBallData || msg.BallData != nil {
+		t.Errorf("options = %+v, ball = %+v; want club data only", msg.ShotDataOptions, msg.BallData)
+	}
+	data := msg.ClubData
+	if math.Abs(data.Speed-89.48) > 0.01 {
+		t.Errorf("Speed = %.2f mph, want 89.48", data.Speed)
+	}
+	if data.Path != 3.5 || data.FaceToTarget != -1.5 || data.AngleOfAttack != -4 || data.Loft != 18 {
+		t.Errorf("ClubData = %+v", data)
+	}
+	if data.HorizontalFaceImpact != 0.25 || data.VerticalFaceImpact != -0.5 {
+		t.Errorf("face impact = %v/%v, want 0.25/-0.5", data.HorizontalFaceImpact, data.VerticalFaceImpact)
+	}
+	if data.Lie != 2 {
+		t.Errorf("Lie = %v, want the middle of the estimate, 2", data.Lie)
+	}
+
+	cleared := ClubMessage(ShotData{ShotNumber: 2}, nil)
+	if !cleared.ShotDataOptions.ContainsClubData || cleared.ClubData == nil || *cleared.ClubData != (ClubData{}) {
+		t.Errorf("cleared club = %+v, want zeroed club data", cleared.ClubData)
+	}
+}
+
+func TestReadyMessage(t *testing.T) {
+	msg := ReadyMessage(ShotData{ShotNumber: 3, ShotDataOptions: ShotOptions{ContainsBallData: true}}, true)
+	want := ShotOptions{LaunchMonitorIsReady: true, LaunchMonitorBallDetected: true}
+	if msg.ShotDataOptions != want || msg.ShotNumber != 3 {
+		t.Errorf("ready message = %+v, want options %+v", msg, want)
+	}
+}
+
+func TestClubCodes(t *testing.T) {
+	tests := []struct {
+		code string
+		club *core.ClubType
+		name string
+	}{
+		{"DR", clubPtr(core.ClubDriver), "DR"},
+		{"W3", clubPtr(core.ClubWood3), "3W"},
+		{"H4", clubPtr(core.ClubWood3), "4H"},
+		{"I7", clubPtr(core.ClubIron7), "7I"},
+		{"GW", clubPtr(core.ClubApproachWedge), "GW"},
+		{"LW", clubPtr(core.ClubSandWedge), "LW"},
+		{"PT", clubPtr(core.ClubPutter), "PUTT"},
+		{"W9", nil, "W9"},
+		{"", nil, ""},
+	}
+	for _, tt := range tests {
+		club := ClubType(tt.code)
+		if (club == nil) != (tt.club == nil) || (club != nil && *club != *tt.club) {
+			t.Errorf("ClubType(%q) = %v, want %v", tt.code, club, tt.club)
+		}
+		if name := ClubName(tt.code); name != tt.name {
+			t.Errorf("ClubName(%q) = %q, want %q", tt.code, name, tt.name)
+		}
+	}
+}
+
+func TestApplyPlayer(t *testing.T) {
+	sm := core.NewStateManager()
+	ApplyPlayer(sm, "Test", Player{Club: "I7", Handed: "LH"})
+
+	if club := sm.GetClub(); club == nil || *club != core.ClubIron7 {
+		t.Errorf("club = %v, want 7 iron", club)
+	}
+	if name := sm.GetClubName(); name == nil || *name != "7I" {
+		t.Errorf("club name = %v, want 7I", name)
+	}
+	if handedness := sm.GetHandedness(); handedness == nil || *handedness != core.LeftHanded {
+		t.Errorf("handedness = %v, want left-handed", handedness)
+	}
+
+	// An unknown club still shows its code, and only "LH" is left-handed
+	ApplyPlayer(sm, "Test", Player{Club: "XX", Handed: "left"})
+	if club := sm.GetClub(); club == nil || *club != core.ClubIron7 {
+		t.Errorf("club = %v, want the 7 iron kept", club)
+	}
+	if name := sm.GetClubName(); name == nil || *name != "XX" {
+		t.Errorf("club name = %v, want XX", name)
+	}
+	if handedness := sm.GetHandedness(); handedness == nil || *handedness != core.RightHanded {
+		t.Errorf("handedness = %v, want right-handed", handedness)
+	}
+}
+
+func clubPtr(club core.ClubType) *core.ClubType {
+	return &club
+}
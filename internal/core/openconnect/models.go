@@ -0,0 +1,57 @@
+// Package openconnect is version 1 of the Open Connect API that GSPro and
+// Awesome Golf take shots over: the messages both hosts share, and the
+// conversion between them and the device's metrics.
+package openconnect
+
+// Player is the golfer whose turn it is. Name is only sent by hosts that
+// report who is up in a multiplayer round.
+type Player struct {
+	Name   string `json:"Name,omitempty"`
+	Club   string `json:"Club"`
+	Handed string `json:"Handed"`
+}
+
+// ShotData is sent for each shot, and without ball or club data to report
+// whether the launch monitor is ready
+type ShotData struct {
+	DeviceID        string      `json:"DeviceID"`
+	Units           string      `json:"Units"`
+	APIversion      string      `json:"APIversion"`
+	ShotNumber      int         `json:"ShotNumber"`
+	ShotDataOptions ShotOptions `json:"ShotDataOptions"`
+	BallData        *BallData   `json:"BallData,omitempty"`
+	ClubData        *ClubData   `json:"ClubData,omitempty"`
+}
+
+// ShotOptions says what a ShotData message contains
+type ShotOptions struct {
+	ContainsBallData          bool `json:"ContainsBallData"`
+	ContainsClubData          bool `json:"ContainsClubData"`
+	LaunchMonitorIsReady      bool `json:"LaunchMonitorIsReady,omitempty"`
+	LaunchMonitorBallDetected bool `json:"LaunchMonitorBallDetected,omitempty"`
+}
+
+// BallData is the ball's launch, with speed in mph
+type BallData struct {
+	Speed     float64 `json:"Speed"`
+	SpinAxis  float64 `json:"SpinAxis"`
+	TotalSpin int16   `json:"TotalSpin"`
+	BackSpin  int16   `json:"BackSpin"`
+	SideSpin  int16   `json:"SideSpin"`
+	HLA       float64 `json:"HLA"`
+	VLA       float64 `json:"VLA"`
+}
+
+// ClubData is the club's delivery, with speed in mph
+type ClubData struct {
+	Speed                float64 `json:"Speed"`
+	AngleOfAttack        float64 `json:"AngleOfAttack"`
+	FaceToTarget         float64 `json:"FaceToTarget"`
+	Lie                  float64 `json:"Lie"`
+	Loft                 float64 `json:"Loft"`
+	Path                 float64 `json:"Path"`
+	SpeedAtImpact        float64 `json:"SpeedAtImpact"`
+	VerticalFaceImpact   float64 `json:"VerticalFaceImpact"`
+	HorizontalFaceImpact float64 `json:"HorizontalFaceImpact"`
+	ClosureRate          float64 `json:"ClosureRate"`
+}
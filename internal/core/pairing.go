@@ -0,0 +1,94 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ErrPairingUnsupported is returned when the Bluetooth client can't pair.
+// macOS and Windows pair on demand, so the tinygo backend doesn't need to.
+var ErrPairingUnsupported = errors.New("pairing not supported")
+
+// BondedDevice is a device the connector has paired with
+type BondedDevice struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// pairer is implemented by clients that can pair with the connected device
+type pairer interface {
+	Pair() error
+	Unpair(address string) error
+	GetConnectedDeviceAddress() string
+}
+
+// PairingSupported reports whether the Bluetooth client can pair
+func (bm *BluetoothManager) PairingSupported() bool {
+	_, ok := bm.bluetoothClient.(pairer)
+	return ok
+}
+
+// SetBondedDevice sets the device paired in an earlier session. Connections
+// prefer its address over scanning by name.
+func (bm *BluetoothManager) SetBondedDevice(device BondedDevice) {
+	bm.connectionMutex.Lock()
+	defer bm.connectionMutex.Unlock()
+	bm.bonded = device
+}
+
+// BondedDevice returns the paired device, if any
+func (bm *BluetoothManager) BondedDevice() BondedDevice {
+	bm.connectionMutex.Lock()
+	defer bm.connectionMutex.Unlock()
+	return bm.bonded
+}
+
+// Pair pairs with the connected device and remembers it
+func (bm *BluetoothManager) Pair() (BondedDevice, error) {
+	client, ok := bm.bluetoothClient.(pairer)
+	if !ok {
+		return BondedDevice{}, ErrPairingUnsupported
+	}
+	if !bm.bluetoothClient.IsConnected() {
+		return BondedDevice{}, errors.New("not connected to device")
+	}
+
+	if err := client.Pair(); err != nil {
+		return BondedDevice{}, fmt.Errorf("failed to pair with device: %w", err)
+	}
+
+	device := BondedDevice{
+		Name:    bm.bluetoothClient.GetConnectedDeviceName(),
+		Address: client.GetConnectedDeviceAddress(),
+	}
+	log.Printf("BluetoothManager: Paired with %s [%s]", device.Name, device.Address)
+	bm.SetBondedDevice(device)
+	return device, nil
+}
+
+// Unpair removes the pairing with the bonded device and forgets it
+func (bm *BluetoothManager) Unpair() error {
+	device := bm.BondedDevice()
+	if client, ok := bm.bluetoothClient.(pairer); ok && device.Address != "" {
+		if err := client.Unpair(device.Address); err != nil {
+			return fmt.Errorf("failed to unpair device: %w", err)
+		}
+	}
+	log.Printf("BluetoothManager: Forgot paired device %s [%s]", device.Name, device.Address)
+	bm.SetBondedDevice(BondedDevice{})
+	return nil
+}
+
+// bondedAddressFor returns the bonded device's address when a connection to
+// deviceName may use it. Callers hold connectionMutex.
+func (bm *BluetoothManager) bondedAddressFor(deviceName string) string {
+	if bm.bonded.Address == "" {
+		return ""
+	}
+	if deviceName != "" && !strings.EqualFold(deviceName, bm.bonded.Name) {
+		return ""
+	}
+	return bm.bonded.Address
+}
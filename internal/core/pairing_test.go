@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPair_RemembersSimulatedDevice(t *testing.T) {
+	bm := NewBluetoothManager(NewStateManager())
+	sim := NewSimulatorBluetoothClient(SimulatorConfig{})
+	bm.SetClient(sim)
+	if err := sim.Connect("SquareGolf(1234)", ""); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer sim.Disconnect()
+
+	if !bm.PairingSupported() {
+		t.Fatal("Expected the simulator to support pairing")
+	}
+	device, err := bm.Pair()
+	if err != nil {
+		t.Fatalf("Pair() error = %v", err)
+	}
+	if device.Name != "SquareGolf(1234)" || device.Address != SimulatedDeviceAddress || !sim.IsPaired() {
+		t.Errorf("Pair() = %+v, paired = %v", device, sim.IsPaired())
+	}
+	if bm.BondedDevice() != device {
+		t.Errorf("BondedDevice() = %+v, want %+v", bm.BondedDevice(), device)
+	}
+
+	if err := bm.Unpair(); err != nil {
+		t.Fatalf("Unpair() error = %v", err)
+	}
+	if sim.IsPaired() || bm.BondedDevice() != (BondedDevice{}) {
+		t.Error("Expected Unpair to forget the device")
+	}
+}
+
+func TestPair_UnsupportedClient(t *testing.T) {
+	bm := NewBluetoothManager(NewStateManager())
+	bm.SetClient(NewMockBluetoothClient())
+
+	if bm.PairingSupported() {
+		t.Error("Expected the mock client not to support pairing")
+	}
+	if _, err := bm.Pair(); !errors.Is(err, ErrPairingUnsupported) {
+		t.Errorf("Pair() error = %v, want ErrPairingUnsupported", err)
+	}
+}
+
+func TestPair_NotConnected(t *testing.T) {
+	bm := NewBluetoothManager(NewStateManager())
+	bm.SetClient(NewSimulatorBluetoothClient(SimulatorConfig{}))
+
+	if _, err := bm.Pair(); err == nil {
+		t.Error("Expected pairing to need a connection")
+	}
+}
+
+func TestBondedAddressFor(t *testing.T) {
+	bm := NewBluetoothManager(NewStateManager())
+	if address := bm.bondedAddressFor(""); address != "" {
+		t.Errorf("bondedAddressFor() = %q without a bonded device", address)
+	}
+
+	bm.SetBondedDevice(BondedDevice{Name: "SquareGolf(1234)", Address: "AA:BB:CC:DD:EE:FF"})
+	if address := bm.bondedAddressFor(""); address != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("bondedAddressFor(\"\") = %q, want the bonded address", address)
+	}
+	if address := bm.bondedAddressFor("squaregolf(1234)"); address != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("bondedAddressFor(same name) = %q, want the bonded address", address)
+	}
+	if address := bm.bondedAddressFor("SquareGolf(9999)"); address != "" {
+		t.Errorf("bondedAddressFor(other name) = %q, want none", address)
+	}
+}
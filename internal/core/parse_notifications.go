@@ -1,11 +1,14 @@
 package core
 
 import (
-	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core/protocol"
 )
 
 // SensorData represents data from the sensor
@@ -20,42 +23,57 @@ type SensorData struct {
 
 // BallMetrics represents ball metrics from a shot
 type BallMetrics struct {
-	RawData          []string `json:"rawData,omitempty"`
-	BallSpeedMPS     float64  `json:"speed"`
-	VerticalAngle    float64  `json:"launchAngle"`
-	HorizontalAngle  float64  `json:"horizontalAngle"`
-	TotalspinRPM     int16    `json:"totalSpin"`
-	SpinAxis         float64  `json:"spinAxis"`
-	BackspinRPM      int16    `json:"backSpin"`
-	SidespinRPM      int16    `json:"sideSpin"`
-	IsBallSpeedValid bool     `json:"isBallSpeedValid"`
-	IsTotalSpinValid bool     `json:"isTotalSpinValid"`
-	IsSpinAxisValid  bool     `json:"isSpinAxisValid"`
-	IsBackspinValid  bool     `json:"isBackSpinValid"`
-	IsSidespinValid  bool     `json:"isSideSpinValid"`
-	ShotType         ShotType `json:"shotType"`
+	RawData          []string               `json:"rawData,omitempty"`
+	BallSpeedMPS     float64                `json:"speed"`
+	VerticalAngle    float64                `json:"launchAngle"`
+	HorizontalAngle  float64                `json:"horizontalAngle"`
+	TotalspinRPM     int16                  `json:"totalSpin"`
+	SpinAxis         float64                `json:"spinAxis"`
+	BackspinRPM      int16                  `json:"backSpin"`
+	SidespinRPM      int16                  `json:"sideSpin"`
+	IsBallSpeedValid bool                   `json:"isBallSpeedValid"`
+	IsTotalSpinValid bool                   `json:"isTotalSpinValid"`
+	IsSpinAxisValid  bool                   `json:"isSpinAxisValid"`
+	IsBackspinValid  bool                   `json:"isBackSpinValid"`
+	IsSidespinValid  bool                   `json:"isSideSpinValid"`
+	ShotType         ShotType               `json:"shotType"`
+	SpinEstimated    bool                   `json:"spinEstimated,omitempty"`
+	Environment      *EnvironmentAdjustment `json:"environment,omitempty"`
+	ShotID           int64                  `json:"shotId,omitempty"`
+	CapturedAt       time.Time              `json:"capturedAt"`
 	validityBitmask  string
 }
 
 // ClubMetrics represents club metrics from a shot
 type ClubMetrics struct {
-	RawData                 []string `json:"rawData,omitempty"`
-	PathAngle               float64  `json:"path"`
-	FaceAngle               float64  `json:"angle"`
-	AttackAngle             float64  `json:"attackAngle"`
-	DynamicLoftAngle        float64  `json:"dynamicLoft"`
-	ImpactHorizontal        float64  `json:"impactHorizontal"`
-	ImpactVertical          float64  `json:"impactVertical"`
-	ClubSpeed               float64  `json:"clubSpeed"`
-	SmashFactor             float64  `json:"smashFactor"`
-	IsPathAngleValid        bool     `json:"isPathValid"`
-	IsFaceAngleValid        bool     `json:"isFaceAngleValid"`
-	IsAttackAngleValid      bool     `json:"isAttackAngleValid"`
-	IsDynamicLoftValid      bool     `json:"isDynamicLoftValid"`
-	IsImpactHorizontalValid bool     `json:"isImpactHorizontalValid"`
-	IsImpactVerticalValid   bool     `json:"isImpactVerticalValid"`
-	IsClubSpeedValid        bool     `json:"isClubSpeedValid"`
-	IsSmashFactorValid      bool     `json:"isSmashFactorValid"`
+	RawData                 []string  `json:"rawData,omitempty"`
+	PathAngle               float64   `json:"path"`
+	FaceAngle               float64   `json:"angle"`
+	AttackAngle             float64   `json:"attackAngle"`
+	DynamicLoftAngle        float64   `json:"dynamicLoft"`
+	ImpactHorizontal        float64   `json:"impactHorizontal"`
+	ImpactVertical          float64   `json:"impactVertical"`
+	ClubSpeed               float64   `json:"clubSpeed"`
+	SmashFactor             float64   `json:"smashFactor"`
+	IsPathAngleValid        bool      `json:"isPathValid"`
+	IsFaceAngleValid        bool      `json:"isFaceAngleValid"`
+	IsAttackAngleValid      bool      `json:"isAttackAngleValid"`
+	IsDynamicLoftValid      bool      `json:"isDynamicLoftValid"`
+	IsImpactHorizontalValid bool      `json:"isImpactHorizontalValid"`
+	IsImpactVerticalValid   bool      `json:"isImpactVerticalValid"`
+	IsClubSpeedValid        bool      `json:"isClubSpeedValid"`
+	IsSmashFactorValid      bool      `json:"isSmashFactorValid"`
+	ClubSpeedEstimated      bool      `json:"clubSpeedEstimated,omitempty"`
+	FaceToPath              float64   `json:"faceToPath"` // face angle less path, see DeriveClubMetrics
+	IsFaceToPathValid       bool      `json:"isFaceToPathValid"`
+	DynamicLie              *LieRange `json:"dynamicLie,omitempty"`
+	SmashFactorEstimated    bool      `json:"smashFactorEstimated,omitempty"` // worked out from an estimated club speed
+	SpinLoft                float64   `json:"spinLoft"`                       // dynamic loft less attack angle
+	IsSpinLoftValid         bool      `json:"isSpinLoftValid"`
+	LaunchEfficiency        float64   `json:"launchEfficiency"` // launch angle over dynamic loft
+	IsLaunchEfficiencyValid bool      `json:"isLaunchEfficiencyValid"`
+	ShotID                  int64     `json:"shotId,omitempty"`
+	CapturedAt              time.Time `json:"capturedAt"`
 }
 
 // AlignmentData represents device alignment/aim information
@@ -65,105 +83,98 @@ type AlignmentData struct {
 	IsAligned bool     `json:"isAligned"` // Whether device is pointing at target (within ±2° threshold)
 }
 
-// ParseSensorData parses raw sensor data bytes
-func ParseSensorData(bytesList []string) (*SensorData, error) {
-	if len(bytesList) < 17 {
-		return nil, fmt.Errorf("insufficient data for parsing sensor data")
-	}
-
-	sensorData := &SensorData{
-		RawData:      bytesList,
-		BallReady:    bytesList[3] == "01" || bytesList[3] == "02",
-		BallDetected: bytesList[4] == "01",
-	}
-
-	// Parse position data
-	posXBytes, err := hex.DecodeString(bytesList[5] + bytesList[6] + bytesList[7] + bytesList[8])
-	if err == nil && len(posXBytes) == 4 {
-		sensorData.PositionX = int32(binary.LittleEndian.Uint32(posXBytes))
-	}
+// ErrInvalidByte marks a notification byte that is not two hex digits
+var ErrInvalidByte = errors.New("invalid byte")
 
-	posYBytes, err := hex.DecodeString(bytesList[9] + bytesList[10] + bytesList[11] + bytesList[12])
-	if err == nil && len(posYBytes) == 4 {
-		sensorData.PositionY = int32(binary.LittleEndian.Uint32(posYBytes))
-	}
+// ParseError explains why a notification could not be parsed. Err wraps
+// protocol.ErrShortMessage, protocol.ErrLongMessage or ErrInvalidByte.
+type ParseError struct {
+	Message string // what was being parsed, e.g. "ball metrics"
+	Offset  int    // the bad byte, or -1 when the length is wrong
+	Err     error
+}
 
-	posZBytes, err := hex.DecodeString(bytesList[13] + bytesList[14] + bytesList[15] + bytesList[16])
-	if err == nil && len(posZBytes) == 4 {
-		sensorData.PositionZ = int32(binary.LittleEndian.Uint32(posZBytes))
+func (e *ParseError) Error() string {
+	if e.Offset >= 0 {
+		return fmt.Sprintf("%s: byte %d: %v", e.Message, e.Offset, e.Err)
 	}
-
-	return sensorData, nil
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
 }
 
-// ParseShotBallMetrics parses ball metrics from shot data
-func ParseShotBallMetrics(bytesList []string) (*BallMetrics, error) {
-	if len(bytesList) < 17 {
-		return nil, fmt.Errorf("insufficient data for parsing ball metrics")
-	}
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
 
-	metrics := &BallMetrics{
-		RawData:          bytesList,
-		IsBallSpeedValid: true,
-		IsTotalSpinValid: true,
-		IsSpinAxisValid:  true,
-		IsBackspinValid:  true,
-		IsSidespinValid:  true,
+// notificationBytes checks a notification's length and turns the
+// hex-string-per-byte form used by the handlers back into bytes for the
+// protocol decoders
+func notificationBytes(message string, bytesList []string, minLength int) ([]byte, error) {
+	if len(bytesList) < minLength {
+		return nil, &ParseError{Message: message, Offset: -1, Err: fmt.Errorf("%w: need %d bytes, got %d", protocol.ErrShortMessage, minLength, len(bytesList))}
 	}
-
-	// Store raw validity bitmask byte for Omni processing
-	if len(bytesList) >= 3 {
-		metrics.validityBitmask = bytesList[2]
+	if len(bytesList) > protocol.MaxMessageLength {
+		return nil, &ParseError{Message: message, Offset: -1, Err: fmt.Errorf("%w: got %d bytes, at most %d", protocol.ErrLongMessage, len(bytesList), protocol.MaxMessageLength)}
 	}
 
-	// Parse ball speed
-	if ballSpeed, valid, ok := parseScaledInt16Metric(bytesList[3], bytesList[4], 100.0); ok {
-		metrics.BallSpeedMPS = ballSpeed
-		metrics.IsBallSpeedValid = valid
-	} else {
-		metrics.IsBallSpeedValid = false
+	data := make([]byte, len(bytesList))
+	for i, byteHex := range bytesList {
+		decoded, err := hex.DecodeString(byteHex)
+		if err != nil || len(decoded) != 1 {
+			return nil, &ParseError{Message: message, Offset: i, Err: fmt.Errorf("%w %q", ErrInvalidByte, byteHex)}
+		}
+		data[i] = decoded[0]
 	}
+	return data, nil
+}
 
-	// Parse vertical angle
-	if verticalAngle, _, ok := parseScaledInt16Metric(bytesList[5], bytesList[6], 100.0); ok {
-		metrics.VerticalAngle = verticalAngle
+// ParseSensorData parses raw sensor data bytes
+func ParseSensorData(bytesList []string) (*SensorData, error) {
+	data, err := notificationBytes("sensor data", bytesList, protocol.SensorLength)
+	if err != nil {
+		return nil, err
 	}
-
-	// Parse horizontal angle
-	if horizontalAngle, _, ok := parseScaledInt16Metric(bytesList[7], bytesList[8], 100.0); ok {
-		metrics.HorizontalAngle = horizontalAngle
+	sensor, err := protocol.DecodeSensor(data)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse total spin
-	if totalSpin, valid, ok := parseInt16Metric(bytesList[9], bytesList[10]); ok {
-		metrics.TotalspinRPM = totalSpin
-		metrics.IsTotalSpinValid = valid
-	} else {
-		metrics.IsTotalSpinValid = false
-	}
+	return &SensorData{
+		RawData:      bytesList,
+		BallReady:    sensor.BallReady,
+		BallDetected: sensor.BallDetected,
+		PositionX:    sensor.X.Value,
+		PositionY:    sensor.Y.Value,
+		PositionZ:    sensor.Z.Value,
+	}, nil
+}
 
-	// Parse spin axis
-	if spinAxis, valid, ok := parseScaledInt16Metric(bytesList[11], bytesList[12], 100.0); ok {
-		metrics.SpinAxis = spinAxis
-		metrics.IsSpinAxisValid = valid
-	} else {
-		metrics.IsSpinAxisValid = false
+// ParseShotBallMetrics parses ball metrics from shot data
+func ParseShotBallMetrics(bytesList []string) (*BallMetrics, error) {
+	data, err := notificationBytes("ball metrics", bytesList, protocol.ShotBallLength)
+	if err != nil {
+		return nil, err
 	}
-
-	// Parse backspin
-	if backspin, valid, ok := parseInt16Metric(bytesList[13], bytesList[14]); ok {
-		metrics.BackspinRPM = backspin
-		metrics.IsBackspinValid = valid
-	} else {
-		metrics.IsBackspinValid = false
+	shot, err := protocol.DecodeShotBall(data)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse sidespin
-	if sidespin, valid, ok := parseInt16Metric(bytesList[15], bytesList[16]); ok {
-		metrics.SidespinRPM = sidespin
-		metrics.IsSidespinValid = valid
-	} else {
-		metrics.IsSidespinValid = false
+	metrics := &BallMetrics{
+		RawData:          bytesList,
+		BallSpeedMPS:     shot.BallSpeed.Scaled(100.0),
+		VerticalAngle:    shot.VerticalAngle.Scaled(100.0),
+		HorizontalAngle:  shot.HorizontalAngle.Scaled(100.0),
+		TotalspinRPM:     shot.TotalSpin.Value,
+		SpinAxis:         shot.SpinAxis.Scaled(100.0),
+		BackspinRPM:      shot.Backspin.Value,
+		SidespinRPM:      shot.Sidespin.Value,
+		IsBallSpeedValid: shot.BallSpeed.Valid,
+		IsTotalSpinValid: shot.TotalSpin.Valid,
+		IsSpinAxisValid:  shot.SpinAxis.Valid,
+		IsBackspinValid:  shot.Backspin.Valid,
+		IsSidespinValid:  shot.Sidespin.Valid,
+		// Store raw validity bitmask byte for Omni processing
+		validityBitmask: bytesList[2],
 	}
 
 	if metrics.BackspinRPM < 0 {
@@ -203,138 +214,85 @@ func ApplyOmniBallValidityBitmask(metrics *BallMetrics) {
 
 // ParseShotClubMetrics parses club metrics from shot data
 func ParseShotClubMetrics(bytesList []string) (*ClubMetrics, error) {
-	if len(bytesList) < 11 {
-		return nil, fmt.Errorf("insufficient data for parsing club metrics")
-	}
-
-	metrics := &ClubMetrics{
-		RawData:            bytesList,
-		IsPathAngleValid:   true,
-		IsFaceAngleValid:   true,
-		IsAttackAngleValid: true,
-		IsDynamicLoftValid: true,
-	}
-
-	// Parse path angle
-	if pathAngle, valid, ok := parseScaledInt16Metric(bytesList[3], bytesList[4], 100.0); ok {
-		metrics.PathAngle = pathAngle
-		metrics.IsPathAngleValid = valid
-	} else {
-		metrics.IsPathAngleValid = false
-	}
-
-	// Parse face angle
-	if faceAngle, valid, ok := parseScaledInt16Metric(bytesList[5], bytesList[6], 100.0); ok {
-		metrics.FaceAngle = faceAngle
-		metrics.IsFaceAngleValid = valid
-	} else {
-		metrics.IsFaceAngleValid = false
-	}
-
-	// Parse attack angle
-	if attackAngle, valid, ok := parseScaledInt16Metric(bytesList[7], bytesList[8], 100.0); ok {
-		metrics.AttackAngle = attackAngle
-		metrics.IsAttackAngleValid = valid
-	} else {
-		metrics.IsAttackAngleValid = false
+	data, err := notificationBytes("club metrics", bytesList, protocol.ShotClubLength)
+	if err != nil {
+		return nil, err
 	}
-
-	// Parse dynamic loft angle
-	if loftAngle, valid, ok := parseScaledInt16Metric(bytesList[9], bytesList[10], 100.0); ok {
-		metrics.DynamicLoftAngle = loftAngle
-		metrics.IsDynamicLoftValid = valid
-	} else {
-		metrics.IsDynamicLoftValid = false
+	club, err := protocol.DecodeShotClub(data)
+	if err != nil {
+		return nil, err
 	}
 
-	return metrics, nil
+	return &ClubMetrics{
+		RawData:            bytesList,
+		PathAngle:          club.Path.Scaled(100.0),
+		FaceAngle:          club.Face.Scaled(100.0),
+		AttackAngle:        club.Attack.Scaled(100.0),
+		DynamicLoftAngle:   club.DynamicLoft.Scaled(100.0),
+		IsPathAngleValid:   club.Path.Valid,
+		IsFaceAngleValid:   club.Face.Valid,
+		IsAttackAngleValid: club.Attack.Valid,
+		IsDynamicLoftValid: club.DynamicLoft.Valid,
+	}, nil
 }
 
 // ParseOmniShotClubMetrics parses club metrics from an Omni device (8 fields with validity bitmask)
 func ParseOmniShotClubMetrics(bytesList []string) (*ClubMetrics, error) {
-	if len(bytesList) < 19 {
-		return nil, fmt.Errorf("insufficient data for parsing Omni club metrics (need 19, got %d)", len(bytesList))
+	data, err := notificationBytes("Omni club metrics", bytesList, protocol.OmniShotClubLength)
+	if err != nil {
+		return nil, err
 	}
-
-	validityByte, err := strconv.ParseUint(bytesList[2], 16, 8)
+	club, err := protocol.DecodeShotClub(data)
 	if err != nil {
-		validityByte = 0
+		return nil, err
 	}
-	validity := byte(validityByte)
 
 	metrics := &ClubMetrics{
 		RawData: bytesList,
 	}
 
-	type fieldDef struct {
-		lowIdx, highIdx int
-		target          *float64
-		validTarget     *bool
-		bit             uint
+	fields := []struct {
+		metric      protocol.Metric
+		target      *float64
+		validTarget *bool
+	}{
+		{club.Path, &metrics.PathAngle, &metrics.IsPathAngleValid},
+		{club.Face, &metrics.FaceAngle, &metrics.IsFaceAngleValid},
+		{club.Attack, &metrics.AttackAngle, &metrics.IsAttackAngleValid},
+		{club.DynamicLoft, &metrics.DynamicLoftAngle, &metrics.IsDynamicLoftValid},
+		{club.ImpactHorizontal, &metrics.ImpactHorizontal, &metrics.IsImpactHorizontalValid},
+		{club.ImpactVertical, &metrics.ImpactVertical, &metrics.IsImpactVerticalValid},
+		{club.ClubSpeed, &metrics.ClubSpeed, &metrics.IsClubSpeedValid},
+		{club.SmashFactor, &metrics.SmashFactor, &metrics.IsSmashFactorValid},
 	}
 
-	fields := []fieldDef{
-		{3, 4, &metrics.PathAngle, &metrics.IsPathAngleValid, 0},
-		{5, 6, &metrics.FaceAngle, &metrics.IsFaceAngleValid, 1},
-		{7, 8, &metrics.AttackAngle, &metrics.IsAttackAngleValid, 2},
-		{9, 10, &metrics.DynamicLoftAngle, &metrics.IsDynamicLoftValid, 3},
-		{11, 12, &metrics.ImpactHorizontal, &metrics.IsImpactHorizontalValid, 4},
-		{13, 14, &metrics.ImpactVertical, &metrics.IsImpactVerticalValid, 5},
-		{15, 16, &metrics.ClubSpeed, &metrics.IsClubSpeedValid, 6},
-		{17, 18, &metrics.SmashFactor, &metrics.IsSmashFactorValid, 7},
-	}
-
-	for _, f := range fields {
-		bitmaskValid := (validity & (1 << f.bit)) != 0
-		if val, sentinelValid, ok := parseScaledInt16Metric(bytesList[f.lowIdx], bytesList[f.highIdx], 100.0); ok {
-			*f.target = val
-			*f.validTarget = bitmaskValid && sentinelValid
-		}
+	// Bit i of the validity byte covers field i
+	for bit, f := range fields {
+		*f.target = f.metric.Scaled(100.0)
+		*f.validTarget = club.Validity&(1<<bit) != 0 && f.metric.Valid
 	}
 
 	return metrics, nil
 }
 
-func parseInt16Metric(lowByte, highByte string) (int16, bool, bool) {
-	metricBytes, err := hex.DecodeString(lowByte + highByte)
-	if err != nil || len(metricBytes) != 2 {
-		return 0, false, false
-	}
-
-	value := int16(binary.LittleEndian.Uint16(metricBytes))
-	if value == -32768 {
-		return 0, false, true
-	}
-
-	return value, true, true
-}
-
-func parseScaledInt16Metric(lowByte, highByte string, scale float64) (float64, bool, bool) {
-	value, valid, ok := parseInt16Metric(lowByte, highByte)
-	if !ok {
-		return 0, false, false
-	}
-
-	return float64(value) / scale, valid, true
-}
-
-// ParseAlignmentData parses alignment/aim data from device accelerometer
-func ParseAlignmentData(bytesList []string) (*AlignmentData, error) {
+// ParseAlignmentData parses alignment/aim data from device accelerometer,
+// reading the angle with the given format
+func ParseAlignmentData(bytesList []string, format AlignmentFormat) (*AlignmentData, error) {
 	// Format: 11 04 {seq} {status} 00 {angle_int16} ...
-	// Angle is signed 16-bit little-endian at bytes 5-6, divided by 100.0
+	// By default the angle is signed 16-bit little-endian at bytes 5-6, divided
+	// by 100.0; a calibration file can override the layout (see AlignmentFormat)
 	// Negative = left, positive = right
-	if len(bytesList) < 7 {
-		return nil, fmt.Errorf("insufficient data for parsing alignment data (need at least 7 bytes, got %d)", len(bytesList))
+	if _, err := notificationBytes("alignment data", bytesList, format.Offset+2); err != nil {
+		return nil, err
 	}
-
-	alignment := &AlignmentData{
-		RawData: bytesList,
+	angle, err := format.Decode(bytesList)
+	if err != nil {
+		return nil, &ParseError{Message: "alignment data", Offset: -1, Err: err}
 	}
 
-	angleBytes, err := hex.DecodeString(bytesList[5] + bytesList[6])
-	if err == nil && len(angleBytes) == 2 {
-		angleRaw := int16(binary.LittleEndian.Uint16(angleBytes))
-		alignment.AimAngle = float64(angleRaw) / 100.0
+	alignment := &AlignmentData{
+		RawData:  bytesList,
+		AimAngle: angle,
 	}
 
 	const alignmentThreshold = 2.0
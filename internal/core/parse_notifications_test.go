@@ -1,8 +1,14 @@
 package core
 
 import (
+	"encoding/hex"
+	"errors"
+	"math"
 	"reflect"
 	"testing"
+	"testing/quick"
+
+	"github.com/brentyates/squaregolf-connector/internal/core/protocol"
 )
 
 func TestParseSensorData(t *testing.T) {
@@ -95,20 +101,8 @@ func TestParseSensorData(t *testing.T) {
 				"02", "00", "00", "00",
 				"03", "00", "00", "00",
 			},
-			want: &SensorData{
-				RawData: []string{
-					"00", "01", "02", "01", "00",
-					"ZZ", "00", "00", "00",
-					"02", "00", "00", "00",
-					"03", "00", "00", "00",
-				},
-				BallReady:    true,
-				BallDetected: false,
-				PositionX:    0,
-				PositionY:    2,
-				PositionZ:    3,
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid position Y hex data",
@@ -118,20 +112,8 @@ func TestParseSensorData(t *testing.T) {
 				"ZZ", "00", "00", "00",
 				"03", "00", "00", "00",
 			},
-			want: &SensorData{
-				RawData: []string{
-					"00", "01", "02", "01", "00",
-					"01", "00", "00", "00",
-					"ZZ", "00", "00", "00",
-					"03", "00", "00", "00",
-				},
-				BallReady:    true,
-				BallDetected: false,
-				PositionX:    1,
-				PositionY:    0,
-				PositionZ:    3,
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid position Z hex data",
@@ -141,20 +123,8 @@ func TestParseSensorData(t *testing.T) {
 				"02", "00", "00", "00",
 				"ZZ", "00", "00", "00",
 			},
-			want: &SensorData{
-				RawData: []string{
-					"00", "01", "02", "01", "00",
-					"01", "00", "00", "00",
-					"02", "00", "00", "00",
-					"ZZ", "00", "00", "00",
-				},
-				BallReady:    true,
-				BallDetected: false,
-				PositionX:    1,
-				PositionY:    2,
-				PositionZ:    0,
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 	}
 
@@ -275,32 +245,8 @@ func TestParseShotBallMetrics(t *testing.T) {
 				"D0", "07", // Backspin: 2000 RPM
 				"B8", "0B", // Sidespin: 3000 RPM
 			},
-			want: &BallMetrics{
-				RawData: []string{
-					"11", "02", "37", // Full shot header
-					"ZZ", "00", // Invalid ball speed
-					"C8", "00", // Vertical angle: 200 (2.00 degrees)
-					"2C", "01", // Horizontal angle: 300 (3.00 degrees)
-					"E8", "03", // Total spin: 1000 RPM
-					"F4", "01", // Spin axis: 500 (5.00)
-					"D0", "07", // Backspin: 2000 RPM
-					"B8", "0B", // Sidespin: 3000 RPM
-				},
-				BallSpeedMPS:     0,
-				VerticalAngle:    2.0,
-				HorizontalAngle:  3.0,
-				TotalspinRPM:     1000,
-				SpinAxis:         5.0,
-				BackspinRPM:      2000,
-				SidespinRPM:      3000,
-				IsBallSpeedValid: false,
-				IsTotalSpinValid: true,
-				IsSpinAxisValid:  true,
-				IsBackspinValid:  true,
-				IsSidespinValid:  true,
-				validityBitmask:  "37",
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for vertical angle",
@@ -314,32 +260,8 @@ func TestParseShotBallMetrics(t *testing.T) {
 				"D0", "07", // Backspin: 2000 RPM
 				"B8", "0B", // Sidespin: 3000 RPM
 			},
-			want: &BallMetrics{
-				RawData: []string{
-					"11", "02", "37", // Full shot header
-					"64", "00", // Ball speed: 100 (1.00 m/s)
-					"ZZ", "00", // Invalid vertical angle
-					"2C", "01", // Horizontal angle: 300 (3.00 degrees)
-					"E8", "03", // Total spin: 1000 RPM
-					"F4", "01", // Spin axis: 500 (5.00)
-					"D0", "07", // Backspin: 2000 RPM
-					"B8", "0B", // Sidespin: 3000 RPM
-				},
-				BallSpeedMPS:     1.0,
-				VerticalAngle:    0,
-				HorizontalAngle:  3.0,
-				TotalspinRPM:     1000,
-				SpinAxis:         5.0,
-				BackspinRPM:      2000,
-				SidespinRPM:      3000,
-				IsBallSpeedValid: true,
-				IsTotalSpinValid: true,
-				IsSpinAxisValid:  true,
-				IsBackspinValid:  true,
-				IsSidespinValid:  true,
-				validityBitmask:  "37",
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for horizontal angle",
@@ -353,32 +275,8 @@ func TestParseShotBallMetrics(t *testing.T) {
 				"D0", "07", // Backspin: 2000 RPM
 				"B8", "0B", // Sidespin: 3000 RPM
 			},
-			want: &BallMetrics{
-				RawData: []string{
-					"11", "02", "37", // Full shot header
-					"64", "00", // Ball speed: 100 (1.00 m/s)
-					"C8", "00", // Vertical angle: 200 (2.00 degrees)
-					"ZZ", "01", // Invalid horizontal angle
-					"E8", "03", // Total spin: 1000 RPM
-					"F4", "01", // Spin axis: 500 (5.00)
-					"D0", "07", // Backspin: 2000 RPM
-					"B8", "0B", // Sidespin: 3000 RPM
-				},
-				BallSpeedMPS:     1.0,
-				VerticalAngle:    2.0,
-				HorizontalAngle:  0,
-				TotalspinRPM:     1000,
-				SpinAxis:         5.0,
-				BackspinRPM:      2000,
-				SidespinRPM:      3000,
-				IsBallSpeedValid: true,
-				IsTotalSpinValid: true,
-				IsSpinAxisValid:  true,
-				IsBackspinValid:  true,
-				IsSidespinValid:  true,
-				validityBitmask:  "37",
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for total spin",
@@ -392,32 +290,8 @@ func TestParseShotBallMetrics(t *testing.T) {
 				"D0", "07", // Backspin: 2000 RPM
 				"B8", "0B", // Sidespin: 3000 RPM
 			},
-			want: &BallMetrics{
-				RawData: []string{
-					"11", "02", "37", // Full shot header
-					"64", "00", // Ball speed: 100 (1.00 m/s)
-					"C8", "00", // Vertical angle: 200 (2.00 degrees)
-					"2C", "01", // Horizontal angle: 300 (3.00 degrees)
-					"ZZ", "03", // Invalid total spin
-					"F4", "01", // Spin axis: 500 (5.00)
-					"D0", "07", // Backspin: 2000 RPM
-					"B8", "0B", // Sidespin: 3000 RPM
-				},
-				BallSpeedMPS:     1.0,
-				VerticalAngle:    2.0,
-				HorizontalAngle:  3.0,
-				TotalspinRPM:     0,
-				SpinAxis:         5.0,
-				BackspinRPM:      2000,
-				SidespinRPM:      3000,
-				IsBallSpeedValid: true,
-				IsTotalSpinValid: false,
-				IsSpinAxisValid:  true,
-				IsBackspinValid:  true,
-				IsSidespinValid:  true,
-				validityBitmask:  "37",
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for spin axis",
@@ -431,32 +305,8 @@ func TestParseShotBallMetrics(t *testing.T) {
 				"D0", "07", // Backspin: 2000 RPM
 				"B8", "0B", // Sidespin: 3000 RPM
 			},
-			want: &BallMetrics{
-				RawData: []string{
-					"11", "02", "37", // Full shot header
-					"64", "00", // Ball speed: 100 (1.00 m/s)
-					"C8", "00", // Vertical angle: 200 (2.00 degrees)
-					"2C", "01", // Horizontal angle: 300 (3.00 degrees)
-					"E8", "03", // Total spin: 1000 RPM
-					"ZZ", "01", // Invalid spin axis
-					"D0", "07", // Backspin: 2000 RPM
-					"B8", "0B", // Sidespin: 3000 RPM
-				},
-				BallSpeedMPS:     1.0,
-				VerticalAngle:    2.0,
-				HorizontalAngle:  3.0,
-				TotalspinRPM:     1000,
-				SpinAxis:         0,
-				BackspinRPM:      2000,
-				SidespinRPM:      3000,
-				IsBallSpeedValid: true,
-				IsTotalSpinValid: true,
-				IsSpinAxisValid:  false,
-				IsBackspinValid:  true,
-				IsSidespinValid:  true,
-				validityBitmask:  "37",
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for backspin",
@@ -470,32 +320,8 @@ func TestParseShotBallMetrics(t *testing.T) {
 				"ZZ", "07", // Invalid backspin
 				"B8", "0B", // Sidespin: 3000 RPM
 			},
-			want: &BallMetrics{
-				RawData: []string{
-					"11", "02", "37", // Full shot header
-					"64", "00", // Ball speed: 100 (1.00 m/s)
-					"C8", "00", // Vertical angle: 200 (2.00 degrees)
-					"2C", "01", // Horizontal angle: 300 (3.00 degrees)
-					"E8", "03", // Total spin: 1000 RPM
-					"F4", "01", // Spin axis: 500 (5.00)
-					"ZZ", "07", // Invalid backspin
-					"B8", "0B", // Sidespin: 3000 RPM
-				},
-				BallSpeedMPS:     1.0,
-				VerticalAngle:    2.0,
-				HorizontalAngle:  3.0,
-				TotalspinRPM:     1000,
-				SpinAxis:         5.0,
-				BackspinRPM:      996, // decomposed: 1000 * cos(5°)
-				SidespinRPM:      3000,
-				IsBallSpeedValid: true,
-				IsTotalSpinValid: true,
-				IsSpinAxisValid:  true,
-				IsBackspinValid:  false,
-				IsSidespinValid:  true,
-				validityBitmask:  "37",
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for sidespin",
@@ -509,32 +335,8 @@ func TestParseShotBallMetrics(t *testing.T) {
 				"D0", "07", // Backspin: 2000 RPM
 				"ZZ", "0B", // Invalid sidespin
 			},
-			want: &BallMetrics{
-				RawData: []string{
-					"11", "02", "37", // Full shot header
-					"64", "00", // Ball speed: 100 (1.00 m/s)
-					"C8", "00", // Vertical angle: 200 (2.00 degrees)
-					"2C", "01", // Horizontal angle: 300 (3.00 degrees)
-					"E8", "03", // Total spin: 1000 RPM
-					"F4", "01", // Spin axis: 500 (5.00)
-					"D0", "07", // Backspin: 2000 RPM
-					"ZZ", "0B", // Invalid sidespin
-				},
-				BallSpeedMPS:     1.0,
-				VerticalAngle:    2.0,
-				HorizontalAngle:  3.0,
-				TotalspinRPM:     1000,
-				SpinAxis:         5.0,
-				BackspinRPM:      2000,
-				SidespinRPM:      87, // decomposed: 1000 * sin(5°)
-				IsBallSpeedValid: true,
-				IsTotalSpinValid: true,
-				IsSpinAxisValid:  true,
-				IsBackspinValid:  true,
-				IsSidespinValid:  false,
-				validityBitmask:  "37",
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Short putt",
@@ -708,24 +510,8 @@ func TestParseShotClubMetrics(t *testing.T) {
 				"2C", "01", // Attack angle: 300 (3.00 degrees)
 				"90", "01", // Dynamic loft: 400 (4.00 degrees)
 			},
-			want: &ClubMetrics{
-				RawData: []string{
-					"00", "01", "02",
-					"ZZ", "00", // Invalid path angle
-					"C8", "00", // Face angle: 200 (2.00 degrees)
-					"2C", "01", // Attack angle: 300 (3.00 degrees)
-					"90", "01", // Dynamic loft: 400 (4.00 degrees)
-				},
-				PathAngle:          0,
-				FaceAngle:          2.0,
-				AttackAngle:        3.0,
-				DynamicLoftAngle:   4.0,
-				IsPathAngleValid:   false,
-				IsFaceAngleValid:   true,
-				IsAttackAngleValid: true,
-				IsDynamicLoftValid: true,
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for face angle",
@@ -736,24 +522,8 @@ func TestParseShotClubMetrics(t *testing.T) {
 				"2C", "01", // Attack angle: 300 (3.00 degrees)
 				"90", "01", // Dynamic loft: 400 (4.00 degrees)
 			},
-			want: &ClubMetrics{
-				RawData: []string{
-					"00", "01", "02",
-					"64", "00", // Path angle: 100 (1.00 degrees)
-					"ZZ", "00", // Invalid face angle
-					"2C", "01", // Attack angle: 300 (3.00 degrees)
-					"90", "01", // Dynamic loft: 400 (4.00 degrees)
-				},
-				PathAngle:          1.0,
-				FaceAngle:          0,
-				AttackAngle:        3.0,
-				DynamicLoftAngle:   4.0,
-				IsPathAngleValid:   true,
-				IsFaceAngleValid:   false,
-				IsAttackAngleValid: true,
-				IsDynamicLoftValid: true,
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for attack angle",
@@ -764,24 +534,8 @@ func TestParseShotClubMetrics(t *testing.T) {
 				"ZZ", "01", // Invalid attack angle
 				"90", "01", // Dynamic loft: 400 (4.00 degrees)
 			},
-			want: &ClubMetrics{
-				RawData: []string{
-					"00", "01", "02",
-					"64", "00", // Path angle: 100 (1.00 degrees)
-					"C8", "00", // Face angle: 200 (2.00 degrees)
-					"ZZ", "01", // Invalid attack angle
-					"90", "01", // Dynamic loft: 400 (4.00 degrees)
-				},
-				PathAngle:          1.0,
-				FaceAngle:          2.0,
-				AttackAngle:        0,
-				DynamicLoftAngle:   4.0,
-				IsPathAngleValid:   true,
-				IsFaceAngleValid:   true,
-				IsAttackAngleValid: false,
-				IsDynamicLoftValid: true,
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Invalid hex data for dynamic loft",
@@ -792,24 +546,8 @@ func TestParseShotClubMetrics(t *testing.T) {
 				"2C", "01", // Attack angle: 300 (3.00 degrees)
 				"ZZ", "01", // Invalid dynamic loft
 			},
-			want: &ClubMetrics{
-				RawData: []string{
-					"00", "01", "02",
-					"64", "00", // Path angle: 100 (1.00 degrees)
-					"C8", "00", // Face angle: 200 (2.00 degrees)
-					"2C", "01", // Attack angle: 300 (3.00 degrees)
-					"ZZ", "01", // Invalid dynamic loft
-				},
-				PathAngle:          1.0,
-				FaceAngle:          2.0,
-				AttackAngle:        3.0,
-				DynamicLoftAngle:   0,
-				IsPathAngleValid:   true,
-				IsFaceAngleValid:   true,
-				IsAttackAngleValid: true,
-				IsDynamicLoftValid: false,
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Sentinel path normalizes to zero and invalid",
@@ -968,3 +706,216 @@ func TestDetectDeviceType(t *testing.T) {
 		})
 	}
 }
+
+func toHexList(data []byte) []string {
+	bytesList := make([]string, len(data))
+	for i, b := range data {
+		bytesList[i] = hex.EncodeToString([]byte{b})
+	}
+	return bytesList
+}
+
+func TestProtocolRegistry_Decode(t *testing.T) {
+	registry := protocol.Default()
+
+	if _, _, err := registry.Decode([]byte{0x11, 0x99, 0x00}); !errors.Is(err, protocol.ErrUnknownMessage) {
+		t.Errorf("Expected ErrUnknownMessage, got %v", err)
+	}
+	if _, _, err := registry.Decode([]byte{0x11, 0x02, 0x37}); !errors.Is(err, protocol.ErrShortMessage) {
+		t.Errorf("Expected ErrShortMessage, got %v", err)
+	}
+
+	messageType, message, err := registry.Decode([]byte{0x11, 0x10, 0x01, 0x09})
+	if err != nil {
+		t.Fatalf("Expected OS version to decode, got %v", err)
+	}
+	version, ok := message.(protocol.OSVersion)
+	if messageType.Header != protocol.HeaderOSVersion || !ok || version.Major != 1 || version.Minor != 9 {
+		t.Errorf("Expected OS version 1.9, got %s %+v", messageType.Header, message)
+	}
+
+	if err := protocol.NewRegistry().Register(protocol.MessageType{Header: protocol.HeaderSensor}); err != nil {
+		t.Fatalf("Expected first registration to succeed, got %v", err)
+	}
+	if err := registry.Register(protocol.MessageType{Header: protocol.HeaderSensor, Name: "duplicate"}); err == nil {
+		t.Error("Expected registering a header twice to fail")
+	}
+}
+
+func FuzzProtocolDecode(f *testing.F) {
+	f.Add([]byte{0x11, 0x01, 0x00, 0x01, 0x01, 0x0a, 0, 0, 0, 0x14, 0, 0, 0, 0x1e, 0, 0, 0})
+	f.Add([]byte{0x11, 0x02, 0x37, 0xe8, 0x03, 0xc8, 0x00, 0x2c, 0x01, 0xe8, 0x03, 0xf4, 0x01, 0xd0, 0x07, 0xb8, 0x0b})
+	f.Add([]byte{0x11, 0x07, 0xff, 0x00, 0x80, 0x64, 0x00, 0x9c, 0xff, 0x10, 0x27, 0, 0, 0, 0, 0, 0, 0, 0})
+	f.Add([]byte{0x11, 0x10, 0x01, 0x06})
+	f.Add([]byte{0x11, 0x03, 0x00, 0x03})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, message, err := protocol.Default().Decode(data)
+		if err != nil {
+			return
+		}
+
+		// The string parsers must agree with the byte decoders
+		switch decoded := message.(type) {
+		case protocol.ShotBall:
+			metrics, err := ParseShotBallMetrics(toHexList(data))
+			if err != nil {
+				t.Fatalf("ParseShotBallMetrics failed on decodable data %x: %v", data, err)
+			}
+			if metrics.IsBallSpeedValid != decoded.BallSpeed.Valid || metrics.BallSpeedMPS != decoded.BallSpeed.Scaled(100.0) {
+				t.Fatalf("Ball speed mismatch for %x: %+v vs %+v", data, metrics, decoded.BallSpeed)
+			}
+		case protocol.Sensor:
+			sensor, err := ParseSensorData(toHexList(data))
+			if err != nil {
+				t.Fatalf("ParseSensorData failed on decodable data %x: %v", data, err)
+			}
+			if sensor.PositionX != decoded.X.Value || sensor.BallDetected != decoded.BallDetected {
+				t.Fatalf("Sensor mismatch for %x: %+v vs %+v", data, sensor, decoded)
+			}
+		}
+	})
+}
+
+func FuzzNotificationHandler(f *testing.F) {
+	f.Add(false, []byte{0x11, 0x02, 0x37, 0xe8, 0x03, 0xc8, 0x00, 0x2c, 0x01, 0xe8, 0x03, 0xf4, 0x01, 0xd0, 0x07, 0xb8, 0x0b})
+	f.Add(false, []byte{0x11, 0x04, 0x00, 0x01, 0x00, 0x10, 0x00})
+	f.Add(false, []byte{0x91, 0x50})
+	f.Add(false, []byte{0x11, 0x07, 0x00})
+	f.Add(true, []byte{0x11, 0x03, 0x00, 0x01, 0x02})
+	f.Add(true, []byte{0x11, 0x07, 0x4f, 0x64, 0x00, 0x9c, 0xff, 0x10, 0x27, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x80})
+	f.Add(true, []byte{0x11, 0x06, 0x00})
+	f.Add(false, append([]byte{0x11, 0x01}, make([]byte, protocol.MaxMessageLength)...))
+
+	home := NewStateManager()
+	homeLM := NewLaunchMonitor(home, NewBluetoothManager(home))
+	omni := NewStateManager()
+	omni.SetDeviceType(DeviceTypeOmni)
+	omniLM := NewLaunchMonitor(omni, NewBluetoothManager(omni))
+
+	f.Fuzz(func(t *testing.T, isOmni bool, data []byte) {
+		// Must not panic on any input, from either kind of device
+		lm := homeLM
+		if isOmni {
+			lm = omniLM
+		}
+		lm.NotificationHandler(NotificationCharUUID, data)
+	})
+}
+
+// FuzzParseNotifications checks that the parsers return either metrics or a
+// ParseError for any notification, including ones with bytes that are not hex
+func FuzzParseNotifications(f *testing.F) {
+	f.Add([]byte{0x11, 0x01, 0x00, 0x01, 0x01, 0x0a, 0, 0, 0, 0x14, 0, 0, 0, 0x1e, 0, 0, 0}, "")
+	f.Add([]byte{0x11, 0x02, 0x37, 0xe8, 0x03, 0xc8, 0x00, 0x2c, 0x01, 0xe8, 0x03, 0xf4, 0x01, 0xd0, 0x07, 0xb8, 0x0b}, "ZZ")
+	f.Add([]byte{0x11, 0x07, 0x4f, 0x64, 0x00, 0x9c, 0xff, 0x10, 0x27, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x80}, "0")
+	f.Add([]byte{0x11, 0x04, 0x00, 0x01, 0x00, 0x10, 0x00}, "0a")
+
+	alignment := DefaultAlignmentFormat()
+	parsers := []struct {
+		name      string
+		minLength int
+		parse     func([]string) (bool, error)
+	}{
+		{"sensor", protocol.SensorLength, func(b []string) (bool, error) { m, err := ParseSensorData(b); return m != nil, err }},
+		{"ball", protocol.ShotBallLength, func(b []string) (bool, error) { m, err := ParseShotBallMetrics(b); return m != nil, err }},
+		{"club", protocol.ShotClubLength, func(b []string) (bool, error) { m, err := ParseShotClubMetrics(b); return m != nil, err }},
+		{"Omni club", protocol.OmniShotClubLength, func(b []string) (bool, error) { m, err := ParseOmniShotClubMetrics(b); return m != nil, err }},
+		{"alignment", alignment.Offset + 2, func(b []string) (bool, error) { m, err := ParseAlignmentData(b, alignment); return m != nil, err }},
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, garbled string) {
+		bytesList := toHexList(data)
+		if garbled != "" && len(bytesList) > 0 {
+			bytesList[len(garbled)%len(bytesList)] = garbled
+		}
+		allHex := true
+		for _, byteHex := range bytesList {
+			if decoded, err := hex.DecodeString(byteHex); err != nil || len(decoded) != 1 {
+				allHex = false
+			}
+		}
+
+		for _, parser := range parsers {
+			parsed, err := parser.parse(bytesList)
+			if parsed == (err != nil) {
+				t.Fatalf("%s: got metrics %v with error %v for %q", parser.name, parsed, err, bytesList)
+			}
+			var parseErr *ParseError
+			if err != nil && !errors.As(err, &parseErr) {
+				t.Fatalf("%s: error %v for %q is not a ParseError", parser.name, err, bytesList)
+			}
+			usable := allHex && len(bytesList) >= parser.minLength && len(bytesList) <= protocol.MaxMessageLength
+			if usable != parsed {
+				t.Fatalf("%s: parsed = %v for %q, want %v (%v)", parser.name, parsed, bytesList, usable, err)
+			}
+		}
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	ball := toHexList([]byte{0x11, 0x02, 0x37, 0xe8, 0x03, 0xc8, 0x00, 0x2c, 0x01, 0xe8, 0x03, 0xf4, 0x01, 0xd0, 0x07, 0xb8, 0x0b})
+	garbled := append([]string(nil), ball...)
+	garbled[5] = "ZZ"
+
+	tests := []struct {
+		name       string
+		bytes      []string
+		want       error
+		wantOffset int
+	}{
+		{"Short", ball[:10], protocol.ErrShortMessage, -1},
+		{"Oversized", append(ball, toHexList(make([]byte, protocol.MaxMessageLength))...), protocol.ErrLongMessage, -1},
+		{"Invalid byte", garbled, ErrInvalidByte, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseShotBallMetrics(tt.bytes)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ParseShotBallMetrics() error = %v, want %v", err, tt.want)
+			}
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || parseErr.Offset != tt.wantOffset {
+				t.Errorf("ParseShotBallMetrics() error = %#v, want offset %d", err, tt.wantOffset)
+			}
+		})
+	}
+}
+
+// TestParseShotBallMetrics_RoundTrip checks that every measured value the
+// device can send decodes as sent
+func TestParseShotBallMetrics_RoundTrip(t *testing.T) {
+	property := func(speed, vertical, horizontal, totalSpin, axis, backspin, sidespin int16) bool {
+		values := []*int16{&speed, &vertical, &horizontal, &totalSpin, &axis, &backspin, &sidespin}
+		data := []byte{0x11, 0x02, 0x37}
+		for _, v := range values {
+			// -32768 marks a missing value
+			if *v == math.MinInt16 {
+				*v++
+			}
+			data = appendInt16LE(data, *v)
+		}
+
+		metrics, err := ParseShotBallMetrics(toHexList(data))
+		if err != nil {
+			return false
+		}
+		wantTotalSpin := totalSpin
+		if backspin < 0 {
+			wantTotalSpin = -totalSpin
+		}
+		return metrics.IsBallSpeedValid && metrics.IsTotalSpinValid && metrics.IsSpinAxisValid &&
+			metrics.IsBackspinValid && metrics.IsSidespinValid &&
+			metrics.BallSpeedMPS == float64(speed)/100 &&
+			metrics.VerticalAngle == float64(vertical)/100 &&
+			metrics.HorizontalAngle == float64(horizontal)/100 &&
+			metrics.TotalspinRPM == wantTotalSpin &&
+			metrics.SpinAxis == float64(axis)/100 &&
+			metrics.BackspinRPM == backspin &&
+			metrics.SidespinRPM == sidespin
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
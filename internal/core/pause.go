@@ -0,0 +1,104 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ErrSessionPaused is returned when ball detection is armed or a shot is
+// submitted while the session is paused
+var ErrSessionPaused = errors.New("session is paused")
+
+// Pause disarms ball detection and drops any shot that still arrives, so
+// practice swings while the player is away don't reach the simulators. The
+// heartbeat keeps running so the device stays connected. Nothing re-arms
+// detection until Resume.
+func (lm *LaunchMonitor) Pause() error {
+	lm.pauseMu.Lock()
+	if lm.paused {
+		lm.pauseMu.Unlock()
+		return nil
+	}
+	lm.paused = true
+	lm.pauseMu.Unlock()
+
+	connected := lm.bluetoothClient != nil && lm.bluetoothClient.IsConnected()
+
+	// Standby keeps its own record of whether to re-arm when woken
+	var err error
+	resume := false
+	if !lm.InStandby() {
+		lm.detectStateMu.Lock()
+		resume = lm.detectModeActive || lm.resumeDetection
+		lm.resumeDetection = false
+		lm.detectStateMu.Unlock()
+		resume = resume || lm.IsIdle()
+
+		if connected {
+			err = lm.DeactivateBallDetection()
+		} else {
+			lm.setIdle(false)
+		}
+	}
+
+	lm.pauseMu.Lock()
+	lm.resumeAfterPause = resume
+	lm.pauseMu.Unlock()
+
+	log.Println("LaunchMonitor: Session paused")
+	lm.stateManager.SetSessionPaused(true)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate ball detection for pause: %w", err)
+	}
+	return nil
+}
+
+// IsPaused reports whether the session is paused
+func (lm *LaunchMonitor) IsPaused() bool {
+	lm.pauseMu.Lock()
+	defer lm.pauseMu.Unlock()
+	return lm.paused
+}
+
+// Resume ends a pause. Ball detection is re-armed if it was armed when the
+// session was paused, which also re-sends the club and handedness; otherwise
+// only the club selection is sent.
+func (lm *LaunchMonitor) Resume() error {
+	lm.pauseMu.Lock()
+	if !lm.paused {
+		lm.pauseMu.Unlock()
+		return nil
+	}
+	lm.paused = false
+	resume := lm.resumeAfterPause
+	lm.resumeAfterPause = false
+	lm.pauseMu.Unlock()
+
+	log.Println("LaunchMonitor: Session resumed")
+	lm.stateManager.SetSessionPaused(false)
+	lm.recordActivity()
+
+	if lm.InStandby() || lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
+		// Detection is re-armed on waking or on the next connection
+		if resume {
+			lm.detectStateMu.Lock()
+			lm.resumeDetection = true
+			lm.detectStateMu.Unlock()
+		}
+		return nil
+	}
+	// Set if the device was in standby when paused and woken since
+	lm.detectStateMu.Lock()
+	resume = resume || lm.resumeDetection
+	lm.resumeDetection = false
+	lm.detectStateMu.Unlock()
+
+	if resume {
+		return lm.ActivateBallDetection()
+	}
+	if err := lm.sendClubSelection(); err != nil {
+		return fmt.Errorf("failed to restore club selection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestPause_DropsShotsUntilResumed(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	iron := ClubIron7
+	sm.SetClub(&iron)
+	left := LeftHanded
+	sm.SetHandedness(&left)
+
+	if err := lm.ActivateBallDetection(); err != nil {
+		t.Fatalf("ActivateBallDetection() error = %v", err)
+	}
+	defer lm.Shutdown()
+	writes := len(mockClient.GetWriteHistory())
+
+	if err := lm.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if !lm.IsPaused() || !sm.GetSessionPaused() {
+		t.Fatal("Expected the session to be paused")
+	}
+	history := mockClient.GetWriteHistory()
+	if len(history) != writes+1 || history[len(history)-1].Data[1] != 0x81 || history[len(history)-1].Data[3] != 0x00 {
+		t.Fatalf("Expected a deactivate detect command, got %d new writes", len(history)-writes)
+	}
+
+	// A practice swing the device still reports is dropped
+	lm.NotificationHandler("", []byte{
+		0x11, 0x02, 0x37,
+		0x32, 0x00,
+		0x14, 0x00,
+		0x0A, 0x00,
+		0x28, 0x00,
+		0x1E, 0x00,
+		0x32, 0x00,
+		0x1E, 0x00,
+	})
+	if sm.GetLastBallMetrics() != nil {
+		t.Error("Expected a shot during the pause to be dropped")
+	}
+	if err := lm.SubmitExternalShot("gspro-connect:test", &BallMetrics{BallSpeedMPS: 60}, nil); !errors.Is(err, ErrSessionPaused) {
+		t.Errorf("SubmitExternalShot() error = %v, want ErrSessionPaused", err)
+	}
+
+	// Simulators asking for the next shot don't re-arm detection
+	if err := lm.ActivateBallDetection(); !errors.Is(err, ErrSessionPaused) {
+		t.Errorf("ActivateBallDetection() error = %v, want ErrSessionPaused", err)
+	}
+	lm.restoreDeviceState()
+	if len(mockClient.GetWriteHistory()) != writes+1 {
+		t.Error("Expected no commands on reconnect during a pause")
+	}
+
+	writes = len(mockClient.GetWriteHistory())
+	if err := lm.Resume(); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if lm.IsPaused() || sm.GetSessionPaused() {
+		t.Error("Expected resuming to end the pause")
+	}
+	if !lm.detectModeActive {
+		t.Error("Expected resuming to re-arm ball detection")
+	}
+	history = mockClient.GetWriteHistory()
+	if len(history) != writes+2 {
+		t.Fatalf("Expected club and detect commands on resume, got %d writes", len(history)-writes)
+	}
+	if want := ClubCommand(0, iron, left)[6:]; hex.EncodeToString(history[writes].Data[3:]) != want {
+		t.Errorf("Expected the club command to restore the 7 iron left handed, got %x", history[writes].Data)
+	}
+}
+
+func TestPause_ResumeLeavesInactiveDetectionOff(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	if err := lm.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	defer lm.Shutdown()
+	if err := lm.Resume(); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if lm.detectModeActive {
+		t.Error("Expected detection that was off before the pause to stay off")
+	}
+}
@@ -0,0 +1,75 @@
+package core
+
+// Placement hints. Directions are in mat coordinates, where +Y points down the
+// target line and +X is to the right when facing the target.
+const (
+	PlacementHintLeft    = "left"
+	PlacementHintRight   = "right"
+	PlacementHintForward = "forward"
+	PlacementHintBack    = "back"
+)
+
+// PlacementZone is the rectangle, in mat coordinates, where the device detects
+// the ball most reliably
+type PlacementZone struct {
+	MinX int32 `json:"minX"`
+	MaxX int32 `json:"maxX"`
+	MinY int32 `json:"minY"`
+	MaxY int32 `json:"maxY"`
+}
+
+// DefaultPlacementZone returns a zone centred on the device's sweet spot
+func DefaultPlacementZone() PlacementZone {
+	return PlacementZone{MinX: -600, MaxX: 600, MinY: -600, MaxY: 600}
+}
+
+// Valid reports whether the zone has a non-empty area
+func (z PlacementZone) Valid() bool {
+	return z.MinX < z.MaxX && z.MinY < z.MaxY
+}
+
+// PlacementGuidance tells the user whether and where to move the ball
+type PlacementGuidance struct {
+	BallDetected bool     `json:"ballDetected"`
+	InZone       bool     `json:"inZone"`
+	Hints        []string `json:"hints"`
+}
+
+// Equal reports whether two guidance values would show the same hint
+func (g PlacementGuidance) Equal(other PlacementGuidance) bool {
+	if g.BallDetected != other.BallDetected || g.InZone != other.InZone || len(g.Hints) != len(other.Hints) {
+		return false
+	}
+	for i := range g.Hints {
+		if g.Hints[i] != other.Hints[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EvaluatePlacement compares a ball position against the zone. A nil position
+// means no ball is detected.
+func EvaluatePlacement(zone PlacementZone, position *BallPosition) PlacementGuidance {
+	guidance := PlacementGuidance{Hints: []string{}}
+	if position == nil {
+		return guidance
+	}
+	guidance.BallDetected = true
+
+	switch {
+	case position.X < zone.MinX:
+		guidance.Hints = append(guidance.Hints, PlacementHintRight)
+	case position.X > zone.MaxX:
+		guidance.Hints = append(guidance.Hints, PlacementHintLeft)
+	}
+	switch {
+	case position.Y < zone.MinY:
+		guidance.Hints = append(guidance.Hints, PlacementHintForward)
+	case position.Y > zone.MaxY:
+		guidance.Hints = append(guidance.Hints, PlacementHintBack)
+	}
+
+	guidance.InZone = len(guidance.Hints) == 0
+	return guidance
+}
@@ -0,0 +1,103 @@
+package placement
+
+import (
+	"sync"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// Manager turns ball position updates into placement hints
+type Manager struct {
+	stateManager *core.StateManager
+	zone         core.PlacementZone
+	last         core.PlacementGuidance
+	listeners    []*guidanceListener
+	mu           sync.Mutex
+}
+
+// guidanceListener wraps a listener so it can be found again to remove it
+type guidanceListener struct {
+	notify func(core.PlacementGuidance)
+}
+
+// New creates a placement manager for the ball positions on stateManager
+func New(stateManager *core.StateManager) *Manager {
+	m := &Manager{
+		stateManager: stateManager,
+		zone:         core.DefaultPlacementZone(),
+		last:         core.PlacementGuidance{Hints: []string{}},
+	}
+	m.registerStateListeners()
+	return m
+}
+
+// SetZone sets the detection zone and re-evaluates the current position
+func (m *Manager) SetZone(zone core.PlacementZone) {
+	if !zone.Valid() {
+		return
+	}
+
+	m.mu.Lock()
+	m.zone = zone
+	m.mu.Unlock()
+
+	m.update()
+}
+
+// Zone returns the detection zone
+func (m *Manager) Zone() core.PlacementZone {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.zone
+}
+
+// Guidance returns the latest placement guidance
+func (m *Manager) Guidance() core.PlacementGuidance {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
+
+// OnGuidance registers a listener notified when the placement hint changes.
+// The returned func removes it.
+func (m *Manager) OnGuidance(listener func(core.PlacementGuidance)) func() {
+	l := &guidanceListener{notify: listener}
+	m.mu.Lock()
+	m.listeners = append(m.listeners, l)
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i, registered := range m.listeners {
+			if registered == l {
+				m.listeners = append(m.listeners[:i:i], m.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// update evaluates the current ball position and notifies listeners if the
+// guidance changed
+func (m *Manager) update() {
+	var position *core.BallPosition
+	if m.stateManager.GetBallDetected() {
+		position = m.stateManager.GetBallPosition()
+	}
+
+	m.mu.Lock()
+	guidance := core.EvaluatePlacement(m.zone, position)
+	if guidance.Equal(m.last) {
+		m.mu.Unlock()
+		return
+	}
+	m.last = guidance
+	listeners := make([]*guidanceListener, len(m.listeners))
+	copy(listeners, m.listeners)
+	m.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener.notify(guidance)
+	}
+}
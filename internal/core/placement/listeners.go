@@ -0,0 +1,14 @@
+package placement
+
+import "github.com/brentyates/squaregolf-connector/internal/core"
+
+// registerStateListeners registers callbacks for state changes
+func (m *Manager) registerStateListeners() {
+	m.stateManager.RegisterBallPositionCallback(func(oldValue, newValue *core.BallPosition) {
+		m.update()
+	})
+
+	m.stateManager.RegisterBallDetectedCallback(func(oldValue, newValue bool) {
+		m.update()
+	})
+}
@@ -0,0 +1,40 @@
+package core
+
+import "testing"
+
+func TestEvaluatePlacement(t *testing.T) {
+	zone := PlacementZone{MinX: -100, MaxX: 100, MinY: -50, MaxY: 50}
+
+	tests := []struct {
+		name     string
+		position *BallPosition
+		detected bool
+		inZone   bool
+		hints    []string
+	}{
+		{"no ball", nil, false, false, []string{}},
+		{"centred", &BallPosition{X: 0, Y: 0}, true, true, []string{}},
+		{"on the edge", &BallPosition{X: 100, Y: -50}, true, true, []string{}},
+		{"too far left", &BallPosition{X: -150, Y: 0}, true, false, []string{PlacementHintRight}},
+		{"too far right", &BallPosition{X: 150, Y: 0}, true, false, []string{PlacementHintLeft}},
+		{"too far back", &BallPosition{X: 0, Y: -80}, true, false, []string{PlacementHintForward}},
+		{"corner", &BallPosition{X: 150, Y: 80}, true, false, []string{PlacementHintLeft, PlacementHintBack}},
+	}
+
+	for _, tt := range tests {
+		got := EvaluatePlacement(zone, tt.position)
+		want := PlacementGuidance{BallDetected: tt.detected, InZone: tt.inZone, Hints: tt.hints}
+		if !got.Equal(want) {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, want, got)
+		}
+	}
+}
+
+func TestPlacementZoneValid(t *testing.T) {
+	if !DefaultPlacementZone().Valid() {
+		t.Error("Expected default zone to be valid")
+	}
+	if (PlacementZone{MinX: 10, MaxX: 10, MinY: 0, MaxY: 5}).Valid() {
+		t.Error("Expected zero-width zone to be invalid")
+	}
+}
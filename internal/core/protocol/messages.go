@@ -0,0 +1,180 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Minimum message lengths, header included
+const (
+	SensorLength       = 17
+	ShotBallLength     = 17
+	ShotClubLength     = 11
+	OmniShotClubLength = 19
+	OSVersionLength    = 4
+)
+
+// MaxMessageLength is the longest notification accepted. BLE attribute values
+// are at most 512 bytes, so anything longer did not come from the device.
+const MaxMessageLength = 512
+
+// missingValue marks an int16 field the device could not measure
+const missingValue = -32768
+
+// Metric is a little-endian int16 field. The device marks a missing value
+// with -32768, which decodes as Valid false and Value 0.
+type Metric struct {
+	Offset int
+	Value  int16
+	Valid  bool
+}
+
+// Scaled returns the value divided by scale, e.g. 100 for hundredths
+func (m Metric) Scaled(scale float64) float64 {
+	return float64(m.Value) / scale
+}
+
+// Int32Field is a little-endian int32 field
+type Int32Field struct {
+	Offset int
+	Value  int32
+}
+
+func readMetric(data []byte, offset int) Metric {
+	value := int16(binary.LittleEndian.Uint16(data[offset : offset+2]))
+	if value == missingValue {
+		return Metric{Offset: offset}
+	}
+	return Metric{Offset: offset, Value: value, Valid: true}
+}
+
+func readInt32(data []byte, offset int) Int32Field {
+	return Int32Field{Offset: offset, Value: int32(binary.LittleEndian.Uint32(data[offset : offset+4]))}
+}
+
+func requireLength(data []byte, length int, name string) error {
+	if len(data) < length {
+		return fmt.Errorf("%w: %s needs %d bytes, got %d", ErrShortMessage, name, length, len(data))
+	}
+	if len(data) > MaxMessageLength {
+		return fmt.Errorf("%w: %s has %d bytes, at most %d", ErrLongMessage, name, len(data), MaxMessageLength)
+	}
+	return nil
+}
+
+// Sensor is a ball sensor update (11 01)
+type Sensor struct {
+	BallReady    bool
+	BallDetected bool
+	X, Y, Z      Int32Field
+}
+
+// DecodeSensor decodes a sensor update
+func DecodeSensor(data []byte) (Sensor, error) {
+	if err := requireLength(data, SensorLength, "sensor"); err != nil {
+		return Sensor{}, err
+	}
+	return Sensor{
+		BallReady:    data[3] == 0x01 || data[3] == 0x02,
+		BallDetected: data[4] == 0x01,
+		X:            readInt32(data, 5),
+		Y:            readInt32(data, 9),
+		Z:            readInt32(data, 13),
+	}, nil
+}
+
+// ShotBall is the ball half of a shot (11 02). Speed, angles and spin axis are
+// in hundredths; spins are in rpm.
+type ShotBall struct {
+	Validity        byte // Omni per-field validity bitmask
+	BallSpeed       Metric
+	VerticalAngle   Metric
+	HorizontalAngle Metric
+	TotalSpin       Metric
+	SpinAxis        Metric
+	Backspin        Metric
+	Sidespin        Metric
+}
+
+// DecodeShotBall decodes ball metrics
+func DecodeShotBall(data []byte) (ShotBall, error) {
+	if err := requireLength(data, ShotBallLength, "shot ball"); err != nil {
+		return ShotBall{}, err
+	}
+	return ShotBall{
+		Validity:        data[2],
+		BallSpeed:       readMetric(data, 3),
+		VerticalAngle:   readMetric(data, 5),
+		HorizontalAngle: readMetric(data, 7),
+		TotalSpin:       readMetric(data, 9),
+		SpinAxis:        readMetric(data, 11),
+		Backspin:        readMetric(data, 13),
+		Sidespin:        readMetric(data, 15),
+	}, nil
+}
+
+// ShotClub is the club half of a shot (11 07), in hundredths. The Omni sends
+// a longer message with impact location, club speed and smash factor; those
+// fields are only set when Extended is true.
+type ShotClub struct {
+	Validity         byte // Omni per-field validity bitmask
+	Path             Metric
+	Face             Metric
+	Attack           Metric
+	DynamicLoft      Metric
+	Extended         bool
+	ImpactHorizontal Metric
+	ImpactVertical   Metric
+	ClubSpeed        Metric
+	SmashFactor      Metric
+}
+
+// DecodeShotClub decodes club metrics
+func DecodeShotClub(data []byte) (ShotClub, error) {
+	if err := requireLength(data, ShotClubLength, "shot club"); err != nil {
+		return ShotClub{}, err
+	}
+	club := ShotClub{
+		Validity:    data[2],
+		Path:        readMetric(data, 3),
+		Face:        readMetric(data, 5),
+		Attack:      readMetric(data, 7),
+		DynamicLoft: readMetric(data, 9),
+	}
+	if len(data) >= OmniShotClubLength {
+		club.Extended = true
+		club.ImpactHorizontal = readMetric(data, 11)
+		club.ImpactVertical = readMetric(data, 13)
+		club.ClubSpeed = readMetric(data, 15)
+		club.SmashFactor = readMetric(data, 17)
+	}
+	return club, nil
+}
+
+// OSVersion is the firmware version response (11 10)
+type OSVersion struct {
+	Major int
+	Minor int
+}
+
+// DecodeOSVersion decodes a firmware version response
+func DecodeOSVersion(data []byte) (OSVersion, error) {
+	if err := requireLength(data, OSVersionLength, "OS version"); err != nil {
+		return OSVersion{}, err
+	}
+	return OSVersion{Major: int(data[2]), Minor: int(data[3])}, nil
+}
+
+// Raw is a message whose layout depends on context the decoder does not have,
+// such as the device type for status or the alignment format
+type Raw struct {
+	Payload []byte // bytes after the header
+}
+
+// DecodeRaw returns the payload after the header
+func DecodeRaw(data []byte) (Raw, error) {
+	if err := requireLength(data, 2, "message"); err != nil {
+		return Raw{}, err
+	}
+	return Raw{Payload: data[2:]}, nil
+}
@@ -0,0 +1,156 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Header identifies a notification by its first two bytes, e.g. 0x1102 for
+// ball metrics
+type Header uint16
+
+// Known notification headers
+const (
+	HeaderSensor    Header = 0x1101
+	HeaderShotBall  Header = 0x1102
+	HeaderStatus    Header = 0x1103
+	HeaderAlignment Header = 0x1104
+	HeaderCharge    Header = 0x1106
+	HeaderShotClub  Header = 0x1107
+	HeaderOSVersion Header = 0x1110
+)
+
+// BatteryMessageType is the first byte of a battery message on the
+// notification characteristic. Its second byte is not part of the type.
+const BatteryMessageType = 0x91
+
+// String formats the header the way the protocol is usually written, "11 02"
+func (h Header) String() string {
+	return fmt.Sprintf("%02x %02x", byte(h>>8), byte(h))
+}
+
+// HeaderOf returns the header of a notification
+func HeaderOf(data []byte) (Header, bool) {
+	if len(data) < 2 {
+		return 0, false
+	}
+	return Header(data[0])<<8 | Header(data[1]), true
+}
+
+// Errors returned by Decode
+var (
+	ErrUnknownMessage = errors.New("unknown message type")
+	ErrShortMessage   = errors.New("message too short")
+	ErrLongMessage    = errors.New("message too long")
+)
+
+// Decoder turns a notification into a typed message. data always holds at
+// least the message type's MinLength bytes.
+type Decoder func(data []byte) (any, error)
+
+// MessageType describes one kind of notification
+type MessageType struct {
+	Header    Header
+	Name      string
+	MinLength int
+	Decode    Decoder
+}
+
+// Registry maps headers to message types
+type Registry struct {
+	mu    sync.RWMutex
+	types map[Header]MessageType
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[Header]MessageType)}
+}
+
+// Register adds a message type. Registering a header twice is an error.
+func (r *Registry) Register(messageType MessageType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.types[messageType.Header]; ok {
+		return fmt.Errorf("header %s already registered as %s", messageType.Header, existing.Name)
+	}
+	r.types[messageType.Header] = messageType
+	return nil
+}
+
+// Lookup returns the message type for a notification
+func (r *Registry) Lookup(data []byte) (MessageType, bool) {
+	header, ok := HeaderOf(data)
+	if !ok {
+		return MessageType{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	messageType, ok := r.types[header]
+	return messageType, ok
+}
+
+// Types returns the registered message types ordered by header
+func (r *Registry) Types() []MessageType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]MessageType, 0, len(r.types))
+	for _, messageType := range r.types {
+		types = append(types, messageType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Header < types[j].Header })
+	return types
+}
+
+// Decode looks up the notification's message type and decodes it
+func (r *Registry) Decode(data []byte) (MessageType, any, error) {
+	messageType, ok := r.Lookup(data)
+	if !ok {
+		return MessageType{}, nil, ErrUnknownMessage
+	}
+	if len(data) < messageType.MinLength {
+		return messageType, nil, fmt.Errorf("%w: %s needs %d bytes, got %d", ErrShortMessage, messageType.Name, messageType.MinLength, len(data))
+	}
+	if len(data) > MaxMessageLength {
+		return messageType, nil, fmt.Errorf("%w: %s has %d bytes, at most %d", ErrLongMessage, messageType.Name, len(data), MaxMessageLength)
+	}
+	if messageType.Decode == nil {
+		return messageType, nil, nil
+	}
+	message, err := messageType.Decode(data)
+	return messageType, message, err
+}
+
+var (
+	defaultRegistry     *Registry
+	defaultRegistryOnce sync.Once
+)
+
+// Default returns a registry holding every known message type
+func Default() *Registry {
+	defaultRegistryOnce.Do(func() {
+		defaultRegistry = NewRegistry()
+		for _, messageType := range []MessageType{
+			{Header: HeaderSensor, Name: "sensor", MinLength: SensorLength, Decode: decodeAny(DecodeSensor)},
+			{Header: HeaderShotBall, Name: "shot ball", MinLength: ShotBallLength, Decode: decodeAny(DecodeShotBall)},
+			{Header: HeaderStatus, Name: "status", MinLength: 3, Decode: decodeAny(DecodeRaw)},
+			{Header: HeaderAlignment, Name: "alignment", MinLength: 2, Decode: decodeAny(DecodeRaw)},
+			{Header: HeaderCharge, Name: "charge", MinLength: 3, Decode: decodeAny(DecodeRaw)},
+			{Header: HeaderShotClub, Name: "shot club", MinLength: ShotClubLength, Decode: decodeAny(DecodeShotClub)},
+			{Header: HeaderOSVersion, Name: "OS version", MinLength: OSVersionLength, Decode: decodeAny(DecodeOSVersion)},
+		} {
+			if err := defaultRegistry.Register(messageType); err != nil {
+				panic(err)
+			}
+		}
+	})
+	return defaultRegistry
+}
+
+func decodeAny[T any](decode func([]byte) (T, error)) Decoder {
+	return func(data []byte) (any, error) {
+		return decode(data)
+	}
+}
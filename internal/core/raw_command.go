@@ -0,0 +1,97 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/brentyates/squaregolf-connector/internal/core/protocol"
+)
+
+// ErrInvalidRawCommand is returned for a raw command that isn't a whole
+// number of hex bytes
+var ErrInvalidRawCommand = errors.New("command must be hex bytes")
+
+// NotificationWatcher is called with every notification the device sends,
+// before it is handled
+type NotificationWatcher func(uuid string, data []byte)
+
+// notificationWatchers holds the watchers registered with WatchNotifications
+type notificationWatchers struct {
+	mu       sync.Mutex
+	nextID   int
+	watchers map[int]NotificationWatcher
+}
+
+func (w *notificationWatchers) notify(uuid string, data []byte) {
+	w.mu.Lock()
+	if len(w.watchers) == 0 {
+		w.mu.Unlock()
+		return
+	}
+	watchers := make([]NotificationWatcher, 0, len(w.watchers))
+	for _, watcher := range w.watchers {
+		watchers = append(watchers, watcher)
+	}
+	w.mu.Unlock()
+
+	for _, watcher := range watchers {
+		watcher(uuid, append([]byte(nil), data...))
+	}
+}
+
+// WatchNotifications calls watcher with every notification until the
+// returned stop function is called. Watchers are called on the notification
+// path, so they must not block.
+func (lm *LaunchMonitor) WatchNotifications(watcher NotificationWatcher) (stop func()) {
+	w := &lm.notificationWatchers
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers == nil {
+		w.watchers = make(map[int]NotificationWatcher)
+	}
+	id := w.nextID
+	w.nextID++
+	w.watchers[id] = watcher
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.watchers, id)
+	}
+}
+
+// ParseRawCommand turns a command typed as hex, with or without spaces
+// between the bytes, into the form SendRawCommand takes
+func ParseRawCommand(command string) (string, error) {
+	command = strings.ToLower(strings.Join(strings.Fields(command), ""))
+	if command == "" || len(command)%2 != 0 {
+		return "", ErrInvalidRawCommand
+	}
+	if _, err := hex.DecodeString(command); err != nil {
+		return "", ErrInvalidRawCommand
+	}
+	if len(command)/2 > protocol.MaxMessageLength {
+		return "", fmt.Errorf("command is longer than %d bytes", protocol.MaxMessageLength)
+	}
+	return command, nil
+}
+
+// SendRawCommand writes a command exactly as given to the device, through the
+// same queue as the connector's own commands. It is meant for working out
+// undocumented parts of the protocol; nothing about the connector's state is
+// changed by it.
+func (lm *LaunchMonitor) SendRawCommand(command string) error {
+	command, err := ParseRawCommand(command)
+	if err != nil {
+		return err
+	}
+	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
+		return fmt.Errorf("not connected to device")
+	}
+	log.Printf("LaunchMonitor: Sending raw command %s", command)
+	return lm.SendCommand(command)
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRawCommand(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   bool
+	}{
+		{"1186 01 00 00 00 00 00 00", "118601000000000000", false},
+		{"  11AB  ", "11ab", false},
+		{"118", "", true},
+		{"11zz", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRawCommand(tt.input)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("ParseRawCommand(%q) = %q, %v; want %q, error %v", tt.input, got, err, tt.want, tt.err)
+		}
+		if tt.err && !errors.Is(err, ErrInvalidRawCommand) {
+			t.Errorf("ParseRawCommand(%q) error = %v, want ErrInvalidRawCommand", tt.input, err)
+		}
+	}
+}
+
+func TestSendRawCommand_WatchersSeeReplies(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	defer lm.Shutdown()
+
+	var seen [][]byte
+	stop := lm.WatchNotifications(func(uuid string, data []byte) {
+		seen = append(seen, data)
+	})
+
+	if err := lm.SendRawCommand("1186 01 00 00 00 00 00 00"); err != nil {
+		t.Fatalf("SendRawCommand() error = %v", err)
+	}
+	history := mockClient.GetWriteHistory()
+	if len(history) != 1 || history[0].UUID != CommandCharUUID || history[0].Data[1] != 0x86 {
+		t.Fatalf("Expected the raw command to be written as given, got %+v", history)
+	}
+
+	// Unknown message types reach the watchers too
+	lm.NotificationHandler(NotificationCharUUID, []byte{0x11, 0x42, 0x01})
+	stop()
+	lm.NotificationHandler(NotificationCharUUID, []byte{0x11, 0x42, 0x02})
+	if len(seen) != 1 || seen[0][2] != 0x01 {
+		t.Errorf("Expected only the notification before stop, got %x", seen)
+	}
+}
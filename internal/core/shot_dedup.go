@@ -0,0 +1,45 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// shotRepeatWindow is how long the device may resend a shot's ball metrics.
+// Identical metrics after this are a new shot that happened to read the same.
+const shotRepeatWindow = 5 * time.Second
+
+// shotDeduplicator tells a resent ball metrics notification from a new shot.
+// Matching the raw bytes alone drops the second of two identical reads, such
+// as two identical putts, so a repeat must also arrive within
+// shotRepeatWindow and before detection is armed again. Detection is armed
+// for every shot, so each arming starts a new sequence.
+type shotDeduplicator struct {
+	mu          sync.Mutex
+	armSequence int
+	lastRaw     string
+	lastAt      time.Time
+	lastArm     int
+}
+
+// armed starts a new detection sequence; the next shot is new whatever it
+// reads
+func (d *shotDeduplicator) armed() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.armSequence++
+}
+
+// repeat reports whether a shot's raw data is the device resending the last
+// shot, and records it as the last shot if not
+func (d *shotDeduplicator) repeat(rawData string, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if rawData == d.lastRaw && d.armSequence == d.lastArm && now.Sub(d.lastAt) < shotRepeatWindow {
+		return true
+	}
+	d.lastRaw = rawData
+	d.lastAt = now
+	d.lastArm = d.armSequence
+	return false
+}
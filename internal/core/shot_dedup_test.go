@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHandleShotBallMetrics_RepeatsAndIdenticalShots(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	clock := NewFakeClock(time.Unix(0, 0))
+	lm.SetClock(clock)
+
+	putt := []byte{
+		0x11, 0x02, 0x37,
+		0xC8, 0x00, // Ball speed (200 = 2 m/s)
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+	}
+	shots := func() int {
+		count := 0
+		for _, write := range mockClient.GetWriteHistory() {
+			if write.Data[1] == 0x87 {
+				count++
+			}
+		}
+		return count
+	}
+
+	lm.NotificationHandler("", putt)
+	lm.NotificationHandler("", putt)
+	if got := shots(); got != 1 {
+		t.Fatalf("Expected the resent notification to be dropped, got %d shots", got)
+	}
+
+	// Detection is armed for the next shot, which reads the same
+	lm.shotDedup.armed()
+	clock.Advance(time.Second)
+	lm.NotificationHandler("", putt)
+	if got := shots(); got != 2 {
+		t.Fatalf("Expected an identical putt after re-arming to be a new shot, got %d shots", got)
+	}
+
+	// Without re-arming, identical metrics long after are still a new shot
+	clock.Advance(shotRepeatWindow)
+	lm.NotificationHandler("", putt)
+	if got := shots(); got != 3 {
+		t.Errorf("Expected an identical putt after the repeat window to be a new shot, got %d shots", got)
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import "math"
+
+// deriveEfficiency fills in how well the swing turned into ball speed and
+// launch:
+//
+//   - the smash factor, ball speed over club speed, when the device didn't
+//     report one. It is marked estimated when the club speed was.
+//   - spin loft, dynamic loft less attack angle, the main driver of spin
+//   - launch efficiency, the share of the dynamic loft the ball launched at
+func (club *ClubMetrics) deriveEfficiency(ball *BallMetrics) {
+	club.SpinLoft = 0
+	club.IsSpinLoftValid = club.IsDynamicLoftValid && club.IsAttackAngleValid
+	if club.IsSpinLoftValid {
+		club.SpinLoft = roundTenth(club.DynamicLoftAngle - club.AttackAngle)
+	}
+
+	club.LaunchEfficiency = 0
+	club.IsLaunchEfficiencyValid = false
+	if ball == nil {
+		return
+	}
+	if club.IsDynamicLoftValid && club.DynamicLoftAngle > 0 {
+		club.LaunchEfficiency = math.Round(ball.VerticalAngle/club.DynamicLoftAngle*100) / 100
+		club.IsLaunchEfficiencyValid = true
+	}
+	if !club.IsSmashFactorValid && club.IsClubSpeedValid && club.ClubSpeed > 0 && ball.BallSpeedMPS > 0 {
+		club.SmashFactor = math.Round(ball.BallSpeedMPS/club.ClubSpeed*100) / 100
+		club.IsSmashFactorValid = true
+		club.SmashFactorEstimated = club.ClubSpeedEstimated
+	}
+}
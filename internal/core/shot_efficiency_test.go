@@ -0,0 +1,63 @@
+package core
+
+import "testing"
+
+func TestDeriveClubMetrics_Efficiency(t *testing.T) {
+	ball := &BallMetrics{BallSpeedMPS: 66, IsBallSpeedValid: true, VerticalAngle: 12, ShotType: ShotTypeFull}
+	club := &ClubMetrics{
+		ClubSpeed: 44, IsClubSpeedValid: true,
+		DynamicLoftAngle: 15, IsDynamicLoftValid: true,
+		AttackAngle: 3, IsAttackAngleValid: true,
+	}
+	DeriveClubMetrics(club, ball, RightHanded)
+
+	if !club.IsSmashFactorValid || club.SmashFactor != 1.5 || club.SmashFactorEstimated {
+		t.Errorf("Expected a measured smash factor of 1.5, got %v (valid %v, estimated %v)",
+			club.SmashFactor, club.IsSmashFactorValid, club.SmashFactorEstimated)
+	}
+	if !club.IsSpinLoftValid || club.SpinLoft != 12 {
+		t.Errorf("Expected spin loft 12, got %v (valid %v)", club.SpinLoft, club.IsSpinLoftValid)
+	}
+	if !club.IsLaunchEfficiencyValid || club.LaunchEfficiency != 0.8 {
+		t.Errorf("Expected launch efficiency 0.8, got %v (valid %v)", club.LaunchEfficiency, club.IsLaunchEfficiencyValid)
+	}
+}
+
+func TestDeriveClubMetrics_SmashFactor(t *testing.T) {
+	ball := &BallMetrics{BallSpeedMPS: 60, IsBallSpeedValid: true, ShotType: ShotTypeFull}
+
+	reported := &ClubMetrics{ClubSpeed: 40, IsClubSpeedValid: true, SmashFactor: 1.45, IsSmashFactorValid: true}
+	DeriveClubMetrics(reported, ball, RightHanded)
+	if reported.SmashFactor != 1.45 {
+		t.Errorf("Expected the device's smash factor to be kept, got %v", reported.SmashFactor)
+	}
+
+	estimated := &ClubMetrics{ClubSpeed: 40, IsClubSpeedValid: true, ClubSpeedEstimated: true}
+	DeriveClubMetrics(estimated, ball, RightHanded)
+	if !estimated.IsSmashFactorValid || estimated.SmashFactor != 1.5 || !estimated.SmashFactorEstimated {
+		t.Errorf("Expected an estimated smash factor of 1.5, got %v (valid %v, estimated %v)",
+			estimated.SmashFactor, estimated.IsSmashFactorValid, estimated.SmashFactorEstimated)
+	}
+
+	putt := &ClubMetrics{ClubSpeed: 2, IsClubSpeedValid: true}
+	DeriveClubMetrics(putt, &BallMetrics{BallSpeedMPS: 3, IsBallSpeedValid: true, ShotType: ShotTypePutt}, RightHanded)
+	if putt.IsSmashFactorValid || putt.IsLaunchEfficiencyValid {
+		t.Error("Expected no smash factor or launch efficiency for a putt")
+	}
+}
+
+func TestLaunchMonitor_PublishesEfficiency(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+
+	ball := &BallMetrics{BallSpeedMPS: 66, IsBallSpeedValid: true, VerticalAngle: 12}
+	club := &ClubMetrics{DynamicLoftAngle: 15, IsDynamicLoftValid: true, AttackAngle: 3, IsAttackAngleValid: true}
+	if err := lm.SubmitExternalShot("gspro-connect:other", ball, club); err != nil {
+		t.Fatalf("SubmitExternalShot() error = %v", err)
+	}
+	sm.Flush()
+
+	published := sm.GetLastClubMetrics()
+	if published == nil || !published.IsSpinLoftValid || published.SpinLoft != 12 || published.LaunchEfficiency != 0.8 {
+		t.Fatalf("Expected published club metrics with spin loft and launch efficiency, got %+v", published)
+	}
+}
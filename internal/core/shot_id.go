@@ -0,0 +1,36 @@
+package core
+
+import "time"
+
+// stampBallMetrics gives a new shot the next shot ID and the time it was
+// captured. IDs only increase while the connector runs, so everything sent
+// downstream for one swing can be matched up by its ID.
+func (lm *LaunchMonitor) stampBallMetrics(ball *BallMetrics, capturedAt time.Time) {
+	lm.shotIDMu.Lock()
+	defer lm.shotIDMu.Unlock()
+	lm.shotID++
+	ball.ShotID = lm.shotID
+	if ball.CapturedAt.IsZero() {
+		ball.CapturedAt = capturedAt
+	}
+}
+
+// stampClubMetrics gives club metrics the ID of the shot they follow
+func (lm *LaunchMonitor) stampClubMetrics(club *ClubMetrics, capturedAt time.Time) {
+	lm.shotIDMu.Lock()
+	defer lm.shotIDMu.Unlock()
+	club.ShotID = lm.shotID
+	if club.CapturedAt.IsZero() {
+		club.CapturedAt = capturedAt
+	}
+}
+
+// SameShot reports whether club metrics belong to the shot with ball metrics
+// ball. Metrics without a shot ID, from before IDs were assigned, match any
+// shot.
+func (club *ClubMetrics) SameShot(ball *BallMetrics) bool {
+	if club == nil || ball == nil {
+		return false
+	}
+	return club.ShotID == 0 || ball.ShotID == 0 || club.ShotID == ball.ShotID
+}
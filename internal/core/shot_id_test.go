@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShotIDs_IncreaseAndMatchClubMetrics(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+	clock := NewFakeClock(time.Unix(100, 0))
+	lm.SetClock(clock)
+
+	shot := func(speed string) *BallMetrics {
+		lm.HandleShotBallMetrics([]string{
+			"11", "02", "37",
+			speed, "00",
+			"00", "00", "00", "00", "00", "00", "00", "00", "00", "00", "00", "00",
+		})
+		sm.Flush()
+		return sm.GetLastBallMetrics()
+	}
+
+	first := shot("c8")
+	clock.Advance(time.Minute)
+	second := shot("c9")
+	if first == nil || second == nil || first == second {
+		t.Fatal("Expected two shots to be published")
+	}
+	if first.ShotID == 0 || second.ShotID <= first.ShotID {
+		t.Fatalf("Expected increasing shot IDs, got %d then %d", first.ShotID, second.ShotID)
+	}
+	if !second.CapturedAt.Equal(clock.Now()) {
+		t.Errorf("Expected the shot to be captured at %v, got %v", clock.Now(), second.CapturedAt)
+	}
+
+	clock.Advance(time.Second)
+	lm.HandleShotClubMetrics([]string{
+		"11", "07", "ff",
+		"d8", "fe", "90", "01", "38", "ff", "d0", "07",
+		"64", "00", "c8", "ff", "b8", "0b", "82", "00",
+	})
+	sm.Flush()
+	club := sm.GetLastClubMetrics()
+	if club == nil {
+		t.Fatal("Expected club metrics to be published")
+	}
+	if club.ShotID != second.ShotID || !club.SameShot(second) || club.SameShot(first) {
+		t.Errorf("Expected club metrics for shot %d, got %d", second.ShotID, club.ShotID)
+	}
+	if !club.CapturedAt.Equal(clock.Now()) {
+		t.Errorf("Expected club metrics captured at %v, got %v", clock.Now(), club.CapturedAt)
+	}
+
+	external := &BallMetrics{BallSpeedMPS: 60}
+	externalClub := &ClubMetrics{PathAngle: 2}
+	if err := lm.SubmitExternalShot("gspro-connect:other", external, externalClub); err != nil {
+		t.Fatalf("SubmitExternalShot() error = %v", err)
+	}
+	if external.ShotID != second.ShotID+1 || externalClub.ShotID != external.ShotID {
+		t.Errorf("Expected the external shot and its club metrics to get shot ID %d, got %d and %d",
+			second.ShotID+1, external.ShotID, externalClub.ShotID)
+	}
+}
@@ -0,0 +1,191 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShotSource identifies where a shot came from
+type ShotSource string
+
+// ShotSourceDevice is the connected Square launch monitor
+const ShotSourceDevice ShotSource = "device"
+
+// DefaultShotArbitrationWindow is how long after an accepted shot another
+// source's shot is treated as the same swing seen twice
+const DefaultShotArbitrationWindow = 3 * time.Second
+
+// ShotRouting selects how the arbiter picks between shot sources
+type ShotRouting string
+
+const (
+	// ShotRoutingFirst accepts whichever source reports a swing first
+	ShotRoutingFirst ShotRouting = "first"
+	// ShotRoutingPutter takes putts from external sources, such as a putting
+	// monitor, and full shots from the device, based on the selected club
+	ShotRoutingPutter ShotRouting = "putter"
+)
+
+// ParseShotRouting validates a routing name. An empty name is ShotRoutingFirst.
+func ParseShotRouting(name string) (ShotRouting, error) {
+	switch routing := ShotRouting(name); routing {
+	case "":
+		return ShotRoutingFirst, nil
+	case ShotRoutingFirst, ShotRoutingPutter:
+		return routing, nil
+	default:
+		return "", fmt.Errorf("unknown shot routing %q", name)
+	}
+}
+
+// ErrShotRejected is returned when the arbiter drops an external shot
+var ErrShotRejected = errors.New("shot rejected by arbitration")
+
+// ShotArbiter decides which source owns a swing when several launch monitors
+// feed the same pipeline. The first source to report a shot wins; shots from
+// other sources within the window are dropped. With putter routing, the
+// selected club decides first.
+type ShotArbiter struct {
+	mu           sync.Mutex
+	clock        Clock
+	window       time.Duration
+	routing      ShotRouting
+	lastSource   ShotSource
+	lastAccepted time.Time
+}
+
+// NewShotArbiter creates an arbiter with first-wins routing. A window of 0
+// accepts every shot.
+func NewShotArbiter(clock Clock, window time.Duration) *ShotArbiter {
+	return &ShotArbiter{clock: clock, window: window, routing: ShotRoutingFirst}
+}
+
+// SetRouting changes how shots are routed between sources
+func (a *ShotArbiter) SetRouting(routing ShotRouting) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.routing = routing
+}
+
+// Routing returns the current routing
+func (a *ShotArbiter) Routing() ShotRouting {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.routing
+}
+
+// Accept reports whether a shot from source may enter the pipeline, and
+// records it as the latest shot if so. club is the selected club, or nil if
+// none has been chosen.
+func (a *ShotArbiter) Accept(source ShotSource, club *ClubType) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.routing == ShotRoutingPutter && club != nil {
+		putting := *club == ClubPutter
+		if putting == (source == ShotSourceDevice) {
+			return false
+		}
+	}
+
+	now := a.clock.Now()
+	if a.window > 0 && !a.lastAccepted.IsZero() && source != a.lastSource && now.Sub(a.lastAccepted) < a.window {
+		return false
+	}
+	a.lastSource = source
+	a.lastAccepted = now
+	return true
+}
+
+// LastSource returns the source of the most recently accepted shot
+func (a *ShotArbiter) LastSource() ShotSource {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastSource
+}
+
+// SetShotArbiter enables arbitration between the device and external shot
+// sources. A nil arbiter accepts every shot.
+func (lm *LaunchMonitor) SetShotArbiter(arbiter *ShotArbiter) {
+	lm.arbiterMu.Lock()
+	defer lm.arbiterMu.Unlock()
+	lm.shotArbiter = arbiter
+}
+
+func (lm *LaunchMonitor) acceptShot(source ShotSource) bool {
+	lm.arbiterMu.Lock()
+	arbiter := lm.shotArbiter
+	lm.arbiterMu.Unlock()
+	return arbiter == nil || arbiter.Accept(source, lm.stateManager.GetClub())
+}
+
+// acceptDeviceShot arbitrates a device shot. The device repeats a shot
+// notification, so a rejected packet stays rejected rather than being accepted
+// once the window has passed.
+func (lm *LaunchMonitor) acceptDeviceShot(rawData string) bool {
+	lm.arbiterMu.Lock()
+	if rawData == lm.rejectedShotRaw {
+		lm.arbiterMu.Unlock()
+		return false
+	}
+	lm.arbiterMu.Unlock()
+
+	accepted := lm.acceptShot(ShotSourceDevice)
+
+	lm.arbiterMu.Lock()
+	defer lm.arbiterMu.Unlock()
+	lm.deviceShotRejected = !accepted
+	if !accepted {
+		lm.rejectedShotRaw = rawData
+	}
+	return accepted
+}
+
+// deviceShotDropped reports whether the device's latest shot lost
+// arbitration, so its club metrics are dropped too
+func (lm *LaunchMonitor) deviceShotDropped() bool {
+	lm.arbiterMu.Lock()
+	defer lm.arbiterMu.Unlock()
+	return lm.deviceShotRejected
+}
+
+// SubmitExternalShot feeds a shot from another launch monitor into the
+// pipeline. Club metrics are optional.
+func (lm *LaunchMonitor) SubmitExternalShot(source ShotSource, ballMetrics *BallMetrics, clubMetrics *ClubMetrics) error {
+	if ballMetrics == nil {
+		return fmt.Errorf("shot from %s has no ball data", source)
+	}
+	if source == ShotSourceDevice || strings.TrimSpace(string(source)) == "" {
+		return fmt.Errorf("invalid external shot source %q", source)
+	}
+	if lm.IsPaused() {
+		log.Printf("LaunchMonitor: Dropped shot from %s, session is paused", source)
+		return fmt.Errorf("%w: %s", ErrSessionPaused, source)
+	}
+	if !lm.acceptShot(source) {
+		log.Printf("LaunchMonitor: Dropped shot from %s, routed to another source", source)
+		return fmt.Errorf("%w: %s", ErrShotRejected, source)
+	}
+
+	if ballMetrics.ShotType == "" {
+		ballMetrics.ShotType = ShotTypeFull
+		if club := lm.stateManager.GetClub(); club != nil && *club == ClubPutter {
+			ballMetrics.ShotType = ShotTypePutt
+		}
+	}
+
+	log.Printf("LaunchMonitor: Accepted shot from %s", source)
+	capturedAt := lm.clock.Now()
+	lm.stampBallMetrics(ballMetrics, capturedAt)
+	lm.stateManager.SetLastBallMetrics(ballMetrics)
+	if clubMetrics != nil {
+		lm.stampClubMetrics(clubMetrics, capturedAt)
+		lm.deriveClubMetrics(clubMetrics, ballMetrics)
+		lm.stateManager.SetLastClubMetrics(clubMetrics)
+	}
+	return nil
+}
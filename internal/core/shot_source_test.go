@@ -0,0 +1,138 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShotArbiter_FirstSourceWinsWithinWindow(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	arbiter := NewShotArbiter(clock, 3*time.Second)
+
+	if !arbiter.Accept(ShotSourceDevice, nil) {
+		t.Fatal("Expected first shot to be accepted")
+	}
+	if arbiter.Accept("gspro-connect:other", nil) {
+		t.Error("Expected another source within the window to be rejected")
+	}
+	if !arbiter.Accept(ShotSourceDevice, nil) {
+		t.Error("Expected the same source to keep reporting shots")
+	}
+
+	clock.Advance(3 * time.Second)
+	if !arbiter.Accept("gspro-connect:other", nil) {
+		t.Error("Expected another source to be accepted after the window")
+	}
+	if got := arbiter.LastSource(); got != "gspro-connect:other" {
+		t.Errorf("Expected last source gspro-connect:other, got %q", got)
+	}
+}
+
+func TestSubmitExternalShot(t *testing.T) {
+	sm, lm, _, _ := newTestLaunchMonitor(t)
+
+	ball := &BallMetrics{BallSpeedMPS: 60, IsBallSpeedValid: true}
+	club := &ClubMetrics{PathAngle: 2}
+	if err := lm.SubmitExternalShot("gspro-connect:other", ball, club); err != nil {
+		t.Fatalf("Expected shot to be accepted, got %v", err)
+	}
+	if sm.GetLastBallMetrics() != ball || sm.GetLastClubMetrics() != club {
+		t.Error("Expected external shot to be published")
+	}
+	if ball.ShotType != ShotTypeFull {
+		t.Errorf("Expected shot type %q, got %q", ShotTypeFull, ball.ShotType)
+	}
+
+	if err := lm.SubmitExternalShot(ShotSourceDevice, ball, nil); err == nil {
+		t.Error("Expected the device source to be refused for external shots")
+	}
+	if err := lm.SubmitExternalShot("gspro-connect:other", nil, nil); err == nil {
+		t.Error("Expected a shot without ball data to be refused")
+	}
+}
+
+func TestDeviceShotDroppedAfterExternalShot(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	clock := NewFakeClock(time.Unix(0, 0))
+	lm.SetShotArbiter(NewShotArbiter(clock, DefaultShotArbitrationWindow))
+
+	external := &BallMetrics{BallSpeedMPS: 60, IsBallSpeedValid: true}
+	if err := lm.SubmitExternalShot("gspro-connect:other", external, nil); err != nil {
+		t.Fatalf("Expected external shot to be accepted, got %v", err)
+	}
+
+	lm.NotificationHandler("", noSpinShot)
+	if sm.GetLastBallMetrics() != external {
+		t.Error("Expected device shot of the same swing to be dropped")
+	}
+	lm.publishClubMetrics(&ClubMetrics{PathAngle: 1.5})
+	if sm.GetLastClubMetrics() != nil {
+		t.Error("Expected club metrics of a dropped shot not to be published")
+	}
+
+	// The device repeating the dropped packet later must not turn it into a shot
+	clock.Advance(DefaultShotArbitrationWindow)
+	lm.NotificationHandler("", noSpinShot)
+	if sm.GetLastBallMetrics() != external {
+		t.Error("Expected repeated packet of a dropped shot to stay dropped")
+	}
+
+	if err := lm.SubmitExternalShot("gspro-connect:other", external, nil); err != nil {
+		t.Errorf("Expected external source to keep reporting, got %v", err)
+	}
+	clock.Advance(time.Second)
+	if err := lm.SubmitExternalShot("gspro-connect:third", external, nil); !errors.Is(err, ErrShotRejected) {
+		t.Errorf("Expected ErrShotRejected, got %v", err)
+	}
+}
+
+func TestShotArbiter_PutterRouting(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	arbiter := NewShotArbiter(clock, DefaultShotArbitrationWindow)
+	arbiter.SetRouting(ShotRoutingPutter)
+
+	putter := ClubPutter
+	driver := ClubDriver
+	tests := []struct {
+		name   string
+		source ShotSource
+		club   *ClubType
+		want   bool
+	}{
+		{"device with putter", ShotSourceDevice, &putter, false},
+		{"putting monitor with putter", "gspro-connect:putting", &putter, true},
+		{"putting monitor with driver", "gspro-connect:putting", &driver, false},
+		{"device with driver", ShotSourceDevice, &driver, true},
+	}
+
+	for _, tt := range tests {
+		// Each case is a separate swing
+		clock.Advance(DefaultShotArbitrationWindow)
+		if got := arbiter.Accept(tt.source, tt.club); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	// Without a selected club the first source wins
+	clock.Advance(DefaultShotArbitrationWindow)
+	if !arbiter.Accept("gspro-connect:putting", nil) {
+		t.Error("Expected first shot without a club to be accepted")
+	}
+	if arbiter.Accept(ShotSourceDevice, nil) {
+		t.Error("Expected second source within the window to be rejected")
+	}
+}
+
+func TestParseShotRouting(t *testing.T) {
+	if routing, err := ParseShotRouting(""); err != nil || routing != ShotRoutingFirst {
+		t.Errorf("Expected empty name to be first, got %q, %v", routing, err)
+	}
+	if routing, err := ParseShotRouting("putter"); err != nil || routing != ShotRoutingPutter {
+		t.Errorf("Expected putter, got %q, %v", routing, err)
+	}
+	if _, err := ParseShotRouting("club"); err == nil {
+		t.Error("Expected an unknown routing to be an error")
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"net"
 	"sync"
 	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
 )
 
 const (
@@ -17,10 +19,19 @@ const (
 	MaxFailedAttempts = 20
 )
 
+// Endpoint is the address of a simulator
+type Endpoint struct {
+	Host string `json:"ip"`
+	Port int    `json:"port"`
+}
+
 type Base struct {
 	Protocol           Protocol
 	Host               string
 	Port               int
+	Primary            Endpoint // the endpoint last connected to directly
+	Standby            Endpoint // used once Primary exceeds its retry limits; empty when unset
+	FailedOver         bool
 	Socket             net.Conn
 	Connected          bool
 	Running            bool
@@ -30,6 +41,10 @@ type Base struct {
 	ReconnectAttempts  int
 	LastConnectAttempt time.Time
 	BackoffDuration    time.Duration
+	Clock              core.Clock
+	Traffic            *TrafficRecorder
+	Supervisor         *core.Supervisor // restarts the connection and receive loops after a panic
+	watchdog           watchdog
 }
 
 func NewBase(protocol Protocol, host string, port int) *Base {
@@ -43,8 +58,11 @@ func NewBase(protocol Protocol, host string, port int) *Base {
 		Protocol:        protocol,
 		Host:            host,
 		Port:            port,
+		Primary:         Endpoint{Host: host, Port: port},
 		AutoReconnect:   true,
 		BackoffDuration: InitialBackoff,
+		Clock:           core.RealClock(),
+		Traffic:         NewTrafficRecorder(DefaultTrafficEntries),
 	}
 }
 
@@ -58,6 +76,9 @@ func (b *Base) Connect(host string, port int) {
 
 	b.Host = host
 	b.Port = port
+	if !b.FailedOver {
+		b.Primary = Endpoint{Host: host, Port: port}
+	}
 
 	if b.Socket != nil {
 		log.Printf("[%s] Forcing cleanup of stale socket before reconnection", b.Protocol.Name())
@@ -66,7 +87,7 @@ func (b *Base) Connect(host string, port int) {
 	}
 
 	b.Protocol.SetStatus(StatusConnecting)
-	b.LastConnectAttempt = time.Now()
+	b.LastConnectAttempt = b.Clock.Now()
 
 	addr := net.JoinHostPort(b.Host, fmt.Sprintf("%d", b.Port))
 	log.Printf("[%s] Connecting to server at %s (attempt %d, backoff: %v)", b.Protocol.Name(), addr, b.ReconnectAttempts+1, b.BackoffDuration)
@@ -101,12 +122,13 @@ func (b *Base) Connect(host string, port int) {
 	b.Wg.Add(1)
 	go func() {
 		defer b.Wg.Done()
-		b.receiveMessages()
+		b.Supervisor.Supervise(b.Protocol.Name()+" receiver", b.receiveMessages)
 	}()
 
-	time.Sleep(500 * time.Millisecond)
+	b.Clock.Sleep(500 * time.Millisecond)
 	b.Protocol.SetStatus(StatusConnected)
 	b.Protocol.OnConnected()
+	b.watchdogConnected()
 }
 
 func (b *Base) Disconnect() {
@@ -163,7 +185,7 @@ func (b *Base) Start() {
 	b.Wg.Add(1)
 	go func() {
 		defer b.Wg.Done()
-		b.connectionThread()
+		b.Supervisor.Supervise(b.Protocol.Name()+" connection", b.connectionThread)
 	}()
 }
 
@@ -180,6 +202,13 @@ func (b *Base) Stop() {
 	b.Wg.Wait()
 }
 
+func (b *Base) Shutdown() {
+	b.DisableAutoReconnect()
+	b.Stop()
+	b.Disconnect()
+	b.Traffic.Close()
+}
+
 func (b *Base) EnableAutoReconnect() {
 	b.ConnectMutex.Lock()
 	defer b.ConnectMutex.Unlock()
@@ -202,9 +231,60 @@ func (b *Base) ResetReconnectionState() {
 	b.ReconnectAttempts = 0
 	b.BackoffDuration = InitialBackoff
 	b.LastConnectAttempt = time.Time{}
+	if b.FailedOver {
+		b.FailedOver = false
+		b.Host = b.Primary.Host
+		b.Port = b.Primary.Port
+	}
 	log.Printf("[%s] Reconnection state reset", b.Protocol.Name())
 }
 
+// SetStandby sets the endpoint to fail over to when the primary exceeds its
+// retry limits. An empty host removes the standby.
+func (b *Base) SetStandby(host string, port int) {
+	b.ConnectMutex.Lock()
+	defer b.ConnectMutex.Unlock()
+
+	if host == "" {
+		b.Standby = Endpoint{}
+		return
+	}
+	if port == 0 {
+		port = b.Protocol.DefaultPort()
+	}
+	b.Standby = Endpoint{Host: host, Port: port}
+}
+
+// FailoverState returns the primary and standby endpoints and whether
+// connections have failed over to the standby
+func (b *Base) FailoverState() (primary, standby Endpoint, failedOver bool) {
+	b.ConnectMutex.Lock()
+	defer b.ConnectMutex.Unlock()
+	return b.Primary, b.Standby, b.FailedOver
+}
+
+// failover switches reconnection attempts to the standby endpoint. It returns
+// false when there is no standby or connections have already failed over.
+func (b *Base) failover() bool {
+	b.ConnectMutex.Lock()
+	defer b.ConnectMutex.Unlock()
+
+	if b.FailedOver || b.Standby.Host == "" {
+		return false
+	}
+
+	log.Printf("[%s] Primary %s unreachable, failing over to standby %s", b.Protocol.Name(),
+		net.JoinHostPort(b.Primary.Host, fmt.Sprintf("%d", b.Primary.Port)),
+		net.JoinHostPort(b.Standby.Host, fmt.Sprintf("%d", b.Standby.Port)))
+	b.FailedOver = true
+	b.Host = b.Standby.Host
+	b.Port = b.Standby.Port
+	b.ReconnectAttempts = 0
+	b.BackoffDuration = InitialBackoff
+	b.LastConnectAttempt = time.Time{}
+	return true
+}
+
 func (b *Base) SendMessage(data []byte) error {
 	if !b.Connected || b.Socket == nil {
 		return fmt.Errorf("not connected to %s", b.Protocol.Name())
@@ -216,6 +296,7 @@ func (b *Base) SendMessage(data []byte) error {
 		b.Disconnect()
 		return fmt.Errorf("error sending data: %w", err)
 	}
+	b.Traffic.Record(DirectionSent, data)
 	return nil
 }
 
@@ -281,7 +362,12 @@ func (b *Base) receiveMessages() {
 	var messageBuffer []byte
 
 	for b.Running && b.Connected {
-		b.Socket.SetReadDeadline(time.Now().Add(10 * time.Second))
+		if b.stalled() {
+			b.Protocol.SetError(fmt.Errorf("stale connection: no reply from server"))
+			b.Protocol.SetStatus(StatusError)
+			break
+		}
+		b.Socket.SetReadDeadline(time.Now().Add(b.readDeadline()))
 
 		n, err := b.Socket.Read(buffer)
 
@@ -302,11 +388,13 @@ func (b *Base) receiveMessages() {
 			break
 		}
 
+		b.replyReceived()
 		messageBuffer = append(messageBuffer, buffer[:n]...)
 
 		objects, remaining := findJSONObjects(messageBuffer)
 		for _, obj := range objects {
 			log.Printf("[%s] Received message: %s", b.Protocol.Name(), obj)
+			b.Traffic.Record(DirectionReceived, []byte(obj))
 			b.Protocol.ProcessMessage(obj)
 		}
 
@@ -317,7 +405,7 @@ func (b *Base) receiveMessages() {
 }
 
 func (b *Base) connectionThread() {
-	firstAttemptTime := time.Now()
+	firstAttemptTime := b.Clock.Now()
 
 	for b.Running {
 		b.ConnectMutex.Lock()
@@ -329,7 +417,14 @@ func (b *Base) connectionThread() {
 		b.ConnectMutex.Unlock()
 
 		if !connected && autoReconnect {
-			if time.Since(firstAttemptTime) > MaxReconnectTime {
+			if b.Clock.Since(firstAttemptTime) > MaxReconnectTime || reconnectAttempts >= MaxFailedAttempts {
+				if b.failover() {
+					firstAttemptTime = b.Clock.Now()
+					continue
+				}
+			}
+
+			if b.Clock.Since(firstAttemptTime) > MaxReconnectTime {
 				log.Printf("[%s] Reconnection timeout: exceeded %v of reconnection attempts", b.Protocol.Name(), MaxReconnectTime)
 				log.Printf("[%s] Auto-reconnect disabled. Please reconnect manually via the web UI.", b.Protocol.Name())
 				b.DisableAutoReconnect()
@@ -347,8 +442,8 @@ func (b *Base) connectionThread() {
 				continue
 			}
 
-			if !lastAttempt.IsZero() && time.Since(lastAttempt) < backoff {
-				time.Sleep(1 * time.Second)
+			if !lastAttempt.IsZero() && b.Clock.Since(lastAttempt) < backoff {
+				b.Clock.Sleep(1 * time.Second)
 				continue
 			}
 
@@ -356,13 +451,13 @@ func (b *Base) connectionThread() {
 
 			b.ConnectMutex.Lock()
 			if b.Connected {
-				firstAttemptTime = time.Now()
+				firstAttemptTime = b.Clock.Now()
 			}
 			b.ConnectMutex.Unlock()
 		} else if connected {
-			firstAttemptTime = time.Now()
+			firstAttemptTime = b.Clock.Now()
 		}
 
-		time.Sleep(1 * time.Second)
+		b.Clock.Sleep(1 * time.Second)
 	}
 }
@@ -0,0 +1,145 @@
+package simulator
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Direction of a recorded message
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+)
+
+const (
+	// DefaultTrafficEntries is how many recent messages a recorder keeps in memory
+	DefaultTrafficEntries = 500
+
+	trafficFileMaxSizeMB  = 5
+	trafficFileMaxBackups = 3
+)
+
+// TrafficEntry is one message exchanged with a simulator
+type TrafficEntry struct {
+	Time      time.Time       `json:"time"`
+	Direction string          `json:"direction"`
+	Message   json.RawMessage `json:"message"`
+}
+
+// TrafficRecorder records the JSON messages exchanged with a simulator while
+// enabled. The most recent messages are kept in memory and, when a path is
+// set, every message is appended to a rotating file.
+type TrafficRecorder struct {
+	mu      sync.Mutex
+	enabled bool
+	entries []TrafficEntry
+	max     int
+	path    string
+	file    *lumberjack.Logger
+}
+
+// NewTrafficRecorder returns a disabled recorder keeping up to max messages
+// in memory
+func NewTrafficRecorder(max int) *TrafficRecorder {
+	if max <= 0 {
+		max = DefaultTrafficEntries
+	}
+	return &TrafficRecorder{max: max}
+}
+
+// SetPath sets the file messages are written to. An empty path keeps
+// messages in memory only.
+func (r *TrafficRecorder) SetPath(path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closeFileLocked()
+	r.path = path
+}
+
+// SetEnabled starts or stops recording. Recorded messages are kept.
+func (r *TrafficRecorder) SetEnabled(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enabled = enabled
+	if !enabled {
+		r.closeFileLocked()
+	}
+}
+
+// Enabled reports whether messages are being recorded
+func (r *TrafficRecorder) Enabled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled
+}
+
+// Record stores a message if recording is enabled. Messages that aren't
+// valid JSON are stored as JSON strings.
+func (r *TrafficRecorder) Record(direction string, message []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.enabled {
+		return
+	}
+
+	raw := json.RawMessage(append([]byte(nil), message...))
+	if !json.Valid(raw) {
+		raw, _ = json.Marshal(string(message))
+	}
+	entry := TrafficEntry{Time: time.Now(), Direction: direction, Message: raw}
+
+	r.entries = append(r.entries, entry)
+	if len(r.entries) > r.max {
+		r.entries = r.entries[len(r.entries)-r.max:]
+	}
+
+	if r.path == "" {
+		return
+	}
+	if r.file == nil {
+		r.file = &lumberjack.Logger{
+			Filename:   r.path,
+			MaxSize:    trafficFileMaxSizeMB,
+			MaxBackups: trafficFileMaxBackups,
+		}
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		log.Printf("Failed to write simulator traffic to %s: %v", r.path, err)
+	}
+}
+
+// Last returns up to n of the most recent messages, oldest first
+func (r *TrafficRecorder) Last(n int) []TrafficEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if n <= 0 || n > len(r.entries) {
+		n = len(r.entries)
+	}
+	return append([]TrafficEntry{}, r.entries[len(r.entries)-n:]...)
+}
+
+// Close closes the traffic file
+func (r *TrafficRecorder) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closeFileLocked()
+}
+
+func (r *TrafficRecorder) closeFileLocked() {
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+}
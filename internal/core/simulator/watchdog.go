@@ -0,0 +1,121 @@
+package simulator
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// readTimeout is how long the receive loop waits for data before checking
+// whether it should stop
+const readTimeout = 10 * time.Second
+
+// StaleConnection describes a connection the watchdog cycled because the
+// simulator stopped answering shots
+type StaleConnection struct {
+	Simulator   string    `json:"simulator"`
+	SilentFor   float64   `json:"silentForSeconds"` // how long the simulator went without answering
+	DetectedAt  time.Time `json:"detectedAt"`
+	RecoveredAt time.Time `json:"recoveredAt"`
+}
+
+// watchdog notices a simulator that keeps the connection open but stops
+// answering. Without it, shots go unanswered until the player notices.
+type watchdog struct {
+	mu           sync.Mutex
+	timeout      time.Duration // zero turns the watchdog off
+	waitingSince time.Time     // when a message needing a reply was sent, if unanswered
+	stale        *StaleConnection
+	listeners    []func(StaleConnection)
+}
+
+// SetStallTimeout sets how long a shot may go unanswered before the
+// connection is treated as stale and cycled. Zero turns the watchdog off.
+func (b *Base) SetStallTimeout(timeout time.Duration) {
+	b.watchdog.mu.Lock()
+	defer b.watchdog.mu.Unlock()
+	b.watchdog.timeout = timeout
+}
+
+// OnStaleConnectionRecovered registers a listener called when a connection
+// cycled by the watchdog has reconnected
+func (b *Base) OnStaleConnectionRecovered(listener func(StaleConnection)) {
+	b.watchdog.mu.Lock()
+	defer b.watchdog.mu.Unlock()
+	b.watchdog.listeners = append(b.watchdog.listeners, listener)
+}
+
+// ExpectReply tells the watchdog the message just sent is answered by the
+// simulator, such as a shot GSPro acknowledges
+func (b *Base) ExpectReply() {
+	b.watchdog.mu.Lock()
+	defer b.watchdog.mu.Unlock()
+	if b.watchdog.waitingSince.IsZero() {
+		b.watchdog.waitingSince = b.Clock.Now()
+	}
+}
+
+// replyReceived clears the wait once the simulator sends anything
+func (b *Base) replyReceived() {
+	b.watchdog.mu.Lock()
+	defer b.watchdog.mu.Unlock()
+	b.watchdog.waitingSince = time.Time{}
+}
+
+// readDeadline returns how long a read may block, short enough to notice a
+// stall soon after the timeout
+func (b *Base) readDeadline() time.Duration {
+	b.watchdog.mu.Lock()
+	defer b.watchdog.mu.Unlock()
+	if timeout := b.watchdog.timeout / 2; timeout > 0 && timeout < readTimeout {
+		return timeout
+	}
+	return readTimeout
+}
+
+// stalled reports whether a reply has been awaited for longer than the
+// timeout. A stalled connection is remembered so its recovery is reported.
+func (b *Base) stalled() bool {
+	b.watchdog.mu.Lock()
+	defer b.watchdog.mu.Unlock()
+
+	w := &b.watchdog
+	if w.timeout <= 0 || w.waitingSince.IsZero() {
+		return false
+	}
+	now := b.Clock.Now()
+	silent := now.Sub(w.waitingSince)
+	if silent < w.timeout {
+		return false
+	}
+	log.Printf("[%s] No reply for %v, cycling the connection", b.Protocol.Name(), silent.Round(time.Second))
+	w.stale = &StaleConnection{
+		Simulator:  b.Protocol.Name(),
+		SilentFor:  silent.Seconds(),
+		DetectedAt: now,
+	}
+	w.waitingSince = time.Time{}
+	return true
+}
+
+// watchdogConnected resets the watchdog for a new connection and reports the
+// recovery of one it cycled
+func (b *Base) watchdogConnected() {
+	b.watchdog.mu.Lock()
+	w := &b.watchdog
+	w.waitingSince = time.Time{}
+	stale := w.stale
+	w.stale = nil
+	listeners := make([]func(StaleConnection), len(w.listeners))
+	copy(listeners, w.listeners)
+	b.watchdog.mu.Unlock()
+
+	if stale == nil {
+		return
+	}
+	stale.RecoveredAt = b.Clock.Now()
+	log.Printf("[%s] Stale connection recovered", b.Protocol.Name())
+	for _, listener := range listeners {
+		listener(*stale)
+	}
+}
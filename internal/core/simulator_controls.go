@@ -0,0 +1,325 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ErrSimulatorNotConnected is returned when an event is injected while the
+// simulated device is disconnected
+var ErrSimulatorNotConnected = fmt.Errorf("simulator is not connected")
+
+// SimulatedShot holds the ball values to send for an injected shot, in the
+// same units as BallMetrics. Club is optional; random club data is sent when
+// it is nil.
+type SimulatedShot struct {
+	BallSpeedMPS    float64        `json:"speed"`
+	VerticalAngle   float64        `json:"launchAngle"`
+	HorizontalAngle float64        `json:"horizontalAngle"`
+	TotalspinRPM    int16          `json:"totalSpin"`
+	SpinAxis        float64        `json:"spinAxis"`
+	BackspinRPM     int16          `json:"backSpin"`
+	SidespinRPM     int16          `json:"sideSpin"`
+	Club            *SimulatedClub `json:"club,omitempty"`
+}
+
+// SimulatedClub holds the club values to send for an injected shot, in the
+// same units as ClubMetrics. Club speed is only sent by a simulated Omni.
+type SimulatedClub struct {
+	PathAngle        float64 `json:"path"`
+	FaceAngle        float64 `json:"angle"`
+	AttackAngle      float64 `json:"attackAngle"`
+	DynamicLoftAngle float64 `json:"dynamicLoft"`
+	ClubSpeed        float64 `json:"clubSpeed"`
+}
+
+// SimulatorControlStatus describes the simulator for the test bench
+type SimulatorControlStatus struct {
+	Manual       bool             `json:"manual"`
+	Connected    bool             `json:"connected"`
+	BatteryLevel int              `json:"batteryLevel"`
+	DeviceState  DeviceState      `json:"deviceState"`
+	BallDetected bool             `json:"ballDetected"`
+	BallReady    bool             `json:"ballReady"`
+	Series       ShotSeriesStatus `json:"series"`
+	Faults       SimulatorFaults  `json:"faults"`
+}
+
+// SimulatedDeviceAddress is the address the simulated device reports
+const SimulatedDeviceAddress = "5A:5A:5A:00:00:01"
+
+// Pair pretends to pair with the simulated device
+func (s *SimulatorBluetoothClient) Pair() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.connected {
+		return ErrSimulatorNotConnected
+	}
+	s.paired = true
+	log.Println("Simulator: Paired")
+	return nil
+}
+
+// Unpair forgets the simulated pairing
+func (s *SimulatorBluetoothClient) Unpair(address string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paired = false
+	return nil
+}
+
+// IsPaired reports whether Pair was called since the last Unpair
+func (s *SimulatorBluetoothClient) IsPaired() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.paired
+}
+
+// GetConnectedDeviceAddress returns the simulated device's address while
+// connected
+func (s *SimulatorBluetoothClient) GetConnectedDeviceAddress() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if !s.connected {
+		return ""
+	}
+	return SimulatedDeviceAddress
+}
+
+// GetScanResultDevices returns the simulated device with its address
+func (s *SimulatorBluetoothClient) GetScanResultDevices() []DiscoveredDevice {
+	deviceType := DeviceTypeHome
+	if s.config.SimulateOmni {
+		deviceType = DeviceTypeOmni
+	}
+	return []DiscoveredDevice{{Name: "SquareGolf(****)", Address: SimulatedDeviceAddress, Type: deviceType}}
+}
+
+// SetConnectionLostCallback sets a callback to be notified when the simulated
+// link drops without Disconnect being called
+func (s *SimulatorBluetoothClient) SetConnectionLostCallback(callback func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.onConnectionLost = callback
+}
+
+// SetManual switches between the timed ball detection loop and manual mode,
+// where ball and shot events only happen when injected
+func (s *SimulatorBluetoothClient) SetManual(manual bool) {
+	s.lock.Lock()
+	s.manual = manual
+	if manual && s.ballDetectionCancel != nil {
+		s.ballDetectionCancel()
+		s.ballDetectionCancel = nil
+	}
+	restart := !manual && s.connected && s.deviceState == DeviceStateBallDetection && s.ballDetectionCancel == nil
+	s.lock.Unlock()
+
+	log.Printf("Simulator: Manual mode %v", manual)
+	if restart {
+		s.startBallDetection()
+	}
+}
+
+// ControlStatus returns the simulator's current state
+func (s *SimulatorBluetoothClient) ControlStatus() SimulatorControlStatus {
+	series := s.SeriesStatus()
+	faults := s.Faults()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return SimulatorControlStatus{
+		Manual:       s.manual,
+		Connected:    s.connected,
+		BatteryLevel: s.batteryLevel,
+		DeviceState:  s.deviceState,
+		BallDetected: s.ballState == BallStateDetected || s.ballState == BallStateReady,
+		BallReady:    s.ballState == BallStateReady,
+		Series:       series,
+		Faults:       faults,
+	}
+}
+
+// PlaceBall reports a ball placed on the mat but not yet ready
+func (s *SimulatorBluetoothClient) PlaceBall() error {
+	return s.setBallState(BallStateDetected, LaunchMonitorStatusDetect)
+}
+
+// ReadyBall reports the ball ready to hit
+func (s *SimulatorBluetoothClient) ReadyBall() error {
+	return s.setBallState(BallStateReady, LaunchMonitorStatusReady)
+}
+
+// RemoveBall reports the ball taken off the mat
+func (s *SimulatorBluetoothClient) RemoveBall() error {
+	return s.setBallState(BallStateNone, LaunchMonitorStatusDetect)
+}
+
+// InjectShot sends a shot with the given values, or random values when shot
+// is nil. Club data is sent when the launch monitor requests it, as the
+// device does.
+func (s *SimulatorBluetoothClient) InjectShot(shot *SimulatedShot) error {
+	handler, err := s.notificationHandler()
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	s.injectedClub = nil
+	if shot != nil {
+		s.injectedClub = shot.Club
+	}
+	s.lastActivity = s.clock.Now()
+	s.lock.Unlock()
+
+	s.sendStatusNotification(LaunchMonitorStatusShot)
+	if shot == nil {
+		s.sendBallMetrics(handler)
+	} else {
+		handler(encodeSimulatedBall(shot))
+	}
+	log.Println("Simulator: Injected shot sent")
+
+	// Give the launch monitor time to request club data before the shot ends
+	s.clock.Sleep(time.Second)
+
+	s.lock.Lock()
+	s.ballState = BallStateNone
+	s.lock.Unlock()
+	s.sendStatusNotification(LaunchMonitorStatusDone)
+	return nil
+}
+
+// SimulateDisconnect drops the link as if the device went out of range
+func (s *SimulatorBluetoothClient) SimulateDisconnect() error {
+	s.lock.Lock()
+	if !s.connected {
+		s.lock.Unlock()
+		return ErrSimulatorNotConnected
+	}
+	log.Println("Simulator: Simulating connection loss")
+	s.performDisconnection()
+	s.notifyHandlers = make(map[string]func([]byte))
+	callback := s.onConnectionLost
+	s.lock.Unlock()
+
+	if callback != nil {
+		callback()
+	}
+	return nil
+}
+
+// SetBatteryLevel changes the battery level and notifies the launch monitor
+func (s *SimulatorBluetoothClient) SetBatteryLevel(level int) error {
+	if level < 0 || level > 100 {
+		return fmt.Errorf("battery level must be between 0 and 100")
+	}
+
+	s.lock.Lock()
+	if !s.connected {
+		s.lock.Unlock()
+		return ErrSimulatorNotConnected
+	}
+	s.batteryLevel = level
+	s.characteristics[BatteryLevelCharUUID] = []byte{byte(level)}
+	handler := s.notifyHandlers[BatteryLevelCharUUID]
+	s.lock.Unlock()
+
+	log.Printf("Simulator: Battery level set to %d%%", level)
+	if handler != nil {
+		handler([]byte{byte(level)})
+	}
+	return nil
+}
+
+// startBallDetection starts the timed ball detection loop
+func (s *SimulatorBluetoothClient) startBallDetection() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.lock.Lock()
+	s.ballDetectionCancel = cancel
+	s.lock.Unlock()
+
+	go s.simulateBallDetection(ctx)
+}
+
+func (s *SimulatorBluetoothClient) setBallState(state BallState, status LaunchMonitorStatus) error {
+	handler, err := s.notificationHandler()
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	s.ballState = state
+	s.lastActivity = s.clock.Now()
+	s.lock.Unlock()
+
+	s.sendStatusNotification(status)
+	handler(s.generateSensorData(state))
+	return nil
+}
+
+// notificationHandler returns the launch monitor's notification handler
+func (s *SimulatorBluetoothClient) notificationHandler() (func([]byte), error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if !s.connected {
+		return nil, ErrSimulatorNotConnected
+	}
+	handler := s.notifyHandlers[NotificationCharUUID]
+	if handler == nil {
+		return nil, fmt.Errorf("launch monitor has not enabled notifications")
+	}
+	return handler, nil
+}
+
+// takeInjectedClub returns and clears the club data for an injected shot
+func (s *SimulatorBluetoothClient) takeInjectedClub() *SimulatedClub {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	club := s.injectedClub
+	s.injectedClub = nil
+	return club
+}
+
+// encodeSimulatedBall builds a ball metrics notification (11 02) with every
+// field marked valid
+func encodeSimulatedBall(shot *SimulatedShot) []byte {
+	data := []byte{0x11, 0x02, 0x37}
+	data = appendInt16LE(data, int16(shot.BallSpeedMPS*100))
+	data = appendInt16LE(data, int16(shot.VerticalAngle*100))
+	data = appendInt16LE(data, int16(shot.HorizontalAngle*100))
+	data = appendInt16LE(data, shot.TotalspinRPM)
+	data = appendInt16LE(data, int16(shot.SpinAxis*100))
+	data = appendInt16LE(data, shot.BackspinRPM)
+	data = appendInt16LE(data, shot.SidespinRPM)
+	return data
+}
+
+// encodeSimulatedClub builds a club metrics notification (11 07) in the Home
+// or Omni layout
+func encodeSimulatedClub(club *SimulatedClub, omni bool) []byte {
+	if !omni {
+		data := []byte{0x11, 0x07, 0x0d}
+		data = appendInt16LE(data, int16(club.PathAngle*100))
+		data = appendInt16LE(data, int16(club.FaceAngle*100))
+		data = appendInt16LE(data, int16(club.AttackAngle*100))
+		return appendInt16LE(data, int16(club.DynamicLoftAngle*100))
+	}
+
+	// Impact location and smash factor are not simulated (bits 4, 5 and 7)
+	data := []byte{0x11, 0x07, 0x4F}
+	data = appendInt16LE(data, int16(club.PathAngle*100))
+	data = appendInt16LE(data, int16(club.FaceAngle*100))
+	data = appendInt16LE(data, int16(club.AttackAngle*100))
+	data = appendInt16LE(data, int16(club.DynamicLoftAngle*100))
+	data = appendInt16LE(data, 0)
+	data = appendInt16LE(data, 0)
+	data = appendInt16LE(data, int16(club.ClubSpeed*100))
+	return appendInt16LE(data, -32768)
+}
+
+func appendInt16LE(data []byte, v int16) []byte {
+	return append(data, byte(v&0xFF), byte((v>>8)&0xFF))
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEncodeSimulatedBall(t *testing.T) {
+	shot := &SimulatedShot{
+		BallSpeedMPS:    62.5,
+		VerticalAngle:   12.25,
+		HorizontalAngle: -1.5,
+		TotalspinRPM:    2800,
+		SpinAxis:        -4.2,
+		BackspinRPM:     2792,
+		SidespinRPM:     -205,
+	}
+
+	metrics, err := ParseShotBallMetrics(toHexList(encodeSimulatedBall(shot)))
+	if err != nil {
+		t.Fatalf("ParseShotBallMetrics() error = %v", err)
+	}
+	ApplyOmniBallValidityBitmask(metrics)
+
+	if math.Abs(metrics.BallSpeedMPS-62.5) > 0.01 {
+		t.Errorf("BallSpeedMPS = %v, want 62.5", metrics.BallSpeedMPS)
+	}
+	if math.Abs(metrics.VerticalAngle-12.25) > 0.01 {
+		t.Errorf("VerticalAngle = %v, want 12.25", metrics.VerticalAngle)
+	}
+	if math.Abs(metrics.HorizontalAngle+1.5) > 0.01 {
+		t.Errorf("HorizontalAngle = %v, want -1.5", metrics.HorizontalAngle)
+	}
+	if math.Abs(metrics.SpinAxis+4.2) > 0.01 {
+		t.Errorf("SpinAxis = %v, want -4.2", metrics.SpinAxis)
+	}
+	if metrics.TotalspinRPM != 2800 || metrics.BackspinRPM != 2792 || metrics.SidespinRPM != -205 {
+		t.Errorf("spin = %d/%d/%d, want 2800/2792/-205", metrics.TotalspinRPM, metrics.BackspinRPM, metrics.SidespinRPM)
+	}
+	if !metrics.IsBallSpeedValid || !metrics.IsTotalSpinValid || !metrics.IsSpinAxisValid || !metrics.IsBackspinValid || !metrics.IsSidespinValid {
+		t.Errorf("expected every ball field to be valid, got %+v", metrics)
+	}
+}
+
+func TestEncodeSimulatedClub(t *testing.T) {
+	club := &SimulatedClub{PathAngle: 2.5, FaceAngle: -1.25, AttackAngle: -3, DynamicLoftAngle: 14, ClubSpeed: 42.5}
+
+	home, err := ParseShotClubMetrics(toHexList(encodeSimulatedClub(club, false)))
+	if err != nil {
+		t.Fatalf("ParseShotClubMetrics() error = %v", err)
+	}
+	if home.PathAngle != 2.5 || home.FaceAngle != -1.25 || home.AttackAngle != -3 || home.DynamicLoftAngle != 14 {
+		t.Errorf("home club metrics = %+v", home)
+	}
+
+	omni, err := ParseOmniShotClubMetrics(toHexList(encodeSimulatedClub(club, true)))
+	if err != nil {
+		t.Fatalf("ParseOmniShotClubMetrics() error = %v", err)
+	}
+	if omni.ClubSpeed != 42.5 || !omni.IsClubSpeedValid {
+		t.Errorf("omni club speed = %v (valid %v), want 42.5", omni.ClubSpeed, omni.IsClubSpeedValid)
+	}
+	if omni.IsSmashFactorValid || omni.IsImpactHorizontalValid {
+		t.Errorf("expected smash factor and impact location to be invalid, got %+v", omni)
+	}
+}
+
+func TestSimulatorInjectedEventsRequireConnection(t *testing.T) {
+	sim := NewSimulatorBluetoothClient(SimulatorConfig{})
+
+	if err := sim.PlaceBall(); err != ErrSimulatorNotConnected {
+		t.Errorf("PlaceBall() error = %v, want ErrSimulatorNotConnected", err)
+	}
+	if err := sim.SimulateDisconnect(); err != ErrSimulatorNotConnected {
+		t.Errorf("SimulateDisconnect() error = %v, want ErrSimulatorNotConnected", err)
+	}
+	if err := sim.SetBatteryLevel(101); err == nil {
+		t.Error("SetBatteryLevel(101) should fail")
+	}
+}
+
+func TestSimulatorManualModeInjectsEvents(t *testing.T) {
+	sim := NewSimulatorBluetoothClient(SimulatorConfig{})
+	sim.SetManual(true)
+	if err := sim.Connect("SquareGolf(****)", ""); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer sim.Disconnect()
+
+	var notifications [][]byte
+	if err := sim.StartNotifications(NotificationCharUUID, func(data []byte) {
+		notifications = append(notifications, data)
+	}); err != nil {
+		t.Fatalf("StartNotifications() error = %v", err)
+	}
+
+	// Activating ball detection must not start the timed loop in manual mode
+	sim.handleCommandData([]byte{0x11, 0x81})
+	sim.lock.RLock()
+	loopStarted := sim.ballDetectionCancel != nil
+	sim.lock.RUnlock()
+	if loopStarted {
+		t.Fatal("ball detection loop started in manual mode")
+	}
+
+	if err := sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+	status := sim.ControlStatus()
+	if !status.Manual || !status.BallReady || !status.BallDetected {
+		t.Errorf("ControlStatus() = %+v, want manual with the ball ready", status)
+	}
+	if len(notifications) != 2 {
+		t.Fatalf("got %d notifications, want status and sensor data", len(notifications))
+	}
+	sensor, err := ParseSensorData(toHexList(notifications[1]))
+	if err != nil || !sensor.BallReady {
+		t.Errorf("sensor notification = %+v (err %v), want ball ready", sensor, err)
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Bounds on injected faults
+const (
+	MaxSimulatorFaultCount   = 10000
+	MaxSimulatorFaultDelayMs = 30000
+)
+
+// SimulatorFaults are error conditions injected into the simulated device on
+// demand, so tests can drive the connector's recovery paths without relying
+// on the random error rate. Counts go down as each fault is used.
+type SimulatorFaults struct {
+	FailWrites     int `json:"failWrites"`     // the next N writes fail
+	TruncateFrames int `json:"truncateFrames"` // the next N notifications are cut to half their length
+	DelayMs        int `json:"delayMs"`        // added to every read and write until cleared
+}
+
+// Validate checks the counts and delay are in range
+func (f SimulatorFaults) Validate() error {
+	if f.FailWrites < 0 || f.FailWrites > MaxSimulatorFaultCount ||
+		f.TruncateFrames < 0 || f.TruncateFrames > MaxSimulatorFaultCount {
+		return fmt.Errorf("fault counts must be between 0 and %d", MaxSimulatorFaultCount)
+	}
+	if f.DelayMs < 0 || f.DelayMs > MaxSimulatorFaultDelayMs {
+		return fmt.Errorf("fault delay must be between 0 and %d ms", MaxSimulatorFaultDelayMs)
+	}
+	return nil
+}
+
+// SetFaults replaces the injected faults; the zero value clears them
+func (s *SimulatorBluetoothClient) SetFaults(faults SimulatorFaults) error {
+	if err := faults.Validate(); err != nil {
+		return err
+	}
+	s.faultsMu.Lock()
+	s.faults = faults
+	s.faultsMu.Unlock()
+	log.Printf("Simulator: Faults set to %+v", faults)
+	return nil
+}
+
+// Faults returns the faults still to be injected
+func (s *SimulatorBluetoothClient) Faults() SimulatorFaults {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	return s.faults
+}
+
+// Flood sends count copies of the sensor notification for the current ball
+// state back to back, as a device stuck repeating itself would
+func (s *SimulatorBluetoothClient) Flood(count int) error {
+	if count < 1 || count > MaxSimulatorFaultCount {
+		return fmt.Errorf("flood count must be between 1 and %d", MaxSimulatorFaultCount)
+	}
+	handler, err := s.notificationHandler()
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	frame := s.generateSensorData(s.ballState)
+	s.lock.Unlock()
+
+	log.Printf("Simulator: Flooding %d notifications", count)
+	for i := 0; i < count; i++ {
+		handler(append([]byte(nil), frame...))
+	}
+	return nil
+}
+
+// takeWriteFailure reports whether the next write should fail, using up one
+// injected failure
+func (s *SimulatorBluetoothClient) takeWriteFailure() bool {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	if s.faults.FailWrites == 0 {
+		return false
+	}
+	s.faults.FailWrites--
+	return true
+}
+
+// faultDelay is the injected delay added to reads and writes
+func (s *SimulatorBluetoothClient) faultDelay() time.Duration {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	return time.Duration(s.faults.DelayMs) * time.Millisecond
+}
+
+// withFaults wraps a notification handler so injected truncation applies to
+// every notification the simulator sends through it
+func (s *SimulatorBluetoothClient) withFaults(handler func([]byte)) func([]byte) {
+	return func(data []byte) {
+		s.faultsMu.Lock()
+		truncate := s.faults.TruncateFrames > 0
+		if truncate {
+			s.faults.TruncateFrames--
+		}
+		s.faultsMu.Unlock()
+
+		if truncate {
+			data = data[:len(data)/2]
+		}
+		handler(data)
+	}
+}
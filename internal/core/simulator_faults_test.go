@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestSimulatorFaults(t *testing.T) {
+	sim := NewSimulatorBluetoothClient(SimulatorConfig{})
+	if err := sim.Connect("SquareGolf", ""); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer sim.Disconnect()
+
+	var frames [][]byte
+	if err := sim.StartNotifications(NotificationCharUUID, func(data []byte) {
+		frames = append(frames, data)
+	}); err != nil {
+		t.Fatalf("StartNotifications() error = %v", err)
+	}
+
+	if err := sim.SetFaults(SimulatorFaults{FailWrites: 2, TruncateFrames: 1}); err != nil {
+		t.Fatalf("SetFaults() error = %v", err)
+	}
+	heartbeat := []byte{0x11, 0x83}
+	for i := 0; i < 2; i++ {
+		if err := sim.WriteCharacteristic(CommandCharUUID, heartbeat); err == nil {
+			t.Errorf("Write %d succeeded, want an injected failure", i+1)
+		}
+	}
+	if err := sim.WriteCharacteristic(CommandCharUUID, heartbeat); err != nil {
+		t.Errorf("Write after the injected failures error = %v", err)
+	}
+
+	if err := sim.Flood(3); err != nil {
+		t.Fatalf("Flood() error = %v", err)
+	}
+	if len(frames) != 3 || len(frames[0]) != 8 || len(frames[1]) != 17 {
+		t.Fatalf("Expected 3 frames with only the first truncated, got %x", frames)
+	}
+	if faults := sim.Faults(); faults != (SimulatorFaults{}) {
+		t.Errorf("Expected every fault used up, got %+v", faults)
+	}
+
+	if err := sim.SetFaults(SimulatorFaults{DelayMs: -1}); err == nil {
+		t.Error("Expected a negative delay to be rejected")
+	}
+	if err := sim.Flood(0); err == nil {
+		t.Error("Expected an empty flood to be rejected")
+	}
+}
@@ -28,6 +28,17 @@ type SimulatorBluetoothClient struct {
 	ballDetectionCancel     func()           // Cancel function for the ball detection simulation
 	deviceName              string           // Added to match the new BluetoothClient interface
 	commandChan             chan commandData // Channel for processing commands asynchronously
+	clock                   Clock
+	manual                  bool           // Ball and shot events only happen when injected
+	injectedClub            *SimulatedClub // Club data for the next club metrics request
+	onConnectionLost        func()
+	inactivityTimeout       time.Duration // Disconnect after this long without a command
+	paired                  bool
+	seriesMu                sync.Mutex
+	series                  ShotSeriesStatus // the shot series running or last run
+	seriesCancel            func()
+	faultsMu                sync.Mutex
+	faults                  SimulatorFaults // injected on demand, see SetFaults
 }
 
 // commandData represents a command to be processed asynchronously
@@ -43,6 +54,8 @@ type SimulatorConfig struct {
 	ErrorRate           float64
 	ResponseDelay       time.Duration
 	SimulateOmni        bool
+	InactivityTimeout   time.Duration // Defaults to DefaultDeviceTimeout
+	Clock               Clock         // Defaults to the real clock
 }
 
 // NewSimulatorBluetoothClient creates a new simulator Bluetooth client
@@ -50,6 +63,12 @@ func NewSimulatorBluetoothClient(config SimulatorConfig) *SimulatorBluetoothClie
 	if config.InitialBatteryLevel <= 0 {
 		config.InitialBatteryLevel = 80 // Default to 80% battery if not specified
 	}
+	if config.Clock == nil {
+		config.Clock = RealClock()
+	}
+	if config.InactivityTimeout <= 0 {
+		config.InactivityTimeout = DefaultDeviceTimeout
+	}
 
 	sim := &SimulatorBluetoothClient{
 		connected:               false,
@@ -61,14 +80,18 @@ func NewSimulatorBluetoothClient(config SimulatorConfig) *SimulatorBluetoothClie
 		commandChan:             make(chan commandData, 10), // Buffer size of 10
 		config:                  config,
 		errorRate:               config.ErrorRate,
-		lastActivity:            time.Now(),
+		lastActivity:            config.Clock.Now(),
 		inactivityMonitorActive: false,
-		rand:                    rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:                    rand.New(rand.NewSource(config.Clock.Now().UnixNano())),
+		clock:                   config.Clock,
 		ballDetectionCancel:     nil,
+		inactivityTimeout:       config.InactivityTimeout,
 	}
 
 	// Initialize default characteristic values
 	sim.characteristics[BatteryLevelCharUUID] = []byte{byte(config.InitialBatteryLevel)}
+	sim.characteristics[DISModelNumberCharUUID] = []byte("SquareGolf Simulator")
+	sim.characteristics[DISFirmwareRevisionCharUUID] = []byte("1.0.0")
 
 	// Start the command processor
 	go sim.processCommands()
@@ -94,10 +117,10 @@ func (s *SimulatorBluetoothClient) Connect(deviceName, deviceAddress string) err
 	log.Printf("Simulator: Stored device name: %s", s.deviceName)
 
 	// Simulate connection delay
-	time.Sleep(s.config.ResponseDelay)
+	s.clock.Sleep(s.config.ResponseDelay)
 	s.connected = true
 	s.deviceState = DeviceStateIdle
-	s.lastActivity = time.Now()
+	s.lastActivity = s.clock.Now()
 	s.inactivityMonitorActive = false // Reset the flag before starting the monitor
 
 	// Start battery drain simulation in background
@@ -126,7 +149,7 @@ func (s *SimulatorBluetoothClient) Disconnect() error {
 // Internal method to handle disconnection without locking
 func (s *SimulatorBluetoothClient) performDisconnection() {
 	// Simulate disconnection delay
-	time.Sleep(s.config.ResponseDelay / 2)
+	s.clock.Sleep(s.config.ResponseDelay / 2)
 
 	// Cancel any active ball detection simulation
 	if s.ballDetectionCancel != nil {
@@ -152,7 +175,7 @@ func (s *SimulatorBluetoothClient) WriteCharacteristic(uuid string, data []byte)
 	}
 
 	// Update activity timestamp
-	s.lastActivity = time.Now()
+	s.lastActivity = s.clock.Now()
 
 	// Store the written data for later use
 	s.characteristics[uuid] = data
@@ -168,10 +191,10 @@ func (s *SimulatorBluetoothClient) WriteCharacteristic(uuid string, data []byte)
 	s.lock.Unlock()
 
 	// Simulate write delay (after unlocking)
-	time.Sleep(s.config.ResponseDelay)
+	s.clock.Sleep(s.config.ResponseDelay + s.faultDelay())
 
-	// Randomly fail writes based on error rate
-	if s.simulateError() {
+	// Fail injected writes, and others randomly based on error rate
+	if s.takeWriteFailure() || s.simulateError() {
 		return fmt.Errorf("write failed: connection interrupted")
 	}
 
@@ -201,7 +224,7 @@ func (s *SimulatorBluetoothClient) ReadCharacteristic(uuid string) ([]byte, erro
 	}
 
 	// Simulate read delay
-	time.Sleep(s.config.ResponseDelay)
+	s.clock.Sleep(s.config.ResponseDelay + s.faultDelay())
 
 	// Randomly fail reads based on error rate
 	if s.simulateError() {
@@ -232,13 +255,13 @@ func (s *SimulatorBluetoothClient) StartNotifications(uuid string, handler func(
 	}
 
 	// Simulate setup delay
-	time.Sleep(s.config.ResponseDelay)
+	s.clock.Sleep(s.config.ResponseDelay)
 
-	// Store the notification handler
-	s.notifyHandlers[uuid] = handler
+	// Store the notification handler, applying any injected faults
+	s.notifyHandlers[uuid] = s.withFaults(handler)
 
 	// Update activity timestamp
-	s.lastActivity = time.Now()
+	s.lastActivity = s.clock.Now()
 
 	return nil
 }
@@ -256,7 +279,7 @@ func (s *SimulatorBluetoothClient) StopNotifications(uuid string) error {
 	delete(s.notifyHandlers, uuid)
 
 	// Update activity timestamp
-	s.lastActivity = time.Now()
+	s.lastActivity = s.clock.Now()
 
 	return nil
 }
@@ -311,10 +334,10 @@ func (s *SimulatorBluetoothClient) GetConnectedDeviceManufacturerData() string {
 
 // simulateBatteryDrain simulates battery drain over time
 func (s *SimulatorBluetoothClient) simulateBatteryDrain() {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := s.clock.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for range ticker.C() {
 		s.lock.Lock()
 		if !s.connected {
 			s.lock.Unlock()
@@ -352,7 +375,7 @@ func (s *SimulatorBluetoothClient) simulateLaunchDataNotification(uuid string, h
 	// For now we'll just send some dummy data occasionally
 
 	// Check if we should send a notification now
-	if time.Now().Second()%5 == 0 { // Every 5 seconds
+	if s.clock.Now().Second()%5 == 0 { // Every 5 seconds
 		// In a real implementation, you would format data according to your device's protocol
 		data := []byte{0x01, 0x02, 0x03, 0x04} // Dummy data
 		handler(data)
@@ -361,7 +384,7 @@ func (s *SimulatorBluetoothClient) simulateLaunchDataNotification(uuid string, h
 
 // simulateError returns true if an error should be simulated based on error rate
 func (s *SimulatorBluetoothClient) simulateError() bool {
-	return (s.errorRate > 0) && (s.errorRate > (float64(time.Now().UnixNano()%100) / 100.0))
+	return (s.errorRate > 0) && (s.errorRate > (float64(s.clock.Now().UnixNano()%100) / 100.0))
 }
 
 // SetErrorRate allows changing the simulated error rate
@@ -377,9 +400,26 @@ func (s *SimulatorBluetoothClient) SetErrorRate(rate float64) {
 	s.errorRate = rate
 }
 
+// SetInactivityTimeout changes how long the simulated device waits without a
+// command before it disconnects
+func (s *SimulatorBluetoothClient) SetInactivityTimeout(timeout time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if timeout > 0 {
+		s.inactivityTimeout = timeout
+	}
+}
+
+// InactivityTimeout returns how long the simulated device waits without a
+// command before it disconnects
+func (s *SimulatorBluetoothClient) InactivityTimeout() time.Duration {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inactivityTimeout
+}
+
 // startInactivityMonitor starts a continuous monitor that checks for inactivity
 func (s *SimulatorBluetoothClient) startInactivityMonitor() {
-	const inactivityTimeout = 10 * time.Second
 	const checkInterval = 1 * time.Second
 
 	// If already monitoring, don't start another monitor
@@ -390,12 +430,12 @@ func (s *SimulatorBluetoothClient) startInactivityMonitor() {
 	s.inactivityMonitorActive = true
 
 	// Create a ticker that checks for inactivity every second
-	ticker := time.NewTicker(checkInterval)
+	ticker := s.clock.NewTicker(checkInterval)
 
 	go func() {
 		defer ticker.Stop()
 
-		for range ticker.C {
+		for range ticker.C() {
 			s.lock.Lock()
 
 			if !s.connected || !s.inactivityMonitorActive {
@@ -403,8 +443,8 @@ func (s *SimulatorBluetoothClient) startInactivityMonitor() {
 				return
 			}
 
-			elapsedSinceActivity := time.Since(s.lastActivity)
-			if elapsedSinceActivity >= inactivityTimeout {
+			elapsedSinceActivity := s.clock.Since(s.lastActivity)
+			if elapsedSinceActivity >= s.inactivityTimeout {
 				log.Printf("Disconnecting due to inactivity (no communication for %v)", elapsedSinceActivity)
 				s.performDisconnection()
 				s.lock.Unlock()
@@ -439,7 +479,7 @@ func (s *SimulatorBluetoothClient) handleCommandData(data []byte) {
 	if len(data) > 1 && data[0] == 0x11 && data[1] == 0x83 {
 		// Explicitly update the lastActivity timestamp to prevent disconnection
 		s.lock.Lock()
-		s.lastActivity = time.Now()
+		s.lastActivity = s.clock.Now()
 		s.lock.Unlock()
 		return
 	}
@@ -467,10 +507,12 @@ func (s *SimulatorBluetoothClient) handleCommandData(data []byte) {
 
 		// Send club metrics in response
 		handler := s.notifyHandlers[NotificationCharUUID]
-		if handler != nil {
-			s.sendClubMetrics(handler)
-		} else {
+		if handler == nil {
 			log.Println("Simulator: No notification handler registered for club metrics")
+		} else if club := s.takeInjectedClub(); club != nil {
+			handler(encodeSimulatedClub(club, s.config.SimulateOmni))
+		} else {
+			s.sendClubMetrics(handler)
 		}
 		return
 	}
@@ -490,17 +532,16 @@ func (s *SimulatorBluetoothClient) handleCommandData(data []byte) {
 		s.deviceState = DeviceStateBallDetection
 		// Reset ball state to none when entering ball detection mode
 		s.ballState = BallStateNone
+		manual := s.manual
 		s.lock.Unlock()
 
-		// Create a new context with cancellation for this simulation
-		ctx, cancel := context.WithCancel(context.Background())
-
-		s.lock.Lock()
-		s.ballDetectionCancel = cancel
-		s.lock.Unlock()
+		// In manual mode the ball is placed and hit from the test bench
+		if manual {
+			return
+		}
 
 		// Start ball detection in a separate goroutine to avoid blocking
-		go s.simulateBallDetection(ctx)
+		s.startBallDetection()
 	}
 }
 
@@ -536,7 +577,7 @@ func (s *SimulatorBluetoothClient) simulateBallDetection(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(500 * time.Millisecond):
+			case <-s.clock.After(500 * time.Millisecond):
 				continue
 			}
 		}
@@ -545,7 +586,7 @@ func (s *SimulatorBluetoothClient) simulateBallDetection(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(3 * time.Second):
+		case <-s.clock.After(3 * time.Second):
 			// Continue with simulation
 		}
 
@@ -569,7 +610,7 @@ func (s *SimulatorBluetoothClient) simulateBallDetection(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(1500 * time.Millisecond):
+		case <-s.clock.After(1500 * time.Millisecond):
 			// Continue with simulation
 		}
 
@@ -593,7 +634,7 @@ func (s *SimulatorBluetoothClient) simulateBallDetection(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(4 * time.Second):
+		case <-s.clock.After(4 * time.Second):
 			// Continue with simulation
 		}
 
@@ -613,7 +654,7 @@ func (s *SimulatorBluetoothClient) simulateBallDetection(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(500 * time.Millisecond):
+		case <-s.clock.After(500 * time.Millisecond):
 			// Continue with simulation
 		}
 
@@ -623,7 +664,7 @@ func (s *SimulatorBluetoothClient) simulateBallDetection(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(500 * time.Millisecond):
+		case <-s.clock.After(500 * time.Millisecond):
 			if handler != nil {
 				s.sendClubMetrics(handler)
 				log.Println("Simulator: Club metrics sent")
@@ -640,7 +681,7 @@ func (s *SimulatorBluetoothClient) simulateBallDetection(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(2 * time.Second):
+		case <-s.clock.After(2 * time.Second):
 			// Continue with simulation
 		}
 	}
@@ -827,7 +868,7 @@ func (s *SimulatorBluetoothClient) StartScan(prefix string) error {
 	defer s.lock.Unlock()
 
 	// Simulate scan delay
-	time.Sleep(s.config.ResponseDelay)
+	s.clock.Sleep(s.config.ResponseDelay)
 
 	// Randomly fail scans based on error rate
 	if s.simulateError() {
@@ -843,7 +884,7 @@ func (s *SimulatorBluetoothClient) StopScan() error {
 	defer s.lock.Unlock()
 
 	// Simulate stop delay
-	time.Sleep(s.config.ResponseDelay / 2)
+	s.clock.Sleep(s.config.ResponseDelay / 2)
 
 	// Randomly fail stop based on error rate
 	if s.simulateError() {
@@ -0,0 +1,208 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// maxSeriesShots limits a series so a typo can't keep the simulator
+	// hitting shots for an hour
+	maxSeriesShots = 50
+	// defaultSeriesInterval leaves time for a shot's ball flight in the
+	// simulator before the next one
+	defaultSeriesInterval = 8 * time.Second
+	maxSeriesInterval     = 5 * time.Minute
+
+	mphToMPS = 1 / mpsToMPH
+)
+
+// ErrShotSeriesRunning is returned when a series is started while another is
+// still sending shots
+var ErrShotSeriesRunning = errors.New("a shot series is already running")
+
+// ShotSeries is a run of simulated shots at known values for checking that a
+// simulator scales ball flight and club data as expected. Each shot adds
+// Step to the one before, starting from Start.
+type ShotSeries struct {
+	Start           SimulatedShot `json:"start"`
+	Step            SimulatedShot `json:"step"`
+	Count           int           `json:"count"`
+	IntervalSeconds float64       `json:"intervalSeconds,omitempty"` // between shots; 8 if zero
+}
+
+// ShotSeriesPresets are ready-made series for a driver, a 7 iron and a
+// wedge, each stepping up ball speed and spin from a typical shot
+var ShotSeriesPresets = map[string]ShotSeries{
+	"driver": {
+		Start: SimulatedShot{BallSpeedMPS: 130 * mphToMPS, VerticalAngle: 12, TotalspinRPM: 2000, BackspinRPM: 2000,
+			Club: &SimulatedClub{DynamicLoftAngle: 13, ClubSpeed: 88 * mphToMPS}},
+		Step: SimulatedShot{BallSpeedMPS: 5 * mphToMPS, TotalspinRPM: 150, BackspinRPM: 150,
+			Club: &SimulatedClub{ClubSpeed: 3.4 * mphToMPS}},
+		Count: 10,
+	},
+	"7iron": {
+		Start: SimulatedShot{BallSpeedMPS: 100 * mphToMPS, VerticalAngle: 16, TotalspinRPM: 6000, BackspinRPM: 6000,
+			Club: &SimulatedClub{AttackAngle: -4, DynamicLoftAngle: 22, ClubSpeed: 75 * mphToMPS}},
+		Step: SimulatedShot{BallSpeedMPS: 4 * mphToMPS, TotalspinRPM: 200, BackspinRPM: 200,
+			Club: &SimulatedClub{ClubSpeed: 3 * mphToMPS}},
+		Count: 10,
+	},
+	"wedge": {
+		Start: SimulatedShot{BallSpeedMPS: 60 * mphToMPS, VerticalAngle: 28, TotalspinRPM: 7000, BackspinRPM: 7000,
+			Club: &SimulatedClub{AttackAngle: -5, DynamicLoftAngle: 40, ClubSpeed: 55 * mphToMPS}},
+		Step: SimulatedShot{BallSpeedMPS: 5 * mphToMPS, TotalspinRPM: 300, BackspinRPM: 300,
+			Club: &SimulatedClub{ClubSpeed: 4 * mphToMPS}},
+		Count: 10,
+	},
+}
+
+// Validate checks the series can be run
+func (series ShotSeries) Validate() error {
+	if series.Count < 1 || series.Count > maxSeriesShots {
+		return fmt.Errorf("shot count must be between 1 and %d", maxSeriesShots)
+	}
+	if series.IntervalSeconds < 0 || series.Interval() > maxSeriesInterval {
+		return fmt.Errorf("interval must be between 0 and %d seconds", int(maxSeriesInterval.Seconds()))
+	}
+	return nil
+}
+
+// Interval returns the pause between shots
+func (series ShotSeries) Interval() time.Duration {
+	if series.IntervalSeconds == 0 {
+		return defaultSeriesInterval
+	}
+	return time.Duration(series.IntervalSeconds * float64(time.Second))
+}
+
+// Shots returns the shots of the series in the order they are hit. Club data
+// is only stepped when Start has it.
+func (series ShotSeries) Shots() []SimulatedShot {
+	shots := make([]SimulatedShot, 0, series.Count)
+	for i := 0; i < series.Count; i++ {
+		n := float64(i)
+		start, step := series.Start, series.Step
+		shot := SimulatedShot{
+			BallSpeedMPS:    start.BallSpeedMPS + n*step.BallSpeedMPS,
+			VerticalAngle:   start.VerticalAngle + n*step.VerticalAngle,
+			HorizontalAngle: start.HorizontalAngle + n*step.HorizontalAngle,
+			TotalspinRPM:    start.TotalspinRPM + int16(i)*step.TotalspinRPM,
+			SpinAxis:        start.SpinAxis + n*step.SpinAxis,
+			BackspinRPM:     start.BackspinRPM + int16(i)*step.BackspinRPM,
+			SidespinRPM:     start.SidespinRPM + int16(i)*step.SidespinRPM,
+		}
+		if start.Club != nil {
+			club := *start.Club
+			if step.Club != nil {
+				club.PathAngle += n * step.Club.PathAngle
+				club.FaceAngle += n * step.Club.FaceAngle
+				club.AttackAngle += n * step.Club.AttackAngle
+				club.DynamicLoftAngle += n * step.Club.DynamicLoftAngle
+				club.ClubSpeed += n * step.Club.ClubSpeed
+			}
+			shot.Club = &club
+		}
+		shots = append(shots, shot)
+	}
+	return shots
+}
+
+// ShotSeriesStatus is the progress of the series running or last run
+type ShotSeriesStatus struct {
+	Running   bool            `json:"running"`
+	Sent      int             `json:"sent"`
+	Total     int             `json:"total"`
+	Cancelled bool            `json:"cancelled,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Shots     []SimulatedShot `json:"shots,omitempty"`
+}
+
+// StartSeries hits the shots of a series one after another in the
+// background, readying the ball before each as a player would. The
+// simulator is switched to manual mode until the series ends, so the timed
+// loop doesn't hit shots of its own in between.
+func (s *SimulatorBluetoothClient) StartSeries(series ShotSeries) error {
+	if err := series.Validate(); err != nil {
+		return err
+	}
+	if !s.ControlStatus().Connected {
+		return ErrSimulatorNotConnected
+	}
+
+	s.seriesMu.Lock()
+	if s.series.Running {
+		s.seriesMu.Unlock()
+		return ErrShotSeriesRunning
+	}
+	shots := series.Shots()
+	ctx, cancel := context.WithCancel(context.Background())
+	s.series = ShotSeriesStatus{Running: true, Total: len(shots), Shots: shots}
+	s.seriesCancel = cancel
+	s.seriesMu.Unlock()
+
+	wasManual := s.ControlStatus().Manual
+	s.SetManual(true)
+	log.Printf("Simulator: Starting a series of %d shots", len(shots))
+	go s.runSeries(ctx, shots, series.Interval(), wasManual)
+	return nil
+}
+
+// CancelSeries stops the series after the shot being hit, if any
+func (s *SimulatorBluetoothClient) CancelSeries() {
+	s.seriesMu.Lock()
+	defer s.seriesMu.Unlock()
+	if s.seriesCancel != nil {
+		s.seriesCancel()
+	}
+}
+
+// SeriesStatus returns the progress of the series running or last run
+func (s *SimulatorBluetoothClient) SeriesStatus() ShotSeriesStatus {
+	s.seriesMu.Lock()
+	defer s.seriesMu.Unlock()
+	return s.series
+}
+
+func (s *SimulatorBluetoothClient) runSeries(ctx context.Context, shots []SimulatedShot, interval time.Duration, wasManual bool) {
+	var err error
+	for i := range shots {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-s.clock.After(interval):
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err = s.ReadyBall(); err != nil {
+			break
+		}
+		if err = s.InjectShot(&shots[i]); err != nil {
+			break
+		}
+		s.seriesMu.Lock()
+		s.series.Sent = i + 1
+		s.seriesMu.Unlock()
+	}
+
+	s.seriesMu.Lock()
+	s.series.Running = false
+	s.series.Cancelled = ctx.Err() != nil
+	if err != nil {
+		s.series.Error = err.Error()
+	}
+	s.seriesCancel()
+	s.seriesCancel = nil
+	sent, total := s.series.Sent, s.series.Total
+	s.seriesMu.Unlock()
+
+	if !wasManual {
+		s.SetManual(false)
+	}
+	log.Printf("Simulator: Shot series ended after %d of %d shots", sent, total)
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestShotSeriesStepsEachShot(t *testing.T) {
+	series := ShotSeries{
+		Start: SimulatedShot{BallSpeedMPS: 50, VerticalAngle: 12, TotalspinRPM: 2000, BackspinRPM: 2000,
+			Club: &SimulatedClub{DynamicLoftAngle: 13, ClubSpeed: 35}},
+		Step:  SimulatedShot{BallSpeedMPS: 2, TotalspinRPM: 100, BackspinRPM: 100, Club: &SimulatedClub{ClubSpeed: 1.5}},
+		Count: 3,
+	}
+
+	shots := series.Shots()
+	if len(shots) != 3 {
+		t.Fatalf("got %d shots, want 3", len(shots))
+	}
+	for i, shot := range shots {
+		if want := 50 + 2*float64(i); shot.BallSpeedMPS != want {
+			t.Errorf("shot %d: BallSpeedMPS = %v, want %v", i, shot.BallSpeedMPS, want)
+		}
+		if want := int16(2000 + 100*i); shot.TotalspinRPM != want || shot.BackspinRPM != want {
+			t.Errorf("shot %d: spin = %d/%d, want %d", i, shot.TotalspinRPM, shot.BackspinRPM, want)
+		}
+		if shot.VerticalAngle != 12 {
+			t.Errorf("shot %d: VerticalAngle = %v, want 12", i, shot.VerticalAngle)
+		}
+		if shot.Club == nil || shot.Club.DynamicLoftAngle != 13 || shot.Club.ClubSpeed != 35+1.5*float64(i) {
+			t.Errorf("shot %d: Club = %+v", i, shot.Club)
+		}
+	}
+	if shots[0].Club == shots[1].Club || shots[0].Club == series.Start.Club {
+		t.Error("each shot should have its own club data")
+	}
+}
+
+func TestShotSeriesValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		series ShotSeries
+		valid  bool
+	}{
+		{"default interval", ShotSeries{Count: 10}, true},
+		{"no shots", ShotSeries{Count: 0}, false},
+		{"too many shots", ShotSeries{Count: maxSeriesShots + 1}, false},
+		{"negative interval", ShotSeries{Count: 1, IntervalSeconds: -1}, false},
+		{"long interval", ShotSeries{Count: 1, IntervalSeconds: 301}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.series.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() error = %v, want valid %v", tt.name, err, tt.valid)
+		}
+	}
+
+	if got := (ShotSeries{Count: 1}).Interval(); got != defaultSeriesInterval {
+		t.Errorf("Interval() = %v, want the default %v", got, defaultSeriesInterval)
+	}
+	if got := (ShotSeries{Count: 1, IntervalSeconds: 1.5}).Interval(); got != 1500*time.Millisecond {
+		t.Errorf("Interval() = %v, want 1.5s", got)
+	}
+}
+
+func TestShotSeriesPresetsAreValid(t *testing.T) {
+	for name, series := range ShotSeriesPresets {
+		if err := series.Validate(); err != nil {
+			t.Errorf("%s: Validate() error = %v", name, err)
+		}
+		shots := series.Shots()
+		last := shots[len(shots)-1]
+		if math.Abs(last.BallSpeedMPS*mpsToMPH) > 200 || last.TotalspinRPM <= shots[0].TotalspinRPM {
+			t.Errorf("%s: last shot %+v should step up from %+v", name, last, shots[0])
+		}
+	}
+}
+
+func TestStartSeriesRequiresConnection(t *testing.T) {
+	sim := NewSimulatorBluetoothClient(SimulatorConfig{})
+	if err := sim.StartSeries(ShotSeries{Count: 1}); err != ErrSimulatorNotConnected {
+		t.Errorf("StartSeries() error = %v, want ErrSimulatorNotConnected", err)
+	}
+	if err := sim.StartSeries(ShotSeries{}); err == nil || err == ErrSimulatorNotConnected {
+		t.Errorf("StartSeries() of an empty series error = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+// Simulators with a configurable spin convention
+const (
+	SimulatorGSPro        = "gspro"
+	SimulatorInfiniteTees = "infiniteTees"
+	SimulatorAwesomeGolf  = "awesomeGolf"
+)
+
+// Spin convention presets
+const (
+	// SpinPresetStandard mirrors spin axis and sidespin, the convention GSPro,
+	// Infinite Tees and Awesome Golf expect from this device
+	SpinPresetStandard = "standard"
+	// SpinPresetDevice sends the device's signs unchanged, reversing draw and
+	// fade relative to the standard preset
+	SpinPresetDevice = "device"
+	// SpinPresetMirrored is the standard preset with the start direction
+	// mirrored as well
+	SpinPresetMirrored = "mirrored"
+)
+
+// SpinConvention selects which of the device's signed values are flipped
+// before a shot is sent to a simulator
+type SpinConvention struct {
+	FlipSpinAxis bool `json:"flipSpinAxis"`
+	FlipSideSpin bool `json:"flipSideSpin"`
+	FlipHLA      bool `json:"flipHLA"`
+}
+
+// SpinConventionPresets returns the named conventions offered to users
+func SpinConventionPresets() map[string]SpinConvention {
+	return map[string]SpinConvention{
+		SpinPresetStandard: {FlipSpinAxis: true, FlipSideSpin: true},
+		SpinPresetDevice:   {},
+		SpinPresetMirrored: {FlipSpinAxis: true, FlipSideSpin: true, FlipHLA: true},
+	}
+}
+
+// DefaultSpinConventions returns each simulator's convention keyed by
+// simulator
+func DefaultSpinConventions() map[string]SpinConvention {
+	presets := SpinConventionPresets()
+	return map[string]SpinConvention{
+		SimulatorGSPro:        presets[SpinPresetStandard],
+		SimulatorInfiniteTees: presets[SpinPresetStandard],
+		SimulatorAwesomeGolf:  presets[SpinPresetStandard],
+	}
+}
+
+// ValidSpinConventionSimulator reports whether a simulator has a
+// configurable spin convention
+func ValidSpinConventionSimulator(simulator string) bool {
+	_, ok := DefaultSpinConventions()[simulator]
+	return ok
+}
+
+// SpinConventionFor returns the configured convention for a simulator,
+// falling back to the simulator's default
+func SpinConventionFor(conventions map[string]SpinConvention, simulator string) SpinConvention {
+	if convention, ok := conventions[simulator]; ok {
+		return convention
+	}
+	return DefaultSpinConventions()[simulator]
+}
+
+// Apply maps the device's spin axis, sidespin and horizontal launch angle
+// onto the convention
+func (c SpinConvention) Apply(spinAxis float64, sideSpin int16, horizontalAngle float64) (float64, int16, float64) {
+	if c.FlipSpinAxis {
+		spinAxis = -spinAxis
+	}
+	if c.FlipSideSpin {
+		sideSpin = -sideSpin
+	}
+	if c.FlipHLA {
+		horizontalAngle = -horizontalAngle
+	}
+	return spinAxis, sideSpin, horizontalAngle
+}
@@ -0,0 +1,41 @@
+package core
+
+import "testing"
+
+func TestSpinConvention_Apply(t *testing.T) {
+	presets := SpinConventionPresets()
+
+	tests := []struct {
+		preset              string
+		wantAxis            float64
+		wantSide            int16
+		wantHorizontalAngle float64
+	}{
+		{SpinPresetStandard, -5, -261, 1.5},
+		{SpinPresetDevice, 5, 261, 1.5},
+		{SpinPresetMirrored, -5, -261, -1.5},
+	}
+
+	for _, tt := range tests {
+		axis, side, hla := presets[tt.preset].Apply(5, 261, 1.5)
+		if axis != tt.wantAxis || side != tt.wantSide || hla != tt.wantHorizontalAngle {
+			t.Errorf("%s: Apply() = %v, %v, %v, want %v, %v, %v",
+				tt.preset, axis, side, hla, tt.wantAxis, tt.wantSide, tt.wantHorizontalAngle)
+		}
+	}
+}
+
+func TestSpinConventionFor(t *testing.T) {
+	standard := SpinConventionPresets()[SpinPresetStandard]
+	configured := map[string]SpinConvention{SimulatorGSPro: {}}
+
+	if got := SpinConventionFor(configured, SimulatorGSPro); got != (SpinConvention{}) {
+		t.Errorf("SpinConventionFor(gspro) = %+v, want the configured convention", got)
+	}
+	if got := SpinConventionFor(configured, SimulatorInfiniteTees); got != standard {
+		t.Errorf("SpinConventionFor(infiniteTees) = %+v, want the default %+v", got, standard)
+	}
+	if ValidSpinConventionSimulator("trackman") {
+		t.Error("expected an unknown simulator to be rejected")
+	}
+}
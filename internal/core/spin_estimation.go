@@ -0,0 +1,204 @@
+package core
+
+import (
+	"log"
+	"math"
+)
+
+const (
+	mpsToMPH            = 2.23694
+	minEstimatedSpinRPM = 1000
+	maxEstimatedSpinRPM = 11000
+)
+
+// SpinEstimator estimates total backspin for a shot that has no usable
+// measured spin. It returns false when it cannot produce an estimate.
+type SpinEstimator interface {
+	EstimateSpin(club *ClubType, ballMetrics *BallMetrics) (int16, bool)
+}
+
+// SpinCurve describes how backspin varies with ball speed and launch angle
+// for one club. Spin is BaseRPM at the reference speed and launch, adjusted
+// linearly away from them and clamped to MinRPM and MaxRPM.
+type SpinCurve struct {
+	BaseRPM         float64 `json:"baseRPM"`
+	ReferenceMPH    float64 `json:"referenceMPH"`
+	RPMPerMPH       float64 `json:"rpmPerMPH"`
+	ReferenceLaunch float64 `json:"referenceLaunch"`
+	RPMPerLaunchDeg float64 `json:"rpmPerLaunchDeg"`
+	MinRPM          float64 `json:"minRPM"`
+	MaxRPM          float64 `json:"maxRPM"`
+}
+
+// Backspin returns the curve's backspin for a ball speed and launch angle
+func (c SpinCurve) Backspin(speedMPH, launchDeg float64) float64 {
+	spin := c.BaseRPM +
+		c.RPMPerMPH*(speedMPH-c.ReferenceMPH) +
+		c.RPMPerLaunchDeg*(launchDeg-c.ReferenceLaunch)
+
+	minRPM, maxRPM := c.MinRPM, c.MaxRPM
+	if minRPM <= 0 {
+		minRPM = minEstimatedSpinRPM
+	}
+	if maxRPM <= 0 {
+		maxRPM = maxEstimatedSpinRPM
+	}
+	return math.Max(minRPM, math.Min(maxRPM, spin))
+}
+
+// Valid reports whether the curve can produce a usable estimate
+func (c SpinCurve) Valid() bool {
+	return c.BaseRPM > 0 && c.ReferenceMPH > 0 && (c.MaxRPM == 0 || c.MaxRPM >= c.MinRPM)
+}
+
+// DefaultSpinCurves returns per-club curves keyed by club name, fitted to
+// typical tour averages for speed, launch and spin.
+func DefaultSpinCurves() map[string]SpinCurve {
+	curve := func(base, speed, launch float64) SpinCurve {
+		return SpinCurve{
+			BaseRPM:         base,
+			ReferenceMPH:    speed,
+			RPMPerMPH:       15,
+			ReferenceLaunch: launch,
+			RPMPerLaunchDeg: 120,
+		}
+	}
+
+	return map[string]SpinCurve{
+		ClubDriver.Name():        curve(2700, 167, 10.9),
+		ClubWood3.Name():         curve(3650, 158, 9.2),
+		ClubWood5.Name():         curve(4350, 152, 9.4),
+		ClubWood7.Name():         curve(4700, 146, 10.5),
+		ClubIron4.Name():         curve(4850, 143, 10.4),
+		ClubIron5.Name():         curve(5350, 135, 12.1),
+		ClubIron6.Name():         curve(6250, 130, 14.1),
+		ClubIron7.Name():         curve(7100, 123, 16.3),
+		ClubIron8.Name():         curve(8000, 118, 18.1),
+		ClubIron9.Name():         curve(8650, 112, 20.4),
+		ClubPitchingWedge.Name(): curve(9300, 104, 24.2),
+		ClubApproachWedge.Name(): curve(9800, 95, 26.5),
+		ClubSandWedge.Name():     curve(10200, 85, 29.0),
+	}
+}
+
+// CurveSpinEstimator estimates spin from per-club curves. When the club has
+// no curve, the curve whose reference speed is closest to the ball speed is
+// used instead.
+type CurveSpinEstimator struct {
+	curves map[string]SpinCurve
+}
+
+// NewCurveSpinEstimator creates an estimator from curves keyed by club name.
+// Invalid curves are ignored and the defaults are used if none remain.
+func NewCurveSpinEstimator(curves map[string]SpinCurve) *CurveSpinEstimator {
+	valid := make(map[string]SpinCurve, len(curves))
+	for name, curve := range curves {
+		if curve.Valid() {
+			valid[name] = curve
+		}
+	}
+	if len(valid) == 0 {
+		valid = DefaultSpinCurves()
+	}
+	return &CurveSpinEstimator{curves: valid}
+}
+
+// EstimateSpin implements SpinEstimator
+func (e *CurveSpinEstimator) EstimateSpin(club *ClubType, ballMetrics *BallMetrics) (int16, bool) {
+	if ballMetrics == nil || ballMetrics.BallSpeedMPS <= 0 {
+		return 0, false
+	}
+	speedMPH := ballMetrics.BallSpeedMPS * mpsToMPH
+
+	curve, ok := SpinCurve{}, false
+	if club != nil {
+		curve, ok = e.curves[club.Name()]
+	}
+	if !ok {
+		bestDiff := math.Inf(1)
+		for _, candidate := range e.curves {
+			if diff := math.Abs(candidate.ReferenceMPH - speedMPH); diff < bestDiff {
+				curve, bestDiff = candidate, diff
+			}
+		}
+	}
+
+	return int16(math.Round(curve.Backspin(speedMPH, ballMetrics.VerticalAngle))), true
+}
+
+// ApplyEstimatedSpin fills in spin values on ballMetrics from an estimated
+// total spin and marks them as estimated. Side spin is derived from the spin
+// axis when the axis was measured.
+func ApplyEstimatedSpin(ballMetrics *BallMetrics, totalSpin int16) {
+	if ballMetrics == nil {
+		return
+	}
+
+	axis := 0.0
+	if ballMetrics.IsSpinAxisValid {
+		axis = ballMetrics.SpinAxis * math.Pi / 180
+	}
+
+	ballMetrics.TotalspinRPM = totalSpin
+	ballMetrics.BackspinRPM = int16(math.Round(float64(totalSpin) * math.Cos(axis)))
+	ballMetrics.SidespinRPM = int16(math.Round(float64(totalSpin) * math.Sin(axis)))
+	ballMetrics.IsTotalSpinValid = true
+	ballMetrics.IsBackspinValid = true
+	ballMetrics.IsSidespinValid = true
+	ballMetrics.SpinEstimated = true
+}
+
+// SetSpinEstimator replaces the model used to estimate missing spin
+func (lm *LaunchMonitor) SetSpinEstimator(estimator SpinEstimator) {
+	lm.spinMu.Lock()
+	defer lm.spinMu.Unlock()
+	lm.spinEstimator = estimator
+}
+
+// SetSpinEstimationEnabled sets whether spin is estimated automatically for
+// Standard mode shots and shots that come back without spin
+func (lm *LaunchMonitor) SetSpinEstimationEnabled(enabled bool) {
+	lm.spinMu.Lock()
+	defer lm.spinMu.Unlock()
+	lm.spinEstimation = enabled
+}
+
+// estimateSpin applies the spin estimator to ballMetrics. It returns false
+// if no estimate could be made.
+func (lm *LaunchMonitor) estimateSpin(ballMetrics *BallMetrics) bool {
+	lm.spinMu.RLock()
+	estimator := lm.spinEstimator
+	lm.spinMu.RUnlock()
+
+	if estimator == nil {
+		estimator = NewCurveSpinEstimator(nil)
+	}
+
+	spin, ok := estimator.EstimateSpin(lm.stateManager.GetClub(), ballMetrics)
+	if !ok {
+		return false
+	}
+	ApplyEstimatedSpin(ballMetrics, spin)
+	return true
+}
+
+// applyAutomaticSpinEstimation estimates spin for full shots in Standard mode
+// or when the device reported no spin
+func (lm *LaunchMonitor) applyAutomaticSpinEstimation(ballMetrics *BallMetrics) {
+	lm.spinMu.RLock()
+	enabled := lm.spinEstimation
+	lm.spinMu.RUnlock()
+
+	if !enabled || ballMetrics.ShotType == ShotTypePutt || !ballMetrics.IsBallSpeedValid {
+		return
+	}
+
+	standardMode := lm.currentSpinMode() == Standard
+	if !standardMode && ballMetrics.TotalspinRPM != 0 {
+		return
+	}
+
+	if lm.estimateSpin(ballMetrics) {
+		log.Printf("LaunchMonitor: Estimated %d rpm spin", ballMetrics.TotalspinRPM)
+	}
+}
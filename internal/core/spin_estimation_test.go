@@ -0,0 +1,119 @@
+package core
+
+import "testing"
+
+func TestCurveSpinEstimator_DefaultCurves(t *testing.T) {
+	estimator := NewCurveSpinEstimator(nil)
+
+	tests := []struct {
+		club   ClubType
+		speed  float64
+		launch float64
+		want   int16
+	}{
+		{ClubDriver, 167, 10.9, 2700},
+		{ClubIron7, 123, 16.3, 7100},
+		{ClubIron7, 113, 16.3, 6950},
+		{ClubIron7, 123, 18.3, 7340},
+	}
+
+	for _, tt := range tests {
+		club := tt.club
+		got, ok := estimator.EstimateSpin(&club, &BallMetrics{BallSpeedMPS: tt.speed / mpsToMPH, VerticalAngle: tt.launch})
+		if !ok || got != tt.want {
+			t.Errorf("%s at %.0f mph, %.1f deg: expected %d rpm, got %d (ok=%v)", tt.club.Name(), tt.speed, tt.launch, tt.want, got, ok)
+		}
+	}
+}
+
+func TestCurveSpinEstimator_UnknownClubUsesNearestSpeed(t *testing.T) {
+	estimator := NewCurveSpinEstimator(nil)
+
+	got, ok := estimator.EstimateSpin(nil, &BallMetrics{BallSpeedMPS: 104 / mpsToMPH, VerticalAngle: 24.2})
+	if !ok || got != 9300 {
+		t.Errorf("Expected pitching wedge curve (9300 rpm), got %d", got)
+	}
+
+	if _, ok := estimator.EstimateSpin(nil, &BallMetrics{}); ok {
+		t.Error("Expected no estimate without ball speed")
+	}
+}
+
+func TestCurveSpinEstimator_CustomCurves(t *testing.T) {
+	estimator := NewCurveSpinEstimator(map[string]SpinCurve{
+		ClubDriver.Name(): {BaseRPM: 2200, ReferenceMPH: 150, MinRPM: 2000, MaxRPM: 2500},
+		"Invalid":         {BaseRPM: -1},
+	})
+
+	driver := ClubDriver
+	got, _ := estimator.EstimateSpin(&driver, &BallMetrics{BallSpeedMPS: 150 / mpsToMPH})
+	if got != 2200 {
+		t.Errorf("Expected custom driver curve (2200 rpm), got %d", got)
+	}
+	if len(estimator.curves) != 1 {
+		t.Errorf("Expected invalid curve to be dropped, got %d curves", len(estimator.curves))
+	}
+
+	clamped := SpinCurve{BaseRPM: 2200, ReferenceMPH: 150, RPMPerMPH: 100, MinRPM: 2000, MaxRPM: 2500}
+	if spin := clamped.Backspin(170, 0); spin != 2500 {
+		t.Errorf("Expected spin clamped to 2500, got %v", spin)
+	}
+}
+
+func TestApplyEstimatedSpin(t *testing.T) {
+	metrics := &BallMetrics{SpinAxis: 90, IsSpinAxisValid: true}
+	ApplyEstimatedSpin(metrics, 3000)
+
+	if metrics.TotalspinRPM != 3000 || metrics.BackspinRPM != 0 || metrics.SidespinRPM != 3000 {
+		t.Errorf("Expected all spin to be side spin, got %+v", metrics)
+	}
+	if !metrics.SpinEstimated || !metrics.IsTotalSpinValid || !metrics.IsBackspinValid || !metrics.IsSidespinValid {
+		t.Error("Expected estimated spin to be marked valid and estimated")
+	}
+}
+
+func TestAutomaticSpinEstimation(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	// Disabled by default
+	lm.NotificationHandler("", noSpinShot)
+	if metrics := sm.GetLastBallMetrics(); metrics == nil || metrics.SpinEstimated {
+		t.Fatalf("Expected shot without estimated spin, got %+v", metrics)
+	}
+
+	lm.SetSpinEstimationEnabled(true)
+	sm.SetLastBallMetrics(nil)
+	lm.NotificationHandler("", noSpinShot)
+	metrics := sm.GetLastBallMetrics()
+	if metrics == nil || !metrics.SpinEstimated || metrics.TotalspinRPM == 0 {
+		t.Errorf("Expected spin to be estimated for a zero-spin shot, got %+v", metrics)
+	}
+
+	// Putts are left alone
+	putter := ClubPutter
+	sm.SetClub(&putter)
+	sm.SetLastBallMetrics(nil)
+	lm.NotificationHandler("", noSpinShot)
+	if metrics := sm.GetLastBallMetrics(); metrics == nil || metrics.SpinEstimated {
+		t.Errorf("Expected putt not to get estimated spin, got %+v", metrics)
+	}
+}
+
+func TestAutomaticSpinEstimation_StandardModeReplacesSpin(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	lm.SetSpinEstimationEnabled(true)
+
+	standard := Standard
+	sm.SetSpinMode(&standard)
+
+	shot := append([]byte(nil), noSpinShot...)
+	shot[9] = 0x10 // Total spin (16 rpm)
+	lm.NotificationHandler("", shot)
+
+	metrics := sm.GetLastBallMetrics()
+	if metrics == nil || !metrics.SpinEstimated || metrics.TotalspinRPM == 16 {
+		t.Errorf("Expected Standard mode spin to be estimated, got %+v", metrics)
+	}
+}
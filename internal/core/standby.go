@@ -0,0 +1,211 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// sleepScheduleTimeFormat is the clock time format used by sleep schedules
+const sleepScheduleTimeFormat = "15:04"
+
+// sleepScheduleInterval is how often the sleep schedule is checked
+const sleepScheduleInterval = 30 * time.Second
+
+// SleepSchedule puts the device in standby every day between two local clock
+// times. A window may run past midnight, e.g. 22:00 to 07:00.
+type SleepSchedule struct {
+	Enabled bool   `json:"enabled"`
+	Sleep   string `json:"sleep"` // HH:MM
+	Wake    string `json:"wake"`  // HH:MM
+}
+
+// DefaultSleepSchedule returns the schedule switched off, with an overnight
+// window ready to enable
+func DefaultSleepSchedule() SleepSchedule {
+	return SleepSchedule{Enabled: false, Sleep: "23:00", Wake: "07:00"}
+}
+
+// Valid reports whether both times parse and differ
+func (s SleepSchedule) Valid() bool {
+	sleep, err := time.Parse(sleepScheduleTimeFormat, s.Sleep)
+	if err != nil {
+		return false
+	}
+	wake, err := time.Parse(sleepScheduleTimeFormat, s.Wake)
+	if err != nil {
+		return false
+	}
+	return !sleep.Equal(wake)
+}
+
+// Asleep reports whether t falls inside the sleep window
+func (s SleepSchedule) Asleep(t time.Time) bool {
+	if !s.Enabled || !s.Valid() {
+		return false
+	}
+	sleep, _ := time.Parse(sleepScheduleTimeFormat, s.Sleep)
+	wake, _ := time.Parse(sleepScheduleTimeFormat, s.Wake)
+	now := t.Hour()*60 + t.Minute()
+	start := sleep.Hour()*60 + sleep.Minute()
+	end := wake.Hour()*60 + wake.Minute()
+	if start < end {
+		return now >= start && now < end
+	}
+	return now >= start || now < end
+}
+
+// Standby deactivates ball detection and stops the heartbeat and charge
+// polling so the device can sleep. Detection that was armed, or switched off
+// by the idle policy, is re-armed by Wake.
+func (lm *LaunchMonitor) Standby() error {
+	lm.standbyMu.Lock()
+	if lm.standby {
+		lm.standbyMu.Unlock()
+		return nil
+	}
+	lm.standby = true
+	lm.standbyMu.Unlock()
+
+	lm.detectStateMu.Lock()
+	resume := lm.detectModeActive || lm.resumeDetection
+	lm.detectStateMu.Unlock()
+	resume = resume || lm.IsIdle()
+
+	log.Println("LaunchMonitor: Entering standby")
+	lm.stopHeartbeatTask()
+	lm.stopChargePolling()
+
+	var err error
+	if lm.bluetoothClient != nil && lm.bluetoothClient.IsConnected() {
+		err = lm.DeactivateBallDetection()
+	} else {
+		lm.setIdle(false)
+	}
+
+	lm.detectStateMu.Lock()
+	lm.resumeDetection = resume
+	lm.detectStateMu.Unlock()
+
+	lm.stateManager.SetDeviceStandby(true)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate ball detection for standby: %w", err)
+	}
+	return nil
+}
+
+// InStandby reports whether the device was put in standby
+func (lm *LaunchMonitor) InStandby() bool {
+	lm.standbyMu.Lock()
+	defer lm.standbyMu.Unlock()
+	return lm.standby
+}
+
+// leaveStandby restarts the heartbeat and re-arms ball detection if it was
+// armed before standby
+func (lm *LaunchMonitor) leaveStandby() error {
+	lm.clearStandby()
+	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
+		// Detection is re-armed by restoreDeviceState on the next connection
+		return nil
+	}
+
+	lm.detectStateMu.Lock()
+	resume := lm.resumeDetection
+	lm.resumeDetection = false
+	lm.detectStateMu.Unlock()
+	if !resume {
+		return nil
+	}
+	return lm.ActivateBallDetection()
+}
+
+// clearStandby restarts the heartbeat and charge polling. Arming ball
+// detection directly, e.g. when GSPro is ready, also ends standby.
+func (lm *LaunchMonitor) clearStandby() {
+	lm.standbyMu.Lock()
+	wasStandby := lm.standby
+	lm.standby = false
+	lm.standbyMu.Unlock()
+	if !wasStandby {
+		return
+	}
+
+	log.Println("LaunchMonitor: Leaving standby")
+	lm.stateManager.SetDeviceStandby(false)
+	lm.startHeartbeatTask()
+	if lm.bluetoothClient != nil && lm.bluetoothClient.IsConnected() && !lm.GetCapacitorReady() {
+		lm.startChargePolling()
+	}
+}
+
+// SetSleepSchedule sets the daily standby window
+func (lm *LaunchMonitor) SetSleepSchedule(schedule SleepSchedule) {
+	lm.standbyMu.Lock()
+	defer lm.standbyMu.Unlock()
+	lm.sleepSchedule = schedule
+}
+
+// StartSleepSchedule starts checking the sleep schedule. The device is put in
+// standby when the window starts and woken when it ends; a manual wake inside
+// the window lasts until the next window.
+func (lm *LaunchMonitor) StartSleepSchedule() {
+	lm.standbyMu.Lock()
+	defer lm.standbyMu.Unlock()
+
+	if lm.scheduleCancel != nil {
+		lm.scheduleCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	lm.scheduleCancel = cancel
+
+	go func() {
+		ticker := lm.clock.NewTicker(sleepScheduleInterval)
+		defer ticker.Stop()
+
+		lm.checkSleepSchedule()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+				lm.checkSleepSchedule()
+			}
+		}
+	}()
+}
+
+func (lm *LaunchMonitor) stopSleepSchedule() {
+	lm.standbyMu.Lock()
+	defer lm.standbyMu.Unlock()
+	if lm.scheduleCancel != nil {
+		lm.scheduleCancel()
+		lm.scheduleCancel = nil
+	}
+}
+
+// checkSleepSchedule enters or leaves standby when the schedule crosses a
+// window boundary
+func (lm *LaunchMonitor) checkSleepSchedule() {
+	lm.standbyMu.Lock()
+	asleep := lm.sleepSchedule.Asleep(lm.clock.Now())
+	changed := asleep != lm.scheduledAsleep
+	lm.scheduledAsleep = asleep
+	lm.standbyMu.Unlock()
+	if !changed {
+		return
+	}
+
+	var err error
+	if asleep {
+		log.Println("LaunchMonitor: Sleep schedule started")
+		err = lm.Standby()
+	} else if lm.InStandby() {
+		log.Println("LaunchMonitor: Sleep schedule ended")
+		err = lm.Wake()
+	}
+	if err != nil {
+		log.Printf("LaunchMonitor: Sleep schedule: %v", err)
+	}
+}
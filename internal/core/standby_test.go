@@ -0,0 +1,97 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSleepSchedule_Asleep(t *testing.T) {
+	overnight := SleepSchedule{Enabled: true, Sleep: "22:00", Wake: "07:00"}
+	daytime := SleepSchedule{Enabled: true, Sleep: "09:30", Wake: "17:00"}
+	at := func(hour, minute int) time.Time { return time.Date(2024, 5, 1, hour, minute, 0, 0, time.Local) }
+
+	tests := []struct {
+		name     string
+		schedule SleepSchedule
+		time     time.Time
+		want     bool
+	}{
+		{"overnight before start", overnight, at(21, 59), false},
+		{"overnight at start", overnight, at(22, 0), true},
+		{"overnight after midnight", overnight, at(3, 0), true},
+		{"overnight at wake", overnight, at(7, 0), false},
+		{"daytime inside", daytime, at(12, 0), true},
+		{"daytime outside", daytime, at(18, 0), false},
+		{"disabled", SleepSchedule{Sleep: "22:00", Wake: "07:00"}, at(23, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.schedule.Asleep(tt.time); got != tt.want {
+			t.Errorf("%s: Asleep() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if (SleepSchedule{Sleep: "7:00pm", Wake: "07:00"}).Valid() {
+		t.Error("Expected a malformed time to be rejected")
+	}
+	if (SleepSchedule{Sleep: "07:00", Wake: "07:00"}).Valid() {
+		t.Error("Expected an empty window to be rejected")
+	}
+}
+
+func TestStandby_WakeRearmsDetection(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+
+	if err := lm.ActivateBallDetection(); err != nil {
+		t.Fatalf("ActivateBallDetection() error = %v", err)
+	}
+	writes := len(mockClient.GetWriteHistory())
+
+	if err := lm.Standby(); err != nil {
+		t.Fatalf("Standby() error = %v", err)
+	}
+	defer lm.Shutdown()
+	if !lm.InStandby() || !sm.GetDeviceStandby() {
+		t.Fatal("Expected the device to be in standby")
+	}
+	history := mockClient.GetWriteHistory()
+	if len(history) != writes+1 || history[len(history)-1].Data[1] != 0x81 || history[len(history)-1].Data[3] != 0x00 {
+		t.Fatalf("Expected a deactivate detect command, got %d new writes", len(history)-writes)
+	}
+
+	// Reconnecting during standby leaves detection off
+	lm.restoreDeviceState()
+	if len(mockClient.GetWriteHistory()) != writes+1 {
+		t.Error("Expected no commands on reconnect during standby")
+	}
+
+	if err := lm.Wake(); err != nil {
+		t.Fatalf("Wake() error = %v", err)
+	}
+	if lm.InStandby() || sm.GetDeviceStandby() {
+		t.Error("Expected waking to end standby")
+	}
+	if !lm.detectModeActive {
+		t.Error("Expected waking to re-arm ball detection")
+	}
+}
+
+func TestStandby_WakeLeavesInactiveDetectionOff(t *testing.T) {
+	_, lm, mockClient, _ := newTestLaunchMonitor(t)
+	mockClient.connected = true
+	// Keep charge polling from writing after the wake
+	lm.setCapacitorReady(true)
+
+	if err := lm.Standby(); err != nil {
+		t.Fatalf("Standby() error = %v", err)
+	}
+	defer lm.Shutdown()
+	writes := len(mockClient.GetWriteHistory())
+
+	if err := lm.Wake(); err != nil {
+		t.Fatalf("Wake() error = %v", err)
+	}
+	if lm.detectModeActive || len(mockClient.GetWriteHistory()) != writes {
+		t.Error("Expected detection to stay off when it wasn't armed before standby")
+	}
+}
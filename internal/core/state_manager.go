@@ -26,10 +26,13 @@ type AppState struct {
 	Club                *ClubType
 	ClubName            *string // Human-readable club name from GSPro (e.g., "Driver", "7-iron")
 	Handedness          *HandednessType
+	PlayerName          *string // Player GSPro says is up, in a multiplayer round
 	GSProStatus         GSProConnectionStatus
 	GSProError          error
 	InfiniteTeesStatus  InfiniteTeesConnectionStatus
 	InfiniteTeesError   error
+	AwesomeGolfStatus   AwesomeGolfConnectionStatus
+	AwesomeGolfError    error
 	SpinMode            *SpinMode
 	OmniSpeedUnit       *string
 	OmniDistanceUnit    *string
@@ -37,12 +40,13 @@ type AppState struct {
 	OmniCarryAdjustment *int
 	CameraURL           *string
 	CameraEnabled       bool
-	IsAligning          bool    // Whether alignment mode UI is active
-	AlignmentAngle      float64 // Current aim angle in degrees (left negative, right positive)
-	IsAligned           bool    // Whether device is currently aligned (within tolerance)
-	FirmwareVersion     *string // Device firmware version (e.g., "1.6.18")
-	LauncherVersion     *string // Launcher version
-	MMIVersion          *string // MMI version
+	IsAligning          bool            // Whether alignment mode UI is active
+	AlignmentAngle      float64         // Current aim angle in degrees (left negative, right positive)
+	IsAligned           bool            // Whether device is currently aligned (within tolerance)
+	AlignmentFormat     AlignmentFormat // Where the aim angle is in alignment notifications
+	FirmwareVersion     *string         // Device firmware version (e.g., "1.6.18")
+	LauncherVersion     *string         // Launcher version
+	MMIVersion          *string         // MMI version
 	DeviceType          DeviceType
 	OmniHomeGolfStatus  *int
 	OmniStatus          *int
@@ -50,67 +54,116 @@ type AppState struct {
 	OmniSensorStatus    *int
 	CapacitorReady      bool
 	BatteryCharging     *int
+	DeviceIdle          bool          // Whether ball detection is off after a period without shots
+	DeviceStandby       bool          // Whether the device was put in standby
+	SessionPaused       bool          // Whether ball detection and shots are held while the player is away
+	SwingStickMode      bool          // Whether the device measures swings with the swing stick instead of shots
+	LastSwingMetrics    *ClubMetrics  // Club data of the last swing stick swing
+	ShotCooldown        bool          // Whether ball ready and shot notifications are ignored after a shot
+	TaskRestarts        int           // Background tasks restarted after a panic
+	MisreadPrompt       bool          // Whether misread shots are held for the user
+	MisreadShots        []MisreadShot // Shots held for the user to discard or send
 }
 
 // StateCallback is a generic type for state change callbacks
 type StateCallback[T any] func(oldValue, newValue T)
 
+// StateChange is the event published when a state field is set
+type StateChange[T any] struct {
+	Old T
+	New T
+}
+
+// subscribeState adapts a StateCallback to a state change topic
+func subscribeState[T any](bus *EventBus, topic Topic[StateChange[T]], callback StateCallback[T]) *Subscription {
+	return Subscribe(bus, topic, func(change StateChange[T]) {
+		callback(change.Old, change.New)
+	})
+}
+
 // StateManager manages the application state with type safety
 type StateManager struct {
-	state     AppState
-	callbacks struct {
-		DeviceDisplayName   []StateCallback[*string]
-		ConnectionStatus    []StateCallback[ConnectionStatus]
-		BatteryLevel        []StateCallback[*int]
-		BallDetected        []StateCallback[bool]
-		BallReady           []StateCallback[bool]
-		BallPosition        []StateCallback[*BallPosition]
-		LastBallMetrics     []StateCallback[*BallMetrics]
-		LastClubMetrics     []StateCallback[*ClubMetrics]
-		LaunchMonitorStatus []StateCallback[LaunchMonitorStatus]
-		LastError           []StateCallback[error]
-		Club                []StateCallback[*ClubType]
-		Handedness          []StateCallback[*HandednessType]
-		GSProStatus         []StateCallback[GSProConnectionStatus]
-		GSProError          []StateCallback[error]
-		InfiniteTeesStatus  []StateCallback[InfiniteTeesConnectionStatus]
-		InfiniteTeesError   []StateCallback[error]
-		SpinMode            []StateCallback[*SpinMode]
-		OmniSpeedUnit       []StateCallback[*string]
-		OmniDistanceUnit    []StateCallback[*string]
-		OmniGreenSpeed      []StateCallback[*int]
-		OmniCarryAdjustment []StateCallback[*int]
-		CameraURL           []StateCallback[*string]
-		CameraEnabled       []StateCallback[bool]
-		IsAligning          []StateCallback[bool]
-		AlignmentAngle      []StateCallback[float64]
-		IsAligned           []StateCallback[bool]
-		FirmwareVersion     []StateCallback[*string]
-		LauncherVersion     []StateCallback[*string]
-		MMIVersion          []StateCallback[*string]
-		DeviceType          []StateCallback[DeviceType]
-		OmniHomeGolfStatus  []StateCallback[*int]
-		OmniStatus          []StateCallback[*int]
-		OmniClubSelection   []StateCallback[*int]
-		OmniSensorStatus    []StateCallback[*int]
-		CapacitorReady      []StateCallback[bool]
-		BatteryCharging     []StateCallback[*int]
-	}
-	mu sync.RWMutex
+	state AppState
+	bus   *EventBus
+	mu    sync.RWMutex
 }
 
+// State change topics, one per field. Fields that change together share a
+// queue so their subscribers see the changes in order. The ball position and
+// alignment angle stream in quickly, so subscribers that fall behind only get
+// the latest.
 var (
-	instance *StateManager
-	once     sync.Once
+	topicDeviceDisplayName   = NewTopic[StateChange[*string]]("state.DeviceDisplayName")
+	topicConnectionStatus    = NewTopic[StateChange[ConnectionStatus]]("state.ConnectionStatus")
+	topicBatteryLevel        = NewTopic[StateChange[*int]]("state.BatteryLevel").InQueue("state.Battery")
+	topicBallDetected        = NewTopic[StateChange[bool]]("state.BallDetected").InQueue("state.Ball")
+	topicBallReady           = NewTopic[StateChange[bool]]("state.BallReady").InQueue("state.Ball")
+	topicBallPosition        = NewLatestTopic[StateChange[*BallPosition]]("state.BallPosition")
+	topicLastBallMetrics     = NewTopic[StateChange[*BallMetrics]]("state.LastBallMetrics").InQueue("state.Shot")
+	topicLastClubMetrics     = NewTopic[StateChange[*ClubMetrics]]("state.LastClubMetrics").InQueue("state.Shot")
+	topicLaunchMonitorStatus = NewTopic[StateChange[LaunchMonitorStatus]]("state.LaunchMonitorStatus")
+	topicLastError           = NewTopic[StateChange[error]]("state.LastError")
+	topicClub                = NewTopic[StateChange[*ClubType]]("state.Club")
+	topicHandedness          = NewTopic[StateChange[*HandednessType]]("state.Handedness")
+	topicPlayerName          = NewTopic[StateChange[*string]]("state.PlayerName")
+	topicGSProStatus         = NewTopic[StateChange[GSProConnectionStatus]]("state.GSProStatus")
+	topicGSProError          = NewTopic[StateChange[error]]("state.GSProError")
+	topicInfiniteTeesStatus  = NewTopic[StateChange[InfiniteTeesConnectionStatus]]("state.InfiniteTeesStatus")
+	topicInfiniteTeesError   = NewTopic[StateChange[error]]("state.InfiniteTeesError")
+	topicAwesomeGolfStatus   = NewTopic[StateChange[AwesomeGolfConnectionStatus]]("state.AwesomeGolfStatus")
+	topicAwesomeGolfError    = NewTopic[StateChange[error]]("state.AwesomeGolfError")
+	topicSpinMode            = NewTopic[StateChange[*SpinMode]]("state.SpinMode")
+	topicOmniSpeedUnit       = NewTopic[StateChange[*string]]("state.OmniSpeedUnit")
+	topicOmniDistanceUnit    = NewTopic[StateChange[*string]]("state.OmniDistanceUnit")
+	topicOmniGreenSpeed      = NewTopic[StateChange[*int]]("state.OmniGreenSpeed")
+	topicOmniCarryAdjustment = NewTopic[StateChange[*int]]("state.OmniCarryAdjustment")
+	topicCameraURL           = NewTopic[StateChange[*string]]("state.CameraURL")
+	topicCameraEnabled       = NewTopic[StateChange[bool]]("state.CameraEnabled")
+	topicIsAligning          = NewTopic[StateChange[bool]]("state.IsAligning")
+	topicAlignmentAngle      = NewLatestTopic[StateChange[float64]]("state.AlignmentAngle")
+	topicIsAligned           = NewTopic[StateChange[bool]]("state.IsAligned")
+	topicFirmwareVersion     = NewTopic[StateChange[*string]]("state.FirmwareVersion")
+	topicLauncherVersion     = NewTopic[StateChange[*string]]("state.LauncherVersion")
+	topicMMIVersion          = NewTopic[StateChange[*string]]("state.MMIVersion")
+	topicDeviceType          = NewTopic[StateChange[DeviceType]]("state.DeviceType")
+	topicOmniHomeGolfStatus  = NewTopic[StateChange[*int]]("state.OmniHomeGolfStatus")
+	topicOmniStatus          = NewTopic[StateChange[*int]]("state.OmniStatus")
+	topicOmniClubSelection   = NewTopic[StateChange[*int]]("state.OmniClubSelection")
+	topicOmniSensorStatus    = NewTopic[StateChange[*int]]("state.OmniSensorStatus")
+	topicCapacitorReady      = NewTopic[StateChange[bool]]("state.CapacitorReady")
+	topicBatteryCharging     = NewTopic[StateChange[*int]]("state.BatteryCharging").InQueue("state.Battery")
+	topicDeviceIdle          = NewTopic[StateChange[bool]]("state.DeviceIdle")
+	topicDeviceStandby       = NewTopic[StateChange[bool]]("state.DeviceStandby")
+	topicSessionPaused       = NewTopic[StateChange[bool]]("state.SessionPaused")
+	topicSwingStickMode      = NewTopic[StateChange[bool]]("state.SwingStickMode")
+	topicLastSwingMetrics    = NewTopic[StateChange[*ClubMetrics]]("state.LastSwingMetrics").InQueue("state.Shot")
+	topicShotCooldown        = NewTopic[StateChange[bool]]("state.ShotCooldown")
+	topicTaskRestarts        = NewTopic[StateChange[int]]("state.TaskRestarts")
+	topicMisreadPrompt       = NewTopic[StateChange[bool]]("state.MisreadPrompt")
+	topicMisreadShots        = NewTopic[StateChange[[]MisreadShot]]("state.MisreadShots")
 )
 
-// GetInstance returns the singleton instance of StateManager
-func GetInstance() *StateManager {
-	once.Do(func() {
-		instance = &StateManager{}
-		instance.initialize()
-	})
-	return instance
+// NewStateManager creates a StateManager with default state
+func NewStateManager() *StateManager {
+	sm := &StateManager{bus: NewEventBus(0)}
+	sm.initialize()
+	return sm
+}
+
+// Events returns the bus state changes are published on
+func (sm *StateManager) Events() *EventBus {
+	return sm.bus
+}
+
+// Flush waits until every state change so far has reached its callbacks
+func (sm *StateManager) Flush() {
+	sm.bus.Flush()
+}
+
+// UnregisterCallback stops a callback returned by any Register*Callback
+// method. It is equivalent to sub.Unsubscribe.
+func (sm *StateManager) UnregisterCallback(sub *Subscription) {
+	sub.Unsubscribe()
 }
 
 // initialize sets up the default state values
@@ -122,11 +175,13 @@ func (sm *StateManager) initialize() {
 		BallReady:           false,
 		GSProStatus:         GSProStatusDisconnected,
 		InfiniteTeesStatus:  InfiniteTeesStatusDisconnected,
+		AwesomeGolfStatus:   AwesomeGolfStatusDisconnected,
 		CameraURL:           &defaultCameraURL,
 		CameraEnabled:       false,
 		IsAligning:          false,
 		AlignmentAngle:      0.0,
 		IsAligned:           false,
+		AlignmentFormat:     DefaultAlignmentFormat(),
 		LaunchMonitorStatus: LaunchMonitorStatusNone,
 		DeviceType:          DeviceTypeUnknown,
 	}
@@ -144,12 +199,9 @@ func (sm *StateManager) SetDeviceDisplayName(value *string) {
 	sm.mu.Lock()
 	oldValue := sm.state.DeviceDisplayName
 	sm.state.DeviceDisplayName = value
-	callbacks := sm.callbacks.DeviceDisplayName
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicDeviceDisplayName, StateChange[*string]{Old: oldValue, New: value})
 }
 
 // GetConnectionStatus returns the connection status
@@ -164,12 +216,9 @@ func (sm *StateManager) SetConnectionStatus(value ConnectionStatus) {
 	sm.mu.Lock()
 	oldValue := sm.state.ConnectionStatus
 	sm.state.ConnectionStatus = value
-	callbacks := sm.callbacks.ConnectionStatus
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicConnectionStatus, StateChange[ConnectionStatus]{Old: oldValue, New: value})
 }
 
 // GetBatteryLevel returns the battery level
@@ -184,12 +233,9 @@ func (sm *StateManager) SetBatteryLevel(value *int) {
 	sm.mu.Lock()
 	oldValue := sm.state.BatteryLevel
 	sm.state.BatteryLevel = value
-	callbacks := sm.callbacks.BatteryLevel
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicBatteryLevel, StateChange[*int]{Old: oldValue, New: value})
 }
 
 // GetBallDetected returns whether a ball is detected
@@ -204,12 +250,9 @@ func (sm *StateManager) SetBallDetected(value bool) {
 	sm.mu.Lock()
 	oldValue := sm.state.BallDetected
 	sm.state.BallDetected = value
-	callbacks := sm.callbacks.BallDetected
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicBallDetected, StateChange[bool]{Old: oldValue, New: value})
 }
 
 // GetBallReady returns whether a ball is ready
@@ -224,12 +267,9 @@ func (sm *StateManager) SetBallReady(value bool) {
 	sm.mu.Lock()
 	oldValue := sm.state.BallReady
 	sm.state.BallReady = value
-	callbacks := sm.callbacks.BallReady
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicBallReady, StateChange[bool]{Old: oldValue, New: value})
 }
 
 // GetBallPosition returns the ball position
@@ -244,12 +284,9 @@ func (sm *StateManager) SetBallPosition(value *BallPosition) {
 	sm.mu.Lock()
 	oldValue := sm.state.BallPosition
 	sm.state.BallPosition = value
-	callbacks := sm.callbacks.BallPosition
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicBallPosition, StateChange[*BallPosition]{Old: oldValue, New: value})
 }
 
 // GetLastBallMetrics returns the last ball metrics
@@ -264,12 +301,9 @@ func (sm *StateManager) SetLastBallMetrics(value *BallMetrics) {
 	sm.mu.Lock()
 	oldValue := sm.state.LastBallMetrics
 	sm.state.LastBallMetrics = value
-	callbacks := sm.callbacks.LastBallMetrics
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicLastBallMetrics, StateChange[*BallMetrics]{Old: oldValue, New: value})
 }
 
 // GetLastClubMetrics returns the last club metrics
@@ -284,12 +318,9 @@ func (sm *StateManager) SetLastClubMetrics(value *ClubMetrics) {
 	sm.mu.Lock()
 	oldValue := sm.state.LastClubMetrics
 	sm.state.LastClubMetrics = value
-	callbacks := sm.callbacks.LastClubMetrics
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicLastClubMetrics, StateChange[*ClubMetrics]{Old: oldValue, New: value})
 }
 
 // GetLaunchMonitorStatus returns the current launch monitor status.
@@ -304,12 +335,9 @@ func (sm *StateManager) SetLaunchMonitorStatus(value LaunchMonitorStatus) {
 	sm.mu.Lock()
 	oldValue := sm.state.LaunchMonitorStatus
 	sm.state.LaunchMonitorStatus = value
-	callbacks := sm.callbacks.LaunchMonitorStatus
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicLaunchMonitorStatus, StateChange[LaunchMonitorStatus]{Old: oldValue, New: value})
 }
 
 // GetLastError returns the last error
@@ -324,12 +352,9 @@ func (sm *StateManager) SetLastError(value error) {
 	sm.mu.Lock()
 	oldValue := sm.state.LastError
 	sm.state.LastError = value
-	callbacks := sm.callbacks.LastError
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicLastError, StateChange[error]{Old: oldValue, New: value})
 }
 
 // GetClub returns the current club
@@ -344,12 +369,9 @@ func (sm *StateManager) SetClub(value *ClubType) {
 	sm.mu.Lock()
 	oldValue := sm.state.Club
 	sm.state.Club = value
-	callbacks := sm.callbacks.Club
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicClub, StateChange[*ClubType]{Old: oldValue, New: value})
 }
 
 // GetClubName returns the human-readable club name
@@ -366,6 +388,20 @@ func (sm *StateManager) SetClubName(value *string) {
 	sm.mu.Unlock()
 }
 
+// GetAlignmentFormat returns the format alignment notifications are read with
+func (sm *StateManager) GetAlignmentFormat() AlignmentFormat {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.AlignmentFormat
+}
+
+// SetAlignmentFormat sets the format alignment notifications are read with
+func (sm *StateManager) SetAlignmentFormat(value AlignmentFormat) {
+	sm.mu.Lock()
+	sm.state.AlignmentFormat = value
+	sm.mu.Unlock()
+}
+
 // GetHandedness returns the current handedness
 func (sm *StateManager) GetHandedness() *HandednessType {
 	sm.mu.RLock()
@@ -378,96 +414,91 @@ func (sm *StateManager) SetHandedness(value *HandednessType) {
 	sm.mu.Lock()
 	oldValue := sm.state.Handedness
 	sm.state.Handedness = value
-	callbacks := sm.callbacks.Handedness
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicHandedness, StateChange[*HandednessType]{Old: oldValue, New: value})
 }
 
 // RegisterDeviceDisplayNameCallback registers a callback for device display name changes
-func (sm *StateManager) RegisterDeviceDisplayNameCallback(callback StateCallback[*string]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.DeviceDisplayName = append(sm.callbacks.DeviceDisplayName, callback)
+func (sm *StateManager) RegisterDeviceDisplayNameCallback(callback StateCallback[*string]) *Subscription {
+	return subscribeState(sm.bus, topicDeviceDisplayName, callback)
 }
 
 // RegisterConnectionStatusCallback registers a callback for connection status changes
-func (sm *StateManager) RegisterConnectionStatusCallback(callback StateCallback[ConnectionStatus]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.ConnectionStatus = append(sm.callbacks.ConnectionStatus, callback)
+func (sm *StateManager) RegisterConnectionStatusCallback(callback StateCallback[ConnectionStatus]) *Subscription {
+	return subscribeState(sm.bus, topicConnectionStatus, callback)
 }
 
 // RegisterBatteryLevelCallback registers a callback for battery level changes
-func (sm *StateManager) RegisterBatteryLevelCallback(callback StateCallback[*int]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.BatteryLevel = append(sm.callbacks.BatteryLevel, callback)
+func (sm *StateManager) RegisterBatteryLevelCallback(callback StateCallback[*int]) *Subscription {
+	return subscribeState(sm.bus, topicBatteryLevel, callback)
 }
 
 // RegisterBallDetectedCallback registers a callback for ball detected changes
-func (sm *StateManager) RegisterBallDetectedCallback(callback StateCallback[bool]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.BallDetected = append(sm.callbacks.BallDetected, callback)
+func (sm *StateManager) RegisterBallDetectedCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicBallDetected, callback)
 }
 
 // RegisterBallReadyCallback registers a callback for ball ready changes
-func (sm *StateManager) RegisterBallReadyCallback(callback StateCallback[bool]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.BallReady = append(sm.callbacks.BallReady, callback)
+func (sm *StateManager) RegisterBallReadyCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicBallReady, callback)
 }
 
 // RegisterBallPositionCallback registers a callback for ball position changes
-func (sm *StateManager) RegisterBallPositionCallback(callback StateCallback[*BallPosition]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.BallPosition = append(sm.callbacks.BallPosition, callback)
+func (sm *StateManager) RegisterBallPositionCallback(callback StateCallback[*BallPosition]) *Subscription {
+	return subscribeState(sm.bus, topicBallPosition, callback)
 }
 
 // RegisterLastBallMetricsCallback registers a callback for last ball metrics changes
-func (sm *StateManager) RegisterLastBallMetricsCallback(callback StateCallback[*BallMetrics]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.LastBallMetrics = append(sm.callbacks.LastBallMetrics, callback)
+func (sm *StateManager) RegisterLastBallMetricsCallback(callback StateCallback[*BallMetrics]) *Subscription {
+	return subscribeState(sm.bus, topicLastBallMetrics, callback)
 }
 
 // RegisterLastClubMetricsCallback registers a callback for last club metrics changes
-func (sm *StateManager) RegisterLastClubMetricsCallback(callback StateCallback[*ClubMetrics]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.LastClubMetrics = append(sm.callbacks.LastClubMetrics, callback)
+func (sm *StateManager) RegisterLastClubMetricsCallback(callback StateCallback[*ClubMetrics]) *Subscription {
+	return subscribeState(sm.bus, topicLastClubMetrics, callback)
 }
 
 // RegisterLaunchMonitorStatusCallback registers a callback for launch monitor status changes.
-func (sm *StateManager) RegisterLaunchMonitorStatusCallback(callback StateCallback[LaunchMonitorStatus]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.LaunchMonitorStatus = append(sm.callbacks.LaunchMonitorStatus, callback)
+func (sm *StateManager) RegisterLaunchMonitorStatusCallback(callback StateCallback[LaunchMonitorStatus]) *Subscription {
+	return subscribeState(sm.bus, topicLaunchMonitorStatus, callback)
 }
 
 // RegisterLastErrorCallback registers a callback for last error changes
-func (sm *StateManager) RegisterLastErrorCallback(callback StateCallback[error]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.LastError = append(sm.callbacks.LastError, callback)
+func (sm *StateManager) RegisterLastErrorCallback(callback StateCallback[error]) *Subscription {
+	return subscribeState(sm.bus, topicLastError, callback)
 }
 
 // RegisterClubCallback registers a callback for club changes
-func (sm *StateManager) RegisterClubCallback(callback StateCallback[*ClubType]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.Club = append(sm.callbacks.Club, callback)
+func (sm *StateManager) RegisterClubCallback(callback StateCallback[*ClubType]) *Subscription {
+	return subscribeState(sm.bus, topicClub, callback)
 }
 
 // RegisterHandednessCallback registers a callback for handedness changes
-func (sm *StateManager) RegisterHandednessCallback(callback StateCallback[*HandednessType]) {
+func (sm *StateManager) RegisterHandednessCallback(callback StateCallback[*HandednessType]) *Subscription {
+	return subscribeState(sm.bus, topicHandedness, callback)
+}
+
+// GetPlayerName returns the name of the player GSPro says is up
+func (sm *StateManager) GetPlayerName() *string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.PlayerName
+}
+
+// SetPlayerName sets the name of the player GSPro says is up
+func (sm *StateManager) SetPlayerName(value *string) {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.Handedness = append(sm.callbacks.Handedness, callback)
+	oldValue := sm.state.PlayerName
+	sm.state.PlayerName = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicPlayerName, StateChange[*string]{Old: oldValue, New: value})
+}
+
+// RegisterPlayerNameCallback registers a callback for player changes
+func (sm *StateManager) RegisterPlayerNameCallback(callback StateCallback[*string]) *Subscription {
+	return subscribeState(sm.bus, topicPlayerName, callback)
 }
 
 // GetGSProStatus returns the GSPro connection status
@@ -482,12 +513,9 @@ func (sm *StateManager) SetGSProStatus(value GSProConnectionStatus) {
 	sm.mu.Lock()
 	oldValue := sm.state.GSProStatus
 	sm.state.GSProStatus = value
-	callbacks := sm.callbacks.GSProStatus
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicGSProStatus, StateChange[GSProConnectionStatus]{Old: oldValue, New: value})
 }
 
 // GetGSProError returns the GSPro error
@@ -502,26 +530,19 @@ func (sm *StateManager) SetGSProError(value error) {
 	sm.mu.Lock()
 	oldValue := sm.state.GSProError
 	sm.state.GSProError = value
-	callbacks := sm.callbacks.GSProError
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicGSProError, StateChange[error]{Old: oldValue, New: value})
 }
 
 // RegisterGSProStatusCallback registers a callback for GSPro status changes
-func (sm *StateManager) RegisterGSProStatusCallback(callback StateCallback[GSProConnectionStatus]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.GSProStatus = append(sm.callbacks.GSProStatus, callback)
+func (sm *StateManager) RegisterGSProStatusCallback(callback StateCallback[GSProConnectionStatus]) *Subscription {
+	return subscribeState(sm.bus, topicGSProStatus, callback)
 }
 
 // RegisterGSProErrorCallback registers a callback for GSPro error changes
-func (sm *StateManager) RegisterGSProErrorCallback(callback StateCallback[error]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.GSProError = append(sm.callbacks.GSProError, callback)
+func (sm *StateManager) RegisterGSProErrorCallback(callback StateCallback[error]) *Subscription {
+	return subscribeState(sm.bus, topicGSProError, callback)
 }
 
 // GetInfiniteTeesStatus returns the Infinite Tees connection status
@@ -536,12 +557,9 @@ func (sm *StateManager) SetInfiniteTeesStatus(value InfiniteTeesConnectionStatus
 	sm.mu.Lock()
 	oldValue := sm.state.InfiniteTeesStatus
 	sm.state.InfiniteTeesStatus = value
-	callbacks := sm.callbacks.InfiniteTeesStatus
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicInfiniteTeesStatus, StateChange[InfiniteTeesConnectionStatus]{Old: oldValue, New: value})
 }
 
 // GetInfiniteTeesError returns the Infinite Tees error
@@ -556,26 +574,63 @@ func (sm *StateManager) SetInfiniteTeesError(value error) {
 	sm.mu.Lock()
 	oldValue := sm.state.InfiniteTeesError
 	sm.state.InfiniteTeesError = value
-	callbacks := sm.callbacks.InfiniteTeesError
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicInfiniteTeesError, StateChange[error]{Old: oldValue, New: value})
 }
 
 // RegisterInfiniteTeesStatusCallback registers a callback for Infinite Tees status changes
-func (sm *StateManager) RegisterInfiniteTeesStatusCallback(callback StateCallback[InfiniteTeesConnectionStatus]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.InfiniteTeesStatus = append(sm.callbacks.InfiniteTeesStatus, callback)
+func (sm *StateManager) RegisterInfiniteTeesStatusCallback(callback StateCallback[InfiniteTeesConnectionStatus]) *Subscription {
+	return subscribeState(sm.bus, topicInfiniteTeesStatus, callback)
 }
 
 // RegisterInfiniteTeesErrorCallback registers a callback for Infinite Tees error changes
-func (sm *StateManager) RegisterInfiniteTeesErrorCallback(callback StateCallback[error]) {
+func (sm *StateManager) RegisterInfiniteTeesErrorCallback(callback StateCallback[error]) *Subscription {
+	return subscribeState(sm.bus, topicInfiniteTeesError, callback)
+}
+
+// GetAwesomeGolfStatus returns the Awesome Golf connection status
+func (sm *StateManager) GetAwesomeGolfStatus() AwesomeGolfConnectionStatus {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.AwesomeGolfStatus
+}
+
+// SetAwesomeGolfStatus sets the Awesome Golf connection status
+func (sm *StateManager) SetAwesomeGolfStatus(value AwesomeGolfConnectionStatus) {
+	sm.mu.Lock()
+	oldValue := sm.state.AwesomeGolfStatus
+	sm.state.AwesomeGolfStatus = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicAwesomeGolfStatus, StateChange[AwesomeGolfConnectionStatus]{Old: oldValue, New: value})
+}
+
+// GetAwesomeGolfError returns the Awesome Golf error
+func (sm *StateManager) GetAwesomeGolfError() error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.AwesomeGolfError
+}
+
+// SetAwesomeGolfError sets the Awesome Golf error
+func (sm *StateManager) SetAwesomeGolfError(value error) {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.InfiniteTeesError = append(sm.callbacks.InfiniteTeesError, callback)
+	oldValue := sm.state.AwesomeGolfError
+	sm.state.AwesomeGolfError = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicAwesomeGolfError, StateChange[error]{Old: oldValue, New: value})
+}
+
+// RegisterAwesomeGolfStatusCallback registers a callback for Awesome Golf status changes
+func (sm *StateManager) RegisterAwesomeGolfStatusCallback(callback StateCallback[AwesomeGolfConnectionStatus]) *Subscription {
+	return subscribeState(sm.bus, topicAwesomeGolfStatus, callback)
+}
+
+// RegisterAwesomeGolfErrorCallback registers a callback for Awesome Golf error changes
+func (sm *StateManager) RegisterAwesomeGolfErrorCallback(callback StateCallback[error]) *Subscription {
+	return subscribeState(sm.bus, topicAwesomeGolfError, callback)
 }
 
 // GetSpinMode returns the current spin mode
@@ -590,19 +645,14 @@ func (sm *StateManager) SetSpinMode(value *SpinMode) {
 	sm.mu.Lock()
 	oldValue := sm.state.SpinMode
 	sm.state.SpinMode = value
-	callbacks := sm.callbacks.SpinMode
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicSpinMode, StateChange[*SpinMode]{Old: oldValue, New: value})
 }
 
 // RegisterSpinModeCallback registers a callback for spin mode changes
-func (sm *StateManager) RegisterSpinModeCallback(callback StateCallback[*SpinMode]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.SpinMode = append(sm.callbacks.SpinMode, callback)
+func (sm *StateManager) RegisterSpinModeCallback(callback StateCallback[*SpinMode]) *Subscription {
+	return subscribeState(sm.bus, topicSpinMode, callback)
 }
 
 func (sm *StateManager) GetOmniSpeedUnit() *string {
@@ -615,18 +665,13 @@ func (sm *StateManager) SetOmniSpeedUnit(value *string) {
 	sm.mu.Lock()
 	oldValue := sm.state.OmniSpeedUnit
 	sm.state.OmniSpeedUnit = value
-	callbacks := sm.callbacks.OmniSpeedUnit
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicOmniSpeedUnit, StateChange[*string]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterOmniSpeedUnitCallback(callback StateCallback[*string]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.OmniSpeedUnit = append(sm.callbacks.OmniSpeedUnit, callback)
+func (sm *StateManager) RegisterOmniSpeedUnitCallback(callback StateCallback[*string]) *Subscription {
+	return subscribeState(sm.bus, topicOmniSpeedUnit, callback)
 }
 
 func (sm *StateManager) GetOmniDistanceUnit() *string {
@@ -639,18 +684,13 @@ func (sm *StateManager) SetOmniDistanceUnit(value *string) {
 	sm.mu.Lock()
 	oldValue := sm.state.OmniDistanceUnit
 	sm.state.OmniDistanceUnit = value
-	callbacks := sm.callbacks.OmniDistanceUnit
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicOmniDistanceUnit, StateChange[*string]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterOmniDistanceUnitCallback(callback StateCallback[*string]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.OmniDistanceUnit = append(sm.callbacks.OmniDistanceUnit, callback)
+func (sm *StateManager) RegisterOmniDistanceUnitCallback(callback StateCallback[*string]) *Subscription {
+	return subscribeState(sm.bus, topicOmniDistanceUnit, callback)
 }
 
 func (sm *StateManager) GetOmniGreenSpeed() *int {
@@ -663,18 +703,13 @@ func (sm *StateManager) SetOmniGreenSpeed(value *int) {
 	sm.mu.Lock()
 	oldValue := sm.state.OmniGreenSpeed
 	sm.state.OmniGreenSpeed = value
-	callbacks := sm.callbacks.OmniGreenSpeed
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicOmniGreenSpeed, StateChange[*int]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterOmniGreenSpeedCallback(callback StateCallback[*int]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.OmniGreenSpeed = append(sm.callbacks.OmniGreenSpeed, callback)
+func (sm *StateManager) RegisterOmniGreenSpeedCallback(callback StateCallback[*int]) *Subscription {
+	return subscribeState(sm.bus, topicOmniGreenSpeed, callback)
 }
 
 func (sm *StateManager) GetOmniCarryAdjustment() *int {
@@ -687,18 +722,13 @@ func (sm *StateManager) SetOmniCarryAdjustment(value *int) {
 	sm.mu.Lock()
 	oldValue := sm.state.OmniCarryAdjustment
 	sm.state.OmniCarryAdjustment = value
-	callbacks := sm.callbacks.OmniCarryAdjustment
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicOmniCarryAdjustment, StateChange[*int]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterOmniCarryAdjustmentCallback(callback StateCallback[*int]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.OmniCarryAdjustment = append(sm.callbacks.OmniCarryAdjustment, callback)
+func (sm *StateManager) RegisterOmniCarryAdjustmentCallback(callback StateCallback[*int]) *Subscription {
+	return subscribeState(sm.bus, topicOmniCarryAdjustment, callback)
 }
 
 // GetCameraURL returns the camera URL
@@ -713,12 +743,9 @@ func (sm *StateManager) SetCameraURL(value *string) {
 	sm.mu.Lock()
 	oldValue := sm.state.CameraURL
 	sm.state.CameraURL = value
-	callbacks := sm.callbacks.CameraURL
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicCameraURL, StateChange[*string]{Old: oldValue, New: value})
 }
 
 // GetCameraEnabled returns whether camera integration is enabled
@@ -733,26 +760,19 @@ func (sm *StateManager) SetCameraEnabled(value bool) {
 	sm.mu.Lock()
 	oldValue := sm.state.CameraEnabled
 	sm.state.CameraEnabled = value
-	callbacks := sm.callbacks.CameraEnabled
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicCameraEnabled, StateChange[bool]{Old: oldValue, New: value})
 }
 
 // RegisterCameraURLCallback registers a callback for camera URL changes
-func (sm *StateManager) RegisterCameraURLCallback(callback StateCallback[*string]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.CameraURL = append(sm.callbacks.CameraURL, callback)
+func (sm *StateManager) RegisterCameraURLCallback(callback StateCallback[*string]) *Subscription {
+	return subscribeState(sm.bus, topicCameraURL, callback)
 }
 
 // RegisterCameraEnabledCallback registers a callback for camera enabled changes
-func (sm *StateManager) RegisterCameraEnabledCallback(callback StateCallback[bool]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.CameraEnabled = append(sm.callbacks.CameraEnabled, callback)
+func (sm *StateManager) RegisterCameraEnabledCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicCameraEnabled, callback)
 }
 
 // GetIsAligning returns whether alignment mode is active
@@ -767,12 +787,9 @@ func (sm *StateManager) SetIsAligning(value bool) {
 	sm.mu.Lock()
 	oldValue := sm.state.IsAligning
 	sm.state.IsAligning = value
-	callbacks := sm.callbacks.IsAligning
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicIsAligning, StateChange[bool]{Old: oldValue, New: value})
 }
 
 // GetAlignmentAngle returns the current alignment angle in degrees
@@ -787,12 +804,9 @@ func (sm *StateManager) SetAlignmentAngle(value float64) {
 	sm.mu.Lock()
 	oldValue := sm.state.AlignmentAngle
 	sm.state.AlignmentAngle = value
-	callbacks := sm.callbacks.AlignmentAngle
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicAlignmentAngle, StateChange[float64]{Old: oldValue, New: value})
 }
 
 // GetIsAligned returns whether the device is currently aligned
@@ -807,33 +821,24 @@ func (sm *StateManager) SetIsAligned(value bool) {
 	sm.mu.Lock()
 	oldValue := sm.state.IsAligned
 	sm.state.IsAligned = value
-	callbacks := sm.callbacks.IsAligned
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicIsAligned, StateChange[bool]{Old: oldValue, New: value})
 }
 
 // RegisterIsAligningCallback registers a callback for alignment mode changes
-func (sm *StateManager) RegisterIsAligningCallback(callback StateCallback[bool]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.IsAligning = append(sm.callbacks.IsAligning, callback)
+func (sm *StateManager) RegisterIsAligningCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicIsAligning, callback)
 }
 
 // RegisterAlignmentAngleCallback registers a callback for alignment angle changes
-func (sm *StateManager) RegisterAlignmentAngleCallback(callback StateCallback[float64]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.AlignmentAngle = append(sm.callbacks.AlignmentAngle, callback)
+func (sm *StateManager) RegisterAlignmentAngleCallback(callback StateCallback[float64]) *Subscription {
+	return subscribeState(sm.bus, topicAlignmentAngle, callback)
 }
 
 // RegisterIsAlignedCallback registers a callback for alignment status changes
-func (sm *StateManager) RegisterIsAlignedCallback(callback StateCallback[bool]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.IsAligned = append(sm.callbacks.IsAligned, callback)
+func (sm *StateManager) RegisterIsAlignedCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicIsAligned, callback)
 }
 
 // GetFirmwareVersion returns the device firmware version
@@ -848,19 +853,14 @@ func (sm *StateManager) SetFirmwareVersion(value *string) {
 	sm.mu.Lock()
 	oldValue := sm.state.FirmwareVersion
 	sm.state.FirmwareVersion = value
-	callbacks := sm.callbacks.FirmwareVersion
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicFirmwareVersion, StateChange[*string]{Old: oldValue, New: value})
 }
 
 // RegisterFirmwareVersionCallback registers a callback for firmware version changes
-func (sm *StateManager) RegisterFirmwareVersionCallback(callback StateCallback[*string]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.FirmwareVersion = append(sm.callbacks.FirmwareVersion, callback)
+func (sm *StateManager) RegisterFirmwareVersionCallback(callback StateCallback[*string]) *Subscription {
+	return subscribeState(sm.bus, topicFirmwareVersion, callback)
 }
 
 // GetLauncherVersion returns the launcher version
@@ -875,19 +875,14 @@ func (sm *StateManager) SetLauncherVersion(value *string) {
 	sm.mu.Lock()
 	oldValue := sm.state.LauncherVersion
 	sm.state.LauncherVersion = value
-	callbacks := sm.callbacks.LauncherVersion
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicLauncherVersion, StateChange[*string]{Old: oldValue, New: value})
 }
 
 // RegisterLauncherVersionCallback registers a callback for launcher version changes
-func (sm *StateManager) RegisterLauncherVersionCallback(callback StateCallback[*string]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.LauncherVersion = append(sm.callbacks.LauncherVersion, callback)
+func (sm *StateManager) RegisterLauncherVersionCallback(callback StateCallback[*string]) *Subscription {
+	return subscribeState(sm.bus, topicLauncherVersion, callback)
 }
 
 // GetMMIVersion returns the MMI version
@@ -902,19 +897,14 @@ func (sm *StateManager) SetMMIVersion(value *string) {
 	sm.mu.Lock()
 	oldValue := sm.state.MMIVersion
 	sm.state.MMIVersion = value
-	callbacks := sm.callbacks.MMIVersion
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicMMIVersion, StateChange[*string]{Old: oldValue, New: value})
 }
 
 // RegisterMMIVersionCallback registers a callback for MMI version changes
-func (sm *StateManager) RegisterMMIVersionCallback(callback StateCallback[*string]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.MMIVersion = append(sm.callbacks.MMIVersion, callback)
+func (sm *StateManager) RegisterMMIVersionCallback(callback StateCallback[*string]) *Subscription {
+	return subscribeState(sm.bus, topicMMIVersion, callback)
 }
 
 func (sm *StateManager) GetDeviceType() DeviceType {
@@ -927,18 +917,13 @@ func (sm *StateManager) SetDeviceType(value DeviceType) {
 	sm.mu.Lock()
 	oldValue := sm.state.DeviceType
 	sm.state.DeviceType = value
-	callbacks := sm.callbacks.DeviceType
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicDeviceType, StateChange[DeviceType]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterDeviceTypeCallback(callback StateCallback[DeviceType]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.DeviceType = append(sm.callbacks.DeviceType, callback)
+func (sm *StateManager) RegisterDeviceTypeCallback(callback StateCallback[DeviceType]) *Subscription {
+	return subscribeState(sm.bus, topicDeviceType, callback)
 }
 
 func (sm *StateManager) GetOmniHomeGolfStatus() *int {
@@ -951,18 +936,13 @@ func (sm *StateManager) SetOmniHomeGolfStatus(value *int) {
 	sm.mu.Lock()
 	oldValue := sm.state.OmniHomeGolfStatus
 	sm.state.OmniHomeGolfStatus = value
-	callbacks := sm.callbacks.OmniHomeGolfStatus
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicOmniHomeGolfStatus, StateChange[*int]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterOmniHomeGolfStatusCallback(callback StateCallback[*int]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.OmniHomeGolfStatus = append(sm.callbacks.OmniHomeGolfStatus, callback)
+func (sm *StateManager) RegisterOmniHomeGolfStatusCallback(callback StateCallback[*int]) *Subscription {
+	return subscribeState(sm.bus, topicOmniHomeGolfStatus, callback)
 }
 
 func (sm *StateManager) GetOmniStatus() *int {
@@ -975,18 +955,13 @@ func (sm *StateManager) SetOmniStatus(value *int) {
 	sm.mu.Lock()
 	oldValue := sm.state.OmniStatus
 	sm.state.OmniStatus = value
-	callbacks := sm.callbacks.OmniStatus
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicOmniStatus, StateChange[*int]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterOmniStatusCallback(callback StateCallback[*int]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.OmniStatus = append(sm.callbacks.OmniStatus, callback)
+func (sm *StateManager) RegisterOmniStatusCallback(callback StateCallback[*int]) *Subscription {
+	return subscribeState(sm.bus, topicOmniStatus, callback)
 }
 
 func (sm *StateManager) GetOmniClubSelection() *int {
@@ -999,18 +974,13 @@ func (sm *StateManager) SetOmniClubSelection(value *int) {
 	sm.mu.Lock()
 	oldValue := sm.state.OmniClubSelection
 	sm.state.OmniClubSelection = value
-	callbacks := sm.callbacks.OmniClubSelection
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicOmniClubSelection, StateChange[*int]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterOmniClubSelectionCallback(callback StateCallback[*int]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.OmniClubSelection = append(sm.callbacks.OmniClubSelection, callback)
+func (sm *StateManager) RegisterOmniClubSelectionCallback(callback StateCallback[*int]) *Subscription {
+	return subscribeState(sm.bus, topicOmniClubSelection, callback)
 }
 
 func (sm *StateManager) GetOmniSensorStatus() *int {
@@ -1023,18 +993,13 @@ func (sm *StateManager) SetOmniSensorStatus(value *int) {
 	sm.mu.Lock()
 	oldValue := sm.state.OmniSensorStatus
 	sm.state.OmniSensorStatus = value
-	callbacks := sm.callbacks.OmniSensorStatus
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicOmniSensorStatus, StateChange[*int]{Old: oldValue, New: value})
 }
 
-func (sm *StateManager) RegisterOmniSensorStatusCallback(callback StateCallback[*int]) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.OmniSensorStatus = append(sm.callbacks.OmniSensorStatus, callback)
+func (sm *StateManager) RegisterOmniSensorStatusCallback(callback StateCallback[*int]) *Subscription {
+	return subscribeState(sm.bus, topicOmniSensorStatus, callback)
 }
 
 func (sm *StateManager) GetCapacitorReady() bool {
@@ -1047,18 +1012,146 @@ func (sm *StateManager) SetCapacitorReady(value bool) {
 	sm.mu.Lock()
 	oldValue := sm.state.CapacitorReady
 	sm.state.CapacitorReady = value
-	callbacks := sm.callbacks.CapacitorReady
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicCapacitorReady, StateChange[bool]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterCapacitorReadyCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicCapacitorReady, callback)
+}
+
+func (sm *StateManager) GetDeviceIdle() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.DeviceIdle
+}
+
+func (sm *StateManager) SetDeviceIdle(value bool) {
+	sm.mu.Lock()
+	oldValue := sm.state.DeviceIdle
+	sm.state.DeviceIdle = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicDeviceIdle, StateChange[bool]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterDeviceIdleCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicDeviceIdle, callback)
+}
+
+func (sm *StateManager) GetDeviceStandby() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.DeviceStandby
+}
+
+func (sm *StateManager) SetDeviceStandby(value bool) {
+	sm.mu.Lock()
+	oldValue := sm.state.DeviceStandby
+	sm.state.DeviceStandby = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicDeviceStandby, StateChange[bool]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterDeviceStandbyCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicDeviceStandby, callback)
+}
+
+func (sm *StateManager) GetSessionPaused() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.SessionPaused
+}
+
+func (sm *StateManager) SetSessionPaused(value bool) {
+	sm.mu.Lock()
+	oldValue := sm.state.SessionPaused
+	sm.state.SessionPaused = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicSessionPaused, StateChange[bool]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterSessionPausedCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicSessionPaused, callback)
 }
 
-func (sm *StateManager) RegisterCapacitorReadyCallback(callback StateCallback[bool]) {
+func (sm *StateManager) GetSwingStickMode() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.SwingStickMode
+}
+
+func (sm *StateManager) SetSwingStickMode(value bool) {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.CapacitorReady = append(sm.callbacks.CapacitorReady, callback)
+	oldValue := sm.state.SwingStickMode
+	sm.state.SwingStickMode = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicSwingStickMode, StateChange[bool]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterSwingStickModeCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicSwingStickMode, callback)
+}
+
+func (sm *StateManager) GetLastSwingMetrics() *ClubMetrics {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.LastSwingMetrics
+}
+
+func (sm *StateManager) SetLastSwingMetrics(value *ClubMetrics) {
+	sm.mu.Lock()
+	oldValue := sm.state.LastSwingMetrics
+	sm.state.LastSwingMetrics = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicLastSwingMetrics, StateChange[*ClubMetrics]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterLastSwingMetricsCallback(callback StateCallback[*ClubMetrics]) *Subscription {
+	return subscribeState(sm.bus, topicLastSwingMetrics, callback)
+}
+
+func (sm *StateManager) GetShotCooldown() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.ShotCooldown
+}
+
+func (sm *StateManager) SetShotCooldown(value bool) {
+	sm.mu.Lock()
+	oldValue := sm.state.ShotCooldown
+	sm.state.ShotCooldown = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicShotCooldown, StateChange[bool]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterShotCooldownCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicShotCooldown, callback)
+}
+
+func (sm *StateManager) GetTaskRestarts() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.TaskRestarts
+}
+
+func (sm *StateManager) SetTaskRestarts(value int) {
+	sm.mu.Lock()
+	oldValue := sm.state.TaskRestarts
+	sm.state.TaskRestarts = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicTaskRestarts, StateChange[int]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterTaskRestartsCallback(callback StateCallback[int]) *Subscription {
+	return subscribeState(sm.bus, topicTaskRestarts, callback)
 }
 
 func (sm *StateManager) GetBatteryCharging() *int {
@@ -1071,16 +1164,67 @@ func (sm *StateManager) SetBatteryCharging(value *int) {
 	sm.mu.Lock()
 	oldValue := sm.state.BatteryCharging
 	sm.state.BatteryCharging = value
-	callbacks := sm.callbacks.BatteryCharging
 	sm.mu.Unlock()
 
-	for _, callback := range callbacks {
-		callback(oldValue, value)
-	}
+	Publish(sm.bus, topicBatteryCharging, StateChange[*int]{Old: oldValue, New: value})
+}
+
+func (sm *StateManager) RegisterBatteryChargingCallback(callback StateCallback[*int]) *Subscription {
+	return subscribeState(sm.bus, topicBatteryCharging, callback)
+}
+
+// GetMisreadPrompt returns whether misread shots are held for the user
+func (sm *StateManager) GetMisreadPrompt() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state.MisreadPrompt
 }
 
-func (sm *StateManager) RegisterBatteryChargingCallback(callback StateCallback[*int]) {
+// SetMisreadPrompt sets whether misread shots are held for the user
+func (sm *StateManager) SetMisreadPrompt(value bool) {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-	sm.callbacks.BatteryCharging = append(sm.callbacks.BatteryCharging, callback)
+	oldValue := sm.state.MisreadPrompt
+	sm.state.MisreadPrompt = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicMisreadPrompt, StateChange[bool]{Old: oldValue, New: value})
+}
+
+// RegisterMisreadPromptCallback registers a callback for misread prompt setting changes
+func (sm *StateManager) RegisterMisreadPromptCallback(callback StateCallback[bool]) *Subscription {
+	return subscribeState(sm.bus, topicMisreadPrompt, callback)
+}
+
+// GetMisreadShots returns a copy of the shots awaiting a misread decision
+func (sm *StateManager) GetMisreadShots() []MisreadShot {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return append([]MisreadShot(nil), sm.state.MisreadShots...)
+}
+
+// SetMisreadShots sets the shots awaiting a misread decision
+func (sm *StateManager) SetMisreadShots(value []MisreadShot) {
+	sm.mu.Lock()
+	oldValue := sm.state.MisreadShots
+	sm.state.MisreadShots = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicMisreadShots, StateChange[[]MisreadShot]{Old: oldValue, New: value})
+}
+
+// UpdateMisreadShots replaces the shots awaiting a misread decision with
+// what update returns for a copy of them, with no other change in between
+func (sm *StateManager) UpdateMisreadShots(update func([]MisreadShot) []MisreadShot) {
+	sm.mu.Lock()
+	oldValue := sm.state.MisreadShots
+	value := update(append([]MisreadShot(nil), oldValue...))
+	sm.state.MisreadShots = value
+	sm.mu.Unlock()
+
+	Publish(sm.bus, topicMisreadShots, StateChange[[]MisreadShot]{Old: oldValue, New: value})
+}
+
+// RegisterMisreadShotsCallback registers a callback for misread queue changes
+func (sm *StateManager) RegisterMisreadShotsCallback(callback StateCallback[[]MisreadShot]) *Subscription {
+	return subscribeState(sm.bus, topicMisreadShots, callback)
 }
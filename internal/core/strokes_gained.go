@@ -0,0 +1,195 @@
+package core
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Practices that record target shots besides the target games, which use
+// their GameMode
+const (
+	PracticeWedges  = "wedges"
+	PracticeCombine = "combine"
+)
+
+// greenRadiusYards is how close to the target a shot must finish to be on
+// the green and putting
+const greenRadiusYards = 10
+
+// strokesPoint is the average number of strokes to hole out from a distance
+type strokesPoint struct {
+	distance float64
+	strokes  float64
+}
+
+// The baselines are a tour player's average strokes to hole out, from
+// published strokes gained tables. Fairway and rough distances are in
+// yards, putts in feet.
+var (
+	fairwayBaseline = []strokesPoint{
+		{10, 2.18}, {20, 2.40}, {40, 2.60}, {60, 2.70}, {80, 2.75}, {100, 2.80},
+		{120, 2.85}, {140, 2.91}, {160, 2.98}, {180, 3.08}, {200, 3.19},
+		{220, 3.32}, {240, 3.45}, {260, 3.58}, {280, 3.69}, {300, 3.78}, {350, 3.98},
+	}
+	roughBaseline = []strokesPoint{
+		{10, 2.34}, {20, 2.59}, {40, 2.78}, {60, 2.91}, {80, 2.96}, {100, 3.02},
+		{120, 3.08}, {140, 3.15}, {160, 3.23}, {180, 3.31}, {200, 3.42},
+		{250, 3.70}, {300, 3.90},
+	}
+	puttBaseline = []strokesPoint{
+		{1, 1.00}, {2, 1.01}, {3, 1.04}, {4, 1.13}, {5, 1.23}, {6, 1.34}, {8, 1.50},
+		{10, 1.61}, {15, 1.78}, {20, 1.87}, {30, 1.98}, {40, 2.06}, {50, 2.14},
+		{60, 2.21}, {90, 2.40},
+	}
+)
+
+// TargetShot is a shot hit at a known target in a practice mode
+type TargetShot struct {
+	Practice     string    `json:"practice"` // a GameMode, PracticeWedges or PracticeCombine
+	Profile      string    `json:"profile"`
+	Session      time.Time `json:"session"` // when the practice started
+	Club         string    `json:"club"`
+	TargetYards  float64   `json:"targetYards"`
+	CarryYards   float64   `json:"carryYards"`
+	OfflineYards float64   `json:"offlineYards"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// MissYards is how far from the target the shot finished
+func (s TargetShot) MissYards() float64 {
+	return math.Hypot(s.CarryYards-s.TargetYards, s.OfflineYards)
+}
+
+// StrokesGained compares the shot to the baseline: the strokes expected from
+// the fairway at the target distance, less the shot itself and the strokes
+// expected from where it finished. A shot within greenRadiusYards is putting;
+// any other is in the rough. Positive is better than the baseline.
+func (s TargetShot) StrokesGained() float64 {
+	return ExpectedStrokes(s.TargetYards, false) - 1 - ExpectedStrokes(s.MissYards(), true)
+}
+
+// ExpectedStrokes returns the baseline strokes to hole out from distanceYards.
+// afterShot treats the ball as having been hit there, so it is putting when
+// close and in the rough otherwise; a ball not yet hit is on the fairway.
+func ExpectedStrokes(distanceYards float64, afterShot bool) float64 {
+	switch {
+	case distanceYards <= 0:
+		return 0
+	case !afterShot:
+		return interpolateStrokes(fairwayBaseline, distanceYards)
+	case distanceYards <= greenRadiusYards:
+		return interpolateStrokes(puttBaseline, distanceYards*3)
+	default:
+		return interpolateStrokes(roughBaseline, distanceYards)
+	}
+}
+
+// interpolateStrokes reads a baseline between its points, holding the
+// nearest value beyond either end
+func interpolateStrokes(baseline []strokesPoint, distance float64) float64 {
+	if distance <= baseline[0].distance {
+		return baseline[0].strokes
+	}
+	for i := 1; i < len(baseline); i++ {
+		if distance <= baseline[i].distance {
+			lo, hi := baseline[i-1], baseline[i]
+			return lo.strokes + (distance-lo.distance)/(hi.distance-lo.distance)*(hi.strokes-lo.strokes)
+		}
+	}
+	return baseline[len(baseline)-1].strokes
+}
+
+// StrokesGainedSummary totals the strokes gained by a group of shots
+type StrokesGainedSummary struct {
+	Shots            int     `json:"shots"`
+	Total            float64 `json:"total"`
+	PerShot          float64 `json:"perShot"`
+	AverageMissYards float64 `json:"averageMissYards"`
+}
+
+// ClubStrokesGained is the strokes gained with one club
+type ClubStrokesGained struct {
+	Club string `json:"club"`
+	StrokesGainedSummary
+}
+
+// SessionStrokesGained is the strokes gained in one run of a practice
+type SessionStrokesGained struct {
+	Practice string    `json:"practice"`
+	Profile  string    `json:"profile"`
+	Session  time.Time `json:"session"`
+	StrokesGainedSummary
+}
+
+// StrokesGainedReport is the strokes gained overall, by club, best first,
+// and by session, newest first
+type StrokesGainedReport struct {
+	StrokesGainedSummary
+	Clubs    []ClubStrokesGained    `json:"clubs"`
+	Sessions []SessionStrokesGained `json:"sessions"`
+}
+
+// SummarizeStrokesGained reports the strokes gained by shots
+func SummarizeStrokesGained(shots []TargetShot) StrokesGainedReport {
+	report := StrokesGainedReport{
+		StrokesGainedSummary: summarizeStrokesGained(shots),
+		Clubs:                []ClubStrokesGained{},
+		Sessions:             []SessionStrokesGained{},
+	}
+
+	byClub := make(map[string][]TargetShot)
+	type sessionKey struct {
+		practice, profile string
+		session           int64
+	}
+	bySession := make(map[sessionKey][]TargetShot)
+	for _, shot := range shots {
+		byClub[shot.Club] = append(byClub[shot.Club], shot)
+		key := sessionKey{shot.Practice, shot.Profile, shot.Session.UnixNano()}
+		bySession[key] = append(bySession[key], shot)
+	}
+
+	for club, group := range byClub {
+		report.Clubs = append(report.Clubs, ClubStrokesGained{Club: club, StrokesGainedSummary: summarizeStrokesGained(group)})
+	}
+	sort.Slice(report.Clubs, func(i, j int) bool {
+		if report.Clubs[i].PerShot != report.Clubs[j].PerShot {
+			return report.Clubs[i].PerShot > report.Clubs[j].PerShot
+		}
+		return report.Clubs[i].Club < report.Clubs[j].Club
+	})
+
+	for key, group := range bySession {
+		report.Sessions = append(report.Sessions, SessionStrokesGained{
+			Practice:             key.practice,
+			Profile:              key.profile,
+			Session:              group[0].Session,
+			StrokesGainedSummary: summarizeStrokesGained(group),
+		})
+	}
+	sort.Slice(report.Sessions, func(i, j int) bool {
+		a, b := report.Sessions[i], report.Sessions[j]
+		if !a.Session.Equal(b.Session) {
+			return a.Session.After(b.Session)
+		}
+		return a.Practice < b.Practice
+	})
+	return report
+}
+
+func summarizeStrokesGained(shots []TargetShot) StrokesGainedSummary {
+	summary := StrokesGainedSummary{Shots: len(shots)}
+	if len(shots) == 0 {
+		return summary
+	}
+	miss := 0.0
+	for _, shot := range shots {
+		summary.Total += shot.StrokesGained()
+		miss += shot.MissYards()
+	}
+	summary.PerShot = math.Round(summary.Total/float64(len(shots))*1000) / 1000
+	summary.Total = math.Round(summary.Total*100) / 100
+	summary.AverageMissYards = roundYards(miss / float64(len(shots)))
+	return summary
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestExpectedStrokes(t *testing.T) {
+	tests := []struct {
+		distance  float64
+		afterShot bool
+		want      float64
+	}{
+		{100, false, 2.80},
+		{110, false, 2.825},  // between 100 and 120
+		{5, false, 2.18},     // held below the table
+		{500, false, 3.98},   // held above the table
+		{2, true, 1.34},      // a 6 foot putt
+		{10, true, 1.98},     // 30 feet, the edge of the green
+		{10.5, true, 2.3525}, // just off the green, in the rough
+		{0, true, 0},         // holed
+	}
+	for _, tt := range tests {
+		if got := ExpectedStrokes(tt.distance, tt.afterShot); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ExpectedStrokes(%v, %v) = %v, want %v", tt.distance, tt.afterShot, got, tt.want)
+		}
+	}
+}
+
+func TestTargetShot_StrokesGained(t *testing.T) {
+	// 100 yards to 2 yards: 2.80 - 1 - 1.34
+	shot := TargetShot{TargetYards: 100, CarryYards: 98, OfflineYards: 0}
+	if got := shot.StrokesGained(); math.Abs(got-0.46) > 1e-9 {
+		t.Errorf("StrokesGained() = %v, want 0.46", got)
+	}
+	// 100 yards to 20 yards in the rough: 2.80 - 1 - 2.59
+	shot = TargetShot{TargetYards: 100, CarryYards: 100, OfflineYards: -20}
+	if got := shot.StrokesGained(); math.Abs(got+0.79) > 1e-9 {
+		t.Errorf("StrokesGained() = %v, want -0.79", got)
+	}
+}
+
+func TestSummarizeStrokesGained(t *testing.T) {
+	first := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	shots := []TargetShot{
+		{Practice: PracticeWedges, Profile: "Sam", Session: first, Club: "Sand Wedge", TargetYards: 100, CarryYards: 98},
+		{Practice: PracticeWedges, Profile: "Sam", Session: first, Club: "Sand Wedge", TargetYards: 100, CarryYards: 100, OfflineYards: -20},
+		{Practice: PracticeCombine, Profile: "Sam", Session: second, Club: "7 Iron", TargetYards: 100, CarryYards: 98},
+	}
+
+	report := SummarizeStrokesGained(shots)
+	if report.Shots != 3 || report.Total != 0.13 || report.PerShot != 0.043 {
+		t.Errorf("summary = %+v, want 3 shots, 0.13 total, 0.043 per shot", report.StrokesGainedSummary)
+	}
+	if len(report.Clubs) != 2 || report.Clubs[0].Club != "7 Iron" || report.Clubs[1].PerShot != -0.165 {
+		t.Errorf("Clubs = %+v, want 7 Iron first and Sand Wedge at -0.165", report.Clubs)
+	}
+	if len(report.Sessions) != 2 || !report.Sessions[0].Session.Equal(second) || report.Sessions[1].Shots != 2 {
+		t.Errorf("Sessions = %+v, want the combine first and the 2 shot wedge practice", report.Sessions)
+	}
+	if report.Sessions[1].AverageMissYards != 11 {
+		t.Errorf("AverageMissYards = %v, want 11", report.Sessions[1].AverageMissYards)
+	}
+
+	if empty := SummarizeStrokesGained(nil); empty.Clubs == nil || empty.Sessions == nil || empty.Shots != 0 {
+		t.Errorf("SummarizeStrokesGained(nil) = %+v, want empty lists", empty)
+	}
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Restart pacing for supervised tasks
+const (
+	supervisorInitialBackoff = 1 * time.Second
+	supervisorMaxBackoff     = 30 * time.Second
+	// supervisorStableRun is how long a task must run before a panic is
+	// treated as a fresh failure rather than a crash loop
+	supervisorStableRun = 1 * time.Minute
+)
+
+// TaskStatus describes a supervised background task
+type TaskStatus struct {
+	Name        string     `json:"name"`
+	Running     bool       `json:"running"`
+	Panics      int        `json:"panics"` // recovered panics; supervised tasks are restarted after each
+	LastPanic   string     `json:"lastPanic,omitempty"`
+	LastPanicAt *time.Time `json:"lastPanicAt,omitempty"`
+}
+
+// Supervisor runs background tasks, recovering panics so one failure doesn't
+// silently stop a connection thread or broadcaster until the app restarts.
+// Restarts are counted in the state manager. A nil Supervisor runs tasks
+// without recovery.
+type Supervisor struct {
+	stateManager *StateManager
+	clock        Clock
+
+	mu       sync.Mutex
+	tasks    map[string]*TaskStatus
+	restarts int
+}
+
+// NewSupervisor creates a Supervisor reporting restarts to sm
+func NewSupervisor(sm *StateManager) *Supervisor {
+	return &Supervisor{
+		stateManager: sm,
+		clock:        RealClock(),
+		tasks:        make(map[string]*TaskStatus),
+	}
+}
+
+// SetClock replaces the clock used to pace restarts
+func (s *Supervisor) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// Go runs task in its own goroutine under Supervise
+func (s *Supervisor) Go(name string, task func()) {
+	go s.Supervise(name, task)
+}
+
+// Supervise runs task, running it again after a backoff each time it panics.
+// It returns once task returns normally.
+func (s *Supervisor) Supervise(name string, task func()) {
+	if s == nil {
+		task()
+		return
+	}
+
+	backoff := supervisorInitialBackoff
+	for {
+		s.setRunning(name, true)
+		started := s.clock.Now()
+		panicValue, panicked := s.run(task)
+		if !panicked {
+			s.setRunning(name, false)
+			return
+		}
+
+		if s.clock.Since(started) >= supervisorStableRun {
+			backoff = supervisorInitialBackoff
+		}
+		log.Printf("Supervisor: %s panicked, restarting in %v: %v", name, backoff, panicValue)
+		s.recordPanic(name, panicValue, true)
+		s.clock.Sleep(backoff)
+		backoff *= 2
+		if backoff > supervisorMaxBackoff {
+			backoff = supervisorMaxBackoff
+		}
+	}
+}
+
+// Guard runs a one-off call, recovering and recording a panic instead of
+// crashing the app. The call is not repeated.
+func (s *Supervisor) Guard(name string, call func()) {
+	if s == nil {
+		call()
+		return
+	}
+	if panicValue, panicked := s.run(call); panicked {
+		log.Printf("Supervisor: %s panicked: %v", name, panicValue)
+		s.recordPanic(name, panicValue, false)
+	}
+}
+
+// Tasks returns the supervised tasks sorted by name
+func (s *Supervisor) Tasks() []TaskStatus {
+	if s == nil {
+		return []TaskStatus{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks := make([]TaskStatus, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, *task)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Name < tasks[j].Name })
+	return tasks
+}
+
+func (s *Supervisor) run(task func()) (panicValue string, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicValue = fmt.Sprint(r)
+			panicked = true
+			log.Printf("Supervisor: stack trace:\n%s", debug.Stack())
+		}
+	}()
+	task()
+	return "", false
+}
+
+func (s *Supervisor) taskLocked(name string) *TaskStatus {
+	task, ok := s.tasks[name]
+	if !ok {
+		task = &TaskStatus{Name: name}
+		s.tasks[name] = task
+	}
+	return task
+}
+
+func (s *Supervisor) setRunning(name string, running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taskLocked(name).Running = running
+}
+
+// recordPanic records a recovered panic, counting a restart in the state
+// manager when the task will be run again
+func (s *Supervisor) recordPanic(name, panicValue string, restart bool) {
+	now := s.clock.Now()
+	s.mu.Lock()
+	task := s.taskLocked(name)
+	task.Panics++
+	task.LastPanic = panicValue
+	task.LastPanicAt = &now
+	if restart {
+		s.restarts++
+	}
+	restarts := s.restarts
+	s.mu.Unlock()
+
+	if restart {
+		s.stateManager.SetTaskRestarts(restarts)
+	}
+}
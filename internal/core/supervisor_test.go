@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSupervisor_RestartsTaskAfterPanic(t *testing.T) {
+	sm := NewStateManager()
+	clock := NewFakeClock(time.Unix(0, 0))
+	supervisor := NewSupervisor(sm)
+	supervisor.SetClock(clock)
+
+	runs := 0
+	done := make(chan struct{})
+	go func() {
+		supervisor.Supervise("task", func() {
+			runs++
+			if runs == 1 {
+				panic("boom")
+			}
+		})
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(supervisorInitialBackoff)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the task to be restarted and finish")
+	}
+
+	if runs != 2 {
+		t.Errorf("Expected 2 runs, got %d", runs)
+	}
+	if got := sm.GetTaskRestarts(); got != 1 {
+		t.Errorf("Expected 1 restart in state, got %d", got)
+	}
+	tasks := supervisor.Tasks()
+	if len(tasks) != 1 || tasks[0].Panics != 1 || tasks[0].LastPanic != "boom" || tasks[0].Running {
+		t.Errorf("Unexpected task status %+v", tasks)
+	}
+}
+
+func TestSupervisor_GuardRecoversWithoutRestart(t *testing.T) {
+	sm := NewStateManager()
+	supervisor := NewSupervisor(sm)
+
+	runs := 0
+	supervisor.Guard("call", func() {
+		runs++
+		panic("boom")
+	})
+
+	if runs != 1 {
+		t.Errorf("Expected a guarded call to run once, got %d", runs)
+	}
+	if got := sm.GetTaskRestarts(); got != 0 {
+		t.Errorf("Expected no restarts in state, got %d", got)
+	}
+	if tasks := supervisor.Tasks(); len(tasks) != 1 || tasks[0].Panics != 1 {
+		t.Errorf("Expected the panic to be recorded, got %+v", tasks)
+	}
+}
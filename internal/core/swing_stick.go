@@ -0,0 +1,105 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// ErrSwingStickUnsupported is returned when swing stick mode is turned on for
+// an Omni, which has no swing stick club codes
+var ErrSwingStickUnsupported = errors.New("swing stick mode is only supported on the SquareGolf Home")
+
+// SetSwingStick turns swing stick training on or off. In swing stick mode the
+// club is selected with its swing stick code and the device measures swings
+// without a ball; each swing's club data is published as the last swing
+// rather than as a shot, so it never reaches the simulators or shot history.
+// Ball detection is armed when the mode is turned on and stays as it was
+// when it is turned off.
+func (lm *LaunchMonitor) SetSwingStick(enabled bool) error {
+	if enabled && lm.stateManager.GetDeviceType() == DeviceTypeOmni {
+		return ErrSwingStickUnsupported
+	}
+	if enabled && lm.IsPaused() {
+		return ErrSessionPaused
+	}
+	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
+		return fmt.Errorf("not connected to device")
+	}
+
+	lm.swingStickMu.Lock()
+	changed := lm.swingStick != enabled
+	lm.swingStick = enabled
+	lm.lastSwingRaw = ""
+	lm.swingStickMu.Unlock()
+	if !changed {
+		return nil
+	}
+
+	log.Printf("LaunchMonitor: Swing stick mode turned %s", onOff(enabled))
+	lm.stateManager.SetSwingStickMode(enabled)
+
+	lm.detectStateMu.Lock()
+	active := lm.detectModeActive
+	lm.detectStateMu.Unlock()
+	if enabled || active {
+		return lm.ActivateBallDetection()
+	}
+	if err := lm.sendClubSelection(); err != nil {
+		return fmt.Errorf("failed to send club command: %w", err)
+	}
+	return nil
+}
+
+// IsSwingStick reports whether swing stick mode is on
+func (lm *LaunchMonitor) IsSwingStick() bool {
+	lm.swingStickMu.Lock()
+	defer lm.swingStickMu.Unlock()
+	return lm.swingStick
+}
+
+// clubCommand builds the club selection for the device and mode in use
+func (lm *LaunchMonitor) clubCommand(sequence int, club ClubType, handedness HandednessType) string {
+	if lm.stateManager.GetDeviceType() == DeviceTypeOmni {
+		// Omni uses adjusted clubSel encoding
+		return OmniClubCommand(sequence, club, handedness)
+	}
+	if lm.IsSwingStick() {
+		return SwingStickCommand(sequence, club, handedness)
+	}
+	return ClubCommand(sequence, club, handedness)
+}
+
+// handleSwingStickSwing takes the ball metrics notification sent for a swing
+// in swing stick mode. There is no ball, so the notification only asks for
+// the club data; repeats of the same notification are ignored.
+func (lm *LaunchMonitor) handleSwingStickSwing(rawData string) {
+	lm.swingStickMu.Lock()
+	repeat := rawData == lm.lastSwingRaw
+	lm.lastSwingRaw = rawData
+	lm.swingStickMu.Unlock()
+	if repeat {
+		return
+	}
+
+	lm.recordActivity()
+	if lm.bluetoothClient == nil || !lm.bluetoothClient.IsConnected() {
+		return
+	}
+	if err := lm.SendCommand(RequestClubMetricsCommand(lm.getNextSequence())); err != nil {
+		log.Printf("Failed to request swing club metrics: %v", err)
+	}
+}
+
+// publishSwing records a swing's club data and re-arms detection for the
+// next swing, as a simulator would after a shot
+func (lm *LaunchMonitor) publishSwing(clubMetrics *ClubMetrics) {
+	log.Printf("LaunchMonitor: Swing stick swing at %s", i18n.Speed(clubMetrics.ClubSpeed, 1))
+	lm.stateManager.SetLastSwingMetrics(clubMetrics)
+
+	if err := lm.ActivateBallDetection(); err != nil {
+		log.Printf("LaunchMonitor: Failed to re-arm swing stick detection: %v", err)
+	}
+}
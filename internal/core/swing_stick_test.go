@@ -0,0 +1,87 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestSwingStick_SwingsAreNotShots(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	sm.SetDeviceType(DeviceTypeHome)
+	mockClient.connected = true
+	wedge := ClubSandWedge
+	sm.SetClub(&wedge)
+	defer lm.Shutdown()
+
+	if err := lm.SetSwingStick(true); err != nil {
+		t.Fatalf("SetSwingStick(true) error = %v", err)
+	}
+	if !lm.IsSwingStick() || !sm.GetSwingStickMode() {
+		t.Fatal("Expected swing stick mode to be on")
+	}
+	if !lm.detectModeActive {
+		t.Error("Expected swing stick mode to arm detection")
+	}
+	history := mockClient.GetWriteHistory()
+	if len(history) != 2 {
+		t.Fatalf("Expected club and detect commands, got %d writes", len(history))
+	}
+	if want := SwingStickCommand(0, wedge, RightHanded)[6:]; hex.EncodeToString(history[0].Data[3:]) != want {
+		t.Errorf("Expected the swing stick code for the sand wedge, got %x", history[0].Data)
+	}
+
+	// The swing's ball notification only asks for its club data
+	swing := []byte{
+		0x11, 0x02, 0x37,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+	}
+	lm.NotificationHandler("", swing)
+	lm.NotificationHandler("", swing)
+	if sm.GetLastBallMetrics() != nil {
+		t.Error("Expected a swing not to be published as a shot")
+	}
+	history = mockClient.GetWriteHistory()
+	if len(history) != 3 || history[2].Data[1] != 0x87 {
+		t.Fatalf("Expected a single club metrics request, got %d writes", len(history)-2)
+	}
+
+	lm.NotificationHandler("", homeClubData)
+	if sm.GetLastClubMetrics() != nil {
+		t.Error("Expected a swing's club data not to be published as a shot's")
+	}
+	swingMetrics := sm.GetLastSwingMetrics()
+	if swingMetrics == nil || swingMetrics.PathAngle != 0.5 {
+		t.Fatalf("Expected the swing's club data, got %+v", swingMetrics)
+	}
+	if len(mockClient.GetWriteHistory()) != 5 {
+		t.Errorf("Expected detection to be re-armed for the next swing, got %d writes", len(mockClient.GetWriteHistory())-3)
+	}
+
+	if err := lm.SetSwingStick(false); err != nil {
+		t.Fatalf("SetSwingStick(false) error = %v", err)
+	}
+	history = mockClient.GetWriteHistory()
+	if want := ClubCommand(0, wedge, RightHanded)[6:]; hex.EncodeToString(history[len(history)-2].Data[3:]) != want {
+		t.Errorf("Expected leaving swing stick mode to restore the regular club code, got %x", history[len(history)-2].Data)
+	}
+}
+
+func TestSwingStick_OmniUnsupported(t *testing.T) {
+	sm, lm, mockClient, _ := newTestLaunchMonitor(t)
+	sm.SetDeviceType(DeviceTypeOmni)
+	mockClient.connected = true
+
+	if err := lm.SetSwingStick(true); !errors.Is(err, ErrSwingStickUnsupported) {
+		t.Errorf("SetSwingStick(true) error = %v, want ErrSwingStickUnsupported", err)
+	}
+	if lm.IsSwingStick() {
+		t.Error("Expected swing stick mode to stay off")
+	}
+}
@@ -0,0 +1,298 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GameMode names a driving range target game
+type GameMode string
+
+const (
+	// GameClosestToPin scores a fixed number of shots, each at a new random
+	// target, by how close they finish
+	GameClosestToPin GameMode = "closestToPin"
+	// GameLadder climbs targets from shortest to longest. A shot within the
+	// tolerance moves up a rung; a miss repeats it.
+	GameLadder GameMode = "ladder"
+)
+
+// Game defaults
+const (
+	DefaultGameProfile          = "Player"
+	DefaultGameMinYards         = 50
+	DefaultGameMaxYards         = 150
+	DefaultClosestToPinShots    = 10
+	DefaultLadderStepYards      = 10
+	DefaultLadderToleranceYards = 5
+	DefaultLadderMaxShots       = 30
+
+	// gameTargetRounding rounds random targets to distances a range marks
+	gameTargetRounding = 5
+	// maxGameYards bounds targets to what the carry estimate can reach
+	maxGameYards = 350
+	maxGameShots = 100
+	// closestToPinMissFraction is the miss, as a fraction of the target
+	// distance, that scores nothing
+	closestToPinMissFraction = 0.25
+	maxClosestToPinPoints    = 100
+)
+
+// ErrNoGame is returned when scoring a shot with no game in progress
+var ErrNoGame = errors.New("no game in progress")
+
+// Valid reports whether m is a known game mode
+func (m GameMode) Valid() bool {
+	return m == GameClosestToPin || m == GameLadder
+}
+
+// GameConfig starts a game. Zero values take the mode's defaults.
+type GameConfig struct {
+	Mode           GameMode `json:"mode"`
+	Profile        string   `json:"profile"`
+	MinYards       float64  `json:"minYards,omitempty"`
+	MaxYards       float64  `json:"maxYards,omitempty"`
+	Shots          int      `json:"shots,omitempty"`          // closest to pin: shots played; ladder: shot limit
+	StepYards      float64  `json:"stepYards,omitempty"`      // ladder only
+	ToleranceYards float64  `json:"toleranceYards,omitempty"` // ladder only
+}
+
+// withDefaults fills in unset fields
+func (c GameConfig) withDefaults() GameConfig {
+	c.Profile = strings.TrimSpace(c.Profile)
+	if c.Profile == "" {
+		c.Profile = DefaultGameProfile
+	}
+	if c.MinYards == 0 {
+		c.MinYards = DefaultGameMinYards
+	}
+	if c.MaxYards == 0 {
+		c.MaxYards = DefaultGameMaxYards
+	}
+	if c.Mode == GameLadder {
+		if c.Shots == 0 {
+			c.Shots = DefaultLadderMaxShots
+		}
+		if c.StepYards == 0 {
+			c.StepYards = DefaultLadderStepYards
+		}
+		if c.ToleranceYards == 0 {
+			c.ToleranceYards = DefaultLadderToleranceYards
+		}
+	} else if c.Shots == 0 {
+		c.Shots = DefaultClosestToPinShots
+	}
+	return c
+}
+
+// Validate checks a config after defaults are applied
+func (c GameConfig) Validate() error {
+	switch {
+	case !c.Mode.Valid():
+		return errors.New("unknown game mode")
+	case c.MinYards < 1 || c.MaxYards > maxGameYards || c.MinYards > c.MaxYards:
+		return errors.New("invalid target range")
+	case c.Shots < 1 || c.Shots > maxGameShots:
+		return errors.New("invalid number of shots")
+	case c.Mode == GameLadder && (c.StepYards <= 0 || c.ToleranceYards <= 0 || (c.MaxYards-c.MinYards)/c.StepYards >= maxGameShots):
+		return errors.New("invalid ladder step or tolerance")
+	}
+	return nil
+}
+
+// GameTarget is the distance a shot is aimed at, straight down the target
+// line
+type GameTarget struct {
+	DistanceYards  float64 `json:"distanceYards"`
+	ToleranceYards float64 `json:"toleranceYards,omitempty"` // ladder only
+}
+
+// GameShot is a scored shot
+type GameShot struct {
+	Number       int        `json:"number"`
+	Target       GameTarget `json:"target"`
+	CarryYards   float64    `json:"carryYards"`
+	OfflineYards float64    `json:"offlineYards"`
+	MissYards    float64    `json:"missYards"` // distance from the target
+	Points       int        `json:"points"`
+	Hit          bool       `json:"hit"`
+}
+
+// GameState is a game's progress. Target is nil once the game is finished.
+type GameState struct {
+	Config    GameConfig  `json:"config"`
+	StartedAt time.Time   `json:"startedAt"`
+	Target    *GameTarget `json:"target"`
+	Shots     []GameShot  `json:"shots"`
+	Score     int         `json:"score"`
+	Rung      int         `json:"rung,omitempty"`  // ladder: rungs cleared
+	Rungs     int         `json:"rungs,omitempty"` // ladder: rungs to clear
+	Finished  bool        `json:"finished"`
+}
+
+// Game runs one target game. It is not safe for concurrent use.
+type Game struct {
+	state   GameState
+	targets []float64 // ladder rungs
+	rng     *rand.Rand
+}
+
+// NewGame starts a game. rng picks closest-to-pin targets; nil seeds one
+// from the clock.
+func NewGame(config GameConfig, rng *rand.Rand) (*Game, error) {
+	config = config.withDefaults()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	g := &Game{
+		state: GameState{Config: config, StartedAt: time.Now(), Shots: []GameShot{}},
+		rng:   rng,
+	}
+	if config.Mode == GameLadder {
+		for distance := config.MinYards; distance <= config.MaxYards; distance += config.StepYards {
+			g.targets = append(g.targets, distance)
+		}
+		g.state.Rungs = len(g.targets)
+	}
+	g.nextTarget()
+	return g, nil
+}
+
+// State returns a copy of the game's progress
+func (g *Game) State() GameState {
+	state := g.state
+	state.Shots = append([]GameShot(nil), g.state.Shots...)
+	if g.state.Target != nil {
+		target := *g.state.Target
+		state.Target = &target
+	}
+	return state
+}
+
+// Score scores a shot against the current target and moves on to the next.
+// Offline is positive to the right.
+func (g *Game) Score(carryYards, offlineYards float64) (GameShot, error) {
+	if g.state.Finished || g.state.Target == nil {
+		return GameShot{}, ErrNoGame
+	}
+
+	target := *g.state.Target
+	shot := GameShot{
+		Number:       len(g.state.Shots) + 1,
+		Target:       target,
+		CarryYards:   roundYards(carryYards),
+		OfflineYards: roundYards(offlineYards),
+		MissYards:    roundYards(math.Hypot(carryYards-target.DistanceYards, offlineYards)),
+	}
+
+	switch g.state.Config.Mode {
+	case GameClosestToPin:
+		shot.Points = closestToPinPoints(target.DistanceYards, shot.MissYards)
+		shot.Hit = shot.Points > 0
+		g.state.Score += shot.Points
+	case GameLadder:
+		shot.Hit = shot.MissYards <= target.ToleranceYards
+		if shot.Hit {
+			g.state.Rung++
+			g.state.Score = g.state.Rung
+		}
+	}
+	g.state.Shots = append(g.state.Shots, shot)
+
+	if len(g.state.Shots) >= g.state.Config.Shots || (g.state.Rungs > 0 && g.state.Rung >= g.state.Rungs) {
+		g.state.Finished = true
+		g.state.Target = nil
+	} else {
+		g.nextTarget()
+	}
+	return shot, nil
+}
+
+func (g *Game) nextTarget() {
+	config := g.state.Config
+	switch config.Mode {
+	case GameLadder:
+		g.state.Target = &GameTarget{DistanceYards: g.targets[g.state.Rung], ToleranceYards: config.ToleranceYards}
+	default:
+		distance := config.MinYards + g.rng.Float64()*(config.MaxYards-config.MinYards)
+		distance = math.Round(distance/gameTargetRounding) * gameTargetRounding
+		distance = math.Max(config.MinYards, math.Min(config.MaxYards, distance))
+		g.state.Target = &GameTarget{DistanceYards: distance}
+	}
+}
+
+// GameResult is a finished game on a leaderboard
+type GameResult struct {
+	Mode     GameMode  `json:"mode"`
+	Profile  string    `json:"profile"`
+	Score    int       `json:"score"`
+	Shots    int       `json:"shots"`
+	PlayedAt time.Time `json:"playedAt"`
+}
+
+// Result summarizes a game for the leaderboard
+func (s GameState) Result() GameResult {
+	return GameResult{
+		Mode:     s.Config.Mode,
+		Profile:  s.Config.Profile,
+		Score:    s.Score,
+		Shots:    len(s.Shots),
+		PlayedAt: s.StartedAt,
+	}
+}
+
+// outranks reports whether r places above other: a higher score, then
+// fewer shots, then the earlier game
+func (r GameResult) outranks(other GameResult) bool {
+	if r.Score != other.Score {
+		return r.Score > other.Score
+	}
+	if r.Shots != other.Shots {
+		return r.Shots < other.Shots
+	}
+	return r.PlayedAt.Before(other.PlayedAt)
+}
+
+// GameLeaderboard ranks each profile's best result in mode. Profiles are
+// matched without regard to case.
+func GameLeaderboard(results []GameResult, mode GameMode) []GameResult {
+	best := make(map[string]GameResult)
+	for _, result := range results {
+		if result.Mode != mode {
+			continue
+		}
+		key := strings.ToLower(result.Profile)
+		if current, ok := best[key]; !ok || result.outranks(current) {
+			best[key] = result
+		}
+	}
+
+	leaderboard := make([]GameResult, 0, len(best))
+	for _, result := range best {
+		leaderboard = append(leaderboard, result)
+	}
+	sort.Slice(leaderboard, func(i, j int) bool {
+		return leaderboard[i].outranks(leaderboard[j])
+	})
+	return leaderboard
+}
+
+// closestToPinPoints scores a miss out of 100, falling to nothing at a
+// quarter of the target distance so long and short targets are equally hard
+func closestToPinPoints(targetYards, missYards float64) int {
+	scale := targetYards * closestToPinMissFraction
+	points := maxClosestToPinPoints * (1 - missYards/scale)
+	return int(math.Max(0, math.Round(points)))
+}
+
+func roundYards(yards float64) float64 {
+	return math.Round(yards*10) / 10
+}
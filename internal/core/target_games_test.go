@@ -0,0 +1,127 @@
+package core
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestNewGame_Defaults(t *testing.T) {
+	game, err := NewGame(GameConfig{Mode: GameClosestToPin, Profile: "  "}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+	state := game.State()
+	if state.Config.Profile != DefaultGameProfile || state.Config.Shots != DefaultClosestToPinShots {
+		t.Errorf("Config = %+v, want defaults", state.Config)
+	}
+	target := state.Target.DistanceYards
+	if target < DefaultGameMinYards || target > DefaultGameMaxYards || int(target)%gameTargetRounding != 0 {
+		t.Errorf("Target = %v, want a multiple of %d in range", target, gameTargetRounding)
+	}
+}
+
+func TestNewGame_RejectsInvalidConfig(t *testing.T) {
+	configs := []GameConfig{
+		{Mode: "golf"},
+		{Mode: GameClosestToPin, MinYards: 200, MaxYards: 100},
+		{Mode: GameClosestToPin, MaxYards: 500},
+		{Mode: GameClosestToPin, Shots: -1},
+		{Mode: GameLadder, StepYards: 0.5},
+	}
+	for _, config := range configs {
+		if _, err := NewGame(config, nil); err == nil {
+			t.Errorf("NewGame(%+v) succeeded, want an error", config)
+		}
+	}
+}
+
+func TestGame_ClosestToPin(t *testing.T) {
+	game, err := NewGame(GameConfig{Mode: GameClosestToPin, MinYards: 100, MaxYards: 100, Shots: 3}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		carry, offline float64
+		points         int
+	}{
+		{100, 0, 100},
+		{110, 0, 60},  // 10 of the 25 yard scale
+		{100, -30, 0}, // beyond a quarter of the distance
+	}
+	total := 0
+	for _, tt := range tests {
+		shot, err := game.Score(tt.carry, tt.offline)
+		if err != nil {
+			t.Fatalf("Score() error = %v", err)
+		}
+		if shot.Points != tt.points || shot.Hit != (tt.points > 0) {
+			t.Errorf("Score(%v, %v) = %+v, want %d points", tt.carry, tt.offline, shot, tt.points)
+		}
+		total += tt.points
+	}
+
+	state := game.State()
+	if !state.Finished || state.Target != nil || state.Score != total {
+		t.Errorf("State() = %+v, want finished with score %d", state, total)
+	}
+	if _, err := game.Score(100, 0); !errors.Is(err, ErrNoGame) {
+		t.Errorf("Score() after the last shot error = %v, want ErrNoGame", err)
+	}
+}
+
+func TestGame_Ladder(t *testing.T) {
+	game, err := NewGame(GameConfig{Mode: GameLadder, MinYards: 50, MaxYards: 70, StepYards: 10, ToleranceYards: 5}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := game.State(); state.Rungs != 3 || state.Target.DistanceYards != 50 {
+		t.Fatalf("State() = %+v, want 3 rungs starting at 50", state)
+	}
+
+	// A miss repeats the rung
+	game.Score(40, 0)
+	if state := game.State(); state.Rung != 0 || state.Target.DistanceYards != 50 {
+		t.Errorf("after a miss State() = %+v, want the same rung", state)
+	}
+
+	for _, carry := range []float64{52, 58, 71} {
+		game.Score(carry, 1)
+	}
+	state := game.State()
+	if !state.Finished || state.Rung != 3 || state.Score != 3 || len(state.Shots) != 4 {
+		t.Errorf("State() = %+v, want finished with every rung cleared in 4 shots", state)
+	}
+}
+
+func TestGame_LadderShotLimit(t *testing.T) {
+	game, _ := NewGame(GameConfig{Mode: GameLadder, Shots: 2}, nil)
+	game.Score(50, 0)
+	game.Score(0, 0)
+	if state := game.State(); !state.Finished || state.Score != 1 {
+		t.Errorf("State() = %+v, want finished after the shot limit with one rung", state)
+	}
+}
+
+func TestGameLeaderboard(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := []GameResult{
+		{Mode: GameClosestToPin, Profile: "Ana", Score: 400, Shots: 10, PlayedAt: day},
+		{Mode: GameClosestToPin, Profile: "ana", Score: 650, Shots: 10, PlayedAt: day.Add(time.Hour)},
+		{Mode: GameClosestToPin, Profile: "Ben", Score: 650, Shots: 10, PlayedAt: day.Add(2 * time.Hour)},
+		{Mode: GameLadder, Profile: "Ben", Score: 11, Shots: 20, PlayedAt: day},
+		{Mode: GameLadder, Profile: "Cy", Score: 11, Shots: 15, PlayedAt: day},
+	}
+
+	ctp := GameLeaderboard(results, GameClosestToPin)
+	if len(ctp) != 2 || ctp[0].Profile != "ana" || ctp[0].Score != 650 || ctp[1].Profile != "Ben" {
+		t.Errorf("closest to pin leaderboard = %+v, want ana's best then Ben (tie goes to the earlier game)", ctp)
+	}
+
+	ladder := GameLeaderboard(results, GameLadder)
+	if len(ladder) != 2 || ladder[0].Profile != "Cy" {
+		t.Errorf("ladder leaderboard = %+v, want Cy first with fewer shots", ladder)
+	}
+}
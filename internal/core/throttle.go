@@ -0,0 +1,97 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// Default rates for ball position output. The state manager still receives
+// every position at the device's rate.
+const (
+	DefaultPositionBroadcastRate = 10 // Hz, to WebSocket clients
+	DefaultPositionLogRate       = 2  // Hz, to the log
+)
+
+// RateInterval converts a rate in Hz to the interval between events. A
+// non-positive rate means unlimited and returns 0.
+func RateInterval(hz int) time.Duration {
+	if hz <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(hz)
+}
+
+// Throttle coalesces bursts of Trigger calls into at most one run of fn per
+// interval. A call that arrives too soon is deferred rather than dropped, so
+// the last state in a burst is always delivered.
+type Throttle struct {
+	mu       sync.Mutex
+	clock    Clock
+	interval time.Duration
+	fn       func()
+	last     time.Time
+	pending  bool
+}
+
+// NewThrottle creates a throttle. An interval of 0 runs fn on every Trigger.
+func NewThrottle(clock Clock, interval time.Duration, fn func()) *Throttle {
+	return &Throttle{clock: clock, interval: interval, fn: fn}
+}
+
+// Trigger runs fn now if the interval has passed since the last run, and
+// otherwise schedules a single run for when it has
+func (t *Throttle) Trigger() {
+	t.mu.Lock()
+	if t.pending {
+		t.mu.Unlock()
+		return
+	}
+
+	wait := t.interval - t.clock.Since(t.last)
+	if t.interval <= 0 || t.last.IsZero() || wait <= 0 {
+		t.last = t.clock.Now()
+		t.mu.Unlock()
+		t.fn()
+		return
+	}
+
+	t.pending = true
+	t.mu.Unlock()
+
+	go func() {
+		<-t.clock.After(wait)
+
+		t.mu.Lock()
+		t.pending = false
+		t.last = t.clock.Now()
+		t.mu.Unlock()
+		t.fn()
+	}()
+}
+
+// RateLimiter allows an event at most once per interval and drops the rest.
+// It suits log lines, where only a sample is needed.
+type RateLimiter struct {
+	mu       sync.Mutex
+	clock    Clock
+	interval time.Duration
+	last     time.Time
+}
+
+// NewRateLimiter creates a rate limiter. An interval of 0 allows every event.
+func NewRateLimiter(clock Clock, interval time.Duration) *RateLimiter {
+	return &RateLimiter{clock: clock, interval: interval}
+}
+
+// Allow reports whether an event may happen now
+func (r *RateLimiter) Allow() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	if r.interval > 0 && !r.last.IsZero() && now.Sub(r.last) < r.interval {
+		return false
+	}
+	r.last = now
+	return true
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottle_CoalescesBurstAndDeliversLast(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var runs atomic.Int32
+	throttle := NewThrottle(clock, 100*time.Millisecond, func() {
+		runs.Add(1)
+	})
+
+	throttle.Trigger()
+	if runs.Load() != 1 {
+		t.Fatalf("Expected the first trigger to run immediately, got %d runs", runs.Load())
+	}
+
+	for i := 0; i < 20; i++ {
+		throttle.Trigger()
+	}
+	if runs.Load() != 1 {
+		t.Fatalf("Expected a burst within the interval to be deferred, got %d runs", runs.Load())
+	}
+
+	clock.BlockUntil(1)
+	clock.Advance(100 * time.Millisecond)
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() != 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if runs.Load() != 2 {
+		t.Errorf("Expected the burst to coalesce into one trailing run, got %d runs", runs.Load())
+	}
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	limiter := NewRateLimiter(clock, RateInterval(2))
+
+	if !limiter.Allow() {
+		t.Fatal("Expected the first event to be allowed")
+	}
+	if limiter.Allow() {
+		t.Error("Expected an event within the interval to be dropped")
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if !limiter.Allow() {
+		t.Error("Expected an event after the interval to be allowed")
+	}
+}
+
+func TestRateInterval_Unlimited(t *testing.T) {
+	if got := RateInterval(0); got != 0 {
+		t.Errorf("Expected 0 Hz to mean unlimited, got %v", got)
+	}
+	if got := RateInterval(10); got != 100*time.Millisecond {
+		t.Errorf("Expected 10 Hz to be 100ms, got %v", got)
+	}
+}
@@ -0,0 +1,13 @@
+package core
+
+import "tinygo.org/x/bluetooth"
+
+// parseTinyGoAddress parses a saved device address. macOS identifies
+// peripherals by a UUID assigned by CoreBluetooth rather than a MAC address.
+func parseTinyGoAddress(address string) (bluetooth.Address, bool) {
+	uuid, err := bluetooth.ParseUUID(address)
+	if err != nil {
+		return bluetooth.Address{}, false
+	}
+	return bluetooth.Address{UUID: uuid}, true
+}
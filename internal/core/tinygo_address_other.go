@@ -0,0 +1,14 @@
+//go:build !darwin
+
+package core
+
+import "tinygo.org/x/bluetooth"
+
+// parseTinyGoAddress parses a saved device address
+func parseTinyGoAddress(address string) (bluetooth.Address, bool) {
+	mac, err := bluetooth.ParseMAC(address)
+	if err != nil {
+		return bluetooth.Address{}, false
+	}
+	return bluetooth.Address{MACAddress: bluetooth.MACAddress{MAC: mac}}, true
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"tinygo.org/x/bluetooth"
@@ -71,6 +72,62 @@ func releaseAdapter() {
 	}
 }
 
+const (
+	// maxAttributeValueLength is the longest value a characteristic can hold.
+	// The OS fetches values longer than the MTU with read blob requests.
+	maxAttributeValueLength = 512
+	// attNotificationOverhead is the ATT header sent with each notification,
+	// so a notification carries at most MTU - 3 bytes
+	attNotificationOverhead = 3
+)
+
+// serviceChangedCharUUID is the GATT Service Changed characteristic. A device
+// indicates on it when its services change, invalidating discovered
+// characteristics.
+var serviceChangedCharUUID = bluetooth.New16BitUUID(0x2A05).String()
+
+// notificationSubscription passes notifications from one characteristic to a
+// handler until it is cancelled. The adapter can't always unsubscribe, so
+// cancelling is what stops a stale characteristic reaching the handler.
+type notificationSubscription struct {
+	uuid    string
+	handler func([]byte)
+	limit   int // largest notification the MTU allows, 0 if unknown
+	active  atomic.Bool
+	warned  atomic.Bool
+}
+
+func newNotificationSubscription(uuid string, handler func([]byte), mtu int) *notificationSubscription {
+	sub := &notificationSubscription{uuid: uuid, handler: handler}
+	if mtu > attNotificationOverhead {
+		sub.limit = mtu - attNotificationOverhead
+	}
+	sub.active.Store(true)
+	return sub
+}
+
+// deliver passes a copy of buf to the handler. It runs on the adapter's
+// goroutine without the client mutex.
+func (s *notificationSubscription) deliver(buf []byte) {
+	if !s.active.Load() || s.handler == nil {
+		return
+	}
+	// A notification that fills the MTU may have been cut short by the OS
+	if s.limit > 0 && len(buf) >= s.limit && s.warned.CompareAndSwap(false, true) {
+		log.Printf("Notification on %s filled the %d byte limit and may be truncated", s.uuid, s.limit)
+	}
+
+	// Copying the data to avoid potential race conditions
+	dataCopy := make([]byte, len(buf))
+	copy(dataCopy, buf)
+	s.handler(dataCopy)
+}
+
+// cancel stops further notifications reaching the handler
+func (s *notificationSubscription) cancel() {
+	s.active.Store(false)
+}
+
 // ConnectionPhase represents the current phase of the connection process
 type ConnectionPhase string
 
@@ -81,15 +138,17 @@ const (
 
 // TinyGoBluetoothClient implements BluetoothClient interface using tinygo-org/bluetooth
 type TinyGoBluetoothClient struct {
-	adapter              *bluetooth.Adapter
-	device               *bluetooth.Device
-	connected            bool
-	mutex                sync.Mutex
-	characteristics      map[string]*bluetooth.DeviceCharacteristic
-	notificationHandlers map[string]func([]byte)
-	connectedDeviceName  string // Store the name of the connected device
-	connectedScanResult  *bluetooth.ScanResult
-	onPhaseChange        func(ConnectionPhase)
+	adapter             *bluetooth.Adapter
+	device              *bluetooth.Device
+	connected           bool
+	mutex               sync.Mutex
+	characteristics     map[string]*bluetooth.DeviceCharacteristic
+	subscriptions       map[string]*notificationSubscription
+	mtu                 int    // ATT MTU of the connection, 0 if unknown
+	connectedDeviceName string // Store the name of the connected device
+	connectedScanResult *bluetooth.ScanResult
+	onPhaseChange       func(ConnectionPhase)
+	onConnectionLost    func()
 
 	// New fields for scan management
 	scanning    bool
@@ -107,11 +166,11 @@ func NewTinyGoBluetoothClient() (*TinyGoBluetoothClient, error) {
 	}
 
 	return &TinyGoBluetoothClient{
-		adapter:              adapter,
-		connected:            false,
-		characteristics:      make(map[string]*bluetooth.DeviceCharacteristic),
-		notificationHandlers: make(map[string]func([]byte)),
-		scanResults:          make(map[string]bluetooth.ScanResult),
+		adapter:         adapter,
+		connected:       false,
+		characteristics: make(map[string]*bluetooth.DeviceCharacteristic),
+		subscriptions:   make(map[string]*notificationSubscription),
+		scanResults:     make(map[string]bluetooth.ScanResult),
 	}, nil
 }
 
@@ -129,6 +188,44 @@ func (t *TinyGoBluetoothClient) notifyPhaseChange(phase ConnectionPhase) {
 	}
 }
 
+// SetConnectionLostCallback sets a callback to be notified when the link to the
+// device drops without Disconnect being called
+func (t *TinyGoBluetoothClient) SetConnectionLostCallback(callback func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.onConnectionLost = callback
+}
+
+// handleConnectionChange is registered with the adapter to watch for the
+// connected device going away
+func (t *TinyGoBluetoothClient) handleConnectionChange(device bluetooth.Device, connected bool) {
+	if connected {
+		return
+	}
+	// The adapter may call this from within Disconnect, which holds the mutex
+	go t.handleConnectionLost(device.Address.String())
+}
+
+// handleConnectionLost clears the connection state after an unexpected drop
+func (t *TinyGoBluetoothClient) handleConnectionLost(address string) {
+	t.mutex.Lock()
+	if !t.connected || t.device == nil || t.device.Address.String() != address {
+		t.mutex.Unlock()
+		return
+	}
+
+	log.Printf("Connection to device %s lost", address)
+	t.connected = false
+	t.device = nil
+	t.clearCharacteristicsLocked()
+	callback := t.onConnectionLost
+	t.mutex.Unlock()
+
+	if callback != nil {
+		callback()
+	}
+}
+
 // StartScan starts scanning for BLE devices in the background
 func (t *TinyGoBluetoothClient) StartScan(prefix string) error {
 	t.scanMutex.Lock()
@@ -231,7 +328,7 @@ func (t *TinyGoBluetoothClient) Connect(targetName, targetAddress string) error
 	// Check if we have the device in our scan results
 	for _, result := range t.scanResults {
 		if (targetName != "" && result.LocalName() == targetName) ||
-			(targetAddress != "" && result.Address.String() == targetAddress) ||
+			(targetAddress != "" && strings.EqualFold(result.Address.String(), targetAddress)) ||
 			(targetName == "" && strings.HasPrefix(result.LocalName(), BluetoothDevicePrefix)) {
 			deviceToConnect = result
 			found = true
@@ -248,6 +345,23 @@ func (t *TinyGoBluetoothClient) Connect(targetName, targetAddress string) error
 
 	t.scanMutex.Unlock()
 
+	// A saved address can be connected to without scanning if the system has
+	// seen the device before. Otherwise fall back to a scan.
+	if !found && targetAddress != "" {
+		if address, ok := parseTinyGoAddress(targetAddress); ok {
+			t.notifyPhaseChange(PhaseConnecting)
+			t.adapter.SetConnectHandler(t.handleConnectionChange)
+
+			log.Printf("Connecting directly to device [%s]...", targetAddress)
+			device, err := t.adapter.Connect(address, bluetooth.ConnectionParams{})
+			if err == nil {
+				log.Println("Successfully connected to device")
+				return t.setupConnection(device, targetName, nil)
+			}
+			log.Printf("Direct connection failed, scanning instead: %v", err)
+		}
+	}
+
 	// If device not found in existing scan results, start a new scan
 	if !found {
 		log.Printf("Target device not in scan results, starting new scan for '%s' or '%s'...", targetName, targetAddress)
@@ -260,7 +374,7 @@ func (t *TinyGoBluetoothClient) Connect(targetName, targetAddress string) error
 
 		err := t.adapter.Scan(func(adapter *bluetooth.Adapter, device bluetooth.ScanResult) {
 			if (targetName != "" && device.LocalName() == targetName) ||
-				(targetAddress != "" && device.Address.String() == targetAddress) ||
+				(targetAddress != "" && strings.EqualFold(device.Address.String(), targetAddress)) ||
 				(targetName == "" && strings.HasPrefix(device.LocalName(), BluetoothDevicePrefix)) {
 				log.Printf("Found target device: %s [%s]", device.LocalName(), device.Address.String())
 				adapter.StopScan()
@@ -290,6 +404,9 @@ func (t *TinyGoBluetoothClient) Connect(targetName, targetAddress string) error
 	// Notify that we're in connecting phase
 	t.notifyPhaseChange(PhaseConnecting)
 
+	// Watch for the link dropping so a reconnect can restore it
+	t.adapter.SetConnectHandler(t.handleConnectionChange)
+
 	// Connect to the device
 	log.Printf("Connecting to device %s [%s]...", deviceToConnect.LocalName(), deviceToConnect.Address.String())
 	device, err := t.adapter.Connect(deviceToConnect.Address, bluetooth.ConnectionParams{})
@@ -298,19 +415,41 @@ func (t *TinyGoBluetoothClient) Connect(targetName, targetAddress string) error
 		return fmt.Errorf("failed to connect to device: %w", err)
 	}
 	log.Println("Successfully connected to device")
+	return t.setupConnection(device, deviceToConnect.LocalName(), &deviceToConnect)
+}
+
+// setupConnection discovers the characteristics of a newly connected device.
+// scanResult is nil when the device was connected to by address without a
+// scan, so its manufacturer data isn't known. Callers hold the mutex.
+func (t *TinyGoBluetoothClient) setupConnection(device bluetooth.Device, name string, scanResult *bluetooth.ScanResult) error {
 	t.device = &device
-	t.connectedDeviceName = deviceToConnect.LocalName()
-	t.connectedScanResult = &deviceToConnect
+	t.connectedDeviceName = name
+	t.connectedScanResult = scanResult
+
+	characteristics, err := t.discoverCharacteristicsLocked()
+	if err != nil {
+		return err
+	}
+	t.characteristics = characteristics
+	t.watchServiceChangedLocked()
+
+	log.Println("Connection process completed successfully")
+	t.connected = true
+	return nil
+}
 
-	// Discover services and characteristics
+// discoverCharacteristicsLocked discovers the connected device's services and
+// characteristics and records the MTU. Callers hold the mutex.
+func (t *TinyGoBluetoothClient) discoverCharacteristicsLocked() (map[string]*bluetooth.DeviceCharacteristic, error) {
 	log.Println("Discovering services...")
-	services, err := device.DiscoverServices(nil)
+	services, err := t.device.DiscoverServices(nil)
 	if err != nil {
 		log.Printf("Error discovering services: %v", err)
-		return fmt.Errorf("failed to discover services: %w", err)
+		return nil, fmt.Errorf("failed to discover services: %w", err)
 	}
 	log.Printf("Found %d services", len(services))
 
+	found := make(map[string]*bluetooth.DeviceCharacteristic)
 	for i, service := range services {
 		log.Printf("Service %d: %s", i+1, service.UUID().String())
 		log.Printf("Discovering characteristics for service %s...", service.UUID().String())
@@ -324,16 +463,115 @@ func (t *TinyGoBluetoothClient) Connect(targetName, targetAddress string) error
 		for j, char := range characteristics {
 			uuidStr := char.UUID().String()
 			charCopy := char // Create a copy to avoid issues with loop variable capture
-			t.characteristics[uuidStr] = &charCopy
+			found[uuidStr] = &charCopy
 			log.Printf("Characteristic %d.%d: %s", i+1, j+1, uuidStr)
 		}
 	}
 
-	log.Println("Connection process completed successfully")
-	t.connected = true
+	// The OS negotiates the MTU when it connects. Not every platform reports
+	// it, and notifications are only checked for truncation when it does.
+	t.mtu = 0
+	if char, ok := found[NotificationCharUUID]; ok {
+		if mtu, err := char.GetMTU(); err == nil && mtu > 0 {
+			t.mtu = int(mtu)
+			log.Printf("Negotiated MTU: %d bytes", t.mtu)
+		}
+	}
+
+	return found, nil
+}
+
+// watchServiceChangedLocked refreshes the characteristics when the device
+// indicates its services changed. Devices without the Service Changed
+// characteristic, or platforms that handle it themselves, are left alone.
+func (t *TinyGoBluetoothClient) watchServiceChangedLocked() {
+	char, ok := t.characteristics[serviceChangedCharUUID]
+	if !ok {
+		return
+	}
+	err := t.subscribeLocked(serviceChangedCharUUID, char, func([]byte) {
+		log.Println("Device services changed, refreshing characteristics")
+		// The notification arrives on the adapter's goroutine
+		go func() {
+			if err := t.RefreshCharacteristics(); err != nil {
+				log.Printf("Failed to refresh characteristics: %v", err)
+			}
+		}()
+	})
+	if err != nil {
+		log.Printf("Failed to watch for service changes: %v", err)
+	}
+}
+
+// RefreshCharacteristics discovers the connected device's characteristics
+// again and moves notification handlers over to them
+func (t *TinyGoBluetoothClient) RefreshCharacteristics() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.connected || t.device == nil {
+		return errors.New("not connected")
+	}
+	return t.refreshCharacteristicsLocked()
+}
+
+// refreshCharacteristicsLocked replaces the discovered characteristics.
+// Subscriptions on the old characteristics are cancelled and made again on
+// the new ones. Callers hold the mutex.
+func (t *TinyGoBluetoothClient) refreshCharacteristicsLocked() error {
+	characteristics, err := t.discoverCharacteristicsLocked()
+	if err != nil {
+		return err
+	}
+
+	handlers := make(map[string]func([]byte), len(t.subscriptions))
+	for uuid, sub := range t.subscriptions {
+		handlers[uuid] = sub.handler
+		t.stopNotificationsLocked(uuid)
+	}
+	t.characteristics = characteristics
+
+	for uuid, handler := range handlers {
+		char, ok := t.characteristics[uuid]
+		if !ok {
+			log.Printf("Characteristic %s is gone, dropping its notifications", uuid)
+			continue
+		}
+		if err := t.subscribeLocked(uuid, char, handler); err != nil {
+			log.Printf("Failed to resubscribe to %s: %v", uuid, err)
+		}
+	}
 	return nil
 }
 
+// characteristicLocked looks up a characteristic, discovering them again if
+// it's missing in case the device's services changed without telling us.
+// Callers hold the mutex.
+func (t *TinyGoBluetoothClient) characteristicLocked(uuid string) (*bluetooth.DeviceCharacteristic, error) {
+	if char, ok := t.characteristics[uuid]; ok {
+		return char, nil
+	}
+	log.Printf("Characteristic %s not cached, refreshing characteristics", uuid)
+	if err := t.refreshCharacteristicsLocked(); err != nil {
+		return nil, err
+	}
+	if char, ok := t.characteristics[uuid]; ok {
+		return char, nil
+	}
+	return nil, fmt.Errorf("characteristic not found: %s", uuid)
+}
+
+// clearCharacteristicsLocked forgets the discovered characteristics and
+// cancels every subscription. Callers hold the mutex.
+func (t *TinyGoBluetoothClient) clearCharacteristicsLocked() {
+	for _, sub := range t.subscriptions {
+		sub.cancel()
+	}
+	t.characteristics = make(map[string]*bluetooth.DeviceCharacteristic)
+	t.subscriptions = make(map[string]*notificationSubscription)
+	t.mtu = 0
+}
+
 // Disconnect disconnects from the BLE device
 func (t *TinyGoBluetoothClient) Disconnect() error {
 	t.mutex.Lock()
@@ -344,8 +582,8 @@ func (t *TinyGoBluetoothClient) Disconnect() error {
 	}
 
 	// Stop all notifications first
-	for uuid := range t.notificationHandlers {
-		t.StopNotifications(uuid)
+	for uuid := range t.subscriptions {
+		t.stopNotificationsLocked(uuid)
 	}
 
 	// Stop any ongoing scan
@@ -364,8 +602,7 @@ func (t *TinyGoBluetoothClient) Disconnect() error {
 
 	t.connected = false
 	t.device = nil
-	t.characteristics = make(map[string]*bluetooth.DeviceCharacteristic)
-	t.notificationHandlers = make(map[string]func([]byte))
+	t.clearCharacteristicsLocked()
 
 	// Release the adapter after disconnection
 	go func() {
@@ -387,12 +624,12 @@ func (t *TinyGoBluetoothClient) WriteCharacteristic(uuid string, data []byte) er
 		return errors.New("not connected")
 	}
 
-	char, ok := t.characteristics[uuid]
-	if !ok {
-		return fmt.Errorf("characteristic not found: %s", uuid)
+	char, err := t.characteristicLocked(uuid)
+	if err != nil {
+		return err
 	}
 
-	_, err := char.Write(data)
+	_, err = char.Write(data)
 	if err != nil {
 		return fmt.Errorf("failed to write to characteristic: %w", err)
 	}
@@ -409,17 +646,24 @@ func (t *TinyGoBluetoothClient) ReadCharacteristic(uuid string) ([]byte, error)
 		return nil, errors.New("not connected")
 	}
 
-	char, ok := t.characteristics[uuid]
-	if !ok {
-		return nil, fmt.Errorf("characteristic not found: %s", uuid)
+	char, err := t.characteristicLocked(uuid)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a buffer to read the data into
-	buf := make([]byte, 100) // Assuming maximum size of 100 bytes
+	buf := make([]byte, maxAttributeValueLength)
 	n, err := char.Read(buf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read characteristic: %w", err)
 	}
+	// Some platforms report the full length of a value that didn't fit
+	if n > len(buf) {
+		buf = make([]byte, n)
+		if n, err = char.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to read characteristic: %w", err)
+		}
+		n = min(n, len(buf))
+	}
 
 	return buf[:n], nil
 }
@@ -433,38 +677,35 @@ func (t *TinyGoBluetoothClient) StartNotifications(uuid string, handler func([]b
 		return errors.New("not connected")
 	}
 
-	char, ok := t.characteristics[uuid]
-	if !ok {
-		return fmt.Errorf("characteristic not found: %s", uuid)
+	char, err := t.characteristicLocked(uuid)
+	if err != nil {
+		return err
 	}
 
-	// Store the handler
-	t.notificationHandlers[uuid] = handler
-
-	// Create a safe notification handler that doesn't hold the mutex
-	safeHandler := func(buf []byte) {
-		if handler != nil {
-			// Copying the data to avoid potential race conditions
-			dataCopy := make([]byte, len(buf))
-			copy(dataCopy, buf)
-
-			// Use the handler directly instead of spawning a new goroutine
-			// This avoids creating too many goroutines that might cause problems
-			handler(dataCopy)
-		}
+	// Replace any earlier handler rather than delivering to both
+	if _, ok := t.subscriptions[uuid]; ok {
+		t.stopNotificationsLocked(uuid)
 	}
 
-	// Enable notifications
-	err := char.EnableNotifications(safeHandler)
-
-	if err != nil {
-		delete(t.notificationHandlers, uuid)
+	if err := t.subscribeLocked(uuid, char, handler); err != nil {
 		return fmt.Errorf("failed to enable notifications: %w", err)
 	}
 
 	return nil
 }
 
+// subscribeLocked enables notifications on char and records the
+// subscription. Callers hold the mutex.
+func (t *TinyGoBluetoothClient) subscribeLocked(uuid string, char *bluetooth.DeviceCharacteristic, handler func([]byte)) error {
+	sub := newNotificationSubscription(uuid, handler, t.mtu)
+	if err := char.EnableNotifications(sub.deliver); err != nil {
+		sub.cancel()
+		return err
+	}
+	t.subscriptions[uuid] = sub
+	return nil
+}
+
 // StopNotifications stops notifications for a characteristic
 func (t *TinyGoBluetoothClient) StopNotifications(uuid string) error {
 	t.mutex.Lock()
@@ -474,13 +715,25 @@ func (t *TinyGoBluetoothClient) StopNotifications(uuid string) error {
 		return errors.New("not connected")
 	}
 
-	//Just remove the handler since tinygo-org/bluetooth doesn't have a DisableNotifications method
-	delete(t.notificationHandlers, uuid)
-	log.Printf("Stopped notifications for %s", uuid)
-
+	t.stopNotificationsLocked(uuid)
 	return nil
 }
 
+// stopNotificationsLocked cancels the subscription for uuid. Callers hold the
+// mutex.
+func (t *TinyGoBluetoothClient) stopNotificationsLocked(uuid string) {
+	sub, ok := t.subscriptions[uuid]
+	if !ok {
+		return
+	}
+	sub.cancel()
+	delete(t.subscriptions, uuid)
+	if char, ok := t.characteristics[uuid]; ok {
+		disableNotifications(char)
+	}
+	log.Printf("Stopped notifications for %s", uuid)
+}
+
 // IsConnected returns the connection status
 func (t *TinyGoBluetoothClient) IsConnected() bool {
 	t.mutex.Lock()
@@ -488,6 +741,23 @@ func (t *TinyGoBluetoothClient) IsConnected() bool {
 	return t.connected
 }
 
+// GetScanResultDevices returns the name and address of each device found by
+// the scan
+func (t *TinyGoBluetoothClient) GetScanResultDevices() []DiscoveredDevice {
+	t.scanMutex.Lock()
+	defer t.scanMutex.Unlock()
+
+	devices := make([]DiscoveredDevice, 0, len(t.scanResults))
+	for address, result := range t.scanResults {
+		device := DiscoveredDevice{Name: result.LocalName(), Address: address}
+		if mfgData := result.ManufacturerData(); len(mfgData) > 0 {
+			device.Type = DetectDeviceType(hex.EncodeToString(mfgData[0].Data))
+		}
+		devices = append(devices, device)
+	}
+	return devices
+}
+
 // GetDiscoveredDevices returns the list of discovered devices
 func (t *TinyGoBluetoothClient) GetDiscoveredDevices() []string {
 	t.scanMutex.Lock()
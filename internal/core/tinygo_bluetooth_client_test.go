@@ -0,0 +1,59 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNotificationSubscription_DeliversCopyUntilCancelled(t *testing.T) {
+	var received [][]byte
+	sub := newNotificationSubscription(NotificationCharUUID, func(data []byte) {
+		received = append(received, data)
+	}, 0)
+
+	buf := []byte{0x11, 0x02, 0x03}
+	sub.deliver(buf)
+	buf[0] = 0xFF
+	if len(received) != 1 || !bytes.Equal(received[0], []byte{0x11, 0x02, 0x03}) {
+		t.Fatalf("received = %x, want a copy of the notification", received)
+	}
+
+	sub.cancel()
+	sub.deliver([]byte{0x11})
+	if len(received) != 1 {
+		t.Errorf("Expected no delivery after cancel, got %d notifications", len(received))
+	}
+}
+
+func TestNotificationSubscription_Limit(t *testing.T) {
+	tests := []struct {
+		mtu  int
+		want int
+	}{
+		{0, 0},
+		{23, 20},
+		{247, 244},
+	}
+	for _, tt := range tests {
+		sub := newNotificationSubscription(NotificationCharUUID, nil, tt.mtu)
+		if sub.limit != tt.want {
+			t.Errorf("limit for MTU %d = %d, want %d", tt.mtu, sub.limit, tt.want)
+		}
+	}
+}
+
+func TestNotificationSubscription_DeliversFullNotifications(t *testing.T) {
+	var received []byte
+	sub := newNotificationSubscription(NotificationCharUUID, func(data []byte) {
+		received = data
+	}, 23)
+
+	full := bytes.Repeat([]byte{0x11}, 20)
+	sub.deliver(full)
+	if !bytes.Equal(received, full) {
+		t.Errorf("received = %x, want %x", received, full)
+	}
+	if !sub.warned.Load() {
+		t.Error("Expected a notification filling the MTU to be flagged")
+	}
+}
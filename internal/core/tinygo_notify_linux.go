@@ -0,0 +1,16 @@
+package core
+
+import (
+	"log"
+
+	"tinygo.org/x/bluetooth"
+)
+
+// disableNotifications unsubscribes from char. BlueZ refuses a second
+// subscription to a characteristic, so this has to happen before it is
+// subscribed to again.
+func disableNotifications(char *bluetooth.DeviceCharacteristic) {
+	if err := char.EnableNotifications(nil); err != nil {
+		log.Printf("Failed to disable notifications: %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package core
+
+import "tinygo.org/x/bluetooth"
+
+// disableNotifications does nothing where tinygo can't unsubscribe. The
+// cancelled subscription drops any notifications that still arrive.
+func disableNotifications(char *bluetooth.DeviceCharacteristic) {}
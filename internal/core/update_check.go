@@ -0,0 +1,326 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ReleasesRepo is the GitHub repository checked for new versions
+	ReleasesRepo = "brentyates/squaregolf-connector"
+	// DefaultUpdateCheckInterval is how often a running connector checks for
+	// a new release
+	DefaultUpdateCheckInterval = 24 * time.Hour
+
+	githubAPIURL       = "https://api.github.com"
+	updateCheckTimeout = 30 * time.Second
+)
+
+// ReleaseAsset is a file attached to a release
+type ReleaseAsset struct {
+	Name   string `json:"name"`
+	URL    string `json:"browser_download_url"`
+	Size   int64  `json:"size"`
+	Digest string `json:"digest"` // "sha256:" and the hex digest, when GitHub lists one
+}
+
+// Release is a published connector release
+type Release struct {
+	Tag        string         `json:"tag_name"`
+	URL        string         `json:"html_url"`
+	Draft      bool           `json:"draft"`
+	Prerelease bool           `json:"prerelease"`
+	Assets     []ReleaseAsset `json:"assets"`
+}
+
+// UpdateStatus reports whether a newer connector is available
+type UpdateStatus struct {
+	Current     string     `json:"current"`
+	Latest      string     `json:"latest,omitempty"`
+	Available   bool       `json:"available"`
+	ReleaseURL  string     `json:"releaseUrl,omitempty"`
+	CheckedAt   *time.Time `json:"checkedAt,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	Installable bool       `json:"installable"`         // installing is enabled and the release has a verifiable build for this platform
+	Installed   string     `json:"installed,omitempty"` // version swapped in, running after a restart
+	CanRollback bool       `json:"canRollback"`
+}
+
+// UpdateChecker polls GitHub for releases newer than the running version.
+// Installing them is opt in; see EnableInstall.
+type UpdateChecker struct {
+	current string
+	apiURL  string
+	client  *http.Client
+	clock   Clock
+
+	mu        sync.Mutex
+	status    UpdateStatus
+	release   *Release
+	installer *updateInstaller // nil unless installing is enabled
+	onChange  func(UpdateStatus)
+}
+
+// NewUpdateChecker creates a checker for the running version
+func NewUpdateChecker(current string) *UpdateChecker {
+	return &UpdateChecker{
+		current: current,
+		apiURL:  githubAPIURL,
+		client:  &http.Client{Timeout: updateCheckTimeout},
+		clock:   RealClock(),
+		status:  UpdateStatus{Current: current},
+	}
+}
+
+// SetAPIURL points the checker at another GitHub API host
+func (u *UpdateChecker) SetAPIURL(url string) {
+	u.apiURL = strings.TrimSuffix(url, "/")
+}
+
+// SetClock replaces the clock used to schedule checks
+func (u *UpdateChecker) SetClock(clock Clock) {
+	u.clock = clock
+}
+
+// OnChange sets a callback run whenever the status changes
+func (u *UpdateChecker) OnChange(callback func(UpdateStatus)) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.onChange = callback
+}
+
+// Status returns the result of the last check
+func (u *UpdateChecker) Status() UpdateStatus {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.statusLocked()
+}
+
+func (u *UpdateChecker) statusLocked() UpdateStatus {
+	status := u.status
+	if u.installer != nil {
+		asset := u.installer.assetFor(u.release)
+		status.Installable = status.Available && asset != nil && hasChecksum(u.release, asset)
+		status.CanRollback = u.installer.canRollback()
+	}
+	return status
+}
+
+// Run checks for updates now and then every interval until ctx is done. If
+// installing is enabled, a new release is installed as soon as it is found.
+func (u *UpdateChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := u.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := u.Check(ctx)
+		if err != nil {
+			log.Printf("Update check failed: %v", err)
+		} else if status.Installable {
+			if _, err := u.Install(ctx); err != nil {
+				log.Printf("Automatic update failed: %v", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Check fetches the releases and compares the newest with the running version
+func (u *UpdateChecker) Check(ctx context.Context) (UpdateStatus, error) {
+	release, err := u.latestRelease(ctx)
+	now := u.clock.Now()
+
+	u.mu.Lock()
+	previous := u.statusLocked()
+	u.status.CheckedAt = &now
+	if err != nil {
+		u.status.Error = err.Error()
+	} else {
+		u.status.Error = ""
+		u.release = release
+		u.status.Latest = ""
+		u.status.ReleaseURL = ""
+		u.status.Available = false
+		if release != nil {
+			u.status.Latest = strings.TrimPrefix(release.Tag, "v")
+			u.status.ReleaseURL = release.URL
+			u.status.Available = CompareVersions(u.status.Latest, u.current) > 0 && u.status.Latest != u.status.Installed
+		}
+	}
+	status := u.statusLocked()
+	callback := u.onChange
+	u.mu.Unlock()
+
+	if status.Available && !previous.Available {
+		log.Printf("Update available: %s (running %s) %s", status.Latest, status.Current, status.ReleaseURL)
+	}
+	if callback != nil && updateStatusChanged(previous, status) {
+		callback(status)
+	}
+	return status, err
+}
+
+// updateStatusChanged ignores the check time so a callback only runs when
+// something a user would see changes
+func updateStatusChanged(a, b UpdateStatus) bool {
+	a.CheckedAt, b.CheckedAt = nil, nil
+	return a != b
+}
+
+// latestRelease returns the newest published release. Prereleases are only
+// offered to users already running one.
+func (u *UpdateChecker) latestRelease(ctx context.Context) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=20", u.apiURL, ReleasesRepo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "squaregolf-connector/"+u.current)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch releases: %s", resp.Status)
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	allowPrerelease := isPrerelease(u.current)
+	var latest *Release
+	for i := range releases {
+		release := &releases[i]
+		if release.Draft || (release.Prerelease && !allowPrerelease) {
+			continue
+		}
+		if _, err := parseVersion(release.Tag); err != nil {
+			continue
+		}
+		if latest == nil || CompareVersions(release.Tag, latest.Tag) > 0 {
+			latest = release
+		}
+	}
+	return latest, nil
+}
+
+// version is a parsed semantic version
+type version struct {
+	core       [3]int
+	prerelease []string
+}
+
+// parseVersion parses versions like "0.2.1" and "v0.3.0-alpha.2". Build
+// metadata after "+" is ignored.
+func parseVersion(s string) (version, error) {
+	var v version
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, pre, hasPre := strings.Cut(s, "-")
+
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, errors.New("invalid version")
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, errors.New("invalid version")
+		}
+		v.core[i] = n
+	}
+	if hasPre {
+		if pre == "" {
+			return v, errors.New("invalid version")
+		}
+		v.prerelease = strings.Split(pre, ".")
+	}
+	return v, nil
+}
+
+func isPrerelease(s string) bool {
+	v, err := parseVersion(s)
+	return err == nil && len(v.prerelease) > 0
+}
+
+// CompareVersions compares two semantic versions, returning -1, 0 or 1. A
+// version that doesn't parse sorts before one that does.
+func CompareVersions(a, b string) int {
+	va, errA := parseVersion(a)
+	vb, errB := parseVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+
+	for i := range va.core {
+		if c := compareInts(va.core[i], vb.core[i]); c != 0 {
+			return c
+		}
+	}
+
+	// A release sorts after its prereleases
+	switch {
+	case len(va.prerelease) == 0 && len(vb.prerelease) == 0:
+		return 0
+	case len(va.prerelease) == 0:
+		return 1
+	case len(vb.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(va.prerelease) && i < len(vb.prerelease); i++ {
+		if c := comparePrereleaseIdentifiers(va.prerelease[i], vb.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(va.prerelease), len(vb.prerelease))
+}
+
+// comparePrereleaseIdentifiers orders numeric identifiers numerically and
+// before alphanumeric ones, which are ordered as strings
+func comparePrereleaseIdentifiers(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,308 @@
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.2.1", "0.2.1", 0},
+		{"v0.2.1", "0.2.1", 0},
+		{"0.2.2", "0.2.1", 1},
+		{"0.10.0", "0.9.9", 1},
+		{"1.0", "0.99.99", 1},
+		{"0.2.1", "0.2.1-alpha.1", 1},
+		{"0.2.1-alpha.2", "0.2.1-alpha.1", 1},
+		{"0.2.1-alpha.10", "0.2.1-alpha.9", 1},
+		{"0.2.1-beta", "0.2.1-alpha.9", 1},
+		{"0.2.1-alpha.1", "0.2.1-alpha", 1},
+		{"0.2.1-1", "0.2.1-alpha", -1},
+		{"0.2.1+build.5", "0.2.1", 0},
+		{"latest", "0.0.1", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := CompareVersions(tt.b, tt.a); got != -tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+// newReleasesServer serves releases from the GitHub releases endpoint and
+// files from /download
+func newReleasesServer(t *testing.T, releases []Release, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/"+ReleasesRepo+"/releases", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(releases)
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[filepath.Base(r.URL.Path)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUpdateChecker_FindsNewerRelease(t *testing.T) {
+	server := newReleasesServer(t, []Release{
+		{Tag: "v0.4.0", Draft: true},
+		{Tag: "v0.3.1-beta.1", Prerelease: true},
+		{Tag: "v0.3.0", URL: "https://example.com/v0.3.0"},
+		{Tag: "v0.2.0"},
+	}, nil)
+
+	checker := NewUpdateChecker("0.2.1")
+	checker.SetAPIURL(server.URL)
+	var notified []UpdateStatus
+	checker.OnChange(func(status UpdateStatus) {
+		notified = append(notified, status)
+	})
+
+	status, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !status.Available || status.Latest != "0.3.0" || status.ReleaseURL != "https://example.com/v0.3.0" {
+		t.Errorf("Check() = %+v, want 0.3.0 available", status)
+	}
+	if status.CheckedAt == nil || status.Installable {
+		t.Errorf("Check() = %+v, want a check time and no install", status)
+	}
+
+	// A second check with nothing new doesn't notify again
+	checker.Check(context.Background())
+	if len(notified) != 1 {
+		t.Errorf("notified %d times, want 1", len(notified))
+	}
+}
+
+func TestUpdateChecker_OffersPrereleasesToPrereleaseUsers(t *testing.T) {
+	server := newReleasesServer(t, []Release{
+		{Tag: "v0.3.0-alpha.1", Prerelease: true},
+		{Tag: "v0.2.1"},
+	}, nil)
+
+	checker := NewUpdateChecker("0.2.1-alpha.1")
+	checker.SetAPIURL(server.URL)
+	status, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if status.Latest != "0.3.0-alpha.1" || !status.Available {
+		t.Errorf("Check() = %+v, want the prerelease", status)
+	}
+}
+
+func TestUpdateChecker_UpToDate(t *testing.T) {
+	server := newReleasesServer(t, []Release{{Tag: "v0.2.1"}}, nil)
+
+	checker := NewUpdateChecker("0.2.1")
+	checker.SetAPIURL(server.URL)
+	status, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if status.Available || status.Latest != "0.2.1" {
+		t.Errorf("Check() = %+v, want up to date", status)
+	}
+}
+
+func TestUpdateChecker_ReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	checker := NewUpdateChecker("0.2.1")
+	checker.SetAPIURL(server.URL)
+	status, err := checker.Check(context.Background())
+	if err == nil || status.Error == "" {
+		t.Errorf("Check() = %+v, %v; want an error", status, err)
+	}
+}
+
+func TestUpdateChecker_RunChecksOnInterval(t *testing.T) {
+	checks := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+		checks <- struct{}{}
+	}))
+	defer server.Close()
+
+	clock := NewFakeClock(time.Unix(0, 0))
+	checker := NewUpdateChecker("0.2.1")
+	checker.SetAPIURL(server.URL)
+	checker.SetClock(clock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go checker.Run(ctx, time.Hour)
+
+	<-checks
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	<-checks
+}
+
+// updateArchive builds a release archive laid out like the Windows build
+func updateArchive(t *testing.T, exe, index string) []byte {
+	t.Helper()
+	return zipArchive(t, map[string]string{
+		"SquareGolf Connector/SquareGolf Connector.exe": exe,
+		"SquareGolf Connector/web/index.html":           index,
+	})
+}
+
+// zipArchive builds an archive holding files
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// sha256Digest returns data's digest as GitHub lists it for release assets
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestUpdateChecker_InstallAndRollback(t *testing.T) {
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "SquareGolf Connector.exe")
+	os.WriteFile(exePath, []byte("old exe"), 0755)
+	os.MkdirAll(filepath.Join(dir, "web"), 0755)
+	os.WriteFile(filepath.Join(dir, "web", "index.html"), []byte("old page"), 0644)
+
+	archive := updateArchive(t, "new exe", "new page")
+	server := newReleasesServer(t, nil, map[string][]byte{"SquareGolf-Connector-v0.3.0-Windows.zip": archive})
+	releases := []Release{{
+		Tag: "v0.3.0",
+		Assets: []ReleaseAsset{
+			{Name: "SquareGolf-Connector-v0.3.0-macOS.zip", URL: server.URL + "/download/missing.zip"},
+			{Name: "SquareGolf-Connector-v0.3.0-Windows.zip", URL: server.URL + "/download/SquareGolf-Connector-v0.3.0-Windows.zip", Size: int64(len(archive)), Digest: sha256Digest(archive)},
+		},
+	}}
+	releasesServer := newReleasesServer(t, releases, nil)
+
+	checker := NewUpdateChecker("0.2.1")
+	checker.SetAPIURL(releasesServer.URL)
+	installer, err := newUpdateInstaller(exePath, "windows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker.installer = installer
+
+	if _, err := checker.Install(context.Background()); !errors.Is(err, ErrNoUpdate) {
+		t.Errorf("Install() before a check error = %v, want ErrNoUpdate", err)
+	}
+	if status, _ := checker.Check(context.Background()); !status.Installable {
+		t.Fatalf("Check() = %+v, want installable", status)
+	}
+
+	status, err := checker.Install(context.Background())
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if status.Installed != "0.3.0" || status.Available || !status.CanRollback {
+		t.Errorf("Install() = %+v", status)
+	}
+	if got := readFile(t, exePath); got != "new exe" {
+		t.Errorf("executable = %q, want the new one", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "web", "index.html")); got != "new page" {
+		t.Errorf("web page = %q, want the new one", got)
+	}
+
+	// The next check doesn't offer the installed version again
+	if status, _ := checker.Check(context.Background()); status.Available {
+		t.Errorf("Check() after install = %+v, want nothing available", status)
+	}
+
+	status, err = checker.Rollback()
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if status.Installed != "" || !status.Available || status.CanRollback {
+		t.Errorf("Rollback() = %+v", status)
+	}
+	if got := readFile(t, exePath); got != "old exe" {
+		t.Errorf("executable after rollback = %q, want the old one", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "web", "index.html")); got != "old page" {
+		t.Errorf("web page after rollback = %q, want the old one", got)
+	}
+}
+
+func TestUpdateChecker_InstallRejectsIncompleteDownload(t *testing.T) {
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "SquareGolf Connector.exe")
+	os.WriteFile(exePath, []byte("old exe"), 0755)
+
+	archive := updateArchive(t, "new exe", "new page")
+	files := newReleasesServer(t, nil, map[string][]byte{"update.zip": archive[:len(archive)/2]})
+	releases := newReleasesServer(t, []Release{{
+		Tag:    "v0.3.0",
+		Assets: []ReleaseAsset{{Name: "SquareGolf-Connector-v0.3.0-Windows.zip", URL: files.URL + "/download/update.zip", Size: int64(len(archive)), Digest: sha256Digest(archive)}},
+	}}, nil)
+
+	checker := NewUpdateChecker("0.2.1")
+	checker.SetAPIURL(releases.URL)
+	checker.installer, _ = newUpdateInstaller(exePath, "windows")
+	checker.Check(context.Background())
+
+	if _, err := checker.Install(context.Background()); err == nil {
+		t.Fatal("Expected an incomplete download to fail")
+	}
+	if got := readFile(t, exePath); got != "old exe" {
+		t.Errorf("executable = %q, want it untouched", got)
+	}
+}
+
+func TestNewUpdateInstaller_Unsupported(t *testing.T) {
+	if _, err := newUpdateInstaller("/Applications/SquareGolf Connector.app/Contents/MacOS/squaregolf-connector", "darwin"); !errors.Is(err, ErrUpdateInstallUnsupported) {
+		t.Errorf("newUpdateInstaller(darwin) error = %v, want ErrUpdateInstallUnsupported", err)
+	}
+}
@@ -0,0 +1,461 @@
+package core
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// maxUpdateArchiveSize caps a download whose size the release doesn't list
+const maxUpdateArchiveSize = 200 << 20
+
+const updateDownloadTimeout = 10 * time.Minute
+
+// updateChecksumsAsset lists the SHA-256 of each archive in releases made
+// before GitHub listed digests itself, in sha256sum's format
+const updateChecksumsAsset = "SHA256SUMS"
+
+const maxChecksumsSize = 1 << 20
+
+var (
+	// ErrUpdateInstallDisabled is returned when installing wasn't enabled
+	ErrUpdateInstallDisabled = errors.New("installing updates is disabled")
+	// ErrUpdateInstallUnsupported is returned on platforms whose release
+	// can't be swapped in place. The macOS app is a signed bundle, so it is
+	// updated by downloading it again.
+	ErrUpdateInstallUnsupported = errors.New("installing updates is not supported on this platform")
+	// ErrNoUpdate is returned when installing with no newer release
+	ErrNoUpdate = errors.New("no update available")
+)
+
+// updateInstaller swaps the executable and the web folder next to it for the
+// ones in a release archive. The replaced copies are kept for rollback as
+// .old until the installed version has started, so a second install before
+// a restart doesn't replace the last version known to work.
+type updateInstaller struct {
+	exePath string
+	webDir  string
+	suffix  string // end of the name of this platform's release asset
+	client  *http.Client
+}
+
+func newUpdateInstaller(exePath, goos string) (*updateInstaller, error) {
+	var suffix string
+	switch goos {
+	case "windows":
+		suffix = "-Windows.zip"
+	default:
+		return nil, ErrUpdateInstallUnsupported
+	}
+	return &updateInstaller{
+		exePath: exePath,
+		webDir:  filepath.Join(filepath.Dir(exePath), "web"),
+		suffix:  suffix,
+		client:  &http.Client{Timeout: updateDownloadTimeout},
+	}, nil
+}
+
+// EnableInstall lets Install replace the executable at exePath, and Run
+// install new releases as it finds them
+func (u *UpdateChecker) EnableInstall(exePath string) error {
+	installer, err := newUpdateInstaller(exePath, runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.installer = installer
+	return nil
+}
+
+// Install downloads the latest release and swaps it in. The connector runs
+// the new version after it restarts.
+func (u *UpdateChecker) Install(ctx context.Context) (UpdateStatus, error) {
+	u.mu.Lock()
+	installer := u.installer
+	status := u.statusLocked()
+	release := u.release
+	var asset *ReleaseAsset
+	if installer != nil {
+		asset = installer.assetFor(release)
+	}
+	u.mu.Unlock()
+
+	switch {
+	case installer == nil:
+		return status, ErrUpdateInstallDisabled
+	case !status.Available:
+		return status, ErrNoUpdate
+	case asset == nil:
+		return status, fmt.Errorf("release %s has no %s build", status.Latest, runtime.GOOS)
+	}
+
+	log.Printf("Installing update %s from %s", status.Latest, asset.URL)
+	if err := installer.install(ctx, release, asset); err != nil {
+		return status, fmt.Errorf("failed to install update: %w", err)
+	}
+	log.Printf("Installed update %s; restart the connector to use it", status.Latest)
+
+	return u.setInstalled(status.Latest), nil
+}
+
+// Rollback restores the executable and web folder replaced by the last
+// install
+func (u *UpdateChecker) Rollback() (UpdateStatus, error) {
+	u.mu.Lock()
+	installer := u.installer
+	status := u.statusLocked()
+	u.mu.Unlock()
+
+	if installer == nil {
+		return status, ErrUpdateInstallDisabled
+	}
+	if err := installer.rollback(); err != nil {
+		return status, fmt.Errorf("failed to roll back update: %w", err)
+	}
+	log.Println("Rolled back to the previous version; restart the connector to use it")
+
+	return u.setInstalled(""), nil
+}
+
+// ConfirmStarted records that the running version started successfully, so
+// the next install may replace the copy kept for rollback
+func (u *UpdateChecker) ConfirmStarted() {
+	u.mu.Lock()
+	installer := u.installer
+	u.mu.Unlock()
+	if installer != nil {
+		installer.confirmStarted()
+	}
+}
+
+// setInstalled records the version waiting for a restart and notifies the
+// change callback
+func (u *UpdateChecker) setInstalled(installed string) UpdateStatus {
+	u.mu.Lock()
+	previous := u.statusLocked()
+	u.status.Installed = installed
+	u.status.Available = u.status.Latest != "" && CompareVersions(u.status.Latest, u.current) > 0 && u.status.Latest != installed
+	status := u.statusLocked()
+	callback := u.onChange
+	u.mu.Unlock()
+
+	if callback != nil && updateStatusChanged(previous, status) {
+		callback(status)
+	}
+	return status
+}
+
+// assetFor returns release's archive for this platform
+func (i *updateInstaller) assetFor(release *Release) *ReleaseAsset {
+	if release == nil {
+		return nil
+	}
+	for idx := range release.Assets {
+		if strings.HasSuffix(release.Assets[idx].Name, i.suffix) {
+			return &release.Assets[idx]
+		}
+	}
+	return nil
+}
+
+// hasChecksum reports whether release publishes a SHA-256 for asset
+func hasChecksum(release *Release, asset *ReleaseAsset) bool {
+	if _, ok := assetDigest(asset); ok {
+		return true
+	}
+	return checksumsAsset(release) != nil
+}
+
+func assetDigest(asset *ReleaseAsset) (string, bool) {
+	digest, ok := strings.CutPrefix(asset.Digest, "sha256:")
+	return strings.ToLower(digest), ok && validSHA256(digest)
+}
+
+func checksumsAsset(release *Release) *ReleaseAsset {
+	for idx := range release.Assets {
+		if release.Assets[idx].Name == updateChecksumsAsset {
+			return &release.Assets[idx]
+		}
+	}
+	return nil
+}
+
+func validSHA256(digest string) bool {
+	b, err := hex.DecodeString(digest)
+	return err == nil && len(b) == sha256.Size
+}
+
+func (i *updateInstaller) canRollback() bool {
+	_, err := os.Stat(i.exePath + ".old")
+	return err == nil
+}
+
+// pendingPath marks an installed version that hasn't started yet
+func (i *updateInstaller) pendingPath() string {
+	return i.exePath + ".pending"
+}
+
+func (i *updateInstaller) pending() bool {
+	_, err := os.Stat(i.pendingPath())
+	return err == nil
+}
+
+// confirmStarted clears the pending mark and the copies an install before a
+// restart set aside
+func (i *updateInstaller) confirmStarted() {
+	if !i.pending() {
+		return
+	}
+	if err := os.Remove(i.pendingPath()); err != nil {
+		log.Printf("Failed to clear the pending update: %v", err)
+		return
+	}
+	os.RemoveAll(i.exePath + ".failed")
+	os.RemoveAll(i.webDir + ".failed")
+	log.Println("Installed update started; it will be kept as the rollback copy for the next one")
+}
+
+// install downloads asset, checks it against its published SHA-256,
+// unpacks it beside the current files and swaps them in
+func (i *updateInstaller) install(ctx context.Context, release *Release, asset *ReleaseAsset) error {
+	checksum, err := i.checksumFor(ctx, release, asset)
+	if err != nil {
+		return err
+	}
+	archivePath, err := i.download(ctx, asset, checksum)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archivePath)
+
+	if err := i.extract(archivePath); err != nil {
+		os.Remove(i.exePath + ".new")
+		os.RemoveAll(i.webDir + ".new")
+		return err
+	}
+
+	// An install that hasn't started yet isn't known to work, so the copy
+	// kept from before it stays the one to roll back to
+	backup := ".old"
+	if i.pending() {
+		backup = ".failed"
+	}
+	if err := swapPath(i.exePath, i.exePath+".new", i.exePath+backup); err != nil {
+		return err
+	}
+	if err := swapPath(i.webDir, i.webDir+".new", i.webDir+backup); err != nil {
+		// Put the executable back so it matches the web folder
+		if restoreErr := swapPath(i.exePath, i.exePath+backup, i.exePath+".new"); restoreErr != nil {
+			log.Printf("Failed to restore executable after a failed update: %v", restoreErr)
+		}
+		return err
+	}
+	return os.WriteFile(i.pendingPath(), nil, 0644)
+}
+
+// rollback swaps the copies kept by the last install back in
+func (i *updateInstaller) rollback() error {
+	if !i.canRollback() {
+		return errors.New("no previous version to roll back to")
+	}
+	if err := swapPath(i.exePath, i.exePath+".old", i.exePath+".failed"); err != nil {
+		return err
+	}
+	if _, err := os.Stat(i.webDir + ".old"); err == nil {
+		if err := swapPath(i.webDir, i.webDir+".old", i.webDir+".failed"); err != nil {
+			return err
+		}
+	}
+	// The version rolled back to has run before
+	if err := os.Remove(i.pendingPath()); err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to clear the pending update: %v", err)
+	}
+	return nil
+}
+
+// checksumFor returns the SHA-256 published for asset, in hex. GitHub lists
+// one for each asset; older releases publish a SHA256SUMS file instead.
+func (i *updateInstaller) checksumFor(ctx context.Context, release *Release, asset *ReleaseAsset) (string, error) {
+	if digest, ok := assetDigest(asset); ok {
+		return digest, nil
+	}
+	sums := checksumsAsset(release)
+	if sums == nil {
+		return "", fmt.Errorf("release publishes no SHA-256 for %s", asset.Name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sums.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", sums.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: %s", sums.Name, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumsSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", sums.Name, err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		// sha256sum marks files hashed in binary mode with a *
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset.Name && validSHA256(fields[0]) {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s lists no SHA-256 for %s", sums.Name, asset.Name)
+}
+
+// download saves asset to a temporary file next to the executable, failing
+// unless its SHA-256 is checksum
+func (i *updateInstaller) download(ctx context.Context, asset *ReleaseAsset, checksum string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: %s", asset.Name, resp.Status)
+	}
+
+	limit := int64(maxUpdateArchiveSize)
+	if asset.Size > 0 {
+		limit = asset.Size
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(i.exePath), ".update-*.zip")
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(file, hash), io.LimitReader(resp.Body, limit+1))
+	closeErr := file.Close()
+	switch {
+	case err != nil:
+		err = fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	case closeErr != nil:
+		err = closeErr
+	case n > limit:
+		err = fmt.Errorf("%s is larger than expected", asset.Name)
+	case asset.Size > 0 && n != asset.Size:
+		err = fmt.Errorf("%s is incomplete: got %d of %d bytes", asset.Name, n, asset.Size)
+	case hex.EncodeToString(hash.Sum(nil)) != checksum:
+		err = fmt.Errorf("%s doesn't match its published SHA-256", asset.Name)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// extract unpacks the executable and web folder to their .new paths. The
+// archive holds one folder containing both.
+func (i *updateInstaller) extract(archivePath string) error {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open update archive: %w", err)
+	}
+	defer archive.Close()
+
+	exeName := filepath.Base(i.exePath)
+	var exe *zip.File
+	for _, f := range archive.File {
+		if !f.FileInfo().IsDir() && path.Base(f.Name) == exeName {
+			exe = f
+			break
+		}
+	}
+	if exe == nil {
+		return fmt.Errorf("update archive has no %s", exeName)
+	}
+	root := path.Dir(exe.Name)
+
+	if err := extractZipFile(exe, i.exePath+".new"); err != nil {
+		return err
+	}
+
+	webPrefix := path.Join(root, "web") + "/"
+	os.RemoveAll(i.webDir + ".new")
+	foundWeb := false
+	for _, f := range archive.File {
+		if !strings.HasPrefix(f.Name, webPrefix) || f.FileInfo().IsDir() {
+			continue
+		}
+		rel := path.Clean(strings.TrimPrefix(f.Name, webPrefix))
+		if rel == "." || !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return fmt.Errorf("update archive has an invalid path: %s", f.Name)
+		}
+		if err := extractZipFile(f, filepath.Join(i.webDir+".new", filepath.FromSlash(rel))); err != nil {
+			return err
+		}
+		foundWeb = true
+	}
+	if !foundWeb {
+		return errors.New("update archive has no web folder")
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	src, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read %s from update archive: %w", f.Name, err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return fmt.Errorf("failed to extract %s: %w", f.Name, err)
+	}
+	return dst.Close()
+}
+
+// swapPath moves current to backup and replacement to current, restoring
+// current if the second move fails. Windows allows renaming, but not
+// deleting, a running executable, so any earlier backup is removed first;
+// callers pick a backup path that doesn't hold one still needed.
+func swapPath(current, replacement, backup string) error {
+	if err := os.RemoveAll(backup); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", backup, err)
+	}
+	if err := os.Rename(current, backup); err != nil {
+		return fmt.Errorf("failed to move %s aside: %w", current, err)
+	}
+	if err := os.Rename(replacement, current); err != nil {
+		if restoreErr := os.Rename(backup, current); restoreErr != nil {
+			log.Printf("Failed to restore %s: %v", current, restoreErr)
+		}
+		return fmt.Errorf("failed to move %s into place: %w", replacement, err)
+	}
+	return nil
+}
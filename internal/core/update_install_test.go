@@ -0,0 +1,250 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testAssetName = "SquareGolf-Connector-v0.3.0-Windows.zip"
+
+// newTestInstaller returns an installer for an old executable and web folder
+// in a temporary directory
+func newTestInstaller(t *testing.T) (*updateInstaller, string) {
+	t.Helper()
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "SquareGolf Connector.exe")
+	if err := os.WriteFile(exePath, []byte("old exe"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "web"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "web", "index.html"), []byte("old page"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	installer, err := newUpdateInstaller(exePath, "windows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return installer, dir
+}
+
+// serveRelease serves archive and returns a release listing it with digest
+func serveRelease(t *testing.T, archive []byte, digest string) (*Release, *ReleaseAsset) {
+	t.Helper()
+	server := newReleasesServer(t, nil, map[string][]byte{testAssetName: archive})
+	release := &Release{Tag: "v0.3.0", Assets: []ReleaseAsset{{
+		Name:   testAssetName,
+		URL:    server.URL + "/download/" + testAssetName,
+		Size:   int64(len(archive)),
+		Digest: digest,
+	}}}
+	return release, &release.Assets[0]
+}
+
+func TestUpdateInstaller_RejectsAChecksumMismatch(t *testing.T) {
+	installer, _ := newTestInstaller(t)
+	archive := updateArchive(t, "new exe", "new page")
+	release, asset := serveRelease(t, archive, sha256Digest([]byte("something else")))
+
+	err := installer.install(context.Background(), release, asset)
+	if err == nil || !strings.Contains(err.Error(), "SHA-256") {
+		t.Fatalf("install() error = %v, want a checksum mismatch", err)
+	}
+	if got := readFile(t, installer.exePath); got != "old exe" {
+		t.Errorf("executable = %q, want it untouched", got)
+	}
+	if _, err := os.Stat(installer.exePath + ".new"); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing extracted, got %v", err)
+	}
+}
+
+func TestUpdateInstaller_RequiresAPublishedChecksum(t *testing.T) {
+	installer, _ := newTestInstaller(t)
+	archive := updateArchive(t, "new exe", "new page")
+	release, asset := serveRelease(t, archive, "")
+
+	if hasChecksum(release, asset) {
+		t.Error("hasChecksum() = true for a release without one")
+	}
+	if err := installer.install(context.Background(), release, asset); err == nil {
+		t.Fatal("Expected an unverifiable archive to be refused")
+	}
+	if got := readFile(t, installer.exePath); got != "old exe" {
+		t.Errorf("executable = %q, want it untouched", got)
+	}
+}
+
+func TestUpdateInstaller_UsesTheChecksumsFile(t *testing.T) {
+	installer, _ := newTestInstaller(t)
+	archive := updateArchive(t, "new exe", "new page")
+	digest := strings.TrimPrefix(sha256Digest(archive), "sha256:")
+	sums := fmt.Sprintf("%s  SquareGolf-Connector-v0.3.0-macOS.zip\n%s *%s\n", strings.Repeat("0", 64), digest, testAssetName)
+	server := newReleasesServer(t, nil, map[string][]byte{testAssetName: archive, updateChecksumsAsset: []byte(sums)})
+	release := &Release{Tag: "v0.3.0", Assets: []ReleaseAsset{
+		{Name: testAssetName, URL: server.URL + "/download/" + testAssetName},
+		{Name: updateChecksumsAsset, URL: server.URL + "/download/" + updateChecksumsAsset},
+	}}
+
+	if !hasChecksum(release, &release.Assets[0]) {
+		t.Error("hasChecksum() = false with a checksums file")
+	}
+	if err := installer.install(context.Background(), release, &release.Assets[0]); err != nil {
+		t.Fatalf("install() error = %v", err)
+	}
+	if got := readFile(t, installer.exePath); got != "new exe" {
+		t.Errorf("executable = %q, want the new one", got)
+	}
+}
+
+func TestUpdateInstaller_KeepsTheLastStartedVersionForRollback(t *testing.T) {
+	installer, dir := newTestInstaller(t)
+	install := func(exe string) {
+		t.Helper()
+		archive := updateArchive(t, exe, exe+" page")
+		release, asset := serveRelease(t, archive, sha256Digest(archive))
+		if err := installer.install(context.Background(), release, asset); err != nil {
+			t.Fatalf("install(%s) error = %v", exe, err)
+		}
+	}
+
+	install("v2")
+	// A second install before restarting must not replace the old version,
+	// the last one known to start
+	install("v3")
+	if got := readFile(t, installer.exePath); got != "v3" {
+		t.Errorf("executable = %q, want v3", got)
+	}
+	if got := readFile(t, installer.exePath+".old"); got != "old exe" {
+		t.Errorf("rollback copy = %q, want the old executable kept", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "web.old", "index.html")); got != "old page" {
+		t.Errorf("rollback web page = %q, want the old one kept", got)
+	}
+
+	// Once v3 has started it becomes the rollback copy for the next install
+	installer.confirmStarted()
+	if _, err := os.Stat(installer.exePath + ".failed"); !os.IsNotExist(err) {
+		t.Errorf("Expected the set aside v2 to be removed, got %v", err)
+	}
+	install("v4")
+	if got := readFile(t, installer.exePath+".old"); got != "v3" {
+		t.Errorf("rollback copy = %q, want v3", got)
+	}
+
+	if err := installer.rollback(); err != nil {
+		t.Fatalf("rollback() error = %v", err)
+	}
+	if got := readFile(t, installer.exePath); got != "v3" {
+		t.Errorf("executable after rollback = %q, want v3", got)
+	}
+	if installer.pending() {
+		t.Error("Expected the version rolled back to not be pending")
+	}
+}
+
+// writeArchive saves an archive holding files and returns its path
+func writeArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "update.zip")
+	if err := os.WriteFile(path, zipArchive(t, files), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestUpdateInstaller_Extract(t *testing.T) {
+	installer, _ := newTestInstaller(t)
+	archive := writeArchive(t, map[string]string{
+		"SquareGolf Connector/SquareGolf Connector.exe": "new exe",
+		"SquareGolf Connector/web/index.html":           "new page",
+		"SquareGolf Connector/web/static/app.js":        "app",
+		"SquareGolf Connector/README.txt":               "readme",
+	})
+
+	if err := installer.extract(archive); err != nil {
+		t.Fatalf("extract() error = %v", err)
+	}
+	if got := readFile(t, installer.exePath+".new"); got != "new exe" {
+		t.Errorf("executable = %q", got)
+	}
+	if got := readFile(t, filepath.Join(installer.webDir+".new", "static", "app.js")); got != "app" {
+		t.Errorf("web file = %q", got)
+	}
+	if got := readFile(t, installer.exePath); got != "old exe" {
+		t.Errorf("current executable = %q, want it untouched until the swap", got)
+	}
+}
+
+func TestUpdateInstaller_ExtractRejectsBadArchives(t *testing.T) {
+	tests := map[string]map[string]string{
+		"no executable": {"SquareGolf Connector/web/index.html": "page"},
+		"no web folder": {"SquareGolf Connector/SquareGolf Connector.exe": "exe"},
+		"escaping path": {
+			"SquareGolf Connector/SquareGolf Connector.exe": "exe",
+			"SquareGolf Connector/web/../../evil.exe":       "evil",
+		},
+		"parent folder": {
+			"SquareGolf Connector/SquareGolf Connector.exe": "exe",
+			"SquareGolf Connector/web/..":                   "evil",
+		},
+	}
+	for name, files := range tests {
+		installer, dir := newTestInstaller(t)
+		if err := installer.extract(writeArchive(t, files)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "evil.exe")); !os.IsNotExist(err) {
+			t.Errorf("%s: a file was written outside the web folder", name)
+		}
+	}
+
+	installer, _ := newTestInstaller(t)
+	notZip := filepath.Join(t.TempDir(), "update.zip")
+	os.WriteFile(notZip, []byte("not a zip"), 0644)
+	if err := installer.extract(notZip); err == nil {
+		t.Error("not a zip: expected an error")
+	}
+}
+
+func TestSwapPath(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "app.exe")
+	os.WriteFile(current, []byte("current"), 0755)
+	os.WriteFile(current+".new", []byte("replacement"), 0755)
+	os.WriteFile(current+".old", []byte("earlier backup"), 0755)
+
+	if err := swapPath(current, current+".new", current+".old"); err != nil {
+		t.Fatalf("swapPath() error = %v", err)
+	}
+	if got := readFile(t, current); got != "replacement" {
+		t.Errorf("current = %q, want the replacement", got)
+	}
+	if got := readFile(t, current+".old"); got != "current" {
+		t.Errorf("backup = %q, want the replaced file", got)
+	}
+	if _, err := os.Stat(current + ".new"); !os.IsNotExist(err) {
+		t.Errorf("Expected the replacement moved, got %v", err)
+	}
+}
+
+func TestSwapPath_RestoresCurrentWhenTheReplacementIsMissing(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "web")
+	os.MkdirAll(current, 0755)
+	os.WriteFile(filepath.Join(current, "index.html"), []byte("current"), 0644)
+
+	if err := swapPath(current, current+".new", current+".old"); err == nil {
+		t.Fatal("Expected an error with no replacement")
+	}
+	if got := readFile(t, filepath.Join(current, "index.html")); got != "current" {
+		t.Errorf("current = %q, want it restored", got)
+	}
+	if _, err := os.Stat(current + ".old"); !os.IsNotExist(err) {
+		t.Errorf("Expected no backup left behind, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package voice
+
+import (
+	"log"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// Metric names that can be announced
+const (
+	MetricBallSpeed   = "ballSpeed"
+	MetricCarry       = "carry"
+	MetricSpin        = "spin"
+	MetricLaunchAngle = "launchAngle"
+)
+
+// DefaultMetrics are announced when no metrics have been configured
+var DefaultMetrics = []string{MetricBallSpeed, MetricCarry, MetricSpin}
+
+// Announcer reads out shot results after each shot
+type Announcer struct {
+	stateManager *core.StateManager
+	speaker      Speaker
+	enabled      bool
+	metrics      []string
+	queue        chan string
+	mu           sync.Mutex
+}
+
+// New creates an Announcer for the shots published on stateManager
+func New(stateManager *core.StateManager) *Announcer {
+	a := &Announcer{
+		stateManager: stateManager,
+		speaker:      NewSystemSpeaker(),
+		metrics:      append([]string(nil), DefaultMetrics...),
+		queue:        make(chan string, 4),
+	}
+	a.registerStateListeners()
+	go a.run()
+	return a
+}
+
+// IsValidMetric reports whether name is a metric the announcer can read out
+func IsValidMetric(name string) bool {
+	switch name {
+	case MetricBallSpeed, MetricCarry, MetricSpin, MetricLaunchAngle:
+		return true
+	}
+	return false
+}
+
+// IsEnabled returns whether announcements are enabled
+func (a *Announcer) IsEnabled() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.enabled
+}
+
+// SetEnabled enables or disables announcements
+func (a *Announcer) SetEnabled(enabled bool) {
+	a.mu.Lock()
+	a.enabled = enabled
+	a.mu.Unlock()
+}
+
+// SetMetrics sets which metrics are announced, in order
+func (a *Announcer) SetMetrics(metrics []string) {
+	filtered := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		if IsValidMetric(metric) {
+			filtered = append(filtered, metric)
+		}
+	}
+
+	a.mu.Lock()
+	a.metrics = filtered
+	a.mu.Unlock()
+}
+
+// GetMetrics returns the announced metrics
+func (a *Announcer) GetMetrics() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.metrics...)
+}
+
+// SetSpeaker replaces the text-to-speech backend
+func (a *Announcer) SetSpeaker(speaker Speaker) {
+	a.mu.Lock()
+	a.speaker = speaker
+	a.mu.Unlock()
+}
+
+// Announce queues a shot announcement. Announcements are dropped rather than
+// queued up when shots arrive faster than they can be spoken.
+func (a *Announcer) Announce(ballMetrics *core.BallMetrics) {
+	a.mu.Lock()
+	enabled := a.enabled
+	metrics := append([]string(nil), a.metrics...)
+	a.mu.Unlock()
+
+	if !enabled || ballMetrics == nil {
+		return
+	}
+
+	text := FormatAnnouncement(ballMetrics, metrics)
+	if text == "" {
+		return
+	}
+
+	select {
+	case a.queue <- text:
+	default:
+		log.Println("Voice: announcement queue full, skipping shot")
+	}
+}
+
+func (a *Announcer) run() {
+	for text := range a.queue {
+		a.mu.Lock()
+		speaker := a.speaker
+		a.mu.Unlock()
+
+		if err := speaker.Speak(text); err != nil {
+			log.Printf("Voice: %v", err)
+		}
+	}
+}
+
+// FormatAnnouncement builds the spoken text for a shot in the selected locale
+func FormatAnnouncement(ballMetrics *core.BallMetrics, metrics []string) string {
+	var parts []string
+
+	for _, metric := range metrics {
+		switch metric {
+		case MetricBallSpeed:
+			if ballMetrics.IsBallSpeedValid {
+				parts = append(parts, i18n.SpokenSpeed(ballMetrics.BallSpeedMPS))
+			}
+		case MetricCarry:
+			if ballMetrics.IsBallSpeedValid {
+				parts = append(parts, i18n.SpokenCarry(core.ShotCarryYards(ballMetrics)))
+			}
+		case MetricSpin:
+			if ballMetrics.IsTotalSpinValid {
+				parts = append(parts, i18n.Tf("spin %d", int(math.Round(float64(ballMetrics.TotalspinRPM)/10)*10)))
+			}
+		case MetricLaunchAngle:
+			parts = append(parts, i18n.Tf("launch %.0f degrees", ballMetrics.VerticalAngle))
+		}
+	}
+
+	return strings.Join(parts, i18n.T(", "))
+}
@@ -0,0 +1,14 @@
+package voice
+
+import (
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+// registerStateListeners registers callbacks for state changes
+func (a *Announcer) registerStateListeners() {
+	a.stateManager.RegisterLastBallMetricsCallback(func(oldValue, newValue *core.BallMetrics) {
+		if newValue != nil && newValue != oldValue {
+			a.Announce(newValue)
+		}
+	})
+}
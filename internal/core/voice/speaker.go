@@ -0,0 +1,75 @@
+package voice
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// Speaker turns text into speech
+type Speaker interface {
+	Speak(text string) error
+}
+
+// systemSpeaker uses the platform's text-to-speech command
+type systemSpeaker struct{}
+
+// systemVoice is the voice each engine uses for a locale
+type systemVoice struct {
+	say     string // macOS voice name
+	culture string // Windows culture
+	espeak  string // espeak language
+}
+
+var systemVoices = map[string]systemVoice{
+	i18n.LocaleKorean:   {say: "Yuna", culture: "ko-KR", espeak: "ko"},
+	i18n.LocaleJapanese: {say: "Kyoko", culture: "ja-JP", espeak: "ja"},
+}
+
+// NewSystemSpeaker returns a Speaker backed by the OS text-to-speech engine:
+// "say" on macOS, System.Speech on Windows and espeak elsewhere. Text is
+// spoken with a voice for the selected locale when one is installed.
+func NewSystemSpeaker() Speaker {
+	return systemSpeaker{}
+}
+
+func (systemSpeaker) Speak(text string) error {
+	var cmd *exec.Cmd
+	voice, localized := systemVoices[i18n.Locale()]
+
+	switch runtime.GOOS {
+	case "darwin":
+		if localized {
+			cmd = exec.Command("say", "-v", voice.say, text)
+		} else {
+			cmd = exec.Command("say", text)
+		}
+	case "windows":
+		// Keep the default voice if none is installed for the culture
+		script := "Add-Type -AssemblyName System.Speech; " +
+			"$s = New-Object System.Speech.Synthesis.SpeechSynthesizer; " +
+			"if ($args[1]) { try { $s.SelectVoiceByHints('NotSet', 'NotSet', 0, [Globalization.CultureInfo]$args[1]) } catch {} }; " +
+			"$s.Speak($args[0])"
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script, text, voice.culture)
+	default:
+		path, err := exec.LookPath("espeak-ng")
+		if err != nil {
+			path, err = exec.LookPath("espeak")
+		}
+		if err != nil {
+			return fmt.Errorf("no text-to-speech engine found (install espeak)")
+		}
+		if localized {
+			cmd = exec.Command(path, "-v", voice.espeak, text)
+		} else {
+			cmd = exec.Command(path, text)
+		}
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to speak: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// SessionWarmup marks shots hit during a warmup in the shot history
+const SessionWarmup = "warmup"
+
+// Warmup defaults
+const (
+	DefaultWarmupBallsPerClub = 5
+
+	maxWarmupClubs = 14
+	maxWarmupBalls = 20
+)
+
+// DefaultWarmupClubs returns the usual progression from a short club to the
+// driver
+func DefaultWarmupClubs() []string {
+	return []string{ClubPitchingWedge.Name(), ClubIron7.Name(), ClubDriver.Name()}
+}
+
+// warmupClubs are the clubs a warmup can select on the device
+var warmupClubs = []ClubType{
+	ClubDriver, ClubWood3, ClubWood5, ClubWood7,
+	ClubIron4, ClubIron5, ClubIron6, ClubIron7, ClubIron8, ClubIron9,
+	ClubPitchingWedge, ClubApproachWedge, ClubSandWedge, ClubPutter,
+}
+
+// ClubByName returns the club with the display name, ignoring case
+func ClubByName(name string) (ClubType, bool) {
+	for _, club := range warmupClubs {
+		if strings.EqualFold(club.Name(), strings.TrimSpace(name)) {
+			return club, true
+		}
+	}
+	return ClubType{}, false
+}
+
+// WarmupConfig starts a warmup. Empty fields take the defaults.
+type WarmupConfig struct {
+	Profile      string   `json:"profile"`
+	Clubs        []string `json:"clubs,omitempty"` // in the order they are hit
+	BallsPerClub int      `json:"ballsPerClub,omitempty"`
+}
+
+func (c WarmupConfig) withDefaults() WarmupConfig {
+	c.Profile = strings.TrimSpace(c.Profile)
+	if c.Profile == "" {
+		c.Profile = DefaultGameProfile
+	}
+	clubs := make([]string, 0, len(c.Clubs))
+	for _, name := range c.Clubs {
+		// Names are stored as the device reports them
+		if club, ok := ClubByName(name); ok {
+			name = club.Name()
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			clubs = append(clubs, name)
+		}
+	}
+	c.Clubs = clubs
+	if len(c.Clubs) == 0 {
+		c.Clubs = DefaultWarmupClubs()
+	}
+	if c.BallsPerClub == 0 {
+		c.BallsPerClub = DefaultWarmupBallsPerClub
+	}
+	return c
+}
+
+// Validate checks a config after defaults are applied
+func (c WarmupConfig) Validate() error {
+	if len(c.Clubs) > maxWarmupClubs {
+		return errors.New("too many clubs")
+	}
+	for _, name := range c.Clubs {
+		if _, ok := ClubByName(name); !ok {
+			return errors.New("unknown club")
+		}
+	}
+	if c.BallsPerClub < 1 || c.BallsPerClub > maxWarmupBalls {
+		return errors.New("invalid number of shots")
+	}
+	return nil
+}
+
+// WarmupShot is a shot hit during a warmup
+type WarmupShot struct {
+	ShotID       int       `json:"shotId"`
+	Club         string    `json:"club"`
+	BallSpeedMPS float64   `json:"ballSpeedMps"`
+	CarryYards   float64   `json:"carryYards"`
+	OfflineYards float64   `json:"offlineYards"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// WarmupState is a warmup's progress. Club is nil once every club has been
+// hit.
+type WarmupState struct {
+	Config    WarmupConfig `json:"config"`
+	StartedAt time.Time    `json:"startedAt"`
+	Club      *string      `json:"club"`
+	ClubBalls int          `json:"clubBalls"` // hit with the current club
+	Shots     []WarmupShot `json:"shots"`
+	Total     int          `json:"total"` // shots in the warmup
+	Finished  bool         `json:"finished"`
+}
+
+// Summary summarizes the shots hit so far with each club
+func (s WarmupState) Summary() WarmupSummary {
+	summary := WarmupSummary{
+		Profile:   s.Config.Profile,
+		StartedAt: s.StartedAt,
+		Completed: s.Finished,
+		Shots:     len(s.Shots),
+		Clubs:     []WarmupClubSummary{},
+	}
+	if len(s.Shots) > 0 {
+		summary.EndedAt = s.Shots[len(s.Shots)-1].Timestamp
+	}
+	for _, club := range s.Config.Clubs {
+		var speeds, carries []float64
+		offline := 0.0
+		for _, shot := range s.Shots {
+			if shot.Club == club {
+				speeds = append(speeds, shot.BallSpeedMPS)
+				carries = append(carries, shot.CarryYards)
+				offline += shot.OfflineYards
+			}
+		}
+		if len(carries) == 0 {
+			continue
+		}
+		speed, _ := meanAndSpread(speeds)
+		carry, spread := meanAndSpread(carries)
+		summary.Clubs = append(summary.Clubs, WarmupClubSummary{
+			Club:                club,
+			Shots:               len(carries),
+			AverageBallSpeedMPS: roundYards(speed),
+			AverageCarryYards:   roundYards(carry),
+			CarrySpreadYards:    roundYards(spread),
+			AverageOfflineYards: roundYards(offline / float64(len(carries))),
+		})
+	}
+	return summary
+}
+
+// WarmupClubSummary is how the shots with one club went
+type WarmupClubSummary struct {
+	Club                string  `json:"club"`
+	Shots               int     `json:"shots"`
+	AverageBallSpeedMPS float64 `json:"averageBallSpeedMps"`
+	AverageCarryYards   float64 `json:"averageCarryYards"`
+	CarrySpreadYards    float64 `json:"carrySpreadYards"` // standard deviation
+	AverageOfflineYards float64 `json:"averageOfflineYards"`
+}
+
+// WarmupSummary is a finished or stopped warmup, kept apart from the games
+type WarmupSummary struct {
+	Profile   string              `json:"profile"`
+	StartedAt time.Time           `json:"startedAt"`
+	EndedAt   time.Time           `json:"endedAt"`
+	Completed bool                `json:"completed"` // false if stopped early
+	Shots     int                 `json:"shots"`
+	Clubs     []WarmupClubSummary `json:"clubs"`
+}
+
+// Warmup steps through the clubs in order, moving to the next club after
+// the set number of balls. It is not safe for concurrent use.
+type Warmup struct {
+	state WarmupState
+	club  int
+}
+
+// NewWarmup starts a warmup
+func NewWarmup(config WarmupConfig) (*Warmup, error) {
+	config = config.withDefaults()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	w := &Warmup{state: WarmupState{
+		Config:    config,
+		StartedAt: time.Now(),
+		Club:      &config.Clubs[0],
+		Shots:     []WarmupShot{},
+		Total:     len(config.Clubs) * config.BallsPerClub,
+	}}
+	return w, nil
+}
+
+// State returns a copy of the warmup's progress
+func (w *Warmup) State() WarmupState {
+	state := w.state
+	state.Config.Clubs = append([]string(nil), w.state.Config.Clubs...)
+	state.Shots = append([]WarmupShot{}, w.state.Shots...)
+	if w.state.Club != nil {
+		club := *w.state.Club
+		state.Club = &club
+	}
+	return state
+}
+
+// Record records a shot with the current club, moving on to the next club
+// once it has been hit enough. The shot's club is the prompted one, since
+// the warmup selects it on the device.
+func (w *Warmup) Record(shotID int, ballSpeedMPS, carryYards, offlineYards float64, at time.Time) (WarmupShot, error) {
+	if w.state.Finished {
+		return WarmupShot{}, ErrNoGame
+	}
+
+	shot := WarmupShot{
+		ShotID:       shotID,
+		Club:         *w.state.Club,
+		BallSpeedMPS: ballSpeedMPS,
+		CarryYards:   roundYards(carryYards),
+		OfflineYards: roundYards(offlineYards),
+		Timestamp:    at,
+	}
+	w.state.Shots = append(w.state.Shots, shot)
+	w.state.ClubBalls++
+
+	if w.state.ClubBalls >= w.state.Config.BallsPerClub {
+		w.club++
+		w.state.ClubBalls = 0
+		if w.club < len(w.state.Config.Clubs) {
+			w.state.Club = &w.state.Config.Clubs[w.club]
+		} else {
+			w.state.Club = nil
+			w.state.Finished = true
+		}
+	}
+	return shot, nil
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewWarmup_Defaults(t *testing.T) {
+	warmup, err := NewWarmup(WarmupConfig{Clubs: []string{" "}})
+	if err != nil {
+		t.Fatalf("NewWarmup() error = %v", err)
+	}
+	state := warmup.State()
+	if state.Config.Profile != DefaultGameProfile || state.Config.BallsPerClub != DefaultWarmupBallsPerClub {
+		t.Errorf("Config = %+v, want defaults", state.Config)
+	}
+	if state.Total != 3*DefaultWarmupBallsPerClub || state.Club == nil || *state.Club != ClubPitchingWedge.Name() {
+		t.Errorf("State = %+v, want %d shots starting with the pitching wedge", state, 3*DefaultWarmupBallsPerClub)
+	}
+}
+
+func TestNewWarmup_RejectsInvalidConfig(t *testing.T) {
+	configs := []WarmupConfig{
+		{Clubs: []string{"Lob Wedge"}},
+		{BallsPerClub: -1},
+		{BallsPerClub: 50},
+	}
+	for _, config := range configs {
+		if _, err := NewWarmup(config); err == nil {
+			t.Errorf("NewWarmup(%+v) succeeded, want an error", config)
+		}
+	}
+}
+
+func TestWarmup_AdvancesThroughClubs(t *testing.T) {
+	warmup, err := NewWarmup(WarmupConfig{Profile: "Sam", Clubs: []string{"sand wedge", "driver"}, BallsPerClub: 2})
+	if err != nil {
+		t.Fatalf("NewWarmup() error = %v", err)
+	}
+	at := time.Unix(0, 0)
+	carries := []float64{70, 80, 240, 260}
+	for i, carry := range carries {
+		if _, err := warmup.Record(i+1, 50, carry, 0, at); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+		state := warmup.State()
+		switch i {
+		case 0:
+			if *state.Club != "Sand Wedge" || state.ClubBalls != 1 {
+				t.Errorf("After one ball: club %v, %d balls, want the sand wedge with 1", *state.Club, state.ClubBalls)
+			}
+		case 1:
+			if *state.Club != "Driver" || state.ClubBalls != 0 {
+				t.Errorf("After two balls: club %v, %d balls, want the driver with none", *state.Club, state.ClubBalls)
+			}
+		}
+	}
+
+	state := warmup.State()
+	if !state.Finished || state.Club != nil {
+		t.Fatalf("Expected the warmup to finish after the last ball, got %+v", state)
+	}
+	if _, err := warmup.Record(5, 50, 100, 0, at); !errors.Is(err, ErrNoGame) {
+		t.Errorf("Record() after finishing error = %v, want ErrNoGame", err)
+	}
+
+	summary := state.Summary()
+	if !summary.Completed || summary.Shots != 4 || len(summary.Clubs) != 2 {
+		t.Fatalf("Summary = %+v, want 4 shots with 2 clubs", summary)
+	}
+	if driver := summary.Clubs[1]; driver.Club != "Driver" || driver.AverageCarryYards != 250 || driver.CarrySpreadYards != 10 {
+		t.Errorf("Driver summary = %+v, want 250 yd ±10", driver)
+	}
+}
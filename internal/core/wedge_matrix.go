@@ -0,0 +1,255 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Wedge practice defaults
+const (
+	DefaultWedgeShotsPerTarget = 3
+
+	maxWedgeClubs   = 8
+	maxWedgeTargets = 10
+	maxWedgeShots   = 10
+)
+
+// DefaultWedgeClubs returns the wedges the device can select
+func DefaultWedgeClubs() []string {
+	return []string{ClubPitchingWedge.Name(), ClubApproachWedge.Name(), ClubSandWedge.Name()}
+}
+
+// DefaultWedgeTargets returns the carry distances practiced with each wedge
+func DefaultWedgeTargets() []float64 {
+	return []float64{40, 60, 80}
+}
+
+// WedgePracticeConfig starts a wedge practice. Empty fields take the
+// defaults.
+type WedgePracticeConfig struct {
+	Profile        string    `json:"profile"`
+	Clubs          []string  `json:"clubs,omitempty"`
+	TargetsYards   []float64 `json:"targetsYards,omitempty"`
+	ShotsPerTarget int       `json:"shotsPerTarget,omitempty"`
+}
+
+func (c WedgePracticeConfig) withDefaults() WedgePracticeConfig {
+	c.Profile = strings.TrimSpace(c.Profile)
+	if c.Profile == "" {
+		c.Profile = DefaultGameProfile
+	}
+	clubs := make([]string, 0, len(c.Clubs))
+	for _, club := range c.Clubs {
+		if club = strings.TrimSpace(club); club != "" {
+			clubs = append(clubs, club)
+		}
+	}
+	c.Clubs = clubs
+	if len(c.Clubs) == 0 {
+		c.Clubs = DefaultWedgeClubs()
+	}
+	if len(c.TargetsYards) == 0 {
+		c.TargetsYards = DefaultWedgeTargets()
+	} else {
+		c.TargetsYards = append([]float64(nil), c.TargetsYards...)
+		sort.Float64s(c.TargetsYards)
+	}
+	if c.ShotsPerTarget == 0 {
+		c.ShotsPerTarget = DefaultWedgeShotsPerTarget
+	}
+	return c
+}
+
+// Validate checks a config after defaults are applied
+func (c WedgePracticeConfig) Validate() error {
+	if len(c.Clubs) > maxWedgeClubs {
+		return errors.New("too many clubs")
+	}
+	if len(c.TargetsYards) > maxWedgeTargets {
+		return errors.New("too many targets")
+	}
+	for _, target := range c.TargetsYards {
+		if target < 1 || target > maxGameYards {
+			return errors.New("invalid target range")
+		}
+	}
+	if c.ShotsPerTarget < 1 || c.ShotsPerTarget > maxWedgeShots {
+		return errors.New("invalid number of shots")
+	}
+	return nil
+}
+
+// WedgePrompt is the club and carry the next shot should be hit with
+type WedgePrompt struct {
+	Club        string  `json:"club"`
+	TargetYards float64 `json:"targetYards"`
+}
+
+// WedgeAttempt is a shot hit at a prompt
+type WedgeAttempt struct {
+	WedgePrompt
+	CarryYards   float64   `json:"carryYards"`
+	OfflineYards float64   `json:"offlineYards"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// WedgePracticeState is a practice's progress. Prompt is nil once every
+// target has been hit.
+type WedgePracticeState struct {
+	Config    WedgePracticeConfig `json:"config"`
+	StartedAt time.Time           `json:"startedAt"`
+	Prompt    *WedgePrompt        `json:"prompt"`
+	Attempts  []WedgeAttempt      `json:"attempts"`
+	Total     int                 `json:"total"` // shots in the practice
+	Finished  bool                `json:"finished"`
+}
+
+// WedgePractice prompts for each club and target in turn, taking every
+// target with a club before moving to the next club. It is not safe for
+// concurrent use.
+type WedgePractice struct {
+	state   WedgePracticeState
+	prompts []WedgePrompt
+}
+
+// NewWedgePractice starts a wedge practice
+func NewWedgePractice(config WedgePracticeConfig) (*WedgePractice, error) {
+	config = config.withDefaults()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &WedgePractice{state: WedgePracticeState{
+		Config:    config,
+		StartedAt: time.Now(),
+		Attempts:  []WedgeAttempt{},
+	}}
+	for _, club := range config.Clubs {
+		for _, target := range config.TargetsYards {
+			for i := 0; i < config.ShotsPerTarget; i++ {
+				p.prompts = append(p.prompts, WedgePrompt{Club: club, TargetYards: target})
+			}
+		}
+	}
+	p.state.Total = len(p.prompts)
+	p.state.Prompt = &p.prompts[0]
+	return p, nil
+}
+
+// State returns a copy of the practice's progress
+func (p *WedgePractice) State() WedgePracticeState {
+	state := p.state
+	state.Attempts = append([]WedgeAttempt(nil), p.state.Attempts...)
+	if p.state.Prompt != nil {
+		prompt := *p.state.Prompt
+		state.Prompt = &prompt
+	}
+	return state
+}
+
+// Record records a shot against the current prompt and moves on to the next
+func (p *WedgePractice) Record(carryYards, offlineYards float64, at time.Time) (WedgeAttempt, error) {
+	if p.state.Finished {
+		return WedgeAttempt{}, ErrNoGame
+	}
+
+	attempt := WedgeAttempt{
+		WedgePrompt:  *p.state.Prompt,
+		CarryYards:   roundYards(carryYards),
+		OfflineYards: roundYards(offlineYards),
+		Timestamp:    at,
+	}
+	p.state.Attempts = append(p.state.Attempts, attempt)
+
+	if next := len(p.state.Attempts); next < len(p.prompts) {
+		p.state.Prompt = &p.prompts[next]
+	} else {
+		p.state.Prompt = nil
+		p.state.Finished = true
+	}
+	return attempt, nil
+}
+
+// WedgeMatrixCell summarizes the shots hit with a club at a target
+type WedgeMatrixCell struct {
+	Club                string  `json:"club"`
+	TargetYards         float64 `json:"targetYards"`
+	Shots               int     `json:"shots"`
+	AverageCarryYards   float64 `json:"averageCarryYards"`
+	CarrySpreadYards    float64 `json:"carrySpreadYards"`  // standard deviation
+	AverageErrorYards   float64 `json:"averageErrorYards"` // carry minus target; negative is short
+	AverageOfflineYards float64 `json:"averageOfflineYards"`
+}
+
+// WedgeMatrix is the carry each club produces for each target, the chart a
+// player takes to the course
+type WedgeMatrix struct {
+	Profile      string            `json:"profile"`
+	Clubs        []string          `json:"clubs"`
+	TargetsYards []float64         `json:"targetsYards"`
+	Cells        []WedgeMatrixCell `json:"cells"` // by club, then target
+}
+
+// BuildWedgeMatrix summarizes a profile's attempts. Clubs keep the order they
+// were first practiced in; targets are shortest first.
+func BuildWedgeMatrix(profile string, attempts []WedgeAttempt) WedgeMatrix {
+	matrix := WedgeMatrix{Profile: profile, Clubs: []string{}, TargetsYards: []float64{}, Cells: []WedgeMatrixCell{}}
+	byCell := make(map[WedgePrompt][]WedgeAttempt)
+	seenClub := make(map[string]bool)
+	seenTarget := make(map[float64]bool)
+	for _, attempt := range attempts {
+		if !seenClub[attempt.Club] {
+			seenClub[attempt.Club] = true
+			matrix.Clubs = append(matrix.Clubs, attempt.Club)
+		}
+		if !seenTarget[attempt.TargetYards] {
+			seenTarget[attempt.TargetYards] = true
+			matrix.TargetsYards = append(matrix.TargetsYards, attempt.TargetYards)
+		}
+		byCell[attempt.WedgePrompt] = append(byCell[attempt.WedgePrompt], attempt)
+	}
+	sort.Float64s(matrix.TargetsYards)
+
+	for _, club := range matrix.Clubs {
+		for _, target := range matrix.TargetsYards {
+			cellAttempts := byCell[WedgePrompt{Club: club, TargetYards: target}]
+			if len(cellAttempts) == 0 {
+				continue
+			}
+			carries := make([]float64, len(cellAttempts))
+			offline := 0.0
+			for i, attempt := range cellAttempts {
+				carries[i] = attempt.CarryYards
+				offline += attempt.OfflineYards
+			}
+			mean, spread := meanAndSpread(carries)
+			matrix.Cells = append(matrix.Cells, WedgeMatrixCell{
+				Club:                club,
+				TargetYards:         target,
+				Shots:               len(cellAttempts),
+				AverageCarryYards:   roundYards(mean),
+				CarrySpreadYards:    roundYards(spread),
+				AverageErrorYards:   roundYards(mean - target),
+				AverageOfflineYards: roundYards(offline / float64(len(cellAttempts))),
+			})
+		}
+	}
+	return matrix
+}
+
+// meanAndSpread returns the mean and population standard deviation
+func meanAndSpread(values []float64) (float64, float64) {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
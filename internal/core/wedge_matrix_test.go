@@ -0,0 +1,118 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewWedgePractice_Defaults(t *testing.T) {
+	practice, err := NewWedgePractice(WedgePracticeConfig{Clubs: []string{" "}})
+	if err != nil {
+		t.Fatalf("NewWedgePractice() error = %v", err)
+	}
+	state := practice.State()
+	if state.Config.Profile != DefaultGameProfile || len(state.Config.Clubs) != 3 || state.Config.ShotsPerTarget != DefaultWedgeShotsPerTarget {
+		t.Errorf("Config = %+v, want defaults", state.Config)
+	}
+	if state.Total != 3*3*DefaultWedgeShotsPerTarget {
+		t.Errorf("Total = %d, want %d", state.Total, 3*3*DefaultWedgeShotsPerTarget)
+	}
+	if want := (WedgePrompt{Club: ClubPitchingWedge.Name(), TargetYards: 40}); *state.Prompt != want {
+		t.Errorf("Prompt = %+v, want %+v", *state.Prompt, want)
+	}
+}
+
+func TestNewWedgePractice_RejectsInvalidConfig(t *testing.T) {
+	configs := []WedgePracticeConfig{
+		{TargetsYards: []float64{0}},
+		{TargetsYards: []float64{40, 500}},
+		{ShotsPerTarget: -1},
+		{ShotsPerTarget: 50},
+		{TargetsYards: []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110}},
+	}
+	for _, config := range configs {
+		if _, err := NewWedgePractice(config); err == nil {
+			t.Errorf("NewWedgePractice(%+v) succeeded, want an error", config)
+		}
+	}
+}
+
+func TestWedgePractice_Record(t *testing.T) {
+	practice, err := NewWedgePractice(WedgePracticeConfig{
+		Clubs:          []string{"Sand Wedge", "Pitching Wedge"},
+		TargetsYards:   []float64{60, 40},
+		ShotsPerTarget: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every target with one club, shortest first, before the next club
+	want := []WedgePrompt{
+		{"Sand Wedge", 40},
+		{"Sand Wedge", 60},
+		{"Pitching Wedge", 40},
+		{"Pitching Wedge", 60},
+	}
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for i, prompt := range want {
+		attempt, err := practice.Record(prompt.TargetYards+1.04, -2, at)
+		if err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+		if attempt.WedgePrompt != prompt || attempt.CarryYards != prompt.TargetYards+1 || !attempt.Timestamp.Equal(at) {
+			t.Errorf("attempt %d = %+v, want %+v", i, attempt, prompt)
+		}
+	}
+
+	state := practice.State()
+	if !state.Finished || state.Prompt != nil || len(state.Attempts) != len(want) {
+		t.Errorf("State() = %+v, want finished with %d attempts", state, len(want))
+	}
+	if _, err := practice.Record(40, 0, at); !errors.Is(err, ErrNoGame) {
+		t.Errorf("Record() after the last shot error = %v, want ErrNoGame", err)
+	}
+}
+
+func TestBuildWedgeMatrix(t *testing.T) {
+	attempt := func(club string, target, carry, offline float64) WedgeAttempt {
+		return WedgeAttempt{WedgePrompt: WedgePrompt{Club: club, TargetYards: target}, CarryYards: carry, OfflineYards: offline}
+	}
+	matrix := BuildWedgeMatrix("Sam", []WedgeAttempt{
+		attempt("Sand Wedge", 60, 56, 2),
+		attempt("Sand Wedge", 40, 42, 0),
+		attempt("Sand Wedge", 60, 60, -4),
+		attempt("Pitching Wedge", 80, 83, 1),
+	})
+
+	if matrix.Profile != "Sam" || len(matrix.Clubs) != 2 || matrix.Clubs[0] != "Sand Wedge" {
+		t.Errorf("Clubs = %v, want Sand Wedge then Pitching Wedge", matrix.Clubs)
+	}
+	if len(matrix.TargetsYards) != 3 || matrix.TargetsYards[0] != 40 || matrix.TargetsYards[2] != 80 {
+		t.Errorf("TargetsYards = %v, want 40, 60, 80", matrix.TargetsYards)
+	}
+	// No cell for clubs never hit at a target
+	if len(matrix.Cells) != 3 {
+		t.Fatalf("Cells = %+v, want 3", matrix.Cells)
+	}
+	want := WedgeMatrixCell{
+		Club:                "Sand Wedge",
+		TargetYards:         60,
+		Shots:               2,
+		AverageCarryYards:   58,
+		CarrySpreadYards:    2,
+		AverageErrorYards:   -2,
+		AverageOfflineYards: -1,
+	}
+	if matrix.Cells[1] != want {
+		t.Errorf("Cells[1] = %+v, want %+v", matrix.Cells[1], want)
+	}
+}
+
+func TestBuildWedgeMatrix_Empty(t *testing.T) {
+	matrix := BuildWedgeMatrix("Sam", nil)
+	if matrix.Clubs == nil || matrix.TargetsYards == nil || matrix.Cells == nil {
+		t.Errorf("BuildWedgeMatrix(nil) = %+v, want empty lists for JSON", matrix)
+	}
+}
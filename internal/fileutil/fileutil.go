@@ -0,0 +1,49 @@
+// Package fileutil holds file helpers shared by the packages that save state
+// to disk.
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteAtomic replaces path with data so that a crash part way through
+// leaves either the old file or the new one, never a truncated mix. If
+// backup is set the replaced file is kept there.
+func WriteAtomic(path, backup string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	// Readers can fall back to the backup if a crash lands between the
+	// renames. The backup holds the same data, so it gets the same mode.
+	if backup != "" {
+		if err := os.Rename(path, backup); err == nil {
+			os.Chmod(backup, perm)
+		} else if !os.IsNotExist(err) {
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
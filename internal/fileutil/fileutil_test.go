@@ -0,0 +1,87 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWriteAtomic_KeepsThePreviousFileAsBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	backup := path + ".bak"
+
+	if err := WriteAtomic(path, backup, []byte("first"), 0600); err != nil {
+		t.Fatalf("first write error = %v", err)
+	}
+	if _, err := os.Stat(backup); !os.IsNotExist(err) {
+		t.Errorf("Expected no backup after the first write, got %v", err)
+	}
+
+	if err := WriteAtomic(path, backup, []byte("second"), 0600); err != nil {
+		t.Fatalf("second write error = %v", err)
+	}
+	if got := readFile(t, path); got != "second" {
+		t.Errorf("file = %q, want second", got)
+	}
+	if got := readFile(t, backup); got != "first" {
+		t.Errorf("backup = %q, want first", got)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("permissions = %v, want 0600", perm)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("directory has %d entries, want only the file and its backup", len(entries))
+	}
+}
+
+func TestWriteAtomic_FailureLeavesTheFileAlone(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "missing", "config.json")
+
+	if err := WriteAtomic(path, path+".bak", []byte("data"), 0644); err == nil {
+		t.Fatal("Expected an error writing into a missing directory")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected no file, got %v", err)
+	}
+}
+
+func TestWriteAtomic_WithoutBackupReplacesTheFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "battery.json")
+	for _, data := range []string{"first", "second"} {
+		if err := WriteAtomic(path, "", []byte(data), 0644); err != nil {
+			t.Fatalf("write %s error = %v", data, err)
+		}
+	}
+
+	if got := readFile(t, path); got != "second" {
+		t.Errorf("file = %q, want second", got)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the file", len(entries))
+	}
+}
@@ -0,0 +1,155 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Speed units numbers are shown in
+const (
+	SpeedMPH = "mph"
+	SpeedMPS = "mps"
+)
+
+// Distance units numbers are shown in
+const (
+	DistanceYards  = "yards"
+	DistanceMeters = "meters"
+)
+
+const (
+	mpsToMPH       = 2.23694
+	yardsToMeters  = 0.9144
+	decimalPoint   = "."
+	decimalComma   = ","
+	defaultDecimal = decimalPoint
+)
+
+// NumberFormat sets how numbers are written for people: the speed and
+// distance units and the decimal separator. It is used by voice
+// announcements, the tail command and the overlay, so they all agree.
+type NumberFormat struct {
+	SpeedUnit        string `json:"speedUnit"`        // "mph" or "mps"
+	DistanceUnit     string `json:"distanceUnit"`     // "yards" or "meters"
+	DecimalSeparator string `json:"decimalSeparator"` // "." or ","
+}
+
+// DefaultNumberFormat returns mph, yards and a decimal point
+func DefaultNumberFormat() NumberFormat {
+	return NumberFormat{SpeedUnit: SpeedMPH, DistanceUnit: DistanceYards, DecimalSeparator: defaultDecimal}
+}
+
+// Valid reports whether the units and separator are supported
+func (f NumberFormat) Valid() bool {
+	return (f.SpeedUnit == SpeedMPH || f.SpeedUnit == SpeedMPS) &&
+		(f.DistanceUnit == DistanceYards || f.DistanceUnit == DistanceMeters) &&
+		(f.DecimalSeparator == decimalPoint || f.DecimalSeparator == decimalComma)
+}
+
+var (
+	numberFormat   = DefaultNumberFormat()
+	numberFormatMu sync.RWMutex
+)
+
+// SetNumberFormat selects how numbers are written. An invalid format is
+// ignored.
+func SetNumberFormat(format NumberFormat) {
+	if !format.Valid() {
+		return
+	}
+	numberFormatMu.Lock()
+	numberFormat = format
+	numberFormatMu.Unlock()
+}
+
+// CurrentNumberFormat returns the selected number format
+func CurrentNumberFormat() NumberFormat {
+	numberFormatMu.RLock()
+	defer numberFormatMu.RUnlock()
+	return numberFormat
+}
+
+// Number writes value with decimals digits after the selected separator
+func Number(value float64, decimals int) string {
+	return CurrentNumberFormat().Number(value, decimals)
+}
+
+// Number writes value with decimals digits after the separator
+func (f NumberFormat) Number(value float64, decimals int) string {
+	text := strconv.FormatFloat(value, 'f', decimals, 64)
+	if text == "-"+strconv.FormatFloat(0, 'f', decimals, 64) {
+		text = text[1:]
+	}
+	if f.DecimalSeparator == decimalComma {
+		text = strings.Replace(text, decimalPoint, decimalComma, 1)
+	}
+	return text
+}
+
+// Speed converts a speed in m/s to the selected unit
+func (f NumberFormat) Speed(mps float64) float64 {
+	if f.SpeedUnit == SpeedMPS {
+		return mps
+	}
+	return mps * mpsToMPH
+}
+
+// SpeedLabel is the short name of the selected speed unit
+func (f NumberFormat) SpeedLabel() string {
+	if f.SpeedUnit == SpeedMPS {
+		return "m/s"
+	}
+	return "mph"
+}
+
+// Distance converts a distance in yards to the selected unit
+func (f NumberFormat) Distance(yards float64) float64 {
+	if f.DistanceUnit == DistanceMeters {
+		return yards * yardsToMeters
+	}
+	return yards
+}
+
+// DistanceLabel is the short name of the selected distance unit
+func (f NumberFormat) DistanceLabel() string {
+	if f.DistanceUnit == DistanceMeters {
+		return "m"
+	}
+	return "yd"
+}
+
+// Speed writes a speed given in m/s in the selected unit, e.g. "150.2 mph"
+func Speed(mps float64, decimals int) string {
+	f := CurrentNumberFormat()
+	return f.Number(f.Speed(mps), decimals) + " " + f.SpeedLabel()
+}
+
+// Distance writes a distance given in yards in the selected unit, e.g.
+// "231 yd"
+func Distance(yards float64, decimals int) string {
+	f := CurrentNumberFormat()
+	return f.Number(f.Distance(yards), decimals) + " " + f.DistanceLabel()
+}
+
+// SpokenSpeed is a speed given in m/s as it is read out in the selected
+// unit and locale, e.g. "150 miles per hour"
+func SpokenSpeed(mps float64) string {
+	f := CurrentNumberFormat()
+	value := f.Number(f.Speed(mps), 0)
+	if f.SpeedUnit == SpeedMPS {
+		return Tf("%s meters per second", value)
+	}
+	return Tf("%s miles per hour", value)
+}
+
+// SpokenCarry is a carry distance given in yards as it is read out in the
+// selected unit and locale, e.g. "carry 231 yards"
+func SpokenCarry(yards float64) string {
+	f := CurrentNumberFormat()
+	value := f.Number(f.Distance(yards), 0)
+	if f.DistanceUnit == DistanceMeters {
+		return Tf("carry %s meters", value)
+	}
+	return Tf("carry %s yards", value)
+}
@@ -0,0 +1,94 @@
+// Package i18n translates server-generated text such as API errors, status
+// messages and voice announcements. Messages are looked up by their English
+// text, so a message without a translation is shown in English.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Supported locales
+const (
+	LocaleEnglish  = "en"
+	LocaleKorean   = "ko"
+	LocaleJapanese = "ja"
+)
+
+// DefaultLocale is used until a locale is selected
+const DefaultLocale = LocaleEnglish
+
+var (
+	locale   = DefaultLocale
+	localeMu sync.RWMutex
+)
+
+// Locales returns the supported locales
+func Locales() []string {
+	return []string{LocaleEnglish, LocaleKorean, LocaleJapanese}
+}
+
+// IsSupported reports whether locale can be selected
+func IsSupported(locale string) bool {
+	for _, supported := range Locales() {
+		if locale == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLocale selects the locale for translated text. Region suffixes are
+// ignored, so "ko-KR" selects Korean; unsupported locales fall back to English.
+func SetLocale(newLocale string) {
+	newLocale = strings.ToLower(newLocale)
+	if i := strings.IndexAny(newLocale, "-_"); i >= 0 {
+		newLocale = newLocale[:i]
+	}
+	if !IsSupported(newLocale) {
+		newLocale = DefaultLocale
+	}
+
+	localeMu.Lock()
+	locale = newLocale
+	localeMu.Unlock()
+}
+
+// Locale returns the selected locale
+func Locale() string {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	return locale
+}
+
+// T translates message into the selected locale
+func T(message string) string {
+	return lookup(Locale(), message)
+}
+
+// Tf translates format into the selected locale and formats it with args
+func Tf(format string, args ...interface{}) string {
+	return fmt.Sprintf(lookup(Locale(), format), args...)
+}
+
+// Error translates an error's message. Wrapped errors read as
+// "context: cause", so each part is translated on its own.
+func Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	current := Locale()
+	parts := strings.Split(err.Error(), ": ")
+	for i, part := range parts {
+		parts[i] = lookup(current, part)
+	}
+	return strings.Join(parts, ": ")
+}
+
+func lookup(locale, message string) string {
+	if translated, ok := translations[locale][message]; ok {
+		return translated
+	}
+	return message
+}
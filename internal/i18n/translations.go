@@ -0,0 +1,303 @@
+package i18n
+
+// translations maps English messages to each locale's text. Format strings
+// keep the English verbs so they can be passed to Tf.
+var translations = map[string]map[string]string{
+	LocaleKorean: {
+		// API request errors
+		"Invalid request body": "잘못된 요청 본문입니다",
+		"Invalid %s":           "%s 값이 올바르지 않습니다",
+		"Invalid %s value":     "허용되지 않는 %s 값입니다",
+		"Invalid handedness value (must be 'left' or 'right')": "잘못된 타석 방향입니다 ('left' 또는 'right'여야 합니다)",
+		"Invalid shot id": "잘못된 샷 ID입니다",
+		"Invalid from":    "시작 날짜가 올바르지 않습니다",
+		"Invalid to":      "종료 날짜가 올바르지 않습니다",
+		"Invalid after":   "after 값이 올바르지 않습니다",
+		"Invalid limit":   "limit 값이 올바르지 않습니다",
+		"Invalid offset":  "offset 값이 올바르지 않습니다",
+		"expected YYYY-MM-DD or an RFC 3339 time": "YYYY-MM-DD 또는 RFC 3339 시간 형식이어야 합니다",
+		"Shot not found":                                    "샷을 찾을 수 없습니다",
+		"Video not found":                                   "영상을 찾을 수 없습니다",
+		"No raw data was kept for this shot":                "이 샷의 원시 데이터가 저장되지 않았습니다",
+		"Video is stored on the camera":                     "영상이 카메라에 저장되어 있습니다",
+		"No alignment capture in progress":                  "진행 중인 정렬 캡처가 없습니다",
+		"No ball position available":                        "공 위치 정보가 없습니다",
+		"External camera feature not enabled":               "외부 카메라 기능이 활성화되지 않았습니다",
+		"Simulator is not running":                          "시뮬레이터가 실행 중이 아닙니다",
+		"Origin not allowed":                                "허용되지 않은 출처입니다",
+		"An access token is required":                       "액세스 토큰이 필요합니다",
+		"This access token can only view":                   "이 액세스 토큰은 보기만 할 수 있습니다",
+		"Streaming not supported":                           "스트리밍을 지원하지 않습니다",
+		"simulator is not connected":                        "시뮬레이터가 연결되어 있지 않습니다",
+		"a shot series is already running":                  "샷 시리즈가 이미 실행 중입니다",
+		"battery level must be between 0 and 100":           "배터리 잔량은 0에서 100 사이여야 합니다",
+		"shot count must be between 1 and 50":               "샷 수는 1에서 50 사이여야 합니다",
+		"interval must be between 0 and 300 seconds":        "간격은 0초에서 300초 사이여야 합니다",
+		"fault counts must be between 0 and 10000":          "오류 횟수는 0에서 10000 사이여야 합니다",
+		"fault delay must be between 0 and 30000 ms":        "오류 지연은 0에서 30000ms 사이여야 합니다",
+		"flood count must be between 1 and 10000":           "알림 수는 1에서 10000 사이여야 합니다",
+		"misread shot not found":                            "오측정 샷을 찾을 수 없습니다",
+		"unknown misread action":                            "알 수 없는 오측정 처리 방식입니다",
+		"unknown calibration point":                         "알 수 없는 보정 지점입니다",
+		"calibration needs origin and target samples":       "보정에는 원점과 목표 샘플이 필요합니다",
+		"target samples are too close to the origin":        "목표 샘플이 원점에 너무 가깝습니다",
+		"need packets captured at two or more angles":       "두 개 이상의 각도에서 캡처한 패킷이 필요합니다",
+		"no field in the captured packets tracks the angle": "캡처한 패킷에서 각도를 나타내는 필드를 찾지 못했습니다",
+
+		// Connection status errors
+		"Failed to initialize Bluetooth":       "블루투스를 초기화하지 못했습니다",
+		"failed to connect to device":          "기기에 연결하지 못했습니다",
+		"failed to connect":                    "연결하지 못했습니다",
+		"failed to pair with device":           "기기와 페어링하지 못했습니다",
+		"failed to unpair device":              "기기 페어링을 해제하지 못했습니다",
+		"pairing not supported":                "이 플랫폼에서는 운영 체제가 페어링을 처리합니다",
+		"disconnect before scanning":           "검색하기 전에 장치 연결을 해제하세요",
+		"not connected to device":              "기기에 연결되어 있지 않습니다",
+		"failed to enable notifications":       "알림을 활성화하지 못했습니다",
+		"failed to subscribe to notifications": "알림을 구독하지 못했습니다",
+		"error reading from server":            "서버에서 데이터를 읽는 중 오류가 발생했습니다",
+		"error sending data":                   "데이터 전송 중 오류가 발생했습니다",
+		"server closed connection":             "서버가 연결을 종료했습니다",
+		"stale connection":                     "연결이 응답하지 않습니다",
+		"no reply from server":                 "서버에서 응답이 없습니다",
+		"reconnection timeout":                 "재연결 시간이 초과되었습니다",
+		"too many failed attempts":             "실패한 시도가 너무 많습니다",
+		"please reconnect manually":            "수동으로 다시 연결하세요",
+
+		// Update errors
+		"Update checks are disabled":                           "업데이트 확인이 비활성화되어 있습니다",
+		"installing updates is disabled":                       "업데이트 설치가 비활성화되어 있습니다",
+		"installing updates is not supported on this platform": "이 플랫폼에서는 업데이트 설치를 지원하지 않습니다",
+		"no update available":                                  "사용 가능한 업데이트가 없습니다",
+		"failed to install update":                             "업데이트를 설치하지 못했습니다",
+		"failed to roll back update":                           "업데이트를 되돌리지 못했습니다",
+		"no previous version to roll back to":                  "되돌릴 이전 버전이 없습니다",
+
+		// Settings validation
+		"Invalid settings":                                         "설정이 올바르지 않습니다",
+		"has the wrong type":                                       "형식이 올바르지 않습니다",
+		"is not a supported value":                                 "지원되지 않는 값입니다",
+		"is out of range":                                          "허용 범위를 벗어났습니다",
+		"must be an IP address or host name":                       "IP 주소 또는 호스트 이름이어야 합니다",
+		"must be an IP address":                                    "IP 주소여야 합니다",
+		"must be a port from 1 to 65535":                           "1에서 65535 사이의 포트여야 합니다",
+		"must be an http, https or rtsp URL":                       "http, https 또는 rtsp URL이어야 합니다",
+		"must be an origin such as http://192.168.1.20:8080, or *": "http://192.168.1.20:8080 같은 오리진 또는 *여야 합니다",
+		"must be a MAC address or device UUID":                     "MAC 주소 또는 장치 UUID여야 합니다",
+		"must be off while Awesome Golf uses GSPro's address":      "Awesome Golf가 GSPro와 같은 주소를 사용하는 동안에는 꺼야 합니다",
+
+		// Game errors
+		"unknown game mode":                "알 수 없는 게임 모드입니다",
+		"invalid target range":             "목표 거리 범위가 올바르지 않습니다",
+		"invalid number of shots":          "샷 수가 올바르지 않습니다",
+		"invalid ladder step or tolerance": "래더 간격 또는 허용 오차가 올바르지 않습니다",
+		"no game in progress":              "진행 중인 게임이 없습니다",
+
+		// Wedge practice errors
+		"too many clubs":   "클럽이 너무 많습니다",
+		"too many targets": "목표 거리가 너무 많습니다",
+
+		// Warmup errors
+		"unknown club": "알 수 없는 클럽입니다",
+
+		// Known device errors
+		"unknown device":          "알 수 없는 장치입니다",
+		"nickname already in use": "이미 사용 중인 별명입니다",
+		"nickname too long":       "별명이 너무 깁니다",
+
+		// GSPro resend errors
+		"no shot to resend":   "다시 보낼 샷이 없습니다",
+		"no shots are queued": "대기 중인 샷이 없습니다",
+		"the last shot has changed, confirm the resend again":       "마지막 샷이 바뀌었습니다. 다시 보내기를 다시 확인하세요",
+		"the last shot was already resent":                          "마지막 샷은 이미 다시 보냈습니다",
+		"GSPro is not connected":                                    "GSPro에 연결되어 있지 않습니다",
+		"diagnostics are already running":                           "진단이 이미 실행 중입니다",
+		"session is paused":                                         "세션이 일시 정지되었습니다",
+		"swing stick mode is only supported on the SquareGolf Home": "스윙 스틱 모드는 SquareGolf Home에서만 지원됩니다",
+		"command must be hex bytes":                                 "명령은 16진수 바이트여야 합니다",
+		"failed to deactivate ball detection for pause":             "일시 정지를 위해 볼 감지를 끄지 못했습니다",
+		"failed to restore club selection":                          "클럽 선택을 복원하지 못했습니다",
+		"Feature %s is turned off":                                  "%s 기능이 꺼져 있습니다",
+		"unknown feature":                                           "알 수 없는 기능",
+
+		// Combine errors
+		"combine is not running":  "진행 중인 컴바인이 없습니다",
+		"combine is not paused":   "컴바인이 일시 중지되지 않았습니다",
+		"No combine to report on": "보고할 컴바인이 없습니다",
+
+		// Export errors
+		"failed to read the credentials file":                "자격 증명 파일을 읽지 못했습니다",
+		"the credentials file is not a service account key":  "자격 증명 파일이 서비스 계정 키가 아닙니다",
+		"the service account private key is not PEM encoded": "서비스 계정 개인 키가 PEM 형식이 아닙니다",
+		"failed to parse the service account private key":    "서비스 계정 개인 키를 해석하지 못했습니다",
+		"the service account private key is not an RSA key":  "서비스 계정 개인 키가 RSA 키가 아닙니다",
+		"failed to sign in to Google":                        "Google 로그인에 실패했습니다",
+		"Google sign-in failed":                              "Google 로그인에 실패했습니다",
+		"Google Sheets read failed":                          "Google 스프레드시트를 읽지 못했습니다",
+		"Google Sheets append failed":                        "Google 스프레드시트에 행을 추가하지 못했습니다",
+		"invalid S3 endpoint":                                "S3 엔드포인트가 올바르지 않습니다",
+		"S3 upload failed":                                   "S3 업로드에 실패했습니다",
+		"unknown export destination":                         "알 수 없는 내보내기 대상입니다",
+
+		// Misread reasons
+		"invalid ball speed": "볼 스피드가 올바르지 않음",
+		"missing spin":       "스핀 정보 없음",
+
+		// Voice announcements
+		"%s miles per hour":    "시속 %s마일",
+		"%s meters per second": "초속 %s미터",
+		"carry %s yards":       "캐리 %s야드",
+		"carry %s meters":      "캐리 %s미터",
+		"spin %d":              "스핀 %d",
+		"launch %.0f degrees":  "발사각 %.0f도",
+	},
+	LocaleJapanese: {
+		// API request errors
+		"Invalid request body": "リクエスト本文が不正です",
+		"Invalid %s":           "%s が不正です",
+		"Invalid %s value":     "%s の値は使用できません",
+		"Invalid handedness value (must be 'left' or 'right')": "利き手の値が不正です（'left' または 'right' を指定してください）",
+		"Invalid shot id": "ショットIDが不正です",
+		"Invalid from":    "開始日時が不正です",
+		"Invalid to":      "終了日時が不正です",
+		"Invalid after":   "after が不正です",
+		"Invalid limit":   "limit が不正です",
+		"Invalid offset":  "offset が不正です",
+		"expected YYYY-MM-DD or an RFC 3339 time": "YYYY-MM-DD または RFC 3339 形式の日時を指定してください",
+		"Shot not found":                                    "ショットが見つかりません",
+		"Video not found":                                   "動画が見つかりません",
+		"No raw data was kept for this shot":                "このショットの生データは保存されていません",
+		"Video is stored on the camera":                     "動画はカメラに保存されています",
+		"No alignment capture in progress":                  "進行中のアライメントキャプチャはありません",
+		"No ball position available":                        "ボールの位置情報がありません",
+		"External camera feature not enabled":               "外部カメラ機能が有効になっていません",
+		"Simulator is not running":                          "シミュレーターが動作していません",
+		"Origin not allowed":                                "許可されていないオリジンです",
+		"An access token is required":                       "アクセストークンが必要です",
+		"This access token can only view":                   "このアクセストークンは閲覧のみ可能です",
+		"Streaming not supported":                           "ストリーミングはサポートされていません",
+		"simulator is not connected":                        "シミュレーターが接続されていません",
+		"a shot series is already running":                  "ショットシリーズはすでに実行中です",
+		"battery level must be between 0 and 100":           "バッテリー残量は0から100の間で指定してください",
+		"shot count must be between 1 and 50":               "ショット数は1から50の間で指定してください",
+		"interval must be between 0 and 300 seconds":        "間隔は0秒から300秒の間で指定してください",
+		"fault counts must be between 0 and 10000":          "エラー回数は0から10000の間で指定してください",
+		"fault delay must be between 0 and 30000 ms":        "エラー遅延は0から30000msの間で指定してください",
+		"flood count must be between 1 and 10000":           "通知数は1から10000の間で指定してください",
+		"misread shot not found":                            "誤計測ショットが見つかりません",
+		"unknown misread action":                            "不明な誤計測の処理です",
+		"unknown calibration point":                         "不明なキャリブレーションポイントです",
+		"calibration needs origin and target samples":       "キャリブレーションには原点とターゲットのサンプルが必要です",
+		"target samples are too close to the origin":        "ターゲットのサンプルが原点に近すぎます",
+		"need packets captured at two or more angles":       "2つ以上の角度でキャプチャしたパケットが必要です",
+		"no field in the captured packets tracks the angle": "キャプチャしたパケットに角度を示すフィールドがありません",
+
+		// Connection status errors
+		"Failed to initialize Bluetooth":       "Bluetoothを初期化できませんでした",
+		"failed to connect to device":          "デバイスに接続できませんでした",
+		"failed to connect":                    "接続できませんでした",
+		"failed to pair with device":           "デバイスとペアリングできませんでした",
+		"failed to unpair device":              "デバイスのペアリングを解除できませんでした",
+		"pairing not supported":                "このプラットフォームではOSがペアリングを行います",
+		"disconnect before scanning":           "スキャンする前にデバイスを切断してください",
+		"not connected to device":              "デバイスに接続されていません",
+		"failed to enable notifications":       "通知を有効にできませんでした",
+		"failed to subscribe to notifications": "通知を購読できませんでした",
+		"error reading from server":            "サーバーからの読み取り中にエラーが発生しました",
+		"error sending data":                   "データ送信中にエラーが発生しました",
+		"server closed connection":             "サーバーが接続を閉じました",
+		"stale connection":                     "接続が応答していません",
+		"no reply from server":                 "サーバーから応答がありません",
+		"reconnection timeout":                 "再接続がタイムアウトしました",
+		"too many failed attempts":             "失敗した試行が多すぎます",
+		"please reconnect manually":            "手動で再接続してください",
+
+		// Update errors
+		"Update checks are disabled":                           "アップデートの確認は無効です",
+		"installing updates is disabled":                       "アップデートのインストールは無効です",
+		"installing updates is not supported on this platform": "このプラットフォームではアップデートのインストールはサポートされていません",
+		"no update available":                                  "利用可能なアップデートはありません",
+		"failed to install update":                             "アップデートをインストールできませんでした",
+		"failed to roll back update":                           "アップデートを元に戻せませんでした",
+		"no previous version to roll back to":                  "元に戻す以前のバージョンがありません",
+
+		// Settings validation
+		"Invalid settings":                                         "設定が不正です",
+		"has the wrong type":                                       "型が正しくありません",
+		"is not a supported value":                                 "サポートされていない値です",
+		"is out of range":                                          "範囲外です",
+		"must be an IP address or host name":                       "IP アドレスまたはホスト名を指定してください",
+		"must be an IP address":                                    "IP アドレスを指定してください",
+		"must be a port from 1 to 65535":                           "1 から 65535 のポートを指定してください",
+		"must be an http, https or rtsp URL":                       "http、https または rtsp の URL を指定してください",
+		"must be an origin such as http://192.168.1.20:8080, or *": "http://192.168.1.20:8080 のようなオリジンか * を指定してください",
+		"must be a MAC address or device UUID":                     "MAC アドレスまたはデバイス UUID を指定してください",
+		"must be off while Awesome Golf uses GSPro's address":      "Awesome Golf が GSPro と同じアドレスを使う間はオフにしてください",
+
+		// Game errors
+		"unknown game mode":                "不明なゲームモードです",
+		"invalid target range":             "ターゲット距離の範囲が不正です",
+		"invalid number of shots":          "ショット数が不正です",
+		"invalid ladder step or tolerance": "ラダーの間隔または許容範囲が不正です",
+		"no game in progress":              "進行中のゲームはありません",
+
+		// Wedge practice errors
+		"too many clubs":   "クラブが多すぎます",
+		"too many targets": "ターゲット距離が多すぎます",
+
+		// Warmup errors
+		"unknown club": "不明なクラブです",
+
+		// Known device errors
+		"unknown device":          "不明なデバイスです",
+		"nickname already in use": "このニックネームは既に使われています",
+		"nickname too long":       "ニックネームが長すぎます",
+
+		// GSPro resend errors
+		"no shot to resend":   "再送信するショットがありません",
+		"no shots are queued": "待機中のショットはありません",
+		"the last shot has changed, confirm the resend again":       "最後のショットが変わりました。もう一度再送信を確認してください",
+		"the last shot was already resent":                          "最後のショットはすでに再送信されています",
+		"GSPro is not connected":                                    "GSProに接続されていません",
+		"diagnostics are already running":                           "診断はすでに実行中です",
+		"session is paused":                                         "セッションは一時停止中です",
+		"swing stick mode is only supported on the SquareGolf Home": "スイングスティックモードはSquareGolf Homeでのみサポートされています",
+		"command must be hex bytes":                                 "コマンドは16進数のバイトで指定してください",
+		"failed to deactivate ball detection for pause":             "一時停止のためにボール検出をオフにできませんでした",
+		"failed to restore club selection":                          "クラブ選択を復元できませんでした",
+		"Feature %s is turned off":                                  "%s 機能はオフになっています",
+		"unknown feature":                                           "不明な機能",
+
+		// Combine errors
+		"combine is not running":  "進行中のコンバインはありません",
+		"combine is not paused":   "コンバインは一時停止していません",
+		"No combine to report on": "レポートするコンバインがありません",
+
+		// Export errors
+		"failed to read the credentials file":                "認証情報ファイルを読み込めませんでした",
+		"the credentials file is not a service account key":  "認証情報ファイルがサービスアカウントキーではありません",
+		"the service account private key is not PEM encoded": "サービスアカウントの秘密鍵がPEM形式ではありません",
+		"failed to parse the service account private key":    "サービスアカウントの秘密鍵を解析できませんでした",
+		"the service account private key is not an RSA key":  "サービスアカウントの秘密鍵がRSA鍵ではありません",
+		"failed to sign in to Google":                        "Googleへのログインに失敗しました",
+		"Google sign-in failed":                              "Googleへのログインに失敗しました",
+		"Google Sheets read failed":                          "Googleスプレッドシートを読み込めませんでした",
+		"Google Sheets append failed":                        "Googleスプレッドシートに行を追加できませんでした",
+		"invalid S3 endpoint":                                "S3エンドポイントが不正です",
+		"S3 upload failed":                                   "S3へのアップロードに失敗しました",
+		"unknown export destination":                         "不明なエクスポート先です",
+
+		// Misread reasons
+		"invalid ball speed": "ボール初速が不正",
+		"missing spin":       "スピンなし",
+
+		// Voice announcements
+		"%s miles per hour":    "時速%sマイル",
+		"%s meters per second": "秒速%sメートル",
+		"carry %s yards":       "キャリー%sヤード",
+		"carry %s meters":      "キャリー%sメートル",
+		"spin %d":              "スピン%d",
+		"launch %.0f degrees":  "打ち出し角%.0f度",
+		", ":                   "、",
+	},
+}
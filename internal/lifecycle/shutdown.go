@@ -0,0 +1,90 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// step is a named shutdown action
+type step struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Coordinator runs registered shutdown steps once, in reverse registration
+// order like deferred calls, so what started last stops first
+type Coordinator struct {
+	mu    sync.Mutex
+	steps []step
+	once  sync.Once
+	err   error
+	done  chan struct{}
+}
+
+// NewCoordinator creates a coordinator with no steps
+func NewCoordinator() *Coordinator {
+	return &Coordinator{done: make(chan struct{})}
+}
+
+// Register adds a step to run during shutdown
+func (c *Coordinator) Register(name string, fn func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.steps = append(c.steps, step{name: name, fn: fn})
+}
+
+// Shutdown runs every step, newest first, and returns their combined errors.
+// A failing step doesn't stop the rest. A step that outlives ctx is abandoned
+// along with the steps after it. Later calls
+// wait for the first to finish and return the same result.
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	c.once.Do(func() {
+		defer close(c.done)
+
+		c.mu.Lock()
+		steps := append([]step(nil), c.steps...)
+		c.mu.Unlock()
+
+		var errs []error
+		for i := len(steps) - 1; i >= 0; i-- {
+			s := steps[i]
+			log.Printf("Shutdown: %s", s.name)
+
+			result := make(chan error, 1)
+			go func() {
+				result <- s.fn(ctx)
+			}()
+
+			select {
+			case err := <-result:
+				if err != nil {
+					log.Printf("Shutdown: %s failed: %v", s.name, err)
+					errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+				}
+			case <-ctx.Done():
+				log.Printf("Shutdown: %s did not finish in time", s.name)
+				errs = append(errs, fmt.Errorf("%s: %w", s.name, ctx.Err()))
+				c.err = errors.Join(errs...)
+				return
+			}
+		}
+		c.err = errors.Join(errs...)
+		log.Println("Shutdown: complete")
+	})
+
+	<-c.done
+	return c.err
+}
+
+// NotifyOnSignal returns a channel that receives SIGINT and SIGTERM
+func NotifyOnSignal() <-chan os.Signal {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	return sigChan
+}
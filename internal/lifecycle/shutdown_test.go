@@ -0,0 +1,108 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCoordinator_RunsStepsInReverseOrder(t *testing.T) {
+	c := NewCoordinator()
+	var order []string
+	for _, name := range []string{"web server", "history", "device"} {
+		c.Register(name, func(context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if want := []string{"device", "history", "web server"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestCoordinator_FailingStepDoesNotSkipTheRest(t *testing.T) {
+	c := NewCoordinator()
+	failure := errors.New("flush failed")
+	var ran []string
+	c.Register("first", func(context.Context) error {
+		ran = append(ran, "first")
+		return nil
+	})
+	c.Register("failing", func(context.Context) error {
+		ran = append(ran, "failing")
+		return failure
+	})
+	c.Register("last", func(context.Context) error {
+		ran = append(ran, "last")
+		return nil
+	})
+
+	err := c.Shutdown(context.Background())
+	if !errors.Is(err, failure) {
+		t.Errorf("Shutdown() error = %v, want the step's error", err)
+	}
+	if want := []string{"last", "failing", "first"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran = %v, want every step: %v", ran, want)
+	}
+}
+
+func TestCoordinator_HungStepIsBoundedByTheDeadline(t *testing.T) {
+	c := NewCoordinator()
+	release := make(chan struct{})
+	defer close(release)
+	c.Register("abandoned", func(context.Context) error {
+		t.Error("Expected the steps after a hung one to be abandoned")
+		return nil
+	})
+	c.Register("hung", func(context.Context) error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := c.Shutdown(ctx)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown() took %v, want it to return at the deadline", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want the deadline", err)
+	}
+}
+
+func TestCoordinator_ShutdownRunsOnce(t *testing.T) {
+	c := NewCoordinator()
+	calls := 0
+	c.Register("step", func(context.Context) error {
+		calls++
+		return errors.New("failed")
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.Shutdown(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("step ran %d times, want once", calls)
+	}
+	for _, err := range errs {
+		if err == nil || err.Error() != errs[0].Error() {
+			t.Errorf("Shutdown() error = %v, want the first call's %v", err, errs[0])
+		}
+	}
+}
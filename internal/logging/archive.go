@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LogFiles returns the current and rotated log files in the log directory,
+// sorted by name
+func LogFiles() ([]string, error) {
+	entries, err := os.ReadDir(getLogDirectory())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !(strings.HasSuffix(name, ".log") || strings.HasSuffix(name, ".log.gz")) {
+			continue
+		}
+		files = append(files, filepath.Join(getLogDirectory(), name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// WriteArchive writes a zip archive of the log files to w
+func WriteArchive(w io.Writer) error {
+	files, err := LogFiles()
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	for _, path := range files {
+		if err := addToArchive(archive, path); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func addToArchive(archive *zip.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		// Rotation may remove a backup after it was listed
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.Base(path)
+	if strings.HasSuffix(path, ".gz") {
+		header.Method = zip.Store
+	} else {
+		header.Method = zip.Deflate
+	}
+
+	dest, err := archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	// The current log may grow while it is copied; only send what was there
+	if _, err := io.CopyN(dest, file, info.Size()); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
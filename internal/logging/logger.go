@@ -5,12 +5,12 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	apexlog "github.com/apex/log"
 	"github.com/apex/log/handlers/json"
 	"github.com/apex/log/handlers/multi"
 	"github.com/apex/log/handlers/text"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
@@ -20,6 +20,12 @@ var (
 	LogFile string
 	// AppDirName is the name of the application directory
 	AppDirName string
+
+	// fileWriter writes the log file and applies the rotation settings
+	fileWriter *rotatingWriter
+
+	// consoleHandler replaces stdout when set, e.g. by the system log
+	consoleHandler apexlog.Handler
 )
 
 // Fields is a type alias for log.Fields to make it easier to use
@@ -30,6 +36,12 @@ func SetAppName(name string) {
 	AppDirName = name
 }
 
+// SetConsoleHandler sends console output to handler instead of stdout. Call it
+// before Init.
+func SetConsoleHandler(handler apexlog.Handler) {
+	consoleHandler = handler
+}
+
 // getLogDirectory returns the appropriate log directory for the current OS
 func getLogDirectory() string {
 	switch runtime.GOOS {
@@ -74,24 +86,22 @@ func Init() error {
 		return err
 	}
 
-	// Set up log file with rotation
+	// Set up log file with rotation; SetRotation applies the saved settings
 	LogFile = filepath.Join(logsDir, "connector.log")
-	rotator := &lumberjack.Logger{
-		Filename:   LogFile,
-		MaxSize:    5,    // megabytes
-		MaxBackups: 5,    // number of backups to keep
-		MaxAge:     28,   // days
-		Compress:   true, // compress old files
-	}
+	fileWriter = newRotatingWriter(LogFile, DefaultRotation())
 
 	// Create handlers
-	consoleHandler := text.New(os.Stdout)
-	fileHandler := json.New(rotator)
+	var console apexlog.Handler = text.New(os.Stdout)
+	if consoleHandler != nil {
+		console = consoleHandler
+	}
+	fileHandler := json.New(fileWriter)
 
-	// Create multi handler to write to both console and file
+	// Create multi handler to write to the console, the file and live viewers
 	handler := multi.New(
-		consoleHandler,
+		console,
 		fileHandler,
+		stream,
 	)
 
 	// Create logger with our handler
@@ -130,12 +140,34 @@ func (l *stdLogger) Write(p []byte) (n int, err error) {
 		"line": line,
 	})
 
-	// Log the message
-	entry.Info(string(p))
+	// Log the message at the level its wording suggests
+	message := string(p)
+	switch levelOf(message) {
+	case apexlog.ErrorLevel:
+		entry.Error(message)
+	case apexlog.WarnLevel:
+		entry.Warn(message)
+	default:
+		entry.Info(message)
+	}
 
 	return len(p), nil
 }
 
+// levelOf guesses the level of a message written through the standard log
+// package, which has no levels of its own
+func levelOf(message string) apexlog.Level {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "error") || strings.Contains(lower, "failed"):
+		return apexlog.ErrorLevel
+	case strings.Contains(lower, "warning"):
+		return apexlog.WarnLevel
+	default:
+		return apexlog.InfoLevel
+	}
+}
+
 // Info logs an info message
 func Info(msg string) {
 	DefaultLogger.Info(msg)
@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Limits for user-configured rotation
+const (
+	maxRotationSizeMB  = 500
+	maxRotationBackups = 100
+	maxRotationAgeDays = 365
+)
+
+// Rotation controls when the log file is rotated and how many old logs are
+// kept
+type Rotation struct {
+	MaxSizeMB  int  `json:"maxSizeMB"`  // rotate once the file reaches this size
+	MaxBackups int  `json:"maxBackups"` // old logs to keep, 0 to keep all
+	MaxAgeDays int  `json:"maxAgeDays"` // delete old logs after this many days, 0 to keep all
+	Compress   bool `json:"compress"`   // gzip old logs
+	Daily      bool `json:"daily"`      // also rotate at local midnight
+}
+
+// DefaultRotation returns the rotation the connector has always used
+func DefaultRotation() Rotation {
+	return Rotation{MaxSizeMB: 5, MaxBackups: 5, MaxAgeDays: 28, Compress: true}
+}
+
+// Valid reports whether the limits are within range
+func (r Rotation) Valid() bool {
+	return r.MaxSizeMB >= 1 && r.MaxSizeMB <= maxRotationSizeMB &&
+		r.MaxBackups >= 0 && r.MaxBackups <= maxRotationBackups &&
+		r.MaxAgeDays >= 0 && r.MaxAgeDays <= maxRotationAgeDays
+}
+
+// rotatingWriter writes to the log file and lets the rotation be changed
+// while the logger is in use
+type rotatingWriter struct {
+	mu       sync.Mutex
+	filename string
+	rotation Rotation
+	file     *lumberjack.Logger
+	stop     chan struct{}
+}
+
+func newRotatingWriter(filename string, rotation Rotation) *rotatingWriter {
+	w := &rotatingWriter{filename: filename}
+	w.apply(rotation)
+	return w
+}
+
+// Write implements io.Writer
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Write(p)
+}
+
+// apply swaps in a file with the new limits and starts or stops daily
+// rotation
+func (w *rotatingWriter) apply(rotation Rotation) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file != nil {
+		w.file.Close()
+	}
+	w.rotation = rotation
+	w.file = &lumberjack.Logger{
+		Filename:   w.filename,
+		MaxSize:    rotation.MaxSizeMB,
+		MaxBackups: rotation.MaxBackups,
+		MaxAge:     rotation.MaxAgeDays,
+		Compress:   rotation.Compress,
+		LocalTime:  true,
+	}
+
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+	if rotation.Daily {
+		w.stop = make(chan struct{})
+		go w.rotateDaily(w.stop)
+	}
+}
+
+// rotateDaily rotates the log at each local midnight until stop is closed
+func (w *rotatingWriter) rotateDaily(stop chan struct{}) {
+	for {
+		now := time.Now()
+		midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+		timer := time.NewTimer(midnight.Sub(now))
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			w.mu.Lock()
+			err := w.file.Rotate()
+			w.mu.Unlock()
+			if err != nil {
+				DefaultLogger.WithError(err).Error("Failed to rotate log file")
+			}
+		}
+	}
+}
+
+// SetRotation changes when the log file is rotated and how many old logs are
+// kept. It does nothing before Init.
+func SetRotation(rotation Rotation) {
+	if fileWriter == nil {
+		return
+	}
+	fileWriter.apply(rotation)
+}
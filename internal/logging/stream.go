@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	apexlog "github.com/apex/log"
+)
+
+const (
+	// recentLines is how many log lines a new subscriber is sent first
+	recentLines = 200
+	// subscriberBuffer is how many lines a slow subscriber may fall behind
+	// before lines are dropped for it
+	subscriberBuffer = 256
+)
+
+// Line is one log entry sent to live viewers
+type Line struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Subscription receives log lines at or above a level
+type Subscription struct {
+	Lines  <-chan Line
+	Recent []Line // lines logged before subscribing, oldest first
+
+	ch    chan Line
+	level apexlog.Level
+}
+
+// Close stops delivery to the subscription
+func (s *Subscription) Close() {
+	stream.remove(s)
+}
+
+// streamHandler keeps recent log lines and fans new ones out to subscribers
+type streamHandler struct {
+	mu          sync.Mutex
+	recent      []Line
+	levels      []apexlog.Level
+	subscribers map[*Subscription]bool
+}
+
+var stream = &streamHandler{subscribers: make(map[*Subscription]bool)}
+
+// HandleLog implements apexlog.Handler
+func (h *streamHandler) HandleLog(e *apexlog.Entry) error {
+	line := Line{Time: e.Timestamp, Level: e.Level.String(), Message: e.Message}
+	if len(e.Fields) > 0 {
+		line.Fields = make(map[string]interface{}, len(e.Fields))
+		for k, v := range e.Fields {
+			line.Fields[k] = v
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.recent = append(h.recent, line)
+	h.levels = append(h.levels, e.Level)
+	if len(h.recent) > recentLines {
+		h.recent = h.recent[len(h.recent)-recentLines:]
+		h.levels = h.levels[len(h.levels)-recentLines:]
+	}
+
+	for sub := range h.subscribers {
+		if e.Level < sub.level {
+			continue
+		}
+		select {
+		case sub.ch <- line:
+		default:
+		}
+	}
+	return nil
+}
+
+func (h *streamHandler) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[sub] {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Subscribe returns the recent log lines at or above level and a channel of
+// new ones. An empty level subscribes to everything logged.
+func Subscribe(level string) (*Subscription, error) {
+	minLevel := apexlog.DebugLevel
+	if level != "" {
+		parsed, err := apexlog.ParseLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		minLevel = parsed
+	}
+
+	ch := make(chan Line, subscriberBuffer)
+	sub := &Subscription{Lines: ch, ch: ch, level: minLevel}
+
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	for i, line := range stream.recent {
+		if stream.levels[i] >= minLevel {
+			sub.Recent = append(sub.Recent, line)
+		}
+	}
+	stream.subscribers[sub] = true
+	return sub, nil
+}
@@ -0,0 +1,726 @@
+package mockgspro
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/app"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/gspro"
+	"github.com/brentyates/squaregolf-connector/internal/core/simulator"
+)
+
+const pipelineTimeout = 5 * time.Second
+
+// pipeline is a simulated device wired through the app's services to a mock
+// GSPro server
+type pipeline struct {
+	sim   *core.SimulatorBluetoothClient
+	app   *app.App
+	gspro *Server
+}
+
+func newPipeline(t *testing.T) *pipeline {
+	t.Helper()
+
+	server := New()
+	if err := server.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	host, port := server.Addr()
+
+	sim := core.NewSimulatorBluetoothClient(core.SimulatorConfig{})
+	sim.SetManual(true)
+	application := app.New(app.Config{Client: sim, GSProIP: host, GSProPort: port})
+
+	p := &pipeline{sim: sim, app: application, gspro: server}
+	t.Cleanup(func() {
+		application.GSPro.Shutdown()
+		application.Bluetooth.DisconnectBluetooth()
+		server.Stop()
+	})
+
+	application.Bluetooth.StartBluetoothConnection("SquareGolf(****)", "")
+	p.waitFor(t, "the device to connect", func() bool {
+		return application.State.GetConnectionStatus() == core.ConnectionStatusConnected
+	})
+
+	application.GSPro.Start()
+	if err := server.WaitForConnection(pipelineTimeout); err != nil {
+		t.Fatal(err)
+	}
+	// The integration resets its shot numbers once the connection settles
+	p.waitFor(t, "GSPro to connect", func() bool {
+		return application.State.GetGSProStatus() == core.GSProStatusConnected
+	})
+	return p
+}
+
+func (p *pipeline) waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(pipelineTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPipeline_PlayerInfoSelectsClubAndActivatesDetection(t *testing.T) {
+	p := newPipeline(t)
+
+	if err := p.gspro.SetPlayer("I7", "LH"); err != nil {
+		t.Fatalf("SetPlayer() error = %v", err)
+	}
+
+	p.waitFor(t, "the club to change", func() bool {
+		club := p.app.State.GetClub()
+		return club != nil && *club == core.ClubIron7
+	})
+	p.waitFor(t, "left-handed to be selected", func() bool {
+		handedness := p.app.State.GetHandedness()
+		return handedness != nil && *handedness == core.LeftHanded
+	})
+	p.waitFor(t, "ball detection to be activated", func() bool {
+		return p.sim.ControlStatus().DeviceState == core.DeviceStateBallDetection
+	})
+}
+
+func TestPipeline_BallReadySendsReadinessUpdate(t *testing.T) {
+	p := newPipeline(t)
+
+	if err := p.gspro.SendReady(); err != nil {
+		t.Fatalf("SendReady() error = %v", err)
+	}
+	p.waitFor(t, "ball detection to be activated", func() bool {
+		return p.sim.ControlStatus().DeviceState == core.DeviceStateBallDetection
+	})
+
+	if err := p.sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+
+	update, _, err := p.gspro.WaitForMessage(pipelineTimeout, 0, func(shot gspro.ShotData) bool {
+		return shot.ShotDataOptions.LaunchMonitorIsReady
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.ShotDataOptions.ContainsBallData || update.ShotDataOptions.ContainsClubData {
+		t.Errorf("readiness update should carry no shot data, got %+v", update.ShotDataOptions)
+	}
+	if !update.ShotDataOptions.LaunchMonitorBallDetected {
+		t.Error("readiness update should report the ball detected")
+	}
+}
+
+func TestPipeline_ShotReachesGSPro(t *testing.T) {
+	p := newPipeline(t)
+
+	if err := p.sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+
+	shot := &core.SimulatedShot{
+		BallSpeedMPS:    60,
+		VerticalAngle:   14.5,
+		HorizontalAngle: -2.25,
+		TotalspinRPM:    3000,
+		SpinAxis:        5,
+		BackspinRPM:     2989,
+		SidespinRPM:     261,
+		Club:            &core.SimulatedClub{PathAngle: 3.5, FaceAngle: -1.5, AttackAngle: -4, DynamicLoftAngle: 18},
+	}
+	if err := p.sim.InjectShot(shot); err != nil {
+		t.Fatalf("InjectShot() error = %v", err)
+	}
+
+	ball, index, err := p.gspro.WaitForMessage(pipelineTimeout, 0, func(shot gspro.ShotData) bool {
+		return shot.ShotDataOptions.ContainsBallData
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ball.ShotNumber != 1 {
+		t.Errorf("ShotNumber = %d, want 1", ball.ShotNumber)
+	}
+	if math.Abs(ball.BallData.Speed-60*2.23694) > 0.05 {
+		t.Errorf("Speed = %.2f mph, want %.2f", ball.BallData.Speed, 60*2.23694)
+	}
+	if ball.BallData.VLA != 14.5 || ball.BallData.HLA != -2.25 {
+		t.Errorf("VLA/HLA = %v/%v, want 14.5/-2.25", ball.BallData.VLA, ball.BallData.HLA)
+	}
+	if ball.BallData.TotalSpin != 3000 || ball.BallData.BackSpin != 2989 {
+		t.Errorf("TotalSpin/BackSpin = %d/%d, want 3000/2989", ball.BallData.TotalSpin, ball.BallData.BackSpin)
+	}
+	// GSPro's spin axis and side spin are mirrored from the device's
+	if ball.BallData.SpinAxis != -5 || ball.BallData.SideSpin != -261 {
+		t.Errorf("SpinAxis/SideSpin = %v/%d, want -5/-261", ball.BallData.SpinAxis, ball.BallData.SideSpin)
+	}
+
+	club, _, err := p.gspro.WaitForMessage(pipelineTimeout, index+1, func(shot gspro.ShotData) bool {
+		return shot.ShotDataOptions.ContainsClubData
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if club.ShotNumber != 1 {
+		t.Errorf("club ShotNumber = %d, want 1", club.ShotNumber)
+	}
+	if club.ClubData.Path != 3.5 || club.ClubData.FaceToTarget != -1.5 || club.ClubData.AngleOfAttack != -4 || club.ClubData.Loft != 18 {
+		t.Errorf("ClubData = %+v", club.ClubData)
+	}
+
+	if acks := p.gspro.Acks(); acks != 2 {
+		t.Errorf("Acks() = %d, want 2 (ball and club data)", acks)
+	}
+}
+
+func TestPipeline_ShotNumbersIncrease(t *testing.T) {
+	p := newPipeline(t)
+
+	for want := 1; want <= 2; want++ {
+		if err := p.sim.ReadyBall(); err != nil {
+			t.Fatalf("ReadyBall() error = %v", err)
+		}
+		if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: 40 + float64(want), VerticalAngle: 20, TotalspinRPM: 6000, BackspinRPM: 6000}); err != nil {
+			t.Fatalf("InjectShot() error = %v", err)
+		}
+
+		ball, _, err := p.gspro.WaitForMessage(pipelineTimeout, 0, func(shot gspro.ShotData) bool {
+			return shot.ShotDataOptions.ContainsBallData && shot.ShotNumber == want
+		})
+		if err != nil {
+			t.Fatalf("shot %d: %v", want, err)
+		}
+		if math.Abs(ball.BallData.Speed-(40+float64(want))*2.23694) > 0.05 {
+			t.Errorf("shot %d: Speed = %.2f", want, ball.BallData.Speed)
+		}
+	}
+}
+
+func TestPipeline_FailsOverToStandby(t *testing.T) {
+	standby := New()
+	if err := standby.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	standbyHost, standbyPort := standby.Addr()
+
+	// Nothing listens on the primary once its listener is closed
+	unused, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	primaryPort := unused.Addr().(*net.TCPAddr).Port
+	unused.Close()
+
+	application := app.New(app.Config{Client: core.NewSimulatorBluetoothClient(core.SimulatorConfig{}), GSProIP: "127.0.0.1", GSProPort: primaryPort})
+	t.Cleanup(func() {
+		application.GSPro.Shutdown()
+		standby.Stop()
+	})
+
+	integration := application.GSPro
+	integration.SetStandby(standbyHost, standbyPort)
+	// Start as though the primary has used up its retries
+	integration.ReconnectAttempts = simulator.MaxFailedAttempts
+	integration.Start()
+
+	if err := standby.WaitForConnection(pipelineTimeout); err != nil {
+		t.Fatal(err)
+	}
+	primary, _, failedOver := integration.FailoverState()
+	if !failedOver {
+		t.Error("FailoverState() failedOver = false, want true")
+	}
+	if primary.Port != primaryPort {
+		t.Errorf("primary port = %d, want %d", primary.Port, primaryPort)
+	}
+	if host, port := integration.GetConnectionInfo(); host != standbyHost || port != standbyPort {
+		t.Errorf("GetConnectionInfo() = %s:%d, want the standby %s:%d", host, port, standbyHost, standbyPort)
+	}
+
+	// A manual reconnect goes back to the primary
+	integration.ResetReconnectionState()
+	if _, _, failedOver := integration.FailoverState(); failedOver {
+		t.Error("FailoverState() failedOver = true after reset, want false")
+	}
+}
+
+func TestPipeline_RecordsGSProTraffic(t *testing.T) {
+	p := newPipeline(t)
+	traffic := p.app.GSPro.Traffic
+	traffic.SetEnabled(true)
+
+	if err := p.sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+	if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: 50, VerticalAngle: 18, TotalspinRPM: 5000, BackspinRPM: 5000}); err != nil {
+		t.Fatalf("InjectShot() error = %v", err)
+	}
+	if _, _, err := p.gspro.WaitForMessage(pipelineTimeout, 0, func(shot gspro.ShotData) bool {
+		return shot.ShotDataOptions.ContainsBallData
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	p.waitFor(t, "the shot and its acknowledgement to be recorded", func() bool {
+		var sentShot, received bool
+		for _, entry := range traffic.Last(0) {
+			var message map[string]interface{}
+			if err := json.Unmarshal(entry.Message, &message); err != nil {
+				t.Fatalf("recorded message %s is not JSON: %v", entry.Message, err)
+			}
+			switch entry.Direction {
+			case simulator.DirectionSent:
+				sentShot = sentShot || message["BallData"] != nil
+			case simulator.DirectionReceived:
+				received = true
+			}
+		}
+		return sentShot && received
+	})
+
+	if last := traffic.Last(1); len(last) != 1 {
+		t.Errorf("Last(1) returned %d entries, want 1", len(last))
+	}
+}
+
+func TestPipeline_ShotCarriesPayloadIdentity(t *testing.T) {
+	p := newPipeline(t)
+	firmware := "1.6.18"
+	p.app.State.SetFirmwareVersion(&firmware)
+	p.app.GSPro.SetPayloadIdentity(gspro.PayloadIdentity{DeviceID: "SquareGolf Connector", Units: gspro.UnitsMeters, APIVersion: "1", IncludeFirmware: true})
+
+	if err := p.sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+	if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: 50, VerticalAngle: 18, TotalspinRPM: 5000, BackspinRPM: 5000}); err != nil {
+		t.Fatalf("InjectShot() error = %v", err)
+	}
+
+	ball, _, err := p.gspro.WaitForMessage(pipelineTimeout, 0, func(shot gspro.ShotData) bool {
+		return shot.ShotDataOptions.ContainsBallData
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ball.DeviceID != "SquareGolf Connector (firmware 1.6.18)" || ball.Units != gspro.UnitsMeters || ball.APIversion != "1" {
+		t.Errorf("DeviceID/Units/APIversion = %q/%q/%q", ball.DeviceID, ball.Units, ball.APIversion)
+	}
+}
+
+func TestPipeline_ShotNumberPersistsAndResets(t *testing.T) {
+	p := newPipeline(t)
+	path := filepath.Join(t.TempDir(), "gspro_shot_number.json")
+	if err := os.WriteFile(path, []byte(`{"shotNumber":41}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.app.GSPro.SetShotNumberPath(path); err != nil {
+		t.Fatalf("SetShotNumberPath() error = %v", err)
+	}
+
+	injectShot := func(speed float64) {
+		t.Helper()
+		if err := p.sim.ReadyBall(); err != nil {
+			t.Fatalf("ReadyBall() error = %v", err)
+		}
+		if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: speed, VerticalAngle: 18, TotalspinRPM: 5000, BackspinRPM: 5000}); err != nil {
+			t.Fatalf("InjectShot() error = %v", err)
+		}
+	}
+	waitForBall := func(skip int) (gspro.ShotData, int) {
+		t.Helper()
+		ball, index, err := p.gspro.WaitForMessage(pipelineTimeout, skip, func(shot gspro.ShotData) bool {
+			return shot.ShotDataOptions.ContainsBallData
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ball, index
+	}
+
+	injectShot(50)
+	ball, index := waitForBall(0)
+	if ball.ShotNumber != 42 {
+		t.Errorf("ShotNumber = %d, want 42 after the saved 41", ball.ShotNumber)
+	}
+
+	// Publishing the same shot again must not count a new shot
+	shot := p.app.State.GetLastBallMetrics()
+	p.app.State.SetLastBallMetrics(nil)
+	p.app.State.SetLastBallMetrics(shot)
+	if resent, _ := waitForBall(index + 1); resent.ShotNumber != 42 {
+		t.Errorf("resent ShotNumber = %d, want 42", resent.ShotNumber)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != `{"shotNumber":42}` {
+		t.Errorf("saved shot number = %s (%v), want 42", data, err)
+	}
+
+	p.app.GSPro.ResetShotNumber()
+	received := len(p.gspro.Received())
+	injectShot(51)
+	if ball, _ := waitForBall(received); ball.ShotNumber != 1 {
+		t.Errorf("ShotNumber after reset = %d, want 1", ball.ShotNumber)
+	}
+}
+
+func TestPipeline_ResendLastShot(t *testing.T) {
+	p := newPipeline(t)
+
+	if _, err := p.app.GSPro.LastShot(); !errors.Is(err, gspro.ErrNoShotToResend) {
+		t.Fatalf("LastShot() before any shot error = %v, want ErrNoShotToResend", err)
+	}
+
+	if err := p.sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+	if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: 45, VerticalAngle: 16, TotalspinRPM: 4000, BackspinRPM: 4000, Club: &core.SimulatedClub{PathAngle: 3.5}}); err != nil {
+		t.Fatalf("InjectShot() error = %v", err)
+	}
+	_, index, err := p.gspro.WaitForMessage(pipelineTimeout, 0, func(shot gspro.ShotData) bool {
+		return shot.ShotDataOptions.ContainsClubData && !shot.ShotDataOptions.ContainsBallData
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var last gspro.LastShot
+	p.waitFor(t, "the club data to be recorded", func() bool {
+		last, err = p.app.GSPro.LastShot()
+		return err == nil && last.ClubData != nil
+	})
+	if last.ShotNumber != 1 || last.Token == "" {
+		t.Fatalf("LastShot() = %+v, want shot 1 with a token", last)
+	}
+
+	if _, err := p.app.GSPro.ResendLastShot("stale"); !errors.Is(err, gspro.ErrResendTokenMismatch) {
+		t.Errorf("ResendLastShot(wrong token) error = %v, want ErrResendTokenMismatch", err)
+	}
+
+	shotNumber, err := p.app.GSPro.ResendLastShot(last.Token)
+	if err != nil {
+		t.Fatalf("ResendLastShot() error = %v", err)
+	}
+	if shotNumber != 2 {
+		t.Errorf("ResendLastShot() = %d, want 2", shotNumber)
+	}
+	resent, _, err := p.gspro.WaitForMessage(pipelineTimeout, index+1, func(shot gspro.ShotData) bool {
+		return shot.ShotDataOptions.ContainsBallData
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resent.ShotNumber != 2 || !resent.ShotDataOptions.ContainsClubData || resent.ClubData == nil || resent.ClubData.Path != 3.5 {
+		t.Errorf("resent shot = %+v, want shot 2 with ball and club data", resent)
+	}
+	if math.Abs(resent.BallData.Speed-45*2.23694) > 0.05 {
+		t.Errorf("resent Speed = %.2f", resent.BallData.Speed)
+	}
+
+	if _, err := p.app.GSPro.ResendLastShot(last.Token); !errors.Is(err, gspro.ErrShotAlreadyResent) {
+		t.Errorf("second ResendLastShot() error = %v, want ErrShotAlreadyResent", err)
+	}
+}
+
+func TestPipeline_AuditsShotsSentToGSPro(t *testing.T) {
+	p := newPipeline(t)
+
+	if err := p.sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+	if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: 50, VerticalAngle: 15, TotalspinRPM: 3500, BackspinRPM: 3500, Club: &core.SimulatedClub{PathAngle: 1}}); err != nil {
+		t.Fatalf("InjectShot() error = %v", err)
+	}
+
+	var audits []gspro.ShotAudit
+	p.waitFor(t, "the ball and club data to be acknowledged", func() bool {
+		audits = p.app.GSPro.Audit(0)
+		return len(audits) == 1 && len(audits[0].Attempts) == 2 && audits[0].Attempts[1].AckedAt != nil
+	})
+	audit := audits[0]
+	if audit.ShotNumber != 1 || !audit.Sent || !audit.Acknowledged || audit.Retries != 0 || audit.Skipped != "" {
+		t.Fatalf("audit = %+v, want shot 1 sent and acknowledged", audit)
+	}
+	ball, club := audit.Attempts[0], audit.Attempts[1]
+	if ball.Kind != gspro.AuditKindBall || club.Kind != gspro.AuditKindClub {
+		t.Errorf("attempt kinds = %s, %s, want ball then club", ball.Kind, club.Kind)
+	}
+	var sent gspro.ShotData
+	if err := json.Unmarshal(ball.Payload, &sent); err != nil || sent.ShotNumber != 1 || !sent.ShotDataOptions.ContainsBallData {
+		t.Errorf("ball payload = %s, want the ball data sent as shot 1", ball.Payload)
+	}
+	var response gspro.Message
+	if err := json.Unmarshal(club.Response, &response); err != nil || response.Message != MessageShotReceived {
+		t.Errorf("club response = %s, want %q", club.Response, MessageShotReceived)
+	}
+
+	last, err := p.app.GSPro.LastShot()
+	if err != nil {
+		t.Fatalf("LastShot() error = %v", err)
+	}
+	if _, err := p.app.GSPro.ResendLastShot(last.Token); err != nil {
+		t.Fatalf("ResendLastShot() error = %v", err)
+	}
+	p.waitFor(t, "the resend to be audited", func() bool {
+		audits = p.app.GSPro.Audit(0)
+		return len(audits) == 1 && len(audits[0].Attempts) == 3
+	})
+	if resend := audits[0].Attempts[2]; resend.Kind != gspro.AuditKindResend || resend.ShotNumber != 2 || audits[0].Retries != 1 {
+		t.Errorf("audit after resend = %+v, want a resend as shot 2 counted as a retry", audits[0])
+	}
+
+	// A shot hit while GSPro is away is recorded as not sent
+	p.app.GSPro.DisableAutoReconnect()
+	p.app.GSPro.Disconnect()
+	if err := p.sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+	if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: 40, VerticalAngle: 20, TotalspinRPM: 6000, BackspinRPM: 6000}); err != nil {
+		t.Fatalf("InjectShot() error = %v", err)
+	}
+	p.waitFor(t, "the missed shot to be audited", func() bool {
+		audits = p.app.GSPro.Audit(1)
+		return len(audits) == 1 && audits[0].Skipped != ""
+	})
+	if audits[0].Sent || len(audits[0].Attempts) != 0 {
+		t.Errorf("missed shot audit = %+v, want nothing sent", audits[0])
+	}
+}
+
+func TestPipeline_QueuesShotsWhileGSProReconnects(t *testing.T) {
+	p := newPipeline(t)
+	p.app.GSPro.SetRetryQueue(gspro.RetryQueueSettings{Depth: 2})
+
+	p.gspro.DropConnection()
+	p.waitFor(t, "GSPro to drop", func() bool {
+		return p.app.State.GetGSProStatus() != core.GSProStatusConnected
+	})
+
+	for i := 1; i <= 3; i++ {
+		if err := p.sim.ReadyBall(); err != nil {
+			t.Fatalf("ReadyBall() error = %v", err)
+		}
+		if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: 40 + float64(i), VerticalAngle: 18, TotalspinRPM: 5000, BackspinRPM: 5000, Club: &core.SimulatedClub{PathAngle: float64(i)}}); err != nil {
+			t.Fatalf("InjectShot() error = %v", err)
+		}
+		p.waitFor(t, "the shot to be audited", func() bool {
+			return len(p.app.GSPro.Audit(0)) == i
+		})
+	}
+	// The third shot didn't fit in the queue
+	queue := p.app.GSPro.Queue()
+	if len(queue.Shots) != 2 || queue.Shots[1].ClubData == nil || queue.Shots[1].ClubData.Path != 2 {
+		t.Fatalf("Queue() = %+v, want the first two shots with their club data", queue)
+	}
+	if audits := p.app.GSPro.Audit(0); audits[2].Skipped != "GSPro not connected" {
+		t.Errorf("third shot audit = %+v, want it skipped", audits[2])
+	}
+
+	// The integration reconnects by itself after its backoff
+	if err := p.gspro.WaitForConnection(3 * pipelineTimeout); err != nil {
+		t.Fatal(err)
+	}
+	p.waitFor(t, "GSPro to reconnect", func() bool {
+		return p.app.State.GetGSProStatus() == core.GSProStatusConnected
+	})
+	skip := len(p.gspro.Received())
+
+	// Queued shots go one at a time as GSPro asks for a shot
+	for want := 1; want <= 2; want++ {
+		if err := p.gspro.SendReady(); err != nil {
+			t.Fatalf("SendReady() error = %v", err)
+		}
+		shot, index, err := p.gspro.WaitForMessage(pipelineTimeout, skip, func(shot gspro.ShotData) bool {
+			return shot.ShotDataOptions.ContainsBallData
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shot.ShotNumber != want || !shot.ShotDataOptions.ContainsClubData || shot.ClubData.Path != float64(want) {
+			t.Errorf("replayed shot = %+v, want shot %d with its club data", shot, want)
+		}
+		skip = index + 1
+	}
+	if queued := len(p.app.GSPro.Queue().Shots); queued != 0 {
+		t.Errorf("Queue() has %d shots after replaying, want 0", queued)
+	}
+}
+
+func TestPipeline_TracksPlayersInAMultiplayerRound(t *testing.T) {
+	p := newPipeline(t)
+
+	playerUp := func(name string) func() bool {
+		return func() bool {
+			player := p.app.State.GetPlayerName()
+			return player != nil && *player == name
+		}
+	}
+	handedness := func(want core.HandednessType) func() bool {
+		return func() bool {
+			handedness := p.app.State.GetHandedness()
+			return handedness != nil && *handedness == want
+		}
+	}
+
+	if err := p.gspro.SetNamedPlayer("Alice", "DR", "RH"); err != nil {
+		t.Fatalf("SetNamedPlayer() error = %v", err)
+	}
+	p.waitFor(t, "Alice to be up", playerUp("Alice"))
+
+	if err := p.gspro.SetNamedPlayer("Bob", "I7", "LH"); err != nil {
+		t.Fatalf("SetNamedPlayer() error = %v", err)
+	}
+	p.waitFor(t, "Bob to be up", playerUp("Bob"))
+	p.waitFor(t, "left-handed to be selected for Bob", handedness(core.LeftHanded))
+
+	// GSPro doesn't repeat Alice's club and handedness; the ones seen are kept
+	if err := p.gspro.SetNamedPlayer("Alice", "", ""); err != nil {
+		t.Fatalf("SetNamedPlayer() error = %v", err)
+	}
+	p.waitFor(t, "Alice to be up again", playerUp("Alice"))
+	p.waitFor(t, "right-handed to be restored for Alice", handedness(core.RightHanded))
+	p.waitFor(t, "Alice's driver to be restored", func() bool {
+		club := p.app.State.GetClub()
+		return club != nil && *club == core.ClubDriver
+	})
+
+	players, current := p.app.GSPro.Players()
+	if current != "Alice" || len(players) != 2 || players[1].Name != "Bob" || players[1].Handed != "LH" {
+		t.Errorf("Players() = %+v, %q; want Alice up and Bob left-handed", players, current)
+	}
+}
+
+func TestPipeline_ResumesTheRoundWhenGSProReconnects(t *testing.T) {
+	p := newPipeline(t)
+
+	if err := p.gspro.SetNamedPlayer("Alice", "I7", "LH"); err != nil {
+		t.Fatalf("SetNamedPlayer() error = %v", err)
+	}
+	p.waitFor(t, "ball detection to be activated", func() bool {
+		return p.sim.ControlStatus().DeviceState == core.DeviceStateBallDetection
+	})
+
+	p.gspro.DropConnection()
+	p.waitFor(t, "GSPro to drop", func() bool {
+		return p.app.State.GetGSProStatus() != core.GSProStatusConnected
+	})
+	// The device goes idle and the handedness is changed while GSPro is away
+	p.sim.SetDeviceState(core.DeviceStateIdle)
+	rightHanded := core.RightHanded
+	p.app.State.SetHandedness(&rightHanded)
+
+	// GSPro neither repeats the player nor asks for a shot after reconnecting
+	if err := p.gspro.WaitForConnection(3 * pipelineTimeout); err != nil {
+		t.Fatal(err)
+	}
+	p.waitFor(t, "ball detection to be activated again", func() bool {
+		return p.sim.ControlStatus().DeviceState == core.DeviceStateBallDetection
+	})
+	p.waitFor(t, "Alice's handedness to be restored", func() bool {
+		handedness := p.app.State.GetHandedness()
+		return handedness != nil && *handedness == core.LeftHanded
+	})
+
+	players, current := p.app.GSPro.Players()
+	if current != "Alice" || len(players) != 1 || players[0].Club != "I7" {
+		t.Errorf("Players() = %+v, %q; want Alice up with the 7 iron", players, current)
+	}
+	if player := p.app.State.GetPlayerName(); player == nil || *player != "Alice" {
+		t.Errorf("player name = %v, want Alice", player)
+	}
+}
+
+func TestPipeline_CyclesAStaleConnection(t *testing.T) {
+	p := newPipeline(t)
+
+	recovered := make(chan simulator.StaleConnection, 1)
+	p.app.GSPro.OnStaleConnectionRecovered(func(stale simulator.StaleConnection) {
+		recovered <- stale
+	})
+	p.app.GSPro.SetStallTimeout(time.Second)
+	p.gspro.SetSilent(true)
+
+	if err := p.sim.ReadyBall(); err != nil {
+		t.Fatalf("ReadyBall() error = %v", err)
+	}
+	if err := p.sim.InjectShot(&core.SimulatedShot{BallSpeedMPS: 40, VerticalAngle: 20, TotalspinRPM: 6000, BackspinRPM: 6000}); err != nil {
+		t.Fatalf("InjectShot() error = %v", err)
+	}
+
+	// The connection is cycled once the shot goes unanswered, then
+	// reconnects after the backoff
+	select {
+	case stale := <-recovered:
+		if stale.Simulator != "GSPro" || stale.SilentFor < 1 || stale.RecoveredAt.Before(stale.DetectedAt) {
+			t.Errorf("stale connection = %+v", stale)
+		}
+	case <-time.After(3 * pipelineTimeout):
+		t.Fatal("timed out waiting for the stale connection to be recovered")
+	}
+	p.waitFor(t, "GSPro to reconnect", func() bool {
+		return p.app.State.GetGSProStatus() == core.GSProStatusConnected
+	})
+}
+
+func TestPipeline_ShotSeriesStepsUpAndCancels(t *testing.T) {
+	p := newPipeline(t)
+
+	series := core.ShotSeries{
+		Start:           core.SimulatedShot{BallSpeedMPS: 40, VerticalAngle: 20, TotalspinRPM: 6000, BackspinRPM: 6000},
+		Step:            core.SimulatedShot{BallSpeedMPS: 5, TotalspinRPM: 500, BackspinRPM: 500},
+		Count:           3,
+		IntervalSeconds: 0.1,
+	}
+	if err := p.sim.StartSeries(series); err != nil {
+		t.Fatalf("StartSeries() error = %v", err)
+	}
+	if err := p.sim.StartSeries(series); err != core.ErrShotSeriesRunning {
+		t.Errorf("second StartSeries() error = %v, want ErrShotSeriesRunning", err)
+	}
+
+	skip := 0
+	for i := 0; i < 3; i++ {
+		ball, index, err := p.gspro.WaitForMessage(pipelineTimeout, skip, func(shot gspro.ShotData) bool {
+			return shot.ShotDataOptions.ContainsBallData
+		})
+		if err != nil {
+			t.Fatalf("shot %d: %v", i+1, err)
+		}
+		skip = index + 1
+		if want := (40 + 5*float64(i)) * 2.23694; math.Abs(ball.BallData.Speed-want) > 0.05 {
+			t.Errorf("shot %d: Speed = %.2f, want %.2f", i+1, ball.BallData.Speed, want)
+		}
+		if want := int16(6000 + 500*i); ball.BallData.TotalSpin != want {
+			t.Errorf("shot %d: TotalSpin = %d, want %d", i+1, ball.BallData.TotalSpin, want)
+		}
+	}
+	p.waitFor(t, "the series to end", func() bool { return !p.sim.SeriesStatus().Running })
+	if status := p.sim.SeriesStatus(); status.Sent != 3 || status.Cancelled || status.Error != "" {
+		t.Errorf("SeriesStatus() = %+v, want all 3 shots sent", status)
+	}
+	if !p.sim.ControlStatus().Manual {
+		t.Error("the simulator should stay in the manual mode it started in")
+	}
+
+	// A long interval leaves time to cancel after the first shot
+	series.IntervalSeconds = 60
+	if err := p.sim.StartSeries(series); err != nil {
+		t.Fatalf("StartSeries() error = %v", err)
+	}
+	p.waitFor(t, "the first shot", func() bool { return p.sim.SeriesStatus().Sent == 1 })
+	p.sim.CancelSeries()
+	p.waitFor(t, "the series to stop", func() bool { return !p.sim.SeriesStatus().Running })
+	if status := p.sim.SeriesStatus(); status.Sent != 1 || !status.Cancelled {
+		t.Errorf("SeriesStatus() = %+v, want cancelled after 1 shot", status)
+	}
+}
@@ -0,0 +1,313 @@
+// Package mockgspro is a stand-in for GSPro's Open Connect API, for tests
+// that run shots through the whole pipeline and check what GSPro receives.
+package mockgspro
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core/gspro"
+)
+
+// Response codes GSPro sends to the launch monitor
+const (
+	CodeShotReceived = 200
+	CodePlayerInfo   = 201
+	CodeReady        = 202
+)
+
+// Messages GSPro sends to the launch monitor
+const (
+	MessageReady        = "GSPro ready"
+	MessagePlayerInfo   = "GSPro Player Information"
+	MessageBallReceived = "Ball Data received"
+	MessageShotReceived = "Club & Ball Data received"
+)
+
+// ErrNotConnected is returned when a message is sent before a launch monitor
+// has connected
+var ErrNotConnected = errors.New("no launch monitor connected")
+
+// Response is a message from GSPro to the launch monitor
+type Response struct {
+	Code    int           `json:"Code"`
+	Message string        `json:"Message"`
+	Player  *gspro.Player `json:"Player,omitempty"`
+}
+
+// Server accepts one launch monitor connection at a time, acks its shots and
+// records every message it sends
+type Server struct {
+	mu       sync.Mutex
+	listener net.Listener
+	conn     net.Conn
+	player   *gspro.Player
+	received []gspro.ShotData
+	acks     int
+	silent   bool // stops acknowledging shots, like a GSPro that stopped reading
+	changed  chan struct{}
+	wg       sync.WaitGroup
+}
+
+// New creates a server. Call Start to begin listening.
+func New() *Server {
+	return &Server{changed: make(chan struct{})}
+}
+
+// Start listens on address, e.g. "127.0.0.1:0" for any free port
+func (s *Server) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.acceptLoop(listener)
+	return nil
+}
+
+// Addr returns the host and port the server is listening on
+func (s *Server) Addr() (string, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+// Stop closes the listener and the current connection
+func (s *Server) Stop() {
+	s.mu.Lock()
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+	}
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// DropConnection closes the current connection but keeps listening, as when
+// the network between GSPro and the launch monitor drops
+func (s *Server) DropConnection() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+// SetPlayer sets the player information sent when a launch monitor connects,
+// and sends it now if one is connected, as GSPro does when the club changes
+func (s *Server) SetPlayer(club, handed string) error {
+	return s.SetNamedPlayer("", club, handed)
+}
+
+// SetNamedPlayer is SetPlayer for a multiplayer round, naming the player who
+// is up. An empty club or handedness is left out of the message.
+func (s *Server) SetNamedPlayer(name, club, handed string) error {
+	s.mu.Lock()
+	s.player = &gspro.Player{Name: name, Club: club, Handed: handed}
+	connected := s.conn != nil
+	s.mu.Unlock()
+
+	if !connected {
+		return nil
+	}
+	return s.sendPlayer()
+}
+
+// SetSilent stops or resumes acknowledging shots. A silent server still
+// records what it receives.
+func (s *Server) SetSilent(silent bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.silent = silent
+}
+
+// SendReady tells the launch monitor GSPro is ready for the next shot
+func (s *Server) SendReady() error {
+	return s.send(Response{Code: CodeReady, Message: MessageReady})
+}
+
+// Connected reports whether a launch monitor is connected
+func (s *Server) Connected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn != nil
+}
+
+// Received returns every message received from the launch monitor
+func (s *Server) Received() []gspro.ShotData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]gspro.ShotData(nil), s.received...)
+}
+
+// Acks returns how many shots have been acknowledged
+func (s *Server) Acks() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acks
+}
+
+// WaitForConnection waits until a launch monitor connects
+func (s *Server) WaitForConnection(timeout time.Duration) error {
+	return s.waitFor(timeout, "a launch monitor to connect", func() bool {
+		return s.conn != nil
+	})
+}
+
+// WaitForMessage waits for the first message received after index skip that
+// matches, and returns it with its index
+func (s *Server) WaitForMessage(timeout time.Duration, skip int, match func(gspro.ShotData) bool) (gspro.ShotData, int, error) {
+	var found gspro.ShotData
+	index := -1
+	err := s.waitFor(timeout, "a matching message", func() bool {
+		for i := skip; i < len(s.received); i++ {
+			if match(s.received[i]) {
+				found, index = s.received[i], i
+				return true
+			}
+		}
+		return false
+	})
+	return found, index, err
+}
+
+// waitFor checks cond, which is called with the lock held, each time the
+// server's state changes until it is true or the timeout passes
+func (s *Server) waitFor(timeout time.Duration, what string, cond func() bool) error {
+	deadline := time.After(timeout)
+	for {
+		s.mu.Lock()
+		done := cond()
+		changed := s.changed
+		s.mu.Unlock()
+		if done {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-deadline:
+			return fmt.Errorf("timed out after %s waiting for %s", timeout, what)
+		}
+	}
+}
+
+// notifyLocked wakes any waiters; the caller holds the lock
+func (s *Server) notifyLocked() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) acceptLoop(listener net.Listener) {
+	defer s.wg.Done()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		// GSPro only talks to one launch monitor; a new connection replaces the old
+		s.mu.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.conn = conn
+		sendPlayer := s.player != nil
+		s.notifyLocked()
+		s.mu.Unlock()
+
+		if sendPlayer {
+			s.sendPlayer()
+		}
+
+		s.wg.Add(1)
+		go s.readLoop(conn)
+	}
+}
+
+func (s *Server) readLoop(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		if s.conn == conn {
+			s.conn = nil
+			s.notifyLocked()
+		}
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	decoder := json.NewDecoder(conn)
+	for {
+		var shot gspro.ShotData
+		if err := decoder.Decode(&shot); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.received = append(s.received, shot)
+		silent := s.silent
+		s.notifyLocked()
+		s.mu.Unlock()
+		if silent {
+			continue
+		}
+
+		// Readiness updates carry no data and are not acknowledged
+		switch {
+		case shot.ShotDataOptions.ContainsClubData:
+			s.ack(conn, MessageShotReceived)
+		case shot.ShotDataOptions.ContainsBallData:
+			s.ack(conn, MessageBallReceived)
+		}
+	}
+}
+
+func (s *Server) ack(conn net.Conn, message string) {
+	if err := writeResponse(conn, Response{Code: CodeShotReceived, Message: message}); err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.acks++
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+func (s *Server) sendPlayer() error {
+	s.mu.Lock()
+	player := *s.player
+	s.mu.Unlock()
+	return s.send(Response{Code: CodePlayerInfo, Message: MessagePlayerInfo, Player: &player})
+}
+
+func (s *Server) send(response Response) error {
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	return writeResponse(conn, response)
+}
+
+func writeResponse(conn net.Conn, response Response) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(data)
+	return err
+}
@@ -0,0 +1,298 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// DefaultBindAddress keeps the web server reachable from this machine only.
+const DefaultBindAddress = "127.0.0.1"
+
+// SetBindAddress sets the interface address the web server listens on.
+// It must be called before Start.
+func (s *Server) SetBindAddress(address string) {
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+	s.bindAddress = strings.TrimSpace(address)
+}
+
+// SetAllowedOrigins sets the extra browser origins permitted to call the API
+// and open the WebSocket. Same-origin requests are always allowed; "*"
+// allows any origin.
+func (s *Server) SetAllowedOrigins(origins []string) {
+	allowed := make([]string, 0, len(origins))
+	for _, origin := range origins {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			allowed = append(allowed, strings.ToLower(origin))
+		}
+	}
+
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+	s.allowedOrigins = allowed
+}
+
+// ParseBindAddress checks a bind address from the command line or the saved
+// settings. An empty address gives the default.
+func ParseBindAddress(value string) (string, error) {
+	address := strings.TrimSpace(value)
+	if address == "" {
+		return DefaultBindAddress, nil
+	}
+	if net.ParseIP(address) == nil {
+		return "", fmt.Errorf("%q is not an IP address", value)
+	}
+	return address, nil
+}
+
+// LocalURL returns the URL this machine reaches the web UI at when the
+// server listens on bindAddress. A wildcard address is reached over loopback.
+func LocalURL(bindAddress string, port int) string {
+	host := "localhost"
+	if ip := net.ParseIP(strings.TrimSpace(bindAddress)); ip != nil && !ip.IsUnspecified() && !ip.IsLoopback() {
+		host = ip.String()
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// ParseAllowedOrigins splits a comma-separated origin list.
+func ParseAllowedOrigins(value string) []string {
+	var origins []string
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimSpace(origin)
+		if origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+func (s *Server) listenAddress(port int) string {
+	s.accessMu.RLock()
+	host := s.bindAddress
+	s.accessMu.RUnlock()
+
+	if host == "" {
+		host = DefaultBindAddress
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// checkOrigin reports whether a request's Origin header is acceptable.
+// Requests without an Origin header come from non-browser clients and are allowed.
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	normalized := strings.ToLower(strings.TrimRight(origin, "/"))
+
+	s.accessMu.RLock()
+	defer s.accessMu.RUnlock()
+	for _, allowed := range s.allowedOrigins {
+		if allowed == "*" || allowed == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// originMiddleware rejects cross-origin requests from origins that are not
+// allowed and answers CORS preflight requests for those that are.
+func (s *Server) originMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !s.checkOrigin(r) {
+			http.Error(w, i18n.T("Origin not allowed"), http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, X-Total-Count")
+
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, If-None-Match")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isRemotelyAccessible reports whether the bind address accepts connections
+// from other machines.
+func (s *Server) isRemotelyAccessible() bool {
+	s.accessMu.RLock()
+	host := s.bindAddress
+	s.accessMu.RUnlock()
+
+	if host == "" || strings.EqualFold(host, "localhost") {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip == nil || !ip.IsLoopback()
+}
+
+// accessCookie keeps a token given in a link, such as /overlay?token=..., so
+// the page's API calls, WebSocket and downloads are authorized too
+const accessCookie = "squaregolf_access_token"
+
+// SetAccessTokens sets the tokens other machines use the API with. With
+// none, the API is open to anyone who can reach it.
+func (s *Server) SetAccessTokens(tokens core.AccessTokens) {
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+	s.accessTokens = append(core.AccessTokens(nil), tokens...)
+}
+
+// requestToken returns the token sent with a request, from the Authorization
+// header, the token query parameter or the access cookie
+func requestToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token
+	}
+	if cookie, err := r.Cookie(accessCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// requestScope returns what a request may do. Requests from this machine,
+// such as the desktop window, and every request while no tokens are set, may
+// do anything. ok is false for a missing or unknown token.
+func (s *Server) requestScope(r *http.Request) (scope core.AccessScope, ok bool) {
+	s.accessMu.RLock()
+	tokens := s.accessTokens
+	s.accessMu.RUnlock()
+
+	if len(tokens) == 0 || isLoopbackRequest(r) {
+		return core.AccessOperator, true
+	}
+	return tokens.Scope(requestToken(r))
+}
+
+// proxyHeaders are set by reverse proxies. A request carrying any of them
+// was passed on from another machine, even if the proxy itself is local.
+var proxyHeaders = []string{"Forwarded", "X-Forwarded-For", "X-Forwarded-Host", "X-Forwarded-Proto", "X-Real-Ip"}
+
+// isLoopbackRequest reports whether a request came from this machine and
+// not through a proxy running on it
+func isLoopbackRequest(r *http.Request) bool {
+	for _, header := range proxyHeaders {
+		if r.Header.Get(header) != "" {
+			return false
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// requireScope lets a request through only if its token allows required.
+// A missing or unknown token is a 401, and a viewer token used to control
+// the connector a 403.
+func (s *Server) requireScope(required core.AccessScope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := s.requestScope(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, i18n.T("An access token is required"), http.StatusUnauthorized)
+			return
+		}
+		if !scope.Allows(required) {
+			http.Error(w, i18n.T("This access token can only view"), http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// accessCookieMiddleware saves a valid token given in the query as a cookie,
+// so a link with the token opens a page that keeps working
+func (s *Server) accessCookieMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token != "" {
+			s.accessMu.RLock()
+			_, ok := s.accessTokens.Scope(token)
+			s.accessMu.RUnlock()
+			if ok {
+				http.SetCookie(w, &http.Cookie{
+					Name:     accessCookie,
+					Value:    token,
+					Path:     "/",
+					HttpOnly: true,
+					SameSite: http.SameSiteStrictMode,
+				})
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleAccessTokens gets or saves the web API tokens and puts the saved ones
+// in use straight away. Tokens are never sent back; saving the redacted
+// placeholder keeps the token saved under that name.
+func (s *Server) handleAccessTokens(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(config.GetInstance().GetSettings().AccessTokens.Redacted())
+		return
+	}
+
+	var req core.AccessTokens
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+	for i := range req {
+		req[i].Name = strings.TrimSpace(req[i].Name)
+	}
+
+	var saved core.AccessTokens
+	err := config.GetInstance().Update(func(settings *config.Settings) {
+		saved = settings.AccessTokens.Merge(req)
+		settings.AccessTokens = saved
+	})
+	if err != nil {
+		writeSettingsError(w, err)
+		return
+	}
+	s.SetAccessTokens(saved)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(saved.Redacted())
+}
@@ -0,0 +1,59 @@
+package web
+
+import "testing"
+
+func TestParseBindAddress(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", DefaultBindAddress, false},
+		{"  ", DefaultBindAddress, false},
+		{"0.0.0.0", "0.0.0.0", false},
+		{" 192.168.1.20 ", "192.168.1.20", false},
+		{"::", "::", false},
+		{"fe80::1", "fe80::1", false},
+		{"localhost", "", true},
+		{"192.168.1.300", "", true},
+		{"192.168.1.20:8080", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBindAddress(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseBindAddress(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLocalURL(t *testing.T) {
+	tests := []struct {
+		bindAddress string
+		want        string
+	}{
+		{"", "http://localhost:8080"},
+		{DefaultBindAddress, "http://localhost:8080"},
+		{"::1", "http://localhost:8080"},
+		{"0.0.0.0", "http://localhost:8080"},
+		{"::", "http://localhost:8080"},
+		{"192.168.1.20", "http://192.168.1.20:8080"},
+		{"fe80::1", "http://[fe80::1]:8080"},
+	}
+	for _, tt := range tests {
+		if got := LocalURL(tt.bindAddress, 8080); got != tt.want {
+			t.Errorf("LocalURL(%q) = %q, want %q", tt.bindAddress, got, tt.want)
+		}
+	}
+}
+
+func TestListenAddress_DefaultsToLoopback(t *testing.T) {
+	s := &Server{}
+	if got := s.listenAddress(8080); got != "127.0.0.1:8080" {
+		t.Errorf("listenAddress() = %q, want loopback", got)
+	}
+
+	s.SetBindAddress(" 192.168.1.20 ")
+	if got := s.listenAddress(8080); got != "192.168.1.20:8080" {
+		t.Errorf("listenAddress() = %q, want the bind address", got)
+	}
+}
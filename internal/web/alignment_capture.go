@@ -0,0 +1,92 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+type AlignmentCaptureStartRequest struct {
+	Angles []float64 `json:"angles"`
+}
+
+type AlignmentCaptureResult struct {
+	Format   core.AlignmentFormat `json:"format"`
+	RMSError float64              `json:"rmsError"`
+}
+
+func (s *Server) writeAlignmentCaptureStatus(w http.ResponseWriter) {
+	status := core.AlignmentCaptureStatus{}
+	if capture := s.launchMonitor.AlignmentCapture(); capture != nil {
+		status = capture.Status()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func (s *Server) handleAlignmentCaptureStatus(w http.ResponseWriter, r *http.Request) {
+	s.writeAlignmentCaptureStatus(w)
+}
+
+// handleAlignmentCaptureStart puts the device in alignment mode and starts
+// recording raw alignment notifications for the prompted angles
+func (s *Server) handleAlignmentCaptureStart(w http.ResponseWriter, r *http.Request) {
+	var req AlignmentCaptureStartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.launchMonitor.StartAlignment(); err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+
+	s.launchMonitor.StartAlignmentCapture(req.Angles)
+	s.writeAlignmentCaptureStatus(w)
+}
+
+func (s *Server) handleAlignmentCaptureNext(w http.ResponseWriter, r *http.Request) {
+	capture := s.launchMonitor.AlignmentCapture()
+	if capture == nil {
+		http.Error(w, i18n.T("No alignment capture in progress"), http.StatusConflict)
+		return
+	}
+	capture.Next()
+	s.writeAlignmentCaptureStatus(w)
+}
+
+// handleAlignmentCaptureFinish fits a format to the captured notifications,
+// saves it as the alignment calibration file and starts using it
+func (s *Server) handleAlignmentCaptureFinish(w http.ResponseWriter, r *http.Request) {
+	capture := s.launchMonitor.AlignmentCapture()
+	if capture == nil {
+		http.Error(w, i18n.T("No alignment capture in progress"), http.StatusConflict)
+		return
+	}
+
+	format, rmsError, err := core.FitAlignmentFormat(capture.Samples())
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusUnprocessableEntity)
+		return
+	}
+	s.launchMonitor.StopAlignmentCapture()
+
+	if err := core.SaveAlignmentFormat(config.GetInstance().AlignmentFormatPath(), format); err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	s.stateManager.SetAlignmentFormat(format)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AlignmentCaptureResult{Format: format, RMSError: rmsError})
+}
+
+func (s *Server) handleAlignmentCaptureCancel(w http.ResponseWriter, r *http.Request) {
+	s.launchMonitor.StopAlignmentCapture()
+	s.writeAlignmentCaptureStatus(w)
+}
@@ -0,0 +1,220 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/analytics"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/gorilla/mux"
+)
+
+// filteredShots applies the optional club, player, session, from, to and
+// after filters, then the offset and limit paging, counting back from the
+// most recent shot. It also returns how many shots matched before paging.
+func (s *Server) filteredShots(r *http.Request) ([]history.Shot, int, error) {
+	query := r.URL.Query()
+	shots := s.shotHistory.Shots()
+	shots = analytics.FilterByClub(shots, query.Get("club"))
+	shots = analytics.FilterByPlayer(shots, query.Get("player"))
+	shots = analytics.FilterBySession(shots, query.Get("session"))
+
+	from, to, err := parseTimeRange(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	shots = analytics.FilterByTime(shots, from, to)
+
+	if afterParam := query.Get("after"); afterParam != "" {
+		after, err := strconv.Atoi(afterParam)
+		if err != nil {
+			return nil, 0, errors.New("Invalid after")
+		}
+		shots = analytics.After(shots, after)
+	}
+	total := len(shots)
+
+	limit, offset := 0, 0
+	if limitParam := query.Get("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit < 0 {
+			return nil, 0, errors.New("Invalid limit")
+		}
+	}
+	if offsetParam := query.Get("offset"); offsetParam != "" {
+		offset, err = strconv.Atoi(offsetParam)
+		if err != nil || offset < 0 {
+			return nil, 0, errors.New("Invalid offset")
+		}
+	}
+	return analytics.Page(shots, offset, limit), total, nil
+}
+
+// parseTimeRange reads the optional from and to query parameters
+func parseTimeRange(query url.Values) (time.Time, time.Time, error) {
+	from, err := parseTimeParam(query.Get("from"), false)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("Invalid from: %v", err)
+	}
+	to, err := parseTimeParam(query.Get("to"), true)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("Invalid to: %v", err)
+	}
+	return from, to, nil
+}
+
+// parseTimeParam accepts an RFC 3339 time or a local YYYY-MM-DD date. A date
+// used as the end of a range includes the whole day.
+func parseTimeParam(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, errors.New("expected YYYY-MM-DD or an RFC 3339 time")
+	}
+	if end {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+func (s *Server) handleShots(w http.ResponseWriter, r *http.Request) {
+	shots, total, err := s.filteredShots(r)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	// Raw notifications are only sent for one shot at a time
+	for i := range shots {
+		shots[i] = shots[i].WithoutRawData()
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSONWithETag(w, r, shots)
+}
+
+// handleShotRaw returns the raw device notifications a shot was parsed from,
+// for debugging suspect readings
+func (s *Server) handleShotRaw(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, i18n.T("Invalid shot id"), http.StatusBadRequest)
+		return
+	}
+
+	shot, ok := s.shotHistory.Shot(id)
+	if !ok {
+		http.Error(w, i18n.T("Shot not found"), http.StatusNotFound)
+		return
+	}
+	frames, ok := shot.RawFrames()
+	if !ok {
+		http.Error(w, i18n.T("No raw data was kept for this shot"), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(frames)
+}
+
+func (s *Server) handleShotVideo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, i18n.T("Invalid shot id"), http.StatusBadRequest)
+		return
+	}
+
+	shot, ok := s.shotHistory.Shot(id)
+	if !ok {
+		http.Error(w, i18n.T("Shot not found"), http.StatusNotFound)
+		return
+	}
+	for _, video := range shot.Videos {
+		if video.Camera != vars["camera"] {
+			continue
+		}
+		if video.Path == "" {
+			http.Error(w, i18n.T("Video is stored on the camera"), http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, video.Path)
+		return
+	}
+	http.Error(w, i18n.T("Video not found"), http.StatusNotFound)
+}
+
+func (s *Server) broadcastShotVideos(shot history.Shot) {
+	if len(shot.Videos) == 0 {
+		return
+	}
+	msg := WSMessage{Type: "shotVideos", Data: shot.WithoutRawData()}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) handleAnalyticsDispersion(w http.ResponseWriter, r *http.Request) {
+	shots, _, err := s.filteredShots(r)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	writeJSONWithETag(w, r, analytics.Dispersion(shots))
+}
+
+func (s *Server) handleAnalyticsGapping(w http.ResponseWriter, r *http.Request) {
+	shots, _, err := s.filteredShots(r)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	writeJSONWithETag(w, r, analytics.Gapping(shots))
+}
+
+func (s *Server) handleAnalyticsConsistency(w http.ResponseWriter, r *http.Request) {
+	shots, _, err := s.filteredShots(r)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	writeJSONWithETag(w, r, analytics.Consistency(shots))
+}
+
+// handleAnalyticsStrokesGained reports strokes gained by the shots hit at a
+// target in the practice modes, filtered by club, player, practice and time
+func (s *Server) handleAnalyticsStrokesGained(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, to, err := parseTimeRange(query)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	club, profile, practice := query.Get("club"), query.Get("profile"), query.Get("practice")
+	var shots []core.TargetShot
+	for _, shot := range s.gameManager.TargetShots() {
+		switch {
+		case club != "" && shot.Club != club,
+			profile != "" && !strings.EqualFold(shot.Profile, profile),
+			practice != "" && shot.Practice != practice,
+			!from.IsZero() && shot.Timestamp.Before(from),
+			!to.IsZero() && !shot.Timestamp.Before(to):
+			continue
+		}
+		shots = append(shots, shot)
+	}
+	writeJSONWithETag(w, r, core.SummarizeStrokesGained(shots))
+}
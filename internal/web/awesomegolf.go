@@ -0,0 +1,113 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+type AwesomeGolfStatus struct {
+	ConnectionStatus string `json:"connectionStatus"`
+	IP               string `json:"ip"`
+	Port             int    `json:"port"`
+	AutoConnect      bool   `json:"autoConnect"`
+	LastError        string `json:"lastError"`
+}
+
+func (s *Server) broadcastAwesomeGolfStatus() {
+	msg := WSMessage{Type: "awesomeGolfStatus", Data: s.getAwesomeGolfStatus()}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) getAwesomeGolfStatus() AwesomeGolfStatus {
+	var lastErrorStr string
+	if err := s.stateManager.GetAwesomeGolfError(); err != nil {
+		lastErrorStr = i18n.Error(err)
+	}
+
+	connectionStatus := "disconnected"
+	switch s.stateManager.GetAwesomeGolfStatus() {
+	case core.AwesomeGolfStatusConnected:
+		connectionStatus = "connected"
+	case core.AwesomeGolfStatusConnecting:
+		connectionStatus = "connecting"
+	case core.AwesomeGolfStatusError:
+		connectionStatus = "error"
+	}
+
+	ip, port := s.awesomeGolfIntegration.GetConnectionInfo()
+	return AwesomeGolfStatus{
+		ConnectionStatus: connectionStatus,
+		IP:               ip,
+		Port:             port,
+		AutoConnect:      config.GetInstance().GetSettings().AwesomeGolfAutoConnect,
+		LastError:        lastErrorStr,
+	}
+}
+
+func (s *Server) handleAwesomeGolfStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.getAwesomeGolfStatus())
+}
+
+func (s *Server) handleAwesomeGolfConnect(w http.ResponseWriter, r *http.Request) {
+	var req ConnectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	go func() {
+		s.awesomeGolfIntegration.ResetReconnectionState()
+		s.awesomeGolfIntegration.EnableAutoReconnect()
+		s.awesomeGolfIntegration.Start()
+		s.awesomeGolfIntegration.Connect(req.IP, req.Port)
+	}()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleAwesomeGolfDisconnect(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		s.awesomeGolfIntegration.DisableAutoReconnect()
+		s.awesomeGolfIntegration.Disconnect()
+	}()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleAwesomeGolfConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		settings := config.GetInstance().GetSettings()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ConnectionConfig{
+			IP:          settings.AwesomeGolfIP,
+			Port:        settings.AwesomeGolfPort,
+			AutoConnect: settings.AwesomeGolfAutoConnect,
+		})
+		return
+	}
+
+	var configData ConnectionConfig
+	if err := json.NewDecoder(r.Body).Decode(&configData); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	err := config.GetInstance().Update(func(settings *config.Settings) {
+		settings.AwesomeGolfIP = strings.TrimSpace(configData.IP)
+		settings.AwesomeGolfPort = configData.Port
+		settings.AwesomeGolfAutoConnect = configData.AutoConnect
+	})
+	if err != nil {
+		writeSettingsError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
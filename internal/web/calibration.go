@@ -0,0 +1,83 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+type CalibrationStatus struct {
+	Calibration core.MatCalibration `json:"calibration"`
+	RawPosition *core.BallPosition  `json:"rawPosition"`
+	Samples     map[string]int      `json:"samples"`
+}
+
+type CalibrationSampleRequest struct {
+	Point string `json:"point"`
+}
+
+func (s *Server) getCalibrationStatus() CalibrationStatus {
+	return CalibrationStatus{
+		Calibration: s.launchMonitor.GetMatCalibration(),
+		RawPosition: s.launchMonitor.RawBallPosition(),
+		Samples:     s.calibrationWizard.SampleCounts(),
+	}
+}
+
+func (s *Server) handleCalibrationStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.getCalibrationStatus())
+}
+
+// handleCalibrationSample records the current raw ball position for one of
+// the wizard's calibration points
+func (s *Server) handleCalibrationSample(w http.ResponseWriter, r *http.Request) {
+	var req CalibrationSampleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	position := s.launchMonitor.RawBallPosition()
+	if position == nil {
+		http.Error(w, i18n.T("No ball position available"), http.StatusConflict)
+		return
+	}
+
+	if err := s.calibrationWizard.AddSample(req.Point, *position); err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.getCalibrationStatus())
+}
+
+func (s *Server) handleCalibrationFinish(w http.ResponseWriter, r *http.Request) {
+	calibration, err := s.calibrationWizard.Compute()
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	s.launchMonitor.SetMatCalibration(calibration)
+	config.GetInstance().SetMatCalibration(calibration)
+	s.calibrationWizard.Reset()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.getCalibrationStatus())
+}
+
+// handleCalibrationReset clears both the wizard samples and the saved
+// calibration so positions are reported in raw device coordinates again
+func (s *Server) handleCalibrationReset(w http.ResponseWriter, r *http.Request) {
+	s.calibrationWizard.Reset()
+	s.launchMonitor.SetMatCalibration(core.MatCalibration{})
+	config.GetInstance().SetMatCalibration(core.MatCalibration{})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.getCalibrationStatus())
+}
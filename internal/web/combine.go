@@ -0,0 +1,85 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// CombineStatus is the combine in progress or last finished, if any
+type CombineStatus struct {
+	Combine *core.CombineState `json:"combine"`
+}
+
+// CombineStartRequest starts a combine for a player
+type CombineStartRequest struct {
+	Profile string `json:"profile"`
+}
+
+func (s *Server) broadcastCombineState(state *core.CombineState) {
+	msg := WSMessage{Type: "combine", Data: state}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) handleCombine(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(CombineStatus{Combine: s.gameManager.CombineState()})
+}
+
+// handleCombineStart starts a combine, replacing anything in progress
+func (s *Server) handleCombineStart(w http.ResponseWriter, r *http.Request) {
+	var req CombineStartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gameManager.StartCombine(req.Profile))
+}
+
+func (s *Server) handleCombinePause(w http.ResponseWriter, r *http.Request) {
+	s.writeCombineChange(w, s.gameManager.PauseCombine)
+}
+
+func (s *Server) handleCombineResume(w http.ResponseWriter, r *http.Request) {
+	s.writeCombineChange(w, s.gameManager.ResumeCombine)
+}
+
+func (s *Server) writeCombineChange(w http.ResponseWriter, change func() (core.CombineState, error)) {
+	state, err := change()
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusConflict)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+func (s *Server) handleCombineStop(w http.ResponseWriter, r *http.Request) {
+	s.gameManager.StopCombine()
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleCombineReport reports on the combine in progress or last finished
+func (s *Server) handleCombineReport(w http.ResponseWriter, r *http.Request) {
+	state := s.gameManager.CombineState()
+	if state == nil {
+		http.Error(w, i18n.T("No combine to report on"), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state.Report())
+}
+
+func (s *Server) handleCombineReports(w http.ResponseWriter, r *http.Request) {
+	profile := strings.TrimSpace(r.URL.Query().Get("profile"))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gameManager.CombineReports(profile))
+}
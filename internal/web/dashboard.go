@@ -0,0 +1,99 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// localBayStatus is this connector's own bay on the dashboard
+func (s *Server) localBayStatus() core.BayStatus {
+	device := s.getDeviceStatus()
+	gspro := s.getGSProStatus()
+	status := core.BayStatus{
+		Device: core.BayDevice{
+			ConnectionStatus: device.ConnectionStatus,
+			DeviceName:       device.DeviceName,
+			DeviceType:       device.DeviceType,
+			BatteryLevel:     device.BatteryLevel,
+			MinutesRemaining: device.Battery.MinutesRemaining,
+			Idle:             device.Idle,
+			Standby:          device.Standby,
+			Paused:           device.Paused,
+			LastError:        device.LastError,
+		},
+		GSPro: core.BayGSPro{
+			ConnectionStatus: gspro.ConnectionStatus,
+			Player:           gspro.Player,
+			LastError:        gspro.LastError,
+		},
+	}
+	if shot, ok := s.shotHistory.Last(); ok {
+		status.LastShot = &core.BayShot{
+			ID:           shot.ID,
+			Timestamp:    shot.Timestamp,
+			Club:         shot.Club,
+			Player:       shot.Player,
+			BallSpeedMPS: shot.Ball.BallSpeedMPS,
+			CarryYards:   shot.CarryYards,
+			OfflineYards: shot.OfflineYards,
+		}
+	}
+	return status
+}
+
+func (s *Server) broadcastDashboard(bays []core.BayStatus) {
+	msg := WSMessage{Type: "dashboard", Data: bays}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+// handleDashboard returns every bay's device, battery, GSPro status and last
+// shot
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.dashboard.Bays())
+}
+
+// handleDashboardConfig gets or saves the bays the dashboard polls
+func (s *Server) handleDashboardConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(config.GetInstance().GetSettings().Dashboard)
+		return
+	}
+
+	var req core.DashboardSettings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	bays := make([]core.DashboardBay, 0, len(req.Bays))
+	for _, bay := range req.Bays {
+		bays = append(bays, core.DashboardBay{Name: strings.TrimSpace(bay.Name), URL: strings.TrimSpace(bay.URL)})
+	}
+	req.Bays = bays
+	if req.PollSeconds == 0 {
+		req.PollSeconds = core.DefaultDashboardPollSeconds
+	}
+
+	err := config.GetInstance().Update(func(settings *config.Settings) {
+		settings.Dashboard = req
+	})
+	if err != nil {
+		writeSettingsError(w, err)
+		return
+	}
+	s.dashboard.Configure(req)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(req)
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+const (
+	// defaultRawCommandWindow is how long notifications are streamed after a
+	// raw command when the request doesn't say
+	defaultRawCommandWindow = 2 * time.Second
+	maxRawCommandWindow     = 30 * time.Second
+	// rawNotificationBuffer holds notifications that arrive faster than they
+	// are written; any more are counted as dropped
+	rawNotificationBuffer = 256
+)
+
+// RawCommandRequest is a command to write to the device as hex bytes, such
+// as "1186 01 00 00 00 00 00 00", and how long to stream the notifications
+// that follow it
+type RawCommandRequest struct {
+	Command  string `json:"command"`
+	WindowMs int    `json:"windowMs,omitempty"` // 2000 if zero, at most 30000
+}
+
+// RawCommandEvent is one line of the response to a raw command: "sent" once
+// the command is written, a "notification" for each one received in the
+// window, then "done"
+type RawCommandEvent struct {
+	Type     string `json:"type"`
+	Command  string `json:"command,omitempty"`
+	UUID     string `json:"uuid,omitempty"`
+	Data     string `json:"data,omitempty"`
+	OffsetMs int64  `json:"offsetMs"` // since the command was queued
+	Dropped  int64  `json:"dropped,omitempty"`
+}
+
+type rawNotification struct {
+	uuid string
+	data []byte
+	at   time.Time
+}
+
+// handleDebugCommand writes a raw command to the device and streams the
+// notifications received after it as JSON lines until the window ends
+func (s *Server) handleDebugCommand(w http.ResponseWriter, r *http.Request) {
+	var req RawCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+	command, err := core.ParseRawCommand(req.Command)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	window := time.Duration(req.WindowMs) * time.Millisecond
+	if req.WindowMs == 0 {
+		window = defaultRawCommandWindow
+	}
+	if window < 0 || window > maxRawCommandWindow {
+		http.Error(w, i18n.Error(fmt.Errorf("window must be between 0 and %d ms", maxRawCommandWindow.Milliseconds())), http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, i18n.T("Streaming not supported"), http.StatusInternalServerError)
+		return
+	}
+
+	// Watching starts before the write so a fast reply isn't missed
+	notifications := make(chan rawNotification, rawNotificationBuffer)
+	var dropped atomic.Int64
+	stop := s.launchMonitor.WatchNotifications(func(uuid string, data []byte) {
+		select {
+		case notifications <- rawNotification{uuid: uuid, data: data, at: time.Now()}:
+		default:
+			dropped.Add(1)
+		}
+	})
+	defer stop()
+
+	sent := time.Now()
+	if err := s.launchMonitor.SendRawCommand(command); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, core.ErrInvalidRawCommand) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, i18n.Error(err), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	encoder := json.NewEncoder(w)
+	encoder.Encode(RawCommandEvent{Type: "sent", Command: command})
+	flusher.Flush()
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case n := <-notifications:
+			encoder.Encode(RawCommandEvent{
+				Type:     "notification",
+				UUID:     n.uuid,
+				Data:     hex.EncodeToString(n.data),
+				OffsetMs: n.at.Sub(sent).Milliseconds(),
+			})
+			flusher.Flush()
+		case <-timer.C:
+			stop()
+			encoder.Encode(RawCommandEvent{Type: "done", OffsetMs: time.Since(sent).Milliseconds(), Dropped: dropped.Load()})
+			flusher.Flush()
+			return
+		}
+	}
+}
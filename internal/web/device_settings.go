@@ -0,0 +1,44 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+func (s *Server) handleDeviceSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.launchMonitor.GetDeviceSettings())
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	// Fields missing from the body keep their current values
+	settings, err := s.launchMonitor.UpdateDeviceSettings(func(current *core.DeviceSettings) error {
+		return json.Unmarshal(body, current)
+	})
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	cfg := config.GetInstance()
+	cfg.SetSpinMode(settings.SpinMode)
+	cfg.SetOmniSpeedUnit(settings.SpeedUnit)
+	cfg.SetOmniDistanceUnit(settings.DistanceUnit)
+	cfg.SetOmniGreenSpeed(settings.GreenSpeed)
+	cfg.SetOmniCarryAdjustment(settings.CarryAdjustment)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
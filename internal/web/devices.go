@@ -0,0 +1,66 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// DeviceNicknameRequest names a known device. An empty nickname clears it.
+type DeviceNicknameRequest struct {
+	Address  string `json:"address"`
+	Nickname string `json:"nickname"`
+}
+
+// DeviceForgetRequest removes a known device
+type DeviceForgetRequest struct {
+	Address string `json:"address"`
+}
+
+// handleDevices lists the devices seen by scans and connections
+func (s *Server) handleDevices(w http.ResponseWriter, r *http.Request) {
+	writeJSONWithETag(w, r, s.knownDevices.List())
+}
+
+func (s *Server) handleDeviceNickname(w http.ResponseWriter, r *http.Request) {
+	var req DeviceNicknameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	device, err := s.knownDevices.SetNickname(req.Address, req.Nickname)
+	if err != nil {
+		http.Error(w, i18n.Error(err), knownDeviceErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(device)
+}
+
+func (s *Server) handleDeviceForget(w http.ResponseWriter, r *http.Request) {
+	var req DeviceForgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.knownDevices.Forget(req.Address); err != nil {
+		http.Error(w, i18n.Error(err), knownDeviceErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func knownDeviceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, core.ErrUnknownDevice):
+		return http.StatusNotFound
+	case errors.Is(err, core.ErrNicknameInUse):
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
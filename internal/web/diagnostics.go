@@ -0,0 +1,298 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/brentyates/squaregolf-connector/internal/version"
+)
+
+const (
+	// diagnosticsConnectTimeout is how long the connect step waits for the
+	// launch monitor
+	diagnosticsConnectTimeout = 30 * time.Second
+	// diagnosticsDialTimeout is how long the GSPro step waits for its port
+	diagnosticsDialTimeout = 3 * time.Second
+)
+
+// ErrDiagnosticsRunning is returned when diagnostics are started while a run
+// is already in progress
+var ErrDiagnosticsRunning = errors.New("diagnostics are already running")
+
+// DiagnosticResult is the outcome of one diagnostics step
+type DiagnosticResult string
+
+const (
+	DiagnosticPass DiagnosticResult = "pass"
+	DiagnosticFail DiagnosticResult = "fail"
+	DiagnosticSkip DiagnosticResult = "skip" // an earlier step failed or the check doesn't apply
+)
+
+// DiagnosticStep is one check of a diagnostics run
+type DiagnosticStep struct {
+	Name       string           `json:"name"`
+	Result     DiagnosticResult `json:"result"`
+	Detail     string           `json:"detail,omitempty"`
+	DurationMs int64            `json:"durationMs"`
+}
+
+// DiagnosticsReport is the result of a diagnostics run, in the order the
+// steps ran. Passed is false when any step failed.
+type DiagnosticsReport struct {
+	Passed    bool             `json:"passed"`
+	StartedAt time.Time        `json:"startedAt"`
+	Version   string           `json:"version"`
+	Platform  string           `json:"platform"`
+	Steps     []DiagnosticStep `json:"steps"`
+}
+
+// Text returns the report as a Markdown list for pasting into an issue
+func (r DiagnosticsReport) Text() string {
+	var b strings.Builder
+	overall := "PASS"
+	if !r.Passed {
+		overall = "FAIL"
+	}
+	fmt.Fprintf(&b, "### SquareGolf Connector diagnostics: %s\n\n", overall)
+	fmt.Fprintf(&b, "- Version: %s\n- Platform: %s\n- Run at: %s\n\n", r.Version, r.Platform, r.StartedAt.Format(time.RFC3339))
+	for _, step := range r.Steps {
+		fmt.Fprintf(&b, "- [%s] %s (%d ms)", strings.ToUpper(string(step.Result)), step.Name, step.DurationMs)
+		if step.Detail != "" {
+			fmt.Fprintf(&b, ": %s", step.Detail)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// diagnostics runs the checks one at a time, so a run doesn't scan or
+// connect while another is doing the same
+type diagnostics struct {
+	mu sync.Mutex
+}
+
+// handleDiagnosticsRun runs the connection checks in order: Bluetooth
+// adapter, device scan, connect, battery read, heartbeat, GSPro port and
+// cameras. The report is JSON, or Markdown text with format=text.
+func (s *Server) handleDiagnosticsRun(w http.ResponseWriter, r *http.Request) {
+	if !s.diagnostics.mu.TryLock() {
+		http.Error(w, i18n.Error(ErrDiagnosticsRunning), http.StatusConflict)
+		return
+	}
+	defer s.diagnostics.mu.Unlock()
+
+	report := s.runDiagnostics(r.Context())
+
+	w.Header().Set("Cache-Control", "no-store")
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(report.Text()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (s *Server) runDiagnostics(ctx context.Context) DiagnosticsReport {
+	report := DiagnosticsReport{
+		Passed:    true,
+		StartedAt: time.Now(),
+		Version:   version.GetShortVersion(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	run := func(name string, check func() (DiagnosticResult, string)) DiagnosticResult {
+		start := time.Now()
+		result, detail := check()
+		report.Steps = append(report.Steps, DiagnosticStep{
+			Name:       name,
+			Result:     result,
+			Detail:     detail,
+			DurationMs: time.Since(start).Milliseconds(),
+		})
+		if result == DiagnosticFail {
+			report.Passed = false
+		}
+		return result
+	}
+	skipped := func(reason string) func() (DiagnosticResult, string) {
+		return func() (DiagnosticResult, string) { return DiagnosticSkip, reason }
+	}
+
+	adapter := run("Bluetooth adapter", s.diagnoseAdapter)
+
+	var found []core.DiscoveredDevice
+	scan := skipped("no Bluetooth adapter")
+	if adapter == DiagnosticPass {
+		scan = func() (DiagnosticResult, string) {
+			var result DiagnosticResult
+			var detail string
+			found, result, detail = s.diagnoseScan(ctx)
+			return result, detail
+		}
+	}
+	scanned := run("Device scan", scan)
+
+	connect := skipped("no device found")
+	if scanned == DiagnosticPass {
+		connect = func() (DiagnosticResult, string) { return s.diagnoseConnect(ctx, found) }
+	}
+	connected := run("Connect", connect)
+
+	battery, heartbeat := skipped("not connected"), skipped("not connected")
+	if connected == DiagnosticPass {
+		battery = s.diagnoseBattery
+		heartbeat = s.diagnoseHeartbeat
+	}
+	run("Read battery", battery)
+	run("Send heartbeat", heartbeat)
+
+	run("GSPro port", s.diagnoseGSPro)
+	run("Cameras", func() (DiagnosticResult, string) { return s.diagnoseCameras(ctx) })
+	return report
+}
+
+func (s *Server) diagnoseAdapter() (DiagnosticResult, string) {
+	check := s.bluetoothHealth()
+	if check.Status != core.HealthOK {
+		return DiagnosticFail, check.Detail
+	}
+	if check.Detail == "" {
+		return DiagnosticPass, "available"
+	}
+	return DiagnosticPass, check.Detail
+}
+
+// diagnoseScan scans for launch monitors. A device that is already connected
+// isn't advertising, so it stands in for the scan, as does a connection
+// attempt in progress, which is scanning already.
+func (s *Server) diagnoseScan(ctx context.Context) ([]core.DiscoveredDevice, DiagnosticResult, string) {
+	switch s.stateManager.GetConnectionStatus() {
+	case core.ConnectionStatusConnected:
+		return nil, DiagnosticPass, "already connected to " + s.connectedDeviceName()
+	case core.ConnectionStatusScanning, core.ConnectionStatusConnecting:
+		return nil, DiagnosticPass, "a connection attempt is already in progress"
+	}
+	devices, err := s.bluetoothManager.Scan(ctx)
+	if err != nil {
+		return nil, DiagnosticFail, err.Error()
+	}
+	if len(devices) == 0 {
+		return nil, DiagnosticFail, "no launch monitor found, check it is switched on and nearby"
+	}
+	names := make([]string, 0, len(devices))
+	for _, device := range devices {
+		names = append(names, device.Name)
+	}
+	return devices, DiagnosticPass, "found " + strings.Join(names, ", ")
+}
+
+// diagnoseConnect connects to the saved device if the scan found it, or else
+// the first device found, and waits for the connection to finish. A
+// connection attempt already in progress is waited for instead.
+func (s *Server) diagnoseConnect(ctx context.Context, found []core.DiscoveredDevice) (DiagnosticResult, string) {
+	switch s.stateManager.GetConnectionStatus() {
+	case core.ConnectionStatusConnected:
+		return DiagnosticPass, "already connected to " + s.connectedDeviceName()
+	case core.ConnectionStatusScanning, core.ConnectionStatusConnecting:
+		return s.awaitConnection(ctx, "the device")
+	}
+	if len(found) == 0 {
+		return DiagnosticFail, "no device to connect to"
+	}
+	device := found[0]
+	saved := config.GetInstance().GetSettings().DeviceAddress
+	for _, candidate := range found {
+		if saved != "" && strings.EqualFold(candidate.Address, saved) {
+			device = candidate
+			break
+		}
+	}
+
+	// Started here rather than in the background so an error left from an
+	// earlier attempt is cleared before the status is polled
+	s.bluetoothManager.StartBluetoothConnection(device.Name, device.Address)
+	return s.awaitConnection(ctx, device.Name)
+}
+
+// awaitConnection waits for the connection attempt to connect or fail
+func (s *Server) awaitConnection(ctx context.Context, name string) (DiagnosticResult, string) {
+
+	ctx, cancel := context.WithTimeout(ctx, diagnosticsConnectTimeout)
+	defer cancel()
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return DiagnosticFail, fmt.Sprintf("%s didn't connect within %v", name, diagnosticsConnectTimeout)
+		case <-ticker.C:
+		}
+		switch s.stateManager.GetConnectionStatus() {
+		case core.ConnectionStatusConnected:
+			return DiagnosticPass, "connected to " + s.connectedDeviceName()
+		case core.ConnectionStatusError:
+			return DiagnosticFail, errorDetail(s.stateManager.GetLastError(), "connection failed")
+		}
+	}
+}
+
+func (s *Server) diagnoseBattery() (DiagnosticResult, string) {
+	level, err := s.launchMonitor.ReadBatteryLevel()
+	if err != nil {
+		return DiagnosticFail, err.Error()
+	}
+	return DiagnosticPass, fmt.Sprintf("%d%%", level)
+}
+
+func (s *Server) diagnoseHeartbeat() (DiagnosticResult, string) {
+	if err := s.launchMonitor.SendHeartbeat(); err != nil {
+		return DiagnosticFail, err.Error()
+	}
+	return DiagnosticPass, "sent"
+}
+
+// diagnoseGSPro checks GSPro's port accepts connections. A connected GSPro
+// isn't dialled again, as GSPro only serves one connector at a time.
+func (s *Server) diagnoseGSPro() (DiagnosticResult, string) {
+	settings := config.GetInstance().GetSettings()
+	address := net.JoinHostPort(settings.GSProIP, strconv.Itoa(settings.GSProPort))
+	if s.stateManager.GetGSProStatus() == core.GSProStatusConnected {
+		return DiagnosticPass, "connected to " + address
+	}
+	conn, err := net.DialTimeout("tcp", address, diagnosticsDialTimeout)
+	if err != nil {
+		return DiagnosticFail, fmt.Sprintf("%s unreachable: %v", address, err)
+	}
+	conn.Close()
+	return DiagnosticPass, address + " reachable"
+}
+
+func (s *Server) diagnoseCameras(ctx context.Context) (DiagnosticResult, string) {
+	check := s.cameraHealth(ctx)
+	switch check.Status {
+	case core.HealthOff:
+		return DiagnosticSkip, "external camera not enabled"
+	case core.HealthFail:
+		return DiagnosticFail, check.Detail
+	}
+	return DiagnosticPass, check.Detail
+}
+
+func (s *Server) connectedDeviceName() string {
+	if name := s.stateManager.GetDeviceDisplayName(); name != nil && *name != "" {
+		return *name
+	}
+	return "device"
+}
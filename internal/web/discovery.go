@@ -0,0 +1,62 @@
+package web
+
+import (
+	"log"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/version"
+)
+
+// startDiscovery advertises the web UI over mDNS when the server is reachable
+// from the local network.
+func (s *Server) startDiscovery(port int) {
+	if !s.isRemotelyAccessible() {
+		return
+	}
+
+	advertiser := newMDNSAdvertiser(port, s.discoveryTXT)
+	if err := advertiser.Start(); err != nil {
+		log.Printf("mDNS advertisement disabled: %v", err)
+		return
+	}
+
+	s.accessMu.Lock()
+	s.mdns = advertiser
+	s.accessMu.Unlock()
+}
+
+func (s *Server) stopDiscovery() {
+	s.accessMu.Lock()
+	advertiser := s.mdns
+	s.mdns = nil
+	s.accessMu.Unlock()
+
+	if advertiser != nil {
+		advertiser.Stop()
+	}
+}
+
+// announceDiscovery re-announces the service so TXT metadata stays current.
+func (s *Server) announceDiscovery() {
+	s.accessMu.RLock()
+	advertiser := s.mdns
+	s.accessMu.RUnlock()
+
+	if advertiser != nil {
+		advertiser.Announce()
+	}
+}
+
+func (s *Server) discoveryTXT() []string {
+	connected := "false"
+	if s.stateManager.GetConnectionStatus() == core.ConnectionStatusConnected {
+		connected = "true"
+	}
+
+	return []string{
+		"version=" + version.GetShortVersion(),
+		"path=/",
+		"api=" + APIPrefix,
+		"deviceConnected=" + connected,
+	}
+}
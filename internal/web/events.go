@@ -0,0 +1,128 @@
+package web
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+const (
+	// clientWriteTimeout is how long a status update may take to reach a
+	// client before it is dropped
+	clientWriteTimeout = 2 * time.Second
+	// eventStreamKeepAlive keeps idle event streams open through proxies
+	eventStreamKeepAlive = 15 * time.Second
+)
+
+// statusClient is a connection status updates are pushed over. Each
+// transport's handler registers its client with addClient and drains the
+// channel it gets back; broadcasts reach every client the same way.
+type statusClient interface {
+	// Transport names the kind of connection for logs
+	Transport() string
+	RemoteAddr() string
+	// Close ends the connection, which makes its handler remove the client
+	Close() error
+}
+
+// wsClient is a browser connected over the WebSocket at /ws
+type wsClient struct {
+	conn *websocket.Conn
+}
+
+func (c wsClient) Transport() string  { return "WebSocket" }
+func (c wsClient) RemoteAddr() string { return c.conn.RemoteAddr().String() }
+func (c wsClient) Close() error       { return c.conn.Close() }
+
+// sseClient is a browser receiving status updates as Server-Sent Events, for
+// networks whose proxies block WebSocket upgrades
+type sseClient struct {
+	remoteAddr string
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+func (c *sseClient) Transport() string  { return "Event stream" }
+func (c *sseClient) RemoteAddr() string { return c.remoteAddr }
+
+func (c *sseClient) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return nil
+}
+
+// addClient registers a client for broadcasts and queues the current status
+// for it. The channel is closed by removeClient.
+func (s *Server) addClient(client statusClient) chan []byte {
+	clientChan := make(chan []byte, clientSendBuffer)
+
+	s.clientsMu.Lock()
+	s.clients[client] = clientChan
+	s.clientsMu.Unlock()
+
+	s.sendInitialStatus(clientChan)
+	return clientChan
+}
+
+// removeClient stops broadcasts to a client. Only the client's handler calls
+// it, after which broadcasts can no longer reach the channel it closes.
+func (s *Server) removeClient(client statusClient, clientChan chan []byte) {
+	s.clientsMu.Lock()
+	delete(s.clients, client)
+	s.clientsMu.Unlock()
+	close(clientChan)
+	client.Close()
+}
+
+// handleEvents sends the messages broadcast over the WebSocket as
+// Server-Sent Events, each a JSON message with a type and data
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, i18n.T("Streaming not supported"), http.StatusInternalServerError)
+		return
+	}
+	controller := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Stops nginx and similar proxies holding events back in a buffer
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	client := &sseClient{remoteAddr: r.RemoteAddr, done: make(chan struct{})}
+	clientChan := s.addClient(client)
+	defer s.removeClient(client, clientChan)
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client.done:
+			return
+		case msg := <-clientChan:
+			controller.SetWriteDeadline(time.Now().Add(clientWriteTimeout))
+			_, err = fmt.Fprintf(w, "data: %s\n\n", msg)
+		case <-keepAlive.C:
+			controller.SetWriteDeadline(time.Now().Add(clientWriteTimeout))
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err == nil {
+			err = controller.Flush()
+		}
+		if err != nil {
+			log.Printf("Event stream send error: %v, closing client", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core/export"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// translateExportStatus shows the last export error in the UI's language
+func translateExportStatus(status export.Status) export.Status {
+	if status.Err != nil {
+		status.LastError = i18n.Error(status.Err)
+	}
+	return status
+}
+
+func (s *Server) broadcastExportStatus(status export.Status) {
+	msg := WSMessage{Type: "exportStatus", Data: translateExportStatus(status)}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+// handleExportConfig gets or saves the shot export settings. The S3 secret
+// is never sent back; saving the redacted placeholder keeps the saved one.
+func (s *Server) handleExportConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(config.GetInstance().GetSettings().Export.Redacted())
+		return
+	}
+
+	var req export.Settings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+	req.SpreadsheetID = strings.TrimSpace(req.SpreadsheetID)
+	req.SheetName = strings.TrimSpace(req.SheetName)
+	req.CredentialsFile = strings.TrimSpace(req.CredentialsFile)
+	req.Bucket = strings.TrimSpace(req.Bucket)
+	req.Region = strings.TrimSpace(req.Region)
+	req.Endpoint = strings.TrimSpace(req.Endpoint)
+	req.AccessKeyID = strings.TrimSpace(req.AccessKeyID)
+
+	var saved export.Settings
+	err := config.GetInstance().Update(func(settings *config.Settings) {
+		if req.SecretAccessKey == export.RedactedSecret {
+			req.SecretAccessKey = settings.Export.SecretAccessKey
+		}
+		settings.Export = req
+		saved = req
+	})
+	if err != nil {
+		writeSettingsError(w, err)
+		return
+	}
+	s.exporter.Configure(saved)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(saved.Redacted())
+}
+
+func (s *Server) handleExportStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translateExportStatus(s.exporter.Status()))
+}
+
+// handleExportRetry sends the shots waiting to be exported without waiting
+// out the retry delay
+func (s *Server) handleExportRetry(w http.ResponseWriter, r *http.Request) {
+	s.exporter.RetryNow()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translateExportStatus(s.exporter.Status()))
+}
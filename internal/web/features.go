@@ -0,0 +1,104 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// FeatureFlags lists the optional features that are on. Simulator is fixed
+// at startup; the others can be changed with a POST.
+type FeatureFlags struct {
+	ExternalCamera bool `json:"externalCamera"`
+	ConnectServer  bool `json:"connectServer"`
+	Games          bool `json:"games"`
+	RawCommands    bool `json:"rawCommands"`
+	Dashboard      bool `json:"dashboard"`
+	Simulator      bool `json:"simulator"`
+}
+
+func (s *Server) featureFlags() FeatureFlags {
+	flags := s.features.All()
+	return FeatureFlags{
+		ExternalCamera: flags[core.FeatureExternalCamera],
+		ConnectServer:  flags[core.FeatureConnectServer],
+		Games:          flags[core.FeatureGames],
+		RawCommands:    flags[core.FeatureRawCommands],
+		Dashboard:      flags[core.FeatureDashboard],
+		Simulator:      s.simulator != nil,
+	}
+}
+
+// handleFeatures returns the features that are on. A POST turns the features
+// in the body on or off and saves them; the others are left as they are.
+func (s *Server) handleFeatures(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		var req map[core.Feature]bool
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+			return
+		}
+		// Checked first so an unknown feature changes nothing
+		known := s.features.All()
+		for feature := range req {
+			if _, ok := known[feature]; !ok {
+				http.Error(w, i18n.Error(fmt.Errorf("%w: %s", core.ErrUnknownFeature, feature)), http.StatusBadRequest)
+				return
+			}
+		}
+		for feature, enabled := range req {
+			if err := config.GetInstance().SetFeature(feature, enabled); err != nil {
+				writeSettingsError(w, err)
+				return
+			}
+			s.features.Set(feature, enabled)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.featureFlags())
+}
+
+// requireFeature replies 404 while a feature is off, so its endpoints look
+// as if they don't exist
+func (s *Server) requireFeature(feature core.Feature, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.features.Enabled(feature) {
+			http.Error(w, i18n.Tf("Feature %s is turned off", feature), http.StatusNotFound)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// onFeatureChanged applies a feature turned on or off from the settings and
+// tells the UI
+func (s *Server) onFeatureChanged(feature core.Feature, enabled bool) {
+	if feature == core.FeatureExternalCamera {
+		// The camera only records if the user enabled it as well
+		s.cameraManager.SetEnabled(enabled && config.GetInstance().GetSettings().CameraEnabled)
+		s.broadcastCameraConfig()
+	}
+	if feature == core.FeatureDashboard {
+		s.dashboard.SetEnabled(enabled)
+	}
+	s.broadcastFeatures()
+}
+
+func (s *Server) broadcastFeatures() {
+	msg := WSMessage{Type: "features", Data: s.featureFlags()}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to encode features: %v", err)
+		return
+	}
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
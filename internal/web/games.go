@@ -0,0 +1,63 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// GameStatus is the target game in progress or last finished, if any
+type GameStatus struct {
+	Game *core.GameState `json:"game"`
+}
+
+func (s *Server) broadcastGameState(state *core.GameState) {
+	msg := WSMessage{Type: "game", Data: state}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) handleGame(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(GameStatus{Game: s.gameManager.State()})
+}
+
+// handleGameStart starts a game, replacing any game in progress
+func (s *Server) handleGameStart(w http.ResponseWriter, r *http.Request) {
+	var config core.GameConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	state, err := s.gameManager.Start(config)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+func (s *Server) handleGameStop(w http.ResponseWriter, r *http.Request) {
+	s.gameManager.Stop()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleGameLeaderboard(w http.ResponseWriter, r *http.Request) {
+	mode := core.GameMode(r.URL.Query().Get("mode"))
+	if mode == "" {
+		mode = core.GameClosestToPin
+	}
+	if !mode.Valid() {
+		http.Error(w, i18n.Tf("Invalid %s value", "mode"), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gameManager.Leaderboard(mode))
+}
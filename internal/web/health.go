@@ -0,0 +1,116 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/config"
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/logging"
+)
+
+// Health is the connector's health for external monitoring. Checks are keyed
+// by dependency: bluetooth, device, gspro, camera, logDisk and dataDisk.
+type Health struct {
+	Status    core.HealthStatus           `json:"status"`
+	CheckedAt time.Time                   `json:"checkedAt"`
+	Checks    map[string]core.HealthCheck `json:"checks"`
+}
+
+// handleHealth checks each dependency. The response is a 503 when any check
+// fails, so monitors that only look at the status code still notice.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]core.HealthCheck{
+		"bluetooth": s.bluetoothHealth(),
+		"device":    s.deviceHealth(),
+		"gspro":     s.gsproHealth(),
+		"camera":    s.cameraHealth(r.Context()),
+		"logDisk":   core.CheckDiskSpace(logging.GetLogDirectory()),
+		"dataDisk":  core.CheckDiskSpace(config.GetInstance().DataDir()),
+	}
+	health := Health{
+		Status:    core.OverallHealth(checks),
+		CheckedAt: time.Now(),
+		Checks:    checks,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if health.Status == core.HealthFail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(health)
+}
+
+func (s *Server) bluetoothHealth() core.HealthCheck {
+	switch {
+	case s.simulator != nil:
+		return core.HealthCheck{Status: core.HealthOK, Detail: "simulated device"}
+	case s.bluetoothManager.GetClient() == nil:
+		return core.HealthCheck{Status: core.HealthFail, Detail: "Bluetooth adapter unavailable"}
+	}
+	return core.HealthCheck{Status: core.HealthOK}
+}
+
+// deviceHealth warns while the launch monitor is not connected, since it may
+// just be switched off, and fails when connecting to it went wrong
+func (s *Server) deviceHealth() core.HealthCheck {
+	switch status := s.stateManager.GetConnectionStatus(); status {
+	case core.ConnectionStatusConnected:
+		check := core.HealthCheck{Status: core.HealthOK}
+		if name := s.stateManager.GetDeviceDisplayName(); name != nil {
+			check.Detail = *name
+		}
+		return check
+	case core.ConnectionStatusError:
+		return core.HealthCheck{Status: core.HealthFail, Detail: errorDetail(s.stateManager.GetLastError(), string(status))}
+	default:
+		return core.HealthCheck{Status: core.HealthWarn, Detail: string(status)}
+	}
+}
+
+// gsproHealth only counts GSPro as in use when it connects automatically
+// or is connected now
+func (s *Server) gsproHealth() core.HealthCheck {
+	status := s.stateManager.GetGSProStatus()
+	switch status {
+	case core.GSProStatusConnected:
+		return core.HealthCheck{Status: core.HealthOK}
+	case core.GSProStatusError:
+		return core.HealthCheck{Status: core.HealthFail, Detail: errorDetail(s.stateManager.GetGSProError(), string(status))}
+	}
+	if !config.GetInstance().GetSettings().GSProAutoConnect {
+		return core.HealthCheck{Status: core.HealthOff, Detail: string(status)}
+	}
+	return core.HealthCheck{Status: core.HealthWarn, Detail: string(status)}
+}
+
+// cameraHealth fails when any enabled camera can't be reached
+func (s *Server) cameraHealth(ctx context.Context) core.HealthCheck {
+	if !s.features.Enabled(core.FeatureExternalCamera) || !s.cameraManager.IsEnabled() {
+		return core.HealthCheck{Status: core.HealthOff}
+	}
+
+	statuses := s.cameraManager.Statuses(ctx)
+	var unreachable []string
+	for _, status := range statuses {
+		if !status.Reachable {
+			unreachable = append(unreachable, fmt.Sprintf("%s: %s", status.Name, status.Error))
+		}
+	}
+	if len(unreachable) > 0 {
+		return core.HealthCheck{Status: core.HealthFail, Detail: strings.Join(unreachable, "; ")}
+	}
+	return core.HealthCheck{Status: core.HealthOK, Detail: fmt.Sprintf("%d reachable", len(statuses))}
+}
+
+func errorDetail(err error, fallback string) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fallback
+}
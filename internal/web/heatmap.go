@@ -0,0 +1,115 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// heatmapCellPixels is the width and height of one cell in the PNG
+const heatmapCellPixels = 24
+
+var (
+	heatmapBackground = color.RGBA{0x1e, 0x1e, 0x1e, 0xff}
+	heatmapZoneColor  = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	heatmapCold       = color.RGBA{0x1f, 0x4e, 0xb4, 0xff}
+	heatmapWarm       = color.RGBA{0xf5, 0xc5, 0x18, 0xff}
+	heatmapHot        = color.RGBA{0xe0, 0x2f, 0x1f, 0xff}
+)
+
+// handleAnalyticsBallPosition reports where the ball sat while ready this
+// session, as JSON or as a PNG with the placement zone outlined
+func (s *Server) handleAnalyticsBallPosition(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	cellSize := core.DefaultHeatmapCellSize
+	if cellParam := query.Get("cell"); cellParam != "" {
+		value, err := strconv.Atoi(cellParam)
+		if err != nil || value < core.MinHeatmapCellSize || value > core.MaxHeatmapCellSize {
+			http.Error(w, i18n.Tf("Invalid %s", "cell"), http.StatusBadRequest)
+			return
+		}
+		cellSize = value
+	}
+
+	heatmap := s.ballHeatmap.Heatmap(int32(cellSize), s.placement.Zone())
+	if query.Get("format") != "png" {
+		writeJSONWithETag(w, r, heatmap)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, renderHeatmap(heatmap)); err != nil {
+		log.Printf("Heatmap PNG render failed: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(buf.Bytes())
+}
+
+// handleAnalyticsBallPositionReset starts a new heatmap session
+func (s *Server) handleAnalyticsBallPositionReset(w http.ResponseWriter, r *http.Request) {
+	s.ballHeatmap.Reset()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.ballHeatmap.Heatmap(core.DefaultHeatmapCellSize, s.placement.Zone()))
+}
+
+// renderHeatmap draws each cell shaded from blue through yellow to red by
+// its share of the busiest cell, with the placement zone outlined
+func renderHeatmap(heatmap core.BallHeatmap) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, heatmap.Columns*heatmapCellPixels, heatmap.Rows*heatmapCellPixels))
+	for row, counts := range heatmap.Counts {
+		for column, count := range counts {
+			fill := heatmapBackground
+			if count > 0 {
+				fill = heatColor(float64(count) / float64(heatmap.MaxCount))
+			}
+			for y := row * heatmapCellPixels; y < (row+1)*heatmapCellPixels; y++ {
+				for x := column * heatmapCellPixels; x < (column+1)*heatmapCellPixels; x++ {
+					img.SetRGBA(x, y, fill)
+				}
+			}
+		}
+	}
+
+	toPixels := func(offset int32) int {
+		return int(int64(offset) * heatmapCellPixels / int64(heatmap.CellSize))
+	}
+	zone := heatmap.Zone
+	left, right := toPixels(zone.MinX-heatmap.MinX), toPixels(zone.MaxX-heatmap.MinX)
+	top, bottom := toPixels(heatmap.MaxY-zone.MaxY), toPixels(heatmap.MaxY-zone.MinY)
+	for x := left; x <= right; x++ {
+		img.SetRGBA(x, top, heatmapZoneColor)
+		img.SetRGBA(x, bottom, heatmapZoneColor)
+	}
+	for y := top; y <= bottom; y++ {
+		img.SetRGBA(left, y, heatmapZoneColor)
+		img.SetRGBA(right, y, heatmapZoneColor)
+	}
+	return img
+}
+
+// heatColor blends from cold to warm over the first half of the scale and
+// from warm to hot over the second
+func heatColor(share float64) color.RGBA {
+	if share <= 0.5 {
+		return blend(heatmapCold, heatmapWarm, share*2)
+	}
+	return blend(heatmapWarm, heatmapHot, (share-0.5)*2)
+}
+
+func blend(from, to color.RGBA, t float64) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+	return color.RGBA{mix(from.R, to.R), mix(from.G, to.G), mix(from.B, to.B), 0xff}
+}
@@ -0,0 +1,79 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/brentyates/squaregolf-connector/internal/logging"
+)
+
+// logStreamKeepAlive keeps idle log streams open through proxies
+const logStreamKeepAlive = 15 * time.Second
+
+// handleLogsDownload sends the current and rotated logs as a zip archive
+func (s *Server) handleLogsDownload(w http.ResponseWriter, r *http.Request) {
+	filename := "connector-logs-" + time.Now().Format("20060102-150405") + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	// Headers are already sent, so a failure can only be logged
+	if err := logging.WriteArchive(w); err != nil {
+		log.Printf("Failed to send log archive: %v", err)
+	}
+}
+
+// handleLogsStream sends the application log as Server-Sent Events, starting
+// with recent lines. The level query parameter (debug, info, warn, error)
+// hides lines below that level.
+func (s *Server) handleLogsStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, i18n.T("Streaming not supported"), http.StatusInternalServerError)
+		return
+	}
+
+	sub, err := logging.Subscribe(r.URL.Query().Get("level"))
+	if err != nil {
+		http.Error(w, i18n.Tf("Invalid %s", "level"), http.StatusBadRequest)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	for _, line := range sub.Recent {
+		writeLogEvent(w, line)
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(logStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line, ok := <-sub.Lines:
+			if !ok {
+				return
+			}
+			writeLogEvent(w, line)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeLogEvent(w http.ResponseWriter, line logging.Line) {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
@@ -0,0 +1,314 @@
+package web
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	mdnsServiceType  = "_squaregolf._tcp.local."
+	mdnsServicesEnum = "_services._dns-sd._udp.local."
+	mdnsTTL          = 120
+
+	dnsTypeA   = 1
+	dnsTypePTR = 12
+	dnsTypeTXT = 16
+	dnsTypeSRV = 33
+	dnsTypeANY = 255
+
+	dnsClassIN         = 1
+	dnsClassCacheFlush = 0x8000
+)
+
+var mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// mdnsAdvertiser answers mDNS queries for the web UI so devices on the LAN can
+// discover it as a _squaregolf._tcp service.
+type mdnsAdvertiser struct {
+	instance string
+	host     string
+	port     int
+	txt      func() []string
+
+	conn     *net.UDPConn
+	mu       sync.Mutex
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newMDNSAdvertiser(port int, txt func() []string) *mdnsAdvertiser {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "squaregolf-connector"
+	}
+	hostname = strings.TrimSuffix(strings.Split(hostname, ".")[0], ".")
+
+	return &mdnsAdvertiser{
+		instance: fmt.Sprintf("SquareGolf Connector (%s).%s", hostname, mdnsServiceType),
+		host:     hostname + ".local.",
+		port:     port,
+		txt:      txt,
+	}
+}
+
+// Start joins the mDNS multicast group and announces the service.
+func (a *mdnsAdvertiser) Start() error {
+	conn, err := net.ListenMulticastUDP("udp4", nil, mdnsGroup)
+	if err != nil {
+		return fmt.Errorf("failed to join mDNS group: %w", err)
+	}
+
+	a.mu.Lock()
+	a.conn = conn
+	a.stopChan = make(chan struct{})
+	a.mu.Unlock()
+
+	a.wg.Add(2)
+	go a.readLoop(conn)
+	go func() {
+		defer a.wg.Done()
+		// RFC 6762 recommends at least two announcements one second apart
+		for i := 0; i < 2; i++ {
+			a.Announce()
+			select {
+			case <-a.stopChan:
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+
+	log.Printf("mDNS: advertising %s on port %d", a.instance, a.port)
+	return nil
+}
+
+// Announce sends an unsolicited response with the current records, e.g. after
+// the TXT metadata changed.
+func (a *mdnsAdvertiser) Announce() {
+	a.send(a.buildResponse(mdnsTTL))
+}
+
+// Stop sends a goodbye packet and leaves the multicast group.
+func (a *mdnsAdvertiser) Stop() {
+	a.mu.Lock()
+	conn := a.conn
+	stopChan := a.stopChan
+	a.mu.Unlock()
+
+	if conn == nil {
+		return
+	}
+
+	a.send(a.buildResponse(0))
+	close(stopChan)
+	conn.Close()
+	a.wg.Wait()
+
+	a.mu.Lock()
+	a.conn = nil
+	a.mu.Unlock()
+}
+
+func (a *mdnsAdvertiser) send(packet []byte) {
+	a.mu.Lock()
+	conn := a.conn
+	a.mu.Unlock()
+
+	if conn == nil {
+		return
+	}
+	if _, err := conn.WriteToUDP(packet, mdnsGroup); err != nil {
+		log.Printf("mDNS: failed to send response: %v", err)
+	}
+}
+
+func (a *mdnsAdvertiser) readLoop(conn *net.UDPConn) {
+	defer a.wg.Done()
+
+	buf := make([]byte, 9000)
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		if a.matchesQuery(buf[:n]) {
+			a.send(a.buildResponse(mdnsTTL))
+		}
+	}
+}
+
+// matchesQuery reports whether a packet is a query for one of our records.
+func (a *mdnsAdvertiser) matchesQuery(packet []byte) bool {
+	if len(packet) < 12 {
+		return false
+	}
+	// Ignore responses from other hosts
+	if packet[2]&0x80 != 0 {
+		return false
+	}
+
+	qdCount := int(binary.BigEndian.Uint16(packet[4:6]))
+	offset := 12
+	for i := 0; i < qdCount; i++ {
+		name, next, err := readDNSName(packet, offset)
+		if err != nil || next+4 > len(packet) {
+			return false
+		}
+		qtype := binary.BigEndian.Uint16(packet[next : next+2])
+		offset = next + 4
+
+		switch {
+		case strings.EqualFold(name, mdnsServiceType) && (qtype == dnsTypePTR || qtype == dnsTypeANY):
+			return true
+		case strings.EqualFold(name, mdnsServicesEnum) && (qtype == dnsTypePTR || qtype == dnsTypeANY):
+			return true
+		case strings.EqualFold(name, a.instance):
+			return true
+		case strings.EqualFold(name, a.host) && (qtype == dnsTypeA || qtype == dnsTypeANY):
+			return true
+		}
+	}
+	return false
+}
+
+func (a *mdnsAdvertiser) buildResponse(ttl uint32) []byte {
+	var records [][]byte
+
+	records = append(records, dnsRecord(mdnsServiceType, dnsTypePTR, dnsClassIN, ttl, encodeDNSName(a.instance)))
+	records = append(records, dnsRecord(mdnsServicesEnum, dnsTypePTR, dnsClassIN, ttl, encodeDNSName(mdnsServiceType)))
+
+	srv := make([]byte, 6)
+	binary.BigEndian.PutUint16(srv[4:6], uint16(a.port))
+	srv = append(srv, encodeDNSName(a.host)...)
+	records = append(records, dnsRecord(a.instance, dnsTypeSRV, dnsClassIN|dnsClassCacheFlush, ttl, srv))
+
+	var txt []byte
+	if a.txt != nil {
+		for _, entry := range a.txt() {
+			if len(entry) > 255 {
+				entry = entry[:255]
+			}
+			txt = append(txt, byte(len(entry)))
+			txt = append(txt, entry...)
+		}
+	}
+	if len(txt) == 0 {
+		txt = []byte{0}
+	}
+	records = append(records, dnsRecord(a.instance, dnsTypeTXT, dnsClassIN|dnsClassCacheFlush, ttl, txt))
+
+	for _, ip := range localIPv4Addresses() {
+		records = append(records, dnsRecord(a.host, dnsTypeA, dnsClassIN|dnsClassCacheFlush, ttl, ip))
+	}
+
+	header := make([]byte, 12)
+	// Authoritative response, ID zero as required for multicast replies
+	binary.BigEndian.PutUint16(header[2:4], 0x8400)
+	binary.BigEndian.PutUint16(header[6:8], uint16(len(records)))
+
+	packet := header
+	for _, record := range records {
+		packet = append(packet, record...)
+	}
+	return packet
+}
+
+func dnsRecord(name string, rrType uint16, class uint16, ttl uint32, data []byte) []byte {
+	record := encodeDNSName(name)
+	fixed := make([]byte, 10)
+	binary.BigEndian.PutUint16(fixed[0:2], rrType)
+	binary.BigEndian.PutUint16(fixed[2:4], class)
+	binary.BigEndian.PutUint32(fixed[4:8], ttl)
+	binary.BigEndian.PutUint16(fixed[8:10], uint16(len(data)))
+	record = append(record, fixed...)
+	return append(record, data...)
+}
+
+// encodeDNSName encodes a fully qualified name. The first label of a service
+// instance name may contain dots and spaces, so it is split off the known
+// service suffix rather than on every dot.
+func encodeDNSName(name string) []byte {
+	var labels []string
+	if strings.HasSuffix(name, "."+mdnsServiceType) {
+		labels = append(labels, strings.TrimSuffix(name, "."+mdnsServiceType))
+		name = mdnsServiceType
+	}
+	labels = append(labels, strings.Split(strings.TrimSuffix(name, "."), ".")...)
+
+	var out []byte
+	for _, label := range labels {
+		if label == "" {
+			continue
+		}
+		if len(label) > 63 {
+			label = label[:63]
+		}
+		out = append(out, byte(len(label)))
+		out = append(out, label...)
+	}
+	return append(out, 0)
+}
+
+// readDNSName decodes a possibly compressed name starting at offset and
+// returns it with a trailing dot along with the offset after the name.
+func readDNSName(packet []byte, offset int) (string, int, error) {
+	var labels []string
+	next := -1
+	for jumps := 0; jumps < 16; {
+		if offset >= len(packet) {
+			return "", 0, errors.New("name out of range")
+		}
+		length := int(packet[offset])
+		switch {
+		case length == 0:
+			if next < 0 {
+				next = offset + 1
+			}
+			return strings.Join(labels, ".") + ".", next, nil
+		case length&0xC0 == 0xC0:
+			if offset+1 >= len(packet) {
+				return "", 0, errors.New("pointer out of range")
+			}
+			if next < 0 {
+				next = offset + 2
+			}
+			offset = int(binary.BigEndian.Uint16(packet[offset:offset+2]) & 0x3FFF)
+			jumps++
+		default:
+			if offset+1+length > len(packet) {
+				return "", 0, errors.New("label out of range")
+			}
+			labels = append(labels, string(packet[offset+1:offset+1+length]))
+			offset += 1 + length
+		}
+	}
+	return "", 0, errors.New("too many compression pointers")
+}
+
+func localIPv4Addresses() [][]byte {
+	var ips [][]byte
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return ips
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() {
+			continue
+		}
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			ips = append(ips, []byte(ip4))
+		}
+	}
+	return ips
+}
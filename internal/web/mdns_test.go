@@ -0,0 +1,225 @@
+package web
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type dnsTestRecord struct {
+	name  string
+	rtype uint16
+	class uint16
+	ttl   uint32
+	data  []byte
+	// dataOffset is where data starts in the packet, for names inside it
+	dataOffset int
+}
+
+// parseResponse decodes the answers of a response built by buildResponse
+func parseResponse(t *testing.T, packet []byte) []dnsTestRecord {
+	t.Helper()
+	if len(packet) < 12 {
+		t.Fatalf("packet of %d bytes is too short", len(packet))
+	}
+	if flags := binary.BigEndian.Uint16(packet[2:4]); flags != 0x8400 {
+		t.Errorf("flags = %#x, want an authoritative response", flags)
+	}
+	var records []dnsTestRecord
+	offset := 12
+	for i := 0; i < int(binary.BigEndian.Uint16(packet[6:8])); i++ {
+		name, next, err := readDNSName(packet, offset)
+		if err != nil || next+10 > len(packet) {
+			t.Fatalf("record %d: bad name: %v", i, err)
+		}
+		length := int(binary.BigEndian.Uint16(packet[next+8 : next+10]))
+		start := next + 10
+		if start+length > len(packet) {
+			t.Fatalf("record %d: data out of range", i)
+		}
+		records = append(records, dnsTestRecord{
+			name:       name,
+			rtype:      binary.BigEndian.Uint16(packet[next : next+2]),
+			class:      binary.BigEndian.Uint16(packet[next+2 : next+4]),
+			ttl:        binary.BigEndian.Uint32(packet[next+4 : next+8]),
+			data:       packet[start : start+length],
+			dataOffset: start,
+		})
+		offset = start + length
+	}
+	if offset != len(packet) {
+		t.Errorf("%d bytes left after the records", len(packet)-offset)
+	}
+	return records
+}
+
+func findRecord(records []dnsTestRecord, name string, rtype uint16) *dnsTestRecord {
+	for i := range records {
+		if records[i].name == name && records[i].rtype == rtype {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+func newTestAdvertiser(txt []string) *mdnsAdvertiser {
+	return &mdnsAdvertiser{
+		instance: "SquareGolf Connector (bay.3)." + mdnsServiceType,
+		host:     "bay3.local.",
+		port:     8080,
+		txt:      func() []string { return txt },
+	}
+}
+
+func TestMDNSAdvertiser_ServiceRecords(t *testing.T) {
+	a := newTestAdvertiser(nil)
+	packet := a.buildResponse(mdnsTTL)
+	records := parseResponse(t, packet)
+
+	ptr := findRecord(records, mdnsServiceType, dnsTypePTR)
+	if ptr == nil {
+		t.Fatal("Expected a PTR record for the service type")
+	}
+	if name, _, err := readDNSName(packet, ptr.dataOffset); err != nil || name != a.instance {
+		t.Errorf("PTR = %q, %v; want %q", name, err, a.instance)
+	}
+	// The dot in the instance name stays inside its first label
+	if label := string(ptr.data[1 : 1+ptr.data[0]]); label != "SquareGolf Connector (bay.3)" {
+		t.Errorf("instance label = %q", label)
+	}
+
+	enum := findRecord(records, mdnsServicesEnum, dnsTypePTR)
+	if enum == nil {
+		t.Fatal("Expected a PTR record for service enumeration")
+	}
+	if name, _, err := readDNSName(packet, enum.dataOffset); err != nil || name != mdnsServiceType {
+		t.Errorf("enumeration PTR = %q, %v; want %q", name, err, mdnsServiceType)
+	}
+
+	srv := findRecord(records, a.instance, dnsTypeSRV)
+	if srv == nil {
+		t.Fatal("Expected an SRV record for the instance")
+	}
+	if port := binary.BigEndian.Uint16(srv.data[4:6]); port != 8080 {
+		t.Errorf("SRV port = %d, want 8080", port)
+	}
+	if host, _, err := readDNSName(packet, srv.dataOffset+6); err != nil || host != "bay3.local." {
+		t.Errorf("SRV target = %q, %v; want bay3.local.", host, err)
+	}
+	if srv.class != dnsClassIN|dnsClassCacheFlush {
+		t.Errorf("SRV class = %#x, want IN with cache flush", srv.class)
+	}
+
+	for _, record := range records {
+		if record.ttl != mdnsTTL {
+			t.Errorf("%s type %d TTL = %d, want %d", record.name, record.rtype, record.ttl, mdnsTTL)
+		}
+		if record.rtype == dnsTypeA && (record.name != "bay3.local." || len(record.data) != 4) {
+			t.Errorf("A record %s with %d bytes, want an IPv4 address for the host", record.name, len(record.data))
+		}
+	}
+}
+
+func TestMDNSAdvertiser_TXTRecord(t *testing.T) {
+	long := "note=" + strings.Repeat("x", 300)
+	a := newTestAdvertiser([]string{"version=1.2.0", "path=/", long})
+	txt := findRecord(parseResponse(t, a.buildResponse(mdnsTTL)), a.instance, dnsTypeTXT)
+	if txt == nil {
+		t.Fatal("Expected a TXT record for the instance")
+	}
+
+	var entries []string
+	for data := txt.data; len(data) > 0; {
+		n := int(data[0])
+		if 1+n > len(data) {
+			t.Fatalf("TXT entry of %d bytes runs past the record", n)
+		}
+		entries = append(entries, string(data[1:1+n]))
+		data = data[1+n:]
+	}
+	if want := []string{"version=1.2.0", "path=/", long[:255]}; !reflect.DeepEqual(entries, want) {
+		t.Errorf("TXT entries = %q, want %q", entries, want)
+	}
+
+	// A TXT record may not be empty, so no metadata is a single empty string
+	empty := newTestAdvertiser(nil)
+	txt = findRecord(parseResponse(t, empty.buildResponse(mdnsTTL)), empty.instance, dnsTypeTXT)
+	if txt == nil || !reflect.DeepEqual(txt.data, []byte{0}) {
+		t.Errorf("empty TXT = %v, want a single zero byte", txt)
+	}
+}
+
+func TestMDNSAdvertiser_GoodbyeHasZeroTTL(t *testing.T) {
+	a := newTestAdvertiser([]string{"version=1.2.0"})
+	for _, record := range parseResponse(t, a.buildResponse(0)) {
+		if record.ttl != 0 {
+			t.Errorf("%s type %d TTL = %d, want 0", record.name, record.rtype, record.ttl)
+		}
+	}
+}
+
+// dnsQuery builds a query packet for name and qtype
+func dnsQuery(name string, qtype uint16) []byte {
+	packet := make([]byte, 12)
+	binary.BigEndian.PutUint16(packet[4:6], 1)
+	packet = append(packet, encodeDNSName(name)...)
+	question := make([]byte, 4)
+	binary.BigEndian.PutUint16(question[0:2], qtype)
+	binary.BigEndian.PutUint16(question[2:4], dnsClassIN)
+	return append(packet, question...)
+}
+
+func TestMDNSAdvertiser_MatchesQuery(t *testing.T) {
+	a := newTestAdvertiser(nil)
+	tests := []struct {
+		name  string
+		qtype uint16
+		want  bool
+	}{
+		{mdnsServiceType, dnsTypePTR, true},
+		{strings.ToUpper(mdnsServiceType), dnsTypeANY, true},
+		{mdnsServicesEnum, dnsTypePTR, true},
+		{a.instance, dnsTypeTXT, true},
+		{"bay3.local.", dnsTypeA, true},
+		{"bay3.local.", dnsTypeTXT, false},
+		{"_http._tcp.local.", dnsTypePTR, false},
+	}
+	for _, tt := range tests {
+		if got := a.matchesQuery(dnsQuery(tt.name, tt.qtype)); got != tt.want {
+			t.Errorf("matchesQuery(%s, %d) = %v, want %v", tt.name, tt.qtype, got, tt.want)
+		}
+	}
+
+	response := a.buildResponse(mdnsTTL)
+	if a.matchesQuery(response) {
+		t.Error("Expected responses from other hosts to be ignored")
+	}
+}
+
+func TestMDNSAdvertiser_StopReleasesTheResponder(t *testing.T) {
+	a := newTestAdvertiser(nil)
+	// Stopping before starting does nothing
+	a.Stop()
+
+	if err := a.Start(); err != nil {
+		t.Skipf("multicast is not available: %v", err)
+	}
+	conn := a.conn
+	a.Stop()
+
+	if a.conn != nil {
+		t.Error("Expected the connection cleared")
+	}
+	if _, err := conn.WriteToUDP([]byte{0}, mdnsGroup); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("write after Stop error = %v, want the socket closed", err)
+	}
+	// A second Stop is harmless and the advertiser can start again
+	a.Stop()
+	if err := a.Start(); err != nil {
+		t.Fatalf("Start() after Stop error = %v", err)
+	}
+	a.Stop()
+}
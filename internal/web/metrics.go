@@ -0,0 +1,38 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+)
+
+type Metrics struct {
+	Latency       LatencyMetrics         `json:"latency"`
+	Notifications core.NotificationStats `json:"notifications"`
+	Tasks         []core.TaskStatus      `json:"tasks"`
+	TaskRestarts  int                    `json:"taskRestarts"`
+}
+
+type LatencyMetrics struct {
+	Summary core.LatencySummary `json:"summary"`
+	Shots   []core.ShotLatency  `json:"shots"`
+}
+
+// handleMetrics reports how long recent shots took from BLE notification to
+// each simulator, how many notification frames of each header were parsed or
+// dropped, and which background tasks have been restarted
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	latency := s.launchMonitor.Latency()
+	metrics := Metrics{
+		Latency: LatencyMetrics{
+			Summary: latency.Summary(),
+			Shots:   latency.Recent(),
+		},
+		Notifications: s.launchMonitor.Notifications().Stats(),
+		Tasks:         s.supervisor.Tasks(),
+		TaskRestarts:  s.stateManager.GetTaskRestarts(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(metrics)
+}
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/gorilla/mux"
+)
+
+type MisreadResolveRequest struct {
+	Action core.MisreadAction `json:"action"`
+}
+
+// misreadShots returns the held shots with their reasons in the selected locale
+func (s *Server) misreadShots() []core.MisreadShot {
+	shots := s.stateManager.GetMisreadShots()
+	for i := range shots {
+		shots[i].Reason = i18n.T(shots[i].Reason)
+	}
+	return shots
+}
+
+func (s *Server) broadcastMisreads() {
+	msg := WSMessage{Type: "misreads", Data: s.misreadShots()}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) handleMisreads(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.misreadShots())
+}
+
+func (s *Server) handleMisreadResolve(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, i18n.T("Invalid shot id"), http.StatusBadRequest)
+		return
+	}
+
+	var req MisreadResolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.launchMonitor.ResolveMisread(id, req.Action); err != nil {
+		if errors.Is(err, core.ErrMisreadNotFound) {
+			http.Error(w, i18n.Error(err), http.StatusNotFound)
+			return
+		}
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
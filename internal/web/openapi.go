@@ -0,0 +1,352 @@
+package web
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/version"
+)
+
+// The OpenAPI 3.0 document types cover only what the connector's API uses
+
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Servers    []OpenAPIServer            `json:"servers"`
+	Tags       []OpenAPITag               `json:"tags"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+type OpenAPITag struct {
+	Name string `json:"name"`
+}
+
+// OpenAPIPathItem holds a path's operations keyed by lower case method
+type OpenAPIPathItem map[string]*OpenAPIOperation
+
+type OpenAPIOperation struct {
+	Tags        []string                   `json:"tags,omitempty"`
+	Summary     string                     `json:"summary"`
+	OperationID string                     `json:"operationId"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIRequestBody struct {
+	Content map[string]OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema is a JSON schema. The zero value accepts any value.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+func (s *Server) handleAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(buildOpenAPIDocument(s.apiRoutes()))
+}
+
+// muxPathVariable matches a gorilla/mux path variable with an optional pattern
+var muxPathVariable = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// buildOpenAPIDocument describes routes. Request and response types become
+// shared component schemas named after the Go types.
+func buildOpenAPIDocument(routes []apiRoute) OpenAPIDocument {
+	schemas := newSchemaBuilder()
+	doc := OpenAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: OpenAPIInfo{
+			Title:       core.AppName + " API",
+			Description: "Controls the connector and reads shot data. Errors are returned as a plain text message, except where an operation lists invalid fields. Once access tokens are set, requests from other machines send one as a Bearer token or a token query parameter.",
+			Version:     version.GetShortVersion(),
+		},
+		Servers: []OpenAPIServer{{URL: APIPrefix}},
+		Paths:   make(map[string]OpenAPIPathItem),
+	}
+
+	seenTags := make(map[string]bool)
+	for _, route := range routes {
+		if route.Tag != "" && !seenTags[route.Tag] {
+			seenTags[route.Tag] = true
+			doc.Tags = append(doc.Tags, OpenAPITag{Name: route.Tag})
+		}
+
+		specPath := muxPathVariable.ReplaceAllString(route.Path, "{$1}")
+		op := &OpenAPIOperation{
+			Summary:     route.Summary,
+			OperationID: operationID(route.Method, specPath),
+			Responses:   make(map[string]OpenAPIResponse),
+		}
+		if route.Tag != "" {
+			op.Tags = []string{route.Tag}
+		}
+
+		for _, param := range route.Params {
+			paramType := param.Type
+			if paramType == "" {
+				paramType = "string"
+			}
+			op.Parameters = append(op.Parameters, OpenAPIParameter{
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.In == "path",
+				Schema:      &OpenAPISchema{Type: paramType},
+			})
+		}
+
+		if route.Request != nil {
+			op.RequestBody = &OpenAPIRequestBody{Content: map[string]OpenAPIMediaType{
+				"application/json": {Schema: schemas.schema(reflect.TypeOf(route.Request))},
+			}}
+		}
+
+		success := OpenAPIResponse{Description: "Success"}
+		switch {
+		case route.ContentType != "":
+			success.Content = map[string]OpenAPIMediaType{
+				route.ContentType: {Schema: &OpenAPISchema{Type: "string", Format: "binary"}},
+			}
+		case route.Response != nil:
+			success.Content = map[string]OpenAPIMediaType{
+				"application/json": {Schema: schemas.schema(reflect.TypeOf(route.Response))},
+			}
+		}
+		op.Responses["200"] = success
+		if route.Invalid != nil {
+			op.Responses["400"] = OpenAPIResponse{
+				Description: "Invalid fields",
+				Content: map[string]OpenAPIMediaType{
+					"application/json": {Schema: schemas.schema(reflect.TypeOf(route.Invalid))},
+				},
+			}
+		}
+		if route.scope() == core.AccessOperator {
+			op.Responses["403"] = OpenAPIResponse{
+				Description: "The access token is a viewer token; this operation needs an operator token",
+				Content: map[string]OpenAPIMediaType{
+					"text/plain": {Schema: &OpenAPISchema{Type: "string"}},
+				},
+			}
+		}
+		if route.Feature != "" {
+			op.Responses["404"] = OpenAPIResponse{
+				Description: "The " + string(route.Feature) + " feature is turned off",
+				Content: map[string]OpenAPIMediaType{
+					"text/plain": {Schema: &OpenAPISchema{Type: "string"}},
+				},
+			}
+		}
+		op.Responses["default"] = OpenAPIResponse{
+			Description: "Error",
+			Content: map[string]OpenAPIMediaType{
+				"text/plain": {Schema: &OpenAPISchema{Type: "string"}},
+			},
+		}
+
+		item := doc.Paths[specPath]
+		if item == nil {
+			item = make(OpenAPIPathItem)
+			doc.Paths[specPath] = item
+		}
+		item[strings.ToLower(route.Method)] = op
+	}
+
+	doc.Components.Schemas = schemas.components
+	return doc
+}
+
+// operationID names an operation after its method and path, such as
+// postDeviceConnect for POST /device/connect
+func operationID(method, specPath string) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(method))
+	upper := true
+	for _, r := range specPath {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		id.WriteRune(r)
+	}
+	return id.String()
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaBuilder turns Go types into schemas following encoding/json's rules
+type schemaBuilder struct {
+	components map[string]*OpenAPISchema
+	names      map[reflect.Type]string
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		components: make(map[string]*OpenAPISchema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) *OpenAPISchema {
+	switch {
+	case t == timeType:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &OpenAPISchema{}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// Custom JSON can't be described from the type
+		return &OpenAPISchema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &OpenAPISchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem := *b.schema(t.Elem())
+		if elem.Ref == "" {
+			elem.Nullable = true
+		}
+		return &elem
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + b.component(t)}
+	default:
+		return &OpenAPISchema{}
+	}
+}
+
+// component adds a named struct to the components and returns its name. The
+// package is added to the name if another package has a type of that name.
+func (b *schemaBuilder) component(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := b.components[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	b.names[t] = name
+	// Reserve the name before building so recursive types refer to it
+	b.components[name] = &OpenAPISchema{}
+	*b.components[name] = *b.structSchema(t)
+	return name
+}
+
+func (b *schemaBuilder) structSchema(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	b.addFields(schema, t)
+	return schema
+}
+
+// addFields adds t's JSON fields to schema. Untagged embedded structs are
+// flattened into the parent as encoding/json does.
+func (b *schemaBuilder) addFields(schema *OpenAPISchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				b.addFields(schema, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if strings.Contains(opts, "string") {
+			schema.Properties[name] = &OpenAPISchema{Type: "string"}
+		} else {
+			schema.Properties[name] = b.schema(fieldType)
+		}
+	}
+}
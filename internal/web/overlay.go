@@ -0,0 +1,381 @@
+package web
+
+import (
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/gorilla/websocket"
+)
+
+// OverlayShot is the compact shot summary shown on second screens and stream overlays.
+type OverlayShot struct {
+	ShotNumber       int       `json:"shotNumber"`
+	ShotID           int64     `json:"shotId,omitempty"` // matches the shot's ball and club metrics
+	Timestamp        time.Time `json:"timestamp"`
+	SpeedUnit        string    `json:"speedUnit"` // "mph" or "mps", from the number format setting
+	BallSpeed        float64   `json:"ballSpeed"`
+	LaunchAngle      float64   `json:"launchAngle"`
+	HorizontalAngle  float64   `json:"horizontalAngle"`
+	TotalSpin        int16     `json:"totalSpin"`
+	SpinAxis         float64   `json:"spinAxis"`
+	BackSpin         int16     `json:"backSpin"`
+	SideSpin         int16     `json:"sideSpin"`
+	ClubSpeed        *float64  `json:"clubSpeed,omitempty"`
+	SmashFactor      *float64  `json:"smashFactor,omitempty"`
+	Path             *float64  `json:"path,omitempty"`
+	FaceAngle        *float64  `json:"faceAngle,omitempty"`
+	AttackAngle      *float64  `json:"attackAngle,omitempty"`
+	SpinLoft         *float64  `json:"spinLoft,omitempty"`
+	LaunchEfficiency *float64  `json:"launchEfficiency,omitempty"` // launch angle over dynamic loft
+
+	ballSpeedMPS float64
+	clubSpeedMPS *float64
+}
+
+// inFormat returns a copy of the shot with its speeds in the format's unit
+func (shot OverlayShot) inFormat(format i18n.NumberFormat) *OverlayShot {
+	shot.SpeedUnit = format.SpeedUnit
+	shot.BallSpeed = format.Speed(shot.ballSpeedMPS)
+	if shot.clubSpeedMPS != nil {
+		speed := format.Speed(*shot.clubSpeedMPS)
+		shot.ClubSpeed = &speed
+	}
+	return &shot
+}
+
+// OverlayState is the read-only feed sent to overlay viewers.
+type OverlayState struct {
+	Connected        bool         `json:"connected"`
+	BallDetected     bool         `json:"ballDetected"`
+	BallReady        bool         `json:"ballReady"`
+	LastShot         *OverlayShot `json:"lastShot"`
+	SpeedLabel       string       `json:"speedLabel"`       // e.g. "mph" or "m/s"
+	DecimalSeparator string       `json:"decimalSeparator"` // "." or ","
+}
+
+// overlayHub fans overlay updates out to read-only viewers. It is kept apart
+// from the main WebSocket clients so viewers never receive control state.
+// Its last shot is the one the shot history stored, with the ball and club
+// metrics already merged, so consumers never see a half-updated shot.
+type overlayHub struct {
+	clients map[*websocket.Conn]chan []byte
+	mu      sync.Mutex
+
+	shotMu    sync.Mutex
+	lastShot  *OverlayShot
+	shotCount int
+}
+
+func newOverlayHub() *overlayHub {
+	return &overlayHub{clients: make(map[*websocket.Conn]chan []byte)}
+}
+
+func (h *overlayHub) add(conn *websocket.Conn) chan []byte {
+	ch := make(chan []byte, 16)
+	h.mu.Lock()
+	h.clients[conn] = ch
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *overlayHub) remove(conn *websocket.Conn) {
+	h.mu.Lock()
+	if ch, exists := h.clients[conn]; exists {
+		delete(h.clients, conn)
+		close(ch)
+	}
+	h.mu.Unlock()
+}
+
+// closeAll disconnects every viewer. Each handler then removes its own entry.
+func (h *overlayHub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.clients {
+		conn.Close()
+	}
+}
+
+func (h *overlayHub) publish(data []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ch := range h.clients {
+		select {
+		case ch <- data:
+		default:
+			// Viewer is behind; it will catch up on the next update
+		}
+	}
+}
+
+// setLastShot makes a stored shot the last shot
+func (h *overlayHub) setLastShot(stored history.Shot) {
+	h.shotMu.Lock()
+	defer h.shotMu.Unlock()
+
+	h.shotCount++
+	shot := buildOverlayShot(&stored.Ball, stored.ClubMetrics)
+	shot.ShotNumber = h.shotCount
+	shot.Timestamp = stored.Timestamp
+	h.lastShot = shot
+}
+
+func (h *overlayHub) getLastShot() *OverlayShot {
+	h.shotMu.Lock()
+	defer h.shotMu.Unlock()
+	if h.lastShot == nil {
+		return nil
+	}
+	return h.lastShot.inFormat(i18n.CurrentNumberFormat())
+}
+
+func buildOverlayShot(ball *core.BallMetrics, club *core.ClubMetrics) *OverlayShot {
+	if ball == nil {
+		return nil
+	}
+
+	shot := &OverlayShot{
+		ShotID:          ball.ShotID,
+		ballSpeedMPS:    ball.BallSpeedMPS,
+		LaunchAngle:     ball.VerticalAngle,
+		HorizontalAngle: ball.HorizontalAngle,
+		TotalSpin:       ball.TotalspinRPM,
+		SpinAxis:        ball.SpinAxis,
+		BackSpin:        ball.BackspinRPM,
+		SideSpin:        ball.SidespinRPM,
+	}
+
+	if club != nil {
+		if club.IsClubSpeedValid {
+			speed := club.ClubSpeed
+			shot.clubSpeedMPS = &speed
+		}
+		if club.IsSmashFactorValid {
+			smash := club.SmashFactor
+			shot.SmashFactor = &smash
+		}
+		if club.IsPathAngleValid {
+			path := club.PathAngle
+			shot.Path = &path
+		}
+		if club.IsFaceAngleValid {
+			face := club.FaceAngle
+			shot.FaceAngle = &face
+		}
+		if club.IsAttackAngleValid {
+			attack := club.AttackAngle
+			shot.AttackAngle = &attack
+		}
+		if club.IsSpinLoftValid {
+			spinLoft := club.SpinLoft
+			shot.SpinLoft = &spinLoft
+		}
+		if club.IsLaunchEfficiencyValid {
+			efficiency := club.LaunchEfficiency
+			shot.LaunchEfficiency = &efficiency
+		}
+	}
+
+	return shot
+}
+
+func (s *Server) getOverlayState() OverlayState {
+	format := i18n.CurrentNumberFormat()
+	return OverlayState{
+		Connected:        s.stateManager.GetConnectionStatus() == core.ConnectionStatusConnected,
+		BallDetected:     s.stateManager.GetBallDetected(),
+		BallReady:        s.stateManager.GetBallReady(),
+		LastShot:         s.overlay.getLastShot(),
+		SpeedLabel:       format.SpeedLabel(),
+		DecimalSeparator: format.DecimalSeparator,
+	}
+}
+
+func (s *Server) broadcastOverlay() {
+	data, err := json.Marshal(WSMessage{Type: "overlay", Data: s.getOverlayState()})
+	if err != nil {
+		return
+	}
+	s.overlay.publish(data)
+}
+
+func (s *Server) setupOverlayCallbacks() {
+	s.track(s.stateManager.RegisterConnectionStatusCallback(func(oldValue, newValue core.ConnectionStatus) {
+		s.broadcastOverlay()
+	}))
+
+	s.track(s.stateManager.RegisterBallDetectedCallback(func(oldValue, newValue bool) {
+		s.broadcastOverlay()
+	}))
+
+	s.track(s.stateManager.RegisterBallReadyCallback(func(oldValue, newValue bool) {
+		s.broadcastOverlay()
+	}))
+
+	s.shotHistory.OnShot(func(shot history.Shot) {
+		s.overlay.setLastShot(shot)
+		s.broadcastOverlay()
+	})
+}
+
+func (s *Server) handleOverlayPage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if err := overlayTemplate.Execute(w, s.getOverlayState()); err != nil {
+		log.Printf("Overlay page render failed: %v", err)
+	}
+}
+
+func (s *Server) handleOverlayWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Overlay WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	clientChan := s.overlay.add(conn)
+
+	go func() {
+		defer conn.Close()
+		for msg := range clientChan {
+			conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		}
+	}()
+
+	if data, err := json.Marshal(WSMessage{Type: "overlay", Data: s.getOverlayState()}); err == nil {
+		clientChan <- data
+	}
+
+	defer func() {
+		s.overlay.remove(conn)
+		conn.Close()
+	}()
+
+	// The overlay feed is read-only; incoming messages are discarded
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+}
+
+func (s *Server) handleOverlayLastShot(w http.ResponseWriter, r *http.Request) {
+	shot := s.overlay.getLastShot()
+	if shot == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	json.NewEncoder(w).Encode(shot)
+}
+
+func (s *Server) handleOverlayLastShotSVG(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := overlaySVGTemplate.Execute(w, s.overlay.getLastShot()); err != nil {
+		log.Printf("Overlay SVG render failed: %v", err)
+	}
+}
+
+var overlayFuncs = template.FuncMap{
+	"f1":         func(v float64) string { return i18n.Number(v, 1) },
+	"f2":         func(v float64) string { return i18n.Number(v, 2) },
+	"percent":    func(v float64) string { return i18n.Number(v*100, 0) },
+	"speedLabel": func() string { return i18n.CurrentNumberFormat().SpeedLabel() },
+}
+
+var overlaySVGTemplate = template.Must(template.New("overlay-svg").Funcs(overlayFuncs).Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="720" height="80" viewBox="0 0 720 80">
+<rect width="720" height="80" rx="8" fill="#000" fill-opacity="0.65"/>
+<g font-family="Helvetica, Arial, sans-serif" fill="#fff">
+<g font-size="12" fill-opacity="0.7">
+<text x="20" y="26">BALL SPEED ({{speedLabel}})</text><text x="160" y="26">LAUNCH</text><text x="280" y="26">DIRECTION</text><text x="410" y="26">SPIN</text><text x="520" y="26">CLUB SPEED ({{speedLabel}})</text><text x="640" y="26">SHOT</text>
+</g>
+<g font-size="28" font-weight="600">
+{{- if .}}
+<text x="20" y="62">{{f1 .BallSpeed}}</text><text x="160" y="62">{{f1 .LaunchAngle}}</text><text x="280" y="62">{{f1 .HorizontalAngle}}</text><text x="410" y="62">{{.TotalSpin}}</text><text x="520" y="62">{{with .ClubSpeed}}{{f1 .}}{{else}}-{{end}}</text><text x="640" y="62">{{.ShotNumber}}</text>
+{{- else}}
+<text x="20" y="62">-</text><text x="160" y="62">-</text><text x="280" y="62">-</text><text x="410" y="62">-</text><text x="520" y="62">-</text><text x="640" y="62">-</text>
+{{- end}}
+</g>
+</g>
+</svg>
+`))
+
+var overlayTemplate = template.Must(template.New("overlay").Funcs(overlayFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>SquareGolf Overlay</title>
+<style>
+  body { margin: 0; background: transparent; color: #fff; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; }
+  .bar { display: flex; gap: 24px; padding: 12px 20px; background: rgba(0, 0, 0, 0.65); align-items: center; }
+  .metric { display: flex; flex-direction: column; min-width: 80px; }
+  .label { font-size: 12px; text-transform: uppercase; opacity: 0.7; }
+  .value { font-size: 28px; font-weight: 600; }
+  .status { width: 14px; height: 14px; border-radius: 50%; background: #666; }
+  .status.detected { background: #f5a623; }
+  .status.ready { background: #3ecf4a; }
+</style>
+</head>
+<body>
+<div class="bar">
+  <div id="status" class="status{{if .BallReady}} ready{{else if .BallDetected}} detected{{end}}"></div>
+  <div class="metric"><span class="label">Ball Speed <span class="unit">{{.SpeedLabel}}</span></span><span class="value" id="ballSpeed">{{with .LastShot}}{{f1 .BallSpeed}}{{else}}-{{end}}</span></div>
+  <div class="metric"><span class="label">Launch</span><span class="value" id="launchAngle">{{with .LastShot}}{{f1 .LaunchAngle}}{{else}}-{{end}}</span></div>
+  <div class="metric"><span class="label">Direction</span><span class="value" id="horizontalAngle">{{with .LastShot}}{{f1 .HorizontalAngle}}{{else}}-{{end}}</span></div>
+  <div class="metric"><span class="label">Spin</span><span class="value" id="totalSpin">{{with .LastShot}}{{.TotalSpin}}{{else}}-{{end}}</span></div>
+  <div class="metric"><span class="label">Spin Axis</span><span class="value" id="spinAxis">{{with .LastShot}}{{f1 .SpinAxis}}{{else}}-{{end}}</span></div>
+  <div class="metric"><span class="label">Club Speed <span class="unit">{{.SpeedLabel}}</span></span><span class="value" id="clubSpeed">{{with .LastShot}}{{with .ClubSpeed}}{{f1 .}}{{else}}-{{end}}{{else}}-{{end}}</span></div>
+  <div class="metric"><span class="label">Smash</span><span class="value" id="smashFactor">{{with .LastShot}}{{with .SmashFactor}}{{f2 .}}{{else}}-{{end}}{{else}}-{{end}}</span></div>
+  <div class="metric"><span class="label">Spin Loft</span><span class="value" id="spinLoft">{{with .LastShot}}{{with .SpinLoft}}{{f1 .}}{{else}}-{{end}}{{else}}-{{end}}</span></div>
+  <div class="metric"><span class="label">Launch Eff. %</span><span class="value" id="launchEfficiency">{{with .LastShot}}{{with .LaunchEfficiency}}{{percent .}}{{else}}-{{end}}{{else}}-{{end}}</span></div>
+</div>
+<script>
+(function () {
+  var separator = ".";
+  function fmt(v, digits) { return (v === undefined || v === null) ? "-" : Number(v).toFixed(digits).replace(".", separator); }
+  function render(state) {
+    separator = state.decimalSeparator || ".";
+    var units = document.querySelectorAll(".unit");
+    for (var i = 0; i < units.length; i++) { units[i].textContent = state.speedLabel; }
+    var status = document.getElementById("status");
+    status.className = "status" + (state.ballReady ? " ready" : (state.ballDetected ? " detected" : ""));
+    var shot = state.lastShot || {};
+    document.getElementById("ballSpeed").textContent = fmt(shot.ballSpeed, 1);
+    document.getElementById("launchAngle").textContent = fmt(shot.launchAngle, 1);
+    document.getElementById("horizontalAngle").textContent = fmt(shot.horizontalAngle, 1);
+    document.getElementById("totalSpin").textContent = fmt(shot.totalSpin, 0);
+    document.getElementById("spinAxis").textContent = fmt(shot.spinAxis, 1);
+    document.getElementById("clubSpeed").textContent = fmt(shot.clubSpeed, 1);
+    document.getElementById("smashFactor").textContent = fmt(shot.smashFactor, 2);
+    document.getElementById("spinLoft").textContent = fmt(shot.spinLoft, 1);
+    document.getElementById("launchEfficiency").textContent = fmt(shot.launchEfficiency === undefined ? undefined : shot.launchEfficiency * 100, 0);
+  }
+  function connect() {
+    var proto = location.protocol === "https:" ? "wss://" : "ws://";
+    var ws = new WebSocket(proto + location.host + "/overlay/ws");
+    ws.onmessage = function (event) {
+      var msg = JSON.parse(event.data);
+      if (msg.type === "overlay") { render(msg.data); }
+    };
+    ws.onclose = function () { setTimeout(connect, 2000); };
+  }
+  connect();
+})();
+</script>
+</body>
+</html>
+`))
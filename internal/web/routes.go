@@ -0,0 +1,343 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/analytics"
+	"github.com/brentyates/squaregolf-connector/internal/core/export"
+	"github.com/brentyates/squaregolf-connector/internal/core/gspro"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// APIPrefix is where the current version of the REST API is served.
+	// Breaking changes go under a new version.
+	APIPrefix = "/api/v1"
+
+	// legacyAPIPrefix serves the same routes for clients written before the
+	// API was versioned
+	legacyAPIPrefix = "/api"
+)
+
+// apiRoute defines an API endpoint. The same definitions register the
+// handlers and generate the OpenAPI document, so the two can't drift apart.
+type apiRoute struct {
+	Method  string
+	Path    string // relative to APIPrefix, in gorilla/mux syntax
+	Handler http.HandlerFunc
+	Tag     string
+	Summary string
+	Params  []apiParam
+
+	// Request and Response are zero values of the JSON bodies, or nil for
+	// none. ContentType replaces the JSON response for files and streams.
+	// Invalid is the JSON body of a 400 response, for handlers that report
+	// more than a plain text error.
+	Request     interface{}
+	Response    interface{}
+	ContentType string
+	Invalid     interface{}
+
+	// Feature, if set, is the optional feature the route belongs to. The
+	// route replies 404 while it is turned off.
+	Feature core.Feature
+
+	// Scope is the access token scope the route needs once tokens are set.
+	// It defaults to viewer for GET and operator for everything else.
+	Scope core.AccessScope
+}
+
+// scope returns the access token scope the route needs
+func (route apiRoute) scope() core.AccessScope {
+	if route.Scope != "" {
+		return route.Scope
+	}
+	if route.Method == http.MethodGet {
+		return core.AccessViewer
+	}
+	return core.AccessOperator
+}
+
+// apiParam is a path or query parameter
+type apiParam struct {
+	Name        string
+	In          string // "path" or "query"
+	Type        string // OpenAPI type; "string" if empty
+	Description string
+}
+
+// shotFilterParams are accepted by every endpoint built on filteredShots
+var shotFilterParams = []apiParam{
+	{Name: "club", In: "query", Description: "Only shots with this club"},
+	{Name: "player", In: "query", Description: "Only shots by this GSPro player"},
+	{Name: "session", In: "query", Description: "Only shots from this kind of session, such as warmup; none for shots outside one"},
+	{Name: "from", In: "query", Description: "Only shots on or after this YYYY-MM-DD date or RFC 3339 time"},
+	{Name: "to", In: "query", Description: "Only shots up to this YYYY-MM-DD date (inclusive) or RFC 3339 time"},
+	{Name: "after", In: "query", Type: "integer", Description: "Only shots with a higher id"},
+	{Name: "limit", In: "query", Type: "integer", Description: "Return at most this many of the most recent shots"},
+	{Name: "offset", In: "query", Type: "integer", Description: "Skip this many of the most recent shots"},
+}
+
+func (s *Server) apiRoutes() []apiRoute {
+	return []apiRoute{
+		// Device
+		{Method: "GET", Path: "/device/status", Handler: s.handleDeviceStatus, Tag: "Device", Summary: "Get the launch monitor connection and ball status", Response: DeviceStatus{}},
+		{Method: "POST", Path: "/device/connect", Handler: s.handleDeviceConnect, Tag: "Device", Summary: "Connect to a launch monitor by nickname, name or address, or the saved one if all are empty", Request: DeviceConnectRequest{}},
+		{Method: "POST", Path: "/device/disconnect", Handler: s.handleDeviceDisconnect, Tag: "Device", Summary: "Disconnect from the launch monitor"},
+		{Method: "POST", Path: "/device/scan", Handler: s.handleDeviceScan, Tag: "Device", Summary: "Scan for launch monitors and add them to the known devices", Response: []core.KnownDevice{}},
+		{Method: "GET", Path: "/devices", Handler: s.handleDevices, Tag: "Device", Summary: "List the launch monitors seen before, most recent first", Response: []core.KnownDevice{}},
+		{Method: "POST", Path: "/devices/nickname", Handler: s.handleDeviceNickname, Tag: "Device", Summary: "Nickname a known launch monitor, or clear its nickname", Request: DeviceNicknameRequest{}, Response: core.KnownDevice{}},
+		{Method: "POST", Path: "/devices/forget", Handler: s.handleDeviceForget, Tag: "Device", Summary: "Remove a launch monitor from the known devices", Request: DeviceForgetRequest{}},
+		{Method: "POST", Path: "/device/practice", Handler: s.handlePracticeMode, Tag: "Device", Summary: "Turn ball detection on or off", Request: PracticeModeRequest{}},
+		{Method: "POST", Path: "/device/swing-stick", Handler: s.handleSwingStick, Tag: "Device", Summary: "Turn swing stick training on or off; swings are reported without a ball and not sent to simulators", Request: SwingStickRequest{}},
+		{Method: "GET", Path: "/device/battery", Handler: s.handleDeviceBattery, Tag: "Device", Summary: "Get the battery drain rate, time remaining and charge cycle history", Response: core.BatteryHistory{}},
+		{Method: "POST", Path: "/device/standby", Handler: s.handleDeviceStandby, Tag: "Device", Summary: "Stop ball detection and the heartbeat until woken"},
+		{Method: "POST", Path: "/device/wake", Handler: s.handleDeviceWake, Tag: "Device", Summary: "Wake the launch monitor from standby"},
+		{Method: "POST", Path: "/session/pause", Handler: s.handleSessionPause, Tag: "Device", Summary: "Disarm ball detection and drop shots while the player is away"},
+		{Method: "POST", Path: "/session/resume", Handler: s.handleSessionResume, Tag: "Device", Summary: "Resume a paused session, re-arming ball detection if it was armed"},
+		{Method: "GET", Path: "/device/heartbeat", Handler: s.handleDeviceHeartbeat, Tag: "Device", Summary: "Get the heartbeat rate in effect", Response: HeartbeatStatus{}},
+		{Method: "POST", Path: "/device/pair", Handler: s.handleDevicePair, Tag: "Device", Summary: "Pair with the connected launch monitor and save it", Response: core.BondedDevice{}},
+		{Method: "POST", Path: "/device/unpair", Handler: s.handleDeviceUnpair, Tag: "Device", Summary: "Remove the pairing and the saved launch monitor"},
+		{Method: "GET", Path: "/device/settings", Handler: s.handleDeviceSettings, Tag: "Device", Summary: "Get the launch monitor settings", Response: core.DeviceSettings{}},
+		{Method: "POST", Path: "/device/settings", Handler: s.handleDeviceSettings, Tag: "Device", Summary: "Change launch monitor settings; missing fields keep their values", Request: core.DeviceSettings{}, Response: core.DeviceSettings{}},
+
+		// GSPro
+		{Method: "GET", Path: "/gspro/status", Handler: s.handleGSProStatus, Tag: "GSPro", Summary: "Get the GSPro connection status", Response: GSProStatus{}},
+		{Method: "POST", Path: "/gspro/connect", Handler: s.handleGSProConnect, Tag: "GSPro", Summary: "Connect to GSPro", Request: ConnectRequest{}},
+		{Method: "POST", Path: "/gspro/disconnect", Handler: s.handleGSProDisconnect, Tag: "GSPro", Summary: "Disconnect from GSPro"},
+		{Method: "GET", Path: "/gspro/config", Handler: s.handleGSProConfig, Tag: "GSPro", Summary: "Get the saved GSPro address", Response: ConnectionConfig{}},
+		{Method: "POST", Path: "/gspro/config", Handler: s.handleGSProConfig, Tag: "GSPro", Summary: "Save the GSPro address", Request: ConnectionConfig{}, Invalid: SettingsError{}},
+		{Method: "GET", Path: "/gspro/discover", Handler: s.handleGSProDiscover, Tag: "GSPro", Summary: "Find machines on the local network running GSPro Connect",
+			Params:   []apiParam{{Name: "port", In: "query", Type: "integer", Description: "GSPro Connect port to probe"}},
+			Response: []gspro.DiscoveryCandidate{}, Scope: core.AccessOperator},
+		{Method: "GET", Path: "/gspro/log", Handler: s.handleGSProLog, Tag: "GSPro", Summary: "Get recent messages exchanged with GSPro",
+			Params:   []apiParam{{Name: "limit", In: "query", Type: "integer", Description: "Return at most this many messages"}},
+			Response: GSProLog{}},
+		{Method: "GET", Path: "/gspro/audit", Handler: s.handleGSProAudit, Tag: "GSPro", Summary: "Get what was sent to GSPro for each recent shot, with GSPro's acknowledgements and any retries",
+			Params:   []apiParam{{Name: "limit", In: "query", Type: "integer", Description: "Return at most this many shots, the most recent"}},
+			Response: []gspro.ShotAudit{}},
+		{Method: "POST", Path: "/gspro/shot-number/reset", Handler: s.handleGSProShotNumberReset, Tag: "GSPro", Summary: "Restart shot numbering"},
+		{Method: "GET", Path: "/gspro/resend-last", Handler: s.handleGSProResendLast, Tag: "GSPro", Summary: "Get the last shot sent to GSPro and the token to resend it",
+			Response: gspro.LastShot{}, Scope: core.AccessOperator},
+		{Method: "POST", Path: "/gspro/resend-last", Handler: s.handleGSProResendLast, Tag: "GSPro", Summary: "Send the last shot to GSPro again with a new shot number",
+			Request: ResendRequest{}, Response: ResendResult{}},
+		{Method: "GET", Path: "/gspro/queue", Handler: s.handleGSProQueue, Tag: "GSPro", Summary: "Get the shots hit while GSPro was reconnecting that haven't been sent",
+			Response: gspro.RetryQueue{}},
+		{Method: "POST", Path: "/gspro/queue/replay", Handler: s.handleGSProQueueReplay, Tag: "GSPro", Summary: "Send the queued shots to GSPro, one at a time as it asks for the next shot",
+			Response: gspro.RetryQueue{}},
+		{Method: "POST", Path: "/gspro/queue/discard", Handler: s.handleGSProQueueDiscard, Tag: "GSPro", Summary: "Drop the queued shots without sending them",
+			Response: QueueDiscardResult{}},
+
+		// Infinite Tees
+		{Method: "GET", Path: "/infinitetees/status", Handler: s.handleInfiniteTeesStatus, Tag: "Infinite Tees", Summary: "Get the Infinite Tees connection status", Response: InfiniteTeesStatus{}},
+		{Method: "POST", Path: "/infinitetees/connect", Handler: s.handleInfiniteTeesConnect, Tag: "Infinite Tees", Summary: "Connect to Infinite Tees", Request: ConnectRequest{}},
+		{Method: "POST", Path: "/infinitetees/disconnect", Handler: s.handleInfiniteTeesDisconnect, Tag: "Infinite Tees", Summary: "Disconnect from Infinite Tees"},
+		{Method: "GET", Path: "/infinitetees/config", Handler: s.handleInfiniteTeesConfig, Tag: "Infinite Tees", Summary: "Get the saved Infinite Tees address", Response: ConnectionConfig{}},
+		{Method: "POST", Path: "/infinitetees/config", Handler: s.handleInfiniteTeesConfig, Tag: "Infinite Tees", Summary: "Save the Infinite Tees address", Request: ConnectionConfig{}, Invalid: SettingsError{}},
+
+		// Awesome Golf
+		{Method: "GET", Path: "/awesomegolf/status", Handler: s.handleAwesomeGolfStatus, Tag: "Awesome Golf", Summary: "Get the Awesome Golf connection status", Response: AwesomeGolfStatus{}},
+		{Method: "POST", Path: "/awesomegolf/connect", Handler: s.handleAwesomeGolfConnect, Tag: "Awesome Golf", Summary: "Connect to Awesome Golf", Request: ConnectRequest{}},
+		{Method: "POST", Path: "/awesomegolf/disconnect", Handler: s.handleAwesomeGolfDisconnect, Tag: "Awesome Golf", Summary: "Disconnect from Awesome Golf"},
+		{Method: "GET", Path: "/awesomegolf/config", Handler: s.handleAwesomeGolfConfig, Tag: "Awesome Golf", Summary: "Get the saved Awesome Golf address", Response: ConnectionConfig{}},
+		{Method: "POST", Path: "/awesomegolf/config", Handler: s.handleAwesomeGolfConfig, Tag: "Awesome Golf", Summary: "Save the Awesome Golf address", Request: ConnectionConfig{}, Invalid: SettingsError{}},
+
+		// Camera
+		{Method: "GET", Path: "/camera/config", Handler: s.handleCameraConfig, Tag: "Camera", Summary: "Get the external camera configuration", Response: CameraConfig{}, Feature: core.FeatureExternalCamera},
+		{Method: "POST", Path: "/camera/config", Handler: s.handleCameraConfig, Tag: "Camera", Summary: "Change the external camera configuration", Request: CameraConfig{}, Invalid: SettingsError{}, Feature: core.FeatureExternalCamera},
+		{Method: "GET", Path: "/camera/status", Handler: s.handleCameraStatus, Tag: "Camera", Summary: "Get the status of each external camera", Response: CameraStatus{}, Feature: core.FeatureExternalCamera},
+
+		// Settings
+		{Method: "GET", Path: "/settings", Handler: s.handleSettings, Tag: "Settings", Summary: "Get the application settings", Response: AppSettings{}},
+		{Method: "POST", Path: "/settings", Handler: s.handleSettings, Tag: "Settings", Summary: "Change application settings; only the fields sent are changed, and none are if any is invalid",
+			Request: AppSettings{}, Invalid: SettingsError{}},
+		{Method: "GET", Path: "/features", Handler: s.handleFeatures, Tag: "Settings", Summary: "Get the optional features that are on", Response: FeatureFlags{}},
+		{Method: "POST", Path: "/features", Handler: s.handleFeatures, Tag: "Settings", Summary: "Turn optional features on or off; features left out are unchanged", Request: map[core.Feature]bool{}, Response: FeatureFlags{}},
+
+		// Version and updates
+		{Method: "GET", Path: "/version", Handler: s.handleVersion, Tag: "Updates", Summary: "Get the running version and the last update check", Response: VersionInfo{}},
+		{Method: "POST", Path: "/update/check", Handler: s.handleUpdateCheck, Tag: "Updates", Summary: "Check for a new release now", Response: core.UpdateStatus{}},
+		{Method: "POST", Path: "/update/install", Handler: s.handleUpdateInstall, Tag: "Updates", Summary: "Install the latest release, which runs after a restart", Response: core.UpdateStatus{}},
+		{Method: "POST", Path: "/update/rollback", Handler: s.handleUpdateRollback, Tag: "Updates", Summary: "Restore the version replaced by the last install", Response: core.UpdateStatus{}},
+
+		// Status updates
+		{Method: "GET", Path: "/events", Handler: s.handleEvents, Tag: "Events", Summary: "Stream the status updates sent over the /ws WebSocket as Server-Sent Events, for networks that block WebSocket upgrades",
+			ContentType: "text/event-stream"},
+
+		// Logs, metrics and health
+		{Method: "GET", Path: "/logs/download", Handler: s.handleLogsDownload, Tag: "Logs", Summary: "Download the current and rotated logs as a zip archive", ContentType: "application/zip", Scope: core.AccessOperator},
+		{Method: "GET", Path: "/logs/stream", Handler: s.handleLogsStream, Tag: "Logs", Summary: "Stream the application log as Server-Sent Events",
+			Params:      []apiParam{{Name: "level", In: "query", Description: "Hide lines below debug, info, warn or error"}},
+			ContentType: "text/event-stream", Scope: core.AccessOperator},
+		{Method: "GET", Path: "/metrics", Handler: s.handleMetrics, Tag: "Metrics", Summary: "Get shot latency, notification counts and background task restarts", Response: Metrics{}},
+		{Method: "GET", Path: "/health", Handler: s.handleHealth, Tag: "Metrics", Summary: "Check the Bluetooth adapter, device, GSPro, cameras and free disk space; 503 if any check fails", Response: Health{}},
+		{Method: "POST", Path: "/diagnostics/run", Handler: s.handleDiagnosticsRun, Tag: "Metrics", Summary: "Run the connection checks in order and report each as pass, fail or skip; connects to a launch monitor found by the scan",
+			Params: []apiParam{{Name: "format", In: "query", Description: "text for a Markdown report to paste into an issue"}}, Response: DiagnosticsReport{}},
+		{Method: "POST", Path: "/debug/command", Handler: s.handleDebugCommand, Tag: "Debug", Summary: "Write a raw hex command to the device and stream the notifications that follow as JSON lines",
+			Request: RawCommandRequest{}, ContentType: "application/x-ndjson", Feature: core.FeatureRawCommands},
+
+		// Access tokens
+		{Method: "GET", Path: "/access/tokens", Handler: s.handleAccessTokens, Tag: "Access", Summary: "Get the web API tokens, without the tokens themselves", Response: core.AccessTokens{}, Scope: core.AccessOperator},
+		{Method: "POST", Path: "/access/tokens", Handler: s.handleAccessTokens, Tag: "Access", Summary: "Save the web API tokens and use them straight away; a redacted token keeps the saved one",
+			Request: core.AccessTokens{}, Response: core.AccessTokens{}, Invalid: SettingsError{}, Scope: core.AccessOperator},
+
+		// Shot export
+		{Method: "GET", Path: "/export/config", Handler: s.handleExportConfig, Tag: "Export", Summary: "Get the shot export settings, without the S3 secret", Response: export.Settings{}, Scope: core.AccessOperator},
+		{Method: "POST", Path: "/export/config", Handler: s.handleExportConfig, Tag: "Export", Summary: "Save the shot export settings; the redacted S3 secret keeps the saved one",
+			Request: export.Settings{}, Response: export.Settings{}, Invalid: SettingsError{}},
+		{Method: "GET", Path: "/export/status", Handler: s.handleExportStatus, Tag: "Export", Summary: "Get the shots waiting to be exported and the last error", Response: export.Status{}},
+		{Method: "POST", Path: "/export/retry", Handler: s.handleExportRetry, Tag: "Export", Summary: "Send the waiting shots now instead of after the retry delay", Response: export.Status{}},
+
+		// Multi-bay dashboard
+		{Method: "GET", Path: "/dashboard", Handler: s.handleDashboard, Tag: "Dashboard", Summary: "Get the device, battery, GSPro status and last shot of this bay and every bay polled", Response: []core.BayStatus{}, Feature: core.FeatureDashboard},
+		{Method: "GET", Path: "/dashboard/config", Handler: s.handleDashboardConfig, Tag: "Dashboard", Summary: "Get the other connectors the dashboard polls and their tokens", Response: core.DashboardSettings{}, Feature: core.FeatureDashboard, Scope: core.AccessOperator},
+		{Method: "POST", Path: "/dashboard/config", Handler: s.handleDashboardConfig, Tag: "Dashboard", Summary: "Save the other connectors the dashboard polls and how often",
+			Request: core.DashboardSettings{}, Response: core.DashboardSettings{}, Invalid: SettingsError{}, Feature: core.FeatureDashboard},
+
+		// Overlay
+		{Method: "GET", Path: "/overlay/lastshot", Handler: s.handleOverlayLastShot, Tag: "Overlay", Summary: "Get the last shot, or 204 before the first one", Response: OverlayShot{}},
+		{Method: "GET", Path: "/overlay/lastshot.svg", Handler: s.handleOverlayLastShotSVG, Tag: "Overlay", Summary: "Render the last shot as an SVG banner", ContentType: "image/svg+xml"},
+
+		// Shot history and analytics
+		{Method: "GET", Path: "/shots", Handler: s.handleShots, Tag: "Shots", Summary: "List recorded shots; X-Total-Count has the number matched before paging", Params: shotFilterParams, Response: []history.Shot{}},
+		{Method: "GET", Path: "/shots/{id:[0-9]+}/videos/{camera}", Handler: s.handleShotVideo, Tag: "Shots", Summary: "Download a shot's video from a camera",
+			Params: []apiParam{
+				{Name: "id", In: "path", Type: "integer", Description: "Shot id"},
+				{Name: "camera", In: "path", Description: "Camera name"},
+			},
+			ContentType: "video/mp4"},
+		{Method: "GET", Path: "/shots/{id:[0-9]+}/raw", Handler: s.handleShotRaw, Tag: "Shots", Summary: "Get the raw device notifications a shot was parsed from, kept while shotRawData is on",
+			Params:   []apiParam{{Name: "id", In: "path", Type: "integer", Description: "Shot id"}},
+			Response: history.RawFrames{}},
+		{Method: "GET", Path: "/shots/misreads", Handler: s.handleMisreads, Tag: "Shots", Summary: "List shots held as possible misreads", Response: []core.MisreadShot{}},
+		{Method: "POST", Path: "/shots/misreads/{id}", Handler: s.handleMisreadResolve, Tag: "Shots", Summary: "Send or discard a held shot",
+			Params:  []apiParam{{Name: "id", In: "path", Type: "integer", Description: "Misread shot id"}},
+			Request: MisreadResolveRequest{}},
+		{Method: "GET", Path: "/analytics/dispersion", Handler: s.handleAnalyticsDispersion, Tag: "Analytics", Summary: "Get shot dispersion by club", Params: shotFilterParams, Response: []analytics.ClubDispersion{}},
+		{Method: "GET", Path: "/analytics/gapping", Handler: s.handleAnalyticsGapping, Tag: "Analytics", Summary: "Get carry gaps between clubs", Params: shotFilterParams, Response: []analytics.ClubGap{}},
+		{Method: "GET", Path: "/analytics/consistency", Handler: s.handleAnalyticsConsistency, Tag: "Analytics", Summary: "Get shot consistency by club", Params: shotFilterParams, Response: []analytics.ClubConsistency{}},
+		{Method: "GET", Path: "/analytics/ball-position", Handler: s.handleAnalyticsBallPosition, Tag: "Analytics", Summary: "Get a heatmap of where the ball sat while ready this session, to find an inconsistent setup behind misreads",
+			Params: []apiParam{
+				{Name: "cell", In: "query", Type: "integer", Description: "Cell size in mat units, 10 to 1000 (default 100)"},
+				{Name: "format", In: "query", Description: "png for an image with the placement zone outlined"},
+			},
+			Response: core.BallHeatmap{}},
+		{Method: "POST", Path: "/analytics/ball-position/reset", Handler: s.handleAnalyticsBallPositionReset, Tag: "Analytics", Summary: "Clear the ball position heatmap and start a new session", Response: core.BallHeatmap{}},
+		{Method: "GET", Path: "/analytics/strokes-gained", Handler: s.handleAnalyticsStrokesGained, Tag: "Analytics", Summary: "Get strokes gained by club and practice session for shots hit at a target",
+			Params: []apiParam{
+				{Name: "club", In: "query", Description: "Only shots with this club"},
+				{Name: "profile", In: "query", Description: "Only this player's shots"},
+				{Name: "practice", In: "query", Description: "Only shots from this practice: closestToPin, ladder, wedges or combine"},
+				{Name: "from", In: "query", Description: "Only shots on or after this YYYY-MM-DD date or RFC 3339 time"},
+				{Name: "to", In: "query", Description: "Only shots up to this YYYY-MM-DD date (inclusive) or RFC 3339 time"},
+			},
+			Response: core.StrokesGainedReport{}},
+
+		// Target games
+		{Method: "GET", Path: "/games", Handler: s.handleGame, Tag: "Games", Summary: "Get the target game in progress or last finished", Response: GameStatus{}},
+		{Method: "POST", Path: "/games/start", Handler: s.handleGameStart, Tag: "Games", Summary: "Start a target game; shots are scored as they are recorded", Request: core.GameConfig{}, Response: core.GameState{}, Feature: core.FeatureGames},
+		{Method: "POST", Path: "/games/stop", Handler: s.handleGameStop, Tag: "Games", Summary: "Abandon the game in progress without recording it"},
+		{Method: "GET", Path: "/games/leaderboard", Handler: s.handleGameLeaderboard, Tag: "Games", Summary: "Rank each profile's best result in a game mode",
+			Params:   []apiParam{{Name: "mode", In: "query", Description: "closestToPin (default) or ladder"}},
+			Response: []core.GameResult{}},
+		{Method: "GET", Path: "/games/wedges", Handler: s.handleWedgePractice, Tag: "Games", Summary: "Get the wedge practice in progress or last finished", Response: WedgePracticeStatus{}},
+		{Method: "POST", Path: "/games/wedges/start", Handler: s.handleWedgePracticeStart, Tag: "Games", Summary: "Start a wedge practice that prompts for each club and carry target", Request: core.WedgePracticeConfig{}, Response: core.WedgePracticeState{}, Feature: core.FeatureGames},
+		{Method: "POST", Path: "/games/wedges/stop", Handler: s.handleWedgePracticeStop, Tag: "Games", Summary: "End the wedge practice; shots already hit stay in the matrix"},
+		{Method: "GET", Path: "/games/wedges/profiles", Handler: s.handleWedgeProfiles, Tag: "Games", Summary: "List the profiles with a wedge matrix", Response: []string{}},
+		{Method: "GET", Path: "/games/wedges/matrix", Handler: s.handleWedgeMatrix, Tag: "Games", Summary: "Get a profile's wedge distance matrix",
+			Params:   []apiParam{{Name: "profile", In: "query", Description: "Profile name (default Player)"}},
+			Response: core.WedgeMatrix{}},
+		{Method: "GET", Path: "/games/wedges/matrix.csv", Handler: s.handleWedgeMatrixCSV, Tag: "Games", Summary: "Download a profile's wedge distance matrix as CSV",
+			Params:      []apiParam{{Name: "profile", In: "query", Description: "Profile name (default Player)"}},
+			ContentType: "text/csv"},
+		{Method: "GET", Path: "/games/combine", Handler: s.handleCombine, Tag: "Games", Summary: "Get the combine in progress or last finished", Response: CombineStatus{}},
+		{Method: "POST", Path: "/games/combine/start", Handler: s.handleCombineStart, Tag: "Games", Summary: "Start a combine skills assessment", Request: CombineStartRequest{}, Response: core.CombineState{}, Feature: core.FeatureGames},
+		{Method: "POST", Path: "/games/combine/pause", Handler: s.handleCombinePause, Tag: "Games", Summary: "Pause the combine; shots are ignored until it resumes", Response: core.CombineState{}},
+		{Method: "POST", Path: "/games/combine/resume", Handler: s.handleCombineResume, Tag: "Games", Summary: "Resume a paused combine", Response: core.CombineState{}},
+		{Method: "POST", Path: "/games/combine/stop", Handler: s.handleCombineStop, Tag: "Games", Summary: "Abandon the combine without saving its report"},
+		{Method: "GET", Path: "/games/combine/report", Handler: s.handleCombineReport, Tag: "Games", Summary: "Score the combine in progress or last finished, overall and by target", Response: core.CombineReport{}},
+		{Method: "GET", Path: "/games/combine/reports", Handler: s.handleCombineReports, Tag: "Games", Summary: "List the reports of finished combines, newest first",
+			Params:   []apiParam{{Name: "profile", In: "query", Description: "Only this player's reports"}},
+			Response: []core.CombineReport{}},
+		{Method: "GET", Path: "/games/warmup", Handler: s.handleWarmup, Tag: "Games", Summary: "Get the warmup in progress or last finished", Response: WarmupStatus{}},
+		{Method: "POST", Path: "/games/warmup/start", Handler: s.handleWarmupStart, Tag: "Games", Summary: "Start a warmup that selects each club in turn and arms detection for its balls; shots are tagged as warmup shots",
+			Request: core.WarmupConfig{}, Response: core.WarmupState{}, Feature: core.FeatureGames},
+		{Method: "POST", Path: "/games/warmup/stop", Handler: s.handleWarmupStop, Tag: "Games", Summary: "End the warmup, keeping a summary of the shots already hit"},
+		{Method: "GET", Path: "/games/warmup/sessions", Handler: s.handleWarmups, Tag: "Games", Summary: "List the summaries of past warmups, newest first", Response: []core.WarmupSummary{}},
+
+		// Alignment
+		{Method: "POST", Path: "/alignment/start", Handler: s.handleAlignmentStart, Tag: "Alignment", Summary: "Start aiming the launch monitor"},
+		{Method: "POST", Path: "/alignment/stop", Handler: s.handleAlignmentStop, Tag: "Alignment", Summary: "Save the current aim and stop"},
+		{Method: "POST", Path: "/alignment/cancel", Handler: s.handleAlignmentCancel, Tag: "Alignment", Summary: "Stop aiming without saving"},
+		{Method: "POST", Path: "/alignment/handedness", Handler: s.handleAlignmentHandedness, Tag: "Alignment", Summary: "Set left or right handed", Request: HandednessRequest{}},
+		{Method: "GET", Path: "/alignment/profiles", Handler: s.handleAlignmentProfiles, Tag: "Alignment", Summary: "Get the aim saved for each handedness", Response: core.AlignmentProfiles{}},
+		{Method: "GET", Path: "/alignment/capture", Handler: s.handleAlignmentCaptureStatus, Tag: "Alignment", Summary: "Get the alignment format capture progress", Response: core.AlignmentCaptureStatus{}},
+		{Method: "POST", Path: "/alignment/capture/start", Handler: s.handleAlignmentCaptureStart, Tag: "Alignment", Summary: "Start capturing alignment packets at known angles", Request: AlignmentCaptureStartRequest{}, Response: core.AlignmentCaptureStatus{}},
+		{Method: "POST", Path: "/alignment/capture/next", Handler: s.handleAlignmentCaptureNext, Tag: "Alignment", Summary: "Move on to the next angle", Response: core.AlignmentCaptureStatus{}},
+		{Method: "POST", Path: "/alignment/capture/finish", Handler: s.handleAlignmentCaptureFinish, Tag: "Alignment", Summary: "Fit and save the alignment format", Response: AlignmentCaptureResult{}},
+		{Method: "POST", Path: "/alignment/capture/cancel", Handler: s.handleAlignmentCaptureCancel, Tag: "Alignment", Summary: "Stop capturing without saving", Response: core.AlignmentCaptureStatus{}},
+
+		// Mat calibration
+		{Method: "GET", Path: "/calibration", Handler: s.handleCalibrationStatus, Tag: "Calibration", Summary: "Get the mat calibration and samples taken", Response: CalibrationStatus{}},
+		{Method: "POST", Path: "/calibration/sample", Handler: s.handleCalibrationSample, Tag: "Calibration", Summary: "Sample the ball position at a calibration point", Request: CalibrationSampleRequest{}, Response: CalibrationStatus{}},
+		{Method: "POST", Path: "/calibration/finish", Handler: s.handleCalibrationFinish, Tag: "Calibration", Summary: "Fit and save the mat calibration", Response: CalibrationStatus{}},
+		{Method: "POST", Path: "/calibration/reset", Handler: s.handleCalibrationReset, Tag: "Calibration", Summary: "Clear the mat calibration", Response: CalibrationStatus{}},
+
+		// Simulated device
+		{Method: "GET", Path: "/simulator/status", Handler: s.handleSimulatorStatus, Tag: "Simulator", Summary: "Get the simulated device's state", Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/mode", Handler: s.handleSimulatorMode, Tag: "Simulator", Summary: "Switch between automatic and manual shots", Request: SimulatorModeRequest{}, Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/ball/placed", Handler: s.handleSimulatorBallPlaced, Tag: "Simulator", Summary: "Place a ball", Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/ball/ready", Handler: s.handleSimulatorBallReady, Tag: "Simulator", Summary: "Mark the ball ready", Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/ball/removed", Handler: s.handleSimulatorBallRemoved, Tag: "Simulator", Summary: "Remove the ball", Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/shot", Handler: s.handleSimulatorShot, Tag: "Simulator", Summary: "Hit the shot in the body, or a random one if it is empty", Request: core.SimulatedShot{}, Response: core.SimulatorControlStatus{}},
+		{Method: "GET", Path: "/simulator/series/presets", Handler: s.handleSimulatorSeriesPresets, Tag: "Simulator", Summary: "Get the preset shot series by name", Response: map[string]core.ShotSeries{}},
+		{Method: "POST", Path: "/simulator/series", Handler: s.handleSimulatorSeries, Tag: "Simulator", Summary: "Hit a series of shots at known values, stepping each from the last, to check a simulator's ball flight",
+			Request: ShotSeriesRequest{}, Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/series/cancel", Handler: s.handleSimulatorSeriesCancel, Tag: "Simulator", Summary: "Stop the shot series after the shot being hit", Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/disconnect", Handler: s.handleSimulatorDisconnect, Tag: "Simulator", Summary: "Drop the connection as if the device went away", Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/battery", Handler: s.handleSimulatorBattery, Tag: "Simulator", Summary: "Set the battery level", Request: SimulatorBatteryRequest{}, Response: core.SimulatorControlStatus{}},
+		{Method: "GET", Path: "/simulator/faults", Handler: s.handleSimulatorFaults, Tag: "Simulator", Summary: "Get the faults still to be injected", Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/faults", Handler: s.handleSimulatorFaults, Tag: "Simulator", Summary: "Fail the next writes, truncate the next notifications or slow every read and write; an empty body clears them",
+			Request: core.SimulatorFaults{}, Response: core.SimulatorControlStatus{}},
+		{Method: "POST", Path: "/simulator/faults/flood", Handler: s.handleSimulatorFlood, Tag: "Simulator", Summary: "Send a burst of repeated sensor notifications", Request: SimulatorFloodRequest{}, Response: core.SimulatorControlStatus{}},
+	}
+}
+
+// registerAPI serves the API under APIPrefix, and under legacyAPIPrefix
+// marked as deprecated
+func (s *Server) registerAPI(router *mux.Router) {
+	routes := s.apiRoutes()
+
+	api := router.PathPrefix(APIPrefix).Subrouter()
+	api.HandleFunc("/spec", s.requireScope(core.AccessViewer, s.handleAPISpec)).Methods("GET")
+	for _, route := range routes {
+		api.HandleFunc(route.Path, s.routeHandler(route)).Methods(route.Method)
+	}
+
+	legacy := router.PathPrefix(legacyAPIPrefix).Subrouter()
+	legacy.Use(deprecatedAPI)
+	for _, route := range routes {
+		legacy.HandleFunc(route.Path, s.routeHandler(route)).Methods(route.Method)
+	}
+}
+
+// routeHandler returns the route's handler, gated on the access token scope
+// it needs and on its feature if it has one
+func (s *Server) routeHandler(route apiRoute) http.HandlerFunc {
+	handler := route.Handler
+	if route.Feature != "" {
+		handler = s.requireFeature(route.Feature, handler)
+	}
+	return s.requireScope(route.scope(), handler)
+}
+
+// deprecatedAPI points clients of the unversioned API at the current version
+func deprecatedAPI(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+APIPrefix+"/spec>; rel=\"successor-version\"")
+		next.ServeHTTP(w, r)
+	})
+}
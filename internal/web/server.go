@@ -1,41 +1,97 @@
 package web
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/brentyates/squaregolf-connector/internal/app"
 	"github.com/brentyates/squaregolf-connector/internal/config"
 	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/core/awesomegolf"
 	"github.com/brentyates/squaregolf-connector/internal/core/camera"
+	"github.com/brentyates/squaregolf-connector/internal/core/chime"
+	"github.com/brentyates/squaregolf-connector/internal/core/export"
+	"github.com/brentyates/squaregolf-connector/internal/core/games"
 	"github.com/brentyates/squaregolf-connector/internal/core/gspro"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
 	"github.com/brentyates/squaregolf-connector/internal/core/infinitetees"
+	"github.com/brentyates/squaregolf-connector/internal/core/placement"
+	"github.com/brentyates/squaregolf-connector/internal/core/simulator"
+	"github.com/brentyates/squaregolf-connector/internal/core/voice"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/brentyates/squaregolf-connector/internal/logging"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 )
 
+// clientSendBuffer is how many messages a WebSocket or event stream client
+// may fall behind before it is disconnected
+const clientSendBuffer = 100
+
+// settingsBroadcastInterval limits how often saved settings are broadcast.
+// A settings request saves each field in turn; the throttle makes sure the
+// last broadcast of the burst carries all of them.
+const settingsBroadcastInterval = 250 * time.Millisecond
+
 type Server struct {
 	stateManager            *core.StateManager
 	bluetoothManager        *core.BluetoothManager
 	launchMonitor           *core.LaunchMonitor
 	gsproIntegration        *gspro.Integration
 	infiniteTeesIntegration *infinitetees.Integration
+	awesomeGolfIntegration  *awesomegolf.Integration
 	cameraManager           *camera.Manager
-	enableExternalCamera    bool
+	supervisor              *core.Supervisor
+	battery                 *core.BatteryTracker
+	ballHeatmap             *core.BallHeatmapRecorder
+	knownDevices            *core.KnownDevices
+	features                *core.FeatureFlags
 	upgrader                websocket.Upgrader
-	clients                 map[*websocket.Conn]chan []byte
+	clients                 map[statusClient]chan []byte
 	clientsMu               sync.Mutex
+	lastDeviceStatus        map[string]json.RawMessage
+	positionThrottle        *core.Throttle
+	settingsThrottle        *core.Throttle
+	settingsPending         chan struct{} // a settings broadcast waiting to be sent
+	statusLogLimiter        *core.RateLimiter
+	deviceStatusMu          sync.Mutex
+	subscriptions           []*core.Subscription
+	unsubscribes            []func()
+	subscriptionsMu         sync.Mutex
 	broadcast               chan []byte
 	httpServer              *http.Server
 	httpServerMu            sync.Mutex
 	webRoot                 string
+	bindAddress             string
+	allowedOrigins          []string
+	accessTokens            core.AccessTokens
+	accessMu                sync.RWMutex
+	mdns                    *mdnsAdvertiser
+	overlay                 *overlayHub
+	shotHistory             *history.Store
+	gameManager             *games.Manager
+	exporter                *export.Exporter
+	announcer               *voice.Announcer
+	chime                   *chime.Manager
+	placement               *placement.Manager
+	dashboard               *core.BayDashboard
+	calibrationWizard       *core.MatCalibrationWizard
+	diagnostics             diagnostics
+	simulator               *core.SimulatorBluetoothClient // nil unless the simulated device is in use
+	updater                 *core.UpdateChecker            // nil when update checks are disabled
 }
 
 type WSMessage struct {
@@ -69,14 +125,41 @@ type DeviceStatus struct {
 	OmniSensorStatus    *int                     `json:"omniSensorStatus"`
 	CapacitorReady      bool                     `json:"capacitorReady"`
 	BatteryCharging     *int                     `json:"batteryCharging"`
+	Battery             core.BatteryEstimate     `json:"battery"`
+	Idle                bool                     `json:"idle"`
+	Standby             bool                     `json:"standby"`
+	Paused              bool                     `json:"paused"`
+	SwingStick          bool                     `json:"swingStick"`
+	LastSwingMetrics    *core.ClubMetrics        `json:"lastSwingMetrics"`
+	ShotCooldown        bool                     `json:"shotCooldown"`
+	PairingSupported    bool                     `json:"pairingSupported"`
+	PairedDevice        *core.BondedDevice       `json:"pairedDevice"`
 }
 
 type GSProStatus struct {
-	ConnectionStatus string `json:"connectionStatus"`
-	IP               string `json:"ip"`
-	Port             int    `json:"port"`
-	AutoConnect      bool   `json:"autoConnect"`
-	LastError        string `json:"lastError"`
+	ConnectionStatus string                `json:"connectionStatus"`
+	IP               string                `json:"ip"`
+	Port             int                   `json:"port"`
+	AutoConnect      bool                  `json:"autoConnect"`
+	LastError        string                `json:"lastError"`
+	FailedOver       bool                  `json:"failedOver"`
+	ShotNumber       int                   `json:"shotNumber"`
+	Endpoints        []GSProEndpointStatus `json:"endpoints"`
+	Player           string                `json:"player,omitempty"`
+	Players          []gspro.PlayerState   `json:"players"`
+	QueuedShots      int                   `json:"queuedShots"`    // hit while GSPro was reconnecting
+	AwaitingReplay   bool                  `json:"awaitingReplay"` // queued shots wait for the player to replay them
+}
+
+// GSProEndpointStatus reports one of the primary and standby GSPro endpoints.
+// Status is the connection status for the active endpoint, "failed" for a
+// primary that was failed over from and "standby" for an unused standby.
+type GSProEndpointStatus struct {
+	Role   string `json:"role"`
+	IP     string `json:"ip"`
+	Port   int    `json:"port"`
+	Active bool   `json:"active"`
+	Status string `json:"status"`
 }
 
 type InfiniteTeesStatus struct {
@@ -87,54 +170,181 @@ type InfiniteTeesStatus struct {
 	LastError        string `json:"lastError"`
 }
 
+// ConnectionConfig is the saved address of a simulator and whether the
+// connector connects to it at startup
+type ConnectionConfig struct {
+	IP          string `json:"ip"`
+	Port        int    `json:"port"`
+	AutoConnect bool   `json:"autoConnect"`
+}
+
+// SettingsError is the body of a 400 response to saving invalid settings.
+// Each field is named by its settings key.
+type SettingsError struct {
+	Error  string              `json:"error"`
+	Fields []config.FieldError `json:"fields"`
+}
+
+type ConnectRequest struct {
+	IP   string `json:"ip"`
+	Port int    `json:"port"`
+}
+
+type DeviceConnectRequest struct {
+	DeviceName    string `json:"deviceName"`
+	DeviceAddress string `json:"deviceAddress"`
+	Nickname      string `json:"nickname,omitempty"` // of a known device, instead of the name and address
+}
+
+type PracticeModeRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+type SwingStickRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+type HandednessRequest struct {
+	Handedness string `json:"handedness"`
+}
+
+// HeartbeatStatus is the heartbeat rate currently in effect. The device
+// timeout is only known for the simulated device.
+type HeartbeatStatus struct {
+	IntervalSeconds      float64  `json:"intervalSeconds"`
+	IdleIntervalSeconds  float64  `json:"idleIntervalSeconds"`
+	DeviceTimeoutSeconds *float64 `json:"deviceTimeoutSeconds,omitempty"`
+	Idle                 bool     `json:"idle"`
+	Standby              bool     `json:"standby"`
+}
+
+// GSProLog is the recent traffic with GSPro, recorded when the traffic log
+// is enabled
+type GSProLog struct {
+	Enabled bool                     `json:"enabled"`
+	Entries []simulator.TrafficEntry `json:"entries"`
+}
+
 type CameraConfig struct {
-	URL     string `json:"url"`
-	Enabled bool   `json:"enabled"`
+	URL     string            `json:"url"`
+	Enabled bool              `json:"enabled"`
+	Cameras []camera.Endpoint `json:"cameras,omitempty"`
 }
 
-type AppSettings struct {
-	DeviceName              string `json:"deviceName"`
-	SpinMode                string `json:"spinMode"`
-	OmniSpeedUnit           string `json:"omniSpeedUnit"`
-	OmniDistanceUnit        string `json:"omniDistanceUnit"`
-	OmniGreenSpeed          int    `json:"omniGreenSpeed"`
-	OmniCarryAdjustment     int    `json:"omniCarryAdjustment"`
-	GSProIP                 string `json:"gsproIP"`
-	GSProPort               int    `json:"gsproPort"`
-	GSProAutoConnect        bool   `json:"gsproAutoConnect"`
-	InfiniteTeesIP          string `json:"infiniteTeesIP"`
-	InfiniteTeesPort        int    `json:"infiniteTeesPort"`
-	InfiniteTeesAutoConnect bool   `json:"infiniteTeesAutoConnect"`
+type CameraStatus struct {
+	Enabled bool            `json:"enabled"`
+	Cameras []camera.Status `json:"cameras"`
 }
 
-type FeatureFlags struct {
-	ExternalCamera bool `json:"externalCamera"`
+type AppSettings struct {
+	DeviceName              string                         `json:"deviceName"`
+	DeviceAddress           string                         `json:"deviceAddress"`
+	SpinMode                string                         `json:"spinMode"`
+	ClubSpinModes           core.ClubSpinModes             `json:"clubSpinModes"`
+	OmniSpeedUnit           string                         `json:"omniSpeedUnit"`
+	OmniDistanceUnit        string                         `json:"omniDistanceUnit"`
+	OmniGreenSpeed          int                            `json:"omniGreenSpeed"`
+	OmniCarryAdjustment     int                            `json:"omniCarryAdjustment"`
+	GSProIP                 string                         `json:"gsproIP"`
+	GSProStandbyIP          string                         `json:"gsproStandbyIP"`
+	GSProStandbyPort        int                            `json:"gsproStandbyPort"`
+	GSProTrafficLog         bool                           `json:"gsproTrafficLog"`
+	GSProPayload            gspro.PayloadIdentity          `json:"gsproPayload"`
+	GSProShotNumberPolicy   gspro.ShotNumberPolicy         `json:"gsproShotNumberPolicy"`
+	GSProRetryQueue         gspro.RetryQueueSettings       `json:"gsproRetryQueue"`
+	GSProPort               int                            `json:"gsproPort"`
+	GSProAutoConnect        bool                           `json:"gsproAutoConnect"`
+	InfiniteTeesIP          string                         `json:"infiniteTeesIP"`
+	InfiniteTeesPort        int                            `json:"infiniteTeesPort"`
+	InfiniteTeesAutoConnect bool                           `json:"infiniteTeesAutoConnect"`
+	VoiceEnabled            bool                           `json:"voiceEnabled"`
+	VoiceMetrics            []string                       `json:"voiceMetrics"`
+	ChimeEnabled            bool                           `json:"chimeEnabled"`
+	ChimeVolume             int                            `json:"chimeVolume"`
+	ChimeOutput             string                         `json:"chimeOutput"`
+	MisreadPrompt           bool                           `json:"misreadPrompt"`
+	ShotRawData             bool                           `json:"shotRawData"`
+	SpinEstimation          bool                           `json:"spinEstimation"`
+	SpinCurves              map[string]core.SpinCurve      `json:"spinCurves"`
+	PlacementZone           core.PlacementZone             `json:"placementZone"`
+	AlignmentSmoothing      core.AlignmentSmoothing        `json:"alignmentSmoothing"`
+	Locale                  string                         `json:"locale"`
+	Locales                 []string                       `json:"locales"`
+	NumberFormat            i18n.NumberFormat              `json:"numberFormat"`
+	Environment             core.EnvironmentSettings       `json:"environment"`
+	Idle                    core.IdleSettings              `json:"idle"`
+	ShotCooldownMs          int                            `json:"shotCooldownMs"`
+	SleepSchedule           core.SleepSchedule             `json:"sleepSchedule"`
+	Heartbeat               core.HeartbeatSettings         `json:"heartbeat"`
+	LogRotation             logging.Rotation               `json:"logRotation"`
+	ClubSpeedEstimation     bool                           `json:"clubSpeedEstimation"`
+	SmashFactors            map[string]float64             `json:"smashFactors"`
+	SpinConventions         map[string]core.SpinConvention `json:"spinConventions"`
+	SpinConventionPresets   map[string]core.SpinConvention `json:"spinConventionPresets"`
 }
 
-func NewServer(stateManager *core.StateManager, bluetoothManager *core.BluetoothManager, launchMonitor *core.LaunchMonitor, cameraManager *camera.Manager, gsproIP string, gsproPort int, itIP string, itPort int, enableExternalCamera bool) *Server {
-	gsproIntegration := gspro.GetInstance(stateManager, launchMonitor, gsproIP, gsproPort)
-	itIntegration := infinitetees.GetInstance(stateManager, launchMonitor, itIP, itPort)
+func NewServer(application *app.App) *Server {
+	stateManager := application.State
 
 	server := &Server{
 		stateManager:            stateManager,
-		bluetoothManager:        bluetoothManager,
-		launchMonitor:           launchMonitor,
-		gsproIntegration:        gsproIntegration,
-		infiniteTeesIntegration: itIntegration,
-		cameraManager:           cameraManager,
-		enableExternalCamera:    enableExternalCamera,
-		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
-		},
-		clients:   make(map[*websocket.Conn]chan []byte),
-		broadcast: make(chan []byte, 100),
-		webRoot:   resolveWebRoot(),
+		bluetoothManager:        application.Bluetooth,
+		launchMonitor:           application.LaunchMonitor,
+		gsproIntegration:        application.GSPro,
+		infiniteTeesIntegration: application.InfiniteTees,
+		awesomeGolfIntegration:  application.AwesomeGolf,
+		cameraManager:           application.Camera,
+		supervisor:              application.Supervisor,
+		battery:                 application.Battery,
+		ballHeatmap:             application.BallHeatmap,
+		knownDevices:            application.KnownDevices,
+		features:                application.Features,
+		clients:                 make(map[statusClient]chan []byte),
+		broadcast:               make(chan []byte, 100),
+		settingsPending:         make(chan struct{}, 1),
+		webRoot:                 resolveWebRoot(),
+		bindAddress:             DefaultBindAddress,
+		overlay:                 newOverlayHub(),
+		shotHistory:             application.History,
+		gameManager:             application.Games,
+		exporter:                application.Exporter,
+		announcer:               application.Voice,
+		chime:                   application.Chime,
+		placement:               application.Placement,
+		calibrationWizard:       core.NewMatCalibrationWizard(),
+	}
+	server.simulator, _ = application.Bluetooth.GetClient().(*core.SimulatorBluetoothClient)
+	settings := config.GetInstance().GetSettings()
+	server.positionThrottle = core.NewThrottle(core.RealClock(), core.RateInterval(settings.PositionBroadcastRate), server.broadcastDeviceStatus)
+	server.settingsThrottle = core.NewThrottle(core.RealClock(), settingsBroadcastInterval, server.broadcastSettings)
+	server.statusLogLimiter = core.NewRateLimiter(core.RealClock(), core.RateInterval(settings.PositionLogRate))
+	server.upgrader = websocket.Upgrader{
+		CheckOrigin: server.checkOrigin,
 	}
 
 	server.setupCallbacks()
-	go server.handleMessages()
+	server.setupOverlayCallbacks()
+	server.gsproIntegration.OnStaleConnectionRecovered(server.broadcastStaleConnection)
+	server.gsproIntegration.OnQueueChanged(server.broadcastGSProStatus)
+	server.features.OnChange(server.onFeatureChanged)
+	config.GetInstance().OnSave(server.settingsThrottle.Trigger)
+	server.battery.OnChange(server.broadcastDeviceStatus)
+	server.shotHistory.OnShot(server.broadcastShotVideos)
+	server.shotHistory.OnVideo(server.broadcastShotVideos)
+	server.gameManager.OnChange(server.broadcastGameState)
+	server.gameManager.OnWedgeChange(server.broadcastWedgeState)
+	server.gameManager.OnCombineChange(server.broadcastCombineState)
+	server.gameManager.OnWarmupChange(server.onWarmupChange)
+	server.exporter.Configure(settings.Export)
+	server.exporter.OnChange(server.broadcastExportStatus)
+	server.supervisor.Go("shot export", server.exporter.Run)
+	server.dashboard = core.NewBayDashboard(core.RealClock(), server.localBayStatus)
+	server.dashboard.Configure(settings.Dashboard)
+	server.dashboard.SetEnabled(server.features.Enabled(core.FeatureDashboard))
+	server.dashboard.OnChange(server.broadcastDashboard)
+	server.supervisor.Go("bay dashboard", server.dashboard.Run)
+	server.supervisor.Go("web broadcaster", server.handleMessages)
+	server.supervisor.Go("settings broadcaster", server.sendSettings)
 
 	return server
 }
@@ -172,154 +382,293 @@ func resolveWebRoot() string {
 
 func (s *Server) setupCallbacks() {
 	// Register all state callbacks to broadcast updates via WebSocket
-	s.stateManager.RegisterConnectionStatusCallback(func(oldValue, newValue core.ConnectionStatus) {
+	s.track(s.stateManager.RegisterConnectionStatusCallback(func(oldValue, newValue core.ConnectionStatus) {
 		s.broadcastDeviceStatus()
-	})
+		s.announceDiscovery()
+	}))
 
-	s.stateManager.RegisterDeviceDisplayNameCallback(func(oldValue, newValue *string) {
+	s.track(s.stateManager.RegisterDeviceDisplayNameCallback(func(oldValue, newValue *string) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterBatteryLevelCallback(func(oldValue, newValue *int) {
+	s.track(s.stateManager.RegisterBatteryLevelCallback(func(oldValue, newValue *int) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterLaunchMonitorStatusCallback(func(oldValue, newValue core.LaunchMonitorStatus) {
+	s.track(s.stateManager.RegisterLaunchMonitorStatusCallback(func(oldValue, newValue core.LaunchMonitorStatus) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterBallDetectedCallback(func(oldValue, newValue bool) {
+	s.track(s.stateManager.RegisterBallDetectedCallback(func(oldValue, newValue bool) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterBallReadyCallback(func(oldValue, newValue bool) {
+	s.track(s.stateManager.RegisterBallReadyCallback(func(oldValue, newValue bool) {
 		s.broadcastDeviceStatus()
-	})
+	}))
+
+	s.track(s.stateManager.RegisterBallPositionCallback(func(oldValue, newValue *core.BallPosition) {
+		s.positionThrottle.Trigger()
+	}))
 
-	s.stateManager.RegisterBallPositionCallback(func(oldValue, newValue *core.BallPosition) {
+	s.track(s.stateManager.RegisterClubCallback(func(oldValue, newValue *core.ClubType) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterClubCallback(func(oldValue, newValue *core.ClubType) {
+	s.track(s.stateManager.RegisterHandednessCallback(func(oldValue, newValue *core.HandednessType) {
 		s.broadcastDeviceStatus()
-	})
+	}))
+
+	s.track(s.stateManager.RegisterPlayerNameCallback(func(oldValue, newValue *string) {
+		s.broadcastGSProStatus()
+	}))
 
-	s.stateManager.RegisterHandednessCallback(func(oldValue, newValue *core.HandednessType) {
+	s.track(s.stateManager.RegisterLastErrorCallback(func(oldValue, newValue error) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterLastErrorCallback(func(oldValue, newValue error) {
+	s.track(s.stateManager.RegisterLastBallMetricsCallback(func(oldValue, newValue *core.BallMetrics) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterLastBallMetricsCallback(func(oldValue, newValue *core.BallMetrics) {
+	s.track(s.stateManager.RegisterLastClubMetricsCallback(func(oldValue, newValue *core.ClubMetrics) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterLastClubMetricsCallback(func(oldValue, newValue *core.ClubMetrics) {
+	s.track(s.stateManager.RegisterDeviceTypeCallback(func(oldValue, newValue core.DeviceType) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterDeviceTypeCallback(func(oldValue, newValue core.DeviceType) {
+	s.track(s.stateManager.RegisterOmniHomeGolfStatusCallback(func(oldValue, newValue *int) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterOmniHomeGolfStatusCallback(func(oldValue, newValue *int) {
+	s.track(s.stateManager.RegisterOmniStatusCallback(func(oldValue, newValue *int) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterOmniStatusCallback(func(oldValue, newValue *int) {
+	s.track(s.stateManager.RegisterOmniClubSelectionCallback(func(oldValue, newValue *int) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterOmniClubSelectionCallback(func(oldValue, newValue *int) {
+	s.track(s.stateManager.RegisterOmniSensorStatusCallback(func(oldValue, newValue *int) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterOmniSensorStatusCallback(func(oldValue, newValue *int) {
+	s.track(s.stateManager.RegisterCapacitorReadyCallback(func(oldValue, newValue bool) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterCapacitorReadyCallback(func(oldValue, newValue bool) {
+	s.track(s.stateManager.RegisterDeviceIdleCallback(func(oldValue, newValue bool) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterBatteryChargingCallback(func(oldValue, newValue *int) {
+	s.track(s.stateManager.RegisterDeviceStandbyCallback(func(oldValue, newValue bool) {
 		s.broadcastDeviceStatus()
-	})
+	}))
+
+	s.track(s.stateManager.RegisterSessionPausedCallback(func(oldValue, newValue bool) {
+		s.broadcastDeviceStatus()
+	}))
+
+	s.track(s.stateManager.RegisterSwingStickModeCallback(func(oldValue, newValue bool) {
+		s.broadcastDeviceStatus()
+	}))
 
-	s.stateManager.RegisterGSProStatusCallback(func(oldValue, newValue core.GSProConnectionStatus) {
+	s.track(s.stateManager.RegisterLastSwingMetricsCallback(func(oldValue, newValue *core.ClubMetrics) {
+		s.broadcastDeviceStatus()
+	}))
+
+	s.track(s.stateManager.RegisterShotCooldownCallback(func(oldValue, newValue bool) {
+		s.broadcastDeviceStatus()
+	}))
+
+	s.track(s.stateManager.RegisterBatteryChargingCallback(func(oldValue, newValue *int) {
+		s.broadcastDeviceStatus()
+	}))
+
+	s.track(s.stateManager.RegisterGSProStatusCallback(func(oldValue, newValue core.GSProConnectionStatus) {
 		s.broadcastGSProStatus()
-	})
+	}))
 
-	s.stateManager.RegisterInfiniteTeesStatusCallback(func(oldValue, newValue core.InfiniteTeesConnectionStatus) {
+	s.track(s.stateManager.RegisterInfiniteTeesStatusCallback(func(oldValue, newValue core.InfiniteTeesConnectionStatus) {
 		s.broadcastInfiniteTeesStatus()
-	})
+	}))
+
+	s.track(s.stateManager.RegisterAwesomeGolfStatusCallback(func(oldValue, newValue core.AwesomeGolfConnectionStatus) {
+		s.broadcastAwesomeGolfStatus()
+	}))
 
-	s.stateManager.RegisterCameraURLCallback(func(oldValue, newValue *string) {
+	s.track(s.stateManager.RegisterCameraURLCallback(func(oldValue, newValue *string) {
 		s.broadcastCameraConfig()
-	})
+	}))
 
-	s.stateManager.RegisterCameraEnabledCallback(func(oldValue, newValue bool) {
+	s.track(s.stateManager.RegisterCameraEnabledCallback(func(oldValue, newValue bool) {
 		s.broadcastCameraConfig()
-	})
+	}))
 
-	s.stateManager.RegisterIsAligningCallback(func(oldValue, newValue bool) {
+	s.track(s.stateManager.RegisterIsAligningCallback(func(oldValue, newValue bool) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterAlignmentAngleCallback(func(oldValue, newValue float64) {
+	s.track(s.stateManager.RegisterAlignmentAngleCallback(func(oldValue, newValue float64) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterIsAlignedCallback(func(oldValue, newValue bool) {
+	s.track(s.stateManager.RegisterIsAlignedCallback(func(oldValue, newValue bool) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterFirmwareVersionCallback(func(oldValue, newValue *string) {
+	s.track(s.stateManager.RegisterFirmwareVersionCallback(func(oldValue, newValue *string) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterLauncherVersionCallback(func(oldValue, newValue *string) {
+	s.track(s.stateManager.RegisterLauncherVersionCallback(func(oldValue, newValue *string) {
 		s.broadcastDeviceStatus()
-	})
+	}))
 
-	s.stateManager.RegisterMMIVersionCallback(func(oldValue, newValue *string) {
+	s.track(s.stateManager.RegisterMMIVersionCallback(func(oldValue, newValue *string) {
 		s.broadcastDeviceStatus()
-	})
+	}))
+
+	s.track(s.stateManager.RegisterMisreadShotsCallback(func(oldValue, newValue []core.MisreadShot) {
+		s.broadcastMisreads()
+	}))
+
+	s.trackFunc(s.chime.OnChime(func(volume int) {
+		s.broadcastChime(volume)
+	}))
+
+	s.trackFunc(s.placement.OnGuidance(func(guidance core.PlacementGuidance) {
+		s.broadcastPlacement(guidance)
+	}))
+}
+
+func (s *Server) track(sub *core.Subscription) {
+	s.subscriptionsMu.Lock()
+	s.subscriptions = append(s.subscriptions, sub)
+	s.subscriptionsMu.Unlock()
+}
+
+// trackFunc keeps the func that removes a listener registered outside the
+// state manager
+func (s *Server) trackFunc(unsubscribe func()) {
+	s.subscriptionsMu.Lock()
+	s.unsubscribes = append(s.unsubscribes, unsubscribe)
+	s.subscriptionsMu.Unlock()
+}
+
+func (s *Server) releaseCallbacks() {
+	s.subscriptionsMu.Lock()
+	subscriptions := s.subscriptions
+	unsubscribes := s.unsubscribes
+	s.subscriptions = nil
+	s.unsubscribes = nil
+	s.subscriptionsMu.Unlock()
+
+	for _, sub := range subscriptions {
+		s.stateManager.UnregisterCallback(sub)
+	}
+	for _, unsubscribe := range unsubscribes {
+		unsubscribe()
+	}
+}
+
+func (s *Server) closeClients() {
+	// Closing the connection ends each handler's read loop, which removes the
+	// client and closes its channel
+	s.clientsMu.Lock()
+	for client := range s.clients {
+		client.Close()
+	}
+	s.clientsMu.Unlock()
+
+	s.overlay.closeAll()
 }
 
 func (s *Server) handleMessages() {
 	for message := range s.broadcast {
-		s.clientsMu.Lock()
-		clientCount := len(s.clients)
-		activeChannels := make([]chan []byte, 0, clientCount)
-		for _, clientChan := range s.clients {
-			activeChannels = append(activeChannels, clientChan)
-		}
-		s.clientsMu.Unlock()
+		s.sendToClients(message)
+	}
+}
 
-		for _, clientChan := range activeChannels {
-			func(ch chan []byte) {
-				defer func() {
-					if r := recover(); r != nil {
-						// Channel was closed by client disconnect, ignore panic
-					}
-				}()
-				select {
-				case ch <- message:
-				default:
-					log.Printf("WebSocket client buffer full, dropping message")
-				}
-			}(clientChan)
+func (s *Server) sendToClients(message []byte) {
+	// Send while holding the lock so a disconnecting client cannot close
+	// its channel mid-send; sends never block
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	for client, clientChan := range s.clients {
+		select {
+		case clientChan <- message:
+		default:
+			// A client whose writer has fallen a full buffer behind is
+			// evicted so it cannot hold back everyone else
+			log.Printf("%s client %s is not keeping up, disconnecting", client.Transport(), client.RemoteAddr())
+			delete(s.clients, client)
+			client.Close()
 		}
 	}
 }
 
 func (s *Server) broadcastDeviceStatus() {
 	status := s.getDeviceStatus()
-	log.Printf("Broadcasting device status - BallDetected: %v, BallPosition: %+v", status.BallDetected, status.BallPosition)
-	msg := WSMessage{Type: "deviceStatus", Data: status}
+
+	s.deviceStatusMu.Lock()
+	defer s.deviceStatusMu.Unlock()
+
+	delta, err := s.deviceStatusDelta(status)
+	if err != nil {
+		log.Printf("Failed to encode device status: %v", err)
+		return
+	}
+	if len(delta) == 0 {
+		return
+	}
+
+	if s.statusLogLimiter.Allow() {
+		log.Printf("Broadcasting device status - BallDetected: %v, BallPosition: %+v", status.BallDetected, status.BallPosition)
+	}
+	// Clients get the full status on connect and merge only changed fields
+	// after that, so position ticks do not resend the whole status
+	msg := WSMessage{Type: "deviceStatusDelta", Data: delta}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) deviceStatusDelta(status DeviceStatus) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(status)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	delta := make(map[string]json.RawMessage)
+	for key, value := range fields {
+		if previous, ok := s.lastDeviceStatus[key]; !ok || !bytes.Equal(previous, value) {
+			delta[key] = value
+		}
+	}
+	s.lastDeviceStatus = fields
+	return delta, nil
+}
+
+func (s *Server) broadcastChime(volume int) {
+	msg := WSMessage{Type: "chime", Data: map[string]int{"volume": volume}}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) broadcastPlacement(guidance core.PlacementGuidance) {
+	msg := WSMessage{Type: "placement", Data: guidance}
 	data, _ := json.Marshal(msg)
 	select {
 	case s.broadcast <- data:
@@ -337,6 +686,17 @@ func (s *Server) broadcastGSProStatus() {
 	}
 }
 
+// broadcastStaleConnection tells the UI a simulator stopped answering and was
+// reconnected, as the last shot may not have reached it
+func (s *Server) broadcastStaleConnection(stale simulator.StaleConnection) {
+	msg := WSMessage{Type: "staleConnectionRecovered", Data: stale}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
 func (s *Server) broadcastInfiniteTeesStatus() {
 	status := s.getInfiniteTeesStatus()
 	msg := WSMessage{Type: "infiniteTeesStatus", Data: status}
@@ -350,7 +710,7 @@ func (s *Server) broadcastInfiniteTeesStatus() {
 func (s *Server) getDeviceStatus() DeviceStatus {
 	var lastErrorStr string
 	if err := s.stateManager.GetLastError(); err != nil {
-		lastErrorStr = err.Error()
+		lastErrorStr = i18n.Error(err)
 	}
 
 	connectionStatus := "disconnected"
@@ -365,6 +725,11 @@ func (s *Server) getDeviceStatus() DeviceStatus {
 		connectionStatus = "error"
 	}
 
+	var pairedDevice *core.BondedDevice
+	if bonded := s.bluetoothManager.BondedDevice(); bonded.Address != "" {
+		pairedDevice = &bonded
+	}
+
 	return DeviceStatus{
 		ConnectionStatus:    connectionStatus,
 		DeviceName:          s.stateManager.GetDeviceDisplayName(),
@@ -391,13 +756,22 @@ func (s *Server) getDeviceStatus() DeviceStatus {
 		OmniSensorStatus:    s.stateManager.GetOmniSensorStatus(),
 		CapacitorReady:      s.stateManager.GetCapacitorReady(),
 		BatteryCharging:     s.stateManager.GetBatteryCharging(),
+		Battery:             s.battery.Estimate(),
+		Idle:                s.stateManager.GetDeviceIdle(),
+		Standby:             s.stateManager.GetDeviceStandby(),
+		Paused:              s.stateManager.GetSessionPaused(),
+		SwingStick:          s.stateManager.GetSwingStickMode(),
+		LastSwingMetrics:    s.stateManager.GetLastSwingMetrics(),
+		ShotCooldown:        s.stateManager.GetShotCooldown(),
+		PairingSupported:    s.bluetoothManager.PairingSupported(),
+		PairedDevice:        pairedDevice,
 	}
 }
 
 func (s *Server) getGSProStatus() GSProStatus {
 	var lastErrorStr string
 	if err := s.stateManager.GetGSProError(); err != nil {
-		lastErrorStr = err.Error()
+		lastErrorStr = i18n.Error(err)
 	}
 
 	connectionStatus := "disconnected"
@@ -414,19 +788,43 @@ func (s *Server) getGSProStatus() GSProStatus {
 	ip, port := s.gsproIntegration.GetConnectionInfo()
 	settings := config.GetInstance().GetSettings()
 
+	primary, standby, failedOver := s.gsproIntegration.FailoverState()
+	primaryStatus := GSProEndpointStatus{Role: "primary", IP: primary.Host, Port: primary.Port, Active: !failedOver, Status: connectionStatus}
+	if failedOver {
+		primaryStatus.Status = "failed"
+	}
+	endpoints := []GSProEndpointStatus{primaryStatus}
+	if standby.Host != "" {
+		standbyStatus := GSProEndpointStatus{Role: "standby", IP: standby.Host, Port: standby.Port, Active: failedOver, Status: "standby"}
+		if failedOver {
+			standbyStatus.Status = connectionStatus
+		}
+		endpoints = append(endpoints, standbyStatus)
+	}
+
+	players, current := s.gsproIntegration.Players()
+	queue := s.gsproIntegration.Queue()
+
 	return GSProStatus{
 		ConnectionStatus: connectionStatus,
 		IP:               ip,
 		Port:             port,
 		AutoConnect:      settings.GSProAutoConnect,
 		LastError:        lastErrorStr,
+		FailedOver:       failedOver,
+		ShotNumber:       s.gsproIntegration.ShotNumber(),
+		Endpoints:        endpoints,
+		Player:           current,
+		Players:          players,
+		QueuedShots:      len(queue.Shots),
+		AwaitingReplay:   queue.AwaitingReplay,
 	}
 }
 
 func (s *Server) getInfiniteTeesStatus() InfiniteTeesStatus {
 	var lastErrorStr string
 	if err := s.stateManager.GetInfiniteTeesError(); err != nil {
-		lastErrorStr = err.Error()
+		lastErrorStr = i18n.Error(err)
 	}
 
 	connectionStatus := "disconnected"
@@ -451,7 +849,24 @@ func (s *Server) getInfiniteTeesStatus() InfiniteTeesStatus {
 	}
 }
 
+// Start listens on port and serves requests until Stop
 func (s *Server) Start(port int) error {
+	listener, err := s.Listen(port)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Listen binds the web server's address. Once it returns without an error
+// the port is ours and Serve can handle requests on the listener.
+func (s *Server) Listen(port int) (net.Listener, error) {
+	return net.Listen("tcp", s.listenAddress(port))
+}
+
+// Serve handles requests on a listener from Listen until Stop
+func (s *Server) Serve(listener net.Listener) error {
+	port := listener.Addr().(*net.TCPAddr).Port
 	router := mux.NewRouter()
 
 	// Serve static files with no-cache headers for development
@@ -464,59 +879,33 @@ func (s *Server) Start(port int) error {
 	}))
 
 	// API routes
-	api := router.PathPrefix("/api").Subrouter()
-
-	// Device endpoints
-	api.HandleFunc("/device/status", s.handleDeviceStatus).Methods("GET")
-	api.HandleFunc("/device/connect", s.handleDeviceConnect).Methods("POST")
-	api.HandleFunc("/device/disconnect", s.handleDeviceDisconnect).Methods("POST")
-	api.HandleFunc("/device/practice", s.handlePracticeMode).Methods("POST")
-
-	// GSPro endpoints
-	api.HandleFunc("/gspro/status", s.handleGSProStatus).Methods("GET")
-	api.HandleFunc("/gspro/connect", s.handleGSProConnect).Methods("POST")
-	api.HandleFunc("/gspro/disconnect", s.handleGSProDisconnect).Methods("POST")
-	api.HandleFunc("/gspro/config", s.handleGSProConfig).Methods("GET", "POST")
-
-	// Infinite Tees endpoints
-	api.HandleFunc("/infinitetees/status", s.handleInfiniteTeesStatus).Methods("GET")
-	api.HandleFunc("/infinitetees/connect", s.handleInfiniteTeesConnect).Methods("POST")
-	api.HandleFunc("/infinitetees/disconnect", s.handleInfiniteTeesDisconnect).Methods("POST")
-	api.HandleFunc("/infinitetees/config", s.handleInfiniteTeesConfig).Methods("GET", "POST")
-
-	// Camera endpoints
-	api.HandleFunc("/camera/config", s.handleCameraConfig).Methods("GET", "POST")
-
-	// Settings endpoints
-	api.HandleFunc("/settings", s.handleSettings).Methods("GET", "POST")
-
-	// Feature flags endpoint
-	api.HandleFunc("/features", s.handleFeatures).Methods("GET")
-
-	// Alignment endpoints
-	api.HandleFunc("/alignment/start", s.handleAlignmentStart).Methods("POST")
-	api.HandleFunc("/alignment/stop", s.handleAlignmentStop).Methods("POST")
-	api.HandleFunc("/alignment/cancel", s.handleAlignmentCancel).Methods("POST")
-	api.HandleFunc("/alignment/handedness", s.handleAlignmentHandedness).Methods("POST")
+	s.registerAPI(router)
 
 	// WebSocket endpoint
-	router.HandleFunc("/ws", s.handleWebSocket)
+	router.HandleFunc("/ws", s.requireScope(core.AccessViewer, s.handleWebSocket))
+
+	// Read-only overlay for second screens and OBS browser sources
+	router.HandleFunc("/overlay", s.requireScope(core.AccessViewer, s.handleOverlayPage)).Methods("GET")
+	router.HandleFunc("/overlay/ws", s.requireScope(core.AccessViewer, s.handleOverlayWebSocket))
 
 	// Serve index.html for all non-API routes (SPA support)
 	router.PathPrefix("/").HandlerFunc(s.handleIndex)
 
+	addr := listener.Addr().String()
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
-		Handler: router,
+		Addr:    addr,
+		Handler: s.originMiddleware(s.accessCookieMiddleware(router)),
 	}
 
 	s.httpServerMu.Lock()
 	s.httpServer = httpServer
 	s.httpServerMu.Unlock()
 
-	log.Printf("Web server starting on port %d", port)
-	log.Printf("Access via: http://localhost:%d", port)
-	err := httpServer.ListenAndServe()
+	s.startDiscovery(port)
+
+	log.Printf("Web server starting on %s", addr)
+	log.Printf("Access via: %s", LocalURL(listener.Addr().(*net.TCPAddr).IP.String(), port))
+	err := httpServer.Serve(listener)
 	if err == http.ErrServerClosed {
 		return nil
 	}
@@ -528,10 +917,16 @@ func (s *Server) Stop(ctx context.Context) error {
 	httpServer := s.httpServer
 	s.httpServerMu.Unlock()
 
+	s.releaseCallbacks()
+	// Hijacked WebSocket connections are not closed by Shutdown
+	s.closeClients()
+
 	if httpServer == nil {
 		return nil
 	}
 
+	s.stopDiscovery()
+
 	return httpServer.Shutdown(ctx)
 }
 
@@ -547,16 +942,14 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	clientChan := make(chan []byte, 100)
-
-	s.clientsMu.Lock()
-	s.clients[conn] = clientChan
-	s.clientsMu.Unlock()
+	client := wsClient{conn: conn}
+	clientChan := s.addClient(client)
+	defer s.removeClient(client, clientChan)
 
 	go func() {
 		defer conn.Close()
 		for msg := range clientChan {
-			conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
+			conn.SetWriteDeadline(time.Now().Add(clientWriteTimeout))
 			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
 				log.Printf("WebSocket send error: %v, closing client", err)
 				return
@@ -564,18 +957,6 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	s.sendInitialStatus(clientChan)
-
-	defer func() {
-		s.clientsMu.Lock()
-		if ch, exists := s.clients[conn]; exists {
-			delete(s.clients, conn)
-			close(ch)
-		}
-		s.clientsMu.Unlock()
-		conn.Close()
-	}()
-
 	for {
 		_, _, err := conn.ReadMessage()
 		if err != nil {
@@ -603,11 +984,70 @@ func (s *Server) sendInitialStatus(clientChan chan []byte) {
 	data, _ = json.Marshal(msg)
 	clientChan <- data
 
+	// Send Awesome Golf status
+	msg = WSMessage{Type: "awesomeGolfStatus", Data: s.getAwesomeGolfStatus()}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
 	// Send camera config
 	cameraConfig := s.getCameraConfig()
 	msg = WSMessage{Type: "cameraConfig", Data: cameraConfig}
 	data, _ = json.Marshal(msg)
 	clientChan <- data
+
+	// Send the optional features that are on
+	msg = WSMessage{Type: "features", Data: s.featureFlags()}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
+	// Send ball placement guidance
+	msg = WSMessage{Type: "placement", Data: s.placement.Guidance()}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
+	// Send shots awaiting a misread decision
+	msg = WSMessage{Type: "misreads", Data: s.misreadShots()}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
+	// Send the target game in progress
+	msg = WSMessage{Type: "game", Data: s.gameManager.State()}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
+	// Send the wedge practice in progress
+	msg = WSMessage{Type: "wedgePractice", Data: s.gameManager.WedgeState()}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
+	// Send the combine in progress
+	msg = WSMessage{Type: "combine", Data: s.gameManager.CombineState()}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
+	// Send the warmup in progress
+	msg = WSMessage{Type: "warmup", Data: s.gameManager.WarmupState()}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
+	// Send the shot export's progress
+	msg = WSMessage{Type: "exportStatus", Data: translateExportStatus(s.exporter.Status())}
+	data, _ = json.Marshal(msg)
+	clientChan <- data
+
+	// Send every bay's status while the dashboard is on
+	if s.features.Enabled(core.FeatureDashboard) {
+		msg = WSMessage{Type: "dashboard", Data: s.dashboard.Bays()}
+		data, _ = json.Marshal(msg)
+		clientChan <- data
+	}
+
+	// Send the result of the last update check
+	if s.updater != nil {
+		msg = WSMessage{Type: "updateStatus", Data: s.updater.Status()}
+		data, _ = json.Marshal(msg)
+		clientChan <- data
+	}
 }
 
 func (s *Server) handleDeviceStatus(w http.ResponseWriter, r *http.Request) {
@@ -616,16 +1056,36 @@ func (s *Server) handleDeviceStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(status)
 }
 
+// handleDeviceBattery reports the battery estimate with the charge cycles
+// and the drain rates of recent sessions
+func (s *Server) handleDeviceBattery(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.battery.History())
+}
+
 func (s *Server) handleDeviceConnect(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		DeviceName string `json:"deviceName"`
-	}
+	var req DeviceConnectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 		return
 	}
+	if req.Nickname != "" {
+		device, ok := s.knownDevices.ByNickname(req.Nickname)
+		if !ok {
+			http.Error(w, i18n.Error(core.ErrUnknownDevice), http.StatusNotFound)
+			return
+		}
+		req.DeviceName, req.DeviceAddress = device.Name, device.Address
+	}
+	if !core.ValidDeviceAddress(req.DeviceAddress) {
+		http.Error(w, i18n.Tf("Invalid %s value", "deviceAddress"), http.StatusBadRequest)
+		return
+	}
+	if req.DeviceName == "" && req.DeviceAddress == "" {
+		req.DeviceAddress = config.GetInstance().GetSettings().DeviceAddress
+	}
 
-	go s.bluetoothManager.StartBluetoothConnection(req.DeviceName, "")
+	go s.bluetoothManager.StartBluetoothConnection(req.DeviceName, req.DeviceAddress)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -634,6 +1094,24 @@ func (s *Server) handleDeviceDisconnect(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 }
 
+// handleDeviceScan scans for launch monitors and returns their addresses so
+// one can be saved for connecting without a scan. Devices found are added to
+// the known devices and returned with their nicknames.
+func (s *Server) handleDeviceScan(w http.ResponseWriter, r *http.Request) {
+	devices, err := s.bluetoothManager.Scan(r.Context())
+	if errors.Is(err, core.ErrScanWhileConnected) {
+		http.Error(w, i18n.Error(err), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.knownDevices.Merge(devices))
+}
+
 func (s *Server) handleGSProStatus(w http.ResponseWriter, r *http.Request) {
 	status := s.getGSProStatus()
 	w.Header().Set("Content-Type", "application/json")
@@ -641,12 +1119,9 @@ func (s *Server) handleGSProStatus(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleGSProConnect(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		IP   string `json:"ip"`
-		Port int    `json:"port"`
-	}
+	var req ConnectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 		return
 	}
 
@@ -659,6 +1134,161 @@ func (s *Server) handleGSProConnect(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// gsproDiscoverTimeout bounds a subnet scan for GSPro machines
+const gsproDiscoverTimeout = 5 * time.Second
+
+func (s *Server) handleGSProDiscover(w http.ResponseWriter, r *http.Request) {
+	port := gspro.DefaultConnectServerPort
+	if value := r.URL.Query().Get("port"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > 65535 {
+			http.Error(w, i18n.Tf("Invalid %s", "port"), http.StatusBadRequest)
+			return
+		}
+		port = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), gsproDiscoverTimeout)
+	defer cancel()
+	candidates, err := gspro.Discover(ctx, port)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(candidates)
+}
+
+func (s *Server) handleGSProShotNumberReset(w http.ResponseWriter, r *http.Request) {
+	s.gsproIntegration.ResetShotNumber()
+	s.broadcastGSProStatus()
+	w.WriteHeader(http.StatusOK)
+}
+
+// ResendRequest confirms a resend with the token of the shot shown to the
+// player
+type ResendRequest struct {
+	Token string `json:"token"`
+}
+
+// ResendResult is the number the last shot was resent with
+type ResendResult struct {
+	ShotNumber int `json:"shotNumber"`
+}
+
+// handleGSProResendLast shows the last shot sent to GSPro, or sends it again
+// once the player confirms it
+func (s *Server) handleGSProResendLast(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		shot, err := s.gsproIntegration.LastShot()
+		if err != nil {
+			http.Error(w, i18n.Error(err), resendErrorStatus(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(shot)
+		return
+	}
+
+	var req ResendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+	shotNumber, err := s.gsproIntegration.ResendLastShot(req.Token)
+	if err != nil {
+		http.Error(w, i18n.Error(err), resendErrorStatus(err))
+		return
+	}
+	s.broadcastGSProStatus()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ResendResult{ShotNumber: shotNumber})
+}
+
+// QueueDiscardResult is how many queued shots were dropped
+type QueueDiscardResult struct {
+	Discarded int `json:"discarded"`
+}
+
+// handleGSProQueue returns the shots hit while GSPro was reconnecting that
+// haven't been sent yet
+func (s *Server) handleGSProQueue(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gsproIntegration.Queue())
+}
+
+// handleGSProQueueReplay sends the queued shots once the player confirms
+func (s *Server) handleGSProQueueReplay(w http.ResponseWriter, r *http.Request) {
+	if err := s.gsproIntegration.ReplayQueue(); err != nil {
+		http.Error(w, i18n.Error(err), resendErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gsproIntegration.Queue())
+}
+
+func (s *Server) handleGSProQueueDiscard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(QueueDiscardResult{Discarded: s.gsproIntegration.DiscardQueue()})
+}
+
+func resendErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gspro.ErrNoShotToResend), errors.Is(err, gspro.ErrQueueEmpty):
+		return http.StatusNotFound
+	case errors.Is(err, gspro.ErrResendTokenMismatch), errors.Is(err, gspro.ErrShotAlreadyResent):
+		return http.StatusConflict
+	case errors.Is(err, gspro.ErrNotConnected):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// defaultGSProLogEntries is how many recorded messages /api/v1/gspro/log returns
+// without a limit
+const defaultGSProLogEntries = 100
+
+func (s *Server) handleGSProLog(w http.ResponseWriter, r *http.Request) {
+	limit := defaultGSProLogEntries
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, i18n.T("Invalid limit"), http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(GSProLog{
+		Enabled: s.gsproIntegration.Traffic.Enabled(),
+		Entries: s.gsproIntegration.Traffic.Last(limit),
+	})
+}
+
+// defaultGSProAuditShots is how many shots /api/v1/gspro/audit returns
+// without a limit
+const defaultGSProAuditShots = 50
+
+// handleGSProAudit returns what was sent to GSPro for each recent shot and
+// whether GSPro acknowledged it
+func (s *Server) handleGSProAudit(w http.ResponseWriter, r *http.Request) {
+	limit := defaultGSProAuditShots
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, i18n.T("Invalid limit"), http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gsproIntegration.Audit(limit))
+}
+
 func (s *Server) handleGSProDisconnect(w http.ResponseWriter, r *http.Request) {
 	go func() {
 		s.gsproIntegration.DisableAutoReconnect()
@@ -670,11 +1300,7 @@ func (s *Server) handleGSProDisconnect(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleGSProConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" {
 		settings := config.GetInstance().GetSettings()
-		configData := struct {
-			IP          string `json:"ip"`
-			Port        int    `json:"port"`
-			AutoConnect bool   `json:"autoConnect"`
-		}{
+		configData := ConnectionConfig{
 			IP:          settings.GSProIP,
 			Port:        settings.GSProPort,
 			AutoConnect: settings.GSProAutoConnect,
@@ -682,20 +1308,21 @@ func (s *Server) handleGSProConfig(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(configData)
 	} else {
-		var configData struct {
-			IP          string `json:"ip"`
-			Port        int    `json:"port"`
-			AutoConnect bool   `json:"autoConnect"`
-		}
+		var configData ConnectionConfig
 		if err := json.NewDecoder(r.Body).Decode(&configData); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 			return
 		}
 
-		cfg := config.GetInstance()
-		cfg.SetGSProIP(configData.IP)
-		cfg.SetGSProPort(configData.Port)
-		cfg.SetGSProAutoConnect(configData.AutoConnect)
+		err := config.GetInstance().Update(func(settings *config.Settings) {
+			settings.GSProIP = strings.TrimSpace(configData.IP)
+			settings.GSProPort = configData.Port
+			settings.GSProAutoConnect = configData.AutoConnect
+		})
+		if err != nil {
+			writeSettingsError(w, err)
+			return
+		}
 
 		w.WriteHeader(http.StatusOK)
 	}
@@ -708,12 +1335,9 @@ func (s *Server) handleInfiniteTeesStatus(w http.ResponseWriter, r *http.Request
 }
 
 func (s *Server) handleInfiniteTeesConnect(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		IP   string `json:"ip"`
-		Port int    `json:"port"`
-	}
+	var req ConnectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 		return
 	}
 
@@ -737,11 +1361,7 @@ func (s *Server) handleInfiniteTeesDisconnect(w http.ResponseWriter, r *http.Req
 func (s *Server) handleInfiniteTeesConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" {
 		settings := config.GetInstance().GetSettings()
-		configData := struct {
-			IP          string `json:"ip"`
-			Port        int    `json:"port"`
-			AutoConnect bool   `json:"autoConnect"`
-		}{
+		configData := ConnectionConfig{
 			IP:          settings.InfiniteTeesIP,
 			Port:        settings.InfiniteTeesPort,
 			AutoConnect: settings.InfiniteTeesAutoConnect,
@@ -749,67 +1369,195 @@ func (s *Server) handleInfiniteTeesConfig(w http.ResponseWriter, r *http.Request
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(configData)
 	} else {
-		var configData struct {
-			IP          string `json:"ip"`
-			Port        int    `json:"port"`
-			AutoConnect bool   `json:"autoConnect"`
-		}
+		var configData ConnectionConfig
 		if err := json.NewDecoder(r.Body).Decode(&configData); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 			return
 		}
 
-		cfg := config.GetInstance()
-		cfg.SetInfiniteTeesIP(configData.IP)
-		cfg.SetInfiniteTeesPort(configData.Port)
-		cfg.SetInfiniteTeesAutoConnect(configData.AutoConnect)
+		err := config.GetInstance().Update(func(settings *config.Settings) {
+			settings.InfiniteTeesIP = strings.TrimSpace(configData.IP)
+			settings.InfiniteTeesPort = configData.Port
+			settings.InfiniteTeesAutoConnect = configData.AutoConnect
+		})
+		if err != nil {
+			writeSettingsError(w, err)
+			return
+		}
 
 		w.WriteHeader(http.StatusOK)
 	}
 }
 
-func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
-	if r.Method == "GET" {
-		settings := config.GetInstance().GetSettings()
+// checkSettings validates the settings a POST would save, so none are
+// applied unless all of them are valid
+func checkSettings(current config.Settings, rawSettings map[string]json.RawMessage) error {
+	// Decode into a deep copy; the current settings share their maps and
+	// slices with the config manager
+	data, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	var candidate config.Settings
+	if err := json.Unmarshal(data, &candidate); err != nil {
+		return err
+	}
+
+	var fields []config.FieldError
+	for key, rawValue := range rawSettings {
+		single, _ := json.Marshal(map[string]json.RawMessage{key: rawValue})
+		if err := json.Unmarshal(single, &candidate); err != nil {
+			fields = append(fields, config.FieldError{Field: key, Message: "has the wrong type"})
+		}
+	}
+	if len(fields) > 0 {
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+		return &config.ValidationError{Fields: fields}
+	}
+
+	if rawValue, ok := rawSettings["deviceAddress"]; ok {
+		// The handler trims the address before saving it
+		json.Unmarshal(rawValue, &candidate.DeviceAddress)
+		candidate.DeviceAddress = strings.TrimSpace(candidate.DeviceAddress)
+	}
+	return candidate.Validate()
+}
+
+// writeSettingsError reports a failed settings save, listing the invalid
+// fields when err is a validation error
+func writeSettingsError(w http.ResponseWriter, err error) {
+	var invalid *config.ValidationError
+	if !errors.As(err, &invalid) {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+
+	body := SettingsError{Error: i18n.T("Invalid settings")}
+	for _, field := range invalid.Fields {
+		body.Fields = append(body.Fields, config.FieldError{Field: field.Field, Message: i18n.T(field.Message)})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(body)
+}
+
+// appSettings returns the settings as the web UI edits them
+func (s *Server) appSettings() AppSettings {
+	settings := config.GetInstance().GetSettings()
+	return AppSettings{
+		DeviceName:              settings.DeviceName,
+		DeviceAddress:           settings.DeviceAddress,
+		SpinMode:                settings.SpinMode,
+		ClubSpinModes:           settings.ClubSpinModes,
+		OmniSpeedUnit:           settings.OmniSpeedUnit,
+		OmniDistanceUnit:        settings.OmniDistanceUnit,
+		OmniGreenSpeed:          settings.OmniGreenSpeed,
+		OmniCarryAdjustment:     settings.OmniCarryAdjustment,
+		GSProIP:                 settings.GSProIP,
+		GSProStandbyIP:          settings.GSProStandbyIP,
+		GSProStandbyPort:        settings.GSProStandbyPort,
+		GSProTrafficLog:         settings.GSProTrafficLog,
+		GSProPayload:            settings.GSProPayload,
+		GSProShotNumberPolicy:   settings.GSProShotNumberPolicy,
+		GSProRetryQueue:         settings.GSProRetryQueue,
+		GSProPort:               settings.GSProPort,
+		GSProAutoConnect:        settings.GSProAutoConnect,
+		InfiniteTeesIP:          settings.InfiniteTeesIP,
+		InfiniteTeesPort:        settings.InfiniteTeesPort,
+		InfiniteTeesAutoConnect: settings.InfiniteTeesAutoConnect,
+		VoiceEnabled:            settings.VoiceEnabled,
+		VoiceMetrics:            settings.VoiceMetrics,
+		ChimeEnabled:            settings.ChimeEnabled,
+		ChimeVolume:             settings.ChimeVolume,
+		ChimeOutput:             settings.ChimeOutput,
+		MisreadPrompt:           settings.MisreadPrompt,
+		ShotRawData:             settings.ShotRawData,
+		SpinEstimation:          settings.SpinEstimation,
+		SpinCurves:              settings.SpinCurves,
+		PlacementZone:           settings.PlacementZone,
+		AlignmentSmoothing:      settings.AlignmentSmoothing,
+		Locale:                  i18n.Locale(),
+		Locales:                 i18n.Locales(),
+		NumberFormat:            settings.NumberFormat,
+		Environment:             settings.Environment,
+		Idle:                    settings.Idle,
+		ShotCooldownMs:          settings.ShotCooldownMs,
+		SleepSchedule:           settings.SleepSchedule,
+		Heartbeat:               settings.Heartbeat,
+		LogRotation:             settings.LogRotation,
+		ClubSpeedEstimation:     settings.ClubSpeedEstimation,
+		SmashFactors:            settings.SmashFactors,
+		SpinConventions:         settings.SpinConventions,
+		SpinConventionPresets:   core.SpinConventionPresets(),
+	}
+}
+
+// broadcastSettings sends the saved settings to every window, so a change
+// made in the desktop window shows in open browser tabs and the other way
+// round. A broadcast already waiting covers the change, since the settings
+// are read when it is sent.
+func (s *Server) broadcastSettings() {
+	select {
+	case s.settingsPending <- struct{}{}:
+	default:
+	}
+}
 
-		appSettings := AppSettings{
-			DeviceName:              settings.DeviceName,
-			SpinMode:                settings.SpinMode,
-			OmniSpeedUnit:           settings.OmniSpeedUnit,
-			OmniDistanceUnit:        settings.OmniDistanceUnit,
-			OmniGreenSpeed:          settings.OmniGreenSpeed,
-			OmniCarryAdjustment:     settings.OmniCarryAdjustment,
-			GSProIP:                 settings.GSProIP,
-			GSProPort:               settings.GSProPort,
-			GSProAutoConnect:        settings.GSProAutoConnect,
-			InfiniteTeesIP:          settings.InfiniteTeesIP,
-			InfiniteTeesPort:        settings.InfiniteTeesPort,
-			InfiniteTeesAutoConnect: settings.InfiniteTeesAutoConnect,
+// sendSettings sends each pending settings broadcast, waiting for room in
+// the broadcast queue rather than dropping it
+func (s *Server) sendSettings() {
+	for range s.settingsPending {
+		msg := WSMessage{Type: "settings", Data: s.appSettings()}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			log.Printf("Failed to encode settings: %v", err)
+			continue
 		}
+		s.broadcast <- data
+	}
+}
+
+func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		appSettings := s.appSettings()
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(appSettings)
 	} else {
 		var rawSettings map[string]json.RawMessage
 		if err := json.NewDecoder(r.Body).Decode(&rawSettings); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 			return
 		}
 
 		cfg := config.GetInstance()
+		if err := checkSettings(cfg.GetSettings(), rawSettings); err != nil {
+			writeSettingsError(w, err)
+			return
+		}
 
 		if rawValue, ok := rawSettings["deviceName"]; ok {
 			var value string
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid deviceName", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "deviceName"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetDeviceName(value)
 		}
 
+		if rawValue, ok := rawSettings["deviceAddress"]; ok {
+			var value string
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "deviceAddress"), http.StatusBadRequest)
+				return
+			}
+			value = strings.TrimSpace(value)
+			cfg.SetDeviceAddress(value)
+		}
+
 		if rawValue, ok := rawSettings["spinMode"]; ok {
 			var value string
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid spinMode", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "spinMode"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetSpinMode(value)
@@ -823,14 +1571,20 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 			s.stateManager.SetSpinMode(&spinMode)
 		}
 
-		if rawValue, ok := rawSettings["omniSpeedUnit"]; ok {
-			var value string
+		if rawValue, ok := rawSettings["clubSpinModes"]; ok {
+			var value core.ClubSpinModes
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid omniSpeedUnit", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "clubSpinModes"), http.StatusBadRequest)
 				return
 			}
-			if value != "mps" && value != "mph" {
-				http.Error(w, "Invalid omniSpeedUnit value", http.StatusBadRequest)
+			cfg.SetClubSpinModes(value)
+			s.launchMonitor.SetClubSpinModes(value)
+		}
+
+		if rawValue, ok := rawSettings["omniSpeedUnit"]; ok {
+			var value string
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "omniSpeedUnit"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetOmniSpeedUnit(value)
@@ -840,11 +1594,7 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if rawValue, ok := rawSettings["omniDistanceUnit"]; ok {
 			var value string
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid omniDistanceUnit", http.StatusBadRequest)
-				return
-			}
-			if value != "meters" && value != "mixed" && value != "yards" {
-				http.Error(w, "Invalid omniDistanceUnit value", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "omniDistanceUnit"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetOmniDistanceUnit(value)
@@ -854,11 +1604,7 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if rawValue, ok := rawSettings["omniGreenSpeed"]; ok {
 			var value int
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid omniGreenSpeed", http.StatusBadRequest)
-				return
-			}
-			if value < 8 || value > 13 {
-				http.Error(w, "Invalid omniGreenSpeed value", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "omniGreenSpeed"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetOmniGreenSpeed(value)
@@ -868,11 +1614,7 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if rawValue, ok := rawSettings["omniCarryAdjustment"]; ok {
 			var value int
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid omniCarryAdjustment", http.StatusBadRequest)
-				return
-			}
-			if value < -99 || value > 99 {
-				http.Error(w, "Invalid omniCarryAdjustment value", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "omniCarryAdjustment"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetOmniCarryAdjustment(value)
@@ -882,7 +1624,7 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if rawValue, ok := rawSettings["gsproIP"]; ok {
 			var value string
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid gsproIP", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "gsproIP"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetGSProIP(value)
@@ -891,7 +1633,7 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if rawValue, ok := rawSettings["gsproPort"]; ok {
 			var value int
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid gsproPort", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "gsproPort"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetGSProPort(value)
@@ -900,16 +1642,83 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if rawValue, ok := rawSettings["gsproAutoConnect"]; ok {
 			var value bool
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid gsproAutoConnect", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "gsproAutoConnect"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetGSProAutoConnect(value)
 		}
 
+		// The standby address and port are saved together, as whether a
+		// port is valid depends on the address
+		standby := cfg.GetSettings()
+		if rawValue, ok := rawSettings["gsproStandbyIP"]; ok {
+			if err := json.Unmarshal(rawValue, &standby.GSProStandbyIP); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "gsproStandbyIP"), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if rawValue, ok := rawSettings["gsproStandbyPort"]; ok {
+			if err := json.Unmarshal(rawValue, &standby.GSProStandbyPort); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "gsproStandbyPort"), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if rawValue, ok := rawSettings["gsproTrafficLog"]; ok {
+			var value bool
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "gsproTrafficLog"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetGSProTrafficLog(value)
+			s.gsproIntegration.Traffic.SetEnabled(value)
+		}
+
+		if rawValue, ok := rawSettings["gsproPayload"]; ok {
+			var value gspro.PayloadIdentity
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "gsproPayload"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetGSProPayload(value)
+			s.gsproIntegration.SetPayloadIdentity(value)
+		}
+
+		if rawValue, ok := rawSettings["gsproShotNumberPolicy"]; ok {
+			var value gspro.ShotNumberPolicy
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "gsproShotNumberPolicy"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetGSProShotNumberPolicy(value)
+			s.gsproIntegration.SetShotNumberPolicy(value)
+		}
+
+		if rawValue, ok := rawSettings["gsproRetryQueue"]; ok {
+			var value gspro.RetryQueueSettings
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "gsproRetryQueue"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetGSProRetryQueue(value)
+			s.gsproIntegration.SetRetryQueue(value)
+		}
+
+		_, standbyIPChanged := rawSettings["gsproStandbyIP"]
+		_, standbyPortChanged := rawSettings["gsproStandbyPort"]
+		if standbyIPChanged || standbyPortChanged {
+			cfg.Update(func(settings *config.Settings) {
+				settings.GSProStandbyIP = standby.GSProStandbyIP
+				settings.GSProStandbyPort = standby.GSProStandbyPort
+			})
+			s.gsproIntegration.SetStandby(standby.GSProStandbyIP, standby.GSProStandbyPort)
+		}
+
 		if rawValue, ok := rawSettings["infiniteTeesIP"]; ok {
 			var value string
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid infiniteTeesIP", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "infiniteTeesIP"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetInfiniteTeesIP(value)
@@ -918,7 +1727,7 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if rawValue, ok := rawSettings["infiniteTeesPort"]; ok {
 			var value int
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid infiniteTeesPort", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "infiniteTeesPort"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetInfiniteTeesPort(value)
@@ -927,37 +1736,269 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if rawValue, ok := rawSettings["infiniteTeesAutoConnect"]; ok {
 			var value bool
 			if err := json.Unmarshal(rawValue, &value); err != nil {
-				http.Error(w, "Invalid infiniteTeesAutoConnect", http.StatusBadRequest)
+				http.Error(w, i18n.Tf("Invalid %s", "infiniteTeesAutoConnect"), http.StatusBadRequest)
 				return
 			}
 			cfg.SetInfiniteTeesAutoConnect(value)
 		}
 
+		if rawValue, ok := rawSettings["voiceEnabled"]; ok {
+			var value bool
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "voiceEnabled"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetVoiceEnabled(value)
+			s.announcer.SetEnabled(value)
+		}
+
+		if rawValue, ok := rawSettings["voiceMetrics"]; ok {
+			var value []string
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "voiceMetrics"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetVoiceMetrics(value)
+			s.announcer.SetMetrics(value)
+		}
+
+		if rawValue, ok := rawSettings["chimeEnabled"]; ok {
+			var value bool
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "chimeEnabled"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetChimeEnabled(value)
+			s.chime.SetEnabled(value)
+		}
+
+		if rawValue, ok := rawSettings["chimeVolume"]; ok {
+			var value int
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "chimeVolume"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetChimeVolume(value)
+			s.chime.SetVolume(value)
+		}
+
+		if rawValue, ok := rawSettings["chimeOutput"]; ok {
+			var value string
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "chimeOutput"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetChimeOutput(value)
+			s.chime.SetOutput(value)
+		}
+
+		if rawValue, ok := rawSettings["misreadPrompt"]; ok {
+			var value bool
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "misreadPrompt"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetMisreadPrompt(value)
+			s.stateManager.SetMisreadPrompt(value)
+		}
+
+		if rawValue, ok := rawSettings["shotRawData"]; ok {
+			var value bool
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "shotRawData"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetShotRawData(value)
+			s.shotHistory.SetKeepRawData(value)
+		}
+
+		if rawValue, ok := rawSettings["spinEstimation"]; ok {
+			var value bool
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "spinEstimation"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetSpinEstimation(value)
+			s.launchMonitor.SetSpinEstimationEnabled(value)
+		}
+
+		if rawValue, ok := rawSettings["spinCurves"]; ok {
+			var value map[string]core.SpinCurve
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "spinCurves"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetSpinCurves(value)
+			s.launchMonitor.SetSpinEstimator(core.NewCurveSpinEstimator(value))
+		}
+
+		if rawValue, ok := rawSettings["placementZone"]; ok {
+			var value core.PlacementZone
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "placementZone"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetPlacementZone(value)
+			s.placement.SetZone(value)
+		}
+
+		if rawValue, ok := rawSettings["locale"]; ok {
+			var value string
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "locale"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetLocale(value)
+			i18n.SetLocale(value)
+			s.broadcastMisreads()
+		}
+
+		if rawValue, ok := rawSettings["numberFormat"]; ok {
+			var value i18n.NumberFormat
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "numberFormat"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetNumberFormat(value)
+			i18n.SetNumberFormat(value)
+			s.broadcastOverlay()
+		}
+
+		if rawValue, ok := rawSettings["environment"]; ok {
+			var value core.EnvironmentSettings
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "environment"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetEnvironment(value)
+			s.launchMonitor.SetEnvironment(value)
+		}
+
+		if rawValue, ok := rawSettings["alignmentSmoothing"]; ok {
+			var value core.AlignmentSmoothing
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "alignmentSmoothing"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetAlignmentSmoothing(value)
+			s.launchMonitor.SetAlignmentSmoothing(value)
+		}
+
+		if rawValue, ok := rawSettings["idle"]; ok {
+			var value core.IdleSettings
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "idle"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetIdle(value)
+			s.launchMonitor.SetIdleSettings(value)
+		}
+
+		if rawValue, ok := rawSettings["shotCooldownMs"]; ok {
+			var value int
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "shotCooldownMs"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetShotCooldownMs(value)
+			s.launchMonitor.SetShotCooldown(time.Duration(value) * time.Millisecond)
+		}
+
+		if rawValue, ok := rawSettings["sleepSchedule"]; ok {
+			var value core.SleepSchedule
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "sleepSchedule"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetSleepSchedule(value)
+			s.launchMonitor.SetSleepSchedule(value)
+		}
+
+		if rawValue, ok := rawSettings["heartbeat"]; ok {
+			var value core.HeartbeatSettings
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "heartbeat"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetHeartbeat(value)
+			s.launchMonitor.SetHeartbeatInterval(value.Interval())
+			if s.simulator != nil {
+				s.simulator.SetInactivityTimeout(value.DeviceTimeout())
+			}
+		}
+
+		if rawValue, ok := rawSettings["logRotation"]; ok {
+			var value logging.Rotation
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "logRotation"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetLogRotation(value)
+			logging.SetRotation(value)
+		}
+
+		if rawValue, ok := rawSettings["clubSpeedEstimation"]; ok {
+			var value bool
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "clubSpeedEstimation"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetClubSpeedEstimation(value)
+			s.launchMonitor.SetClubSpeedEstimationEnabled(value)
+		}
+
+		if rawValue, ok := rawSettings["smashFactors"]; ok {
+			var value map[string]float64
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "smashFactors"), http.StatusBadRequest)
+				return
+			}
+			cfg.SetSmashFactors(value)
+			s.launchMonitor.SetSmashFactors(value)
+		}
+
+		if rawValue, ok := rawSettings["spinConventions"]; ok {
+			var value map[string]core.SpinConvention
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				http.Error(w, i18n.Tf("Invalid %s", "spinConventions"), http.StatusBadRequest)
+				return
+			}
+			conventions := make(map[string]core.SpinConvention)
+			for simulator, convention := range cfg.GetSettings().SpinConventions {
+				conventions[simulator] = convention
+			}
+			for simulator, convention := range value {
+				conventions[simulator] = convention
+			}
+			cfg.SetSpinConventions(conventions)
+			s.gsproIntegration.SetSpinConvention(core.SpinConventionFor(conventions, core.SimulatorGSPro))
+			s.infiniteTeesIntegration.SetSpinConvention(core.SpinConventionFor(conventions, core.SimulatorInfiniteTees))
+			s.awesomeGolfIntegration.SetSpinConvention(core.SpinConventionFor(conventions, core.SimulatorAwesomeGolf))
+		}
+
 		w.WriteHeader(http.StatusOK)
 	}
 }
 
 func (s *Server) getCameraConfig() CameraConfig {
-	url := "http://localhost:5000"
+	url := camera.DefaultURL
 	if cameraURL := s.stateManager.GetCameraURL(); cameraURL != nil {
 		url = *cameraURL
 	}
 
 	enabled := s.stateManager.GetCameraEnabled()
 
-	return CameraConfig{
+	cameraConfig := CameraConfig{
 		URL:     url,
 		Enabled: enabled,
 	}
+	if s.cameraManager != nil {
+		cameraConfig.Cameras = s.cameraManager.Cameras()
+	}
+	return cameraConfig
 }
 
 func (s *Server) handleCameraConfig(w http.ResponseWriter, r *http.Request) {
-	// Return 404 if external camera feature is disabled
-	if !s.enableExternalCamera {
-		http.Error(w, "External camera feature not enabled", http.StatusNotFound)
-		return
-	}
-
 	if r.Method == "GET" {
 		cameraConfig := s.getCameraConfig()
 		w.Header().Set("Content-Type", "application/json")
@@ -965,27 +2006,56 @@ func (s *Server) handleCameraConfig(w http.ResponseWriter, r *http.Request) {
 	} else {
 		var cameraConfig CameraConfig
 		if err := json.NewDecoder(r.Body).Decode(&cameraConfig); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 			return
 		}
 
-		// Save camera settings to config
-		cfg := config.GetInstance()
-		cfg.SetCameraURL(cameraConfig.URL)
-		cfg.SetCameraEnabled(cameraConfig.Enabled)
+		// Save camera settings to config. A camera list replaces every
+		// camera; a bare URL only changes the first.
+		cameras := camera.NormalizeEndpoints(cameraConfig.Cameras)
+		if len(cameras) > 0 {
+			cameraConfig.URL = cameras[0].URL
+		}
+		err := config.GetInstance().Update(func(settings *config.Settings) {
+			settings.CameraURL = cameraConfig.URL
+			if len(cameras) > 0 {
+				settings.Cameras = cameras
+			} else if len(settings.Cameras) > 0 {
+				settings.Cameras = append([]camera.Endpoint(nil), settings.Cameras...)
+				settings.Cameras[0].URL = cameraConfig.URL
+			}
+			settings.CameraEnabled = cameraConfig.Enabled
+		})
+		if err != nil {
+			writeSettingsError(w, err)
+			return
+		}
 
 		// Update camera URL and enabled state in state manager
 		s.stateManager.SetCameraURL(&cameraConfig.URL)
 		s.stateManager.SetCameraEnabled(cameraConfig.Enabled)
 
 		// Update camera manager
-		s.cameraManager.SetBaseURL(cameraConfig.URL)
+		if len(cameras) > 0 {
+			s.cameraManager.SetCameras(cameras)
+			s.broadcastCameraConfig()
+		} else {
+			s.cameraManager.SetBaseURL(cameraConfig.URL)
+		}
 		s.cameraManager.SetEnabled(cameraConfig.Enabled)
 
 		w.WriteHeader(http.StatusOK)
 	}
 }
 
+func (s *Server) handleCameraStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(CameraStatus{
+		Enabled: s.cameraManager.IsEnabled(),
+		Cameras: s.cameraManager.Statuses(r.Context()),
+	})
+}
+
 func (s *Server) broadcastCameraConfig() {
 	config := s.getCameraConfig()
 	msg := WSMessage{Type: "cameraConfig", Data: config}
@@ -996,18 +2066,10 @@ func (s *Server) broadcastCameraConfig() {
 	}
 }
 
-func (s *Server) handleFeatures(w http.ResponseWriter, r *http.Request) {
-	features := FeatureFlags{
-		ExternalCamera: s.enableExternalCamera,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(features)
-}
-
 func (s *Server) handleAlignmentStart(w http.ResponseWriter, r *http.Request) {
 	err := s.launchMonitor.StartAlignment()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -1016,28 +2078,33 @@ func (s *Server) handleAlignmentStart(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleAlignmentStop(w http.ResponseWriter, r *http.Request) {
 	err := s.launchMonitor.StopAlignment()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
 		return
 	}
+	if err := config.GetInstance().SetAlignmentProfiles(s.launchMonitor.GetAlignmentProfiles()); err != nil {
+		log.Printf("Failed to save alignment profiles: %v", err)
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
+func (s *Server) handleAlignmentProfiles(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.launchMonitor.GetAlignmentProfiles())
+}
+
 func (s *Server) handleAlignmentCancel(w http.ResponseWriter, r *http.Request) {
 	err := s.launchMonitor.CancelAlignment()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
 
 func (s *Server) handleAlignmentHandedness(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Handedness string `json:"handedness"`
-	}
-
+	var req HandednessRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 		return
 	}
 
@@ -1048,7 +2115,7 @@ func (s *Server) handleAlignmentHandedness(w http.ResponseWriter, r *http.Reques
 	} else if req.Handedness == "right" {
 		handedness = core.RightHanded
 	} else {
-		http.Error(w, "Invalid handedness value (must be 'left' or 'right')", http.StatusBadRequest)
+		http.Error(w, i18n.T("Invalid handedness value (must be 'left' or 'right')"), http.StatusBadRequest)
 		return
 	}
 
@@ -1058,16 +2125,20 @@ func (s *Server) handleAlignmentHandedness(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusOK)
 }
 
+func (s *Server) ShutdownIntegrations() {
+	s.gsproIntegration.Shutdown()
+	s.infiniteTeesIntegration.Shutdown()
+	s.awesomeGolfIntegration.Shutdown()
+}
+
 func (s *Server) GetInfiniteTeesIntegration() *infinitetees.Integration {
 	return s.infiniteTeesIntegration
 }
 
 func (s *Server) handlePracticeMode(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Enabled bool `json:"enabled"`
-	}
+	var req PracticeModeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
 		return
 	}
 
@@ -1078,9 +2149,126 @@ func (s *Server) handlePracticeMode(w http.ResponseWriter, r *http.Request) {
 		err = s.launchMonitor.DeactivateBallDetection()
 	}
 
+	if errors.Is(err, core.ErrSessionPaused) {
+		http.Error(w, i18n.Error(err), http.StatusConflict)
+		return
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
+
+// handleSwingStick turns swing stick training on or off
+func (s *Server) handleSwingStick(w http.ResponseWriter, r *http.Request) {
+	var req SwingStickRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	err := s.launchMonitor.SetSwingStick(req.Enabled)
+	switch {
+	case errors.Is(err, core.ErrSwingStickUnsupported):
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	case errors.Is(err, core.ErrSessionPaused):
+		http.Error(w, i18n.Error(err), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleSessionPause disarms ball detection and drops shots until the session
+// is resumed
+func (s *Server) handleSessionPause(w http.ResponseWriter, r *http.Request) {
+	if err := s.launchMonitor.Pause(); err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleSessionResume re-arms ball detection if it was armed when the session
+// was paused
+func (s *Server) handleSessionResume(w http.ResponseWriter, r *http.Request) {
+	if err := s.launchMonitor.Resume(); err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleDeviceStandby deactivates ball detection and stops the heartbeat
+// until the device is woken
+func (s *Server) handleDeviceStandby(w http.ResponseWriter, r *http.Request) {
+	if err := s.launchMonitor.Standby(); err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleDeviceWake brings the device out of standby or idle and restarts the
+// idle timer. The web UI calls it when the user interacts with the page.
+func (s *Server) handleDeviceWake(w http.ResponseWriter, r *http.Request) {
+	if err := s.launchMonitor.Wake(); err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleDeviceHeartbeat reports the heartbeat rate currently in effect
+func (s *Server) handleDeviceHeartbeat(w http.ResponseWriter, r *http.Request) {
+	status := HeartbeatStatus{
+		IntervalSeconds:     s.launchMonitor.HeartbeatInterval().Seconds(),
+		IdleIntervalSeconds: s.launchMonitor.IdleHeartbeatInterval().Seconds(),
+		Idle:                s.launchMonitor.IsIdle(),
+		Standby:             s.launchMonitor.InStandby(),
+	}
+	if s.simulator != nil {
+		timeout := s.simulator.InactivityTimeout().Seconds()
+		status.DeviceTimeoutSeconds = &timeout
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// handleDevicePair pairs with the connected device and saves it so later
+// connections go straight to its address
+func (s *Server) handleDevicePair(w http.ResponseWriter, r *http.Request) {
+	device, err := s.bluetoothManager.Pair()
+	if errors.Is(err, core.ErrPairingUnsupported) {
+		http.Error(w, i18n.Error(err), http.StatusNotImplemented)
+		return
+	}
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	if err := config.GetInstance().SetBondedDevice(device); err != nil {
+		log.Printf("Failed to save paired device: %v", err)
+	}
+	s.broadcastDeviceStatus()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(device)
+}
+
+// handleDeviceUnpair removes the pairing and the saved device
+func (s *Server) handleDeviceUnpair(w http.ResponseWriter, r *http.Request) {
+	if err := s.bluetoothManager.Unpair(); err != nil {
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	if err := config.GetInstance().SetBondedDevice(core.BondedDevice{}); err != nil {
+		log.Printf("Failed to clear paired device: %v", err)
+	}
+	s.broadcastDeviceStatus()
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,220 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+type SimulatorModeRequest struct {
+	Manual bool `json:"manual"`
+}
+
+type SimulatorBatteryRequest struct {
+	Level int `json:"level"`
+}
+
+// SimulatorFloodRequest sends a burst of notifications
+type SimulatorFloodRequest struct {
+	Count int `json:"count"`
+}
+
+// ShotSeriesRequest starts one of the preset series by name, or the series
+// given. IntervalSeconds overrides the pause between shots of a preset.
+type ShotSeriesRequest struct {
+	Preset          string           `json:"preset,omitempty"`
+	Series          *core.ShotSeries `json:"series,omitempty"`
+	IntervalSeconds float64          `json:"intervalSeconds,omitempty"`
+}
+
+// requireSimulator replies 404 unless the app is using the simulated device
+func (s *Server) requireSimulator(w http.ResponseWriter) bool {
+	if s.simulator == nil {
+		http.Error(w, i18n.T("Simulator is not running"), http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+// writeSimulatorResult replies with the simulator's status, or the error from
+// the action that was just run
+func (s *Server) writeSimulatorResult(w http.ResponseWriter, err error) {
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, core.ErrSimulatorNotConnected) || errors.Is(err, core.ErrShotSeriesRunning) {
+			status = http.StatusConflict
+		}
+		http.Error(w, i18n.Error(err), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.simulator.ControlStatus())
+}
+
+func (s *Server) handleSimulatorStatus(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+	s.writeSimulatorResult(w, nil)
+}
+
+func (s *Server) handleSimulatorMode(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+
+	var req SimulatorModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	s.simulator.SetManual(req.Manual)
+	s.writeSimulatorResult(w, nil)
+}
+
+func (s *Server) handleSimulatorBallPlaced(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+	s.writeSimulatorResult(w, s.simulator.PlaceBall())
+}
+
+func (s *Server) handleSimulatorBallReady(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+	s.writeSimulatorResult(w, s.simulator.ReadyBall())
+}
+
+func (s *Server) handleSimulatorBallRemoved(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+	s.writeSimulatorResult(w, s.simulator.RemoveBall())
+}
+
+// handleSimulatorShot sends the shot in the request body, or a random shot
+// when the body is empty
+func (s *Server) handleSimulatorShot(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+
+	var shot *core.SimulatedShot
+	if err := json.NewDecoder(r.Body).Decode(&shot); err != nil && err != io.EOF {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	s.writeSimulatorResult(w, s.simulator.InjectShot(shot))
+}
+
+func (s *Server) handleSimulatorDisconnect(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+	s.writeSimulatorResult(w, s.simulator.SimulateDisconnect())
+}
+
+func (s *Server) handleSimulatorBattery(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+
+	var req SimulatorBatteryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	s.writeSimulatorResult(w, s.simulator.SetBatteryLevel(req.Level))
+}
+
+func (s *Server) handleSimulatorSeriesPresets(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(core.ShotSeriesPresets)
+}
+
+// handleSimulatorSeries starts hitting a series of shots at known values, so
+// the simulator's ball flight can be checked against them
+func (s *Server) handleSimulatorSeries(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+
+	var req ShotSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	var series core.ShotSeries
+	switch {
+	case req.Series != nil:
+		series = *req.Series
+	case req.Preset != "":
+		preset, ok := core.ShotSeriesPresets[req.Preset]
+		if !ok {
+			http.Error(w, i18n.Tf("Invalid %s value", "preset"), http.StatusBadRequest)
+			return
+		}
+		series = preset
+	default:
+		http.Error(w, i18n.Tf("Invalid %s value", "preset"), http.StatusBadRequest)
+		return
+	}
+	if req.IntervalSeconds != 0 {
+		series.IntervalSeconds = req.IntervalSeconds
+	}
+
+	s.writeSimulatorResult(w, s.simulator.StartSeries(series))
+}
+
+func (s *Server) handleSimulatorSeriesCancel(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+	s.simulator.CancelSeries()
+	s.writeSimulatorResult(w, nil)
+}
+
+// handleSimulatorFaults reports the injected faults, or replaces them with
+// the ones posted
+func (s *Server) handleSimulatorFaults(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+	if r.Method == http.MethodGet {
+		s.writeSimulatorResult(w, nil)
+		return
+	}
+
+	var faults core.SimulatorFaults
+	if err := json.NewDecoder(r.Body).Decode(&faults); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+	s.writeSimulatorResult(w, s.simulator.SetFaults(faults))
+}
+
+func (s *Server) handleSimulatorFlood(w http.ResponseWriter, r *http.Request) {
+	if !s.requireSimulator(w) {
+		return
+	}
+
+	var req SimulatorFloodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+	s.writeSimulatorResult(w, s.simulator.Flood(req.Count))
+}
@@ -0,0 +1,122 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/brentyates/squaregolf-connector/internal/version"
+)
+
+// updateRequestTimeout bounds a check or install started from the web UI
+const updateRequestTimeout = 10 * time.Minute
+
+// VersionInfo is the running version and, if update checks are enabled, the
+// result of the last one
+type VersionInfo struct {
+	Version string             `json:"version"`
+	Build   string             `json:"build"`
+	Update  *core.UpdateStatus `json:"update"`
+}
+
+// SetUpdateChecker reports checker's results over the API and WebSocket.
+// It must be called before Start.
+func (s *Server) SetUpdateChecker(checker *core.UpdateChecker) {
+	s.updater = checker
+	checker.OnChange(s.broadcastUpdateStatus)
+}
+
+func (s *Server) broadcastUpdateStatus(status core.UpdateStatus) {
+	msg := WSMessage{Type: "updateStatus", Data: status}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) versionInfo() VersionInfo {
+	info := VersionInfo{
+		Version: version.GetShortVersion(),
+		Build:   version.GetVersion(),
+	}
+	if s.updater != nil {
+		status := s.updater.Status()
+		info.Update = &status
+	}
+	return info
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.versionInfo())
+}
+
+// handleUpdateCheck checks for a new release now rather than waiting for
+// the next scheduled check
+func (s *Server) handleUpdateCheck(w http.ResponseWriter, r *http.Request) {
+	if s.updater == nil {
+		http.Error(w, i18n.T("Update checks are disabled"), http.StatusNotFound)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), updateRequestTimeout)
+	defer cancel()
+
+	// A failed check is reported in the status
+	status, _ := s.updater.Check(ctx)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// handleUpdateInstall installs the latest release, which runs after the
+// connector restarts
+func (s *Server) handleUpdateInstall(w http.ResponseWriter, r *http.Request) {
+	if s.updater == nil {
+		http.Error(w, i18n.T("Update checks are disabled"), http.StatusNotFound)
+		return
+	}
+	// Keep installing if the browser goes away; a half-swapped install is
+	// worse than a finished one nobody is waiting for
+	ctx, cancel := context.WithTimeout(context.Background(), updateRequestTimeout)
+	defer cancel()
+
+	status, err := s.updater.Install(ctx)
+	if err != nil {
+		http.Error(w, i18n.Error(err), updateErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// handleUpdateRollback restores the version replaced by the last install
+func (s *Server) handleUpdateRollback(w http.ResponseWriter, r *http.Request) {
+	if s.updater == nil {
+		http.Error(w, i18n.T("Update checks are disabled"), http.StatusNotFound)
+		return
+	}
+	status, err := s.updater.Rollback()
+	if err != nil {
+		http.Error(w, i18n.Error(err), updateErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func updateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, core.ErrUpdateInstallDisabled):
+		return http.StatusForbidden
+	case errors.Is(err, core.ErrUpdateInstallUnsupported):
+		return http.StatusNotImplemented
+	case errors.Is(err, core.ErrNoUpdate):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// WarmupStatus is the warmup in progress or last finished, if any
+type WarmupStatus struct {
+	Warmup *core.WarmupState `json:"warmup"`
+}
+
+// onWarmupChange tells clients about the warmup and, while it runs, selects
+// its club on the device and arms detection for the next ball
+func (s *Server) onWarmupChange(state *core.WarmupState) {
+	msg := WSMessage{Type: "warmup", Data: state}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+
+	if state == nil || state.Club == nil {
+		return
+	}
+	club, ok := core.ClubByName(*state.Club)
+	if !ok {
+		return
+	}
+	if current := s.stateManager.GetClub(); current == nil || *current != club {
+		s.stateManager.SetClub(&club)
+	}
+	if err := s.launchMonitor.ActivateBallDetection(); err != nil && !errors.Is(err, core.ErrSessionPaused) {
+		log.Printf("Warmup: failed to arm ball detection: %v", err)
+	}
+}
+
+func (s *Server) handleWarmup(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(WarmupStatus{Warmup: s.gameManager.WarmupState()})
+}
+
+// handleWarmupStart starts a warmup, replacing any game or practice in
+// progress
+func (s *Server) handleWarmupStart(w http.ResponseWriter, r *http.Request) {
+	var config core.WarmupConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	state, err := s.gameManager.StartWarmup(config)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+func (s *Server) handleWarmupStop(w http.ResponseWriter, r *http.Request) {
+	s.gameManager.StopWarmup()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleWarmups(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gameManager.Warmups())
+}
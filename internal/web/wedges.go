@@ -0,0 +1,107 @@
+package web
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+)
+
+// WedgePracticeStatus is the wedge practice in progress or last finished, if
+// any
+type WedgePracticeStatus struct {
+	Practice *core.WedgePracticeState `json:"practice"`
+}
+
+func (s *Server) broadcastWedgeState(state *core.WedgePracticeState) {
+	msg := WSMessage{Type: "wedgePractice", Data: state}
+	data, _ := json.Marshal(msg)
+	select {
+	case s.broadcast <- data:
+	default:
+	}
+}
+
+func (s *Server) handleWedgePractice(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(WedgePracticeStatus{Practice: s.gameManager.WedgeState()})
+}
+
+// handleWedgePracticeStart starts a wedge practice, replacing any game or
+// practice in progress
+func (s *Server) handleWedgePracticeStart(w http.ResponseWriter, r *http.Request) {
+	var config core.WedgePracticeConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, i18n.T("Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	state, err := s.gameManager.StartWedges(config)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+func (s *Server) handleWedgePracticeStop(w http.ResponseWriter, r *http.Request) {
+	s.gameManager.StopWedges()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleWedgeProfiles(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gameManager.WedgeProfiles())
+}
+
+func (s *Server) handleWedgeMatrix(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.gameManager.WedgeMatrix(wedgeProfile(r)))
+}
+
+// handleWedgeMatrixCSV exports a profile's matrix with a row for each club
+// and target
+func (s *Server) handleWedgeMatrixCSV(w http.ResponseWriter, r *http.Request) {
+	matrix := s.gameManager.WedgeMatrix(wedgeProfile(r))
+	filename := "wedge-matrix-" + strings.ToLower(strings.Join(strings.Fields(matrix.Profile), "-")) + ".csv"
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	out := csv.NewWriter(w)
+	out.Write([]string{"Club", "Target (yd)", "Shots", "Average Carry (yd)", "Carry Spread (yd)", "Average Error (yd)", "Average Offline (yd)"})
+	for _, cell := range matrix.Cells {
+		out.Write([]string{
+			cell.Club,
+			formatYards(cell.TargetYards),
+			strconv.Itoa(cell.Shots),
+			formatYards(cell.AverageCarryYards),
+			formatYards(cell.CarrySpreadYards),
+			formatYards(cell.AverageErrorYards),
+			formatYards(cell.AverageOfflineYards),
+		})
+	}
+	// Headers are already sent, so a failure can only be logged
+	out.Flush()
+	if err := out.Error(); err != nil {
+		log.Printf("Failed to send wedge matrix: %v", err)
+	}
+}
+
+// wedgeProfile returns the profile query parameter, or the default profile
+func wedgeProfile(r *http.Request) string {
+	profile := strings.TrimSpace(r.URL.Query().Get("profile"))
+	if profile == "" {
+		return core.DefaultGameProfile
+	}
+	return profile
+}
+
+func formatYards(yards float64) string {
+	return strconv.FormatFloat(yards, 'f', 1, 64)
+}
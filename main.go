@@ -6,17 +6,18 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"os/signal"
-	"sync"
-	"syscall"
+	"path/filepath"
 	"time"
 
+	"github.com/brentyates/squaregolf-connector/internal/app"
 	appcfg "github.com/brentyates/squaregolf-connector/internal/config"
 	"github.com/brentyates/squaregolf-connector/internal/core"
-	"github.com/brentyates/squaregolf-connector/internal/core/camera"
-	"github.com/brentyates/squaregolf-connector/internal/core/gspro"
+	"github.com/brentyates/squaregolf-connector/internal/core/history"
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/brentyates/squaregolf-connector/internal/lifecycle"
 	"github.com/brentyates/squaregolf-connector/internal/logging"
 	"github.com/brentyates/squaregolf-connector/internal/ui"
+	"github.com/brentyates/squaregolf-connector/internal/version"
 	"github.com/brentyates/squaregolf-connector/internal/web"
 )
 
@@ -24,10 +25,13 @@ import (
 type AppConfig struct {
 	UseMock              core.MockMode
 	DeviceName           string
+	DeviceAddress        string
 	Headless             bool
 	WebMode              bool
 	ServerOnly           bool
 	WebPort              int
+	BindAddress          string
+	AllowedOrigins       []string
 	GSProIP              string
 	GSProPort            int
 	EnableGSPro          bool
@@ -35,10 +39,15 @@ type AppConfig struct {
 	InfiniteTeesPort     int
 	EnableExternalCamera bool
 	SimulateOmni         bool
+	ConnectServerPort    int
+	ShotRouting          core.ShotRouting
+	BLEBackend           core.BLEBackend
+	UpdateCheck          bool
+	AutoUpdate           bool
 }
 
 // Initialize the backend services (Bluetooth, state manager, etc.)
-func initializeBackend(config AppConfig) (*core.StateManager, *core.BluetoothManager, *core.LaunchMonitor) {
+func initializeBackend(config AppConfig) *app.App {
 	// Initialize logging
 	logging.SetAppName(core.AppName)
 	if err := logging.Init(); err != nil {
@@ -46,9 +55,6 @@ func initializeBackend(config AppConfig) (*core.StateManager, *core.BluetoothMan
 	}
 	log.Println("Starting Square BT application...")
 
-	// Get the state manager instance
-	stateManager := core.GetInstance()
-
 	// Create the appropriate Bluetooth client
 	var bleClient core.BluetoothClient
 	var err error
@@ -62,11 +68,13 @@ func initializeBackend(config AppConfig) (*core.StateManager, *core.BluetoothMan
 			BatteryDrainRate: 1,
 			ResponseDelay:    100 * time.Millisecond,
 			SimulateOmni:     config.SimulateOmni,
+			// The simulated device disconnects when heartbeats stop arriving
+			InactivityTimeout: appcfg.GetInstance().GetSettings().Heartbeat.DeviceTimeout(),
 		}
 		bleClient = core.NewSimulatorBluetoothClient(simulatorConfig)
 	} else {
-		log.Println("Using real Bluetooth implementation with TinyGo")
-		bleClient, err = core.NewTinyGoBluetoothClient()
+		log.Printf("Using real Bluetooth implementation with the %s backend", config.BLEBackend)
+		bleClient, err = core.NewBluetoothClient(config.BLEBackend)
 		if err != nil {
 			log.Printf("Failed to initialize Bluetooth: %v", err)
 			// Exit the application if Bluetooth initialization fails
@@ -74,19 +82,248 @@ func initializeBackend(config AppConfig) (*core.StateManager, *core.BluetoothMan
 		}
 	}
 
-	// Get the singleton bluetooth manager instance
-	bluetoothManager := core.GetBluetoothInstance(stateManager)
+	// Build the services for the launch monitor
+	settings := appcfg.GetInstance().GetSettings()
+	application := app.New(app.Config{
+		Features:          startupFeatures(config, settings),
+		Client:            bleClient,
+		GSProIP:           config.GSProIP,
+		GSProPort:         config.GSProPort,
+		InfiniteTeesIP:    config.InfiniteTeesIP,
+		InfiniteTeesPort:  config.InfiniteTeesPort,
+		AwesomeGolfIP:     settings.AwesomeGolfIP,
+		AwesomeGolfPort:   settings.AwesomeGolfPort,
+		Cameras:           settings.CameraEndpoints(),
+		CameraEnabled:     settings.CameraEnabled,
+		ClipDir:           filepath.Join(appcfg.GetInstance().DataDir(), "clips"),
+		ConnectServerPort: config.ConnectServerPort,
+		ShotRouting:       config.ShotRouting,
+		BLEBackend:        config.BLEBackend,
+	})
+	launchMonitor := application.LaunchMonitor
+
+	// Record completed shots for history and analytics
+	if err := application.History.SetPath(appcfg.GetInstance().ShotHistoryPath()); err != nil {
+		log.Printf("History: failed to load shot history: %v", err)
+	}
+	application.History.SetKeepRawData(settings.ShotRawData)
+
+	// Keep game results, wedge matrices and the like across restarts
+	if err := application.Games.SetDataDir(appcfg.GetInstance().DataDir()); err != nil {
+		log.Printf("Games: %v", err)
+	}
+
+	// Rotate and prune logs as configured
+	logging.SetRotation(settings.LogRotation)
+
+	// Translate server-generated text into the saved locale
+	i18n.SetLocale(settings.Locale)
+	i18n.SetNumberFormat(settings.NumberFormat)
+
+	// Set up voice announcements from saved settings
+	application.Voice.SetMetrics(settings.VoiceMetrics)
+	application.Voice.SetEnabled(settings.VoiceEnabled)
+
+	// Set up the ball-ready chime
+	application.Chime.SetVolume(settings.ChimeVolume)
+	application.Chime.SetOutput(settings.ChimeOutput)
+	application.Chime.SetEnabled(settings.ChimeEnabled)
+
+	// Clubs with their own spin mode, e.g. Standard for the putter
+	launchMonitor.SetClubSpinModes(settings.ClubSpinModes)
+
+	// Estimate spin for Standard mode and zero-spin shots
+	launchMonitor.SetSpinEstimator(core.NewCurveSpinEstimator(settings.SpinCurves))
+	launchMonitor.SetSpinEstimationEnabled(settings.SpinEstimation)
+
+	// Estimate club speed from ball speed for devices that don't measure it
+	launchMonitor.SetSmashFactors(settings.SmashFactors)
+	launchMonitor.SetClubSpeedEstimationEnabled(settings.ClubSpeedEstimation)
+
+	// Environmental adjustment stays off unless the user turns it on
+	launchMonitor.SetEnvironment(settings.Environment)
+
+	// Switch ball detection off during long breaks when the user opts in
+	launchMonitor.SetIdleSettings(settings.Idle)
+	launchMonitor.SetShotCooldown(time.Duration(settings.ShotCooldownMs) * time.Millisecond)
+	launchMonitor.SetSleepSchedule(settings.SleepSchedule)
+	launchMonitor.StartSleepSchedule()
+
+	// Keep the device awake at the configured rate
+	launchMonitor.SetHeartbeatInterval(settings.Heartbeat.Interval())
+
+	// Reconnect to a paired device by address
+	application.Bluetooth.SetBondedDevice(settings.BondedDevice)
+
+	// Remember the device type so connecting by address, which can skip the
+	// advertisement, still tells Home and Omni apart
+	application.Bluetooth.SetDeviceTypeHint(settings.DeviceType)
+	application.Bluetooth.SetDeviceIdentifiedCallback(func(deviceType core.DeviceType) {
+		if err := appcfg.GetInstance().SetDeviceType(deviceType); err != nil {
+			log.Printf("Failed to save device type: %v", err)
+		}
+	})
+
+	// Fail over to a second GSPro PC when one is configured
+	application.GSPro.SetStandby(settings.GSProStandbyIP, settings.GSProStandbyPort)
+
+	// Identify the connector in GSPro's logs
+	if settings.GSProPayload.Valid() {
+		application.GSPro.SetPayloadIdentity(settings.GSProPayload)
+	}
+
+	// Keep counting GSPro shots across restarts unless set to reset on connect
+	if settings.GSProShotNumberPolicy.Valid() {
+		application.GSPro.SetShotNumberPolicy(settings.GSProShotNumberPolicy)
+	}
+	// Keep shots hit while GSPro reconnects to send once it's back
+	if settings.GSProRetryQueue.Valid() {
+		application.GSPro.SetRetryQueue(settings.GSProRetryQueue)
+	}
+	if err := application.GSPro.SetShotNumberPath(appcfg.GetInstance().GSProShotNumberPath()); err != nil {
+		log.Printf("Failed to load GSPro shot number: %v", err)
+	}
+
+	// Estimate battery time remaining from earlier sessions' drain
+	if err := application.Battery.SetPath(appcfg.GetInstance().BatteryHistoryPath()); err != nil {
+		log.Printf("Failed to load battery history: %v", err)
+	}
+
+	// Remember devices seen across restarts so their nicknames are kept
+	if err := application.KnownDevices.SetPath(appcfg.GetInstance().KnownDevicesPath()); err != nil {
+		log.Printf("Failed to load known devices: %v", err)
+	}
+
+	// Record GSPro traffic for debugging dropped shots when enabled
+	application.GSPro.Traffic.SetPath(filepath.Join(logging.GetLogDirectory(), "gspro-traffic.log"))
+	application.GSPro.Traffic.SetEnabled(settings.GSProTrafficLog)
+
+	// Map spin and start direction signs to each simulator's convention
+	application.GSPro.SetSpinConvention(core.SpinConventionFor(settings.SpinConventions, core.SimulatorGSPro))
+	application.InfiniteTees.SetSpinConvention(core.SpinConventionFor(settings.SpinConventions, core.SimulatorInfiniteTees))
+	application.AwesomeGolf.SetSpinConvention(core.SpinConventionFor(settings.SpinConventions, core.SimulatorAwesomeGolf))
+
+	// Report ball positions relative to the user's hitting area
+	launchMonitor.SetMatCalibration(settings.MatCalibration)
+
+	// Re-apply each handedness's saved aim when the player changes
+	launchMonitor.SetAlignmentProfiles(settings.AlignmentProfiles)
+
+	// Steady the aim angle shown while aligning
+	launchMonitor.SetAlignmentSmoothing(settings.AlignmentSmoothing)
+
+	// Use a fitted alignment format if one was captured
+	alignmentFormat, err := core.LoadAlignmentFormat(appcfg.GetInstance().AlignmentFormatPath())
+	if err != nil {
+		log.Printf("Failed to load alignment calibration: %v", err)
+	}
+	application.State.SetAlignmentFormat(alignmentFormat)
+
+	// Guide ball placement into the detection zone
+	application.Placement.SetZone(settings.PlacementZone)
+
+	return application
+}
+
+// shutdownTimeout bounds how long shutdown waits for in-flight work
+const shutdownTimeout = 10 * time.Second
+
+// registerDeviceShutdown registers the device shutdown steps. Registered
+// last, they run first, and the launch monitor is stopped before Bluetooth
+// disconnects so the device is left idle.
+func registerDeviceShutdown(coordinator *lifecycle.Coordinator, bluetoothManager *core.BluetoothManager, launchMonitor *core.LaunchMonitor) {
+	coordinator.Register("disconnecting Bluetooth", func(ctx context.Context) error {
+		return bluetoothManager.Shutdown()
+	})
+	coordinator.Register("stopping launch monitor", func(ctx context.Context) error {
+		launchMonitor.Shutdown()
+		return nil
+	})
+}
+
+// registerHistoryShutdown stores any shot still waiting for club data
+func registerHistoryShutdown(coordinator *lifecycle.Coordinator, shotHistory *history.Store) {
+	coordinator.Register("flushing shot history", func(ctx context.Context) error {
+		shotHistory.Flush()
+		return nil
+	})
+}
+
+// startupFeatures returns the saved feature flags with the ones turned on
+// from the command line
+func startupFeatures(config AppConfig, settings appcfg.Settings) map[core.Feature]bool {
+	features := make(map[core.Feature]bool, len(settings.Features)+2)
+	for feature, enabled := range settings.Features {
+		features[feature] = enabled
+	}
+	if config.EnableExternalCamera {
+		features[core.FeatureExternalCamera] = true
+	}
+	if config.ConnectServerPort != 0 {
+		features[core.FeatureConnectServer] = true
+	}
+	return features
+}
+
+// startConnectServer accepts shots from other launch monitors if enabled.
+// It is stopped on shutdown even when turned on later from the settings.
+func startConnectServer(coordinator *lifecycle.Coordinator, application *app.App) {
+	if application.Features.Enabled(core.FeatureConnectServer) {
+		if err := application.StartConnectServer(); err != nil {
+			log.Printf("Failed to start GSPro Connect server: %v", err)
+		}
+	}
+	coordinator.Register("stopping GSPro Connect server", func(ctx context.Context) error {
+		application.StopConnectServer()
+		return nil
+	})
+}
+
+// startUpdateChecker checks GitHub for new releases in the background and
+// reports them through the web server. It returns nil if checks are off.
+func startUpdateChecker(coordinator *lifecycle.Coordinator, config AppConfig, application *app.App, server *web.Server) *core.UpdateChecker {
+	if !config.UpdateCheck {
+		return nil
+	}
+	checker := core.NewUpdateChecker(version.GetShortVersion())
+	if config.AutoUpdate {
+		exePath, err := os.Executable()
+		if err == nil {
+			err = checker.EnableInstall(exePath)
+		}
+		if err != nil {
+			log.Printf("Warning: automatic updates are unavailable: %v", err)
+		}
+	}
+	server.SetUpdateChecker(checker)
 
-	// Set the bluetooth client on the bluetooth manager
-	bluetoothManager.SetClient(bleClient)
+	ctx, cancel := context.WithCancel(context.Background())
+	application.Supervisor.Go("update checker", func() {
+		checker.Run(ctx, core.DefaultUpdateCheckInterval)
+	})
+	coordinator.Register("stopping update checks", func(context.Context) error {
+		cancel()
+		return nil
+	})
+	return checker
+}
 
-	// Get the singleton launch monitor instance
-	launchMonitor := core.GetLaunchMonitorInstance(stateManager, bluetoothManager)
+// registerCameraShutdown stops camera recorders, such as RTSP buffers
+func registerCameraShutdown(coordinator *lifecycle.Coordinator, application *app.App) {
+	coordinator.Register("stopping cameras", func(ctx context.Context) error {
+		application.Camera.Close()
+		return nil
+	})
+}
 
-	// Set up launch monitor to handle notifications from the bluetooth manager
-	launchMonitor.SetupNotifications(bluetoothManager)
+// shutdown runs the coordinator with the shutdown timeout
+func shutdown(coordinator *lifecycle.Coordinator) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-	return stateManager, bluetoothManager, launchMonitor
+	if err := coordinator.Shutdown(ctx); err != nil {
+		log.Printf("Warning: Shutdown did not complete cleanly: %v", err)
+	}
 }
 
 // setupHeadlessCallbacks configures callbacks for headless mode
@@ -143,8 +380,9 @@ func setupHeadlessCallbacks(stateManager *core.StateManager) {
 		log.Printf("Ball ready: %v", newValue)
 	})
 
+	positionLogLimiter := core.NewRateLimiter(core.RealClock(), core.RateInterval(appcfg.GetInstance().GetSettings().PositionLogRate))
 	stateManager.RegisterBallPositionCallback(func(oldValue, newValue *core.BallPosition) {
-		if newValue != nil {
+		if newValue != nil && positionLogLimiter.Allow() {
 			log.Printf("Ball position: X=%d, Y=%d, Z=%d", newValue.X, newValue.Y, newValue.Z)
 		}
 	})
@@ -157,13 +395,20 @@ func setupHeadlessCallbacks(stateManager *core.StateManager) {
 }
 
 // startCLI initializes and runs the command-line interface
-func startCLI(config AppConfig, stateManager *core.StateManager, bluetoothManager *core.BluetoothManager, launchMonitor *core.LaunchMonitor) {
+func startCLI(config AppConfig, application *app.App) {
+	stateManager := application.State
+	bluetoothManager := application.Bluetooth
+
 	// Setup callbacks for headless mode
 	setupHeadlessCallbacks(stateManager)
 
 	// Start bluetooth connection
 	log.Println("Starting Bluetooth connection...")
-	bluetoothManager.StartBluetoothConnection(config.DeviceName, "")
+	deviceAddress := config.DeviceAddress
+	if deviceAddress == "" {
+		deviceAddress = appcfg.GetInstance().GetSettings().DeviceAddress
+	}
+	bluetoothManager.StartBluetoothConnection(config.DeviceName, deviceAddress)
 
 	// Wait for connection to be established
 	log.Println("Waiting for Bluetooth connection...")
@@ -186,46 +431,51 @@ func startCLI(config AppConfig, stateManager *core.StateManager, bluetoothManage
 		return
 	}
 
+	// Steps run in reverse, so the device stops first and the history is
+	// flushed last
+	coordinator := lifecycle.NewCoordinator()
+	registerHistoryShutdown(coordinator, application.History)
+	registerCameraShutdown(coordinator, application)
+	startConnectServer(coordinator, application)
+
 	// Setup GSPro integration if enabled
 	if config.EnableGSPro {
 		log.Println("Starting GSPro integration")
-		gsproIntegration := gspro.GetInstance(stateManager, launchMonitor, config.GSProIP, config.GSProPort)
+		gsproIntegration := application.GSPro
 		gsproIntegration.EnableAutoReconnect()
 		gsproIntegration.Start()
+		coordinator.Register("closing GSPro connection", func(ctx context.Context) error {
+			gsproIntegration.Shutdown()
+			return nil
+		})
 	}
-
-	// Wait for interrupt signal to gracefully shut down
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	registerDeviceShutdown(coordinator, bluetoothManager, application.LaunchMonitor)
 
 	// Block until we receive a signal
-	<-sigChan
+	<-lifecycle.NotifyOnSignal()
 	log.Println("Shutting down...")
 
-	// Clean up
-	bluetoothManager.DisconnectBluetooth()
-
-	// Give everything a moment to clean up
-	time.Sleep(1 * time.Second)
+	shutdown(coordinator)
 	log.Println("Application stopped")
 }
 
-// startWebServer initializes and runs the web server
-func startWebServer(config AppConfig, stateManager *core.StateManager, bluetoothManager *core.BluetoothManager, launchMonitor *core.LaunchMonitor) {
+// startWebServer initializes and runs the web server until stop is closed.
+// ready is called once the server is listening.
+func startWebServer(config AppConfig, application *app.App, stop <-chan struct{}, ready func()) {
+	stateManager := application.State
+	bluetoothManager := application.Bluetooth
+
 	// Initialize config manager and load settings (happens behind the scenes like Fyne)
 	settings := appcfg.GetInstance().GetSettings()
 
 	// Apply loaded settings to state manager
 	appcfg.GetInstance().ApplyToStateManager(stateManager)
 
-	// Initialize camera manager only if external camera feature is enabled
-	var cameraManager *camera.Manager
-	if config.EnableExternalCamera {
-		cameraManager = camera.GetInstance(stateManager, settings.CameraURL, settings.CameraEnabled)
-	}
-
 	// Create web server
-	server := web.NewServer(stateManager, bluetoothManager, launchMonitor, cameraManager, config.GSProIP, config.GSProPort, config.InfiniteTeesIP, config.InfiniteTeesPort, config.EnableExternalCamera)
+	server := web.NewServer(application)
+	server.SetBindAddress(config.BindAddress)
+	server.SetAllowedOrigins(config.AllowedOrigins)
+	server.SetAccessTokens(settings.AccessTokens)
 
 	// Setup auto-connects based on settings
 	if config.EnableGSPro || settings.GSProAutoConnect {
@@ -236,7 +486,7 @@ func startWebServer(config AppConfig, stateManager *core.StateManager, bluetooth
 			gsproPort = settings.GSProPort
 		}
 		log.Printf("Auto-connecting to GSPro at %s:%d", gsproIP, gsproPort)
-		gsproIntegration := gspro.GetInstance(stateManager, launchMonitor, gsproIP, gsproPort)
+		gsproIntegration := application.GSPro
 		gsproIntegration.EnableAutoReconnect()
 		gsproIntegration.Start()
 		go gsproIntegration.Connect(gsproIP, gsproPort)
@@ -250,42 +500,57 @@ func startWebServer(config AppConfig, stateManager *core.StateManager, bluetooth
 		go itIntegration.Connect(settings.InfiniteTeesIP, settings.InfiniteTeesPort)
 	}
 
-	log.Printf("Auto-connecting to device: %s", settings.DeviceName)
-	bluetoothManager.StartBluetoothConnection(settings.DeviceName, "")
-
-	// Set up graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	if settings.AwesomeGolfAutoConnect {
+		log.Printf("Auto-connecting to Awesome Golf at %s:%d", settings.AwesomeGolfIP, settings.AwesomeGolfPort)
+		agIntegration := application.AwesomeGolf
+		agIntegration.EnableAutoReconnect()
+		agIntegration.Start()
+		go agIntegration.Connect(settings.AwesomeGolfIP, settings.AwesomeGolfPort)
+	}
 
-	// Start the web server in a goroutine
-	var stopOnce sync.Once
+	log.Printf("Auto-connecting to device: %s", settings.DeviceName)
+	bluetoothManager.StartBluetoothConnection(settings.DeviceName, settings.DeviceAddress)
+
+	// Set up graceful shutdown. Steps run in reverse, so the device stops
+	// first and the web server last, letting clients see the disconnect.
+	coordinator := lifecycle.NewCoordinator()
+	coordinator.Register("stopping web server", server.Stop)
+	updates := startUpdateChecker(coordinator, config, application, server)
+	registerHistoryShutdown(coordinator, application.History)
+	registerCameraShutdown(coordinator, application)
+	startConnectServer(coordinator, application)
+	coordinator.Register("closing simulator connections", func(ctx context.Context) error {
+		server.ShutdownIntegrations()
+		return nil
+	})
+	registerDeviceShutdown(coordinator, bluetoothManager, application.LaunchMonitor)
 	stopServer := func() {
-		stopOnce.Do(func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			if err := server.Stop(ctx); err != nil {
-				log.Printf("Warning: Could not stop web server cleanly: %v", err)
-			}
-
-			bluetoothManager.DisconnectBluetooth()
-		})
+		shutdown(coordinator)
 	}
 
+	// Bind the port before serving so a failure to listen is known here
 	serverErr := make(chan error, 1)
-	go func() {
-		log.Printf("Starting web server on http://localhost:%d", config.WebPort)
-		if err := server.Start(config.WebPort); err != nil {
-			serverErr <- err
-		}
-	}()
+	log.Printf("Starting web server on %s", web.LocalURL(config.BindAddress, config.WebPort))
+	listener, err := server.Listen(config.WebPort)
+	if err != nil {
+		serverErr <- err
+	} else {
+		go func() {
+			if err := server.Serve(listener); err != nil {
+				serverErr <- err
+			}
+		}()
+	}
 
-	// Give the server a moment to start up
-	time.Sleep(500 * time.Millisecond)
+	ready()
+	if updates != nil && err == nil {
+		// Getting this far means an installed update works
+		updates.ConfirmStarted()
+	}
 
 	if config.ServerOnly {
 		select {
-		case <-sigChan:
+		case <-stop:
 			log.Println("Shutting down web server...")
 			stopServer()
 		case err := <-serverErr:
@@ -295,12 +560,12 @@ func startWebServer(config AppConfig, stateManager *core.StateManager, bluetooth
 		return
 	}
 
-	window := ui.NewDesktopWindow(fmt.Sprintf("http://localhost:%d", config.WebPort))
+	window := ui.NewDesktopWindow(web.LocalURL(config.BindAddress, config.WebPort))
 
 	exitErr := make(chan error, 1)
 	go func() {
 		select {
-		case <-sigChan:
+		case <-stop:
 			log.Println("Shutting down web server...")
 			stopServer()
 			window.Terminate()
@@ -323,31 +588,65 @@ func startWebServer(config AppConfig, stateManager *core.StateManager, bluetooth
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == tailCommand {
+		if err := runTail(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Parse command line flags
 	useMock := flag.String("mock", "", "Mock mode: 'stub' for basic mock, 'simulate' for simulated device with realistic behavior, or empty for real hardware")
 	deviceName := flag.String("device", "", "Name of the Bluetooth device to connect to")
+	deviceAddress := flag.String("device-address", "", "Address of the Bluetooth device to connect to without scanning (a MAC address, or the device UUID on macOS)")
 	headless := flag.Bool("headless", false, "Run in headless CLI mode without UI")
 	serverOnly := flag.Bool("server-only", false, "Run the web server without opening the desktop window")
 	webPort := flag.Int("web-port", 8080, "Port for web server")
+	bindAddress := flag.String("bind-address", web.DefaultBindAddress, "Address the web server listens on (use 0.0.0.0 to allow remote access)")
+	allowedOrigins := flag.String("allowed-origins", "", "Comma-separated list of extra browser origins allowed to access the web server")
 	gsproIP := flag.String("gspro-ip", "127.0.0.1", "IP address of GSPro server")
 	gsproPort := flag.Int("gspro-port", 921, "Port of GSPro server")
 	enableGSPro := flag.Bool("enable-gspro", false, "Enable GSPro integration")
 	itIP := flag.String("it-ip", "127.0.0.1", "IP address of Infinite Tees server")
 	itPort := flag.Int("it-port", 999, "Port of Infinite Tees server")
 	enableExternalCamera := flag.Bool("enable-external-camera", false, "Enable external camera integration (experimental)")
+	connectServerPort := flag.Int("connect-server-port", 0, "Accept shots from other launch monitors on this port using the GSPro Connect API (921 if GSPro runs on another machine, 0 to disable)")
+	shotRouting := flag.String("shot-routing", "first", "How shots from the connect server and the device are arbitrated: 'first' takes whichever reports a swing first, 'putter' takes putts from connected launch monitors and full shots from the device based on the selected club")
 	simulateOmni := flag.Bool("omni", false, "Simulate an Omni device instead of Home (requires --mock simulate)")
+	bleBackend := flag.String("ble-backend", "tinygo", "Bluetooth backend for real hardware: 'tinygo' works everywhere, 'bluez' talks to BlueZ over D-Bus on Linux")
+	updateCheck := flag.Bool("update-check", true, "Check GitHub daily for a newer connector release and show it in the web UI")
+	autoUpdate := flag.Bool("auto-update", false, "Download and install new releases when they are found; they run after a restart, and the previous version is kept for rollback (Windows only)")
+	service := flag.String("service", "", "Run as a system service: 'install' registers the connector to start at boot using the saved settings, 'uninstall' removes it, 'run' is used by the service manager (Windows and Linux)")
 	flag.Parse()
 
+	action, err := parseServiceAction(*service)
+	if err != nil {
+		log.Fatalf("Invalid --service: %v", err)
+	}
+	if action == serviceInstall || action == serviceUninstall {
+		if err := runService(action, nil); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Load saved settings for defaults
 	savedSettings := appcfg.GetInstance().GetSettings()
 
 	itIPProvided := false
 	itPortProvided := false
+	bindAddressProvided := false
+	allowedOriginsProvided := false
 	flag.Visit(func(f *flag.Flag) {
-		if f.Name == "it-ip" {
+		switch f.Name {
+		case "it-ip":
 			itIPProvided = true
-		} else if f.Name == "it-port" {
+		case "it-port":
 			itPortProvided = true
+		case "bind-address":
+			bindAddressProvided = true
+		case "allowed-origins":
+			allowedOriginsProvided = true
 		}
 	})
 
@@ -361,13 +660,42 @@ func main() {
 		infiniteTeesPort = savedSettings.InfiniteTeesPort
 	}
 
+	// Remote access stays localhost-only unless enabled via CLI or saved settings
+	webBindAddress := *bindAddress
+	if !bindAddressProvided && savedSettings.BindAddress != "" {
+		webBindAddress = savedSettings.BindAddress
+	}
+	webBindAddress, err = web.ParseBindAddress(webBindAddress)
+	if err != nil {
+		log.Fatalf("Invalid --bind-address: %v", err)
+	}
+	webAllowedOrigins := web.ParseAllowedOrigins(*allowedOrigins)
+	if !allowedOriginsProvided {
+		webAllowedOrigins = savedSettings.AllowedOrigins
+	}
+
+	routing, err := core.ParseShotRouting(*shotRouting)
+	if err != nil {
+		log.Fatalf("Invalid --shot-routing: %v", err)
+	}
+	if !core.ValidDeviceAddress(*deviceAddress) {
+		log.Fatalf("Invalid --device-address: %q", *deviceAddress)
+	}
+	backend, err := core.ParseBLEBackend(*bleBackend)
+	if err != nil {
+		log.Fatalf("Invalid --ble-backend: %v", err)
+	}
+
 	config := AppConfig{
 		UseMock:              core.MockMode(*useMock),
 		DeviceName:           *deviceName,
+		DeviceAddress:        *deviceAddress,
 		Headless:             *headless,
 		WebMode:              !*headless,
 		ServerOnly:           *serverOnly,
 		WebPort:              *webPort,
+		BindAddress:          webBindAddress,
+		AllowedOrigins:       webAllowedOrigins,
 		GSProIP:              *gsproIP,
 		GSProPort:            *gsproPort,
 		EnableGSPro:          *enableGSPro,
@@ -375,15 +703,34 @@ func main() {
 		InfiniteTeesPort:     infiniteTeesPort,
 		EnableExternalCamera: *enableExternalCamera,
 		SimulateOmni:         *simulateOmni,
+		ConnectServerPort:    *connectServerPort,
+		ShotRouting:          routing,
+		BLEBackend:           backend,
+		UpdateCheck:          *updateCheck,
+		AutoUpdate:           *autoUpdate,
+	}
+
+	// A service has no desktop to open a window on
+	if action == serviceRun {
+		config.Headless = false
+		config.WebMode = true
+		config.ServerOnly = true
+		err := runService(action, func(stop <-chan struct{}, ready func()) {
+			startWebServer(config, initializeBackend(config), stop, ready)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
 	// Initialize common backend components
-	stateManager, bluetoothManager, launchMonitor := initializeBackend(config)
+	application := initializeBackend(config)
 
 	// Launch the appropriate interface based on mode
 	if config.Headless {
-		startCLI(config, stateManager, bluetoothManager, launchMonitor)
+		startCLI(config, application)
 	} else {
-		startWebServer(config, stateManager, bluetoothManager, launchMonitor)
+		startWebServer(config, application, stopOnSignal(), func() {})
 	}
 }
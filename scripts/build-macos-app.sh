@@ -20,7 +20,7 @@ fi
 rm -rf "$APP_DIR" "$ICONSET_DIR"
 mkdir -p "$MACOS_DIR" "$RESOURCES_DIR" "$ICONSET_DIR"
 
-go build -o "$MACOS_DIR/squaregolf-connector" "$ROOT_DIR/main.go"
+go build -C "$ROOT_DIR" -o "$MACOS_DIR/squaregolf-connector" .
 
 cp "$ROOT_DIR/macos/Info.plist" "$CONTENTS_DIR/Info.plist"
 cp -R "$ROOT_DIR/web" "$RESOURCES_DIR/web"
@@ -50,10 +50,11 @@ export GOARCH=amd64
 export CGO_ENABLED=1
 
 go build \
+    -C "$ROOT_DIR" \
     -trimpath \
     -ldflags "-H windowsgui -s -w" \
     -o "$APP_DIR/$EXE_NAME" \
-    "$ROOT_DIR/main.go"
+    .
 
 rm -f "$SYSO_PATH"
 
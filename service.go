@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/brentyates/squaregolf-connector/internal/lifecycle"
+)
+
+const (
+	// serviceName identifies the connector to the service manager
+	serviceName = "squaregolf-connector"
+	// serviceDescription is shown in the service manager
+	serviceDescription = "Relays shots from a SquareGolf launch monitor to golf simulators"
+)
+
+// serviceAction is what the -service flag asks for
+type serviceAction string
+
+const (
+	serviceNone      serviceAction = ""
+	serviceRun       serviceAction = "run"
+	serviceInstall   serviceAction = "install"
+	serviceUninstall serviceAction = "uninstall"
+)
+
+// parseServiceAction validates the -service flag
+func parseServiceAction(name string) (serviceAction, error) {
+	switch action := serviceAction(name); action {
+	case serviceNone, serviceRun, serviceInstall, serviceUninstall:
+		return action, nil
+	default:
+		return "", fmt.Errorf("unknown service action %q", name)
+	}
+}
+
+// serviceRunner runs the connector until stop is closed, calling ready once
+// it is serving
+type serviceRunner func(stop <-chan struct{}, ready func())
+
+// stopOnSignal returns a channel that is closed on SIGINT or SIGTERM
+func stopOnSignal() <-chan struct{} {
+	stop := make(chan struct{})
+	signals := lifecycle.NotifyOnSignal()
+	go func() {
+		<-signals
+		close(stop)
+	}()
+	return stop
+}
+
+// runService handles the -service flag. Installing registers the current
+// executable to start with the system; running hands the connector's
+// lifecycle to the service manager.
+func runService(action serviceAction, run serviceRunner) error {
+	switch action {
+	case serviceInstall:
+		return installService()
+	case serviceUninstall:
+		return uninstallService()
+	default:
+		return runAsService(run)
+	}
+}
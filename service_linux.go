@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	apexlog "github.com/apex/log"
+
+	"github.com/brentyates/squaregolf-connector/internal/logging"
+)
+
+// systemdUnitPath is where install writes the unit file
+var systemdUnitPath = filepath.Join("/etc/systemd/system", serviceName+".service")
+
+// systemdUnit is the unit file installed for the connector. Type=notify
+// makes systemd wait for the web server before reporting the unit started.
+// It runs as the installing user so it reads the settings saved in their
+// home directory.
+const systemdUnit = `[Unit]
+Description=%s
+After=bluetooth.target network-online.target
+Wants=bluetooth.target network-online.target
+
+[Service]
+Type=notify
+User=%s
+Environment=%s
+WorkingDirectory=%s
+ExecStart=%s -service run
+Restart=on-failure
+RestartSec=5
+
+[Install]
+WantedBy=multi-user.target
+`
+
+// installService writes the systemd unit and enables it so the connector
+// starts at boot
+func installService() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find executable: %w", err)
+	}
+	account, err := serviceAccount()
+	if err != nil {
+		return fmt.Errorf("failed to find the user to run the service as: %w", err)
+	}
+	unit := fmt.Sprintf(systemdUnit, serviceDescription, account.Username,
+		systemdQuote("HOME="+account.HomeDir), systemdEscape(account.HomeDir), systemdQuote(exe))
+	if err := os.WriteFile(systemdUnitPath, []byte(unit), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", systemdUnitPath, err)
+	}
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	if err := systemctl("enable", "--now", serviceName); err != nil {
+		return err
+	}
+	log.Printf("Installed and started the %s service as %s", serviceName, account.Username)
+	return nil
+}
+
+// serviceAccount returns the user installing the service. Installing needs
+// root, so under sudo that is the user who ran sudo.
+func serviceAccount() (*user.User, error) {
+	if name := os.Getenv("SUDO_USER"); name != "" && name != "root" {
+		return user.Lookup(name)
+	}
+	return user.Current()
+}
+
+// systemdEscape doubles percent signs so systemd doesn't expand them as
+// specifiers
+func systemdEscape(value string) string {
+	return strings.ReplaceAll(value, "%", "%%")
+}
+
+// systemdQuote quotes a unit file value so a path with spaces stays one
+// argument
+func systemdQuote(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + systemdEscape(value) + `"`
+}
+
+// uninstallService stops and disables the unit and removes it
+func uninstallService() error {
+	if err := systemctl("disable", "--now", serviceName); err != nil {
+		return err
+	}
+	if err := os.Remove(systemdUnitPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", systemdUnitPath, err)
+	}
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	log.Printf("Removed the %s service", serviceName)
+	return nil
+}
+
+func systemctl(args ...string) error {
+	output, err := exec.Command("systemctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// runAsService runs under systemd, which stops the connector with SIGTERM.
+// Console output goes to the journal with its level.
+func runAsService(run serviceRunner) error {
+	logging.SetConsoleHandler(&journalHandler{w: os.Stdout})
+
+	stop := make(chan struct{})
+	signalStop := stopOnSignal()
+	go func() {
+		<-signalStop
+		sdNotify("STOPPING=1")
+		close(stop)
+	}()
+
+	run(stop, func() { sdNotify("READY=1") })
+	return nil
+}
+
+// journalHandler writes log entries with the syslog priority prefix journald
+// uses to set each line's level
+type journalHandler struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (h *journalHandler) HandleLog(e *apexlog.Entry) error {
+	priority := 6 // info
+	switch e.Level {
+	case apexlog.DebugLevel:
+		priority = 7
+	case apexlog.WarnLevel:
+		priority = 4
+	case apexlog.ErrorLevel:
+		priority = 3
+	case apexlog.FatalLevel:
+		priority = 2
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := fmt.Fprintf(h.w, "<%d>%s\n", priority, strings.TrimSpace(e.Message))
+	return err
+}
+
+// sdNotify sends a state change to systemd. It does nothing when not started
+// by systemd.
+func sdNotify(state string) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		log.Printf("Failed to notify systemd: %v", err)
+		return
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		log.Printf("Failed to notify systemd: %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !windows && !linux
+
+package main
+
+import "errors"
+
+// errServiceUnsupported is returned on platforms without a service wrapper.
+// On macOS, add the app to Login Items instead.
+var errServiceUnsupported = errors.New("service mode is only supported on Windows and Linux")
+
+func installService() error {
+	return errServiceUnsupported
+}
+
+func uninstallService() error {
+	return errServiceUnsupported
+}
+
+func runAsService(run serviceRunner) error {
+	return errServiceUnsupported
+}
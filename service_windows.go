@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	apexlog "github.com/apex/log"
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"github.com/brentyates/squaregolf-connector/internal/core"
+	"github.com/brentyates/squaregolf-connector/internal/logging"
+)
+
+// eventLogID is the event ID used for every entry the connector writes
+const eventLogID = 1
+
+// installService registers the connector with the service manager to start
+// automatically, restarting it if it exits unexpectedly
+func installService() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find executable: %w", err)
+	}
+	profile, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to find the user profile: %w", err)
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(serviceName); err == nil {
+		s.Close()
+		return fmt.Errorf("service %s is already installed", serviceName)
+	}
+
+	s, err := m.CreateService(serviceName, exe, mgr.Config{
+		DisplayName: core.AppName,
+		Description: serviceDescription,
+		StartType:   mgr.StartAutomatic,
+	}, "-service", "run")
+	if err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+	defer s.Close()
+
+	// The service runs as LocalSystem, whose profile holds no settings, so
+	// point it at the installing user's profile
+	if err := setServiceEnvironment("USERPROFILE=" + profile); err != nil {
+		s.Delete()
+		return fmt.Errorf("failed to set the service environment: %w", err)
+	}
+
+	restart := mgr.RecoveryAction{Type: mgr.ServiceRestart, Delay: 5 * time.Second}
+	if err := s.SetRecoveryActions([]mgr.RecoveryAction{restart, restart, restart}, uint32((24 * time.Hour).Seconds())); err != nil {
+		log.Printf("Warning: failed to set service recovery actions: %v", err)
+	}
+
+	if err := eventlog.InstallAsEventCreate(serviceName, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
+		s.Delete()
+		return fmt.Errorf("failed to register event log source: %w", err)
+	}
+
+	if err := s.Start(); err != nil {
+		return fmt.Errorf("failed to start service: %w", err)
+	}
+	log.Printf("Installed and started the %s service", serviceName)
+	return nil
+}
+
+// setServiceEnvironment sets the environment variables the service manager
+// starts the connector with
+func setServiceEnvironment(env ...string) error {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\`+serviceName, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+	return key.SetStringsValue("Environment", env)
+}
+
+// uninstallService stops the service and removes it and its event log source
+func uninstallService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", serviceName)
+	}
+	defer s.Close()
+
+	if status, err := s.Control(svc.Stop); err == nil {
+		// Give the connector time to disconnect from the device
+		deadline := time.Now().Add(shutdownTimeout)
+		for status.State != svc.Stopped && time.Now().Before(deadline) {
+			time.Sleep(500 * time.Millisecond)
+			if status, err = s.Query(); err != nil {
+				break
+			}
+		}
+	}
+
+	if err := s.Delete(); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	if err := eventlog.Remove(serviceName); err != nil {
+		log.Printf("Warning: failed to remove event log source: %v", err)
+	}
+	log.Printf("Removed the %s service", serviceName)
+	return nil
+}
+
+// runAsService runs under the service manager, which must have started the
+// process. Console output goes to the event log.
+func runAsService(run serviceRunner) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return fmt.Errorf("failed to detect the service manager: %w", err)
+	}
+	if !isService {
+		return errors.New("-service run must be started by the service manager; use -service install")
+	}
+
+	events, err := eventlog.Open(serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer events.Close()
+	logging.SetConsoleHandler(&eventLogHandler{log: events})
+
+	return svc.Run(serviceName, &windowsService{run: run})
+}
+
+// windowsService adapts a serviceRunner to the service manager's control
+// requests
+type windowsService struct {
+	run serviceRunner
+}
+
+func (w *windowsService) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	const accepted = svc.AcceptStop | svc.AcceptShutdown
+	status <- svc.Status{State: svc.StartPending}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.run(stop, func() {
+			status <- svc.Status{State: svc.Running, Accepts: accepted}
+		})
+	}()
+
+	for {
+		select {
+		case <-done:
+			// The connector stopped without being asked to
+			return false, 1
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				status <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending, WaitHint: uint32(shutdownTimeout.Milliseconds())}
+				close(stop)
+				<-done
+				return false, 0
+			}
+		}
+	}
+}
+
+// eventLogHandler writes log entries to the Windows event log
+type eventLogHandler struct {
+	log *eventlog.Log
+}
+
+func (h *eventLogHandler) HandleLog(e *apexlog.Entry) error {
+	message := strings.TrimSpace(e.Message)
+	switch e.Level {
+	case apexlog.ErrorLevel, apexlog.FatalLevel:
+		return h.log.Error(eventLogID, message)
+	case apexlog.WarnLevel:
+		return h.log.Warning(eventLogID, message)
+	default:
+		return h.log.Info(eventLogID, message)
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/brentyates/squaregolf-connector/internal/i18n"
+	"github.com/brentyates/squaregolf-connector/internal/web"
+)
+
+const (
+	// tailCommand is the subcommand that prints a running connector's shots
+	tailCommand = "tail"
+	// tailRetryInterval is how long the tailer waits before reconnecting to a
+	// connector that went away
+	tailRetryInterval = 2 * time.Second
+	// tailSettingsTimeout bounds reading the connector's number format
+	tailSettingsTimeout = 5 * time.Second
+)
+
+// runTail prints the shots and status changes of a connector that is already
+// running, read from its WebSocket. It reconnects until interrupted, so it can
+// be left running across connector restarts.
+func runTail(args []string) error {
+	flags := flag.NewFlagSet(tailCommand, flag.ExitOnError)
+	address := flags.String("url", "http://localhost:8080", "Address of the running connector's web server")
+	jsonOutput := flags.Bool("json", false, "Print each message as a line of JSON instead of formatted text")
+	shotsOnly := flags.Bool("shots-only", false, "Print shots only, without connection and ball status changes")
+	once := flags.Bool("once", false, "Exit when the connection closes instead of reconnecting")
+	token := flags.String("token", "", "Access token, if the connector has any set")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s %s [flags]\n\nPrints shots and status changes from a running connector.\n\n", os.Args[0], tailCommand)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	wsURL, err := tailWebSocketURL(*address)
+	if err != nil {
+		return fmt.Errorf("invalid -url: %w", err)
+	}
+
+	header := http.Header{}
+	if *token != "" {
+		header.Set("Authorization", "Bearer "+*token)
+	}
+
+	stop := stopOnSignal()
+	for {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, header)
+		if err == nil {
+			log.Printf("Tailing %s", *address)
+			if err := tailNumberFormat(*address, header); err != nil {
+				log.Printf("Couldn't read the connector's number format, using the default: %v", err)
+			}
+			printer := &tailPrinter{out: os.Stdout, json: *jsonOutput, shotsOnly: *shotsOnly}
+			err = tailConnection(conn, printer, stop)
+		}
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+		if *once {
+			return err
+		}
+		log.Printf("Connector unavailable (%v), retrying in %v", err, tailRetryInterval)
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(tailRetryInterval):
+		}
+	}
+}
+
+// tailWebSocketURL turns the connector's web address into its WebSocket URL
+func tailWebSocketURL(address string) (string, error) {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "http", "ws":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("no host in %q", address)
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/ws"
+	return u.String(), nil
+}
+
+// tailNumberFormat shows numbers in the units and with the decimal separator
+// the connector is set to
+func tailNumberFormat(address string, header http.Header) error {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	client := http.Client{Timeout: tailSettingsTimeout}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(address, "/")+"/api/v1/settings", nil)
+	if err != nil {
+		return err
+	}
+	req.Header = header.Clone()
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("settings returned %s", resp.Status)
+	}
+
+	var settings web.AppSettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return err
+	}
+	i18n.SetNumberFormat(settings.NumberFormat)
+	return nil
+}
+
+// tailConnection reads messages until the connection fails or stop is closed
+func tailConnection(conn *websocket.Conn, printer *tailPrinter, stop <-chan struct{}) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+			conn.Close()
+		case <-done:
+		}
+	}()
+	defer conn.Close()
+
+	for {
+		var msg tailMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return err
+		}
+		printer.print(msg)
+	}
+}
+
+// tailMessage is a WebSocket message, with its data left encoded until its
+// type is known
+type tailMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// tailPrinter formats messages as they arrive. It keeps the device status
+// merged from the deltas so it can tell what changed.
+type tailPrinter struct {
+	out       io.Writer
+	json      bool
+	shotsOnly bool
+
+	device      map[string]json.RawMessage
+	gsproStatus string
+}
+
+func (p *tailPrinter) print(msg tailMessage) {
+	if p.json {
+		encoded, err := json.Marshal(msg)
+		if err == nil {
+			fmt.Fprintf(p.out, "%s\n", encoded)
+		}
+		return
+	}
+
+	switch msg.Type {
+	case "deviceStatus":
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(msg.Data, &fields); err != nil {
+			return
+		}
+		// The full status is only sent on connect, so its last shot is old
+		p.device = fields
+		status := p.deviceStatus()
+		p.status("device %s%s", status.ConnectionStatus, tailDeviceDetails(status))
+	case "deviceStatusDelta":
+		var delta map[string]json.RawMessage
+		if err := json.Unmarshal(msg.Data, &delta); err != nil || p.device == nil {
+			return
+		}
+		for key, value := range delta {
+			p.device[key] = value
+		}
+		p.deviceChanged(delta)
+	case "gsproStatus":
+		var status web.GSProStatus
+		if err := json.Unmarshal(msg.Data, &status); err != nil {
+			return
+		}
+		if status.ConnectionStatus != p.gsproStatus {
+			p.gsproStatus = status.ConnectionStatus
+			p.status("GSPro %s", status.ConnectionStatus)
+		}
+	case "staleConnectionRecovered":
+		p.status("simulator stopped answering and was reconnected; the last shot may be missing")
+	}
+}
+
+func (p *tailPrinter) deviceStatus() web.DeviceStatus {
+	var status web.DeviceStatus
+	encoded, _ := json.Marshal(p.device)
+	json.Unmarshal(encoded, &status)
+	return status
+}
+
+func (p *tailPrinter) deviceChanged(delta map[string]json.RawMessage) {
+	status := p.deviceStatus()
+	if _, ok := delta["connectionStatus"]; ok {
+		p.status("device %s%s", status.ConnectionStatus, tailDeviceDetails(status))
+	}
+	if _, ok := delta["club"]; ok && status.Club != nil {
+		p.status("club %s", status.Club.Name())
+	}
+	if _, ok := delta["ballReady"]; ok && status.BallReady {
+		p.status("ball ready")
+	}
+	if _, ok := delta["batteryLevel"]; ok && status.BatteryLevel != nil {
+		p.status("battery %d%%", *status.BatteryLevel)
+	}
+	if _, ok := delta["lastBallMetrics"]; ok && status.LastBallMetrics != nil {
+		p.shot(status)
+	}
+}
+
+// status prints a line that isn't a shot, unless only shots are wanted
+func (p *tailPrinter) status(format string, args ...interface{}) {
+	if p.shotsOnly {
+		return
+	}
+	fmt.Fprintf(p.out, "%s  %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+}
+
+func (p *tailPrinter) shot(status web.DeviceStatus) {
+	ball := status.LastBallMetrics
+	line := fmt.Sprintf("%s  SHOT", time.Now().Format("15:04:05"))
+	if status.Club != nil {
+		line += "  " + status.Club.Name()
+	}
+	line += fmt.Sprintf("  ball %s  launch %s°  direction %s°  spin %d rpm  axis %s°",
+		i18n.Speed(ball.BallSpeedMPS, 1), i18n.Number(ball.VerticalAngle, 1), i18n.Number(ball.HorizontalAngle, 1),
+		ball.TotalspinRPM, i18n.Number(ball.SpinAxis, 1))
+	if club := status.LastClubMetrics; club != nil {
+		line += fmt.Sprintf("  club %s  path %s°  face %s°",
+			i18n.Speed(club.ClubSpeed, 1), i18n.Number(club.PathAngle, 1), i18n.Number(club.FaceAngle, 1))
+	}
+	fmt.Fprintln(p.out, line)
+}
+
+// tailDeviceDetails describes a connected device
+func tailDeviceDetails(status web.DeviceStatus) string {
+	var details []string
+	if status.DeviceName != nil && *status.DeviceName != "" {
+		details = append(details, *status.DeviceName)
+	}
+	if status.BatteryLevel != nil {
+		details = append(details, fmt.Sprintf("battery %d%%", *status.BatteryLevel))
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(details, ", ") + ")"
+}
@@ -4,11 +4,20 @@ import { WebSocketService } from '../services/WebSocketService.js';
 import { DeviceService } from '../services/DeviceService.js';
 import { GSProService } from '../services/GSProService.js';
 import { InfiniteTeesService } from '../services/InfiniteTeesService.js';
+import { AwesomeGolfService } from '../services/AwesomeGolfService.js';
 import { ApiClient } from '../services/ApiClient.js';
 import { AlignmentManager } from '../features/AlignmentManager.js';
 import { SettingsManager } from '../features/SettingsManager.js';
 import { CameraManager } from '../features/CameraManager.js';
 import { ShotMonitor } from '../features/ShotMonitor.js';
+import { SimulatorPanel } from '../features/SimulatorPanel.js';
+import { GamesPanel } from '../features/GamesPanel.js';
+import { WedgePanel } from '../features/WedgePanel.js';
+import { CombinePanel } from '../features/CombinePanel.js';
+import { WarmupPanel } from '../features/WarmupPanel.js';
+import { ExportPanel } from '../features/ExportPanel.js';
+import { DashboardPanel } from '../features/DashboardPanel.js';
+import { RawCommandConsole } from '../features/RawCommandConsole.js';
 import { ToastManager } from '../ui/ToastManager.js';
 import { ScreenManager } from '../ui/ScreenManager.js';
 
@@ -27,12 +36,21 @@ export class SquareGolfApp {
         this.deviceService = new DeviceService(this.api, this.eventBus);
         this.gsproService = new GSProService(this.api, this.eventBus);
         this.infiniteTeesService = new InfiniteTeesService(this.api, this.eventBus);
+        this.awesomeGolfService = new AwesomeGolfService(this.api, this.eventBus);
 
         // Features
         this.alignmentManager = new AlignmentManager(this.api, this.eventBus);
         this.settingsManager = new SettingsManager(this.api, this.eventBus);
         this.cameraManager = new CameraManager(this.api, this.eventBus);
         this.shotMonitor = new ShotMonitor(this.api, this.eventBus);
+        this.simulatorPanel = new SimulatorPanel(this.api, this.eventBus);
+        this.gamesPanel = new GamesPanel(this.api, this.eventBus);
+        this.wedgePanel = new WedgePanel(this.api, this.eventBus);
+        this.combinePanel = new CombinePanel(this.api, this.eventBus);
+        this.warmupPanel = new WarmupPanel(this.api, this.eventBus);
+        this.exportPanel = new ExportPanel(this.api, this.eventBus);
+        this.dashboardPanel = new DashboardPanel(this.api, this.eventBus);
+        this.rawCommandConsole = new RawCommandConsole(this.api, this.eventBus);
 
         // Local state
         this.features = {};
@@ -66,6 +84,14 @@ export class SquareGolfApp {
             this.setHidden(this.$('statusBar'), true);
             this.ws.connect();
             this.settingsManager.load();
+            this.awesomeGolfService.loadConfig();
+            this.loadVersion();
+            this.loadKnownDevices();
+            this.gamesPanel.load();
+            this.wedgePanel.load();
+            this.combinePanel.load();
+            this.warmupPanel.load();
+            this.exportPanel.load();
         });
     }
 
@@ -97,6 +123,7 @@ export class SquareGolfApp {
         });
         this.eventBus.on('gspro:error', (msg) => this.toast.error(`GSPro: ${msg}`));
         this.eventBus.on('gspro:status', (status) => this.updateGSProStatus(status));
+        this.eventBus.on('gspro:resent', (shotNumber) => this.toast.info(`Resent the last shot to GSPro as shot ${shotNumber}`));
 
         // Infinite Tees events
         this.eventBus.on('infinitetees:connecting', () => {
@@ -108,6 +135,19 @@ export class SquareGolfApp {
         this.eventBus.on('infinitetees:error', (msg) => this.toast.error(`Infinite Tees: ${msg}`));
         this.eventBus.on('infinitetees:status', (status) => this.updateInfiniteTeesStatus(status));
 
+        // Awesome Golf events
+        this.eventBus.on('awesomegolf:connecting', () => {
+            this.toast.info('Awesome Golf connection initiated...');
+        });
+        this.eventBus.on('awesomegolf:disconnecting', () => {
+            this.toast.info('Awesome Golf disconnection initiated...');
+        });
+        this.eventBus.on('awesomegolf:error', (msg) => this.toast.error(`Awesome Golf: ${msg}`));
+        this.eventBus.on('awesomegolf:status', (status) => this.updateAwesomeGolfStatus(status));
+        this.eventBus.on('awesomegolf:config', (config) => this.applyAwesomeGolfConfig(config));
+        this.eventBus.on('simulator:error', (msg) => this.toast.error(`Simulator: ${msg}`));
+        this.eventBus.on('games:error', (msg) => this.toast.error(`Game: ${msg}`));
+
         // Alignment events
         this.eventBus.on('alignment:saved', () => {
             this.setAlignmentBusy(false);
@@ -137,6 +177,7 @@ export class SquareGolfApp {
             this.currentHandedness = handedness;
             this.updateHandednessDisplay(handedness);
         });
+        this.eventBus.on('alignment:profiles', (profiles) => this.updateAlignmentProfiles(profiles));
 
         // Screen navigation events
         this.eventBus.on('screen:before-change', ({ from, to }) => {
@@ -155,10 +196,20 @@ export class SquareGolfApp {
         // Settings events
         this.eventBus.on('settings:loaded', (settings) => this.applySettings(settings));
         this.eventBus.on('settings:error', (msg) => this.toast.error(`Failed to save settings: ${msg}`));
+        this.eventBus.on('settings:invalid', (fields) => {
+            this.invalidFields = fields.map(({ field }) => field);
+            this.invalidFields.forEach((field) => this.setFieldError(field));
+        });
 
         // Camera events
         this.eventBus.on('camera:saved', () => this.toast.success('Camera settings saved successfully'));
         this.eventBus.on('camera:error', (msg) => this.toast.error(`Failed to save camera config: ${msg}`));
+
+        // Shot export events
+        this.eventBus.on('export:saved', () => this.toast.success('Export settings saved'));
+        this.eventBus.on('export:error', (msg) => this.toast.error(`Shot export: ${msg}`));
+        this.eventBus.on('dashboard:saved', () => this.toast.success('Dashboard settings saved'));
+        this.eventBus.on('dashboard:error', (msg) => this.toast.error(`Dashboard: ${msg}`));
     }
 
     setupEventListeners() {
@@ -173,10 +224,35 @@ export class SquareGolfApp {
         this.bind('statusDevice', 'click', () => this.screen.show('device'));
         this.bind('statusGSPro', 'click', () => this.screen.show('gspro'));
         this.bind('statusInfiniteTees', 'click', () => this.screen.show('infiniteTees'));
+        this.bind('statusAwesomeGolf', 'click', () => this.screen.show('awesomeGolf'));
         this.bind('statusBallReady', 'click', () => this.screen.show('device'));
 
         // Alignment panel controls
         this.bind('calibrateBtn', 'click', () => this.openAlignmentPanel());
+        this.bind('standbyBtn', 'click', () => {
+            if (this.deviceService.getStatus()?.standby) {
+                this.deviceService.wake();
+            } else {
+                this.deviceService.standby();
+            }
+        });
+        this.bind('pauseBtn', 'click', () => {
+            if (this.deviceService.getStatus()?.paused) {
+                this.deviceService.resume();
+            } else {
+                this.deviceService.pause();
+            }
+        });
+        this.bind('swingStickBtn', 'click', () => {
+            this.deviceService.setSwingStick(!this.deviceService.getStatus()?.swingStick);
+        });
+        this.bind('pairBtn', 'click', () => {
+            if (this.deviceService.getStatus()?.pairedDevice) {
+                this.deviceService.unpair();
+            } else {
+                this.deviceService.pair();
+            }
+        });
         this.bind('closeAlignmentBtn', 'click', () => this.closeAlignmentPanel());
         this.bind('retryAlignmentBtn', 'click', () => this.retryAlignment());
 
@@ -208,6 +284,17 @@ export class SquareGolfApp {
         this.bind('gsproAutoConnect', 'change', () => this.saveGSProConfig());
         this.bind('gsproIP', 'input', () => this.clearFieldError('gsproIP'));
         this.bind('gsproPort', 'input', () => this.clearFieldError('gsproPort'));
+        this.bind('gsproDiscoverBtn', 'click', () => this.discoverGSPro());
+        this.bind('deviceScanBtn', 'click', () => this.scanDevices());
+        this.bind('updateCheckBtn', 'click', () => this.updateRequest('/api/v1/update/check', 'updateCheckBtn', 'Checking...'));
+        this.bind('updateInstallBtn', 'click', () => this.updateRequest('/api/v1/update/install', 'updateInstallBtn', 'Installing...'));
+        this.bind('updateRollbackBtn', 'click', () => this.updateRequest('/api/v1/update/rollback', 'updateRollbackBtn', 'Rolling back...'));
+        this.bind('gsproShotNumberResetBtn', 'click', () => this.gsproService.resetShotNumber());
+        this.bind('gsproResendBtn', 'click', () => this.previewGSProResend());
+        this.bind('gsproResendConfirmBtn', 'click', () => this.confirmGSProResend());
+        this.bind('gsproResendCancelBtn', 'click', () => this.hideGSProResend());
+        this.bind('gsproQueueReplayBtn', 'click', () => this.gsproService.replayQueue());
+        this.bind('gsproQueueDiscardBtn', 'click', () => this.gsproService.discardQueue());
 
         // Infinite Tees controls
         this.bind('infiniteTeesConnectBtn', 'click', () => {
@@ -227,9 +314,56 @@ export class SquareGolfApp {
         this.bind('infiniteTeesIP', 'input', () => this.clearFieldError('infiniteTeesIP'));
         this.bind('infiniteTeesPort', 'input', () => this.clearFieldError('infiniteTeesPort'));
 
+        // Awesome Golf controls
+        this.bind('awesomeGolfConnectBtn', 'click', () => {
+            const config = this.getConnectionConfig('awesomeGolf', true);
+            if (!config) return;
+            const { ip, port } = config;
+            this.awesomeGolfService.connect(ip, port);
+        });
+        this.bind('awesomeGolfDisconnectBtn', 'click', () => {
+            this.awesomeGolfService.disconnect();
+        });
+        this.bind('awesomeGolfIP', 'change', () => this.saveAwesomeGolfConfig());
+        this.bind('awesomeGolfPort', 'change', () => this.saveAwesomeGolfConfig());
+        this.bind('awesomeGolfAutoConnect', 'change', () => this.saveAwesomeGolfConfig());
+        this.bind('awesomeGolfIP', 'input', () => this.clearFieldError('awesomeGolfIP'));
+        this.bind('awesomeGolfPort', 'input', () => this.clearFieldError('awesomeGolfPort'));
+
         // Camera controls
         this.bind('cameraSaveBtn', 'click', () => this.cameraManager.save());
 
+        // Shot export controls
+        this.bind('exportDestination', 'change', () => this.exportPanel.showDestination());
+        this.bind('exportSaveBtn', 'click', () => this.exportPanel.save());
+        this.bind('exportRetryBtn', 'click', () => this.exportPanel.retry());
+        this.bind('dashboardSaveBtn', 'click', () => this.dashboardPanel.save());
+
+        // Target games
+        this.bind('gameStartBtn', 'click', () => this.gamesPanel.start());
+        this.bind('gameStopBtn', 'click', () => this.gamesPanel.stop());
+        this.bind('leaderboardMode', 'change', () => this.gamesPanel.loadLeaderboard());
+        this.bind('wedgeStartBtn', 'click', () => this.wedgePanel.start());
+        this.bind('wedgeStopBtn', 'click', () => this.wedgePanel.stop());
+        this.bind('wedgeProfile', 'change', () => this.wedgePanel.loadMatrix());
+        this.bind('combineStartBtn', 'click', () => this.combinePanel.start());
+        this.bind('combinePauseBtn', 'click', () => this.combinePanel.togglePause());
+        this.bind('combineStopBtn', 'click', () => this.combinePanel.stop());
+        this.bind('warmupStartBtn', 'click', () => this.warmupPanel.start());
+        this.bind('warmupStopBtn', 'click', () => this.warmupPanel.stop());
+
+        // Simulator test bench
+        this.bind('simManual', 'change', (e) => this.simulatorPanel.setManual(e.target.checked));
+        this.bind('simPlaceBallBtn', 'click', () => this.simulatorPanel.placeBall());
+        this.bind('simReadyBallBtn', 'click', () => this.simulatorPanel.readyBall());
+        this.bind('simRemoveBallBtn', 'click', () => this.simulatorPanel.removeBall());
+        this.bind('simRandomShotBtn', 'click', () => this.simulatorPanel.randomShot());
+        this.bind('simHitShotBtn', 'click', () => this.simulatorPanel.hitShot());
+        this.bind('simBatteryBtn', 'click', () => this.simulatorPanel.setBattery());
+        this.bind('simDisconnectBtn', 'click', () => this.simulatorPanel.disconnect());
+        this.bind('simSeriesStartBtn', 'click', () => this.simulatorPanel.startSeries());
+        this.bind('simSeriesCancelBtn', 'click', () => this.simulatorPanel.cancelSeries());
+
         // Alignment controls
         this.bind('leftHandedBtn', 'click', () => this.handleHandednessChange('left'));
         this.bind('rightHandedBtn', 'click', () => this.handleHandednessChange('right'));
@@ -250,6 +384,76 @@ export class SquareGolfApp {
         this.bind('omniDistanceUnit', 'change', () => this.saveSettings());
         this.bind('omniGreenSpeed', 'change', () => this.saveSettings());
         this.bind('omniCarryAdjustment', 'change', () => this.saveSettings());
+        this.bind('chimeEnabled', 'change', () => this.saveSettings());
+        this.bind('chimeOutput', 'change', () => this.saveSettings());
+        this.bind('chimeVolume', 'change', () => this.saveSettings());
+        this.bind('misreadPrompt', 'change', () => this.saveSettings());
+        this.bind('shotRawData', 'change', () => this.saveSettings());
+        this.bind('spinEstimation', 'change', () => this.saveSettings());
+        this.bind('clubSpeedEstimation', 'change', () => this.saveSettings());
+        this.bind('locale', 'change', () => this.saveSettings());
+        ['numberSpeedUnit', 'numberDistanceUnit', 'numberDecimalSeparator'].forEach((id) => {
+            this.bind(id, 'change', () => this.saveSettings());
+        });
+        this.bind('deviceAddress', 'change', () => this.saveSettings());
+        ['logMaxSizeMB', 'logMaxBackups', 'logMaxAgeDays', 'logDaily', 'logCompress'].forEach((id) => {
+            this.bind(id, 'change', () => this.saveSettings());
+        });
+        this.bind('logViewerLevel', 'change', (event) => this.streamLogs(event.target.value));
+        this.bind('runDiagnosticsBtn', 'click', () => this.runDiagnostics());
+        this.bind('copyDiagnosticsBtn', 'click', () => this.copyDiagnostics());
+        this.bind('environmentMode', 'change', () => this.saveSettings());
+        this.bind('environmentAltitude', 'change', () => this.saveSettings());
+        this.bind('environmentTemperature', 'change', () => this.saveSettings());
+        this.bind('alignmentReadings', 'change', () => this.saveSettings());
+        this.bind('alignmentUpdateRate', 'change', () => this.saveSettings());
+        this.bind('idleEnabled', 'change', () => this.saveSettings());
+        this.bind('idleMinutes', 'change', () => this.saveSettings());
+        this.bind('shotCooldownMs', 'change', () => this.saveSettings());
+        ['sleepScheduleEnabled', 'sleepScheduleSleep', 'sleepScheduleWake', 'heartbeatInterval', 'heartbeatDeviceTimeout'].forEach((id) => {
+            this.bind(id, 'change', () => this.saveSettings());
+        });
+
+        // Any interaction with the page wakes an idle device
+        ['pointerdown', 'keydown'].forEach((type) => {
+            document.addEventListener(type, () => this.wakeIfIdle(), { passive: true });
+        });
+        this.bind('gsproSpinConvention', 'change', () => this.saveSettings());
+        this.bind('gsproStandbyIP', 'change', () => this.saveSettings());
+        this.bind('gsproStandbyPort', 'change', () => this.saveSettings());
+        this.bind('gsproTrafficLog', 'change', () => this.saveSettings());
+        this.bind('featureExternalCamera', 'change', (e) => this.setFeature('externalCamera', e.target.checked));
+        this.bind('featureConnectServer', 'change', (e) => this.setFeature('connectServer', e.target.checked));
+        this.bind('featureGames', 'change', (e) => this.setFeature('games', e.target.checked));
+        this.bind('featureRawCommands', 'change', (e) => this.setFeature('rawCommands', e.target.checked));
+        this.bind('featureDashboard', 'change', (e) => this.setFeature('dashboard', e.target.checked));
+        this.bind('rawCommandSendBtn', 'click', () => this.rawCommandConsole.send());
+        this.bind('rawCommandClearBtn', 'click', () => this.rawCommandConsole.clear());
+        this.bind('rawCommandInput', 'keydown', (e) => {
+            if (e.key === 'Enter') this.rawCommandConsole.send();
+        });
+        ['gsproDeviceID', 'gsproUnits', 'gsproAPIVersion', 'gsproIncludeFirmware', 'gsproShotNumberPolicy', 'gsproRetryQueueDepth', 'gsproRetryQueueConfirm'].forEach((id) => {
+            this.bind(id, 'change', () => this.saveSettings());
+        });
+        this.bind('infiniteTeesSpinConvention', 'change', () => this.saveSettings());
+        this.bind('awesomeGolfSpinConvention', 'change', () => this.saveSettings());
+
+        // Alignment format capture
+        this.bind('alignmentCaptureStartBtn', 'click', () => this.alignmentCaptureRequest('/api/v1/alignment/capture/start'));
+        this.bind('alignmentCaptureNextBtn', 'click', () => this.alignmentCaptureRequest('/api/v1/alignment/capture/next'));
+        this.bind('alignmentCaptureFinishBtn', 'click', () => this.alignmentCaptureRequest('/api/v1/alignment/capture/finish'));
+
+        // Mat calibration wizard
+        this.bind('calibrationOriginBtn', 'click', () => this.calibrationRequest('/api/v1/calibration/sample', { point: 'origin' }));
+        this.bind('calibrationTargetBtn', 'click', () => this.calibrationRequest('/api/v1/calibration/sample', { point: 'target' }));
+        this.bind('calibrationFinishBtn', 'click', () => this.calibrationRequest('/api/v1/calibration/finish'));
+        this.bind('calibrationResetBtn', 'click', () => this.calibrationRequest('/api/v1/calibration/reset'));
+        this.bind('misreadList', 'click', (event) => {
+            const button = event.target.closest('[data-misread-action]');
+            if (button) {
+                this.resolveMisread(button.dataset.misreadId, button.dataset.misreadAction);
+            }
+        });
     }
 
     async handleHandednessChange(handedness) {
@@ -268,15 +472,65 @@ export class SquareGolfApp {
             case 'deviceStatus':
                 this.deviceService.updateStatus(message.data);
                 break;
+            case 'deviceStatusDelta':
+                this.deviceService.applyStatusDelta(message.data);
+                break;
             case 'gsproStatus':
                 this.gsproService.updateStatus(message.data);
                 break;
             case 'infiniteTeesStatus':
                 this.infiniteTeesService.updateStatus(message.data);
                 break;
+            case 'awesomeGolfStatus':
+                this.awesomeGolfService.updateStatus(message.data);
+                break;
             case 'cameraConfig':
                 this.cameraManager.updateConfig(message.data);
                 break;
+            case 'features':
+                this.features = message.data || {};
+                this.applyFeatures();
+                break;
+            case 'settings':
+                this.settingsManager.receive(message.data);
+                break;
+            case 'chime':
+                this.playChime(message.data?.volume ?? 80);
+                break;
+            case 'placement':
+                this.updatePlacementHint(message.data);
+                break;
+            case 'misreads':
+                this.renderMisreads(message.data || []);
+                break;
+            case 'shotVideos':
+                this.renderShotVideos(message.data);
+                break;
+            case 'updateStatus':
+                this.renderUpdateStatus(message.data);
+                break;
+            case 'game':
+                this.gamesPanel.render(message.data);
+                break;
+            case 'wedgePractice':
+                this.wedgePanel.render(message.data);
+                break;
+            case 'combine':
+                this.combinePanel.render(message.data);
+                break;
+            case 'warmup':
+                this.warmupPanel.render(message.data);
+                break;
+            case 'staleConnectionRecovered':
+                this.toast.warning(`${message.data.simulator} stopped answering and was reconnected. ` +
+                    'If your last shot is missing, use Resend Last Shot in the GSPro settings.');
+                break;
+            case 'exportStatus':
+                this.exportPanel.renderStatus(message.data);
+                break;
+            case 'dashboard':
+                this.dashboardPanel.render(message.data);
+                break;
             case 'alignmentData':
                 if (message.data) {
                     this.alignmentManager.updateDisplay(
@@ -290,6 +544,247 @@ export class SquareGolfApp {
         }
     }
 
+    playChime(volume) {
+        const AudioContextClass = window.AudioContext || window.webkitAudioContext;
+        if (!AudioContextClass) return;
+
+        this.audioContext = this.audioContext || new AudioContextClass();
+        const ctx = this.audioContext;
+        const gain = ctx.createGain();
+        gain.connect(ctx.destination);
+        gain.gain.setValueAtTime(0.0001, ctx.currentTime);
+        gain.gain.exponentialRampToValueAtTime(Math.max(volume / 100, 0.0001) * 0.5, ctx.currentTime + 0.02);
+        gain.gain.exponentialRampToValueAtTime(0.0001, ctx.currentTime + 0.8);
+
+        [880, 1320].forEach((frequency, index) => {
+            const osc = ctx.createOscillator();
+            osc.type = 'sine';
+            osc.frequency.value = frequency;
+            osc.connect(gain);
+            osc.start(ctx.currentTime + index * 0.12);
+            osc.stop(ctx.currentTime + 0.8);
+        });
+    }
+
+    updatePlacementHint(guidance) {
+        const element = this.$('placementHint');
+        if (!element) return;
+
+        const labels = {
+            left: 'Move ball left',
+            right: 'Move ball right',
+            forward: 'Move ball forward',
+            back: 'Move ball back'
+        };
+
+        this.setHidden(element, !guidance?.ballDetected);
+        if (!guidance?.ballDetected) return;
+
+        element.classList.toggle('in-zone', guidance.inZone);
+        element.textContent = guidance.inZone
+            ? 'Ball is in the detection zone'
+            : guidance.hints.map((hint) => labels[hint] || hint).join(', ');
+    }
+
+    renderMisreads(shots) {
+        const list = this.$('misreadList');
+        if (!list) return;
+
+        list.replaceChildren();
+        shots.forEach((shot) => {
+            const item = document.createElement('div');
+            item.className = 'misread-item';
+
+            const label = document.createElement('span');
+            const speed = shot.ballMetrics?.speed ? `${(shot.ballMetrics.speed * 2.23694).toFixed(1)} mph` : 'no ball speed';
+            label.textContent = `Possible misread (${shot.reason}, ${speed})`;
+            item.appendChild(label);
+
+            const actions = document.createElement('div');
+            actions.className = 'misread-actions';
+            [
+                ['discard', 'Discard'],
+                ['send', 'Send As-Is'],
+                ['estimate', 'Send With Estimated Spin']
+            ].forEach(([action, text]) => {
+                const button = document.createElement('button');
+                button.className = action === 'discard' ? 'btn btn-secondary' : 'btn btn-primary';
+                button.textContent = text;
+                button.dataset.misreadId = shot.id;
+                button.dataset.misreadAction = action;
+                actions.appendChild(button);
+            });
+            item.appendChild(actions);
+            list.appendChild(item);
+        });
+        this.setHidden(list, shots.length === 0);
+    }
+
+    renderShotVideos(shot) {
+        const container = this.$('shotVideos');
+        if (!container || !shot) return;
+
+        container.replaceChildren();
+        const label = document.createElement('span');
+        label.textContent = `Shot ${shot.id} video:`;
+        container.appendChild(label);
+
+        (shot.videos || []).forEach((video) => {
+            if (video.path) {
+                const link = document.createElement('a');
+                link.href = `/api/v1/shots/${shot.id}/videos/${encodeURIComponent(video.camera)}`;
+                link.target = '_blank';
+                link.rel = 'noopener';
+                link.textContent = video.camera;
+                container.appendChild(link);
+            } else {
+                const name = document.createElement('span');
+                name.textContent = `${video.camera} (${video.filename} on camera)`;
+                container.appendChild(name);
+            }
+        });
+        this.setHidden(container, !shot.videos || shot.videos.length === 0);
+    }
+
+    // Tails the application log into the log viewer; an empty level stops it
+    streamLogs(level) {
+        const viewer = this.$('logViewer');
+        if (this.logStream) {
+            this.logStream.close();
+            this.logStream = null;
+        }
+        this.setHidden(viewer, !level);
+        if (!level || !viewer) return;
+
+        viewer.textContent = '';
+        this.logStream = new EventSource(`/api/v1/logs/stream?level=${encodeURIComponent(level)}`);
+        this.logStream.onmessage = (event) => {
+            const line = JSON.parse(event.data);
+            const row = document.createElement('div');
+            row.className = `log-${line.level}`;
+            row.textContent = `${new Date(line.time).toLocaleTimeString()} ${line.level.toUpperCase()} ${line.message.trimEnd()}`;
+            const atBottom = viewer.scrollTop + viewer.clientHeight >= viewer.scrollHeight - 4;
+            viewer.appendChild(row);
+            while (viewer.childElementCount > 500) {
+                viewer.firstElementChild.remove();
+            }
+            if (atBottom) viewer.scrollTop = viewer.scrollHeight;
+        };
+    }
+
+    // Runs the connection checks and shows the report as text to paste into
+    // an issue. Connecting to the launch monitor can take up to 30 seconds.
+    async runDiagnostics() {
+        const button = this.$('runDiagnosticsBtn');
+        const report = this.$('diagnosticsReport');
+        if (button) button.disabled = true;
+        this.toast.info('Running diagnostics...');
+        try {
+            const response = await this.api.post('/api/v1/diagnostics/run?format=text');
+            const text = await response.text();
+            if (!response.ok) {
+                this.toast.error(text.trim() || 'Failed to run diagnostics');
+                return;
+            }
+            if (report) report.textContent = text;
+            this.setHidden(report, false);
+            this.setHidden(this.$('copyDiagnosticsBtn'), false);
+            if (text.includes('[FAIL]')) {
+                this.toast.warning('Diagnostics found a problem');
+            } else {
+                this.toast.success('All diagnostics passed');
+            }
+        } catch (error) {
+            this.toast.error('Failed to run diagnostics');
+        } finally {
+            if (button) button.disabled = false;
+        }
+    }
+
+    async copyDiagnostics() {
+        const text = this.$('diagnosticsReport')?.textContent;
+        if (!text) return;
+        try {
+            await navigator.clipboard.writeText(text);
+            this.toast.success('Report copied');
+        } catch {
+            this.toast.error('Failed to copy the report');
+        }
+    }
+
+    wakeIfIdle() {
+        if (!this.deviceService.getStatus()?.idle) return;
+        const now = Date.now();
+        if (this.lastWakeRequest && now - this.lastWakeRequest < 5000) return;
+        this.lastWakeRequest = now;
+        this.deviceService.wake();
+    }
+
+    async resolveMisread(id, action) {
+        try {
+            const response = await this.api.post(`/api/v1/shots/misreads/${id}`, { action });
+            if (!response.ok) {
+                throw new Error(await response.text());
+            }
+        } catch (error) {
+            this.toast.error(`Failed to resolve misread: ${error.message}`);
+        }
+    }
+
+    async alignmentCaptureRequest(url) {
+        const status = this.$('alignmentCaptureStatus');
+        try {
+            const response = await this.api.post(url);
+            if (!response.ok) {
+                throw new Error(await response.text());
+            }
+            const result = await response.json();
+
+            if (result.format) {
+                if (status) status.textContent = `Alignment format saved (fit error ${result.rmsError.toFixed(2)}°).`;
+                this.updateAlignmentCaptureButtons(false, false);
+                return;
+            }
+
+            if (status) {
+                status.textContent = result.promptAngle !== null
+                    ? `Step ${result.step} of ${result.steps}: aim the device ${result.promptAngle}° and hold still (${result.packets} readings).`
+                    : 'All angles captured. Fit the format to finish.';
+            }
+            this.updateAlignmentCaptureButtons(result.active, result.promptAngle !== null);
+        } catch (error) {
+            this.toast.error(`Alignment capture failed: ${error.message}`);
+        }
+    }
+
+    updateAlignmentCaptureButtons(active, hasPrompt) {
+        this.setHidden(this.$('alignmentCaptureStartBtn'), active);
+        this.setHidden(this.$('alignmentCaptureNextBtn'), !active || !hasPrompt);
+        this.setHidden(this.$('alignmentCaptureFinishBtn'), !active);
+    }
+
+    async calibrationRequest(url, body = null) {
+        try {
+            const response = await this.api.post(url, body);
+            if (!response.ok) {
+                throw new Error(await response.text());
+            }
+            this.updateCalibrationStatus(await response.json());
+        } catch (error) {
+            this.toast.error(`Calibration failed: ${error.message}`);
+        }
+    }
+
+    updateCalibrationStatus(status) {
+        const element = this.$('calibrationStatus');
+        if (!element || !status) return;
+
+        const { calibration, samples } = status;
+        element.textContent = `Samples: ${samples.origin} hitting spot, ${samples.target} target line. ` +
+            `Offset (${calibration.offsetX.toFixed(0)}, ${calibration.offsetY.toFixed(0)}), ` +
+            `rotation ${calibration.rotationDeg.toFixed(1)}°`;
+    }
+
     updateConnectionIndicator(connected) {
         this.updateBinaryIndicator('statusWebSocket', connected);
     }
@@ -366,9 +861,25 @@ export class SquareGolfApp {
         }
     }
 
+    renderSwingStickStatus(status) {
+        const element = this.$('swingStickStatus');
+        if (!element) return;
+        this.setHidden(element, !status.swingStick);
+        if (!status.swingStick) return;
+
+        const swing = status.lastSwingMetrics;
+        if (!swing) {
+            element.textContent = 'Swing stick training: take a swing. Swings are not sent to the simulator.';
+            return;
+        }
+        const speed = (swing.clubSpeed * 2.23694).toFixed(1);
+        element.textContent = `Last swing: ${speed} mph, path ${swing.path.toFixed(1)}°, face ${swing.angle.toFixed(1)}°`;
+    }
+
     updateDeviceControls({ canConnect, canDisconnect, showCalibrate, showDeviceInfo, errorMessage = '' }) {
         const btn = this.$('connectDisconnectBtn');
         const calibrateBtn = this.$('calibrateBtn');
+        const standbyBtn = this.$('standbyBtn');
         const deviceDetailsInline = this.$('deviceDetailsInline');
         const deviceHeaderSeparator = this.$('deviceHeaderSeparator');
         const batteryInline = this.$('batteryInline');
@@ -382,6 +893,9 @@ export class SquareGolfApp {
         }
 
         this.setHidden(calibrateBtn, !showCalibrate);
+        this.setHidden(standbyBtn, !showCalibrate);
+        this.setHidden(this.$('pauseBtn'), !showCalibrate);
+        this.setHidden(this.$('swingStickBtn'), !showCalibrate);
         this.setHidden(deviceDetailsInline, !showDeviceInfo);
         this.setHidden(deviceHeaderSeparator, !showDeviceInfo);
         this.setHidden(batteryInline, !showDeviceInfo);
@@ -519,6 +1033,26 @@ export class SquareGolfApp {
         batteryElement.textContent = level <= 5 ? 'Low' : `${level}%`;
     }
 
+    // Shows the time left on battery, and the drain rate it comes from on hover
+    updateBatteryEstimate(estimate) {
+        const remaining = this.$('batteryRemaining');
+        const inline = this.$('batteryInline');
+        if (!remaining) return;
+
+        const minutes = estimate?.minutesRemaining;
+        if (typeof minutes !== 'number') {
+            remaining.textContent = '';
+            if (inline) inline.title = estimate?.pluggedIn ? 'Plugged in' : 'Estimating time remaining';
+            return;
+        }
+        const hours = Math.floor(minutes / 60);
+        remaining.textContent = hours > 0 ? `~${hours}h ${minutes % 60}m` : `~${minutes}m`;
+        if (inline) {
+            const source = estimate.calibrated ? 'earlier sessions' : 'this session';
+            inline.title = `Using ${estimate.drainPerHour.toFixed(1)}% per hour, from ${source}`;
+        }
+    }
+
     updateCapacitorDisplay(ready, connectionStatus) {
         const el = this.$('capacitorStatus');
         if (!el) return;
@@ -569,6 +1103,38 @@ export class SquareGolfApp {
         this.updateDeviceConnectionIndicator(status.connectionStatus);
         this.updateDeviceHeaderStatus(status);
 
+        const standbyBtn = this.$('standbyBtn');
+        if (standbyBtn) {
+            standbyBtn.title = status.standby ? 'Wake' : 'Standby';
+            const icon = standbyBtn.querySelector('.material-icons');
+            if (icon) icon.textContent = status.standby ? 'wb_sunny' : 'bedtime';
+        }
+
+        const pauseBtn = this.$('pauseBtn');
+        if (pauseBtn) {
+            pauseBtn.title = status.paused ? 'Resume Session' : 'Pause Session';
+            pauseBtn.classList.toggle('active', Boolean(status.paused));
+            const icon = pauseBtn.querySelector('.material-icons');
+            if (icon) icon.textContent = status.paused ? 'play_circle' : 'pause_circle';
+        }
+
+        const swingStickBtn = this.$('swingStickBtn');
+        if (swingStickBtn) {
+            swingStickBtn.title = status.swingStick ? 'Leave Swing Stick Training' : 'Swing Stick Training';
+            swingStickBtn.classList.toggle('active', Boolean(status.swingStick));
+        }
+        this.renderSwingStickStatus(status);
+        this.setHidden(this.$('shotCooldownItem'), !status.shotCooldown);
+
+        const pairBtn = this.$('pairBtn');
+        if (pairBtn) {
+            const paired = Boolean(status.pairedDevice);
+            pairBtn.title = paired ? `Unpair ${status.pairedDevice.name || status.pairedDevice.address}` : 'Pair';
+            const icon = pairBtn.querySelector('.material-icons');
+            if (icon) icon.textContent = paired ? 'link_off' : 'link';
+            this.setHidden(pairBtn, !status.pairingSupported || (!paired && status.connectionStatus !== 'connected'));
+        }
+
         switch (status.connectionStatus) {
             case 'connected':
                 this.updateDeviceControls({
@@ -617,6 +1183,7 @@ export class SquareGolfApp {
         }
 
         this.updateBatteryDisplay(status.batteryLevel, status.batteryCharging);
+        this.updateBatteryEstimate(status.battery);
         this.updateCapacitorDisplay(status.capacitorReady, status.connectionStatus);
         this.updateVersionDisplay(status);
         this.updateOptionalDeviceInfo({
@@ -697,6 +1264,17 @@ export class SquareGolfApp {
 
         this.setAlignmentBusy(true);
         this.alignmentManager.start();
+        this.alignmentManager.loadProfiles();
+    }
+
+    // updateAlignmentProfiles shows the aim that is re-applied when the
+    // player switches handedness
+    updateAlignmentProfiles(profiles) {
+        const text = this.$('alignmentProfiles');
+        if (!text) return;
+        const describe = (angle) => (angle === undefined || angle === null ? 'not saved' : `${angle.toFixed(1)}°`);
+        text.textContent = `Saved aim: right handed ${describe(profiles.right)}, left handed ${describe(profiles.left)}. ` +
+            'The saved aim is used again when the player switches.';
     }
 
     closeAlignmentPanel() {
@@ -733,7 +1311,11 @@ export class SquareGolfApp {
                 stateClass: 'connected',
                 icon: 'bluetooth_connected',
                 text: 'Connected',
-                hint: status.deviceName ? `Ready • ${status.deviceName}` : 'Device ready'
+                hint: status.standby
+                    ? 'Standby'
+                    : status.idle
+                        ? 'Idle • click to wake'
+                        : (status.deviceName ? `Ready • ${status.deviceName}` : 'Device ready')
             },
             scanning: {
                 stateClass: 'connecting',
@@ -812,6 +1394,76 @@ export class SquareGolfApp {
             ipFieldId: 'gsproIP',
             portFieldId: 'gsproPort'
         });
+
+        const failover = this.$('gsproFailover');
+        const standby = (status.endpoints || []).find((endpoint) => endpoint.role === 'standby');
+        if (failover) {
+            failover.classList.toggle('hidden', !status.failedOver || !standby);
+            failover.textContent = standby ? `Primary unreachable, using standby GSPro at ${standby.ip}:${standby.port}` : '';
+        }
+
+        // Multiplayer rounds name the player who is up
+        const player = this.$('gsproPlayer');
+        if (player) {
+            const players = status.players || [];
+            const current = players.find((entry) => entry.name === status.player);
+            player.classList.toggle('hidden', !status.player);
+            player.textContent = status.player
+                ? `Player up: ${status.player}${current?.handed ? ` (${current.handed})` : ''}, ${players.length} player${players.length === 1 ? '' : 's'} this round`
+                : '';
+        }
+
+        // Shots hit while GSPro was reconnecting
+        const queue = this.$('gsproQueue');
+        if (queue) {
+            const queued = status.queuedShots || 0;
+            queue.classList.toggle('hidden', queued === 0);
+            this.$('gsproQueueSummary').textContent = `${queued} shot${queued === 1 ? '' : 's'} hit while GSPro was disconnected ` +
+                (status.awaitingReplay ? 'waiting to be sent.' : 'will be sent as GSPro asks for the next shot.');
+            this.$('gsproQueueReplayBtn').disabled = !status.awaitingReplay || status.connectionStatus !== 'connected';
+        }
+    }
+
+    // previewGSProResend shows the last shot sent to GSPro so the player can
+    // check it is the one GSPro missed before sending it again
+    async previewGSProResend() {
+        const shot = await this.gsproService.loadLastShot();
+        const preview = this.$('gsproResendPreview');
+        if (!preview) return;
+        if (!shot) {
+            this.toast.info('No shot has been sent to GSPro yet');
+            this.hideGSProResend();
+            return;
+        }
+
+        const ball = shot.ballData;
+        const sentAt = new Date(shot.sentAt).toLocaleTimeString();
+        preview.textContent = `Shot ${shot.shotNumber} at ${sentAt}: ${ball.Speed.toFixed(1)} mph, ` +
+            `${ball.VLA.toFixed(1)}° launch, ${ball.TotalSpin} rpm` +
+            (shot.clubData ? `, ${shot.clubData.Speed.toFixed(1)} mph club speed` : '') +
+            (shot.resent ? '. Already resent.' : '. Send it again as a new shot?');
+        preview.dataset.token = shot.token;
+        this.$('gsproResendConfirmBtn').disabled = shot.resent;
+        this.$('gsproResendConfirm')?.classList.remove('hidden');
+    }
+
+    async confirmGSProResend() {
+        const token = this.$('gsproResendPreview')?.dataset.token;
+        if (!token) return;
+        const button = this.$('gsproResendConfirmBtn');
+        button.disabled = true;
+        const result = await this.gsproService.resendLastShot(token);
+        if (result.success) {
+            this.hideGSProResend();
+        } else {
+            button.disabled = false;
+        }
+    }
+
+    hideGSProResend() {
+        this.$('gsproResendConfirm')?.classList.add('hidden');
+        const preview = this.$('gsproResendPreview');
+        if (preview) delete preview.dataset.token;
     }
 
     async saveGSProConfig() {
@@ -824,6 +1476,110 @@ export class SquareGolfApp {
         await this.gsproService.saveConfig(ip, port, autoConnect);
     }
 
+    async discoverGSPro() {
+        const button = this.$('gsproDiscoverBtn');
+        const results = this.$('gsproDiscoverResults');
+        if (!button || !results) return;
+
+        const port = parseInt(this.$('gsproPort')?.value || '921', 10) || 921;
+        button.disabled = true;
+        button.textContent = 'Searching...';
+        const candidates = await this.gsproService.discover(port);
+        button.disabled = false;
+        button.textContent = 'Find GSPro on this network';
+
+        results.replaceChildren();
+        results.classList.remove('hidden');
+        if (candidates.length === 0) {
+            results.textContent = `No GSPro found on port ${port}`;
+            return;
+        }
+        candidates.forEach((candidate) => {
+            const choice = document.createElement('button');
+            choice.className = 'btn btn-secondary';
+            choice.textContent = candidate.local ? `${candidate.ip} (this computer)` : candidate.ip;
+            choice.addEventListener('click', () => {
+                this.$('gsproIP').value = candidate.ip;
+                this.$('gsproPort').value = candidate.port;
+                results.classList.add('hidden');
+                this.saveGSProConfig();
+            });
+            results.appendChild(choice);
+        });
+    }
+
+    async scanDevices() {
+        const button = this.$('deviceScanBtn');
+        const results = this.$('deviceScanResults');
+        if (!button || !results) return;
+
+        button.disabled = true;
+        button.textContent = 'Scanning...';
+        const devices = await this.deviceService.scan();
+        button.disabled = false;
+        button.textContent = 'Find Devices';
+
+        results.replaceChildren();
+        results.classList.remove('hidden');
+        if (devices.length === 0) {
+            results.textContent = 'No devices found';
+            return;
+        }
+        this.loadKnownDevices();
+        devices.forEach((device) => {
+            const choice = document.createElement('button');
+            choice.className = 'btn btn-secondary';
+            choice.textContent = this.describeDevice(device);
+            choice.disabled = !device.address;
+            choice.addEventListener('click', () => {
+                this.$('deviceAddress').value = device.address;
+                results.classList.add('hidden');
+                this.saveSettings();
+            });
+            results.appendChild(choice);
+        });
+    }
+
+    describeDevice(device) {
+        const name = device.nickname ? `${device.nickname} (${device.name})` : device.name;
+        return device.address ? `${name} [${device.address}]` : name;
+    }
+
+    // Lists the devices seen before so they can be nicknamed and connected
+    // to by nickname
+    async loadKnownDevices() {
+        const list = this.$('knownDevices');
+        if (!list) return;
+
+        const devices = await this.deviceService.knownDevices();
+        this.setHidden(this.$('knownDevicesGroup'), devices.length === 0);
+        list.replaceChildren(...devices.map((device) => {
+            const row = document.createElement('li');
+            const label = document.createElement('span');
+            label.textContent = `${device.name} [${device.address}] `;
+
+            const nickname = document.createElement('input');
+            nickname.type = 'text';
+            nickname.className = 'input-field';
+            nickname.placeholder = 'Nickname';
+            nickname.maxLength = 40;
+            nickname.value = device.nickname || '';
+            nickname.addEventListener('change', async () => {
+                await this.deviceService.setNickname(device.address, nickname.value.trim());
+                this.loadKnownDevices();
+            });
+
+            const connect = document.createElement('button');
+            connect.className = 'btn btn-secondary';
+            connect.textContent = 'Connect';
+            connect.disabled = !device.nickname;
+            connect.addEventListener('click', () => this.deviceService.connectByNickname(device.nickname));
+
+            row.append(label, nickname, connect);
+            return row;
+        }));
+    }
+
     updateInfiniteTeesStatus(status) {
         this.updateGlobalConnectionIndicator('statusInfiniteTees', status.connectionStatus);
         this.updateConnectionPanel({
@@ -847,6 +1603,38 @@ export class SquareGolfApp {
         await this.infiniteTeesService.saveConfig(ip, port, autoConnect);
     }
 
+    updateAwesomeGolfStatus(status) {
+        this.updateGlobalConnectionIndicator('statusAwesomeGolf', status.connectionStatus);
+        this.updateConnectionPanel({
+            status,
+            statusElementId: 'awesomeGolfStatus',
+            errorElementId: 'awesomeGolfError',
+            connectBtnId: 'awesomeGolfConnectBtn',
+            disconnectBtnId: 'awesomeGolfDisconnectBtn',
+            ipFieldId: 'awesomeGolfIP',
+            portFieldId: 'awesomeGolfPort'
+        });
+    }
+
+    applyAwesomeGolfConfig(config) {
+        const ip = this.$('awesomeGolfIP');
+        const port = this.$('awesomeGolfPort');
+        const autoConnect = this.$('awesomeGolfAutoConnect');
+        if (ip) ip.value = config.ip || '127.0.0.1';
+        if (port) port.value = config.port || 921;
+        if (autoConnect) autoConnect.checked = config.autoConnect || false;
+    }
+
+    async saveAwesomeGolfConfig() {
+        const config = this.getConnectionConfig('awesomeGolf', false);
+        if (!config) return;
+
+        const { ip, port } = config;
+        const autoConnect = this.$('awesomeGolfAutoConnect')?.checked;
+
+        await this.awesomeGolfService.saveConfig(ip, port, autoConnect);
+    }
+
     getConnectionConfig(prefix, notifyOnError) {
         const ipField = this.$(`${prefix}IP`);
         const portField = this.$(`${prefix}Port`);
@@ -968,9 +1756,77 @@ export class SquareGolfApp {
         }
     }
 
+    async loadVersion() {
+        try {
+            const response = await this.api.get('/api/v1/version');
+            if (!response.ok) return;
+            const info = await response.json();
+            const versionInfo = this.$('versionInfo');
+            if (versionInfo) {
+                versionInfo.textContent = `Version ${info.version}`;
+                versionInfo.title = info.build;
+            }
+            this.setHidden(this.$('updateControls'), !info.update);
+            if (info.update) this.renderUpdateStatus(info.update);
+        } catch (error) {
+            console.error('Failed to load version:', error);
+        }
+    }
+
+    renderUpdateStatus(status) {
+        const info = this.$('updateInfo');
+        if (!info || !status) return;
+
+        info.replaceChildren();
+        if (status.installed) {
+            info.textContent = `Version ${status.installed} is installed. Restart the connector to use it.`;
+        } else if (status.available) {
+            info.append(`Version ${status.latest} is available. `);
+            if (status.releaseUrl) {
+                const link = document.createElement('a');
+                link.href = status.releaseUrl;
+                link.target = '_blank';
+                link.className = 'inline-link';
+                link.textContent = 'View release';
+                info.append(link);
+            }
+        } else if (status.error) {
+            info.textContent = `Could not check for updates: ${status.error}`;
+        } else if (status.checkedAt) {
+            info.textContent = 'You are running the latest version.';
+        }
+        this.setHidden(info, info.childNodes.length === 0);
+        this.setHidden(this.$('updateControls'), false);
+        this.setHidden(this.$('updateInstallBtn'), !status.installable);
+        this.setHidden(this.$('updateRollbackBtn'), !status.canRollback);
+    }
+
+    async updateRequest(url, buttonId, busyLabel) {
+        const button = this.$(buttonId);
+        const label = button?.textContent;
+        if (button) {
+            button.disabled = true;
+            button.textContent = busyLabel;
+        }
+        try {
+            const response = await this.api.post(url);
+            if (!response.ok) {
+                throw new Error(await response.text());
+            }
+            this.renderUpdateStatus(await response.json());
+        } catch (error) {
+            this.toast.error(`Update failed: ${error.message}`);
+        } finally {
+            if (button) {
+                button.disabled = false;
+                button.textContent = label;
+            }
+        }
+    }
+
     async loadFeatures() {
         try {
-            const response = await this.api.get('/api/features');
+            const response = await this.api.get('/api/v1/features');
             if (response.ok) {
                 this.features = await response.json();
                 this.applyFeatures();
@@ -980,10 +1836,46 @@ export class SquareGolfApp {
         }
     }
 
+    async setFeature(feature, enabled) {
+        try {
+            const response = await this.api.post('/api/v1/features', { [feature]: enabled });
+            if (!response.ok) {
+                throw new Error(await response.text());
+            }
+            this.features = await response.json();
+        } catch (error) {
+            this.toast.error(`Failed to change feature: ${error.message}`);
+        }
+        this.applyFeatures();
+    }
+
     applyFeatures() {
+        const featureToggles = {
+            featureExternalCamera: 'externalCamera',
+            featureConnectServer: 'connectServer',
+            featureGames: 'games',
+            featureRawCommands: 'rawCommands',
+            featureDashboard: 'dashboard',
+        };
+        Object.entries(featureToggles).forEach(([id, feature]) => {
+            const toggle = this.$(id);
+            if (toggle) toggle.checked = Boolean(this.features[feature]);
+        });
+
+        const gamesSupported = Boolean(this.features.games);
+        this.setHidden(document.querySelector('.nav-button[data-screen="games"]'), !gamesSupported);
+        this.setHidden(this.$('rawCommandCard'), !this.features.rawCommands);
+
+        const dashboardNav = document.querySelector('.nav-button[data-screen="dashboard"]');
+        const dashboardShown = !dashboardNav?.classList.contains('hidden');
+        this.setHidden(dashboardNav, !this.features.dashboard);
+        if (this.features.dashboard && !dashboardShown) this.dashboardPanel.load();
+
+
         const cameraCard = this.$('cameraSettingsCard');
         const cameraSaveBtn = this.$('cameraSaveBtn');
         const cameraURL = this.$('cameraURL');
+        const cameraDTLURL = this.$('cameraDTLURL');
         const cameraEnabled = this.$('cameraEnabled');
         const cameraSupported = Boolean(this.features.externalCamera);
 
@@ -991,7 +1883,13 @@ export class SquareGolfApp {
 
         if (cameraSaveBtn) cameraSaveBtn.disabled = !cameraSupported;
         if (cameraURL) cameraURL.disabled = !cameraSupported;
+        if (cameraDTLURL) cameraDTLURL.disabled = !cameraSupported;
         if (cameraEnabled) cameraEnabled.disabled = !cameraSupported;
+
+        const simulatorSupported = Boolean(this.features.simulator);
+        const simulatorShown = !this.$('simulatorCard')?.classList.contains('hidden');
+        this.setHidden(this.$('simulatorCard'), !simulatorSupported);
+        if (simulatorSupported && !simulatorShown) this.simulatorPanel.loadStatus();
     }
 
     applySettings(settings) {
@@ -1006,6 +1904,33 @@ export class SquareGolfApp {
         if (gsproPort) gsproPort.value = settings.gsproPort || 921;
         if (gsproAutoConnect) gsproAutoConnect.checked = settings.gsproAutoConnect || false;
 
+        const gsproStandbyIP = this.$('gsproStandbyIP');
+        const gsproStandbyPort = this.$('gsproStandbyPort');
+        if (gsproStandbyIP) gsproStandbyIP.value = settings.gsproStandbyIP || '';
+        if (gsproStandbyPort) gsproStandbyPort.value = settings.gsproStandbyPort || 921;
+
+        const gsproTrafficLog = this.$('gsproTrafficLog');
+        if (gsproTrafficLog) gsproTrafficLog.checked = settings.gsproTrafficLog || false;
+
+        const gsproPayload = settings.gsproPayload || {};
+        const gsproDeviceID = this.$('gsproDeviceID');
+        const gsproUnits = this.$('gsproUnits');
+        const gsproAPIVersion = this.$('gsproAPIVersion');
+        const gsproIncludeFirmware = this.$('gsproIncludeFirmware');
+        if (gsproDeviceID) gsproDeviceID.value = gsproPayload.deviceID || 'CustomLaunchMonitor';
+        if (gsproUnits) gsproUnits.value = gsproPayload.units || 'Yards';
+        if (gsproAPIVersion) gsproAPIVersion.value = gsproPayload.apiVersion || '1';
+        if (gsproIncludeFirmware) gsproIncludeFirmware.checked = gsproPayload.includeFirmware || false;
+
+        const gsproShotNumberPolicy = this.$('gsproShotNumberPolicy');
+        if (gsproShotNumberPolicy) gsproShotNumberPolicy.value = settings.gsproShotNumberPolicy || 'keep';
+
+        const gsproRetryQueue = settings.gsproRetryQueue || {};
+        const gsproRetryQueueDepth = this.$('gsproRetryQueueDepth');
+        const gsproRetryQueueConfirm = this.$('gsproRetryQueueConfirm');
+        if (gsproRetryQueueDepth) gsproRetryQueueDepth.value = gsproRetryQueue.depth ?? 10;
+        if (gsproRetryQueueConfirm) gsproRetryQueueConfirm.checked = gsproRetryQueue.confirm || false;
+
         const itIP = this.$('infiniteTeesIP');
         const itPort = this.$('infiniteTeesPort');
         const itAutoConnect = this.$('infiniteTeesAutoConnect');
@@ -1021,21 +1946,213 @@ export class SquareGolfApp {
         if (omniDistanceUnit) omniDistanceUnit.value = settings.omniDistanceUnit || 'meters';
         if (omniGreenSpeed) omniGreenSpeed.value = String(settings.omniGreenSpeed || 10);
         if (omniCarryAdjustment) omniCarryAdjustment.value = settings.omniCarryAdjustment ?? 0;
+
+        const chimeEnabled = this.$('chimeEnabled');
+        const chimeOutput = this.$('chimeOutput');
+        const chimeVolume = this.$('chimeVolume');
+        if (chimeEnabled) chimeEnabled.checked = settings.chimeEnabled || false;
+        if (chimeOutput) chimeOutput.value = settings.chimeOutput || 'browser';
+        if (chimeVolume) chimeVolume.value = settings.chimeVolume ?? 80;
+
+        const misreadPrompt = this.$('misreadPrompt');
+        if (misreadPrompt) misreadPrompt.checked = settings.misreadPrompt || false;
+        const shotRawData = this.$('shotRawData');
+        if (shotRawData) shotRawData.checked = settings.shotRawData || false;
+
+        const spinEstimation = this.$('spinEstimation');
+        if (spinEstimation) spinEstimation.checked = settings.spinEstimation ?? false;
+
+        const clubSpeedEstimation = this.$('clubSpeedEstimation');
+        if (clubSpeedEstimation) clubSpeedEstimation.checked = settings.clubSpeedEstimation ?? true;
+
+        const locale = this.$('locale');
+        if (locale) locale.value = settings.locale || 'en';
+
+        const numberFormat = settings.numberFormat || {};
+        const numberSpeedUnit = this.$('numberSpeedUnit');
+        if (numberSpeedUnit) numberSpeedUnit.value = numberFormat.speedUnit || 'mph';
+        const numberDistanceUnit = this.$('numberDistanceUnit');
+        if (numberDistanceUnit) numberDistanceUnit.value = numberFormat.distanceUnit || 'yards';
+        const numberDecimalSeparator = this.$('numberDecimalSeparator');
+        if (numberDecimalSeparator) numberDecimalSeparator.value = numberFormat.decimalSeparator || '.';
+
+        const deviceAddress = this.$('deviceAddress');
+        if (deviceAddress) deviceAddress.value = settings.deviceAddress || '';
+
+        const environment = settings.environment || {};
+        const environmentMode = this.$('environmentMode');
+        const environmentAltitude = this.$('environmentAltitude');
+        const environmentTemperature = this.$('environmentTemperature');
+        if (environmentMode) environmentMode.value = environment.mode || 'off';
+        if (environmentAltitude) environmentAltitude.value = environment.altitudeMeters ?? 0;
+        if (environmentTemperature) environmentTemperature.value = environment.temperatureC ?? 20;
+
+        const alignmentSmoothing = settings.alignmentSmoothing || {};
+        const alignmentReadings = this.$('alignmentReadings');
+        const alignmentUpdateRate = this.$('alignmentUpdateRate');
+        if (alignmentReadings) alignmentReadings.value = alignmentSmoothing.readings ?? 5;
+        if (alignmentUpdateRate) alignmentUpdateRate.value = alignmentSmoothing.updateRate ?? 10;
+
+        const idle = settings.idle || {};
+        const idleEnabled = this.$('idleEnabled');
+        const idleMinutes = this.$('idleMinutes');
+        if (idleEnabled) idleEnabled.checked = idle.enabled ?? false;
+        if (idleMinutes) idleMinutes.value = idle.minutes ?? 15;
+
+        const shotCooldownMs = this.$('shotCooldownMs');
+        if (shotCooldownMs) shotCooldownMs.value = settings.shotCooldownMs ?? 0;
+
+        const logRotation = settings.logRotation || {};
+        const logMaxSizeMB = this.$('logMaxSizeMB');
+        const logMaxBackups = this.$('logMaxBackups');
+        const logMaxAgeDays = this.$('logMaxAgeDays');
+        const logDaily = this.$('logDaily');
+        const logCompress = this.$('logCompress');
+        if (logMaxSizeMB) logMaxSizeMB.value = logRotation.maxSizeMB ?? 5;
+        if (logMaxBackups) logMaxBackups.value = logRotation.maxBackups ?? 5;
+        if (logMaxAgeDays) logMaxAgeDays.value = logRotation.maxAgeDays ?? 28;
+        if (logDaily) logDaily.checked = logRotation.daily ?? false;
+        if (logCompress) logCompress.checked = logRotation.compress ?? true;
+
+        const sleepSchedule = settings.sleepSchedule || {};
+        const sleepScheduleEnabled = this.$('sleepScheduleEnabled');
+        const sleepScheduleSleep = this.$('sleepScheduleSleep');
+        const sleepScheduleWake = this.$('sleepScheduleWake');
+        if (sleepScheduleEnabled) sleepScheduleEnabled.checked = sleepSchedule.enabled ?? false;
+        if (sleepScheduleSleep) sleepScheduleSleep.value = sleepSchedule.sleep || '23:00';
+        if (sleepScheduleWake) sleepScheduleWake.value = sleepSchedule.wake || '07:00';
+
+        const heartbeat = settings.heartbeat || {};
+        const heartbeatInterval = this.$('heartbeatInterval');
+        const heartbeatDeviceTimeout = this.$('heartbeatDeviceTimeout');
+        if (heartbeatInterval) heartbeatInterval.value = heartbeat.intervalSeconds ?? 5;
+        if (heartbeatDeviceTimeout) heartbeatDeviceTimeout.value = heartbeat.deviceTimeoutSeconds ?? 10;
+
+        const spinConventions = settings.spinConventions || {};
+        const presets = settings.spinConventionPresets || {};
+        const gsproSpinConvention = this.$('gsproSpinConvention');
+        const infiniteTeesSpinConvention = this.$('infiniteTeesSpinConvention');
+        if (gsproSpinConvention) gsproSpinConvention.value = this.spinConventionPreset(spinConventions.gspro, presets);
+        if (infiniteTeesSpinConvention) infiniteTeesSpinConvention.value = this.spinConventionPreset(spinConventions.infiniteTees, presets);
+        const awesomeGolfSpinConvention = this.$('awesomeGolfSpinConvention');
+        if (awesomeGolfSpinConvention) awesomeGolfSpinConvention.value = this.spinConventionPreset(spinConventions.awesomeGolf, presets);
+    }
+
+    // Name the preset matching a saved convention, or 'custom' for flips set in the settings file
+    spinConventionPreset(convention, presets) {
+        if (!convention) return 'standard';
+        const match = Object.entries(presets).find(([, preset]) =>
+            preset.flipSpinAxis === convention.flipSpinAxis &&
+            preset.flipSideSpin === convention.flipSideSpin &&
+            preset.flipHLA === convention.flipHLA);
+        return match ? match[0] : 'custom';
     }
 
     async saveSettings() {
+        (this.invalidFields ?? []).forEach((field) => this.clearFieldError(field));
+        this.invalidFields = [];
+
         const spinMode = document.querySelector('input[name="spinMode"]:checked')?.value;
         const omniSpeedUnit = this.$('omniSpeedUnit')?.value || 'mps';
         const omniDistanceUnit = this.$('omniDistanceUnit')?.value || 'meters';
         const omniGreenSpeed = parseInt(this.$('omniGreenSpeed')?.value || '10', 10);
         const omniCarryAdjustment = parseInt(this.$('omniCarryAdjustment')?.value || '0', 10);
+        const deviceAddress = (this.$('deviceAddress')?.value || '').trim();
+        const chimeEnabled = this.$('chimeEnabled')?.checked || false;
+        const chimeOutput = this.$('chimeOutput')?.value || 'browser';
+        const chimeVolume = parseInt(this.$('chimeVolume')?.value || '80', 10);
+        const misreadPrompt = this.$('misreadPrompt')?.checked || false;
+        const shotRawData = this.$('shotRawData')?.checked || false;
+        const spinEstimation = this.$('spinEstimation')?.checked || false;
+        const clubSpeedEstimation = this.$('clubSpeedEstimation')?.checked || false;
+        const locale = this.$('locale')?.value || 'en';
+        const numberFormat = {
+            speedUnit: this.$('numberSpeedUnit')?.value || 'mph',
+            distanceUnit: this.$('numberDistanceUnit')?.value || 'yards',
+            decimalSeparator: this.$('numberDecimalSeparator')?.value || '.'
+        };
+        const gsproStandbyIP = this.$('gsproStandbyIP')?.value.trim() || '';
+        const gsproStandbyPort = parseInt(this.$('gsproStandbyPort')?.value || '921', 10);
+        const gsproTrafficLog = this.$('gsproTrafficLog')?.checked || false;
+        const gsproPayload = {
+            deviceID: this.$('gsproDeviceID')?.value.trim() || 'CustomLaunchMonitor',
+            units: this.$('gsproUnits')?.value || 'Yards',
+            apiVersion: this.$('gsproAPIVersion')?.value.trim() || '1',
+            includeFirmware: this.$('gsproIncludeFirmware')?.checked || false
+        };
+        const gsproShotNumberPolicy = this.$('gsproShotNumberPolicy')?.value || 'keep';
+        const gsproRetryQueue = {
+            depth: parseInt(this.$('gsproRetryQueueDepth')?.value || '10', 10),
+            confirm: this.$('gsproRetryQueueConfirm')?.checked || false
+        };
+        const environment = {
+            mode: this.$('environmentMode')?.value || 'off',
+            altitudeMeters: parseFloat(this.$('environmentAltitude')?.value || '0'),
+            temperatureC: parseFloat(this.$('environmentTemperature')?.value || '20')
+        };
+        const alignmentSmoothing = {
+            readings: parseInt(this.$('alignmentReadings')?.value || '5', 10),
+            updateRate: parseInt(this.$('alignmentUpdateRate')?.value || '10', 10)
+        };
+        const idle = {
+            enabled: this.$('idleEnabled')?.checked || false,
+            minutes: parseInt(this.$('idleMinutes')?.value || '15', 10)
+        };
+        const shotCooldownMs = parseInt(this.$('shotCooldownMs')?.value || '0', 10);
+        const logRotation = {
+            maxSizeMB: parseInt(this.$('logMaxSizeMB')?.value || '5', 10),
+            maxBackups: parseInt(this.$('logMaxBackups')?.value || '5', 10),
+            maxAgeDays: parseInt(this.$('logMaxAgeDays')?.value || '28', 10),
+            daily: this.$('logDaily')?.checked || false,
+            compress: this.$('logCompress')?.checked ?? true
+        };
+        const sleepSchedule = {
+            enabled: this.$('sleepScheduleEnabled')?.checked || false,
+            sleep: this.$('sleepScheduleSleep')?.value || '23:00',
+            wake: this.$('sleepScheduleWake')?.value || '07:00'
+        };
+        const heartbeat = {
+            intervalSeconds: parseInt(this.$('heartbeatInterval')?.value || '5', 10),
+            deviceTimeoutSeconds: parseInt(this.$('heartbeatDeviceTimeout')?.value || '10', 10)
+        };
+        const current = this.settingsManager.getAll();
+        const presets = current.spinConventionPresets || {};
+        const spinConventions = { ...current.spinConventions };
+        [['gspro', 'gsproSpinConvention'], ['infiniteTees', 'infiniteTeesSpinConvention'], ['awesomeGolf', 'awesomeGolfSpinConvention']].forEach(([simulator, id]) => {
+            const preset = presets[this.$(id)?.value];
+            if (preset) spinConventions[simulator] = preset;
+        });
         await this.settingsManager.save({
-            ...this.settingsManager.getAll(),
+            ...current,
             spinMode,
             omniSpeedUnit,
             omniDistanceUnit,
             omniGreenSpeed,
-            omniCarryAdjustment
+            omniCarryAdjustment,
+            deviceAddress,
+            chimeEnabled,
+            chimeOutput,
+            chimeVolume,
+            misreadPrompt,
+            shotRawData,
+            spinEstimation,
+            clubSpeedEstimation,
+            locale,
+            numberFormat,
+            environment,
+            alignmentSmoothing,
+            idle,
+            shotCooldownMs,
+            sleepSchedule,
+            heartbeat,
+            logRotation,
+            spinConventions,
+            gsproStandbyIP,
+            gsproStandbyPort,
+            gsproTrafficLog,
+            gsproPayload,
+            gsproShotNumberPolicy,
+            gsproRetryQueue
         });
     }
 }
@@ -8,7 +8,7 @@ export class AlignmentManager {
 
     async start() {
         return this.#runCommand({
-            url: '/api/alignment/start',
+            url: '/api/v1/alignment/start',
             successEvent: 'alignment:started',
             errorEvent: 'alignment:error',
             stopOnError: true,
@@ -18,7 +18,7 @@ export class AlignmentManager {
 
     async stop() {
         return this.#runCommand({
-            url: '/api/alignment/stop',
+            url: '/api/v1/alignment/stop',
             successEvent: 'alignment:stopped',
             errorEvent: 'alignment:error',
             emitError: false,
@@ -28,7 +28,7 @@ export class AlignmentManager {
 
     async save() {
         return this.#runCommand({
-            url: '/api/alignment/stop',
+            url: '/api/v1/alignment/stop',
             successEvent: 'alignment:saved',
             errorEvent: 'alignment:error',
             stopOnError: true,
@@ -38,7 +38,7 @@ export class AlignmentManager {
 
     async cancel() {
         return this.#runCommand({
-            url: '/api/alignment/cancel',
+            url: '/api/v1/alignment/cancel',
             successEvent: 'alignment:cancelled',
             errorEvent: 'alignment:error',
             stopOnError: true,
@@ -48,7 +48,7 @@ export class AlignmentManager {
 
     async setHandedness(handedness) {
         try {
-            const response = await this.api.post('/api/alignment/handedness', { handedness });
+            const response = await this.api.post('/api/v1/alignment/handedness', { handedness });
 
             if (!response.ok) {
                 throw new Error('Failed to set handedness');
@@ -64,6 +64,18 @@ export class AlignmentManager {
         }
     }
 
+    // loadProfiles fetches the aim saved for each handedness
+    async loadProfiles() {
+        try {
+            const response = await this.api.get('/api/v1/alignment/profiles');
+            if (response.ok) {
+                this.eventBus.emit('alignment:profiles', await response.json());
+            }
+        } catch (error) {
+            console.error('Failed to load alignment profiles:', error);
+        }
+    }
+
     updateDisplay(angle, isAligned) {
         this.eventBus.emit('alignment:update', { angle, isAligned });
     }
@@ -12,6 +12,7 @@ export class CameraManager {
 
     async save() {
         const urlField = this.$('cameraURL');
+        const dtlField = this.$('cameraDTLURL');
         const enabledField = this.$('cameraEnabled');
 
         if (!urlField || !enabledField) {
@@ -19,6 +20,7 @@ export class CameraManager {
         }
 
         const url = urlField.value.trim();
+        const dtlURL = dtlField ? dtlField.value.trim() : '';
         const enabled = enabledField.checked;
 
         if (enabled && !url) {
@@ -26,10 +28,20 @@ export class CameraManager {
             return { success: false };
         }
 
+        const cameras = [{ name: 'front', url }];
+        if (dtlURL) {
+            cameras.push({ name: 'dtl', url: dtlURL });
+        }
+
         try {
-            const response = await this.api.post('/api/camera/config', { url, enabled });
+            const response = await this.api.post('/api/v1/camera/config', { url, enabled, cameras });
 
             if (!response.ok) {
+                const fields = await this.api.fieldErrors(response);
+                if (fields) {
+                    this.eventBus.emit('settings:invalid', fields);
+                    throw new Error(this.api.describeFieldErrors(fields));
+                }
                 throw new Error(`Failed to save config: ${response.statusText}`);
             }
 
@@ -45,12 +57,19 @@ export class CameraManager {
         this.config = config;
 
         const urlField = this.$('cameraURL');
+        const dtlField = this.$('cameraDTLURL');
         const enabledCheckbox = this.$('cameraEnabled');
+        const cameras = config.cameras || [];
+        const dtlCamera = cameras.find((camera) => camera.name === 'dtl');
 
         if (urlField) {
             urlField.value = config.url || '';
         }
 
+        if (dtlField) {
+            dtlField.value = dtlCamera ? dtlCamera.url : '';
+        }
+
         if (enabledCheckbox) {
             enabledCheckbox.checked = config.enabled;
         }
@@ -0,0 +1,160 @@
+// features/CombinePanel.js
+export class CombinePanel {
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+        this.combine = null;
+    }
+
+    $(id) {
+        return document.getElementById(id);
+    }
+
+    async post(url, data = null) {
+        try {
+            const response = await this.api.post(url, data);
+            if (!response.ok) {
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit('games:error', error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
+    start() {
+        return this.post('/api/v1/games/combine/start', {
+            profile: (this.$('combineProfile')?.value || '').trim()
+        });
+    }
+
+    togglePause() {
+        const action = this.combine?.status === 'paused' ? 'resume' : 'pause';
+        return this.post(`/api/v1/games/combine/${action}`);
+    }
+
+    stop() {
+        return this.post('/api/v1/games/combine/stop');
+    }
+
+    async load() {
+        try {
+            const response = await this.api.get('/api/v1/games/combine');
+            if (response.ok) {
+                this.render((await response.json()).combine);
+            }
+        } catch (error) {
+            console.error('Failed to load combine:', error);
+        }
+        return this.loadHistory();
+    }
+
+    async loadHistory() {
+        try {
+            const response = await this.api.get('/api/v1/games/combine/reports');
+            if (response.ok) {
+                this.renderHistory(await response.json());
+            }
+        } catch (error) {
+            console.error('Failed to load combine reports:', error);
+        }
+    }
+
+    async loadReport() {
+        try {
+            const response = await this.api.get('/api/v1/games/combine/report');
+            if (response.ok) {
+                this.renderReport(await response.json());
+            }
+        } catch (error) {
+            console.error('Failed to load combine report:', error);
+        }
+    }
+
+    render(combine) {
+        const finishedNow = combine?.status === 'finished' && this.combine?.status !== 'finished';
+        this.combine = combine;
+        const active = combine && combine.status !== 'finished';
+
+        const pauseBtn = this.$('combinePauseBtn');
+        if (pauseBtn) {
+            pauseBtn.disabled = !active;
+            pauseBtn.textContent = combine?.status === 'paused' ? 'Resume' : 'Pause';
+        }
+        const stopBtn = this.$('combineStopBtn');
+        if (stopBtn) stopBtn.disabled = !active;
+
+        const status = this.$('combineStatus');
+        if (status) status.textContent = this.describe(combine);
+
+        if (combine) {
+            this.loadReport();
+        } else {
+            this.$('combineReport')?.replaceChildren();
+        }
+        if (finishedNow) {
+            this.loadHistory();
+        }
+    }
+
+    describe(combine) {
+        if (!combine) {
+            return 'No combine in progress';
+        }
+        const score = `score ${combine.score}`;
+        if (combine.status === 'finished') {
+            return `Combine finished: ${score}`;
+        }
+        const progress = `Target ${combine.targetYards} yd · round ${combine.round} of 3 · shot ${combine.shots.length + 1} of ${combine.total}`;
+        const last = combine.shots[combine.shots.length - 1];
+        const lastShot = last ? ` · last: ${last.band || 'no score'} (${last.points})` : '';
+        const paused = combine.status === 'paused' ? 'Paused · ' : '';
+        return `${paused}${progress} · ${score}${lastShot}`;
+    }
+
+    renderReport(report) {
+        const table = this.$('combineReport');
+        if (!table) return;
+        if (report.targets.length === 0) {
+            table.replaceChildren();
+            return;
+        }
+
+        const header = document.createElement('tr');
+        header.append(this.cell('th', 'Target'), this.cell('th', 'Shots'), this.cell('th', 'Score'), this.cell('th', 'Avg Miss'));
+        const rows = report.targets.map((target) => {
+            const row = document.createElement('tr');
+            const best = report.best?.targetYards === target.targetYards && report.targets.length > 1;
+            const worst = report.worst?.targetYards === target.targetYards && report.targets.length > 1;
+            const label = this.cell('th', `${target.targetYards} yd`);
+            if (best || worst) {
+                const detail = this.cell('span', best ? 'best' : 'worst');
+                detail.className = 'cell-detail';
+                label.append(detail);
+            }
+            row.append(label, this.cell('td', target.shots), this.cell('td', target.score), this.cell('td', `${target.averageMissYards} yd`));
+            return row;
+        });
+        table.replaceChildren(header, ...rows);
+    }
+
+    renderHistory(reports) {
+        const list = this.$('combineHistory');
+        if (list) {
+            list.replaceChildren(...reports.map((report) => {
+                const item = document.createElement('li');
+                item.textContent = `${report.profile}: ${report.score} (${new Date(report.startedAt).toLocaleDateString()})`;
+                return item;
+            }));
+        }
+        const empty = this.$('combineHistoryEmpty');
+        if (empty) empty.classList.toggle('hidden', reports.length > 0);
+    }
+
+    cell(tag, text) {
+        const element = document.createElement(tag);
+        element.textContent = text;
+        return element;
+    }
+}
@@ -0,0 +1,139 @@
+// features/DashboardPanel.js
+export class DashboardPanel {
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+        this.tokens = new Map();
+    }
+
+    $(id) {
+        return document.getElementById(id);
+    }
+
+    async load() {
+        try {
+            const [config, bays] = await Promise.all([
+                this.api.get('/api/v1/dashboard/config'),
+                this.api.get('/api/v1/dashboard')
+            ]);
+            if (config.ok) {
+                this.renderConfig(await config.json());
+            }
+            if (bays.ok) {
+                this.render(await bays.json());
+            }
+        } catch (error) {
+            console.error('Failed to load dashboard:', error);
+        }
+    }
+
+    // Bays are entered one per line as "name url", or just the url. Access
+    // tokens are only set in config.json, so each bay keeps the token saved
+    // for its url.
+    parseBays(text) {
+        return text.split('\n')
+            .map((line) => line.trim())
+            .filter(Boolean)
+            .map((line) => {
+                const split = line.lastIndexOf(' ');
+                const bay = split < 0
+                    ? { name: '', url: line }
+                    : { name: line.slice(0, split).trim(), url: line.slice(split + 1) };
+                const token = this.tokens.get(bay.url);
+                return token ? { ...bay, token } : bay;
+            });
+    }
+
+    async save() {
+        const settings = {
+            name: (this.$('dashboardName')?.value || '').trim(),
+            bays: this.parseBays(this.$('dashboardBays')?.value || ''),
+            pollSeconds: parseInt(this.$('dashboardPollSeconds')?.value, 10) || 0
+        };
+
+        try {
+            const response = await this.api.post('/api/v1/dashboard/config', settings);
+            if (!response.ok) {
+                const fields = await this.api.fieldErrors(response);
+                if (fields) {
+                    this.eventBus.emit('settings:invalid', fields);
+                    throw new Error(this.api.describeFieldErrors(fields));
+                }
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            this.renderConfig(await response.json());
+            this.eventBus.emit('dashboard:saved');
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit('dashboard:error', error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
+    renderConfig(settings) {
+        const name = this.$('dashboardName');
+        const bays = this.$('dashboardBays');
+        const pollSeconds = this.$('dashboardPollSeconds');
+        this.tokens = new Map((settings.bays || []).filter((bay) => bay.token).map((bay) => [bay.url, bay.token]));
+        if (name) name.value = settings.name || '';
+        if (bays) bays.value = (settings.bays || []).map((bay) => (bay.name ? `${bay.name} ${bay.url}` : bay.url)).join('\n');
+        if (pollSeconds) pollSeconds.value = settings.pollSeconds || 5;
+    }
+
+    render(bays) {
+        const table = this.$('dashboardBayTable');
+        if (!table) return;
+
+        const header = document.createElement('tr');
+        ['Bay', 'Device', 'Battery', 'GSPro', 'Last Shot'].forEach((title) => header.append(this.cell('th', title)));
+        const rows = bays.map((bay) => {
+            const row = document.createElement('tr');
+            row.append(
+                this.cell('th', bay.name),
+                this.cell('td', this.describeDevice(bay)),
+                this.cell('td', this.describeBattery(bay.device)),
+                this.cell('td', this.describeGSPro(bay.gspro)),
+                this.cell('td', this.describeShot(bay.lastShot))
+            );
+            return row;
+        });
+        table.replaceChildren(header, ...rows);
+    }
+
+    describeDevice(bay) {
+        if (!bay.reachable) {
+            return bay.error ? `Unreachable: ${bay.error}` : 'Waiting for first update';
+        }
+        const device = bay.device;
+        const states = [device.connectionStatus];
+        if (device.standby) states.push('standby');
+        else if (device.idle) states.push('idle');
+        if (device.paused) states.push('paused');
+        return states.join(', ');
+    }
+
+    describeBattery(device) {
+        if (device.batteryLevel == null) return '';
+        const remaining = device.minutesRemaining == null
+            ? ''
+            : ` (${Math.floor(device.minutesRemaining / 60)}h ${device.minutesRemaining % 60}m left)`;
+        return `${device.batteryLevel}%${remaining}`;
+    }
+
+    describeGSPro(gspro) {
+        if (!gspro.connectionStatus) return '';
+        return gspro.player ? `${gspro.connectionStatus} · ${gspro.player}` : gspro.connectionStatus;
+    }
+
+    describeShot(shot) {
+        if (!shot) return '';
+        const time = new Date(shot.timestamp).toLocaleTimeString();
+        return `${shot.club} · ${shot.carryYards.toFixed(0)} yd · ${time}`;
+    }
+
+    cell(tag, text) {
+        const element = document.createElement(tag);
+        element.textContent = text;
+        return element;
+    }
+}
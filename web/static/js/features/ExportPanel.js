@@ -0,0 +1,120 @@
+// features/ExportPanel.js
+const FIELDS = {
+    spreadsheetId: 'exportSpreadsheetId',
+    sheetName: 'exportSheetName',
+    credentialsFile: 'exportCredentialsFile',
+    bucket: 'exportBucket',
+    region: 'exportRegion',
+    endpoint: 'exportEndpoint',
+    prefix: 'exportPrefix',
+    accessKeyId: 'exportAccessKeyId',
+    secretAccessKey: 'exportSecretAccessKey'
+};
+
+export class ExportPanel {
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+    }
+
+    $(id) {
+        return document.getElementById(id);
+    }
+
+    async load() {
+        try {
+            const [config, status] = await Promise.all([
+                this.api.get('/api/v1/export/config'),
+                this.api.get('/api/v1/export/status')
+            ]);
+            if (config.ok) {
+                this.renderConfig(await config.json());
+            }
+            if (status.ok) {
+                this.renderStatus(await status.json());
+            }
+        } catch (error) {
+            console.error('Failed to load export settings:', error);
+        }
+    }
+
+    async save() {
+        const settings = {
+            enabled: Boolean(this.$('exportEnabled')?.checked),
+            destination: this.$('exportDestination')?.value || 'sheets'
+        };
+        Object.entries(FIELDS).forEach(([key, id]) => {
+            settings[key] = (this.$(id)?.value || '').trim();
+        });
+
+        try {
+            const response = await this.api.post('/api/v1/export/config', settings);
+            if (!response.ok) {
+                const fields = await this.api.fieldErrors(response);
+                if (fields) {
+                    this.eventBus.emit('settings:invalid', fields);
+                    throw new Error(this.api.describeFieldErrors(fields));
+                }
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            this.renderConfig(await response.json());
+            this.eventBus.emit('export:saved');
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit('export:error', error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
+    async retry() {
+        try {
+            const response = await this.api.post('/api/v1/export/retry');
+            if (response.ok) {
+                this.renderStatus(await response.json());
+            }
+        } catch (error) {
+            this.eventBus.emit('export:error', error.message);
+        }
+    }
+
+    renderConfig(settings) {
+        const enabled = this.$('exportEnabled');
+        if (enabled) enabled.checked = Boolean(settings.enabled);
+        const destination = this.$('exportDestination');
+        if (destination) destination.value = settings.destination || 'sheets';
+        Object.entries(FIELDS).forEach(([key, id]) => {
+            const field = this.$(id);
+            if (field) field.value = settings[key] || '';
+        });
+        this.showDestination();
+    }
+
+    // showDestination shows only the fields for the chosen destination
+    showDestination() {
+        const destination = this.$('exportDestination')?.value || 'sheets';
+        this.$('exportSheetsGroup')?.classList.toggle('hidden', destination !== 'sheets');
+        this.$('exportS3Group')?.classList.toggle('hidden', destination !== 's3');
+    }
+
+    renderStatus(status) {
+        const text = this.$('exportStatus');
+        const retry = this.$('exportRetryBtn');
+        if (!text || !status) return;
+
+        if (!status.enabled) {
+            text.textContent = 'Export is off.';
+        } else {
+            const parts = [`${status.exported} exported`, `${status.pending} waiting`];
+            if (status.dropped) parts.push(`${status.dropped} dropped`);
+            if (status.lastExportAt) {
+                parts.push(`last sent ${new Date(status.lastExportAt).toLocaleTimeString()}`);
+            }
+            text.textContent = parts.join(', ') + '.';
+            if (status.lastError) {
+                const retryAt = status.nextRetryAt ? `; retrying at ${new Date(status.nextRetryAt).toLocaleTimeString()}` : '';
+                text.textContent += ` Last error: ${status.lastError}${retryAt}`;
+            }
+        }
+        if (retry) retry.disabled = !status.enabled || status.pending === 0;
+    }
+}
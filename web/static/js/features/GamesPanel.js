@@ -0,0 +1,122 @@
+// features/GamesPanel.js
+const MODE_NAMES = {
+    closestToPin: 'Closest to the pin',
+    ladder: 'Ladder'
+};
+
+export class GamesPanel {
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+        this.game = null;
+    }
+
+    $(id) {
+        return document.getElementById(id);
+    }
+
+    async post(url, data = null) {
+        try {
+            const response = await this.api.post(url, data);
+            if (!response.ok) {
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit('games:error', error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
+    start() {
+        return this.post('/api/v1/games/start', {
+            mode: this.$('gameMode')?.value || 'closestToPin',
+            profile: (this.$('gameProfile')?.value || '').trim()
+        });
+    }
+
+    stop() {
+        return this.post('/api/v1/games/stop');
+    }
+
+    async load() {
+        try {
+            const response = await this.api.get('/api/v1/games');
+            if (response.ok) {
+                this.render((await response.json()).game);
+            }
+        } catch (error) {
+            console.error('Failed to load game:', error);
+        }
+        return this.loadLeaderboard();
+    }
+
+    async loadLeaderboard() {
+        const mode = this.$('leaderboardMode')?.value || 'closestToPin';
+        try {
+            const response = await this.api.get(`/api/v1/games/leaderboard?mode=${encodeURIComponent(mode)}`);
+            if (response.ok) {
+                this.renderLeaderboard(await response.json());
+            }
+        } catch (error) {
+            console.error('Failed to load leaderboard:', error);
+        }
+    }
+
+    render(game) {
+        const finishedNow = game?.finished && !this.game?.finished;
+        this.game = game;
+
+        const stopBtn = this.$('gameStopBtn');
+        if (stopBtn) stopBtn.disabled = !game || game.finished;
+
+        const status = this.$('gameStatus');
+        if (status) status.textContent = this.describe(game);
+
+        const list = this.$('gameShots');
+        if (list) {
+            list.replaceChildren(...(game?.shots ?? []).map((shot) => {
+                const item = document.createElement('li');
+                const side = shot.offlineYards >= 0 ? 'R' : 'L';
+                const result = game.config.mode === 'ladder' ? (shot.hit ? 'hit' : 'miss') : `${shot.points} pts`;
+                item.textContent = `${shot.target.distanceYards} yd target: ${shot.carryYards} yd, ${Math.abs(shot.offlineYards)} ${side}, ${shot.missYards} yd away (${result})`;
+                return item;
+            }).reverse());
+        }
+
+        if (finishedNow) {
+            this.loadLeaderboard();
+        }
+    }
+
+    describe(game) {
+        if (!game) {
+            return 'No game in progress';
+        }
+        const name = MODE_NAMES[game.config.mode] ?? game.config.mode;
+        if (game.finished) {
+            return game.config.mode === 'ladder'
+                ? `${name} finished: ${game.rung} of ${game.rungs} rungs in ${game.shots.length} shots`
+                : `${name} finished: ${game.score} points`;
+        }
+        const target = `Target ${game.target.distanceYards} yd`;
+        if (game.config.mode === 'ladder') {
+            return `${target} (within ${game.target.toleranceYards} yd) · rung ${game.rung + 1} of ${game.rungs} · shot ${game.shots.length + 1} of ${game.config.shots}`;
+        }
+        return `${target} · shot ${game.shots.length + 1} of ${game.config.shots} · ${game.score} points`;
+    }
+
+    renderLeaderboard(results) {
+        const list = this.$('gameLeaderboard');
+        if (list) {
+            list.replaceChildren(...results.map((result) => {
+                const item = document.createElement('li');
+                const score = result.mode === 'ladder' ? `${result.score} rungs in ${result.shots} shots` : `${result.score} points`;
+                item.textContent = `${result.profile}: ${score} (${new Date(result.playedAt).toLocaleDateString()})`;
+                return item;
+            }));
+        }
+        const empty = this.$('gameLeaderboardEmpty');
+        if (empty) empty.classList.toggle('hidden', results.length > 0);
+    }
+}
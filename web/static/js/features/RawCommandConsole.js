@@ -0,0 +1,80 @@
+// features/RawCommandConsole.js
+export class RawCommandConsole {
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+        this.sending = false;
+    }
+
+    $(id) {
+        return document.getElementById(id);
+    }
+
+    // Writes the command and prints each line of the streamed reply as it
+    // arrives: the command sent, then every notification until the window ends
+    async send() {
+        if (this.sending) return;
+        const command = (this.$('rawCommandInput')?.value || '').trim();
+        if (!command) return;
+        const windowMs = Number(this.$('rawCommandWindow')?.value) || 2000;
+
+        this.setSending(true);
+        this.append(`> ${command}`);
+        try {
+            const response = await this.api.post('/api/v1/debug/command', { command, windowMs });
+            if (!response.ok) {
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+
+            const reader = response.body.getReader();
+            const decoder = new TextDecoder();
+            let buffered = '';
+            for (;;) {
+                const { done, value } = await reader.read();
+                if (done) break;
+                buffered += decoder.decode(value, { stream: true });
+                const lines = buffered.split('\n');
+                buffered = lines.pop();
+                lines.filter(Boolean).forEach((line) => this.render(JSON.parse(line)));
+            }
+        } catch (error) {
+            this.append(`! ${error.message}`);
+        } finally {
+            this.setSending(false);
+        }
+    }
+
+    render(event) {
+        switch (event.type) {
+            case 'sent':
+                this.append(`  sent ${event.command}`);
+                break;
+            case 'notification':
+                this.append(`  +${event.offsetMs}ms ${event.data.match(/../g).join(' ')}`);
+                break;
+            case 'done': {
+                const dropped = event.dropped ? `, ${event.dropped} dropped` : '';
+                this.append(`  done after ${event.offsetMs}ms${dropped}`);
+                break;
+            }
+        }
+    }
+
+    append(line) {
+        const output = this.$('rawCommandOutput');
+        if (!output) return;
+        output.textContent += `${line}\n`;
+        output.scrollTop = output.scrollHeight;
+    }
+
+    clear() {
+        const output = this.$('rawCommandOutput');
+        if (output) output.textContent = '';
+    }
+
+    setSending(sending) {
+        this.sending = sending;
+        const button = this.$('rawCommandSendBtn');
+        if (button) button.disabled = sending;
+    }
+}
@@ -8,7 +8,7 @@ export class SettingsManager {
 
     async load() {
         try {
-            const response = await this.api.get('/api/settings');
+            const response = await this.api.get('/api/v1/settings');
             if (!response.ok) {
                 throw new Error(`Failed to load settings: ${response.statusText}`);
             }
@@ -22,9 +22,14 @@ export class SettingsManager {
 
     async save(newSettings) {
         try {
-            const response = await this.api.post('/api/settings', newSettings);
+            const response = await this.api.post('/api/v1/settings', newSettings);
 
             if (!response.ok) {
+                const fields = await this.api.fieldErrors(response);
+                if (fields) {
+                    this.eventBus.emit('settings:invalid', fields);
+                    throw new Error(this.api.describeFieldErrors(fields));
+                }
                 throw new Error(`Failed to save settings: ${response.statusText}`);
             }
 
@@ -37,6 +42,13 @@ export class SettingsManager {
         }
     }
 
+    // Takes settings saved from another window, so every open window edits
+    // the same copy
+    receive(settings) {
+        this.settings = settings || {};
+        this.eventBus.emit('settings:loaded', this.settings);
+    }
+
     get(key) {
         return this.settings[key];
     }
@@ -99,6 +99,9 @@ export class ShotMonitor {
         this.updateMetricValue('metricSideSpin', ballData?.sideSpin, 'rpm', 'spin', ballData?.isSideSpinValid, 'metricItemSideSpin');
         this.updateMetricValue('metricTotalSpin', ballData?.totalSpin, 'rpm', isTopspin ? 'topspin' : null, ballData?.isTotalSpinValid, 'metricItemTotalSpin');
         this.updateMetricValue('metricSpinAxis', ballData?.spinAxis, '°', false, ballData?.isSpinAxisValid, 'metricItemSpinAxis');
+        const adjustedCarry = document.getElementById('metricItemAdjustedCarry');
+        if (adjustedCarry) adjustedCarry.style.display = ballData?.environment ? '' : 'none';
+        this.updateMetricValue('metricAdjustedCarry', ballData?.environment?.carryYards, 'yds', false, true, 'metricItemAdjustedCarry');
 
         this.updateMetricValue('metricAttackAngle', clubData?.attackAngle, '°', 'attack', clubData?.isAttackAngleValid, 'metricItemAttackAngle');
         this.updateMetricValue('metricClubPath', clubData?.path, '°', 'path', clubData?.isPathValid, 'metricItemClubPath');
@@ -106,7 +109,12 @@ export class ShotMonitor {
         this.updateMetricValue('metricDynamicLoft', clubData?.dynamicLoft, '°', false, clubData?.isDynamicLoftValid, 'metricItemDynamicLoft');
         const clubSpeedMPH = typeof clubData?.clubSpeed === 'number' ? clubData.clubSpeed * 2.23694 : null;
         this.updateMetricValue('metricClubSpeed', clubSpeedMPH, 'mph', false, clubData?.isClubSpeedValid, 'metricItemClubSpeed');
-        this.updateMetricValue('metricSmashFactor', clubData?.smashFactor, '', false, clubData?.isSmashFactorValid, 'metricItemSmashFactor');
+        const smashFactor = clubData?.isSmashFactorValid ? clubData.smashFactor : null;
+        this.updateMetricValue('metricSmashFactor', smashFactor, '', false, !clubData?.smashFactorEstimated, 'metricItemSmashFactor');
+        const spinLoft = clubData?.isSpinLoftValid ? clubData.spinLoft : null;
+        this.updateMetricValue('metricSpinLoft', spinLoft, '°', false, true, 'metricItemSpinLoft');
+        const launchEfficiency = clubData?.isLaunchEfficiencyValid ? clubData.launchEfficiency * 100 : null;
+        this.updateMetricValue('metricLaunchEfficiency', launchEfficiency, '%', false, true, 'metricItemLaunchEfficiency');
         this.updateMetricValue('metricImpactH', clubData?.impactHorizontal, 'mm', 'impact', clubData?.isImpactHorizontalValid, 'metricItemImpactH');
     }
 
@@ -124,7 +132,8 @@ export class ShotMonitor {
             return;
         }
 
-        const decimals = (unit === 'rpm') ? 0 : 1;
+        // Smash factors, the only unitless metric, need two decimals to tell apart
+        const decimals = (unit === 'rpm' || unit === '%') ? 0 : (unit === '' ? 2 : 1);
         let displayValue = typeof value === 'number' ? value.toFixed(decimals) : value;
 
         if (labelFormat && typeof value === 'number' && value !== 0) {
@@ -191,6 +200,14 @@ export class ShotMonitor {
         else if (isValid === true) el.classList.add('valid');
     }
 
+    // An estimated dynamic lie range, e.g. "Toe up 1.2-2.0°"
+    lieText(lie) {
+        if (!lie) return null;
+        if (lie.min >= 0) return `Toe up ${lie.min.toFixed(1)}-${lie.max.toFixed(1)}°`;
+        if (lie.max <= 0) return `Toe down ${Math.abs(lie.max).toFixed(1)}-${Math.abs(lie.min).toFixed(1)}°`;
+        return `${lie.min.toFixed(1)}° to +${lie.max.toFixed(1)}°`;
+    }
+
     setBadge(id, isValid) {
         const el = document.getElementById(id);
         if (!el) return;
@@ -261,6 +278,7 @@ export class ShotMonitor {
             this.setDiagValue('diagClubPath', null);
             this.setDiagValue('diagFaceAngle', null);
             this.setDiagValue('diagFaceToPath', null);
+            this.setDiagValue('diagDynamicLie', null);
             pathLine.setAttribute('stroke', 'var(--text-muted)');
             faceLine.setAttribute('stroke', 'var(--text-muted)');
             if (pathArc) pathArc.setAttribute('d', '');
@@ -336,7 +354,10 @@ export class ShotMonitor {
         this.setDiagValue('diagFaceAngle', clubData.angle, '°', 'face', clubData.isFaceAngleValid);
 
         if (hasPath && hasFace) {
-            const ftp = clubData.angle - clubData.path;
+            // Shots stored before the connector sent face to path work it out here
+            const ftp = typeof clubData.faceToPath === 'number' && clubData.isFaceToPathValid
+                ? clubData.faceToPath
+                : clubData.angle - clubData.path;
             const isOpen = this._isLeftHanded ? (ftp < 0) : (ftp > 0);
             const ftpLabel = Math.abs(ftp) < 0.1 ? 'Square' : (isOpen ? 'Open' : 'Closed');
             const el = document.getElementById('diagFaceToPath');
@@ -347,6 +368,7 @@ export class ShotMonitor {
         } else {
             this.setDiagValue('diagFaceToPath', null);
         }
+        this.setDiagValue('diagDynamicLie', this.lieText(clubData.dynamicLie));
 
         this.updateDispersionDots();
     }
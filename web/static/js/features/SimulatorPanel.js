@@ -0,0 +1,149 @@
+// features/SimulatorPanel.js
+const MPH_TO_MPS = 0.44704;
+
+export class SimulatorPanel {
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+        this.status = null;
+    }
+
+    $(id) {
+        return document.getElementById(id);
+    }
+
+    numberValue(id) {
+        const value = parseFloat(this.$(id)?.value);
+        return Number.isFinite(value) ? value : 0;
+    }
+
+    async send(path, data = null) {
+        try {
+            const response = await this.api.post(`/api/v1/simulator/${path}`, data);
+            if (!response.ok) {
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            this.updateStatus(await response.json());
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit('simulator:error', error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
+    async loadStatus() {
+        try {
+            const response = await this.api.get('/api/v1/simulator/status');
+            if (response.ok) {
+                this.updateStatus(await response.json());
+            }
+        } catch (error) {
+            console.error('Failed to load simulator status:', error);
+        }
+    }
+
+    setManual(manual) {
+        return this.send('mode', { manual });
+    }
+
+    placeBall() {
+        return this.send('ball/placed');
+    }
+
+    readyBall() {
+        return this.send('ball/ready');
+    }
+
+    removeBall() {
+        return this.send('ball/removed');
+    }
+
+    randomShot() {
+        return this.send('shot');
+    }
+
+    hitShot() {
+        const totalSpin = Math.round(this.numberValue('simTotalSpin'));
+        const spinAxis = this.numberValue('simSpinAxis');
+        const axisRad = spinAxis * Math.PI / 180;
+
+        return this.send('shot', {
+            speed: this.numberValue('simBallSpeed') * MPH_TO_MPS,
+            launchAngle: this.numberValue('simLaunchAngle'),
+            horizontalAngle: this.numberValue('simHorizontalAngle'),
+            totalSpin,
+            spinAxis,
+            backSpin: Math.round(totalSpin * Math.cos(axisRad)),
+            sideSpin: Math.round(totalSpin * Math.sin(axisRad)),
+            club: {
+                path: this.numberValue('simClubPath'),
+                angle: this.numberValue('simFaceAngle'),
+                attackAngle: 0,
+                dynamicLoft: 0,
+                clubSpeed: this.numberValue('simClubSpeed') * MPH_TO_MPS
+            }
+        });
+    }
+
+    // Starts a preset series of shots that step up in speed and spin. The
+    // status is polled while it runs to show progress.
+    async startSeries() {
+        const result = await this.send('series', {
+            preset: this.$('simSeriesPreset')?.value ?? 'driver',
+            intervalSeconds: this.numberValue('simSeriesInterval')
+        });
+        if (result.success) this.pollSeries();
+        return result;
+    }
+
+    cancelSeries() {
+        return this.send('series/cancel');
+    }
+
+    pollSeries() {
+        clearTimeout(this.seriesTimer);
+        this.seriesTimer = setTimeout(async () => {
+            await this.loadStatus();
+            if (this.status?.series?.running) this.pollSeries();
+        }, 1000);
+    }
+
+    disconnect() {
+        return this.send('disconnect');
+    }
+
+    setBattery() {
+        return this.send('battery', { level: Math.round(this.numberValue('simBatteryLevel')) });
+    }
+
+    updateStatus(status) {
+        this.status = status;
+
+        const manual = this.$('simManual');
+        if (manual) manual.checked = status.manual;
+
+        const state = this.$('simState');
+        if (state) {
+            let ball = 'no ball';
+            if (status.ballReady) ball = 'ball ready';
+            else if (status.ballDetected) ball = 'ball placed';
+            state.textContent = status.connected
+                ? `Connected, ${ball}, battery ${status.batteryLevel}%`
+                : 'Disconnected';
+        }
+
+        const series = status.series ?? {};
+        const seriesState = this.$('simSeriesState');
+        if (seriesState) {
+            let text = '';
+            if (series.running) text = `Series: ${series.sent} of ${series.total} shots hit`;
+            else if (series.error) text = `Series stopped after ${series.sent} of ${series.total} shots: ${series.error}`;
+            else if (series.cancelled) text = `Series cancelled after ${series.sent} of ${series.total} shots`;
+            else if (series.total) text = `Series finished, ${series.sent} shots hit`;
+            seriesState.textContent = text;
+        }
+        this.$('simSeriesCancelBtn')?.classList.toggle('hidden', !series.running);
+        const start = this.$('simSeriesStartBtn');
+        if (start) start.disabled = Boolean(series.running);
+    }
+}
@@ -0,0 +1,136 @@
+// features/WarmupPanel.js
+export class WarmupPanel {
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+        this.warmup = null;
+    }
+
+    $(id) {
+        return document.getElementById(id);
+    }
+
+    async post(url, data = null) {
+        try {
+            const response = await this.api.post(url, data);
+            if (!response.ok) {
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit('games:error', error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
+    start() {
+        const clubs = (this.$('warmupClubs')?.value || '')
+            .split(',')
+            .map((club) => club.trim())
+            .filter(Boolean);
+        return this.post('/api/v1/games/warmup/start', {
+            profile: (this.$('warmupProfile')?.value || '').trim(),
+            clubs,
+            ballsPerClub: parseInt(this.$('warmupBalls')?.value, 10) || 0
+        });
+    }
+
+    stop() {
+        return this.post('/api/v1/games/warmup/stop');
+    }
+
+    async load() {
+        try {
+            const response = await this.api.get('/api/v1/games/warmup');
+            if (response.ok) {
+                this.render((await response.json()).warmup);
+            }
+        } catch (error) {
+            console.error('Failed to load warmup:', error);
+        }
+        return this.loadHistory();
+    }
+
+    async loadHistory() {
+        try {
+            const response = await this.api.get('/api/v1/games/warmup/sessions');
+            if (response.ok) {
+                this.renderHistory(await response.json());
+            }
+        } catch (error) {
+            console.error('Failed to load warmups:', error);
+        }
+    }
+
+    render(warmup) {
+        // A stopped or finished warmup has been saved to the history
+        const ended = this.warmup && !this.warmup.finished && (!warmup || warmup.finished);
+        this.warmup = warmup;
+
+        const stopBtn = this.$('warmupStopBtn');
+        if (stopBtn) stopBtn.disabled = !warmup || warmup.finished;
+
+        const status = this.$('warmupStatus');
+        if (status) status.textContent = this.describe(warmup);
+
+        this.renderSummary(warmup);
+        if (ended) {
+            this.loadHistory();
+        }
+    }
+
+    describe(warmup) {
+        if (!warmup) {
+            return 'No warmup in progress';
+        }
+        if (warmup.finished) {
+            return `Warmup finished: ${warmup.shots.length} shots`;
+        }
+        const ball = warmup.clubBalls + 1;
+        return `${warmup.club} · ball ${ball} of ${warmup.config.ballsPerClub} · shot ${warmup.shots.length + 1} of ${warmup.total}`;
+    }
+
+    renderSummary(warmup) {
+        const table = this.$('warmupSummary');
+        if (!table) return;
+        if (!warmup || warmup.shots.length === 0) {
+            table.replaceChildren();
+            return;
+        }
+
+        const header = document.createElement('tr');
+        header.append(this.cell('th', 'Club'), this.cell('th', 'Shots'), this.cell('th', 'Avg Carry'), this.cell('th', 'Spread'));
+        const rows = warmup.config.clubs.map((club) => {
+            const carries = warmup.shots.filter((shot) => shot.club === club).map((shot) => shot.carryYards);
+            if (carries.length === 0) {
+                return null;
+            }
+            const mean = carries.reduce((sum, carry) => sum + carry, 0) / carries.length;
+            const spread = Math.sqrt(carries.reduce((sum, carry) => sum + (carry - mean) ** 2, 0) / carries.length);
+            const row = document.createElement('tr');
+            row.append(this.cell('th', club), this.cell('td', carries.length), this.cell('td', `${mean.toFixed(1)} yd`), this.cell('td', `±${spread.toFixed(1)} yd`));
+            return row;
+        }).filter(Boolean);
+        table.replaceChildren(header, ...rows);
+    }
+
+    renderHistory(warmups) {
+        const list = this.$('warmupHistory');
+        if (list) {
+            list.replaceChildren(...warmups.map((warmup) => {
+                const clubs = warmup.clubs.map((club) => `${club.club} ${club.averageCarryYards} yd`).join(', ');
+                const item = document.createElement('li');
+                item.textContent = `${warmup.profile} (${new Date(warmup.startedAt).toLocaleDateString()}): ${warmup.shots} shots${warmup.completed ? '' : ', stopped early'} · ${clubs}`;
+                return item;
+            }));
+        }
+        const empty = this.$('warmupHistoryEmpty');
+        if (empty) empty.classList.toggle('hidden', warmups.length > 0);
+    }
+
+    cell(tag, text) {
+        const element = document.createElement(tag);
+        element.textContent = text;
+        return element;
+    }
+}
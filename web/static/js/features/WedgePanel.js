@@ -0,0 +1,146 @@
+// features/WedgePanel.js
+export class WedgePanel {
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+        this.practice = null;
+    }
+
+    $(id) {
+        return document.getElementById(id);
+    }
+
+    profile() {
+        return (this.$('wedgeProfile')?.value || '').trim() || 'Player';
+    }
+
+    async post(url, data = null) {
+        try {
+            const response = await this.api.post(url, data);
+            if (!response.ok) {
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit('games:error', error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
+    start() {
+        const targets = (this.$('wedgeTargets')?.value || '')
+            .split(/[\s,]+/)
+            .filter(Boolean)
+            .map(Number);
+        return this.post('/api/v1/games/wedges/start', {
+            profile: this.profile(),
+            targetsYards: targets,
+            shotsPerTarget: parseInt(this.$('wedgeShots')?.value, 10) || 0
+        });
+    }
+
+    stop() {
+        return this.post('/api/v1/games/wedges/stop');
+    }
+
+    async load() {
+        try {
+            const response = await this.api.get('/api/v1/games/wedges');
+            if (response.ok) {
+                const practice = (await response.json()).practice;
+                if (practice) {
+                    // render loads the practice's matrix
+                    this.render(practice);
+                    return;
+                }
+            }
+        } catch (error) {
+            console.error('Failed to load wedge practice:', error);
+        }
+        return this.loadMatrix();
+    }
+
+    async loadMatrix() {
+        const profile = this.profile();
+        const query = `?profile=${encodeURIComponent(profile)}`;
+        const link = this.$('wedgeExportLink');
+        if (link) link.href = `/api/v1/games/wedges/matrix.csv${query}`;
+
+        try {
+            const response = await this.api.get(`/api/v1/games/wedges/matrix${query}`);
+            if (response.ok) {
+                this.renderMatrix(await response.json());
+            }
+        } catch (error) {
+            console.error('Failed to load wedge matrix:', error);
+        }
+    }
+
+    render(practice) {
+        this.practice = practice;
+
+        const stopBtn = this.$('wedgeStopBtn');
+        if (stopBtn) stopBtn.disabled = !practice || practice.finished;
+
+        const status = this.$('wedgeStatus');
+        if (status) status.textContent = this.describe(practice);
+
+        // Show the matrix the shots are going into
+        if (practice) {
+            const profile = this.$('wedgeProfile');
+            if (profile && profile.value.trim().toLowerCase() !== practice.config.profile.toLowerCase()) {
+                profile.value = practice.config.profile;
+            }
+            this.loadMatrix();
+        }
+    }
+
+    describe(practice) {
+        if (!practice) {
+            return 'No practice in progress';
+        }
+        const last = practice.attempts[practice.attempts.length - 1];
+        const lastShot = last ? ` · last: ${last.carryYards} yd to ${last.targetYards} yd` : '';
+        if (practice.finished) {
+            return `Practice finished: ${practice.attempts.length} shots${lastShot}`;
+        }
+        const prompt = practice.prompt;
+        return `Hit ${prompt.club} to ${prompt.targetYards} yd · shot ${practice.attempts.length + 1} of ${practice.total}${lastShot}`;
+    }
+
+    renderMatrix(matrix) {
+        const table = this.$('wedgeMatrix');
+        if (table) {
+            const cells = new Map(matrix.cells.map((cell) => [`${cell.club}|${cell.targetYards}`, cell]));
+
+            const header = document.createElement('tr');
+            header.append(this.cell('th', 'Club'), ...matrix.targetsYards.map((target) => this.cell('th', `${target} yd`)));
+
+            const rows = matrix.clubs.map((club) => {
+                const row = document.createElement('tr');
+                row.append(this.cell('th', club));
+                for (const target of matrix.targetsYards) {
+                    const cell = cells.get(`${club}|${target}`);
+                    const td = this.cell('td', cell ? `${cell.averageCarryYards} yd` : '–');
+                    if (cell) {
+                        const spread = this.cell('span', `±${cell.carrySpreadYards} · ${cell.shots} shots`);
+                        spread.className = 'cell-detail';
+                        td.append(spread);
+                    }
+                    row.append(td);
+                }
+                return row;
+            });
+
+            table.replaceChildren(...(rows.length > 0 ? [header, ...rows] : []));
+        }
+        const empty = this.$('wedgeMatrixEmpty');
+        if (empty) empty.classList.toggle('hidden', matrix.cells.length > 0);
+    }
+
+    cell(tag, text) {
+        const element = document.createElement(tag);
+        element.textContent = text;
+        return element;
+    }
+}
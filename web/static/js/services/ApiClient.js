@@ -50,4 +50,23 @@ export class ApiClient {
             body: requestBody
         });
     }
+
+    // Reads the invalid fields from a 400 response to saving settings.
+    // Returns null for any other error.
+    async fieldErrors(response) {
+        const contentType = response.headers.get('Content-Type') ?? '';
+        if (response.status !== 400 || !contentType.includes('application/json')) {
+            return null;
+        }
+        try {
+            const body = await response.json();
+            return Array.isArray(body.fields) && body.fields.length > 0 ? body.fields : null;
+        } catch {
+            return null;
+        }
+    }
+
+    describeFieldErrors(fields) {
+        return fields.map(({ field, message }) => `${field} ${message}`).join('; ');
+    }
 }
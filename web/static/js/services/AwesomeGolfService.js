@@ -0,0 +1,81 @@
+// services/AwesomeGolfService.js
+export class AwesomeGolfService {
+    #errorEvent = 'awesomegolf:error';
+    #statusEvent = 'awesomegolf:status';
+
+    constructor(apiClient, eventBus) {
+        this.api = apiClient;
+        this.eventBus = eventBus;
+        this.status = null;
+    }
+
+    async connect(ip, port) {
+        if (!ip || !port) {
+            this.eventBus.emit(this.#errorEvent, 'Please enter valid IP and port');
+            return { success: false };
+        }
+
+        return this.#submitAction({
+            url: '/api/v1/awesomegolf/connect',
+            body: { ip, port },
+            successEvent: 'awesomegolf:connecting',
+            defaultErrorMessage: 'Failed to connect'
+        });
+    }
+
+    async disconnect() {
+        return this.#submitAction({
+            url: '/api/v1/awesomegolf/disconnect',
+            successEvent: 'awesomegolf:disconnecting',
+            defaultErrorMessage: 'Failed to disconnect'
+        });
+    }
+
+    async saveConfig(ip, port, autoConnect) {
+        return this.#submitAction({
+            url: '/api/v1/awesomegolf/config',
+            body: { ip, port, autoConnect },
+            defaultErrorMessage: 'Failed to save config'
+        });
+    }
+
+    async loadConfig() {
+        try {
+            const response = await this.api.get('/api/v1/awesomegolf/config');
+            if (response.ok) {
+                this.eventBus.emit('awesomegolf:config', await response.json());
+            }
+        } catch (error) {
+            console.error('Failed to load Awesome Golf config:', error);
+        }
+    }
+
+    updateStatus(status) {
+        this.status = status;
+        this.eventBus.emit(this.#statusEvent, status);
+    }
+
+    async #submitAction({ url, body, successEvent, defaultErrorMessage }) {
+        try {
+            const response = await this.api.post(url, body);
+
+            if (!response.ok) {
+                const fields = await this.api.fieldErrors(response);
+                if (fields) {
+                    this.eventBus.emit('settings:invalid', fields);
+                    throw new Error(this.api.describeFieldErrors(fields));
+                }
+                throw new Error(`${defaultErrorMessage}: ${response.statusText}`);
+            }
+
+            if (successEvent) {
+                this.eventBus.emit(successEvent);
+            }
+
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit(this.#errorEvent, error.message);
+            return { success: false, error: error.message };
+        }
+    }
+}
@@ -8,7 +8,7 @@ export class DeviceService {
 
     async connect(deviceName = '') {
         return this.#submitAction({
-            url: '/api/device/connect',
+            url: '/api/v1/device/connect',
             body: { deviceName },
             successEvent: 'device:connecting',
             errorEvent: 'device:error',
@@ -16,20 +16,149 @@ export class DeviceService {
         });
     }
 
+    // Connects to a known device by the nickname given to it
+    async connectByNickname(nickname) {
+        return this.#submitAction({
+            url: '/api/v1/device/connect',
+            body: { nickname },
+            successEvent: 'device:connecting',
+            errorEvent: 'device:error',
+            defaultErrorMessage: 'Failed to initiate connection'
+        });
+    }
+
     async disconnect() {
         return this.#submitAction({
-            url: '/api/device/disconnect',
+            url: '/api/v1/device/disconnect',
             successEvent: 'device:disconnecting',
             errorEvent: 'device:error',
             defaultErrorMessage: 'Failed to disconnect'
         });
     }
 
+    // Switches off ball detection and the heartbeat until wake() is called
+    async standby() {
+        return this.#submitAction({
+            url: '/api/v1/device/standby',
+            successEvent: 'device:standby',
+            errorEvent: 'device:error',
+            defaultErrorMessage: 'Failed to put device in standby'
+        });
+    }
+
+    // Brings the device out of standby, or re-arms ball detection if it went
+    // idle during a break
+    async wake() {
+        return this.#submitAction({
+            url: '/api/v1/device/wake',
+            successEvent: 'device:waking',
+            errorEvent: 'device:error',
+            defaultErrorMessage: 'Failed to wake device'
+        });
+    }
+
+    // Disarms ball detection and drops shots until resume() is called, so
+    // practice swings while away don't reach the simulator
+    async pause() {
+        return this.#submitAction({
+            url: '/api/v1/session/pause',
+            successEvent: 'device:paused',
+            errorEvent: 'device:error',
+            defaultErrorMessage: 'Failed to pause session'
+        });
+    }
+
+    // Re-arms ball detection if it was armed when the session was paused
+    async resume() {
+        return this.#submitAction({
+            url: '/api/v1/session/resume',
+            successEvent: 'device:resumed',
+            errorEvent: 'device:error',
+            defaultErrorMessage: 'Failed to resume session'
+        });
+    }
+
+    // Swing stick training measures swings without a ball; they are not
+    // sent to the simulator
+    async setSwingStick(enabled) {
+        return this.#submitAction({
+            url: '/api/v1/device/swing-stick',
+            body: { enabled },
+            successEvent: 'device:swingStick',
+            errorEvent: 'device:error',
+            defaultErrorMessage: 'Failed to change swing stick mode'
+        });
+    }
+
+    // Pairs with the connected device so reconnects go straight to its
+    // address. Only some Bluetooth backends need this.
+    async pair() {
+        return this.#submitAction({
+            url: '/api/v1/device/pair',
+            successEvent: 'device:paired',
+            errorEvent: 'device:error',
+            defaultErrorMessage: 'Failed to pair with device'
+        });
+    }
+
+    async unpair() {
+        return this.#submitAction({
+            url: '/api/v1/device/unpair',
+            successEvent: 'device:unpaired',
+            errorEvent: 'device:error',
+            defaultErrorMessage: 'Failed to unpair device'
+        });
+    }
+
+    // Scans for a few seconds and returns the devices found with their
+    // addresses. The device has to be disconnected.
+    async scan() {
+        try {
+            const response = await this.api.post('/api/v1/device/scan');
+            if (!response.ok) {
+                throw new Error(`Failed to scan for devices: ${(await response.text()).trim() || response.statusText}`);
+            }
+            return await response.json();
+        } catch (error) {
+            this.eventBus.emit('device:error', error.message);
+            return [];
+        }
+    }
+
+    // Returns the devices seen by earlier scans and connections
+    async knownDevices() {
+        try {
+            const response = await this.api.get('/api/v1/devices');
+            return response.ok ? await response.json() : [];
+        } catch (error) {
+            console.error('Failed to load known devices:', error);
+            return [];
+        }
+    }
+
+    async setNickname(address, nickname) {
+        try {
+            const response = await this.api.post('/api/v1/devices/nickname', { address, nickname });
+            if (!response.ok) {
+                throw new Error(`Failed to save nickname: ${(await response.text()).trim() || response.statusText}`);
+            }
+            return { success: true };
+        } catch (error) {
+            this.eventBus.emit('device:error', error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
     updateStatus(status) {
         this.deviceStatus = status;
         this.eventBus.emit('device:status', status);
     }
 
+    // Merges the fields that changed since the last update
+    applyStatusDelta(delta) {
+        this.updateStatus({ ...(this.deviceStatus || {}), ...delta });
+    }
+
     getStatus() {
         return this.deviceStatus;
     }
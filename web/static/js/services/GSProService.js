@@ -16,7 +16,7 @@ export class GSProService {
         }
 
         return this.#submitAction({
-            url: '/api/gspro/connect',
+            url: '/api/v1/gspro/connect',
             body: { ip, port },
             successEvent: 'gspro:connecting',
             defaultErrorMessage: 'Failed to connect'
@@ -25,7 +25,7 @@ export class GSProService {
 
     async disconnect() {
         return this.#submitAction({
-            url: '/api/gspro/disconnect',
+            url: '/api/v1/gspro/disconnect',
             successEvent: 'gspro:disconnecting',
             defaultErrorMessage: 'Failed to disconnect'
         });
@@ -33,12 +33,79 @@ export class GSProService {
 
     async saveConfig(ip, port, autoConnect) {
         return this.#submitAction({
-            url: '/api/gspro/config',
+            url: '/api/v1/gspro/config',
             body: { ip, port, autoConnect },
             defaultErrorMessage: 'Failed to save config'
         });
     }
 
+    async resetShotNumber() {
+        return this.#submitAction({
+            url: '/api/v1/gspro/shot-number/reset',
+            defaultErrorMessage: 'Failed to reset shot counter'
+        });
+    }
+
+    async replayQueue() {
+        return this.#submitAction({
+            url: '/api/v1/gspro/queue/replay',
+            defaultErrorMessage: 'Failed to send queued shots'
+        });
+    }
+
+    async discardQueue() {
+        return this.#submitAction({
+            url: '/api/v1/gspro/queue/discard',
+            defaultErrorMessage: 'Failed to discard queued shots'
+        });
+    }
+
+    // loadLastShot fetches the last shot sent to GSPro with the token to
+    // resend it, or null when none has been sent
+    async loadLastShot() {
+        try {
+            const response = await this.api.get('/api/v1/gspro/resend-last');
+            if (response.status === 404) {
+                return null;
+            }
+            if (!response.ok) {
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            return await response.json();
+        } catch (error) {
+            this.eventBus.emit(this.#errorEvent, error.message);
+            return null;
+        }
+    }
+
+    async resendLastShot(token) {
+        try {
+            const response = await this.api.post('/api/v1/gspro/resend-last', { token });
+            if (!response.ok) {
+                throw new Error((await response.text()).trim() || response.statusText);
+            }
+            const result = await response.json();
+            this.eventBus.emit('gspro:resent', result.shotNumber);
+            return { success: true, shotNumber: result.shotNumber };
+        } catch (error) {
+            this.eventBus.emit(this.#errorEvent, error.message);
+            return { success: false, error: error.message };
+        }
+    }
+
+    async discover(port) {
+        try {
+            const response = await this.api.get(`/api/v1/gspro/discover?port=${encodeURIComponent(port)}`);
+            if (!response.ok) {
+                throw new Error(`Failed to find GSPro: ${response.statusText}`);
+            }
+            return await response.json();
+        } catch (error) {
+            this.eventBus.emit(this.#errorEvent, error.message);
+            return [];
+        }
+    }
+
     updateStatus(status) {
         this.status = status;
         this.eventBus.emit(this.#statusEvent, status);
@@ -49,6 +116,11 @@ export class GSProService {
             const response = await this.api.post(url, body);
 
             if (!response.ok) {
+                const fields = await this.api.fieldErrors(response);
+                if (fields) {
+                    this.eventBus.emit('settings:invalid', fields);
+                    throw new Error(this.api.describeFieldErrors(fields));
+                }
                 throw new Error(`${defaultErrorMessage}: ${response.statusText}`);
             }
 
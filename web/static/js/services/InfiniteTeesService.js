@@ -16,7 +16,7 @@ export class InfiniteTeesService {
         }
 
         return this.#submitAction({
-            url: '/api/infinitetees/connect',
+            url: '/api/v1/infinitetees/connect',
             body: { ip, port },
             successEvent: 'infinitetees:connecting',
             defaultErrorMessage: 'Failed to connect'
@@ -25,7 +25,7 @@ export class InfiniteTeesService {
 
     async disconnect() {
         return this.#submitAction({
-            url: '/api/infinitetees/disconnect',
+            url: '/api/v1/infinitetees/disconnect',
             successEvent: 'infinitetees:disconnecting',
             defaultErrorMessage: 'Failed to disconnect'
         });
@@ -33,7 +33,7 @@ export class InfiniteTeesService {
 
     async saveConfig(ip, port, autoConnect) {
         return this.#submitAction({
-            url: '/api/infinitetees/config',
+            url: '/api/v1/infinitetees/config',
             body: { ip, port, autoConnect },
             defaultErrorMessage: 'Failed to save config'
         });
@@ -49,6 +49,11 @@ export class InfiniteTeesService {
             const response = await this.api.post(url, body);
 
             if (!response.ok) {
+                const fields = await this.api.fieldErrors(response);
+                if (fields) {
+                    this.eventBus.emit('settings:invalid', fields);
+                    throw new Error(this.api.describeFieldErrors(fields));
+                }
                 throw new Error(`${defaultErrorMessage}: ${response.statusText}`);
             }
 
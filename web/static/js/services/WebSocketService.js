@@ -4,13 +4,22 @@ export class WebSocketService {
     #reconnectDelayMs = 3000;
     #maxReconnectDelayMs = 15000;
     #manuallyDisconnected = false;
+    // WebSocket attempts that closed without ever opening. Proxies that block
+    // the upgrade fail this way, so after a few the event stream is used.
+    #failedUpgrades = 0;
+    #maxFailedUpgrades = 2;
+    #wasOpened = false;
 
     constructor(eventBus) {
         this.eventBus = eventBus;
         this.ws = null;
+        this.events = null;
     }
 
     connect() {
+        if (this.events) {
+            return;
+        }
         if (this.ws && [WebSocket.OPEN, WebSocket.CONNECTING].includes(this.ws.readyState)) {
             return;
         }
@@ -37,6 +46,10 @@ export class WebSocketService {
         this.#manuallyDisconnected = true;
         this.#clearReconnectTimer();
 
+        if (this.events) {
+            this.events.close();
+            this.events = null;
+        }
         if (!this.ws) return;
 
         this.ws.removeEventListener('open', this.#handleOpen);
@@ -49,6 +62,8 @@ export class WebSocketService {
 
     #handleOpen = () => {
         console.log('WebSocket connected');
+        this.#wasOpened = true;
+        this.#failedUpgrades = 0;
         this.#reconnectDelayMs = 3000;
         this.eventBus.emit('ws:connected');
         this.#clearReconnectTimer();
@@ -67,11 +82,33 @@ export class WebSocketService {
         this.eventBus.emit('ws:disconnected');
         this.ws = null;
 
-        if (!this.#manuallyDisconnected) {
-            this.#scheduleReconnect();
+        if (this.#manuallyDisconnected) return;
+
+        // A WebSocket that has worked before is only reconnected, as the
+        // server is most likely restarting
+        if (!this.#wasOpened && ++this.#failedUpgrades >= this.#maxFailedUpgrades) {
+            this.#connectEventStream();
+            return;
         }
+        this.#scheduleReconnect();
     };
 
+    // Receives the same messages as Server-Sent Events, which pass through
+    // proxies that block WebSocket upgrades. EventSource reconnects by itself.
+    #connectEventStream() {
+        console.log('WebSocket unavailable, using the event stream');
+        this.events = new EventSource('/api/v1/events');
+        this.events.addEventListener('open', () => {
+            console.log('Event stream connected');
+            this.eventBus.emit('ws:connected');
+        });
+        this.events.addEventListener('message', this.#handleMessage);
+        this.events.addEventListener('error', () => {
+            console.log('Event stream disconnected');
+            this.eventBus.emit('ws:disconnected');
+        });
+    }
+
     #handleError = (error) => {
         console.error('WebSocket error:', error);
         this.eventBus.emit('ws:error', error);
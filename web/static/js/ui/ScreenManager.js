@@ -4,6 +4,8 @@ export class ScreenManager {
         device: 'Device',
         gspro: 'GSPro',
         infiniteTees: 'Infinite Tees',
+        awesomeGolf: 'Awesome Golf',
+        games: 'Games',
         settings: 'Settings'
     };
 